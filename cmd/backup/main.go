@@ -0,0 +1,95 @@
+// Command backup backs up the database with pg_dump to the backup storage,
+// lists the backups and restores one of them:
+//
+//	go run ./cmd/backup create
+//	go run ./cmd/backup list
+//	go run ./cmd/backup restore <backup id>
+//
+// It records its jobs like the admin API does, so backups made here can be
+// restored from the API and the other way round.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/thitiphongD/my-backend/internal/adapters/database"
+	"github.com/thitiphongD/my-backend/internal/adapters/database/repositories"
+	"github.com/thitiphongD/my-backend/internal/adapters/storage"
+	"github.com/thitiphongD/my-backend/internal/config"
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/internal/core/services"
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: backup create | list | restore <backup id>")
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	cfg := config.LoadConfig()
+	database.ConnectDatabase()
+	db := database.Primary(database.GetDB())
+
+	if err := database.Migrate(db); err != nil {
+		log.Fatal("Failed to migrate database: ", err)
+	}
+
+	backupStorage, err := storage.NewBackupStorage(cfg)
+	if err != nil {
+		log.Fatal("Invalid backup storage configuration: ", err)
+	}
+	backupService := services.NewBackupService(repositories.NewBackupJobRepository(db), database.NewPGDumper(cfg, db), backupStorage,
+		services.NewAuditService(repositories.NewAuditLogRepository(db)))
+	ctx := context.Background()
+
+	var job *domain.BackupJob
+	switch flag.Arg(0) {
+	case "create":
+		job, err = backupService.Backup(ctx, nil)
+	case "restore":
+		id, parseErr := strconv.ParseUint(flag.Arg(1), 10, 32)
+		if parseErr != nil {
+			log.Fatal("restore needs the ID of a backup, see backup list")
+		}
+		job, err = backupService.Restore(ctx, uint(id), nil)
+	case "list":
+		listBackups(ctx, backupService)
+		return
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		log.Fatal("Failed to run job: ", err)
+	}
+	if job.Status != domain.BackupStatusSucceeded {
+		log.Fatalf("%s job %d failed: %s", job.Kind, job.ID, job.Error)
+	}
+
+	log.Printf("💾 %s job %d succeeded", job.Kind, job.ID)
+	log.Printf("   key:  %s", job.StorageKey)
+	log.Printf("   size: %d bytes", job.SizeBytes)
+}
+
+// listBackups prints the latest backups, newest first
+func listBackups(ctx context.Context, backupService ports.BackupService) {
+	result, err := backupService.ListJobs(ctx, domain.BackupJobBackup, domain.NewPaginationRequest(1, 100))
+	if err != nil {
+		log.Fatal("Failed to list backups: ", err)
+	}
+
+	fmt.Printf("%-6s %-10s %-20s %12s  %s\n", "ID", "STATUS", "CREATED", "SIZE", "KEY")
+	for _, job := range result.Data {
+		fmt.Printf("%-6d %-10s %-20s %12d  %s\n", job.ID, job.Status, job.CreatedAt.Format("2006-01-02 15:04:05"), job.SizeBytes, job.StorageKey)
+	}
+}
@@ -0,0 +1,45 @@
+// Command config checks the configuration the server would start with:
+//
+//	go run ./cmd/config check
+//
+// It loads the settings from the environment and .env like the server does
+// and lists every missing or invalid one, exiting with status 1 if there are
+// any, so a deploy can be stopped before the server refuses to start.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/thitiphongD/my-backend/internal/config"
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: config check")
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	switch flag.Arg(0) {
+	case "check":
+		err := config.LoadConfig().Validate()
+		var invalid *config.ValidationError
+		if errors.As(err, &invalid) {
+			fmt.Printf("%d configuration problem(s):\n", len(invalid.Problems))
+			for _, problem := range invalid.Problems {
+				fmt.Printf("  %s: %s\n", problem.Key, problem.Message)
+			}
+			os.Exit(1)
+		}
+		fmt.Println("Configuration is valid")
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+}
@@ -0,0 +1,68 @@
+// Command migrate shows or applies the schema changes of the running version:
+//
+//	go run ./cmd/migrate plan
+//	go run ./cmd/migrate up
+//
+// plan prints the statements the migration would run against the configured
+// database, without applying them, for review before a deploy. up applies
+// them, as the server does when it starts.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/thitiphongD/my-backend/internal/adapters/database"
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: migrate plan | up")
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	database.ConnectDatabase()
+	db := database.Primary(database.GetDB())
+
+	switch flag.Arg(0) {
+	case "plan":
+		plan, err := database.PlanMigration(context.Background(), db)
+		if err != nil {
+			log.Fatal("Failed to plan migration: ", err)
+		}
+		printPlan(plan)
+	case "up":
+		if err := database.Migrate(db); err != nil {
+			log.Fatal("Failed to migrate database: ", err)
+		}
+		log.Printf("✅ Schema migrated to version %d", database.SchemaVersion)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+}
+
+// printPlan prints the plan as a SQL script
+func printPlan(plan *database.MigrationPlan) {
+	fmt.Printf("-- Schema version %d -> %d\n", plan.FromVersion, plan.ToVersion)
+	if len(plan.Changes) == 0 {
+		fmt.Println("-- No schema changes")
+	}
+	for _, statement := range plan.Changes {
+		fmt.Println(statement + ";")
+	}
+	if len(plan.Idempotent) > 0 {
+		fmt.Println()
+		fmt.Println("-- Run by every migration, no-ops once applied:")
+		for _, statement := range plan.Idempotent {
+			fmt.Println(statement + ";")
+		}
+	}
+}
@@ -0,0 +1,38 @@
+// Command reencrypt rewrites the encrypted columns under the active key
+// (ENCRYPTION_KEY_ID), so a retired key can be dropped from ENCRYPTION_KEYS.
+// Rows written before a column was encrypted are encrypted too. It is safe to
+// run repeatedly and while the server is running:
+//
+//	go run ./cmd/reencrypt
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+
+	"github.com/thitiphongD/my-backend/internal/adapters/database"
+	"github.com/thitiphongD/my-backend/internal/config"
+)
+
+func main() {
+	batchSize := flag.Int("batch-size", int(config.LoadConfig().BulkInsertBatchSize), "rows read per batch (BULK_INSERT_BATCH_SIZE)")
+	flag.Parse()
+
+	database.ConnectDatabase()
+	db := database.Primary(database.GetDB())
+
+	if err := database.Migrate(db); err != nil {
+		log.Fatal("Failed to migrate database: ", err)
+	}
+
+	ctx := context.Background()
+	log.Printf("🔑 Re-encrypting with key %q", database.Encryption().ActiveKeyID())
+	for _, column := range database.EncryptedColumns {
+		rewritten, err := database.Reencrypt(ctx, db, column, *batchSize)
+		if err != nil {
+			log.Fatal("Failed to re-encrypt: ", err)
+		}
+		log.Printf("   %s.%s: %d rewritten", column.Table, column.Column, rewritten)
+	}
+}
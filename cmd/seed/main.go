@@ -0,0 +1,46 @@
+// Command seed loads an environment's fixture set (admin user, genres, sample
+// mangas) into the database. Existing records are left untouched, so it is
+// safe to run repeatedly:
+//
+//	go run ./cmd/seed -env development
+package main
+
+import (
+	"cmp"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/thitiphongD/my-backend/internal/adapters/database"
+	"github.com/thitiphongD/my-backend/internal/adapters/database/seed"
+	"github.com/thitiphongD/my-backend/internal/config"
+)
+
+func main() {
+	env := flag.String("env", cmp.Or(os.Getenv("APP_ENV"), "development"), "fixture set to load: <dir>/<env>.yaml, .yml or .json")
+	dir := flag.String("dir", "fixtures", "directory holding the fixture sets")
+	batchSize := flag.Int("batch-size", int(config.LoadConfig().BulkInsertBatchSize), "records inserted per batch (BULK_INSERT_BATCH_SIZE)")
+	flag.Parse()
+
+	fixtures, err := seed.LoadFixtures(*dir, *env)
+	if err != nil {
+		log.Fatal("Failed to load fixtures: ", err)
+	}
+
+	database.ConnectDatabase()
+	db := database.GetDB()
+
+	if err := database.Migrate(db); err != nil {
+		log.Fatal("Failed to migrate database: ", err)
+	}
+
+	report, err := seed.Run(db, fixtures, *batchSize)
+	if err != nil {
+		log.Fatal("Failed to seed database: ", err)
+	}
+
+	log.Printf("🌱 Seeded %s fixtures", *env)
+	log.Printf("   users:  %d created, %d existing", report.Users.Created, report.Users.Existing)
+	log.Printf("   genres: %d created, %d existing", report.Genres.Created, report.Genres.Existing)
+	log.Printf("   mangas: %d created, %d existing", report.Mangas.Created, report.Mangas.Existing)
+}
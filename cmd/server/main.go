@@ -1,78 +1,586 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"log"
+	"net"
+	"os"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/recover"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/redis/go-redis/v9"
+	"github.com/thitiphongD/my-backend/internal/adapters/alert"
+	"github.com/thitiphongD/my-backend/internal/adapters/books"
+	"github.com/thitiphongD/my-backend/internal/adapters/broker"
+	"github.com/thitiphongD/my-backend/internal/adapters/cache"
 	"github.com/thitiphongD/my-backend/internal/adapters/database"
 	"github.com/thitiphongD/my-backend/internal/adapters/database/repositories"
+	"github.com/thitiphongD/my-backend/internal/adapters/email"
+	grpcserver "github.com/thitiphongD/my-backend/internal/adapters/grpc/server"
+	"github.com/thitiphongD/my-backend/internal/adapters/http/handlers"
+	"github.com/thitiphongD/my-backend/internal/adapters/http/middleware"
 	"github.com/thitiphongD/my-backend/internal/adapters/http/routes"
+	"github.com/thitiphongD/my-backend/internal/adapters/imaging"
+	"github.com/thitiphongD/my-backend/internal/adapters/line"
+	"github.com/thitiphongD/my-backend/internal/adapters/ratelimit"
+	"github.com/thitiphongD/my-backend/internal/adapters/scanner"
+	"github.com/thitiphongD/my-backend/internal/adapters/search"
+	"github.com/thitiphongD/my-backend/internal/adapters/sentry"
+	"github.com/thitiphongD/my-backend/internal/adapters/sms"
+	"github.com/thitiphongD/my-backend/internal/adapters/storage"
+	"github.com/thitiphongD/my-backend/internal/adapters/webhook"
 	"github.com/thitiphongD/my-backend/internal/config"
 	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
 	"github.com/thitiphongD/my-backend/internal/core/services"
 )
 
 func main() {
-	// Load configuration
+	workerMode := flag.Bool("worker", false, "run background jobs only, without the HTTP and gRPC servers")
+	flag.Parse()
+
+	// Load configuration, refusing to start with any setting missing or invalid
 	cfg := config.LoadConfig()
+	if err := cfg.Validate(); err != nil {
+		log.Fatal(err)
+	}
 
 	// Initialize database connection
 	database.ConnectDatabase()
 	db := database.GetDB()
 
+	if cfg.DBPoolStatsLogSeconds > 0 {
+		database.LogPoolStats(time.Duration(cfg.DBPoolStatsLogSeconds) * time.Second)
+	}
+
 	// Auto migrate the schema
-	if err := db.AutoMigrate(&domain.User{}, &domain.Manga{}); err != nil {
+	if err := database.Migrate(db); err != nil {
 		log.Fatal("Failed to migrate database: ", err)
 	}
 
-	// Initialize repositories
-	userRepo := repositories.NewUserRepository(db)
+	// Bound every statement from here on; migrations may take longer
+	if err := db.Use(repositories.QueryTimeouts{
+		Read:   time.Duration(cfg.DBReadTimeoutSeconds) * time.Second,
+		Write:  time.Duration(cfg.DBWriteTimeoutSeconds) * time.Second,
+		Report: time.Duration(cfg.DBReportTimeoutSeconds) * time.Second,
+	}); err != nil {
+		log.Fatal("Failed to set up query timeouts: ", err)
+	}
+	if err := db.Use(repositories.QueryMetrics{
+		SlowThreshold: time.Duration(cfg.DBSlowQueryMs) * time.Millisecond,
+		LogAll:        cfg.DBLogQueries,
+	}); err != nil {
+		log.Fatal("Failed to set up query metrics: ", err)
+	}
+
+	// Initialize repositories. Reads go to the read replicas, if any, except in
+	// repositories whose callers read their own writes right away (sign-up then
+	// login, quota counters, checkout then order lookup), which stay on the primary.
+	primary := database.Primary(db)
+	userRepo := repositories.NewUserRepository(primary)
 	mangaRepo := repositories.NewMangaRepository(db)
+	teamRepo := repositories.NewTeamRepository(db)
+	quotaRepo := repositories.NewQuotaRepository(primary)
+	webhookRepo := repositories.NewWebhookRepository(db)
+	apiKeyRepo := repositories.NewAPIKeyRepository(db)
+	dashboardRepo := repositories.NewDashboardRepository(db)
+	genreRepo := repositories.NewGenreRepository(db)
+	chapterRepo := repositories.NewChapterRepository(db)
+	reviewRepo := repositories.NewReviewRepository(db)
+	progressRepo := repositories.NewReadingProgressRepository(db)
+	priceHistoryRepo := repositories.NewPriceHistoryRepository(db)
+	discountRepo := repositories.NewDiscountRepository(db)
+	versionRepo := repositories.NewMangaVersionRepository(db)
+	viewRepo := repositories.NewViewRepository(db)
+	commentRepo := repositories.NewCommentRepository(db)
+	relationRepo := repositories.NewMangaRelationRepository(db)
+	seriesRepo := repositories.NewSeriesRepository(db)
+	wishlistRepo := repositories.NewWishlistRepository(db)
+	cartRepo := repositories.NewCartRepository(db)
+	orderRepo := repositories.NewOrderRepository(primary)
+	rentalRepo := repositories.NewRentalRepository(primary)
+	taxRepo := repositories.NewTaxRateRepository(db)
+	imageRepo := repositories.NewMangaImageRepository(db)
+	translationRepo := repositories.NewMangaTranslationRepository(db)
+	backupJobRepo := repositories.NewBackupJobRepository(primary)
+	outboxRepo := repositories.NewOutboxRepository(primary)
+	jobRepo := repositories.NewJobRepository(primary)
+	notificationPrefsRepo := repositories.NewNotificationPreferenceRepository(primary)
+	digestRepo := repositories.NewDigestRepository(primary)
+	uploadRepo := repositories.NewUploadRepository(primary)
+	auditRepo := repositories.NewAuditLogRepository(primary)
+	phoneRepo := repositories.NewUserPhoneRepository(primary)
+	lineRepo := repositories.NewLineAccountRepository(primary)
+	txManager := repositories.NewTransactionManager(db)
+
+	// Operational alerts go to Slack and Discord, throttled so an outage
+	// doesn't flood the channels
+	var alertSenders []ports.AlertSender
+	if cfg.AlertSlackWebhookURL != "" {
+		slack, err := alert.NewSlackSender(cfg.AlertSlackWebhookURL, 10*time.Second)
+		if err != nil {
+			log.Fatal("Invalid alert configuration: ", err)
+		}
+		alertSenders = append(alertSenders, slack)
+	}
+	if cfg.AlertDiscordWebhookURL != "" {
+		discord, err := alert.NewDiscordSender(cfg.AlertDiscordWebhookURL, 10*time.Second)
+		if err != nil {
+			log.Fatal("Invalid alert configuration: ", err)
+		}
+		alertSenders = append(alertSenders, discord)
+	}
+	alertService := services.NewAlertService(alertSenders, services.AlertLimits{
+		DedupWindow:          time.Duration(cfg.AlertDedupSeconds) * time.Second,
+		MaxPerMinute:         int(cfg.AlertMaxPerMinute),
+		ServerErrorThreshold: int(cfg.AlertServerErrorThreshold),
+		ServerErrorWindow:    time.Duration(cfg.AlertServerErrorWindowSeconds) * time.Second,
+	})
+	if cfg.AlertDBCheckSeconds > 0 {
+		alertService.StartDatabaseMonitor(database.NewDatabaseStats(), time.Duration(cfg.AlertDBCheckSeconds)*time.Second)
+	}
+
+	// Panics and server errors are reported to Sentry when a DSN is set
+	errorReporter := services.NewNoopErrorReporter()
+	if cfg.SentryDSN != "" {
+		sentryReporter, err := sentry.NewReporter(sentry.Config{
+			DSN:         cfg.SentryDSN,
+			Environment: cfg.AppEnv,
+			Release:     cfg.SentryRelease,
+		}, 10*time.Second)
+		if err != nil {
+			log.Fatal("Invalid Sentry configuration: ", err)
+		}
+		errorReporter = sentryReporter
+	}
+
+	// Privileged and mutating actions are recorded in the append-only audit
+	// log by the services making them
+	auditService := services.NewAuditService(auditRepo)
 
-	// Initialize services with dependency injection
-	authService := services.NewAuthService(userRepo)
-	userService := services.NewUserService(userRepo)
-	mangaService := services.NewMangaService(mangaRepo)
+	// Slow work is queued as background jobs; services register the handlers
+	// of their jobs with the job service
+	jobService := services.NewJobService(jobRepo, alertService, errorReporter, auditService,
+		time.Duration(cfg.JobTimeoutSeconds)*time.Second, time.Duration(cfg.JobRetentionDays)*24*time.Hour)
+
+	// Outgoing email is queued so requests never wait on the mail provider
+	emailSender, err := email.NewSender(cfg)
+	if err != nil {
+		log.Fatal("Invalid email configuration: ", err)
+	}
+	emailSender = services.NewJobEmailSender(jobService, emailSender)
+
+	// Emails are rendered from the built-in templates unless a template
+	// directory is configured; development reloads them for every email
+	emailTemplates := email.DefaultTemplates()
+	if cfg.EmailTemplateDir != "" {
+		emailTemplates = os.DirFS(cfg.EmailTemplateDir)
+	}
+	emailRenderer, err := email.NewTemplateRenderer(emailTemplates, cfg.IsDevelopment())
+	if err != nil {
+		log.Fatal("Invalid email templates: ", err)
+	}
+	var emailPreview ports.EmailRenderer
+	if cfg.IsDevelopment() {
+		emailPreview = emailRenderer
+	}
+
+	// Redis is only needed when rate limiting or response caching is shared between instances
+	var redisClient *redis.Client
+	if cfg.RateLimitStore == "redis" || cfg.ResponseCacheStore == "redis" {
+		redisOptions, err := redis.ParseURL(cfg.RedisURL)
+		if err != nil {
+			log.Fatal("Invalid REDIS_URL: ", err)
+		}
+		redisClient = redis.NewClient(redisOptions)
+	}
+
+	// Rate limit counters, for requests and for texts
+	var rateLimitStore ports.RateLimitStore = ratelimit.NewMemoryStore()
+	if cfg.RateLimitStore == "redis" {
+		rateLimitStore = ratelimit.NewRedisStore(redisClient)
+	}
+
+	// Uploaded files go to local disk or an S3-compatible bucket
+	var fileStorage ports.FileStorage = storage.NewLocalStorage(cfg.UploadDir, cfg.UploadBaseURL)
+	if cfg.StorageDriver == "s3" {
+		s3Storage, err := storage.NewS3Storage(storage.S3Config{
+			Endpoint:        cfg.S3Endpoint,
+			Region:          cfg.S3Region,
+			Bucket:          cfg.S3Bucket,
+			AccessKeyID:     cfg.S3AccessKeyID,
+			SecretAccessKey: cfg.S3SecretAccessKey,
+			PublicBaseURL:   cfg.S3PublicBaseURL,
+		}, 5*time.Minute)
+		if err != nil {
+			log.Fatal("Invalid S3 storage configuration: ", err)
+		}
+		fileStorage = s3Storage
+	}
+
+	// Backups are kept apart from uploads, which are public
+	backupStorage, err := storage.NewBackupStorage(cfg)
+	if err != nil {
+		log.Fatal("Invalid backup storage configuration: ", err)
+	}
+
+	var responseCache ports.ResponseCache = cache.NewMemoryCache()
+	if cfg.ResponseCacheStore == "redis" {
+		responseCache = cache.NewRedisCache(redisClient)
+	}
+
+	// Initialize services with dependency injection. Services publish domain
+	// events on the bus; the features reacting to them subscribe as they are
+	// created below.
+	eventBus := services.NewEventBus(txManager)
+	notificationService := services.NewNotificationService(notificationPrefsRepo, userRepo, eventBus, jobService, emailRenderer, emailSender, cfg.AppBaseURL)
+	authService := services.NewAuthService(userRepo, eventBus, auditService)
+	userService := services.NewUserService(userRepo, auditService, emailRenderer, emailSender, cfg.AppBaseURL)
+	webhookService := services.NewWebhookService(webhookRepo, webhook.NewHTTPSender(10*time.Second), jobService, alertService, auditService)
+	apiKeyService := services.NewAPIKeyService(apiKeyRepo, userRepo, auditService, cfg.APIKeyRateLimit)
+	requestStats := services.NewRequestStats()
+	dashboardService := services.NewDashboardService(dashboardRepo, requestStats, time.Duration(cfg.DashboardCacheSeconds)*time.Second)
+	eventStream := services.NewEventStream()
+	// Manga search runs on the database, or on a Meilisearch index
+	var searchBackend ports.SearchService = search.NewPostgresSearch(mangaRepo)
+	if cfg.SearchDriver == "meilisearch" {
+		meilisearch, err := search.NewMeilisearch(search.MeilisearchConfig{
+			URL:    cfg.MeilisearchURL,
+			APIKey: cfg.MeilisearchAPIKey,
+			Index:  cfg.MeilisearchIndex,
+		}, mangaRepo, 10*time.Second)
+		if err != nil {
+			log.Fatal("Invalid Meilisearch configuration: ", err)
+		}
+		searchBackend = meilisearch
+	}
+	searchService := services.NewMangaSearchService(searchBackend, mangaRepo, discountRepo)
+
+	// Manga and order events are recorded in the outbox with the change they
+	// describe, then relayed to the user's webhooks and to their open event
+	// streams, and to the search index when it is kept apart from the database
+	dispatchers := []ports.WebhookDispatcher{webhookService, eventStream}
+	if cfg.SearchDriver == "meilisearch" {
+		dispatchers = append(dispatchers, searchService)
+	}
+	dispatcher := services.NewMultiDispatcher(dispatchers...)
+	outboxService := services.NewOutboxService(outboxRepo, dispatcher, eventBus,
+		int(cfg.OutboxBatchSize), time.Duration(cfg.OutboxRetentionDays)*24*time.Hour)
+	if cfg.OutboxRelayIntervalMs > 0 {
+		outboxService.StartRelay(time.Duration(cfg.OutboxRelayIntervalMs) * time.Millisecond)
+	}
+	// Domain events are published to Kafka or NATS for other services, from
+	// background jobs queued with each change
+	var eventPublisher ports.EventPublisher
+	publishTimeout := time.Duration(cfg.EventPublishTimeoutSeconds) * time.Second
+	switch cfg.EventPublisherDriver {
+	case "nats":
+		eventPublisher, err = broker.NewNATSPublisher(broker.NATSConfig{URL: cfg.NATSURL, JetStream: cfg.NATSJetStream}, publishTimeout)
+	case "kafka":
+		eventPublisher, err = broker.NewKafkaRESTPublisher(cfg.KafkaRESTURL, publishTimeout)
+	}
+	if err != nil {
+		log.Fatal("Invalid event publisher configuration: ", err)
+	}
+	if eventPublisher != nil {
+		services.SubscribeEventPublisher(eventBus, jobService, eventPublisher,
+			services.EventTopics{Prefix: cfg.EventTopicPrefix, Overrides: cfg.EventTopics}, cfg.AppBaseURL)
+	}
+	mangaService := services.NewMangaService(mangaRepo, teamRepo, genreRepo, priceHistoryRepo, discountRepo, versionRepo, eventBus, responseCache, txManager, auditService, int(cfg.BulkInsertBatchSize))
+	presenceService := services.NewPresenceService(userRepo)
+	teamService := services.NewTeamService(teamRepo, userRepo, auditService)
+	genreService := services.NewGenreService(genreRepo, auditService)
+	chapterService := services.NewChapterService(chapterRepo, mangaRepo, teamRepo, auditService)
+	reviewService := services.NewReviewService(reviewRepo, mangaRepo)
+	progressService := services.NewReadingProgressService(progressRepo, mangaRepo, chapterRepo)
+	discountService := services.NewDiscountService(discountRepo, mangaRepo, genreRepo, auditService)
+	viewService := services.NewViewService(viewRepo, discountRepo)
+	viewService.StartFlusher(30 * time.Second)
+	commentService := services.NewCommentService(commentRepo, mangaRepo, eventBus, auditService)
+	relationService := services.NewMangaRelationService(relationRepo, mangaRepo, teamRepo, auditService)
+	seriesService := services.NewSeriesService(seriesRepo, mangaRepo, teamRepo, auditService)
+	wishlistService := services.NewWishlistService(wishlistRepo, mangaRepo, eventBus, outboxService)
+	cartService := services.NewCartService(cartRepo, mangaRepo, discountRepo)
+	orderService := services.NewOrderService(orderRepo, cartRepo, mangaRepo, discountRepo, taxRepo, eventBus, txManager, auditService, 15*time.Minute)
+	services.SubscribeOrderEmails(eventBus, userRepo, emailRenderer, emailSender)
+	// Verification codes and order alerts are texted through a queue as well
+	smsSender, err := sms.NewSender(cfg)
+	if err != nil {
+		log.Fatal("Invalid SMS configuration: ", err)
+	}
+	smsService := services.NewSMSService(phoneRepo, services.NewJobSMSSender(jobService, smsSender), rateLimitStore, auditService, services.SMSLimits{
+		CodesPerHour:   cfg.SMSCodesPerHour,
+		PerNumberDaily: cfg.SMSPerNumberDaily,
+	})
+	// Notifications are pushed on LINE to linked users, while a channel is configured
+	var lineMessenger ports.LineMessenger
+	if cfg.LineChannelAccessToken != "" {
+		lineMessenger, err = line.NewMessagingClient(cfg.LineChannelAccessToken, cfg.LineChannelSecret, 10*time.Second)
+		if err != nil {
+			log.Fatal("Invalid LINE configuration: ", err)
+		}
+	}
+	lineService := services.NewLineService(lineRepo, userRepo, jobService, lineMessenger, cfg.LineLinkURL)
+	orderService.StartReservationSweeper(time.Minute)
+	rentalService := services.NewRentalService(rentalRepo, mangaRepo, auditService)
+	taxService := services.NewTaxRateService(taxRepo, auditService)
+	// Uploads are scanned for malware unless scanning is off; flagged files are
+	// quarantined with the backups, which are never served
+	var malwareScanner ports.MalwareScanner
+	if cfg.MalwareScanDriver == "clamav" {
+		malwareScanner = scanner.NewClamAVScanner(cfg.ClamAVAddress, time.Duration(cfg.ClamAVTimeoutSeconds)*time.Second)
+	}
+	malwareScanService := services.NewMalwareScanService(malwareScanner, fileStorage, backupStorage, userRepo, emailRenderer, emailSender)
+	imageService := services.NewMangaImageService(imageRepo, mangaRepo, teamRepo, fileStorage, malwareScanService, jobService, imaging.NewImageEncoders(cfg), auditService)
+	// Clients upload straight to storage that can presign URLs (S3); presigned
+	// uploads are off with local storage
+	presignedStorage, _ := fileStorage.(ports.PresignedStorage)
+	uploadService := services.NewUploadService(uploadRepo, mangaRepo, teamRepo, imageService, malwareScanService, presignedStorage, jobService, txManager,
+		time.Duration(cfg.UploadPresignExpirySeconds)*time.Second)
+	translationService := services.NewMangaTranslationService(translationRepo, mangaRepo, teamRepo, auditService)
+	rentalService.StartSweeper(time.Minute)
+	bookService := services.NewBookService(books.NewOpenLibraryClient(10*time.Second), 24*time.Hour)
+	quotaService := services.NewQuotaService(quotaRepo, userRepo, map[string]domain.QuotaLimits{
+		domain.RoleUser:       {Daily: cfg.QuotaUserDaily, Monthly: cfg.QuotaUserMonthly},
+		domain.RoleAdmin:      {Daily: cfg.QuotaAdminDaily, Monthly: cfg.QuotaAdminMonthly},
+		domain.RoleSuperAdmin: {Daily: cfg.QuotaAdminDaily, Monthly: cfg.QuotaAdminMonthly},
+	}, auditService)
+
+	purgeService := services.NewPurgeService(mangaRepo, userRepo, uploadRepo, fileStorage,
+		time.Duration(cfg.PurgeRetentionDays)*24*time.Hour, int(cfg.PurgeBatchSize), auditService)
+	if cfg.PurgeIntervalMinutes > 0 {
+		purgeService.StartScheduler(time.Duration(cfg.PurgeIntervalMinutes)*time.Minute, cfg.PurgeDryRun)
+	}
+
+	archiveService := services.NewArchiveService(orderRepo, viewRepo, int(cfg.ArchiveAfterMonths), int(cfg.ArchiveBatchSize), auditService)
+	if cfg.ArchiveIntervalMinutes > 0 {
+		archiveService.StartScheduler(time.Duration(cfg.ArchiveIntervalMinutes) * time.Minute)
+	}
+
+	sellerReportService := services.NewSellerReportService(notificationPrefsRepo, userRepo, orderRepo, viewRepo, jobService, txManager, emailRenderer, emailSender, cfg.AppBaseURL)
+	if cfg.SellerReportIntervalMinutes > 0 {
+		sellerReportService.StartScheduler(time.Duration(cfg.SellerReportIntervalMinutes) * time.Minute)
+	}
+	digestService := services.NewDigestService(digestRepo, notificationPrefsRepo, userRepo, jobService, txManager, emailRenderer, emailSender, cfg.AppBaseURL)
+	if cfg.DigestIntervalMinutes > 0 {
+		digestService.StartScheduler(time.Duration(cfg.DigestIntervalMinutes) * time.Minute)
+	}
+	// Admins can run the scheduled tasks at once, whether or not their
+	// scheduler is on
+	jobService.HandleScheduledTask(domain.ScheduledTaskPurge, func(ctx context.Context) error {
+		_, err := purgeService.Run(ctx, cfg.PurgeDryRun)
+		return err
+	})
+	jobService.HandleScheduledTask(domain.ScheduledTaskArchive, func(ctx context.Context) error {
+		_, err := archiveService.Run(ctx)
+		return err
+	})
+	jobService.HandleScheduledTask(domain.ScheduledTaskSellerReports, func(ctx context.Context) error {
+		_, err := sellerReportService.QueueDueReports(ctx)
+		return err
+	})
+	jobService.HandleScheduledTask(domain.ScheduledTaskDigests, func(ctx context.Context) error {
+		_, err := digestService.QueueDueDigests(ctx)
+		return err
+	})
+	// Order updates, moderation and price drops go out on the channels each
+	// user routes them to
+	services.NewNotificationDispatcher(notificationPrefsRepo, wishlistRepo, mangaRepo, eventBus, jobService, map[string]ports.NotificationChannel{
+		domain.ChannelEmail: services.NewEmailChannel(userRepo, emailRenderer, emailSender),
+		domain.ChannelSMS:   smsService,
+		domain.ChannelLine:  lineService,
+		domain.ChannelInApp: digestService,
+	})
+
+	backupService := services.NewBackupService(backupJobRepo, database.NewPGDumper(cfg, primary), backupStorage, auditService)
+	if err := backupService.FailInterrupted(context.Background()); err != nil {
+		log.Printf("Failed to clean up interrupted backup jobs: %v", err)
+	}
+
+	// Exports are kept with the backups, which are never served publicly
+	handlers.RegisterExportJobs(jobService, mangaService, userService, orderService, backupStorage)
+
+	// A -worker process runs background jobs instead of serving requests; the
+	// schedulers above run in every process
+	jobPollInterval := time.Duration(cfg.JobPollIntervalMs) * time.Millisecond
+	if *workerMode {
+		workers := max(int(cfg.JobWorkers), 1)
+		jobService.StartWorkers(workers, jobPollInterval)
+		log.Printf("⚙️ Job worker started with %d workers", workers)
+		select {}
+	}
+	if cfg.JobWorkers > 0 {
+		jobService.StartWorkers(int(cfg.JobWorkers), jobPollInterval)
+	}
 
 	// Initialize Fiber app
 	app := fiber.New(fiber.Config{
+		// Bodies over BodyLimit are streamed rather than buffered or rejected by the
+		// server; BodyLimitMiddleware and UploadLimitMiddleware enforce the limits
+		// and multipart files are spooled to disk as they are parsed
+		BodyLimit:                    int(cfg.BodyLimit),
+		StreamRequestBody:            true,
+		DisablePreParseMultipartForm: true,
 		ErrorHandler: func(c *fiber.Ctx, err error) error {
 			code := fiber.StatusInternalServerError
 			if e, ok := err.(*fiber.Error); ok {
 				code = e.Code
 			}
 			return c.Status(code).JSON(fiber.Map{
-				"success": false,
-				"error":   err.Error(),
+				"success":    false,
+				"error":      err.Error(),
+				"request_id": c.Locals("requestID"),
 			})
 		},
 	})
 
 	// Global middlewares
+	app.Use(middleware.RequestIDMiddleware())
+	// Outside recover, so panics count among the 5xx responses
+	app.Use(middleware.ServerErrorAlertMiddleware(alertService))
+	app.Use(middleware.RequestStatsMiddleware(requestStats))
 	app.Use(recover.New())
 	app.Use(logger.New(logger.Config{
-		Format: "[${time}] ${ip}:${port} ${status} - ${method} ${path} - ${latency}\n",
+		Format: "[${time}] [${locals:requestID}] ${ip}:${port} ${status} - ${method} ${path} - ${latency}\n",
+	}))
+	// Inside the logger, which answers returned errors, and inside recover,
+	// which answers the panics it raises again
+	app.Use(middleware.ErrorReportingMiddleware(errorReporter))
+	app.Use(middleware.RequestTimeoutMiddleware(time.Duration(cfg.RequestTimeoutSeconds) * time.Second))
+
+	app.Use(middleware.CompressionMiddleware(cfg.CompressionLevel, int(cfg.CompressionMinSize)))
+
+	app.Use(middleware.BodyLimitMiddleware(cfg.BodyLimit))
+	app.Use(middleware.UploadLimitMiddleware(cfg.UploadLimit))
+
+	// Browser sessions may send their token in the auth cookie instead of
+	// the Authorization header
+	app.Use(middleware.CookieTokenMiddleware(cfg.AuthMode))
+	// API keys are authenticated up front, so quotas count their requests
+	// against the key's user
+	app.Use(middleware.APIKeyMiddleware(apiKeyService, rateLimitStore, cfg.APIKeyRateLimit))
+	app.Use(middleware.PresenceMiddleware(presenceService))
+	app.Use(middleware.QuotaMiddleware(quotaService))
+
+	// Rate limiting, with a stricter bucket for login and registration,
+	app.Use("/api/v1/auth", middleware.RateLimitMiddleware(rateLimitStore, middleware.RateLimitConfig{
+		Bucket:        "auth",
+		Anonymous:     middleware.RateLimit{Requests: cfg.RateLimitAuth, Window: time.Minute},
+		Authenticated: middleware.RateLimit{Requests: cfg.RateLimitAuth, Window: time.Minute},
 	}))
+	app.Use("/api", middleware.RateLimitMiddleware(rateLimitStore, middleware.RateLimitConfig{
+		Bucket:        "api",
+		Anonymous:     middleware.RateLimit{Requests: cfg.RateLimitAnonymous, Window: time.Minute},
+		Authenticated: middleware.RateLimit{Requests: cfg.RateLimitAuthenticated, Window: time.Minute},
+	}))
+	// and the limits configured for groups of routes
+	routeLimits, err := domain.ParseRouteLimits(cfg.RouteLimits)
+	if err != nil {
+		log.Fatal("Invalid ROUTE_LIMITS: ", err)
+	}
+	app.Use(middleware.RouteLimitMiddleware(rateLimitStore, routeLimits))
 
 	// CORS middleware
 	app.Use(cors.New(cors.Config{
 		AllowOrigins:     "http://localhost:3000,http://localhost:5173,http://127.0.0.1:3000,http://127.0.0.1:5173",
 		AllowMethods:     "GET,POST,HEAD,PUT,DELETE,PATCH,OPTIONS",
-		AllowHeaders:     "Origin, Content-Type, Accept, Authorization, X-Requested-With",
+		AllowHeaders:     "Origin, Content-Type, Accept, Authorization, X-Requested-With, If-Match, If-None-Match, If-Modified-Since, X-CSRF-Token",
+		ExposeHeaders:    "ETag, Last-Modified, Deprecation, Sunset, Link, X-Cache",
 		AllowCredentials: true,
 	}))
 
+	// Inside CORS, so browsers can read its rejections
+	app.Use(middleware.CSRFMiddleware())
+
+	// Serve uploaded files kept on local disk
+	if cfg.StorageDriver != "s3" {
+		app.Static(cfg.UploadBaseURL, cfg.UploadDir)
+	}
+
+	// Prometheus metrics (query durations and row counts); keep the endpoint
+	// reachable by the scraper only
+	if cfg.MetricsEnabled {
+		app.Get("/metrics", adaptor.HTTPHandler(promhttp.Handler()))
+	}
+
 	// Setup routes
-	routes.SetupRoutes(app, authService, userService, mangaService)
+	routes.SetupRoutes(app, &routes.Services{
+		Auth:        authService,
+		User:        userService,
+		Manga:       mangaService,
+		Presence:    presenceService,
+		Team:        teamService,
+		Quota:       quotaService,
+		Webhook:     webhookService,
+		APIKey:      apiKeyService,
+		Dashboard:   dashboardService,
+		Genre:       genreService,
+		Chapter:     chapterService,
+		Review:      reviewService,
+		Progress:    progressService,
+		Discount:    discountService,
+		View:        viewService,
+		Comment:     commentService,
+		Book:        bookService,
+		Relation:    relationService,
+		Series:      seriesService,
+		Wishlist:    wishlistService,
+		Cart:        cartService,
+		Order:       orderService,
+		Rental:      rentalService,
+		Tax:         taxService,
+		Image:       imageService,
+		Translation: translationService,
+		Events:      eventStream,
+		Database:    database.NewDatabaseStats(),
+		Purge:       purgeService,
+		Archive:     archiveService,
+		Backup:      backupService,
+		Search:      searchService,
+		Jobs:        jobService,
+		Audit:       auditService,
+		Exports:     backupStorage,
+
+		Notification: notificationService,
+		Digest:       digestService,
+		SMS:          smsService,
+		Line:         lineService,
+		Upload:       uploadService,
+		EmailPreview: emailPreview,
+	}, responseCache, time.Duration(cfg.ResponseCacheTTLSeconds)*time.Second, handlers.CookieConfig{
+		Secure:   cfg.CookieSecure,
+		SameSite: cfg.CookieSameSite,
+		AuthMode: cfg.AuthMode,
+	}, routeLimits)
+
+	// Start the gRPC server for internal services on its own port
+	grpcListener, err := net.Listen("tcp", ":"+cfg.GRPCPort)
+	if err != nil {
+		log.Fatal("Failed to listen for gRPC: ", err)
+	}
+	grpcServer := grpcserver.NewServer(&grpcserver.Services{
+		Auth:  authService,
+		User:  userService,
+		Manga: mangaService,
+	})
+	go func() {
+		log.Printf("🔌 gRPC server starting on port %s", cfg.GRPCPort)
+		if err := grpcServer.Serve(grpcListener); err != nil {
+			log.Fatal("Failed to start gRPC server: ", err)
+		}
+	}()
 
 	// Start server
 	port := ":" + cfg.Port
 	log.Printf("🚀 Server starting on port %s", cfg.Port)
 	log.Printf("📚 API Documentation available at http://localhost%s", port)
-	log.Printf("🏥 Health check at http://localhost%s/", port)
+	log.Printf("🏥 Health check at http://localhost%s/health", port)
 
 	if err := app.Listen(port); err != nil {
 		log.Fatal("Failed to start server: ", err)
@@ -0,0 +1,105 @@
+package alert
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// Limits of Discord embeds
+const (
+	discordTitleLimit       = 256
+	discordDescriptionLimit = 4096
+	discordFieldNameLimit   = 256
+	discordFieldValueLimit  = 1024
+	discordFieldCountLimit  = 25
+)
+
+// discordSender implements the AlertSender interface on a Discord channel webhook
+type discordSender struct {
+	webhookURL string
+	client     *http.Client
+}
+
+// NewDiscordSender creates an alert sender posting to the Discord webhook URL
+func NewDiscordSender(webhookURL string, timeout time.Duration) (ports.AlertSender, error) {
+	endpoint, err := parseWebhookURL(webhookURL, "Discord")
+	if err != nil {
+		return nil, err
+	}
+	return &discordSender{
+		webhookURL: endpoint,
+		client:     &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// discordField is a detail of a Discord embed
+type discordField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
+}
+
+// discordFooter is the footer line of a Discord embed
+type discordFooter struct {
+	Text string `json:"text"`
+}
+
+// discordEmbed shows an alert with a colored bar of its severity
+type discordEmbed struct {
+	Title       string         `json:"title"`
+	Description string         `json:"description,omitempty"`
+	Color       int            `json:"color"`
+	Fields      []discordField `json:"fields,omitempty"`
+	Footer      discordFooter  `json:"footer"`
+	Timestamp   string         `json:"timestamp"`
+}
+
+// discordAllowedMentions lists the kinds of mentions Discord resolves
+type discordAllowedMentions struct {
+	Parse []string `json:"parse"`
+}
+
+// discordMessage is the body of a Discord webhook request. Mentions in alerts
+// are never resolved, so an alert can't ping the channel.
+type discordMessage struct {
+	Username        string                 `json:"username"`
+	Embeds          []discordEmbed         `json:"embeds"`
+	AllowedMentions discordAllowedMentions `json:"allowed_mentions"`
+}
+
+// SendAlert posts the alert as a message with one embed
+func (s *discordSender) SendAlert(ctx context.Context, alert *domain.Alert) error {
+	var fields []discordField
+	for _, field := range alert.Fields {
+		if len(fields) == discordFieldCountLimit {
+			break
+		}
+		value := field.Value
+		if value == "" {
+			// Discord rejects empty field values
+			value = "-"
+		}
+		fields = append(fields, discordField{
+			Name:   truncate(field.Name, discordFieldNameLimit),
+			Value:  truncate(value, discordFieldValueLimit),
+			Inline: len(value) <= 40,
+		})
+	}
+
+	return postJSON(ctx, s.client, "Discord", s.webhookURL, &discordMessage{
+		Username: "my-backend",
+		Embeds: []discordEmbed{{
+			Title:       truncate("["+alert.Severity+"] "+alert.Title, discordTitleLimit),
+			Description: truncate(alertText(alert), discordDescriptionLimit),
+			Color:       severityColor(alert.Severity),
+			Fields:      fields,
+			Footer:      discordFooter{Text: alert.Key},
+			Timestamp:   alert.Time.UTC().Format(time.RFC3339),
+		}},
+		AllowedMentions: discordAllowedMentions{Parse: []string{}},
+	})
+}
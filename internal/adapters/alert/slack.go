@@ -0,0 +1,75 @@
+package alert
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// slackSender implements the AlertSender interface on a Slack incoming webhook
+type slackSender struct {
+	webhookURL string
+	client     *http.Client
+}
+
+// NewSlackSender creates an alert sender posting to the Slack incoming webhook URL
+func NewSlackSender(webhookURL string, timeout time.Duration) (ports.AlertSender, error) {
+	endpoint, err := parseWebhookURL(webhookURL, "Slack")
+	if err != nil {
+		return nil, err
+	}
+	return &slackSender{
+		webhookURL: endpoint,
+		client:     &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// slackField is a detail of a Slack attachment
+type slackField struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+	Short bool   `json:"short"`
+}
+
+// slackAttachment shows an alert with a colored bar of its severity
+type slackAttachment struct {
+	Fallback string       `json:"fallback"`
+	Color    string       `json:"color"`
+	Title    string       `json:"title"`
+	Text     string       `json:"text,omitempty"`
+	Fields   []slackField `json:"fields,omitempty"`
+	Footer   string       `json:"footer"`
+	TS       int64        `json:"ts"`
+}
+
+// slackMessage is the body of a Slack incoming webhook request
+type slackMessage struct {
+	Text        string            `json:"text"`
+	Attachments []slackAttachment `json:"attachments"`
+}
+
+// SendAlert posts the alert as a message with one attachment
+func (s *slackSender) SendAlert(ctx context.Context, alert *domain.Alert) error {
+	fields := make([]slackField, 0, len(alert.Fields))
+	for _, field := range alert.Fields {
+		fields = append(fields, slackField{Title: field.Name, Value: field.Value, Short: len(field.Value) <= 40})
+	}
+
+	summary := fmt.Sprintf("[%s] %s", alert.Severity, alert.Title)
+	return postJSON(ctx, s.client, "Slack", s.webhookURL, &slackMessage{
+		Text: summary,
+		Attachments: []slackAttachment{{
+			Fallback: summary,
+			Color:    fmt.Sprintf("#%06X", severityColor(alert.Severity)),
+			Title:    alert.Title,
+			Text:     alertText(alert),
+			Fields:   fields,
+			Footer:   "my-backend · " + alert.Key,
+			TS:       alert.Time.Unix(),
+		}},
+	})
+}
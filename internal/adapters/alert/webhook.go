@@ -0,0 +1,92 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// severityColors are the RGB colors alerts of each severity are shown in
+var severityColors = map[string]int{
+	domain.AlertCritical: 0xD62728,
+	domain.AlertWarning:  0xF2A900,
+	domain.AlertResolved: 0x2CA02C,
+}
+
+// severityColor returns the color of the alert's severity, grey if unknown
+func severityColor(severity string) int {
+	if color, ok := severityColors[severity]; ok {
+		return color
+	}
+	return 0x7F7F7F
+}
+
+// alertText is the message of an alert, noting how many repeats of it were
+// suppressed since the last one was sent
+func alertText(alert *domain.Alert) string {
+	if alert.Suppressed == 0 {
+		return alert.Message
+	}
+	note := strconv.Itoa(alert.Suppressed) + " similar alerts were suppressed."
+	if alert.Suppressed == 1 {
+		note = "1 similar alert was suppressed."
+	}
+	if alert.Message == "" {
+		return note
+	}
+	return alert.Message + "\n" + note
+}
+
+// truncate cuts s to at most n runes, the limit of a chat field
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-1]) + "…"
+}
+
+// parseWebhookURL checks that a chat webhook URL is an absolute HTTP(S) URL
+func parseWebhookURL(raw, provider string) (string, error) {
+	endpoint, err := url.Parse(raw)
+	if err != nil || (endpoint.Scheme != "https" && endpoint.Scheme != "http") || endpoint.Host == "" {
+		return "", errors.New("invalid " + provider + " webhook URL")
+	}
+	return endpoint.String(), nil
+}
+
+// postJSON posts the payload to a chat webhook, failing on a non-2xx response
+func postJSON(ctx context.Context, client *http.Client, provider, webhookURL string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return errors.New("failed to encode alert")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "my-backend-alerts/1.0")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s webhook unreachable: %w", provider, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+		io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return fmt.Errorf("%s webhook responded with status %d: %s", provider, resp.StatusCode, bytes.TrimSpace(detail))
+}
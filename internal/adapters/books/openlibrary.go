@@ -0,0 +1,100 @@
+package books
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// openLibraryBaseURL is the Open Library books API endpoint
+const openLibraryBaseURL = "https://openlibrary.org/api/books"
+
+// openLibraryClient implements the BookLookup interface against Open Library
+type openLibraryClient struct {
+	client *http.Client
+}
+
+// NewOpenLibraryClient creates a new Open Library book lookup
+func NewOpenLibraryClient(timeout time.Duration) ports.BookLookup {
+	return &openLibraryClient{
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// openLibraryBook is the subset of the Open Library "data" response we use
+type openLibraryBook struct {
+	Title   string `json:"title"`
+	Authors []struct {
+		Name string `json:"name"`
+	} `json:"authors"`
+	Publishers []struct {
+		Name string `json:"name"`
+	} `json:"publishers"`
+	PublishDate string `json:"publish_date"`
+	Cover       struct {
+		Large  string `json:"large"`
+		Medium string `json:"medium"`
+	} `json:"cover"`
+}
+
+// LookupISBN fetches book metadata for a normalized ISBN
+func (c *openLibraryClient) LookupISBN(ctx context.Context, isbn string) (*domain.BookMetadata, error) {
+	key := "ISBN:" + isbn
+	query := url.Values{
+		"bibkeys": {key},
+		"format":  {"json"},
+		"jscmd":   {"data"},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, openLibraryBaseURL+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "my-backend/1.0")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("book catalogue responded with status %d", resp.StatusCode)
+	}
+
+	var results map[string]openLibraryBook
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return nil, fmt.Errorf("invalid book catalogue response: %w", err)
+	}
+
+	found, ok := results[key]
+	if !ok {
+		return nil, domain.ErrBookNotFound
+	}
+
+	book := &domain.BookMetadata{
+		ISBN:          isbn,
+		Title:         found.Title,
+		Authors:       make([]string, 0, len(found.Authors)),
+		PublishedDate: found.PublishDate,
+		CoverURL:      found.Cover.Large,
+	}
+	for _, author := range found.Authors {
+		book.Authors = append(book.Authors, author.Name)
+	}
+	if len(found.Publishers) > 0 {
+		book.Publisher = found.Publishers[0].Name
+	}
+	if book.CoverURL == "" {
+		book.CoverURL = found.Cover.Medium
+	}
+
+	return book, nil
+}
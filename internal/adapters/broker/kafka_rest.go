@@ -0,0 +1,108 @@
+package broker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// kafkaRESTContentType is the v2 REST Proxy format for records with JSON keys and values
+const kafkaRESTContentType = "application/vnd.kafka.json.v2+json"
+
+// kafkaRESTPublisher implements the EventPublisher interface through the
+// Kafka REST Proxy v2 API, which Confluent REST Proxy and Redpanda's HTTP
+// proxy both serve, so no Kafka client is needed. Events are keyed by their
+// subject.
+type kafkaRESTPublisher struct {
+	endpoint *url.URL
+	client   *http.Client
+}
+
+// NewKafkaRESTPublisher creates a publisher producing to Kafka through the
+// REST proxy at baseURL. Credentials in the URL are sent with basic auth.
+func NewKafkaRESTPublisher(baseURL string, timeout time.Duration) (ports.EventPublisher, error) {
+	endpoint, err := url.Parse(strings.TrimSuffix(baseURL, "/"))
+	if err != nil || endpoint.Host == "" || (endpoint.Scheme != "http" && endpoint.Scheme != "https") {
+		return nil, errors.New("invalid Kafka REST proxy URL")
+	}
+	return &kafkaRESTPublisher{
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// kafkaRecord is one record of a produce request
+type kafkaRecord struct {
+	Key   interface{}        `json:"key"`
+	Value *domain.CloudEvent `json:"value"`
+}
+
+// kafkaProduceResponse reports the outcome of each record of a produce request
+type kafkaProduceResponse struct {
+	Offsets []struct {
+		Partition *int    `json:"partition"`
+		Offset    *int64  `json:"offset"`
+		ErrorCode *int    `json:"error_code"`
+		Error     *string `json:"error"`
+	} `json:"offsets"`
+}
+
+// Publish produces the event as one record to the topic
+func (p *kafkaRESTPublisher) Publish(ctx context.Context, topic string, event *domain.CloudEvent) error {
+	record := kafkaRecord{Value: event}
+	if event.Subject != "" {
+		record.Key = event.Subject
+	}
+	body, err := json.Marshal(map[string]interface{}{"records": []kafkaRecord{record}})
+	if err != nil {
+		return errors.New("failed to encode event")
+	}
+
+	endpoint := *p.endpoint
+	endpoint.User = nil
+	endpoint.Path += "/topics/" + url.PathEscape(topic)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", kafkaRESTContentType)
+	req.Header.Set("Accept", "application/vnd.kafka.v2+json, application/json")
+	if user := p.endpoint.User; user != nil {
+		password, _ := user.Password()
+		req.SetBasicAuth(user.Username(), password)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("Kafka REST proxy unreachable: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Kafka REST proxy returned %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	var produced kafkaProduceResponse
+	if err := json.Unmarshal(respBody, &produced); err != nil || len(produced.Offsets) != 1 {
+		return errors.New("invalid Kafka REST proxy response")
+	}
+	if offset := produced.Offsets[0]; offset.Error != nil || offset.ErrorCode != nil {
+		message := "unknown error"
+		if offset.Error != nil {
+			message = *offset.Error
+		}
+		return fmt.Errorf("Kafka rejected the event: %s", message)
+	}
+	return nil
+}
@@ -0,0 +1,332 @@
+package broker
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/internal/utils"
+)
+
+// natsDefaultPort is the client port of NATS servers
+const natsDefaultPort = "4222"
+
+// NATSConfig configures the NATS server events are published to
+type NATSConfig struct {
+	URL string // nats://host:4222, with user:password@ or token@ for auth
+	// JetStream waits for the stream capturing a subject to store each event,
+	// instead of only for the server to receive it
+	JetStream bool
+}
+
+// natsRejection is an error the server or JetStream answered a publish with;
+// unlike a broken connection, publishing again on a new one won't help
+type natsRejection struct {
+	message string
+}
+
+// Error implements the error interface
+func (e *natsRejection) Error() string {
+	return e.message
+}
+
+// natsPublisher implements the EventPublisher interface with the NATS client
+// protocol over one connection. Events carry their ID in the Nats-Msg-Id
+// header, so JetStream drops events published twice.
+type natsPublisher struct {
+	cfg      NATSConfig
+	address  string
+	user     string
+	password string
+	token    string
+	timeout  time.Duration
+
+	mu     sync.Mutex
+	conn   net.Conn
+	reader *bufio.Reader
+	inbox  string // subject prefix of JetStream acks
+	sent   uint64
+}
+
+// NewNATSPublisher creates a publisher on the NATS server at cfg.URL. It
+// connects on first use; a publish is given up after timeout.
+func NewNATSPublisher(cfg NATSConfig, timeout time.Duration) (ports.EventPublisher, error) {
+	endpoint, err := url.Parse(cfg.URL)
+	if err != nil || endpoint.Scheme != "nats" || endpoint.Hostname() == "" {
+		return nil, errors.New("invalid NATS URL")
+	}
+
+	p := &natsPublisher{
+		cfg:     cfg,
+		address: endpoint.Host,
+		timeout: timeout,
+	}
+	if endpoint.Port() == "" {
+		p.address = net.JoinHostPort(endpoint.Hostname(), natsDefaultPort)
+	}
+	if user := endpoint.User; user != nil {
+		if password, ok := user.Password(); ok {
+			p.user, p.password = user.Username(), password
+		} else {
+			p.token = user.Username()
+		}
+	}
+	return p, nil
+}
+
+// Publish sends the event to the subject. A connection the server dropped
+// while idle is replaced and the event sent again; events the server rejected
+// are not.
+func (p *natsPublisher) Publish(ctx context.Context, subject string, event *domain.CloudEvent) error {
+	if subject == "" || strings.ContainsAny(subject, " \t\r\n") {
+		return errors.New("invalid NATS subject")
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return errors.New("failed to encode event")
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	reused := p.conn != nil
+	err = p.publish(ctx, subject, event.ID, payload)
+	var rejection *natsRejection
+	if err != nil && reused && !errors.As(err, &rejection) {
+		p.close()
+		err = p.publish(ctx, subject, event.ID, payload)
+	}
+	if err != nil {
+		p.close()
+	}
+	return err
+}
+
+// publish sends one message and waits for the server's PONG, or for the
+// JetStream ack
+func (p *natsPublisher) publish(ctx context.Context, subject, id string, payload []byte) error {
+	if p.conn == nil {
+		if err := p.connect(ctx); err != nil {
+			return err
+		}
+	}
+	if err := p.conn.SetDeadline(p.deadline(ctx)); err != nil {
+		return err
+	}
+
+	header := "NATS/1.0\r\nNats-Msg-Id: " + id + "\r\nContent-Type: " + domain.CloudEventsContentType + "\r\n\r\n"
+	var reply string
+	if p.cfg.JetStream {
+		p.sent++
+		reply = p.inbox + "." + strconv.FormatUint(p.sent, 10)
+	}
+
+	var msg bytes.Buffer
+	msg.WriteString("HPUB " + subject)
+	if reply != "" {
+		msg.WriteString(" " + reply)
+	}
+	fmt.Fprintf(&msg, " %d %d\r\n%s", len(header), len(header)+len(payload), header)
+	msg.Write(payload)
+	msg.WriteString("\r\n")
+	if reply == "" {
+		// The PONG comes after the server has processed the message
+		msg.WriteString("PING\r\n")
+	}
+	if _, err := p.conn.Write(msg.Bytes()); err != nil {
+		return fmt.Errorf("NATS publish failed: %w", err)
+	}
+
+	for {
+		op, args, header, body, err := p.readFrame()
+		if err != nil {
+			return fmt.Errorf("NATS publish failed: %w", err)
+		}
+		switch op {
+		case "PING":
+			if _, err := p.conn.Write([]byte("PONG\r\n")); err != nil {
+				return fmt.Errorf("NATS publish failed: %w", err)
+			}
+		case "PONG":
+			if reply == "" {
+				return nil
+			}
+		case "-ERR":
+			return &natsRejection{"NATS server error: " + args}
+		case "MSG", "HMSG":
+			// Acks of earlier publishes that timed out are skipped
+			if fields := strings.Fields(args); len(fields) > 0 && fields[0] == reply {
+				return jetStreamAck(subject, header, body)
+			}
+		}
+	}
+}
+
+// connect dials the server, checks it supports headers, and authenticates
+func (p *natsPublisher) connect(ctx context.Context) error {
+	dialer := net.Dialer{Timeout: p.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", p.address)
+	if err != nil {
+		return fmt.Errorf("NATS server unreachable: %w", err)
+	}
+	p.conn, p.reader = conn, bufio.NewReader(conn)
+	if err := conn.SetDeadline(p.deadline(ctx)); err != nil {
+		return err
+	}
+
+	op, args, _, _, err := p.readFrame()
+	if err != nil || op != "INFO" {
+		return errors.New("invalid NATS server greeting")
+	}
+	var info struct {
+		Headers     bool `json:"headers"`
+		TLSRequired bool `json:"tls_required"`
+	}
+	if err := json.Unmarshal([]byte(args), &info); err != nil {
+		return errors.New("invalid NATS server greeting")
+	}
+	if info.TLSRequired {
+		return errors.New("NATS server requires TLS, which is not supported")
+	}
+	if !info.Headers {
+		return errors.New("NATS server does not support headers")
+	}
+
+	options := map[string]interface{}{
+		"verbose":       false,
+		"pedantic":      false,
+		"lang":          "go",
+		"version":       "1.0",
+		"name":          "my-backend",
+		"protocol":      1,
+		"headers":       true,
+		"no_responders": true,
+	}
+	if p.user != "" {
+		options["user"], options["pass"] = p.user, p.password
+	}
+	if p.token != "" {
+		options["auth_token"] = p.token
+	}
+	connect, err := json.Marshal(options)
+	if err != nil {
+		return err
+	}
+
+	var msg bytes.Buffer
+	msg.WriteString("CONNECT " + string(connect) + "\r\n")
+	if p.cfg.JetStream {
+		token, err := utils.GenerateRandomToken(12)
+		if err != nil {
+			return err
+		}
+		p.inbox = "_INBOX." + token
+		msg.WriteString("SUB " + p.inbox + ".* 1\r\n")
+	}
+	msg.WriteString("PING\r\n")
+	if _, err := conn.Write(msg.Bytes()); err != nil {
+		return fmt.Errorf("NATS connect failed: %w", err)
+	}
+
+	for {
+		op, args, _, _, err := p.readFrame()
+		if err != nil {
+			return fmt.Errorf("NATS connect failed: %w", err)
+		}
+		switch op {
+		case "PONG":
+			return nil
+		case "-ERR":
+			return fmt.Errorf("NATS server refused the connection: %s", args)
+		}
+	}
+}
+
+// readFrame reads one protocol message from the server. Messages delivered
+// to a subscription come with their header block and body.
+func (p *natsPublisher) readFrame() (op, args string, header, body []byte, err error) {
+	line, err := p.reader.ReadString('\n')
+	if err != nil {
+		return "", "", nil, nil, err
+	}
+	op, args, _ = strings.Cut(strings.TrimRight(line, "\r\n"), " ")
+	op = strings.ToUpper(op)
+	if op != "MSG" && op != "HMSG" {
+		return op, args, nil, nil, nil
+	}
+
+	// MSG <subject> <sid> [reply] <size>; HMSG <subject> <sid> [reply] <header size> <size>
+	fields := strings.Fields(args)
+	if len(fields) < 3 || (op == "HMSG" && len(fields) < 4) {
+		return "", "", nil, nil, errors.New("invalid NATS message")
+	}
+	size, err := strconv.Atoi(fields[len(fields)-1])
+	if err != nil || size < 0 {
+		return "", "", nil, nil, errors.New("invalid NATS message")
+	}
+	headerSize := 0
+	if op == "HMSG" {
+		if headerSize, err = strconv.Atoi(fields[len(fields)-2]); err != nil || headerSize > size {
+			return "", "", nil, nil, errors.New("invalid NATS message")
+		}
+	}
+
+	payload := make([]byte, size+2) // with the trailing CRLF
+	if _, err := io.ReadFull(p.reader, payload); err != nil {
+		return "", "", nil, nil, err
+	}
+	return op, args, payload[:headerSize], payload[headerSize:size], nil
+}
+
+// jetStreamAck reads the reply of JetStream to a publish. A status header
+// without a body means no stream captures the subject.
+func jetStreamAck(subject string, header, body []byte) error {
+	if len(body) == 0 && bytes.HasPrefix(header, []byte("NATS/1.0 503")) {
+		return &natsRejection{"no JetStream stream captures " + subject}
+	}
+	var ack struct {
+		Stream string `json:"stream"`
+		Error  *struct {
+			Description string `json:"description"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &ack); err != nil {
+		return &natsRejection{"invalid JetStream ack"}
+	}
+	if ack.Error != nil {
+		return &natsRejection{"JetStream rejected the event: " + ack.Error.Description}
+	}
+	if ack.Stream == "" {
+		return &natsRejection{"invalid JetStream ack"}
+	}
+	return nil
+}
+
+// deadline is when the current exchange with the server is given up
+func (p *natsPublisher) deadline(ctx context.Context) time.Time {
+	deadline := time.Now().Add(p.timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		return ctxDeadline
+	}
+	return deadline
+}
+
+// close drops the connection, so the next publish connects again
+func (p *natsPublisher) close() {
+	if p.conn != nil {
+		p.conn.Close()
+		p.conn, p.reader = nil, nil
+	}
+}
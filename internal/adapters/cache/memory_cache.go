@@ -0,0 +1,89 @@
+package cache
+
+import (
+	"sync"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// memorySweepInterval is how often expired entries are dropped from memory
+const memorySweepInterval = time.Minute
+
+// memoryEntry is one cached value
+type memoryEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// memoryCache implements the ResponseCache interface for a single instance
+type memoryCache struct {
+	mu          sync.Mutex
+	entries     map[string]*memoryEntry
+	generations map[string]int64
+	lastSweep   time.Time
+}
+
+// NewMemoryCache creates a response cache that keeps entries in process memory
+func NewMemoryCache() ports.ResponseCache {
+	return &memoryCache{
+		entries:     make(map[string]*memoryEntry),
+		generations: make(map[string]int64),
+		lastSweep:   time.Now(),
+	}
+}
+
+// Get returns the entry stored under key, if it has not expired
+func (c *memoryCache) Get(key string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || !time.Now().Before(entry.expiresAt) {
+		return nil, false, nil
+	}
+	return entry.value, true, nil
+}
+
+// Set stores an entry under key for ttl
+func (c *memoryCache) Set(key string, value []byte, ttl time.Duration) error {
+	now := time.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if now.Sub(c.lastSweep) >= memorySweepInterval {
+		for k, entry := range c.entries {
+			if !now.Before(entry.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+		c.lastSweep = now
+	}
+
+	c.entries[key] = &memoryEntry{value: value, expiresAt: now.Add(ttl)}
+	return nil
+}
+
+// Generations returns the current generation of each tag
+func (c *memoryCache) Generations(tags ...string) ([]int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	generations := make([]int64, len(tags))
+	for i, tag := range tags {
+		generations[i] = c.generations[tag]
+	}
+	return generations, nil
+}
+
+// Invalidate advances the generation of each tag
+func (c *memoryCache) Invalidate(tags ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, tag := range tags {
+		c.generations[tag]++
+	}
+	return nil
+}
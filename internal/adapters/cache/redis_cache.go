@@ -0,0 +1,111 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+const (
+	// redisTimeout bounds each cache call so a slow Redis does not stall requests
+	redisTimeout = 500 * time.Millisecond
+
+	redisEntryPrefix      = "response-cache:entry:"
+	redisGenerationPrefix = "response-cache:generation:"
+)
+
+// redisCache implements the ResponseCache interface on Redis, shared by every instance
+type redisCache struct {
+	client *redis.Client
+}
+
+// NewRedisCache creates a response cache that keeps entries in Redis
+func NewRedisCache(client *redis.Client) ports.ResponseCache {
+	return &redisCache{
+		client: client,
+	}
+}
+
+// Get returns the entry stored under key, if any
+func (c *redisCache) Get(key string) ([]byte, bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	value, err := c.client.Get(ctx, redisEntryPrefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, errors.New("failed to read cached response")
+	}
+	return value, true, nil
+}
+
+// Set stores an entry under key for ttl
+func (c *redisCache) Set(key string, value []byte, ttl time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	if err := c.client.Set(ctx, redisEntryPrefix+key, value, ttl).Err(); err != nil {
+		return errors.New("failed to cache response")
+	}
+	return nil
+}
+
+// Generations returns the current generation of each tag
+func (c *redisCache) Generations(tags ...string) ([]int64, error) {
+	if len(tags) == 0 {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	keys := make([]string, len(tags))
+	for i, tag := range tags {
+		keys[i] = redisGenerationPrefix + tag
+	}
+
+	values, err := c.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, errors.New("failed to read cache generations")
+	}
+
+	generations := make([]int64, len(tags))
+	for i, value := range values {
+		// Tags that were never invalidated have no key and stay at generation 0
+		if s, ok := value.(string); ok {
+			generation, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				return nil, errors.New("failed to read cache generations")
+			}
+			generations[i] = generation
+		}
+	}
+	return generations, nil
+}
+
+// Invalidate advances the generation of each tag
+func (c *redisCache) Invalidate(tags ...string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	_, err := c.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, tag := range tags {
+			pipe.Incr(ctx, redisGenerationPrefix+tag)
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.New("failed to invalidate cached responses")
+	}
+	return nil
+}
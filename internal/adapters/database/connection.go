@@ -1,44 +1,117 @@
 package database
 
 import (
+	"database/sql"
 	"fmt"
 	"log"
+	"strings"
+	"time"
 
 	"github.com/thitiphongD/my-backend/internal/config"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
+	"gorm.io/plugin/dbresolver"
 )
 
 var DB *gorm.DB
 
-// ConnectDatabase initializes database connection using config
+// ConnectDatabase initializes database connection using config, to Postgres or,
+// for local development and tests, SQLite. When read replicas are configured,
+// queries outside transactions are spread across them and everything else goes
+// to the primary.
 func ConnectDatabase() {
 	cfg := config.LoadConfig()
+	configureEncryption(cfg)
 
-	// สร้าง connection string จาก config parameters
-	connectionString := fmt.Sprintf(
-		"host=%s user=%s password=%s dbname=%s port=%s sslmode=%s TimeZone=Asia/Bangkok",
-		cfg.DBHost, cfg.DBUser, cfg.DBPass, cfg.DBName, cfg.DBPort, cfg.DBSSLMode,
-	)
-
-	// เพิ่ม channel_binding หากมีการกำหนดค่า
-	if cfg.DBChannelBinding != "" {
-		connectionString += fmt.Sprintf(" channel_binding=%s", cfg.DBChannelBinding)
+	var dialector gorm.Dialector
+	switch cfg.DBDriver {
+	case "postgres":
+		dialector = postgres.Open(connectionString(cfg, cfg.DBHost, cfg.DBPort))
+	case "sqlite":
+		dialector = sqliteDialector(cfg.DBSQLitePath)
+	default:
+		log.Fatalf("Unsupported DB_DRIVER %q (use postgres or sqlite)", cfg.DBDriver)
 	}
 
-	database, err := gorm.Open(postgres.Open(connectionString), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
+	database, err := gorm.Open(dialector, &gorm.Config{
+		Logger: newRequestLogger(logger.Default).LogMode(logger.Info),
+		// Postgres keeps microseconds, so timestamps set on save, such as the
+		// UpdatedAt of ETags, read back exactly as they were written
+		NowFunc: func() time.Time { return time.Now().Truncate(time.Microsecond) },
 	})
 
 	if err != nil {
 		log.Fatal("Failed to connect to database: ", err)
 	}
 
+	primary, err := database.DB()
+	if err != nil {
+		log.Fatal("Failed to connect to database: ", err)
+	}
+	if cfg.DBDriver == "sqlite" {
+		configureSQLitePool(primary)
+	} else {
+		configurePool(primary, cfg)
+	}
+	pools = []connectionPool{{name: "primary", db: primary}}
+
+	// Read replicas are a Postgres deployment concern
+	if cfg.DBDriver == "postgres" && len(cfg.DBReplicaHosts) > 0 {
+		replicas := make([]gorm.Dialector, 0, len(cfg.DBReplicaHosts))
+		for _, replica := range cfg.DBReplicaHosts {
+			host, port, found := strings.Cut(replica, ":")
+			if !found {
+				port = cfg.DBPort
+			}
+			replicas = append(replicas, postgres.Open(connectionString(cfg, host, port)))
+		}
+
+		resolver := dbresolver.Register(dbresolver.Config{
+			Replicas: replicas,
+			Policy:   dbresolver.RandomPolicy{},
+		})
+		if err := database.Use(resolver); err != nil {
+			log.Fatal("Failed to connect to read replicas: ", err)
+		}
+
+		// The resolver lists the primary first, then the replicas in order
+		resolver.Call(func(pool gorm.ConnPool) error {
+			if db, ok := pool.(*sql.DB); ok && db != primary {
+				configurePool(db, cfg)
+				pools = append(pools, connectionPool{name: "replica " + cfg.DBReplicaHosts[len(pools)-1], db: db})
+			}
+			return nil
+		})
+		fmt.Printf("Using %d read replica(s)\n", len(replicas))
+	}
+
 	fmt.Println("Database connected successfully!")
 	DB = database
 }
 
+// connectionString builds the DSN of the server at host:port
+func connectionString(cfg *config.Config, host, port string) string {
+	// สร้าง connection string จาก config parameters
+	dsn := fmt.Sprintf(
+		"host=%s user=%s password=%s dbname=%s port=%s sslmode=%s TimeZone=Asia/Bangkok",
+		host, cfg.DBUser, cfg.DBPass, cfg.DBName, port, cfg.DBSSLMode,
+	)
+
+	// เพิ่ม channel_binding หากมีการกำหนดค่า
+	if cfg.DBChannelBinding != "" {
+		dsn += fmt.Sprintf(" channel_binding=%s", cfg.DBChannelBinding)
+	}
+	return dsn
+}
+
+// Primary returns a handle whose queries always run on the primary. Pass it to
+// repositories whose callers read their own writes straight away, since
+// replicas may lag behind.
+func Primary(db *gorm.DB) *gorm.DB {
+	return db.Clauses(dbresolver.Write).Session(&gorm.Session{})
+}
+
 // GetDB returns the database instance
 func GetDB() *gorm.DB {
 	return DB
@@ -0,0 +1,264 @@
+package database
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/config"
+	"gorm.io/gorm/schema"
+)
+
+// encryptedPrefix marks a column value encrypted by the codec. The full form
+// is enc:<key id>:<base64 of nonce and ciphertext>.
+const encryptedPrefix = "enc:"
+
+// legacyTimeLayouts are the text forms of times written before a column was
+// encrypted: Postgres date::text and the way SQLite stores times
+var legacyTimeLayouts = []string{
+	"2006-01-02",
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05",
+}
+
+// encryption is the codec behind serializer:encrypted. GORM keeps the
+// serializer of a model once it has parsed it (and works on copies of it), so
+// configureEncryption swaps the keyring of this codec rather than registering
+// another one.
+var encryption = &EncryptionCodec{keyring: &keyring{}}
+
+func init() {
+	// Without keys values are stored in plaintext
+	schema.RegisterSerializer("encrypted", encryption)
+}
+
+// EncryptionCodec is the GORM serializer behind the serializer:encrypted tag.
+// Values are encrypted with AES-GCM under the active key and tagged with its
+// id, so rows written under retired keys stay readable until re-encrypted.
+// Values without the enc: prefix are read as plaintext, which covers rows
+// written before a column was encrypted.
+type EncryptionCodec struct {
+	*keyring
+}
+
+// keyring holds the AES-GCM ciphers by key id
+type keyring struct {
+	keys   map[string]cipher.AEAD
+	active string
+}
+
+// NewEncryptionCodec builds a codec from "<id>:<base64 AES key>" entries,
+// encrypting with the key named active, or the first key when active is empty
+func NewEncryptionCodec(keys []string, active string) (*EncryptionCodec, error) {
+	codec := &EncryptionCodec{keyring: &keyring{keys: make(map[string]cipher.AEAD, len(keys))}}
+	for _, entry := range keys {
+		id, encoded, found := strings.Cut(entry, ":")
+		if !found || id == "" {
+			return nil, errors.New("encryption keys must be given as <id>:<base64 key>")
+		}
+		if _, exists := codec.keys[id]; exists {
+			return nil, fmt.Errorf("duplicate encryption key %q", id)
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("encryption key %q is not valid base64", id)
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, fmt.Errorf("encryption key %q must be 16, 24 or 32 bytes", id)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+		codec.keys[id] = aead
+		if codec.active == "" {
+			codec.active = id
+		}
+	}
+
+	if active != "" {
+		if _, ok := codec.keys[active]; !ok {
+			return nil, fmt.Errorf("active encryption key %q is not configured", active)
+		}
+		codec.active = active
+	}
+	return codec, nil
+}
+
+// configureEncryption gives the codec the configured keys
+func configureEncryption(cfg *config.Config) {
+	codec, err := NewEncryptionCodec(cfg.EncryptionKeys, cfg.EncryptionKeyID)
+	if err != nil {
+		log.Fatal("Invalid encryption keys: ", err)
+	}
+	if !codec.Enabled() {
+		log.Println("WARNING: No ENCRYPTION_KEYS set, sensitive columns are stored in plaintext")
+	}
+	*encryption.keyring = *codec.keyring
+}
+
+// Encryption returns the codec behind serializer:encrypted
+func Encryption() *EncryptionCodec {
+	return encryption
+}
+
+// Enabled reports whether the codec has keys to encrypt with
+func (c *EncryptionCodec) Enabled() bool {
+	return c.active != ""
+}
+
+// ActiveKeyID returns the id of the key new values are encrypted with
+func (c *EncryptionCodec) ActiveKeyID() string {
+	return c.active
+}
+
+// Scan implements schema.SerializerInterface
+func (c *EncryptionCodec) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue interface{}) error {
+	fieldValue := reflect.New(field.FieldType)
+
+	var stored string
+	switch v := dbValue.(type) {
+	case nil:
+	case string:
+		stored = v
+	case []byte:
+		stored = string(v)
+	default:
+		return fmt.Errorf("unsupported encrypted value of type %T in %s", dbValue, field.DBName)
+	}
+
+	if dbValue != nil {
+		plain, err := c.decrypt(stored)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt %s: %w", field.DBName, err)
+		}
+		if err := decodePlain(plain, fieldValue); err != nil {
+			return fmt.Errorf("failed to decode %s: %w", field.DBName, err)
+		}
+	}
+
+	field.ReflectValueOf(ctx, dst).Set(fieldValue.Elem())
+	return nil
+}
+
+// Value implements schema.SerializerInterface
+func (c *EncryptionCodec) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue interface{}) (interface{}, error) {
+	value := reflect.ValueOf(fieldValue)
+	if fieldValue == nil || value.Kind() == reflect.Ptr && value.IsNil() {
+		return nil, nil
+	}
+
+	plain, err := encodePlain(reflect.Indirect(value))
+	if err != nil {
+		return nil, err
+	}
+	return c.encrypt(plain)
+}
+
+// Rotate re-encrypts a stored value under the active key. It reports false
+// for values that already are.
+func (c *EncryptionCodec) Rotate(stored string) (string, bool, error) {
+	if !c.Enabled() {
+		return "", false, errors.New("no encryption key configured")
+	}
+	if strings.HasPrefix(stored, encryptedPrefix+c.active+":") {
+		return stored, false, nil
+	}
+
+	plain, err := c.decrypt(stored)
+	if err != nil {
+		return "", false, err
+	}
+	rotated, err := c.encrypt(plain)
+	return rotated, err == nil, err
+}
+
+// encrypt seals plain under the active key, or returns it unchanged when
+// encryption is disabled
+func (c *EncryptionCodec) encrypt(plain []byte) (string, error) {
+	if !c.Enabled() {
+		return string(plain), nil
+	}
+
+	aead := c.keys[c.active]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", errors.New("failed to generate nonce")
+	}
+	sealed := aead.Seal(nonce, nonce, plain, nil)
+	return encryptedPrefix + c.active + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// decrypt opens a stored value with the key it names; values without the
+// prefix are plaintext
+func (c *EncryptionCodec) decrypt(stored string) ([]byte, error) {
+	rest, encrypted := strings.CutPrefix(stored, encryptedPrefix)
+	if !encrypted {
+		return []byte(stored), nil
+	}
+
+	id, encoded, found := strings.Cut(rest, ":")
+	if !found {
+		return nil, errors.New("malformed encrypted value")
+	}
+	aead, ok := c.keys[id]
+	if !ok {
+		return nil, fmt.Errorf("unknown encryption key %q", id)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return nil, errors.New("malformed encrypted value")
+	}
+	plain, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return nil, errors.New("encrypted value failed authentication")
+	}
+	return plain, nil
+}
+
+// encodePlain renders a field value as the plaintext to encrypt: strings as
+// they are, anything else as JSON
+func encodePlain(value reflect.Value) ([]byte, error) {
+	if value.Kind() == reflect.String {
+		return []byte(value.String()), nil
+	}
+	return json.Marshal(value.Interface())
+}
+
+// decodePlain parses plaintext made by encodePlain, or a legacy plaintext
+// column value, into target, a pointer to the field's type
+func decodePlain(plain []byte, target reflect.Value) error {
+	value := target.Elem()
+	if value.Kind() == reflect.Ptr {
+		value.Set(reflect.New(value.Type().Elem()))
+		value = value.Elem()
+	}
+
+	if value.Kind() == reflect.String {
+		value.SetString(string(plain))
+		return nil
+	}
+	if t, ok := value.Addr().Interface().(*time.Time); ok {
+		if json.Unmarshal(plain, t) == nil {
+			return nil
+		}
+		for _, layout := range legacyTimeLayouts {
+			if parsed, err := time.Parse(layout, string(plain)); err == nil {
+				*t = parsed
+				return nil
+			}
+		}
+		return errors.New("invalid time value")
+	}
+	return json.Unmarshal(plain, value.Addr().Interface())
+}
@@ -0,0 +1,91 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// healthCheckTimeout bounds each query of a health check, so an unreachable
+// server can't hang it
+const healthCheckTimeout = 2 * time.Second
+
+// Health pings the primary, measures the lag of every replica and reads the
+// schema version. A failing replica only degrades the status, since the
+// primary still takes writes and pinned reads.
+func (s databaseStats) Health(ctx context.Context) *domain.DatabaseHealth {
+	health := &domain.DatabaseHealth{
+		Status: domain.DatabaseStatusOK,
+		Pools:  s.PoolStats(),
+	}
+	if len(pools) == 0 {
+		health.Status = domain.DatabaseStatusDown
+		health.Error = "database not connected"
+		return health
+	}
+
+	primary := pools[0].db
+	if err := ping(ctx, primary); err != nil {
+		health.Status = domain.DatabaseStatusDown
+		health.Error = "primary unreachable: " + err.Error()
+		return health
+	}
+
+	for _, replica := range pools[1:] {
+		status := domain.DatabaseReplicaHealth{Name: replica.name}
+		lag, err := replicationLag(ctx, replica.db)
+		if err != nil {
+			status.Error = err.Error()
+			health.Status = domain.DatabaseStatusDegraded
+		}
+		status.LagSeconds = lag
+		health.Replicas = append(health.Replicas, status)
+	}
+
+	if version, err := schemaVersion(ctx, primary); err == nil {
+		health.Schema = version
+	}
+
+	return health
+}
+
+func ping(ctx context.Context, db *sql.DB) error {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+	return db.PingContext(ctx)
+}
+
+// replicationLag asks a Postgres replica how long ago it replayed the last
+// transaction from the primary
+func replicationLag(ctx context.Context, db *sql.DB) (*float64, error) {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	var lag sql.NullFloat64
+	err := db.QueryRowContext(ctx, "SELECT EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp())").Scan(&lag)
+	if err != nil {
+		return nil, errors.New("replica unreachable: " + err.Error())
+	}
+	if !lag.Valid {
+		return nil, nil
+	}
+	return &lag.Float64, nil
+}
+
+// schemaVersion reads the latest version recorded by Migrate
+func schemaVersion(ctx context.Context, db *sql.DB) (*domain.DatabaseSchemaVersion, error) {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	var version domain.DatabaseSchemaVersion
+	err := db.QueryRowContext(ctx, "SELECT version, applied_at FROM schema_migrations ORDER BY version DESC LIMIT 1").
+		Scan(&version.Version, &version.AppliedAt)
+	if err != nil {
+		return nil, err
+	}
+	version.Current = version.Version == SchemaVersion
+	return &version, nil
+}
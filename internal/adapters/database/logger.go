@@ -0,0 +1,56 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/utils"
+	"gorm.io/gorm/logger"
+)
+
+// requestLogger prefixes GORM's log lines with the request ID carried by the
+// query's context, for queries run with db.WithContext
+type requestLogger struct {
+	logger.Interface
+}
+
+// newRequestLogger wraps a GORM logger so its lines carry request IDs
+func newRequestLogger(base logger.Interface) logger.Interface {
+	return &requestLogger{Interface: base}
+}
+
+// LogMode sets the log level while keeping the request ID prefix
+func (l *requestLogger) LogMode(level logger.LogLevel) logger.Interface {
+	return &requestLogger{Interface: l.Interface.LogMode(level)}
+}
+
+// Info logs an info message with the request ID
+func (l *requestLogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	l.Interface.Info(ctx, withRequestID(ctx, msg), args...)
+}
+
+// Warn logs a warning with the request ID
+func (l *requestLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	l.Interface.Warn(ctx, withRequestID(ctx, msg), args...)
+}
+
+// Error logs an error with the request ID
+func (l *requestLogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	l.Interface.Error(ctx, withRequestID(ctx, msg), args...)
+}
+
+// Trace logs a query with the request ID
+func (l *requestLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	l.Interface.Trace(ctx, begin, func() (string, int64) {
+		sql, rows := fc()
+		return withRequestID(ctx, sql), rows
+	}, err)
+}
+
+// withRequestID prefixes msg with the context's request ID, if any
+func withRequestID(ctx context.Context, msg string) string {
+	if requestID := utils.RequestIDFromContext(ctx); requestID != "" {
+		return "[" + requestID + "] " + msg
+	}
+	return msg
+}
@@ -0,0 +1,159 @@
+package database
+
+import (
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"gorm.io/gorm"
+)
+
+// SchemaVersion identifies the schema Migrate creates, as yyyymmddNN. Bump it
+// with every model change that alters the schema, so the health check shows
+// which version a database was last migrated to.
+const SchemaVersion int64 = 2026101624
+
+// schemaMigration records a Migrate run that brought the schema to a new version
+type schemaMigration struct {
+	ID        uint      `gorm:"primarykey"`
+	Version   int64     `gorm:"not null;uniqueIndex"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+// Migrate auto migrates the schema of every domain model and records the
+// schema version
+func Migrate(db *gorm.DB) error {
+	if err := migrateSchema(db); err != nil {
+		return err
+	}
+
+	migration := schemaMigration{Version: SchemaVersion, AppliedAt: time.Now()}
+	return db.Where(schemaMigration{Version: SchemaVersion}).FirstOrCreate(&migration).Error
+}
+
+// migrateSchema brings the schema up to date; PlanMigration runs it to see
+// which statements it would execute
+func migrateSchema(db *gorm.DB) error {
+	// Chapters soft deleted on their own, rather than with their manga, are
+	// never restored. Drop them before the unique chapter number index is
+	// built, so they don't hold their numbers.
+	if db.Migrator().HasTable(&domain.Chapter{}) {
+		if err := db.Exec("DELETE FROM chapters WHERE deleted_at IS NOT NULL AND NOT EXISTS " +
+			"(SELECT 1 FROM mangas WHERE mangas.id = chapters.manga_id AND mangas.deleted_at = chapters.deleted_at)").Error; err != nil {
+			return err
+		}
+	}
+
+	err := db.AutoMigrate(
+		&domain.User{},
+		&domain.Genre{},
+		&domain.Manga{},
+		&domain.MangaImage{},
+		&domain.MangaTranslation{},
+		&domain.MangaPriceHistory{},
+		&domain.MangaVersion{},
+		&domain.MangaRelation{},
+		&domain.Series{},
+		&domain.Wishlist{},
+		&domain.WishlistItem{},
+		&domain.CartItem{},
+		&domain.Order{},
+		&domain.OrderItem{},
+		&domain.StockReservation{},
+		&domain.TaxRate{},
+		&domain.OrderTaxLine{},
+		&domain.Rental{},
+		&domain.Discount{},
+		&domain.MangaView{},
+		&domain.Comment{},
+		&domain.Chapter{},
+		&domain.Review{},
+		&domain.ReadingProgress{},
+		&domain.Team{},
+		&domain.TeamMember{},
+		&domain.TeamInvitation{},
+		&domain.QuotaUsage{},
+		&domain.Webhook{},
+		&domain.WebhookDelivery{},
+		&domain.WebhookDeliveryAttempt{},
+		&domain.APIKey{},
+		&domain.BackupJob{},
+		&domain.OutboxEvent{},
+		&domain.Job{},
+		&domain.NotificationPreferences{},
+		&domain.Digest{},
+		&domain.DigestItem{},
+		&domain.Upload{},
+		&domain.JobError{},
+		&domain.ArchivedOrder{},
+		&domain.ArchivedMangaView{},
+		&domain.AuditLog{},
+		&domain.UserPhone{},
+		&domain.LineAccount{},
+		&schemaMigration{},
+	)
+	if err != nil {
+		return err
+	}
+
+	// User name prefix searches match lower(name) LIKE 'prefix%', which Postgres
+	// only serves from an index with the pattern operator class
+	nameIndex := "lower(name) text_pattern_ops"
+	if db.Dialector.Name() == "sqlite" {
+		nameIndex = "lower(name)"
+	}
+	if err := db.Exec("CREATE INDEX IF NOT EXISTS idx_users_name_prefix ON users (" + nameIndex + ")").Error; err != nil {
+		return err
+	}
+
+	// A user has one active rental of a manga at most. Duplicates from before
+	// the index existed keep only the newest active.
+	if err := db.Exec("UPDATE rentals SET status = ? WHERE status = ? AND id NOT IN (SELECT MAX(id) FROM rentals WHERE status = ? GROUP BY user_id, manga_id)",
+		domain.RentalStatusExpired, domain.RentalStatusActive, domain.RentalStatusActive).Error; err != nil {
+		return err
+	}
+	if err := db.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_rentals_user_manga_active ON rentals (user_id, manga_id) WHERE status = '" + domain.RentalStatusActive + "'").Error; err != nil {
+		return err
+	}
+
+	if err := migrateAuditLogGuard(db); err != nil {
+		return err
+	}
+
+	return migrateSearchIndexes(db)
+}
+
+// auditLogGuardStatements make the audit log append-only: triggers reject
+// every UPDATE and DELETE of its rows, and on Postgres a TRUNCATE as well. The
+// trigger function lives in the audit schema, which backups leave out along
+// with the audit log itself.
+var auditLogGuardStatements = map[string][]string{
+	"postgres": {
+		"CREATE SCHEMA IF NOT EXISTS audit",
+		"CREATE OR REPLACE FUNCTION audit.append_only() RETURNS trigger AS $$ " +
+			"BEGIN RAISE EXCEPTION 'audit_logs is append-only'; END; $$ LANGUAGE plpgsql",
+		"DO $$ BEGIN " +
+			"IF NOT EXISTS (SELECT 1 FROM pg_trigger WHERE tgname = 'audit_logs_append_only') THEN " +
+			"CREATE TRIGGER audit_logs_append_only BEFORE UPDATE OR DELETE ON audit_logs " +
+			"FOR EACH ROW EXECUTE FUNCTION audit.append_only(); END IF; " +
+			"IF NOT EXISTS (SELECT 1 FROM pg_trigger WHERE tgname = 'audit_logs_no_truncate') THEN " +
+			"CREATE TRIGGER audit_logs_no_truncate BEFORE TRUNCATE ON audit_logs " +
+			"FOR EACH STATEMENT EXECUTE FUNCTION audit.append_only(); END IF; " +
+			"END $$",
+	},
+	"sqlite": {
+		"CREATE TRIGGER IF NOT EXISTS audit_logs_no_update BEFORE UPDATE ON audit_logs " +
+			"BEGIN SELECT RAISE(ABORT, 'audit_logs is append-only'); END",
+		"CREATE TRIGGER IF NOT EXISTS audit_logs_no_delete BEFORE DELETE ON audit_logs " +
+			"BEGIN SELECT RAISE(ABORT, 'audit_logs is append-only'); END",
+	},
+}
+
+// migrateAuditLogGuard creates the triggers keeping the audit log append-only
+func migrateAuditLogGuard(db *gorm.DB) error {
+	for _, statement := range auditLogGuardStatements[db.Dialector.Name()] {
+		if err := db.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
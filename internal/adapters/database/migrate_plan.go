@@ -0,0 +1,94 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// MigrationPlan lists the statements Migrate would run against a database
+type MigrationPlan struct {
+	// FromVersion is the schema version the database was last migrated to, 0 if never
+	FromVersion int64
+	ToVersion   int64
+	// Changes alter the schema; Idempotent statements run on every migration
+	// and do nothing once applied (CREATE ... IF NOT EXISTS)
+	Changes    []string
+	Idempotent []string
+}
+
+// planReads are the leading keywords of statements a migration runs without
+// changing anything: schema lookups and savepoints
+var planReads = []string{"SELECT", "PRAGMA", "SHOW", "WITH", "SAVEPOINT", "RELEASE", "ROLLBACK"}
+
+// PlanMigration runs the migration in a transaction that is rolled back, and
+// returns the statements it executed. Postgres and SQLite both roll back
+// schema changes, so the plan is exactly what Migrate would do to this
+// database, but it takes the same locks for as long as it runs.
+func PlanMigration(ctx context.Context, db *gorm.DB) (*MigrationPlan, error) {
+	// The recorder also keeps the executed SQL off the query log
+	recorder := &statementRecorder{Interface: logger.Discard}
+	db = db.WithContext(ctx).Session(&gorm.Session{Logger: recorder})
+
+	plan := &MigrationPlan{ToVersion: SchemaVersion}
+	if db.Migrator().HasTable(&schemaMigration{}) {
+		var versions []int64
+		if err := db.Model(&schemaMigration{}).Order("version DESC").Limit(1).Pluck("version", &versions).Error; err != nil {
+			return nil, errors.New("failed to read the schema version")
+		}
+		if len(versions) > 0 {
+			plan.FromVersion = versions[0]
+		}
+	}
+
+	tx := db.Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+	defer tx.Rollback()
+
+	if err := migrateSchema(tx); err != nil {
+		return nil, err
+	}
+
+	for _, statement := range recorder.statements {
+		if strings.Contains(strings.ToUpper(statement), "IF NOT EXISTS") {
+			plan.Idempotent = append(plan.Idempotent, statement)
+		} else {
+			plan.Changes = append(plan.Changes, statement)
+		}
+	}
+	return plan, nil
+}
+
+// statementRecorder is a GORM logger collecting the statements that succeeded
+// and change the schema
+type statementRecorder struct {
+	logger.Interface
+	statements []string
+}
+
+// LogMode keeps recording whatever the level
+func (r *statementRecorder) LogMode(logger.LogLevel) logger.Interface {
+	return r
+}
+
+// Trace records the statement unless it failed or only read
+func (r *statementRecorder) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if err != nil {
+		return
+	}
+	sql, _ := fc()
+	sql = strings.TrimSpace(sql)
+	keyword, _, _ := strings.Cut(sql, " ")
+	for _, read := range planReads {
+		if strings.EqualFold(keyword, read) {
+			return
+		}
+	}
+	r.statements = append(r.statements, sql)
+}
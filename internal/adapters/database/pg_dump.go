@@ -0,0 +1,118 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/thitiphongD/my-backend/internal/config"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"gorm.io/gorm"
+)
+
+// backupExcludedTables are left out of backups, so restoring one keeps the
+// record of every backup, including the restore in progress, and the audit log
+var backupExcludedTables = []string{"backup_jobs", "audit_logs"}
+
+// backupExcludedSchemas are left out of backups; the audit schema holds the
+// function of the audit log triggers, which pg_restore could not drop
+var backupExcludedSchemas = []string{"audit"}
+
+// pgDumper implements the DatabaseDumper interface with the pg_dump and
+// pg_restore tools, which must match the server's major version or be newer
+type pgDumper struct {
+	cfg *config.Config
+	db  *gorm.DB
+}
+
+// NewPGDumper creates a dumper for the primary database configured in cfg.
+// db is migrated after a restore to bring an older backup up to date.
+func NewPGDumper(cfg *config.Config, db *gorm.DB) ports.DatabaseDumper {
+	return &pgDumper{
+		cfg: cfg,
+		db:  db,
+	}
+}
+
+// errBackupDriver is returned when the database is not Postgres
+var errBackupDriver = errors.New("backups require DB_DRIVER=postgres")
+
+// Dump writes a pg_dump archive in the custom format to w
+func (d *pgDumper) Dump(ctx context.Context, w io.Writer) error {
+	if d.cfg.DBDriver != "postgres" {
+		return errBackupDriver
+	}
+
+	args := []string{"--format=custom", "--no-owner", "--no-privileges"}
+	for _, table := range backupExcludedTables {
+		args = append(args, "--exclude-table="+table)
+	}
+	for _, schema := range backupExcludedSchemas {
+		args = append(args, "--exclude-schema="+schema)
+	}
+
+	cmd := d.command(ctx, d.cfg.PGDumpPath, args...)
+	cmd.Stdout = w
+	return run(cmd)
+}
+
+// Restore drops the objects in the archive read from r and recreates them in
+// a single transaction, so a failed restore leaves the database as it was
+func (d *pgDumper) Restore(ctx context.Context, r io.Reader) error {
+	if d.cfg.DBDriver != "postgres" {
+		return errBackupDriver
+	}
+
+	cmd := d.command(ctx, d.cfg.PGRestorePath,
+		"--clean", "--if-exists", "--no-owner", "--no-privileges", "--single-transaction",
+		"--dbname="+d.cfg.DBName)
+	cmd.Stdin = r
+	if err := run(cmd); err != nil {
+		return err
+	}
+
+	if err := Migrate(d.db.WithContext(ctx)); err != nil {
+		return errors.New("restored, but failed to migrate the restored schema: " + err.Error())
+	}
+	return nil
+}
+
+// command builds a Postgres client command connecting to the primary. The
+// connection settings are passed in the environment, which keeps the
+// password out of the process list.
+func (d *pgDumper) command(ctx context.Context, name string, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Env = append(os.Environ(),
+		"PGHOST="+d.cfg.DBHost,
+		"PGPORT="+d.cfg.DBPort,
+		"PGUSER="+d.cfg.DBUser,
+		"PGPASSWORD="+d.cfg.DBPass,
+		"PGDATABASE="+d.cfg.DBName,
+		"PGSSLMODE="+d.cfg.DBSSLMode,
+	)
+	if d.cfg.DBChannelBinding != "" {
+		cmd.Env = append(cmd.Env, "PGCHANNELBINDING="+d.cfg.DBChannelBinding)
+	}
+	return cmd
+}
+
+// run runs the command, failing with the end of its error output
+func run(cmd *exec.Cmd) error {
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		output := strings.TrimSpace(stderr.String())
+		if len(output) > 1000 {
+			output = "..." + output[len(output)-1000:]
+		}
+		if output == "" {
+			return errors.New(cmd.Path + " failed: " + err.Error())
+		}
+		return errors.New(cmd.Path + " failed: " + output)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package database
+
+import (
+	"database/sql"
+	"log"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/config"
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// connectionPool is the connection pool of one database server
+type connectionPool struct {
+	name string
+	db   *sql.DB
+}
+
+// pools holds the pools opened by ConnectDatabase, primary first
+var pools []connectionPool
+
+// configurePool applies the pool limits from config; without them database/sql
+// opens connections without bound and never recycles them
+func configurePool(db *sql.DB, cfg *config.Config) {
+	db.SetMaxOpenConns(int(cfg.DBMaxOpenConns))
+	db.SetMaxIdleConns(int(cfg.DBMaxIdleConns))
+	db.SetConnMaxLifetime(time.Duration(cfg.DBConnMaxLifetimeSeconds) * time.Second)
+	db.SetConnMaxIdleTime(time.Duration(cfg.DBConnMaxIdleTimeSeconds) * time.Second)
+}
+
+// databaseStats implements the DatabaseStats interface over the pools opened by ConnectDatabase
+type databaseStats struct{}
+
+// NewDatabaseStats creates a new database stats instance
+func NewDatabaseStats() ports.DatabaseStats {
+	return databaseStats{}
+}
+
+// PoolStats returns the current stats of every pool
+func (databaseStats) PoolStats() []domain.DatabasePoolStats {
+	stats := make([]domain.DatabasePoolStats, 0, len(pools))
+	for _, pool := range pools {
+		s := pool.db.Stats()
+		stats = append(stats, domain.DatabasePoolStats{
+			Name:               pool.name,
+			MaxOpenConnections: s.MaxOpenConnections,
+			OpenConnections:    s.OpenConnections,
+			InUse:              s.InUse,
+			Idle:               s.Idle,
+			WaitCount:          s.WaitCount,
+			WaitDurationMs:     s.WaitDuration.Milliseconds(),
+			MaxIdleClosed:      s.MaxIdleClosed,
+			MaxIdleTimeClosed:  s.MaxIdleTimeClosed,
+			MaxLifetimeClosed:  s.MaxLifetimeClosed,
+		})
+	}
+	return stats
+}
+
+// LogPoolStats logs the stats of every pool in the background at the given interval
+func LogPoolStats(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			for _, s := range NewDatabaseStats().PoolStats() {
+				log.Printf("db pool %s: open=%d/%d in_use=%d idle=%d wait_count=%d wait=%dms",
+					s.Name, s.OpenConnections, s.MaxOpenConnections, s.InUse, s.Idle, s.WaitCount, s.WaitDurationMs)
+			}
+		}
+	}()
+}
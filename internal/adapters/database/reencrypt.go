@@ -0,0 +1,87 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// EncryptedColumn names a column stored with the serializer:encrypted tag.
+// Key is the table's integer primary key column, "id" when empty.
+type EncryptedColumn struct {
+	Table  string
+	Column string
+	Key    string
+}
+
+// key returns the column rows of the table are walked and updated by
+func (c EncryptedColumn) key() string {
+	if c.Key == "" {
+		return "id"
+	}
+	return c.Key
+}
+
+// EncryptedColumns lists every encrypted column, for key rotation. Keep it in
+// step with the serializer:encrypted tags on the domain models.
+var EncryptedColumns = []EncryptedColumn{
+	{Table: "users", Column: "birth_date"},
+	{Table: "webhooks", Column: "secret"},
+	{Table: "user_phones", Column: "number", Key: "user_id"},
+}
+
+// Reencrypt rewrites the values of column that aren't encrypted under the
+// active key, batchSize rows at a time, including soft deleted rows. It
+// returns how many values were rewritten.
+func Reencrypt(ctx context.Context, db *gorm.DB, column EncryptedColumn, batchSize int) (int, error) {
+	codec := Encryption()
+	if !codec.Enabled() {
+		return 0, errors.New("no encryption key configured")
+	}
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+
+	key := column.key()
+	rewritten := 0
+	var lastID uint
+	for {
+		var rows []struct {
+			ID    uint
+			Value string
+		}
+		err := db.WithContext(ctx).Table(column.Table).
+			Select(key+" AS id, "+column.Column+" AS value").
+			Where(column.Column+" IS NOT NULL AND "+column.Column+" NOT LIKE ? AND "+key+" > ?", encryptedPrefix+codec.ActiveKeyID()+":%", lastID).
+			Order(key).
+			Limit(batchSize).
+			Find(&rows).Error
+		if err != nil {
+			return rewritten, fmt.Errorf("failed to read %s.%s: %w", column.Table, column.Column, err)
+		}
+		if len(rows) == 0 {
+			return rewritten, nil
+		}
+
+		for _, row := range rows {
+			rotated, changed, err := codec.Rotate(row.Value)
+			if err != nil {
+				return rewritten, fmt.Errorf("failed to re-encrypt %s.%s of row %d: %w", column.Table, column.Column, row.ID, err)
+			}
+			if !changed {
+				continue
+			}
+			// Only rewrite the value read, in case it changed in the meantime
+			result := db.WithContext(ctx).Table(column.Table).
+				Where(key+" = ? AND "+column.Column+" = ?", row.ID, row.Value).
+				UpdateColumn(column.Column, rotated)
+			if result.Error != nil {
+				return rewritten, fmt.Errorf("failed to write %s.%s of row %d: %w", column.Table, column.Column, row.ID, result.Error)
+			}
+			rewritten += int(result.RowsAffected)
+		}
+		lastID = rows[len(rows)-1].ID
+	}
+}
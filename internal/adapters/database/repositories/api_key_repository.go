@@ -0,0 +1,129 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"gorm.io/gorm"
+)
+
+// apiKeyRepository implements the APIKeyRepository interface
+type apiKeyRepository struct {
+	db *gorm.DB
+}
+
+// NewAPIKeyRepository creates a new API key repository instance
+func NewAPIKeyRepository(db *gorm.DB) ports.APIKeyRepository {
+	return &apiKeyRepository{
+		db: db,
+	}
+}
+
+// Create stores a new API key
+func (r *apiKeyRepository) Create(ctx context.Context, key *domain.APIKey) error {
+	if err := withContext(ctx, r.db).Create(key).Error; err != nil {
+		return errors.New("failed to create API key")
+	}
+	return nil
+}
+
+// GetByID retrieves an API key by ID
+func (r *apiKeyRepository) GetByID(ctx context.Context, id uint) (*domain.APIKey, error) {
+	var key domain.APIKey
+	if err := withContext(ctx, r.db).First(&key, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("API key not found")
+		}
+		return nil, errors.New("failed to get API key")
+	}
+	return &key, nil
+}
+
+// GetByHash retrieves the API key with the hash
+func (r *apiKeyRepository) GetByHash(ctx context.Context, hash string) (*domain.APIKey, error) {
+	var key domain.APIKey
+	if err := withContext(ctx, r.db).Where("hash = ?", hash).First(&key).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("API key not found")
+		}
+		return nil, errors.New("failed to get API key")
+	}
+	return &key, nil
+}
+
+// ListByUser retrieves a user's API keys, newest first
+func (r *apiKeyRepository) ListByUser(ctx context.Context, userID uint) ([]*domain.APIKey, error) {
+	var keys []*domain.APIKey
+	if err := withContext(ctx, r.db).Where("user_id = ?", userID).Order("id DESC").Find(&keys).Error; err != nil {
+		return nil, errors.New("failed to get API keys")
+	}
+	return keys, nil
+}
+
+// ListPaginated retrieves the API keys of every user, newest first,
+// optionally of one user and one status only
+func (r *apiKeyRepository) ListPaginated(ctx context.Context, userID uint, status string, pagination *domain.PaginationRequest) ([]*domain.APIKey, int64, error) {
+	var keys []*domain.APIKey
+	var total int64
+
+	query := withContext(ctx, r.db).Model(&domain.APIKey{})
+	if userID != 0 {
+		query = query.Where("user_id = ?", userID)
+	}
+	switch status {
+	case domain.APIKeyStatusActive:
+		query = query.Where("revoked_at IS NULL")
+	case domain.APIKeyStatusRevoked:
+		query = query.Where("revoked_at IS NOT NULL")
+	}
+
+	// Count total keys
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.New("failed to count API keys")
+	}
+
+	// Get paginated results
+	offset := pagination.GetOffset()
+	limit := pagination.GetLimit()
+
+	if err := query.Order("id DESC").Offset(offset).Limit(limit).Find(&keys).Error; err != nil {
+		return nil, 0, errors.New("failed to get API keys")
+	}
+
+	return keys, total, nil
+}
+
+// CountActive counts a user's API keys that aren't revoked
+func (r *apiKeyRepository) CountActive(ctx context.Context, userID uint) (int64, error) {
+	var count int64
+	err := withContext(ctx, r.db).Model(&domain.APIKey{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Count(&count).Error
+	if err != nil {
+		return 0, errors.New("failed to count API keys")
+	}
+	return count, nil
+}
+
+// Update saves an API key
+func (r *apiKeyRepository) Update(ctx context.Context, key *domain.APIKey) error {
+	if err := withContext(ctx, r.db).Save(key).Error; err != nil {
+		return errors.New("failed to update API key")
+	}
+	return nil
+}
+
+// TouchLastUsed saves when an API key was last used, leaving the rest of the
+// key and its updated_at alone
+func (r *apiKeyRepository) TouchLastUsed(ctx context.Context, id uint, at time.Time) error {
+	err := withContext(ctx, r.db).Model(&domain.APIKey{}).
+		Where("id = ?", id).
+		UpdateColumn("last_used_at", at).Error
+	if err != nil {
+		return errors.New("failed to update API key")
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"gorm.io/gorm"
+)
+
+// auditLogRepository implements the AuditLogRepository interface
+type auditLogRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditLogRepository creates a new audit log repository instance
+func NewAuditLogRepository(db *gorm.DB) ports.AuditLogRepository {
+	return &auditLogRepository{
+		db: db,
+	}
+}
+
+// Create appends an entry, joining the transaction carried by ctx
+func (r *auditLogRepository) Create(ctx context.Context, entry *domain.AuditLog) error {
+	if err := withContext(ctx, r.db).Create(entry).Error; err != nil {
+		return errors.New("failed to record audit log")
+	}
+	return nil
+}
+
+// ListPaginated retrieves the entries matching the filter, newest first
+func (r *auditLogRepository) ListPaginated(ctx context.Context, filter *domain.AuditLogFilter, pagination *domain.PaginationRequest) ([]*domain.AuditLog, int64, error) {
+	var entries []*domain.AuditLog
+	var total int64
+
+	query := withContext(ctx, r.db).Model(&domain.AuditLog{})
+	if filter.ActorID != nil {
+		query = query.Where("actor_id = ?", *filter.ActorID)
+	}
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != nil {
+		query = query.Where("entity_id = ?", *filter.EntityID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+
+	// Count total entries
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.New("failed to count audit logs")
+	}
+
+	// Get paginated results
+	offset := pagination.GetOffset()
+	limit := pagination.GetLimit()
+
+	if err := query.Order("id DESC").Offset(offset).Limit(limit).Find(&entries).Error; err != nil {
+		return nil, 0, errors.New("failed to get audit logs")
+	}
+
+	return entries, total, nil
+}
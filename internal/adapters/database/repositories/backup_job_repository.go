@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"gorm.io/gorm"
+)
+
+// backupJobRepository implements the BackupJobRepository interface
+type backupJobRepository struct {
+	db *gorm.DB
+}
+
+// NewBackupJobRepository creates a new backup job repository instance
+func NewBackupJobRepository(db *gorm.DB) ports.BackupJobRepository {
+	return &backupJobRepository{
+		db: db,
+	}
+}
+
+// Create creates a new backup job
+func (r *backupJobRepository) Create(ctx context.Context, job *domain.BackupJob) error {
+	if err := withContext(ctx, r.db).Create(job).Error; err != nil {
+		return errors.New("failed to create backup job")
+	}
+	return nil
+}
+
+// Update saves the job's status and results
+func (r *backupJobRepository) Update(ctx context.Context, job *domain.BackupJob) error {
+	if err := withContext(ctx, r.db).Save(job).Error; err != nil {
+		return errors.New("failed to update backup job")
+	}
+	return nil
+}
+
+// GetByID retrieves a backup job by ID
+func (r *backupJobRepository) GetByID(ctx context.Context, id uint) (*domain.BackupJob, error) {
+	var job domain.BackupJob
+	if err := withContext(ctx, r.db).First(&job, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("backup job not found")
+		}
+		return nil, errors.New("failed to get backup job")
+	}
+	return &job, nil
+}
+
+// ListPaginated retrieves backup jobs, newest first, optionally of one kind only
+func (r *backupJobRepository) ListPaginated(ctx context.Context, kind string, pagination *domain.PaginationRequest) ([]*domain.BackupJob, int64, error) {
+	var jobs []*domain.BackupJob
+	var total int64
+
+	query := withContext(ctx, r.db).Model(&domain.BackupJob{})
+	if kind != "" {
+		query = query.Where("kind = ?", kind)
+	}
+
+	// Count total jobs
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.New("failed to count backup jobs")
+	}
+
+	// Get paginated results
+	offset := pagination.GetOffset()
+	limit := pagination.GetLimit()
+
+	if err := query.Order("id DESC").Offset(offset).Limit(limit).Find(&jobs).Error; err != nil {
+		return nil, 0, errors.New("failed to get backup jobs")
+	}
+
+	return jobs, total, nil
+}
+
+// FailUnfinished marks pending and running jobs as failed with the given reason
+func (r *backupJobRepository) FailUnfinished(ctx context.Context, reason string) (int64, error) {
+	result := withContext(ctx, r.db).Model(&domain.BackupJob{}).
+		Where("status IN ?", []string{domain.BackupStatusPending, domain.BackupStatusRunning}).
+		Updates(map[string]interface{}{
+			"status":      domain.BackupStatusFailed,
+			"error":       reason,
+			"finished_at": time.Now(),
+		})
+	if result.Error != nil {
+		return 0, errors.New("failed to update backup jobs")
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,61 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"gorm.io/gorm"
+)
+
+// createInBatches inserts records batchSize at a time (all at once when
+// batchSize <= 0), each batch with multi-row INSERTs in its own transaction.
+// A failed batch is rolled back and its records are retried one by one, so
+// only the records that fail on their own are reported, with the message
+// failure. Once ctx is done the remaining records all fail. resetID clears the
+// primary key a rolled back insert may have assigned.
+func createInBatches[T any](ctx context.Context, db *gorm.DB, records []*T, batchSize int, resetID func(*T), failure string) []domain.BatchCreateFailure {
+	if batchSize <= 0 {
+		batchSize = len(records)
+	}
+
+	var failures []domain.BatchCreateFailure
+	failFrom := func(start int) []domain.BatchCreateFailure {
+		for i := start; i < len(records); i++ {
+			resetID(records[i])
+			failures = append(failures, domain.BatchCreateFailure{Index: i, Error: failure})
+		}
+		return failures
+	}
+
+	for start := 0; start < len(records); start += batchSize {
+		batch := records[start:min(start+batchSize, len(records))]
+		err := withContext(ctx, db).Transaction(func(tx *gorm.DB) error {
+			return tx.Create(&batch).Error
+		})
+		if err == nil {
+			continue
+		}
+		if ctx.Err() != nil {
+			return failFrom(start)
+		}
+
+		for _, record := range batch {
+			resetID(record)
+		}
+		for i, record := range batch {
+			err := withContext(ctx, db).Transaction(func(tx *gorm.DB) error {
+				return tx.Create(record).Error
+			})
+			if err == nil {
+				continue
+			}
+			if ctx.Err() != nil {
+				return failFrom(start + i)
+			}
+			resetID(record)
+			failures = append(failures, domain.BatchCreateFailure{Index: start + i, Error: failure})
+		}
+	}
+
+	return failures
+}
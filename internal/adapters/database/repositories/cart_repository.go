@@ -0,0 +1,74 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// cartRepository implements the CartRepository interface
+type cartRepository struct {
+	db *gorm.DB
+}
+
+// NewCartRepository creates a new cart repository instance
+func NewCartRepository(db *gorm.DB) ports.CartRepository {
+	return &cartRepository{
+		db: db,
+	}
+}
+
+// ListByUserID retrieves the items in a user's cart, oldest first, with their mangas
+func (r *cartRepository) ListByUserID(ctx context.Context, userID uint) ([]*domain.CartItem, error) {
+	var items []*domain.CartItem
+	err := withContext(ctx, r.db).
+		Where("user_id = ?", userID).
+		Where("manga_id IN (?)", r.db.Model(&domain.Manga{}).Select("id")).
+		Preload("Manga.Genres").
+		Order("id").
+		Find(&items).Error
+	if err != nil {
+		return nil, errors.New("failed to get cart")
+	}
+	return items, nil
+}
+
+// SetItem creates or updates the cart item of a (user, manga) pair
+func (r *cartRepository) SetItem(ctx context.Context, item *domain.CartItem) error {
+	err := withContext(ctx, r.db).Omit("Manga").Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}, {Name: "manga_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"quantity":   item.Quantity,
+			"updated_at": time.Now(),
+		}),
+	}).Create(item).Error
+	if err != nil {
+		return errors.New("failed to save cart item")
+	}
+	return nil
+}
+
+// RemoveItem takes a manga out of a user's cart
+func (r *cartRepository) RemoveItem(ctx context.Context, userID, mangaID uint) error {
+	result := withContext(ctx, r.db).Where("user_id = ? AND manga_id = ?", userID, mangaID).Delete(&domain.CartItem{})
+	if result.Error != nil {
+		return errors.New("failed to remove cart item")
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("cart item not found")
+	}
+	return nil
+}
+
+// Clear empties a user's cart
+func (r *cartRepository) Clear(ctx context.Context, userID uint) error {
+	if err := withContext(ctx, r.db).Where("user_id = ?", userID).Delete(&domain.CartItem{}).Error; err != nil {
+		return errors.New("failed to clear cart")
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"gorm.io/gorm"
+)
+
+// chapterRepository implements the ChapterRepository interface
+type chapterRepository struct {
+	db *gorm.DB
+}
+
+// NewChapterRepository creates a new chapter repository instance
+func NewChapterRepository(db *gorm.DB) ports.ChapterRepository {
+	return &chapterRepository{
+		db: db,
+	}
+}
+
+// Create creates a new chapter in the database
+func (r *chapterRepository) Create(ctx context.Context, chapter *domain.Chapter) error {
+	if err := withContext(ctx, r.db).Create(chapter).Error; err != nil {
+		if isDuplicateKey(r.db, err) {
+			return errors.New("chapter with this number already exists")
+		}
+		return errors.New("failed to create chapter")
+	}
+	return nil
+}
+
+// GetByID retrieves a chapter by ID
+func (r *chapterRepository) GetByID(ctx context.Context, id uint) (*domain.Chapter, error) {
+	var chapter domain.Chapter
+	if err := withContext(ctx, r.db).First(&chapter, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("chapter not found")
+		}
+		return nil, errors.New("failed to get chapter")
+	}
+	return &chapter, nil
+}
+
+// GetByMangaAndNumber retrieves a manga's chapter by its number
+func (r *chapterRepository) GetByMangaAndNumber(ctx context.Context, mangaID uint, number float64) (*domain.Chapter, error) {
+	var chapter domain.Chapter
+	if err := withContext(ctx, r.db).Where("manga_id = ? AND number = ?", mangaID, number).First(&chapter).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("chapter not found")
+		}
+		return nil, errors.New("failed to get chapter")
+	}
+	return &chapter, nil
+}
+
+// ListByMangaID retrieves all chapters of a manga ordered by chapter number
+func (r *chapterRepository) ListByMangaID(ctx context.Context, mangaID uint) ([]*domain.Chapter, error) {
+	var chapters []*domain.Chapter
+	if err := withContext(ctx, r.db).Where("manga_id = ?", mangaID).Order("number").Find(&chapters).Error; err != nil {
+		return nil, errors.New("failed to get chapters")
+	}
+	return chapters, nil
+}
+
+// Update updates a chapter in the database
+func (r *chapterRepository) Update(ctx context.Context, chapter *domain.Chapter) error {
+	if err := withContext(ctx, r.db).Save(chapter).Error; err != nil {
+		if isDuplicateKey(r.db, err) {
+			return errors.New("chapter with this number already exists")
+		}
+		return errors.New("failed to update chapter")
+	}
+	return nil
+}
+
+// Delete permanently deletes a chapter, so its number can be used again.
+// Only chapters deleted with their manga are soft deleted, to be restored
+// with it.
+func (r *chapterRepository) Delete(ctx context.Context, id uint) error {
+	if err := withContext(ctx, r.db).Unscoped().Delete(&domain.Chapter{}, id).Error; err != nil {
+		return errors.New("failed to delete chapter")
+	}
+	return nil
+}
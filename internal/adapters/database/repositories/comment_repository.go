@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"gorm.io/gorm"
+)
+
+// commentRepository implements the CommentRepository interface
+type commentRepository struct {
+	db *gorm.DB
+}
+
+// NewCommentRepository creates a new comment repository instance
+func NewCommentRepository(db *gorm.DB) ports.CommentRepository {
+	return &commentRepository{
+		db: db,
+	}
+}
+
+// Create creates a new comment in the database
+func (r *commentRepository) Create(ctx context.Context, comment *domain.Comment) error {
+	if err := withContext(ctx, r.db).Omit("Replies").Create(comment).Error; err != nil {
+		return errors.New("failed to create comment")
+	}
+	return nil
+}
+
+// GetByID retrieves a comment by ID
+func (r *commentRepository) GetByID(ctx context.Context, id uint) (*domain.Comment, error) {
+	var comment domain.Comment
+	if err := withContext(ctx, r.db).First(&comment, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("comment not found")
+		}
+		return nil, errors.New("failed to get comment")
+	}
+	return &comment, nil
+}
+
+// ListThreadsPaginated retrieves a manga's top-level comments, newest first, with their replies oldest first
+func (r *commentRepository) ListThreadsPaginated(ctx context.Context, mangaID uint, pagination *domain.PaginationRequest) ([]*domain.Comment, int64, error) {
+	var comments []*domain.Comment
+	var total int64
+
+	query := withContext(ctx, r.db).Model(&domain.Comment{}).Where("manga_id = ? AND parent_id IS NULL", mangaID)
+
+	// Count total threads
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.New("failed to count comments")
+	}
+
+	// Get paginated results
+	offset := pagination.GetOffset()
+	limit := pagination.GetLimit()
+
+	if err := query.
+		Preload("Replies", func(db *gorm.DB) *gorm.DB { return db.Order("created_at, id") }).
+		Order("created_at DESC, id DESC").
+		Offset(offset).Limit(limit).
+		Find(&comments).Error; err != nil {
+		return nil, 0, errors.New("failed to get comments")
+	}
+
+	return comments, total, nil
+}
+
+// Update updates a comment in the database
+func (r *commentRepository) Update(ctx context.Context, comment *domain.Comment) error {
+	if err := withContext(ctx, r.db).Omit("Replies").Save(comment).Error; err != nil {
+		return errors.New("failed to update comment")
+	}
+	return nil
+}
+
+// Delete soft deletes a comment and its replies
+func (r *commentRepository) Delete(ctx context.Context, id uint) error {
+	err := withContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("parent_id = ?", id).Delete(&domain.Comment{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.Comment{}, id).Error
+	})
+	if err != nil {
+		return errors.New("failed to delete comment")
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"gorm.io/gorm"
+)
+
+// revenueStatuses are the statuses of the orders that count as revenue
+var revenueStatuses = []string{domain.OrderStatusPaid, domain.OrderStatusShipped, domain.OrderStatusFulfilled}
+
+// dashboardRepository implements the DashboardRepository interface
+type dashboardRepository struct {
+	db *gorm.DB
+}
+
+// NewDashboardRepository creates a new dashboard repository instance
+func NewDashboardRepository(db *gorm.DB) ports.DashboardRepository {
+	return &dashboardRepository{
+		db: db,
+	}
+}
+
+// orderTotals are the counts and revenue of one order table
+type orderTotals struct {
+	domain.DashboardCounts
+	Revenue         float64
+	RevenueLastDay  float64
+	RevenueLastWeek float64
+}
+
+// Stats counts the users, mangas, orders, revenue and jobs, one query per
+// table. Orders are counted live and archived alike.
+func (r *dashboardRepository) Stats(ctx context.Context, now, day, week time.Time) (*domain.DashboardStats, error) {
+	ctx = asReport(ctx)
+	stats := &domain.DashboardStats{}
+
+	counts := "COUNT(*) AS total, " +
+		"COUNT(CASE WHEN created_at >= ? THEN 1 END) AS last_day, " +
+		"COUNT(CASE WHEN created_at >= ? THEN 1 END) AS last_week"
+
+	if err := withContext(ctx, r.db).Model(&domain.User{}).Select(counts, day, week).Scan(&stats.Users).Error; err != nil {
+		return nil, errors.New("failed to count users")
+	}
+
+	err := withContext(ctx, r.db).Model(&domain.Manga{}).
+		Select(counts+", COUNT(CASE WHEN status = ? THEN 1 END) AS pending_review", day, week, domain.MangaStatusPendingReview).
+		Scan(&stats.Mangas).Error
+	if err != nil {
+		return nil, errors.New("failed to count mangas")
+	}
+
+	orders := counts + ", " +
+		"COALESCE(SUM(CASE WHEN status IN ? THEN total END), 0) AS revenue, " +
+		"COALESCE(SUM(CASE WHEN status IN ? AND created_at >= ? THEN total END), 0) AS revenue_last_day, " +
+		"COALESCE(SUM(CASE WHEN status IN ? AND created_at >= ? THEN total END), 0) AS revenue_last_week"
+	for _, model := range []any{&domain.Order{}, &domain.ArchivedOrder{}} {
+		var totals orderTotals
+		err := withContext(ctx, r.db).Model(model).
+			Select(orders, day, week, revenueStatuses, revenueStatuses, day, revenueStatuses, week).
+			Scan(&totals).Error
+		if err != nil {
+			return nil, errors.New("failed to count orders")
+		}
+		stats.Orders.Total += totals.Total
+		stats.Orders.LastDay += totals.LastDay
+		stats.Orders.LastWeek += totals.LastWeek
+		stats.Revenue.Total += totals.Revenue
+		stats.Revenue.LastDay += totals.RevenueLastDay
+		stats.Revenue.LastWeek += totals.RevenueLastWeek
+	}
+
+	err = withContext(ctx, r.db).Model(&domain.Job{}).
+		Select("COUNT(CASE WHEN status = ? THEN 1 END) AS pending, "+
+			"COUNT(CASE WHEN status = ? AND run_at <= ? THEN 1 END) AS due, "+
+			"COUNT(CASE WHEN status = ? THEN 1 END) AS running, "+
+			"COUNT(CASE WHEN status = ? THEN 1 END) AS dead",
+			domain.JobStatusPending, domain.JobStatusPending, now, domain.JobStatusRunning, domain.JobStatusDead).
+		Where("status IN ?", []string{domain.JobStatusPending, domain.JobStatusRunning, domain.JobStatusDead}).
+		Scan(&stats.Jobs).Error
+	if err != nil {
+		return nil, errors.New("failed to count jobs")
+	}
+
+	if stats.Jobs.Due > 0 {
+		var oldest []time.Time
+		err := withContext(ctx, r.db).Model(&domain.Job{}).
+			Where("status = ? AND run_at <= ?", domain.JobStatusPending, now).
+			Order("run_at").Limit(1).
+			Pluck("run_at", &oldest).Error
+		if err != nil {
+			return nil, errors.New("failed to count jobs")
+		}
+		if len(oldest) > 0 {
+			stats.Jobs.OldestDueAt = &oldest[0]
+		}
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,131 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"gorm.io/gorm"
+)
+
+// digestRepository implements the DigestRepository interface
+type digestRepository struct {
+	db *gorm.DB
+}
+
+// NewDigestRepository creates a new digest repository instance
+func NewDigestRepository(db *gorm.DB) ports.DigestRepository {
+	return &digestRepository{
+		db: db,
+	}
+}
+
+// AddItem stores an item pending for the user's next digest
+func (r *digestRepository) AddItem(ctx context.Context, item *domain.DigestItem) error {
+	if err := withContext(ctx, r.db).Create(item).Error; err != nil {
+		return errors.New("failed to add digest item")
+	}
+	return nil
+}
+
+// digestFrequencyOf is the digest frequency of the user of a digest item;
+// users without stored preferences get daily digests
+const digestFrequencyOf = "COALESCE((SELECT digests FROM notification_preferences WHERE notification_preferences.user_id = digest_items.user_id), '" + domain.DigestsDaily + "')"
+
+// ListDueUsers lists up to limit users getting digests at the frequency who
+// have items pending since before until
+func (r *digestRepository) ListDueUsers(ctx context.Context, frequency string, until time.Time, limit int) ([]uint, error) {
+	var ids []uint
+	err := withContext(ctx, r.db).Model(&domain.DigestItem{}).
+		Distinct("user_id").
+		Where("digest_id IS NULL AND created_at < ?", until).
+		Where(digestFrequencyOf+" = ?", frequency).
+		Order("user_id").
+		Limit(limit).
+		Pluck("user_id", &ids).Error
+	if err != nil {
+		return nil, errors.New("failed to list due digests")
+	}
+	return ids, nil
+}
+
+// DiscardOffItems deletes the pending items of users who turned digests off
+// since they were added
+func (r *digestRepository) DiscardOffItems(ctx context.Context) (int64, error) {
+	result := withContext(ctx, r.db).
+		Where("digest_id IS NULL AND "+digestFrequencyOf+" = ?", domain.DigestsOff).
+		Delete(&domain.DigestItem{})
+	if result.Error != nil {
+		return 0, errors.New("failed to discard digest items")
+	}
+	return result.RowsAffected, nil
+}
+
+// Create stores a new digest, without its items
+func (r *digestRepository) Create(ctx context.Context, digest *domain.Digest) error {
+	if err := withContext(ctx, r.db).Omit("Items").Create(digest).Error; err != nil {
+		return errors.New("failed to create digest")
+	}
+	return nil
+}
+
+// AttachPendingItems puts the user's items pending since before until in the
+// digest. Items already in a digest are left alone, so of the processes
+// making digests, one gets each item.
+func (r *digestRepository) AttachPendingItems(ctx context.Context, digestID, userID uint, until time.Time) (int64, error) {
+	result := withContext(ctx, r.db).Model(&domain.DigestItem{}).
+		Where("user_id = ? AND digest_id IS NULL AND created_at < ?", userID, until).
+		UpdateColumn("digest_id", digestID)
+	if result.Error != nil {
+		return 0, errors.New("failed to attach digest items")
+	}
+	return result.RowsAffected, nil
+}
+
+// GetByID retrieves a digest with its items
+func (r *digestRepository) GetByID(ctx context.Context, id uint) (*domain.Digest, error) {
+	var digest domain.Digest
+	err := withContext(ctx, r.db).
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		Where("id = ?", id).
+		First(&digest).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("digest not found")
+		}
+		return nil, errors.New("failed to get digest")
+	}
+	return &digest, nil
+}
+
+// ListByUser lists a user's digests with their items, newest first
+func (r *digestRepository) ListByUser(ctx context.Context, userID uint, pagination *domain.PaginationRequest) ([]*domain.Digest, int64, error) {
+	var digests []*domain.Digest
+	var total int64
+
+	query := withContext(ctx, r.db).Model(&domain.Digest{}).Where("user_id = ?", userID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.New("failed to count digests")
+	}
+
+	err := query.
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		Order("id DESC").
+		Offset(pagination.GetOffset()).
+		Limit(pagination.GetLimit()).
+		Find(&digests).Error
+	if err != nil {
+		return nil, 0, errors.New("failed to get digests")
+	}
+	return digests, total, nil
+}
+
+// MarkRead records when a digest was read
+func (r *digestRepository) MarkRead(ctx context.Context, id uint, at time.Time) error {
+	if err := withContext(ctx, r.db).Model(&domain.Digest{}).Where("id = ?", id).UpdateColumn("read_at", at).Error; err != nil {
+		return errors.New("failed to mark digest read")
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"gorm.io/gorm"
+)
+
+// discountRepository implements the DiscountRepository interface
+type discountRepository struct {
+	db *gorm.DB
+}
+
+// NewDiscountRepository creates a new discount repository instance
+func NewDiscountRepository(db *gorm.DB) ports.DiscountRepository {
+	return &discountRepository{
+		db: db,
+	}
+}
+
+// withTargets preloads the IDs of the mangas and genres a discount applies to
+func (r *discountRepository) withTargets(ctx context.Context) *gorm.DB {
+	return withContext(ctx, r.db).
+		Preload("Mangas", func(db *gorm.DB) *gorm.DB { return db.Select("mangas.id") }).
+		Preload("Genres")
+}
+
+// Create creates a new discount with its targets
+func (r *discountRepository) Create(ctx context.Context, discount *domain.Discount) error {
+	if err := withContext(ctx, r.db).Omit("Mangas.*", "Genres.*").Create(discount).Error; err != nil {
+		return errors.New("failed to create discount")
+	}
+	return nil
+}
+
+// GetByID retrieves a discount by ID
+func (r *discountRepository) GetByID(ctx context.Context, id uint) (*domain.Discount, error) {
+	var discount domain.Discount
+	if err := r.withTargets(ctx).First(&discount, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("discount not found")
+		}
+		return nil, errors.New("failed to get discount")
+	}
+	return &discount, nil
+}
+
+// List retrieves all discounts, most recent campaigns first
+func (r *discountRepository) List(ctx context.Context) ([]*domain.Discount, error) {
+	var discounts []*domain.Discount
+	if err := r.withTargets(ctx).Order("starts_at DESC").Find(&discounts).Error; err != nil {
+		return nil, errors.New("failed to get discounts")
+	}
+	return discounts, nil
+}
+
+// ListActive retrieves the discounts running at the given time
+func (r *discountRepository) ListActive(ctx context.Context, now time.Time) ([]*domain.Discount, error) {
+	var discounts []*domain.Discount
+	if err := r.withTargets(ctx).Where("starts_at <= ? AND ends_at > ?", now, now).Find(&discounts).Error; err != nil {
+		return nil, errors.New("failed to get active discounts")
+	}
+	return discounts, nil
+}
+
+// Update updates a discount and replaces its targets
+func (r *discountRepository) Update(ctx context.Context, discount *domain.Discount) error {
+	err := withContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Mangas", "Genres").Save(discount).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(discount).Omit("Mangas.*").Association("Mangas").Replace(discount.Mangas); err != nil {
+			return err
+		}
+		return tx.Model(discount).Omit("Genres.*").Association("Genres").Replace(discount.Genres)
+	})
+	if err != nil {
+		return errors.New("failed to update discount")
+	}
+	return nil
+}
+
+// Delete deletes a discount and its targets
+func (r *discountRepository) Delete(ctx context.Context, id uint) error {
+	err := withContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM discount_mangas WHERE discount_id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM discount_genres WHERE discount_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.Discount{}, id).Error
+	})
+	if err != nil {
+		return errors.New("failed to delete discount")
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"gorm.io/gorm"
+)
+
+// genreRepository implements the GenreRepository interface
+type genreRepository struct {
+	db *gorm.DB
+}
+
+// NewGenreRepository creates a new genre repository instance
+func NewGenreRepository(db *gorm.DB) ports.GenreRepository {
+	return &genreRepository{
+		db: db,
+	}
+}
+
+// Create creates a new genre in the database
+func (r *genreRepository) Create(ctx context.Context, genre *domain.Genre) error {
+	if err := withContext(ctx, r.db).Create(genre).Error; err != nil {
+		return errors.New("failed to create genre")
+	}
+	return nil
+}
+
+// GetByID retrieves a genre by ID
+func (r *genreRepository) GetByID(ctx context.Context, id uint) (*domain.Genre, error) {
+	var genre domain.Genre
+	if err := withContext(ctx, r.db).First(&genre, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("genre not found")
+		}
+		return nil, errors.New("failed to get genre")
+	}
+	return &genre, nil
+}
+
+// GetBySlug retrieves a genre by slug
+func (r *genreRepository) GetBySlug(ctx context.Context, slug string) (*domain.Genre, error) {
+	var genre domain.Genre
+	if err := withContext(ctx, r.db).Where("slug = ?", slug).First(&genre).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("genre not found")
+		}
+		return nil, errors.New("failed to get genre")
+	}
+	return &genre, nil
+}
+
+// GetByIDs retrieves all genres with the given IDs
+func (r *genreRepository) GetByIDs(ctx context.Context, ids []uint) ([]*domain.Genre, error) {
+	var genres []*domain.Genre
+	if len(ids) == 0 {
+		return genres, nil
+	}
+	if err := withContext(ctx, r.db).Where("id IN ?", ids).Find(&genres).Error; err != nil {
+		return nil, errors.New("failed to get genres")
+	}
+	return genres, nil
+}
+
+// ListWithCounts retrieves all genres with the number of (non-deleted) mangas in each
+func (r *genreRepository) ListWithCounts(ctx context.Context) ([]*domain.GenreCount, error) {
+	ctx = asReport(ctx)
+	var genres []*domain.GenreCount
+	if err := withContext(ctx, r.db).Model(&domain.Genre{}).
+		Select("genres.id, genres.name, genres.slug, COUNT(mangas.id) AS manga_count").
+		Joins("LEFT JOIN manga_genres ON manga_genres.genre_id = genres.id").
+		Joins("LEFT JOIN mangas ON mangas.id = manga_genres.manga_id AND mangas.deleted_at IS NULL").
+		Group("genres.id").
+		Order("genres.name").
+		Scan(&genres).Error; err != nil {
+		return nil, errors.New("failed to get genres")
+	}
+	return genres, nil
+}
+
+// Update updates a genre in the database
+func (r *genreRepository) Update(ctx context.Context, genre *domain.Genre) error {
+	if err := withContext(ctx, r.db).Save(genre).Error; err != nil {
+		return errors.New("failed to update genre")
+	}
+	return nil
+}
+
+// Delete deletes a genre and its manga and discount assignments
+func (r *genreRepository) Delete(ctx context.Context, id uint) error {
+	err := withContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM manga_genres WHERE genre_id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM discount_genres WHERE genre_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.Genre{}, id).Error
+	})
+	if err != nil {
+		return errors.New("failed to delete genre")
+	}
+	return nil
+}
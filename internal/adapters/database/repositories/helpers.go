@@ -0,0 +1,73 @@
+package repositories
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// likeEscaper escapes LIKE wildcards so user input is matched literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// escapeLike escapes a string for use inside a LIKE pattern
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+// isSQLite reports whether db runs on SQLite rather than Postgres
+func isSQLite(db *gorm.DB) bool {
+	return db.Dialector.Name() == "sqlite"
+}
+
+// isDuplicateKey reports whether err is a unique constraint violation, as
+// the dialect of db reports it
+func isDuplicateKey(db *gorm.DB, err error) bool {
+	if translator, ok := db.Dialector.(gorm.ErrorTranslator); ok {
+		err = translator.Translate(err)
+	}
+	return errors.Is(err, gorm.ErrDuplicatedKey)
+}
+
+// like returns a condition matching expr against an escaped LIKE pattern.
+// Postgres treats backslashes as the escape character by default, while
+// SQLite needs an explicit ESCAPE clause.
+func like(db *gorm.DB, expr string) string {
+	if isSQLite(db) {
+		return expr + ` LIKE ? ESCAPE '\'`
+	}
+	return expr + " LIKE ?"
+}
+
+// iLike is like, ignoring case; SQLite's LIKE already does
+func iLike(db *gorm.DB, expr string) string {
+	if isSQLite(db) {
+		return like(db, expr)
+	}
+	return expr + " ILIKE ?"
+}
+
+// daysSince returns an SQL expression for the whole days from the date bound to
+// its placeholder to column
+func daysSince(db *gorm.DB, column string) string {
+	if isSQLite(db) {
+		return "CAST(julianday(date(" + column + ")) - julianday(date(?)) AS INTEGER)"
+	}
+	return "(" + column + " - ?::date)"
+}
+
+// applySort orders the query by whitelisted sort fields, then by primary key
+// unless it was sorted on, so ties keep a stable order across pages
+func applySort(db *gorm.DB, sort domain.Sort) *gorm.DB {
+	byID := false
+	for _, field := range sort {
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: field.Field}, Desc: field.Desc})
+		byID = byID || field.Field == "id"
+	}
+	if !byID {
+		db = db.Order("id")
+	}
+	return db
+}
@@ -0,0 +1,281 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// jobRepository implements the JobRepository interface
+type jobRepository struct {
+	db *gorm.DB
+}
+
+// NewJobRepository creates a new job repository instance
+func NewJobRepository(db *gorm.DB) ports.JobRepository {
+	return &jobRepository{
+		db: db,
+	}
+}
+
+// Create stores a job, joining the transaction carried by ctx
+func (r *jobRepository) Create(ctx context.Context, job *domain.Job) error {
+	if err := withContext(ctx, r.db).Create(job).Error; err != nil {
+		return errors.New("failed to enqueue job")
+	}
+	return nil
+}
+
+// Claim locks the due jobs and marks them running in one transaction. Due
+// jobs are pending ones whose run time has come and running ones whose worker
+// let the lock expire. Postgres skips rows another worker has locked, so
+// concurrent workers never claim the same job.
+func (r *jobRepository) Claim(ctx context.Context, kinds []string, limit int, lockedUntil time.Time) ([]*domain.Job, error) {
+	var jobs []*domain.Job
+	now := time.Now()
+	err := withContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		query := tx.Where("kind IN ?", kinds).
+			Where("(status = ? AND run_at <= ?) OR (status = ? AND locked_until < ?)",
+				domain.JobStatusPending, now, domain.JobStatusRunning, now).
+			Order("run_at, id").
+			Limit(limit)
+		if !isSQLite(tx) {
+			query = query.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"})
+		}
+		if err := query.Find(&jobs).Error; err != nil {
+			return err
+		}
+		if len(jobs) == 0 {
+			return nil
+		}
+
+		ids := make([]uint, len(jobs))
+		for i, job := range jobs {
+			ids[i] = job.ID
+			job.Status = domain.JobStatusRunning
+			job.Attempts++
+			job.LockedUntil = &lockedUntil
+		}
+		return tx.Model(&domain.Job{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+			"status":       domain.JobStatusRunning,
+			"attempts":     gorm.Expr("attempts + 1"),
+			"locked_until": lockedUntil,
+		}).Error
+	})
+	if err != nil {
+		return nil, errors.New("failed to claim jobs")
+	}
+	return jobs, nil
+}
+
+// Update saves the job's status and results
+func (r *jobRepository) Update(ctx context.Context, job *domain.Job) error {
+	if err := withContext(ctx, r.db).Save(job).Error; err != nil {
+		return errors.New("failed to update job")
+	}
+	return nil
+}
+
+// UpdateRunning saves the outcome of a job only while it is still running,
+// so a job an admin cancelled stays cancelled
+func (r *jobRepository) UpdateRunning(ctx context.Context, job *domain.Job) (bool, error) {
+	result := withContext(ctx, r.db).Model(job).
+		Where("status = ?", domain.JobStatusRunning).
+		Select("*").
+		Updates(job)
+	if result.Error != nil {
+		return false, errors.New("failed to update job")
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// UpdateProgress saves the progress of a job alone, leaving its lock and
+// status to the worker running it
+func (r *jobRepository) UpdateProgress(ctx context.Context, id uint, progress int) error {
+	err := withContext(ctx, r.db).Model(&domain.Job{}).
+		Where("id = ?", id).
+		UpdateColumn("progress", progress).Error
+	if err != nil {
+		return errors.New("failed to update job progress")
+	}
+	return nil
+}
+
+// GetByID retrieves a job by ID
+func (r *jobRepository) GetByID(ctx context.Context, id uint) (*domain.Job, error) {
+	var job domain.Job
+	if err := withContext(ctx, r.db).First(&job, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("job not found")
+		}
+		return nil, errors.New("failed to get job")
+	}
+	return &job, nil
+}
+
+// GetStatus retrieves the status of a job by ID
+func (r *jobRepository) GetStatus(ctx context.Context, id uint) (string, error) {
+	var statuses []string
+	if err := withContext(ctx, r.db).Model(&domain.Job{}).Where("id = ?", id).Limit(1).Pluck("status", &statuses).Error; err != nil {
+		return "", errors.New("failed to get job status")
+	}
+	if len(statuses) == 0 {
+		return "", errors.New("job not found")
+	}
+	return statuses[0], nil
+}
+
+// GetWithErrorLog retrieves a job by ID with the errors of its failed attempts
+func (r *jobRepository) GetWithErrorLog(ctx context.Context, id uint) (*domain.Job, error) {
+	var job domain.Job
+	err := withContext(ctx, r.db).
+		Preload("ErrorLog", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		First(&job, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("job not found")
+		}
+		return nil, errors.New("failed to get job")
+	}
+	return &job, nil
+}
+
+// ListPaginated retrieves jobs, newest first, optionally of some statuses and one kind only
+func (r *jobRepository) ListPaginated(ctx context.Context, statuses []string, kind string, pagination *domain.PaginationRequest) ([]*domain.Job, int64, error) {
+	var jobs []*domain.Job
+	var total int64
+
+	query := withContext(ctx, r.db).Model(&domain.Job{})
+	if len(statuses) > 0 {
+		query = query.Where("status IN ?", statuses)
+	}
+	if kind != "" {
+		query = query.Where("kind = ?", kind)
+	}
+
+	// Count total jobs
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.New("failed to count jobs")
+	}
+
+	// Get paginated results
+	offset := pagination.GetOffset()
+	limit := pagination.GetLimit()
+
+	if err := query.Order("id DESC").Offset(offset).Limit(limit).Find(&jobs).Error; err != nil {
+		return nil, 0, errors.New("failed to get jobs")
+	}
+
+	return jobs, total, nil
+}
+
+// CreateError records the error of a failed attempt at a job
+func (r *jobRepository) CreateError(ctx context.Context, jobErr *domain.JobError) error {
+	if err := withContext(ctx, r.db).Create(jobErr).Error; err != nil {
+		return errors.New("failed to record job error")
+	}
+	return nil
+}
+
+// deadJobs selects the dead jobs, of one kind or all
+func deadJobs(db *gorm.DB, kind string) *gorm.DB {
+	query := db.Model(&domain.Job{}).Where("status = ?", domain.JobStatusDead)
+	if kind != "" {
+		query = query.Where("kind = ?", kind)
+	}
+	return query
+}
+
+// RequeueDead makes the dead jobs, of one kind or all, pending to run right
+// away with a fresh set of attempts; their error logs are kept
+func (r *jobRepository) RequeueDead(ctx context.Context, kind string) (int64, error) {
+	result := deadJobs(withContext(ctx, r.db), kind).Updates(map[string]interface{}{
+		"status":      domain.JobStatusPending,
+		"attempts":    0,
+		"run_at":      time.Now(),
+		"finished_at": nil,
+	})
+	if result.Error != nil {
+		return 0, errors.New("failed to requeue dead jobs")
+	}
+	return result.RowsAffected, nil
+}
+
+// Cancel marks a pending or running job cancelled. The worker running it
+// notices and stops its handler.
+func (r *jobRepository) Cancel(ctx context.Context, id uint) error {
+	now := time.Now()
+	result := withContext(ctx, r.db).Model(&domain.Job{}).
+		Where("id = ? AND status IN ?", id, []string{domain.JobStatusPending, domain.JobStatusRunning}).
+		Updates(map[string]interface{}{
+			"status":       domain.JobStatusCancelled,
+			"locked_until": nil,
+			"finished_at":  now,
+			"updated_at":   now,
+		})
+	if result.Error != nil {
+		return errors.New("failed to cancel job")
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("only pending or running jobs can be cancelled")
+	}
+	return nil
+}
+
+// deleteJobs deletes the jobs query selects together with their error logs,
+// in one transaction
+func (r *jobRepository) deleteJobs(ctx context.Context, query func(tx *gorm.DB) *gorm.DB) (int64, error) {
+	var deleted int64
+	err := withContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("job_id IN (?)", query(tx).Select("id")).Delete(&domain.JobError{}).Error; err != nil {
+			return err
+		}
+		result := tx.Where("id IN (?)", query(tx).Select("id")).Delete(&domain.Job{})
+		deleted = result.RowsAffected
+		return result.Error
+	})
+	return deleted, err
+}
+
+// DeleteDead deletes a dead job with its error log
+func (r *jobRepository) DeleteDead(ctx context.Context, id uint) error {
+	deleted, err := r.deleteJobs(ctx, func(tx *gorm.DB) *gorm.DB {
+		return deadJobs(tx, "").Where("id = ?", id)
+	})
+	if err != nil {
+		return errors.New("failed to delete job")
+	}
+	if deleted == 0 {
+		return errors.New("only dead jobs can be discarded")
+	}
+	return nil
+}
+
+// DeleteAllDead deletes the dead jobs, of one kind or all, with their error logs
+func (r *jobRepository) DeleteAllDead(ctx context.Context, kind string) (int64, error) {
+	deleted, err := r.deleteJobs(ctx, func(tx *gorm.DB) *gorm.DB {
+		return deadJobs(tx, kind)
+	})
+	if err != nil {
+		return 0, errors.New("failed to delete dead jobs")
+	}
+	return deleted, nil
+}
+
+// DeleteFinishedBefore deletes the jobs that succeeded or were cancelled
+// before the given time with their error logs
+func (r *jobRepository) DeleteFinishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	deleted, err := r.deleteJobs(ctx, func(tx *gorm.DB) *gorm.DB {
+		return tx.Model(&domain.Job{}).
+			Where("status IN ? AND finished_at < ?", []string{domain.JobStatusSucceeded, domain.JobStatusCancelled}, before)
+	})
+	if err != nil {
+		return 0, errors.New("failed to delete finished jobs")
+	}
+	return deleted, nil
+}
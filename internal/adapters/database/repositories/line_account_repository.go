@@ -0,0 +1,120 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// lineAccountRepository implements the LineAccountRepository interface
+type lineAccountRepository struct {
+	db *gorm.DB
+}
+
+// NewLineAccountRepository creates a new LINE account repository instance
+func NewLineAccountRepository(db *gorm.DB) ports.LineAccountRepository {
+	return &lineAccountRepository{
+		db: db,
+	}
+}
+
+// GetByUserID retrieves the LINE account link of a user
+func (r *lineAccountRepository) GetByUserID(ctx context.Context, userID uint) (*domain.LineAccount, error) {
+	var account domain.LineAccount
+	if err := withContext(ctx, r.db).Where("user_id = ?", userID).First(&account).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("LINE account not found")
+		}
+		return nil, errors.New("failed to get LINE account")
+	}
+	return &account, nil
+}
+
+// GetByLineUserID retrieves the link of a LINE user
+func (r *lineAccountRepository) GetByLineUserID(ctx context.Context, lineUserID string) (*domain.LineAccount, error) {
+	var account domain.LineAccount
+	if err := withContext(ctx, r.db).Where("line_user_id = ?", lineUserID).First(&account).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("LINE account not found")
+		}
+		return nil, errors.New("failed to get LINE account")
+	}
+	return &account, nil
+}
+
+// Save stores a user's LINE account link, replacing any stored before
+func (r *lineAccountRepository) Save(ctx context.Context, account *domain.LineAccount) error {
+	account.UpdatedAt = time.Now()
+	err := withContext(ctx, r.db).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "user_id"}}, UpdateAll: true}).
+		Create(account).Error
+	if err != nil {
+		return errors.New("failed to save LINE account")
+	}
+	return nil
+}
+
+// Link sets the LINE user ID of the link in progress with the nonce, in one
+// transaction with taking it off any other user, since a LINE user may only
+// be linked once
+func (r *lineAccountRepository) Link(ctx context.Context, nonce, lineUserID string) (*domain.LineAccount, error) {
+	var account domain.LineAccount
+	err := withContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		query := tx.Where("nonce = ? AND nonce_expires_at > ?", nonce, now)
+		if !isSQLite(tx) {
+			query = query.Clauses(clause.Locking{Strength: "UPDATE"})
+		}
+		if err := query.First(&account).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("LINE link not found or expired")
+			}
+			return err
+		}
+
+		err := tx.Model(&domain.LineAccount{}).
+			Where("line_user_id = ? AND user_id <> ?", lineUserID, account.UserID).
+			Updates(map[string]interface{}{"line_user_id": nil, "linked_at": nil, "updated_at": now}).Error
+		if err != nil {
+			return err
+		}
+
+		account.LineUserID = &lineUserID
+		account.LinkedAt = &now
+		account.Nonce = ""
+		account.NonceExpiresAt = nil
+		return tx.Save(&account).Error
+	})
+	if err != nil {
+		if err.Error() == "LINE link not found or expired" {
+			return nil, err
+		}
+		return nil, errors.New("failed to link LINE account")
+	}
+	return &account, nil
+}
+
+// Delete removes a user's LINE account link
+func (r *lineAccountRepository) Delete(ctx context.Context, userID uint) error {
+	result := withContext(ctx, r.db).Where("user_id = ?", userID).Delete(&domain.LineAccount{})
+	if result.Error != nil {
+		return errors.New("failed to delete LINE account")
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("LINE account not found")
+	}
+	return nil
+}
+
+// DeleteByLineUserID removes the link of a LINE user, if there is one
+func (r *lineAccountRepository) DeleteByLineUserID(ctx context.Context, lineUserID string) error {
+	if err := withContext(ctx, r.db).Where("line_user_id = ?", lineUserID).Delete(&domain.LineAccount{}).Error; err != nil {
+		return errors.New("failed to delete LINE account")
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"gorm.io/gorm"
+)
+
+// mangaImageRepository implements the MangaImageRepository interface
+type mangaImageRepository struct {
+	db *gorm.DB
+}
+
+// NewMangaImageRepository creates a new manga image repository instance
+func NewMangaImageRepository(db *gorm.DB) ports.MangaImageRepository {
+	return &mangaImageRepository{
+		db: db,
+	}
+}
+
+// orderedImages sorts gallery images by position
+func orderedImages(db *gorm.DB) *gorm.DB {
+	return db.Order("position, id")
+}
+
+// Create adds an image after the last one in the manga's gallery
+func (r *mangaImageRepository) Create(ctx context.Context, image *domain.MangaImage) error {
+	err := withContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var last int
+		if err := tx.Model(&domain.MangaImage{}).Where("manga_id = ?", image.MangaID).
+			Select("COALESCE(MAX(position), 0)").Scan(&last).Error; err != nil {
+			return err
+		}
+		image.Position = last + 1
+		return tx.Create(image).Error
+	})
+	if err != nil {
+		return errors.New("failed to create manga image")
+	}
+	return nil
+}
+
+// GetByID retrieves a manga image by ID
+func (r *mangaImageRepository) GetByID(ctx context.Context, id uint) (*domain.MangaImage, error) {
+	var image domain.MangaImage
+	if err := withContext(ctx, r.db).First(&image, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("manga image not found")
+		}
+		return nil, errors.New("failed to get manga image")
+	}
+	return &image, nil
+}
+
+// ListByMangaID retrieves a manga's gallery in order
+func (r *mangaImageRepository) ListByMangaID(ctx context.Context, mangaID uint) ([]*domain.MangaImage, error) {
+	var images []*domain.MangaImage
+	if err := orderedImages(withContext(ctx, r.db)).Where("manga_id = ?", mangaID).Find(&images).Error; err != nil {
+		return nil, errors.New("failed to get manga images")
+	}
+	return images, nil
+}
+
+// CountByMangaID counts the images in a manga's gallery
+func (r *mangaImageRepository) CountByMangaID(ctx context.Context, mangaID uint) (int64, error) {
+	var count int64
+	if err := withContext(ctx, r.db).Model(&domain.MangaImage{}).Where("manga_id = ?", mangaID).Count(&count).Error; err != nil {
+		return 0, errors.New("failed to count manga images")
+	}
+	return count, nil
+}
+
+// Update updates a manga image in the database
+func (r *mangaImageRepository) Update(ctx context.Context, image *domain.MangaImage) error {
+	if err := withContext(ctx, r.db).Save(image).Error; err != nil {
+		return errors.New("failed to update manga image")
+	}
+	return nil
+}
+
+// SetVariants saves the size, variants and thumbnail URL of a processed image,
+// leaving a deleted image deleted
+func (r *mangaImageRepository) SetVariants(ctx context.Context, image *domain.MangaImage) error {
+	err := withContext(ctx, r.db).Model(&domain.MangaImage{}).Where("id = ?", image.ID).
+		Select("thumbnail_url", "width", "height", "variants").Updates(image).Error
+	if err != nil {
+		return errors.New("failed to update manga image")
+	}
+	return nil
+}
+
+// Delete deletes a manga image from the database
+func (r *mangaImageRepository) Delete(ctx context.Context, id uint) error {
+	if err := withContext(ctx, r.db).Delete(&domain.MangaImage{}, id).Error; err != nil {
+		return errors.New("failed to delete manga image")
+	}
+	return nil
+}
+
+// Reorder sets each image's position to its place in imageIDs
+func (r *mangaImageRepository) Reorder(ctx context.Context, mangaID uint, imageIDs []uint) error {
+	err := withContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		for i, imageID := range imageIDs {
+			if err := tx.Model(&domain.MangaImage{}).Where("id = ? AND manga_id = ?", imageID, mangaID).
+				UpdateColumn("position", i+1).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.New("failed to reorder manga images")
+	}
+	return nil
+}
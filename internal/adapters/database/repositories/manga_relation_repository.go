@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"gorm.io/gorm"
+)
+
+// mangaRelationRepository implements the MangaRelationRepository interface
+type mangaRelationRepository struct {
+	db *gorm.DB
+}
+
+// NewMangaRelationRepository creates a new manga relation repository instance
+func NewMangaRelationRepository(db *gorm.DB) ports.MangaRelationRepository {
+	return &mangaRelationRepository{
+		db: db,
+	}
+}
+
+// Create stores a relation together with its inverse
+func (r *mangaRelationRepository) Create(ctx context.Context, relation *domain.MangaRelation) error {
+	inverse := &domain.MangaRelation{
+		MangaID:   relation.RelatedID,
+		RelatedID: relation.MangaID,
+		Type:      domain.InverseMangaRelation(relation.Type),
+		CreatedBy: relation.CreatedBy,
+	}
+	err := withContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(relation).Error; err != nil {
+			return err
+		}
+		return tx.Create(inverse).Error
+	})
+	if err != nil {
+		return errors.New("failed to create manga relation")
+	}
+	return nil
+}
+
+// GetByPair retrieves the relation from one manga to another
+func (r *mangaRelationRepository) GetByPair(ctx context.Context, mangaID, relatedID uint) (*domain.MangaRelation, error) {
+	var relation domain.MangaRelation
+	if err := withContext(ctx, r.db).Where("manga_id = ? AND related_id = ?", mangaID, relatedID).First(&relation).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("manga relation not found")
+		}
+		return nil, errors.New("failed to get manga relation")
+	}
+	return &relation, nil
+}
+
+// ListByMangaID retrieves a manga's relations to published mangas, with the related manga loaded
+func (r *mangaRelationRepository) ListByMangaID(ctx context.Context, mangaID uint) ([]*domain.MangaRelation, error) {
+	var relations []*domain.MangaRelation
+	err := withContext(ctx, r.db).
+		Joins("JOIN mangas ON mangas.id = manga_relations.related_id AND mangas.deleted_at IS NULL").
+		Where("manga_relations.manga_id = ? AND mangas.status = ?", mangaID, domain.MangaStatusPublished).
+		Order("manga_relations.type, manga_relations.related_id").
+		Preload("Related").
+		Find(&relations).Error
+	if err != nil {
+		return nil, errors.New("failed to get manga relations")
+	}
+	return relations, nil
+}
+
+// ListRelatedIDs retrieves the IDs of mangas linked from a manga with the given type
+func (r *mangaRelationRepository) ListRelatedIDs(ctx context.Context, mangaID uint, relationType string) ([]uint, error) {
+	var ids []uint
+	if err := withContext(ctx, r.db).Model(&domain.MangaRelation{}).
+		Where("manga_id = ? AND type = ?", mangaID, relationType).
+		Pluck("related_id", &ids).Error; err != nil {
+		return nil, errors.New("failed to get manga relations")
+	}
+	return ids, nil
+}
+
+// Delete removes a relation in both directions
+func (r *mangaRelationRepository) Delete(ctx context.Context, mangaID, relatedID uint) error {
+	err := withContext(ctx, r.db).
+		Where("(manga_id = ? AND related_id = ?) OR (manga_id = ? AND related_id = ?)", mangaID, relatedID, relatedID, mangaID).
+		Delete(&domain.MangaRelation{}).Error
+	if err != nil {
+		return errors.New("failed to delete manga relation")
+	}
+	return nil
+}
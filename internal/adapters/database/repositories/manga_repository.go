@@ -1,11 +1,14 @@
 package repositories
 
 import (
+	"context"
 	"errors"
+	"time"
 
 	"github.com/thitiphongD/my-backend/internal/core/domain"
 	"github.com/thitiphongD/my-backend/internal/core/ports"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // mangaRepository implements the MangaRepository interface
@@ -20,18 +23,46 @@ func NewMangaRepository(db *gorm.DB) ports.MangaRepository {
 	}
 }
 
+// mangaUpdateOmits lists the manga fields that have their own update paths and
+// must never be overwritten by a full save of a possibly stale manga
+var mangaUpdateOmits = []string{
+	"Genres", "Status", "RejectionReason", "StockQuantity", "ViewCount",
+	"AverageRating", "ReviewCount", "SeriesID", "VolumeNumber", "Images",
+	"Translations",
+}
+
+// visible scopes queries to published mangas whose owner is not currently suspended
+func (r *mangaRepository) visible(ctx context.Context) *gorm.DB {
+	suspended := r.db.Model(&domain.User{}).
+		Select("id").
+		Where("suspended_at IS NOT NULL AND (suspended_until IS NULL OR suspended_until > ?)", time.Now())
+	return withContext(ctx, r.db).Where("mangas.status = ? AND user_created NOT IN (?)", domain.MangaStatusPublished, suspended)
+}
+
 // Create creates a new manga in the database
-func (r *mangaRepository) Create(manga *domain.Manga) error {
-	if err := r.db.Create(manga).Error; err != nil {
+func (r *mangaRepository) Create(ctx context.Context, manga *domain.Manga) error {
+	if err := withContext(ctx, r.db).Create(manga).Error; err != nil {
 		return errors.New("failed to create manga")
 	}
 	return nil
 }
 
+// CreateInBatches creates mangas batchSize at a time with their genres and
+// reports the ones that could not be created; the others get their IDs set
+func (r *mangaRepository) CreateInBatches(ctx context.Context, mangas []*domain.Manga, batchSize int) []domain.BatchCreateFailure {
+	return createInBatches(ctx, r.db, mangas, batchSize, func(m *domain.Manga) { m.ID = 0 }, "failed to create manga")
+}
+
 // GetByID retrieves a manga by ID
-func (r *mangaRepository) GetByID(id uint) (*domain.Manga, error) {
+func (r *mangaRepository) GetByID(ctx context.Context, id uint) (*domain.Manga, error) {
+	return r.GetByIDIncluding(ctx, id, nil)
+}
+
+// GetByIDIncluding retrieves a manga by ID along with the requested relations
+func (r *mangaRepository) GetByIDIncluding(ctx context.Context, id uint, include domain.Includes) (*domain.Manga, error) {
 	var manga domain.Manga
-	if err := r.db.First(&manga, id).Error; err != nil {
+	query := withContext(ctx, r.db).Preload("Genres").Preload("Images", orderedImages).Preload("Translations")
+	if err := preloadIncludes(query, include).First(&manga, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New("manga not found")
 		}
@@ -40,65 +71,79 @@ func (r *mangaRepository) GetByID(id uint) (*domain.Manga, error) {
 	return &manga, nil
 }
 
-// GetByUserID retrieves mangas by user ID
-func (r *mangaRepository) GetByUserID(userID uint) ([]*domain.Manga, error) {
-	var mangas []*domain.Manga
-	if err := r.db.Where("user_created = ?", userID).Find(&mangas).Error; err != nil {
-		return nil, errors.New("failed to get user mangas")
+// preloadIncludes preloads the requested manga relations. The creator is
+// loaded in one query per page, with only the columns of its public profile.
+func preloadIncludes(db *gorm.DB, include domain.Includes) *gorm.DB {
+	if include.Has("creator") {
+		db = db.Preload("Owner", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "name", "avatar_url", "created_at")
+		})
 	}
-	return mangas, nil
+	return db
 }
 
-// List retrieves all mangas from the database
-func (r *mangaRepository) List() ([]*domain.Manga, error) {
+// GetByTeamID retrieves mangas owned by a team in any status
+func (r *mangaRepository) GetByTeamID(ctx context.Context, teamID uint, sort domain.Sort) ([]*domain.Manga, error) {
 	var mangas []*domain.Manga
-	if err := r.db.Find(&mangas).Error; err != nil {
-		return nil, errors.New("failed to get mangas")
+	if err := applySort(withContext(ctx, r.db).Where("team_id = ?", teamID), sort).Preload("Genres").Find(&mangas).Error; err != nil {
+		return nil, errors.New("failed to get team mangas")
 	}
 	return mangas, nil
 }
 
-// Update updates a manga in the database
-func (r *mangaRepository) Update(manga *domain.Manga) error {
-	if err := r.db.Save(manga).Error; err != nil {
+// Update updates a manga in the database. Status, stock, view and review counters
+// are maintained by their own atomic updates and are never overwritten here.
+func (r *mangaRepository) Update(ctx context.Context, manga *domain.Manga) error {
+	if err := withContext(ctx, r.db).Omit(mangaUpdateOmits...).Save(manga).Error; err != nil {
 		return errors.New("failed to update manga")
 	}
 	return nil
 }
 
-// Delete soft deletes a manga from the database
-func (r *mangaRepository) Delete(id uint) error {
-	if err := r.db.Delete(&domain.Manga{}, id).Error; err != nil {
-		return errors.New("failed to delete manga")
+// UpdateIfUnchanged saves a manga like Update, in one conditional update, so
+// of two writers that read the same version only the first succeeds
+func (r *mangaRepository) UpdateIfUnchanged(ctx context.Context, manga *domain.Manga, version time.Time) error {
+	result := withContext(ctx, r.db).Model(manga).Where("updated_at = ?", version).
+		Select("*").Omit(append([]string{"ID", "CreatedAt"}, mangaUpdateOmits...)...).
+		Updates(manga)
+	if result.Error != nil {
+		return errors.New("failed to update manga")
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrPreconditionFailed
 	}
 	return nil
 }
 
-// GetActiveMangas retrieves all active mangas
-func (r *mangaRepository) GetActiveMangas() ([]*domain.Manga, error) {
-	var mangas []*domain.Manga
-	if err := r.db.Where("is_active = ?", true).Find(&mangas).Error; err != nil {
-		return nil, errors.New("failed to get active mangas")
+// ReplaceGenres replaces the genres assigned to a manga
+func (r *mangaRepository) ReplaceGenres(ctx context.Context, manga *domain.Manga, genres []*domain.Genre) error {
+	if err := withContext(ctx, r.db).Model(manga).Association("Genres").Replace(genres); err != nil {
+		return errors.New("failed to update manga genres")
 	}
-	return mangas, nil
+	return nil
 }
 
-// GetMangasByPriceRange retrieves mangas within price range
-func (r *mangaRepository) GetMangasByPriceRange(min, max float64) ([]*domain.Manga, error) {
-	var mangas []*domain.Manga
-	if err := r.db.Where("price BETWEEN ? AND ?", min, max).Find(&mangas).Error; err != nil {
-		return nil, errors.New("failed to get mangas by price range")
+// UpdateStatus moves a manga to a new status only if its current status is one of from
+func (r *mangaRepository) UpdateStatus(ctx context.Context, id uint, from []string, to string, reason string) error {
+	result := withContext(ctx, r.db).Model(&domain.Manga{}).
+		Where("id = ? AND status IN ?", id, from).
+		Updates(map[string]interface{}{"status": to, "rejection_reason": reason})
+	if result.Error != nil {
+		return errors.New("failed to update manga status")
 	}
-	return mangas, nil
+	if result.RowsAffected == 0 {
+		return domain.ErrInvalidTransition
+	}
+	return nil
 }
 
-// ListPaginated retrieves mangas with pagination
-func (r *mangaRepository) ListPaginated(pagination *domain.PaginationRequest) ([]*domain.Manga, int64, error) {
+// GetByStatusPaginated retrieves mangas in a status, oldest update first, with pagination
+func (r *mangaRepository) GetByStatusPaginated(ctx context.Context, status string, pagination *domain.PaginationRequest) ([]*domain.Manga, int64, error) {
 	var mangas []*domain.Manga
 	var total int64
 
-	// Count total records
-	if err := r.db.Model(&domain.Manga{}).Count(&total).Error; err != nil {
+	// Count total mangas
+	if err := withContext(ctx, r.db).Model(&domain.Manga{}).Where("status = ?", status).Count(&total).Error; err != nil {
 		return nil, 0, errors.New("failed to count mangas")
 	}
 
@@ -106,72 +151,506 @@ func (r *mangaRepository) ListPaginated(pagination *domain.PaginationRequest) ([
 	offset := pagination.GetOffset()
 	limit := pagination.GetLimit()
 
-	if err := r.db.Offset(offset).Limit(limit).Find(&mangas).Error; err != nil {
-		return nil, 0, errors.New("failed to get paginated mangas")
+	if err := withContext(ctx, r.db).Where("status = ?", status).Order("updated_at, id").Offset(offset).Limit(limit).Preload("Genres").Find(&mangas).Error; err != nil {
+		return nil, 0, errors.New("failed to get mangas")
 	}
 
 	return mangas, total, nil
 }
 
-// GetActiveMangasPaginated retrieves active mangas with pagination
-func (r *mangaRepository) GetActiveMangasPaginated(pagination *domain.PaginationRequest) ([]*domain.Manga, int64, error) {
+// GetOwnedByUser retrieves a user's own mangas in any status, or in the given status
+func (r *mangaRepository) GetOwnedByUser(ctx context.Context, userID uint, status string, sort domain.Sort) ([]*domain.Manga, error) {
+	query := withContext(ctx, r.db).Where("user_created = ?", userID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var mangas []*domain.Manga
+	if err := applySort(query, sort).Preload("Genres").Find(&mangas).Error; err != nil {
+		return nil, errors.New("failed to get user mangas")
+	}
+	return mangas, nil
+}
+
+// FindByNamePrefix retrieves duplicate candidates by normalized name prefix.
+// The expression matches the idx_mangas_name_normalized index on Postgres.
+func (r *mangaRepository) FindByNamePrefix(ctx context.Context, prefix string, userID uint, limit int) ([]*domain.Manga, error) {
+	var mangas []*domain.Manga
+	db := withContext(ctx, r.db)
+	err := db.
+		Where("(user_created = ? OR status = ?)", userID, domain.MangaStatusPublished).
+		Where(like(db, "regexp_replace(lower(name), '[^[:alnum:]]', '', 'g')"), escapeLike(prefix)+"%").
+		Order("id").
+		Limit(limit).
+		Find(&mangas).Error
+	if err != nil {
+		return nil, errors.New("failed to search mangas")
+	}
+	return mangas, nil
+}
+
+// AdjustStock atomically changes a manga's stock by delta, refusing to go below zero
+func (r *mangaRepository) AdjustStock(ctx context.Context, id uint, delta int) error {
+	result := withContext(ctx, r.db).Model(&domain.Manga{}).
+		Where("id = ? AND stock_quantity + ? >= 0", id, delta).
+		UpdateColumn("stock_quantity", gorm.Expr("stock_quantity + ?", delta))
+	if result.Error != nil {
+		return errors.New("failed to adjust stock")
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrInsufficientStock
+	}
+	return nil
+}
+
+// DecrementStock atomically removes quantity from a manga's stock, failing when not enough is left
+func (r *mangaRepository) DecrementStock(ctx context.Context, id uint, quantity int) error {
+	return r.AdjustStock(ctx, id, -quantity)
+}
+
+// GetLowStock retrieves mangas with stock at or below threshold; a nil userID covers all mangas
+func (r *mangaRepository) GetLowStock(ctx context.Context, userID *uint, threshold int) ([]*domain.Manga, error) {
+	query := withContext(ctx, r.db).Where("stock_quantity <= ?", threshold)
+	if userID != nil {
+		query = query.Where("user_created = ?", *userID)
+	}
+
+	var mangas []*domain.Manga
+	if err := query.Order("stock_quantity, id").Preload("Genres").Find(&mangas).Error; err != nil {
+		return nil, errors.New("failed to get low stock mangas")
+	}
+	return mangas, nil
+}
+
+// Delete soft deletes a manga with its chapters and comments from the database.
+// All rows share one deletion timestamp so Restore can bring back exactly this cascade.
+func (r *mangaRepository) Delete(ctx context.Context, id uint) error {
+	if err := r.DeleteMany(ctx, []uint{id}); err != nil {
+		return errors.New("failed to delete manga")
+	}
+	return nil
+}
+
+// DeleteMany soft deletes several mangas with their chapters and comments in one transaction
+func (r *mangaRepository) DeleteMany(ctx context.Context, ids []uint) error {
+	now := time.Now()
+	err := withContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.Chapter{}).Where("manga_id IN ?", ids).UpdateColumn("deleted_at", now).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&domain.Comment{}).Where("manga_id IN ?", ids).UpdateColumn("deleted_at", now).Error; err != nil {
+			return err
+		}
+		return tx.Model(&domain.Manga{}).Where("id IN ?", ids).UpdateColumn("deleted_at", now).Error
+	})
+	if err != nil {
+		return errors.New("failed to delete mangas")
+	}
+	return nil
+}
+
+// UpdateMany updates several mangas in one transaction, with the same omissions as Update
+func (r *mangaRepository) UpdateMany(ctx context.Context, mangas []*domain.Manga) error {
+	err := withContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		for _, manga := range mangas {
+			if err := tx.Omit(mangaUpdateOmits...).Save(manga).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.New("failed to update mangas")
+	}
+	return nil
+}
+
+// GetDeletedByID retrieves a soft-deleted manga by ID
+func (r *mangaRepository) GetDeletedByID(ctx context.Context, id uint) (*domain.Manga, error) {
+	var manga domain.Manga
+	if err := withContext(ctx, r.db).Unscoped().Where("deleted_at IS NOT NULL").Preload("Genres").First(&manga, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("deleted manga not found")
+		}
+		return nil, errors.New("failed to get deleted manga")
+	}
+	return &manga, nil
+}
+
+// GetDeletedPaginated retrieves soft-deleted mangas, most recently deleted first;
+// a nil userID covers all mangas
+func (r *mangaRepository) GetDeletedPaginated(ctx context.Context, userID *uint, pagination *domain.PaginationRequest) ([]*domain.Manga, int64, error) {
 	var mangas []*domain.Manga
 	var total int64
 
-	// Count total active records
-	if err := r.db.Model(&domain.Manga{}).Where("is_active = ?", true).Count(&total).Error; err != nil {
-		return nil, 0, errors.New("failed to count active mangas")
+	query := withContext(ctx, r.db).Unscoped().Model(&domain.Manga{}).Where("deleted_at IS NOT NULL")
+	if userID != nil {
+		query = query.Where("user_created = ?", *userID)
+	}
+
+	// Count total mangas
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.New("failed to count deleted mangas")
 	}
 
 	// Get paginated results
 	offset := pagination.GetOffset()
 	limit := pagination.GetLimit()
 
-	if err := r.db.Where("is_active = ?", true).Offset(offset).Limit(limit).Find(&mangas).Error; err != nil {
-		return nil, 0, errors.New("failed to get paginated active mangas")
+	if err := query.Order("deleted_at DESC, id").Offset(offset).Limit(limit).Preload("Genres").Find(&mangas).Error; err != nil {
+		return nil, 0, errors.New("failed to get deleted mangas")
 	}
 
 	return mangas, total, nil
 }
 
-// GetMangasByUserIDPaginated retrieves mangas by user ID with pagination
-func (r *mangaRepository) GetMangasByUserIDPaginated(userID uint, pagination *domain.PaginationRequest) ([]*domain.Manga, int64, error) {
+// Restore undeletes a manga together with the chapters and comments deleted with it
+func (r *mangaRepository) Restore(ctx context.Context, manga *domain.Manga) error {
+	deletedAt := manga.DeletedAt.Time
+	err := withContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&domain.Chapter{}).Where("manga_id = ? AND deleted_at = ?", manga.ID, deletedAt).UpdateColumn("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(&domain.Comment{}).Where("manga_id = ? AND deleted_at = ?", manga.ID, deletedAt).UpdateColumn("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Model(&domain.Manga{}).Where("id = ?", manga.ID).UpdateColumn("deleted_at", nil).Error
+	})
+	if err != nil {
+		return errors.New("failed to restore manga")
+	}
+	return nil
+}
+
+// mangaDependents lists the tables whose rows are permanently removed with a purged manga
+var mangaDependents = []string{
+	"chapters",
+	"comments",
+	"reviews",
+	"reading_progresses",
+	"manga_price_histories",
+	"manga_versions",
+	"manga_views",
+	"archived_manga_views",
+	"manga_genres",
+	"discount_mangas",
+	"manga_relations",
+	"wishlist_items",
+	"cart_items",
+	"rentals",
+	"manga_images",
+	"manga_translations",
+	"uploads",
+}
+
+// Purge permanently deletes a manga and every row that depends on it
+func (r *mangaRepository) Purge(ctx context.Context, id uint) error {
+	err := withContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		for _, table := range mangaDependents {
+			if err := tx.Exec("DELETE FROM "+table+" WHERE manga_id = ?", id).Error; err != nil {
+				return err
+			}
+		}
+		// Relations are stored in both directions, so also drop links pointing at the manga
+		if err := tx.Exec("DELETE FROM manga_relations WHERE related_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(&domain.Manga{}, id).Error
+	})
+	if err != nil {
+		return errors.New("failed to purge manga")
+	}
+	return nil
+}
+
+// GetPurgeableIDs retrieves up to limit IDs of mangas soft deleted before the
+// cutoff, longest deleted first
+func (r *mangaRepository) GetPurgeableIDs(ctx context.Context, deletedBefore time.Time, limit int) ([]uint, error) {
+	var ids []uint
+	if err := withContext(ctx, r.db).Unscoped().Model(&domain.Manga{}).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", deletedBefore).
+		Order("deleted_at, id").Limit(limit).Pluck("id", &ids).Error; err != nil {
+		return nil, errors.New("failed to get purgeable mangas")
+	}
+	return ids, nil
+}
+
+// ExportInBatches streams mangas to fn in chunks ordered by ID
+func (r *mangaRepository) ExportInBatches(ctx context.Context, userID *uint, batchSize int, fn func([]*domain.Manga) error) error {
+	ctx = asReport(ctx)
+	query := withContext(ctx, r.db).Preload("Genres")
+	if userID != nil {
+		query = query.Where("user_created = ?", *userID)
+	}
+
 	var mangas []*domain.Manga
+	var fnErr error
+	err := query.FindInBatches(&mangas, batchSize, func(tx *gorm.DB, batch int) error {
+		fnErr = fn(mangas)
+		return fnErr
+	}).Error
+	if fnErr != nil {
+		return fnErr
+	}
+	if err != nil {
+		return errors.New("failed to export mangas")
+	}
+	return nil
+}
+
+// CountForExport counts the mangas ExportInBatches exports
+func (r *mangaRepository) CountForExport(ctx context.Context, userID *uint) (int64, error) {
+	query := withContext(asReport(ctx), r.db).Model(&domain.Manga{})
+	if userID != nil {
+		query = query.Where("user_created = ?", *userID)
+	}
+
 	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return 0, errors.New("failed to count mangas")
+	}
+	return total, nil
+}
+
+// rankByAffinity orders candidates by shared genres, then closeness to the
+// preferred price, then rating
+func rankByAffinity(db *gorm.DB, price float64) *gorm.DB {
+	return db.Clauses(clause.OrderBy{Expression: clause.Expr{
+		SQL:                "COUNT(manga_genres.genre_id) DESC, ABS(mangas.price - ?), mangas.average_rating DESC, mangas.id",
+		Vars:               []interface{}{price},
+		WithoutParentheses: true,
+	}})
+}
+
+// GetSimilar retrieves active mangas sharing genres with the given manga
+func (r *mangaRepository) GetSimilar(ctx context.Context, manga *domain.Manga, limit int) ([]*domain.Manga, error) {
+	ctx = asReport(ctx)
+	genreIDs := r.db.Table("manga_genres").Select("genre_id").Where("manga_id = ?", manga.ID)
 
-	// Count total user records
-	if err := r.db.Model(&domain.Manga{}).Where("user_created = ?", userID).Count(&total).Error; err != nil {
-		return nil, 0, errors.New("failed to count user mangas")
+	var mangas []*domain.Manga
+	err := rankByAffinity(r.visible(ctx).Model(&domain.Manga{}), manga.Price).
+		Select("mangas.*").
+		Joins("JOIN manga_genres ON manga_genres.manga_id = mangas.id").
+		Where("manga_genres.genre_id IN (?)", genreIDs).
+		Where("mangas.id <> ? AND mangas.is_active = ?", manga.ID, true).
+		Group("mangas.id").
+		Limit(limit).
+		Preload("Genres").
+		Find(&mangas).Error
+	if err != nil {
+		return nil, errors.New("failed to get similar mangas")
 	}
+	return mangas, nil
+}
 
-	// Get paginated results
-	offset := pagination.GetOffset()
-	limit := pagination.GetLimit()
+// GetRecommendedForUser retrieves active mangas matching the genres and price range of
+// mangas the user rated highly or is reading. Mangas the user already reviewed, tracks
+// or owns are excluded. Without any signals, the best rated mangas are returned.
+func (r *mangaRepository) GetRecommendedForUser(ctx context.Context, userID uint, limit int) ([]*domain.Manga, error) {
+	ctx = asReport(ctx)
+	liked := r.db.Raw(`SELECT manga_id FROM reviews WHERE user_id = ? AND rating >= 4
+		UNION SELECT manga_id FROM reading_progresses WHERE user_id = ? AND status <> ?`,
+		userID, userID, domain.ReadingStatusDropped)
+	seen := r.db.Raw(`SELECT manga_id FROM reviews WHERE user_id = ?
+		UNION SELECT manga_id FROM reading_progresses WHERE user_id = ?`, userID, userID)
+
+	var signals struct {
+		Count    int64
+		AvgPrice float64
+	}
+	if err := withContext(ctx, r.db).Model(&domain.Manga{}).
+		Select("COUNT(*) AS count, COALESCE(AVG(price), 0) AS avg_price").
+		Where("id IN (?)", liked).
+		Scan(&signals).Error; err != nil {
+		return nil, errors.New("failed to get recommended mangas")
+	}
 
-	if err := r.db.Where("user_created = ?", userID).Offset(offset).Limit(limit).Find(&mangas).Error; err != nil {
-		return nil, 0, errors.New("failed to get paginated user mangas")
+	candidates := r.visible(ctx).Model(&domain.Manga{}).
+		Where("mangas.id NOT IN (?) AND mangas.user_created <> ? AND mangas.is_active = ?", seen, userID, true)
+
+	var mangas []*domain.Manga
+	var err error
+	if signals.Count == 0 {
+		err = candidates.Order("average_rating DESC, review_count DESC, id").
+			Limit(limit).Preload("Genres").Find(&mangas).Error
+	} else {
+		genreIDs := r.db.Table("manga_genres").Select("genre_id").Where("manga_id IN (?)", liked)
+		err = rankByAffinity(candidates, signals.AvgPrice).
+			Select("mangas.*").
+			Joins("JOIN manga_genres ON manga_genres.manga_id = mangas.id").
+			Where("manga_genres.genre_id IN (?)", genreIDs).
+			Group("mangas.id").
+			Limit(limit).
+			Preload("Genres").
+			Find(&mangas).Error
 	}
+	if err != nil {
+		return nil, errors.New("failed to get recommended mangas")
+	}
+	return mangas, nil
+}
 
-	return mangas, total, nil
+// applyMangaFilter translates a manga filter into query conditions
+func (r *mangaRepository) applyMangaFilter(db *gorm.DB, filter *domain.MangaFilter) *gorm.DB {
+	if filter.IsActive != nil {
+		db = db.Where("mangas.is_active = ?", *filter.IsActive)
+	}
+	if filter.MinPrice != nil {
+		db = db.Where("mangas.price >= ?", *filter.MinPrice)
+	}
+	if filter.MaxPrice != nil {
+		db = db.Where("mangas.price <= ?", *filter.MaxPrice)
+	}
+	if filter.UserID != nil {
+		db = db.Where("mangas.user_created = ?", *filter.UserID)
+	}
+	if filter.Genre != "" {
+		genreMangaIDs := r.db.Table("manga_genres").
+			Select("manga_genres.manga_id").
+			Joins("JOIN genres ON genres.id = manga_genres.genre_id").
+			Where("genres.slug = ?", filter.Genre)
+		db = db.Where("mangas.id IN (?)", genreMangaIDs)
+	}
+	if filter.PublicationStatus != "" {
+		db = db.Where("mangas.publication_status = ?", filter.PublicationStatus)
+	}
+	if filter.ContentRating != "" {
+		db = db.Where("mangas.content_rating = ?", filter.ContentRating)
+	}
+	if !filter.IncludeMature {
+		db = db.Where("mangas.content_rating <> ?", domain.ContentRatingMature)
+	}
+	if filter.SeriesID != nil {
+		db = db.Where("mangas.series_id = ?", *filter.SeriesID)
+	}
+	if filter.Ungrouped {
+		db = db.Where("mangas.series_id IS NULL")
+	}
+	if filter.Query != "" {
+		db = matchMangaQuery(db, filter.Query)
+	}
+	return db
 }
 
-// GetMangasByPriceRangePaginated retrieves mangas within price range with pagination
-func (r *mangaRepository) GetMangasByPriceRangePaginated(min, max float64, pagination *domain.PaginationRequest) ([]*domain.Manga, int64, error) {
+// matchMangaQuery matches mangas whose name contains the query. On Postgres,
+// names also match when they contain all of the query's words in any order
+// (web search syntax: "quoted phrases", -excluded words); the search_vector
+// and trigram indexes created by the migrations serve both conditions.
+func matchMangaQuery(db *gorm.DB, query string) *gorm.DB {
+	pattern := "%" + escapeLike(query) + "%"
+	if isSQLite(db) {
+		return db.Where(iLike(db, "mangas.name"), pattern)
+	}
+	return db.Where("(mangas.search_vector @@ websearch_to_tsquery('simple', ?) OR mangas.name ILIKE ?)", query, pattern)
+}
+
+// orderByRelevance orders Postgres search results by how well their name
+// matches the query, best first, then by primary key like applySort
+func orderByRelevance(db *gorm.DB, query string) *gorm.DB {
+	if isSQLite(db) {
+		return applySort(db, nil)
+	}
+	return db.Clauses(clause.OrderBy{Expression: clause.Expr{
+		SQL:                "ts_rank(mangas.search_vector, websearch_to_tsquery('simple', ?)) DESC, mangas.id",
+		Vars:               []interface{}{query},
+		WithoutParentheses: true,
+	}})
+}
+
+// Search retrieves visible mangas matching the filter with pagination. A field
+// selection narrows the loaded columns and skips genres unless they are needed.
+func (r *mangaRepository) Search(ctx context.Context, filter *domain.MangaFilter, pagination *domain.PaginationRequest, sort domain.Sort, fields domain.Fields, include domain.Includes) ([]*domain.Manga, int64, error) {
 	var mangas []*domain.Manga
 	var total int64
 
-	// Count total records in price range
-	if err := r.db.Model(&domain.Manga{}).Where("price BETWEEN ? AND ?", min, max).Count(&total).Error; err != nil {
-		return nil, 0, errors.New("failed to count mangas by price range")
+	// Count matching records
+	if err := r.applyMangaFilter(r.visible(ctx).Model(&domain.Manga{}), filter).Count(&total).Error; err != nil {
+		return nil, 0, errors.New("failed to count mangas")
 	}
 
 	// Get paginated results
 	offset := pagination.GetOffset()
 	limit := pagination.GetLimit()
 
-	if err := r.db.Where("price BETWEEN ? AND ?", min, max).Offset(offset).Limit(limit).Find(&mangas).Error; err != nil {
-		return nil, 0, errors.New("failed to get paginated mangas by price range")
+	query := r.applyMangaFilter(r.visible(ctx), filter)
+	// Without an explicit sort, text searches list the best matches first
+	if filter.Query != "" && len(sort) == 0 {
+		query = orderByRelevance(query, filter.Query)
+	} else {
+		query = applySort(query, sort)
+	}
+	query = query.Offset(offset).Limit(limit)
+	if len(fields) > 0 {
+		query = query.Select(fields.MangaColumns())
+	}
+	// Discounts may be scoped by genre, so effective prices need genres as well
+	if fields.Has("genres") || fields.Has("effective_price") {
+		query = query.Preload("Genres")
+	}
+	if fields.Has("images") {
+		query = query.Preload("Images", orderedImages)
+	}
+	// Translations replace the name, so they are only needed when it is shown
+	if fields.Has("name") {
+		query = query.Preload("Translations")
+	}
+	if fields.Has("creator") {
+		query = preloadIncludes(query, include)
+	}
+
+	if err := query.Find(&mangas).Error; err != nil {
+		return nil, 0, errors.New("failed to search mangas")
 	}
 
 	return mangas, total, nil
 }
+
+// CountByFacet counts the visible mangas matching the filter per facet value.
+// A manga counts once for each of its genres.
+func (r *mangaRepository) CountByFacet(ctx context.Context, filter *domain.MangaFilter, facet string) (map[string]int64, error) {
+	ctx = asReport(ctx)
+	query := r.applyMangaFilter(r.visible(ctx).Model(&domain.Manga{}), filter)
+	switch facet {
+	case domain.MangaFacetGenre:
+		query = query.Select("genres.slug AS value, COUNT(*) AS count").
+			Joins("JOIN manga_genres ON manga_genres.manga_id = mangas.id").
+			Joins("JOIN genres ON genres.id = manga_genres.genre_id").
+			Group("genres.slug")
+	case domain.MangaFacetPublicationStatus, domain.MangaFacetContentRating:
+		query = query.Select("mangas." + facet + " AS value, COUNT(*) AS count").Group("mangas." + facet)
+	default:
+		return nil, errors.New("unknown manga facet")
+	}
+
+	var rows []struct {
+		Value string
+		Count int64
+	}
+	if err := query.Scan(&rows).Error; err != nil {
+		return nil, errors.New("failed to count mangas by " + facet)
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Value] = row.Count
+	}
+	return counts, nil
+}
+
+// GetVisibleByIDs retrieves the visible mangas among ids with their genres,
+// images and translations
+func (r *mangaRepository) GetVisibleByIDs(ctx context.Context, ids []uint) ([]*domain.Manga, error) {
+	var mangas []*domain.Manga
+	if len(ids) == 0 {
+		return mangas, nil
+	}
+	if err := r.visible(ctx).
+		Where("mangas.id IN ?", ids).
+		Preload("Genres").
+		Preload("Images", orderedImages).
+		Preload("Translations").
+		Find(&mangas).Error; err != nil {
+		return nil, errors.New("failed to get mangas")
+	}
+	return mangas, nil
+}
@@ -0,0 +1,73 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// mangaTranslationRepository implements the MangaTranslationRepository interface
+type mangaTranslationRepository struct {
+	db *gorm.DB
+}
+
+// NewMangaTranslationRepository creates a new manga translation repository instance
+func NewMangaTranslationRepository(db *gorm.DB) ports.MangaTranslationRepository {
+	return &mangaTranslationRepository{
+		db: db,
+	}
+}
+
+// Upsert creates or replaces the translation for a (manga, locale) pair
+func (r *mangaTranslationRepository) Upsert(ctx context.Context, translation *domain.MangaTranslation) error {
+	err := withContext(ctx, r.db).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "manga_id"}, {Name: "locale"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"name":        translation.Name,
+			"description": translation.Description,
+			"updated_at":  time.Now(),
+		}),
+	}).Create(translation).Error
+	if err != nil {
+		return errors.New("failed to save manga translation")
+	}
+	return nil
+}
+
+// GetByLocale retrieves a manga's translation in one locale
+func (r *mangaTranslationRepository) GetByLocale(ctx context.Context, mangaID uint, locale string) (*domain.MangaTranslation, error) {
+	var translation domain.MangaTranslation
+	if err := withContext(ctx, r.db).Where("manga_id = ? AND locale = ?", mangaID, locale).First(&translation).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("manga translation not found")
+		}
+		return nil, errors.New("failed to get manga translation")
+	}
+	return &translation, nil
+}
+
+// ListByMangaID retrieves every translation of a manga ordered by locale
+func (r *mangaTranslationRepository) ListByMangaID(ctx context.Context, mangaID uint) ([]*domain.MangaTranslation, error) {
+	var translations []*domain.MangaTranslation
+	if err := withContext(ctx, r.db).Where("manga_id = ?", mangaID).Order("locale").Find(&translations).Error; err != nil {
+		return nil, errors.New("failed to get manga translations")
+	}
+	return translations, nil
+}
+
+// Delete removes a manga's translation in one locale
+func (r *mangaTranslationRepository) Delete(ctx context.Context, mangaID uint, locale string) error {
+	result := withContext(ctx, r.db).Where("manga_id = ? AND locale = ?", mangaID, locale).Delete(&domain.MangaTranslation{})
+	if result.Error != nil {
+		return errors.New("failed to delete manga translation")
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("manga translation not found")
+	}
+	return nil
+}
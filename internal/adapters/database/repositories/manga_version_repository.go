@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"gorm.io/gorm"
+)
+
+// mangaVersionRepository implements the MangaVersionRepository interface
+type mangaVersionRepository struct {
+	db *gorm.DB
+}
+
+// NewMangaVersionRepository creates a new manga version repository instance
+func NewMangaVersionRepository(db *gorm.DB) ports.MangaVersionRepository {
+	return &mangaVersionRepository{
+		db: db,
+	}
+}
+
+// Create stores the version with the next version number. The unique
+// (manga_id, version) index rejects concurrent writers racing for the same number.
+func (r *mangaVersionRepository) Create(ctx context.Context, version *domain.MangaVersion) error {
+	err := withContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var latest int
+		if err := tx.Model(&domain.MangaVersion{}).
+			Select("COALESCE(MAX(version), 0)").
+			Where("manga_id = ?", version.MangaID).
+			Scan(&latest).Error; err != nil {
+			return err
+		}
+		version.Version = latest + 1
+		return tx.Create(version).Error
+	})
+	if err != nil {
+		return errors.New("failed to record manga version")
+	}
+	return nil
+}
+
+// GetByVersion retrieves a specific version of a manga
+func (r *mangaVersionRepository) GetByVersion(ctx context.Context, mangaID uint, version int) (*domain.MangaVersion, error) {
+	var v domain.MangaVersion
+	if err := withContext(ctx, r.db).Where("manga_id = ? AND version = ?", mangaID, version).First(&v).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("manga version not found")
+		}
+		return nil, errors.New("failed to get manga version")
+	}
+	return &v, nil
+}
+
+// ListByMangaID retrieves all versions of a manga, newest first
+func (r *mangaVersionRepository) ListByMangaID(ctx context.Context, mangaID uint) ([]*domain.MangaVersion, error) {
+	var versions []*domain.MangaVersion
+	if err := withContext(ctx, r.db).Where("manga_id = ?", mangaID).Order("version DESC").Find(&versions).Error; err != nil {
+		return nil, errors.New("failed to get manga history")
+	}
+	return versions, nil
+}
+
+// CountByMangaID counts the recorded versions of a manga
+func (r *mangaVersionRepository) CountByMangaID(ctx context.Context, mangaID uint) (int64, error) {
+	var count int64
+	if err := withContext(ctx, r.db).Model(&domain.MangaVersion{}).Where("manga_id = ?", mangaID).Count(&count).Error; err != nil {
+		return 0, errors.New("failed to count manga versions")
+	}
+	return count, nil
+}
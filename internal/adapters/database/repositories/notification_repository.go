@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// notificationPreferenceRepository implements the NotificationPreferenceRepository interface
+type notificationPreferenceRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationPreferenceRepository creates a new notification preference repository instance
+func NewNotificationPreferenceRepository(db *gorm.DB) ports.NotificationPreferenceRepository {
+	return &notificationPreferenceRepository{
+		db: db,
+	}
+}
+
+// GetByUserID retrieves the stored preferences of a user
+func (r *notificationPreferenceRepository) GetByUserID(ctx context.Context, userID uint) (*domain.NotificationPreferences, error) {
+	var prefs domain.NotificationPreferences
+	if err := withContext(ctx, r.db).Where("user_id = ?", userID).First(&prefs).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("notification preferences not found")
+		}
+		return nil, errors.New("failed to get notification preferences")
+	}
+	return &prefs, nil
+}
+
+// ListDueSellerReports lists up to limit users receiving seller reports at the
+// frequency whose last report was for a period ending before until
+func (r *notificationPreferenceRepository) ListDueSellerReports(ctx context.Context, frequency string, until time.Time, limit int) ([]uint, error) {
+	var ids []uint
+	err := withContext(ctx, r.db).Model(&domain.NotificationPreferences{}).
+		Where("seller_reports = ? AND (seller_report_sent_until IS NULL OR seller_report_sent_until < ?)", frequency, until).
+		Order("user_id").
+		Limit(limit).
+		Pluck("user_id", &ids).Error
+	if err != nil {
+		return nil, errors.New("failed to list due seller reports")
+	}
+	return ids, nil
+}
+
+// MarkSellerReportQueued records the end of the period of the user's last
+// queued report. The update only applies while the report is still due, so of
+// the processes queueing reports, one queues each.
+func (r *notificationPreferenceRepository) MarkSellerReportQueued(ctx context.Context, userID uint, until time.Time) (bool, error) {
+	result := withContext(ctx, r.db).Model(&domain.NotificationPreferences{}).
+		Where("user_id = ? AND (seller_report_sent_until IS NULL OR seller_report_sent_until < ?)", userID, until).
+		UpdateColumn("seller_report_sent_until", until)
+	if result.Error != nil {
+		return false, errors.New("failed to mark seller report queued")
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Save stores a user's preferences, replacing any stored before
+func (r *notificationPreferenceRepository) Save(ctx context.Context, prefs *domain.NotificationPreferences) error {
+	prefs.UpdatedAt = time.Now()
+	err := withContext(ctx, r.db).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "user_id"}}, UpdateAll: true}).
+		Create(prefs).Error
+	if err != nil {
+		return errors.New("failed to save notification preferences")
+	}
+	return nil
+}
@@ -0,0 +1,382 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"gorm.io/gorm"
+)
+
+// orderRepository implements the OrderRepository interface
+type orderRepository struct {
+	db *gorm.DB
+}
+
+// NewOrderRepository creates a new order repository instance
+func NewOrderRepository(db *gorm.DB) ports.OrderRepository {
+	return &orderRepository{
+		db: db,
+	}
+}
+
+// withOrderItems preloads an order's items in checkout order, and its tax lines
+func withOrderItems(db *gorm.DB) *gorm.DB {
+	return db.Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).Preload("TaxLines")
+}
+
+// Create saves the order and reserves stock for its items atomically
+func (r *orderRepository) Create(ctx context.Context, order *domain.Order) error {
+	err := withContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		for _, item := range order.Items {
+			// The guard makes the check and the decrement one statement, so two
+			// checkouts can never both take the last copy
+			result := tx.Model(&domain.Manga{}).
+				Where("id = ? AND stock_quantity >= ?", item.MangaID, item.Quantity).
+				UpdateColumn("stock_quantity", gorm.Expr("stock_quantity - ?", item.Quantity))
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return domain.ErrInsufficientStock
+			}
+		}
+		if err := tx.Create(order).Error; err != nil {
+			return err
+		}
+
+		reservations := make([]domain.StockReservation, len(order.Items))
+		for i, item := range order.Items {
+			reservations[i] = domain.StockReservation{
+				OrderID:   order.ID,
+				MangaID:   item.MangaID,
+				Quantity:  item.Quantity,
+				Status:    domain.ReservationHeld,
+				ExpiresAt: *order.ReservedUntil,
+			}
+		}
+		return tx.Create(&reservations).Error
+	})
+	if errors.Is(err, domain.ErrInsufficientStock) {
+		return err
+	}
+	if err != nil {
+		return errors.New("failed to create order")
+	}
+	return nil
+}
+
+// GetByID retrieves an order by ID
+func (r *orderRepository) GetByID(ctx context.Context, id uint) (*domain.Order, error) {
+	var order domain.Order
+	if err := withOrderItems(withContext(ctx, r.db)).First(&order, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrOrderNotFound
+		}
+		return nil, errors.New("failed to get order")
+	}
+	return &order, nil
+}
+
+// ListByUserIDPaginated retrieves a buyer's orders, newest first, with pagination
+func (r *orderRepository) ListByUserIDPaginated(ctx context.Context, userID uint, pagination *domain.PaginationRequest) ([]*domain.Order, int64, error) {
+	return r.listPaginated(withContext(ctx, r.db).Where("user_id = ?", userID), pagination)
+}
+
+// ListBySellerPaginated retrieves orders containing the seller's mangas, newest first, with pagination
+func (r *orderRepository) ListBySellerPaginated(ctx context.Context, sellerID uint, pagination *domain.PaginationRequest) ([]*domain.Order, int64, error) {
+	sold := r.db.Model(&domain.OrderItem{}).Select("order_id").Where("seller_id = ?", sellerID)
+	return r.listPaginated(withContext(ctx, r.db).Where("id IN (?)", sold), pagination)
+}
+
+// ExportSellerSales streams the items the seller sold in orders placed from
+// from until to to fn in chunks ordered by item ID, paging on the ID so each
+// chunk is a fresh indexed query. Archived orders are not included.
+func (r *orderRepository) ExportSellerSales(ctx context.Context, sellerID uint, from, to time.Time, batchSize int, fn func(rows []*domain.SalesReportRow, total int64) error) error {
+	ctx = asReport(ctx)
+	sales := func() *gorm.DB {
+		return withContext(ctx, r.db).Table("order_items").
+			Joins("JOIN orders ON orders.id = order_items.order_id").
+			Where("order_items.seller_id = ? AND orders.created_at >= ? AND orders.created_at < ?", sellerID, from, to)
+	}
+
+	var total int64
+	if err := sales().Count(&total).Error; err != nil {
+		return errors.New("failed to count sales")
+	}
+
+	var lastID uint
+	for {
+		var rows []*domain.SalesReportRow
+		err := sales().
+			Select("order_items.id AS item_id, order_items.order_id, orders.created_at AS ordered_at, orders.status, "+
+				"order_items.manga_id, order_items.name, order_items.unit_price, order_items.quantity, "+
+				"order_items.subtotal, order_items.tax_amount").
+			Where("order_items.id > ?", lastID).
+			Order("order_items.id").
+			Limit(batchSize).
+			Scan(&rows).Error
+		if err != nil {
+			return errors.New("failed to export sales")
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		if err := fn(rows, total); err != nil {
+			return err
+		}
+		if len(rows) < batchSize {
+			return nil
+		}
+		lastID = rows[len(rows)-1].ItemID
+	}
+}
+
+// sellerSales selects the items the seller sold in orders placed from from until to
+func (r *orderRepository) sellerSales(ctx context.Context, sellerID uint, from, to time.Time) *gorm.DB {
+	return withContext(ctx, r.db).Table("order_items").
+		Joins("JOIN orders ON orders.id = order_items.order_id").
+		Where("order_items.seller_id = ? AND orders.status IN ? AND orders.created_at >= ? AND orders.created_at < ?",
+			sellerID, domain.SoldOrderStatuses, from, to)
+}
+
+// SummarizeSellerSales sums up the seller's paid, shipped and fulfilled items
+// in orders placed from from until to
+func (r *orderRepository) SummarizeSellerSales(ctx context.Context, sellerID uint, from, to time.Time) (*domain.SalesSummary, error) {
+	var summary domain.SalesSummary
+	err := r.sellerSales(asReport(ctx), sellerID, from, to).
+		Select("COUNT(DISTINCT order_items.order_id) AS orders, " +
+			"COALESCE(SUM(order_items.quantity), 0) AS items_sold, " +
+			"COALESCE(SUM(order_items.subtotal), 0) AS revenue").
+		Scan(&summary).Error
+	if err != nil {
+		return nil, errors.New("failed to summarize sales")
+	}
+	return &summary, nil
+}
+
+// GetTopSellerMangas ranks the seller's mangas by copies sold in orders placed
+// from from until to, then by revenue
+func (r *orderRepository) GetTopSellerMangas(ctx context.Context, sellerID uint, from, to time.Time, limit int) ([]*domain.MangaSales, error) {
+	var sales []*domain.MangaSales
+	err := r.sellerSales(asReport(ctx), sellerID, from, to).
+		Select("order_items.manga_id, MAX(order_items.name) AS name, " +
+			"SUM(order_items.quantity) AS sold, SUM(order_items.subtotal) AS revenue").
+		Group("order_items.manga_id").
+		Order("sold DESC, revenue DESC, order_items.manga_id").
+		Limit(limit).
+		Scan(&sales).Error
+	if err != nil {
+		return nil, errors.New("failed to get top mangas")
+	}
+	return sales, nil
+}
+
+// listPaginated runs a paginated order query with items preloaded
+func (r *orderRepository) listPaginated(query *gorm.DB, pagination *domain.PaginationRequest) ([]*domain.Order, int64, error) {
+	var orders []*domain.Order
+	var total int64
+
+	// Count total orders
+	if err := query.Session(&gorm.Session{}).Model(&domain.Order{}).Count(&total).Error; err != nil {
+		return nil, 0, errors.New("failed to count orders")
+	}
+
+	// Get paginated results
+	offset := pagination.GetOffset()
+	limit := pagination.GetLimit()
+
+	if err := withOrderItems(query).Order("created_at DESC, id DESC").Offset(offset).Limit(limit).Find(&orders).Error; err != nil {
+		return nil, 0, errors.New("failed to get orders")
+	}
+
+	return orders, total, nil
+}
+
+// UpdateStatus moves an order to a new status, restocking its items when it is cancelled
+func (r *orderRepository) UpdateStatus(ctx context.Context, id uint, from []string, to string) error {
+	err := withContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		updates := map[string]interface{}{"status": to}
+		if to == domain.OrderStatusFulfilled {
+			updates["delivered_at"] = time.Now()
+		}
+
+		result := tx.Model(&domain.Order{}).
+			Where("id = ? AND status IN ?", id, from).
+			Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrInvalidOrderTransition
+		}
+		reservations := tx.Model(&domain.StockReservation{}).Where("order_id = ?", id)
+		switch to {
+		case domain.OrderStatusPaid:
+			var lapsed int64
+			if err := reservations.Session(&gorm.Session{}).
+				Where("status = ? AND expires_at <= ?", domain.ReservationHeld, time.Now()).
+				Count(&lapsed).Error; err != nil {
+				return err
+			}
+			if lapsed > 0 {
+				return domain.ErrReservationExpired
+			}
+			return reservations.Where("status = ?", domain.ReservationHeld).
+				Update("status", domain.ReservationConfirmed).Error
+		case domain.OrderStatusCancelled:
+			if err := reservations.Where("status <> ?", domain.ReservationReleased).
+				Update("status", domain.ReservationReleased).Error; err != nil {
+				return err
+			}
+		default:
+			return nil
+		}
+
+		var items []domain.OrderItem
+		if err := tx.Where("order_id = ?", id).Find(&items).Error; err != nil {
+			return err
+		}
+		for _, item := range items {
+			// Unscoped so items of a since-deleted manga are restocked if it is restored
+			if err := tx.Unscoped().Model(&domain.Manga{}).Where("id = ?", item.MangaID).
+				UpdateColumn("stock_quantity", gorm.Expr("stock_quantity + ?", item.Quantity)).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, domain.ErrInvalidOrderTransition) || errors.Is(err, domain.ErrReservationExpired) {
+		return err
+	}
+	if err != nil {
+		return errors.New("failed to update order status")
+	}
+	return nil
+}
+
+// Ship stores tracking details and marks the order shipped, keeping the first shipping time
+func (r *orderRepository) Ship(ctx context.Context, id uint, shipment *domain.Shipment) error {
+	result := withContext(ctx, r.db).Model(&domain.Order{}).
+		Where("id = ? AND status IN ?", id, []string{domain.OrderStatusPaid, domain.OrderStatusShipped}).
+		Updates(map[string]interface{}{
+			"status":          domain.OrderStatusShipped,
+			"carrier":         shipment.Carrier,
+			"tracking_number": shipment.TrackingNumber,
+			"tracking_url":    shipment.TrackingURL,
+			"shipped_at":      gorm.Expr("COALESCE(shipped_at, ?)", time.Now()),
+		})
+	if result.Error != nil {
+		return errors.New("failed to ship order")
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrInvalidOrderTransition
+	}
+	return nil
+}
+
+// ListExpiredReservations returns pending orders whose stock reservation has lapsed
+func (r *orderRepository) ListExpiredReservations(ctx context.Context, now time.Time) ([]uint, error) {
+	var ids []uint
+	err := withContext(ctx, r.db).Model(&domain.Order{}).
+		Where("status = ? AND reserved_until <= ?", domain.OrderStatusPending, now).
+		Order("id").
+		Pluck("id", &ids).Error
+	if err != nil {
+		return nil, errors.New("failed to list expired reservations")
+	}
+	return ids, nil
+}
+
+// orderDependents lists the tables whose rows are dropped with an archived order
+var orderDependents = []string{
+	"order_items",
+	"order_tax_lines",
+	"stock_reservations",
+}
+
+// Archive moves the oldest due orders into archived_orders in one transaction
+func (r *orderRepository) Archive(ctx context.Context, createdBefore time.Time, limit int) (int, error) {
+	var orders []*domain.Order
+	err := withContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		// Final orders never change again, so they can't move while being archived
+		err := withOrderItems(tx).
+			Where("status IN ? AND created_at < ?", domain.ArchivableOrderStatuses, createdBefore).
+			Order("id").
+			Limit(limit).
+			Find(&orders).Error
+		if err != nil || len(orders) == 0 {
+			return err
+		}
+
+		now := time.Now()
+		archived := make([]*domain.ArchivedOrder, len(orders))
+		ids := make([]uint, len(orders))
+		for i, order := range orders {
+			if archived[i], err = domain.NewArchivedOrder(order, now); err != nil {
+				return err
+			}
+			ids[i] = order.ID
+		}
+		if err := tx.Create(&archived).Error; err != nil {
+			return err
+		}
+
+		for _, table := range orderDependents {
+			if err := tx.Exec("DELETE FROM "+table+" WHERE order_id IN ?", ids).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Where("id IN ?", ids).Delete(&domain.Order{}).Error
+	})
+	if err != nil {
+		return 0, errors.New("failed to archive orders")
+	}
+	return len(orders), nil
+}
+
+// GetArchivedByID retrieves an archived order by ID
+func (r *orderRepository) GetArchivedByID(ctx context.Context, id uint) (*domain.Order, error) {
+	var archived domain.ArchivedOrder
+	if err := withContext(ctx, r.db).First(&archived, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrOrderNotFound
+		}
+		return nil, errors.New("failed to get order")
+	}
+
+	order, err := archived.Order()
+	if err != nil {
+		return nil, errors.New("failed to get order")
+	}
+	return order, nil
+}
+
+// ListArchivedByUserIDPaginated retrieves a buyer's archived orders, newest first, with pagination
+func (r *orderRepository) ListArchivedByUserIDPaginated(ctx context.Context, userID uint, pagination *domain.PaginationRequest) ([]*domain.Order, int64, error) {
+	query := withContext(ctx, r.db).Model(&domain.ArchivedOrder{}).Where("user_id = ?", userID)
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, errors.New("failed to count orders")
+	}
+
+	var archived []*domain.ArchivedOrder
+	err := query.Order("created_at DESC, id DESC").
+		Offset(pagination.GetOffset()).
+		Limit(pagination.GetLimit()).
+		Find(&archived).Error
+	if err != nil {
+		return nil, 0, errors.New("failed to get orders")
+	}
+
+	orders := make([]*domain.Order, len(archived))
+	for i, a := range archived {
+		if orders[i], err = a.Order(); err != nil {
+			return nil, 0, errors.New("failed to get orders")
+		}
+	}
+	return orders, total, nil
+}
@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// outboxRepository implements the OutboxRepository interface
+type outboxRepository struct {
+	db *gorm.DB
+}
+
+// NewOutboxRepository creates a new outbox repository instance
+func NewOutboxRepository(db *gorm.DB) ports.OutboxRepository {
+	return &outboxRepository{
+		db: db,
+	}
+}
+
+// Create records an event, joining the transaction carried by ctx
+func (r *outboxRepository) Create(ctx context.Context, event *domain.OutboxEvent) error {
+	if err := withContext(ctx, r.db).Create(event).Error; err != nil {
+		return errors.New("failed to record event")
+	}
+	return nil
+}
+
+// PublishPending locks the oldest unpublished events, passes them to publish
+// and marks them published in one transaction. Postgres skips rows another
+// relay has locked, so concurrent instances never publish the same event. If
+// the transaction fails after publish ran, the events are published again.
+func (r *outboxRepository) PublishPending(ctx context.Context, limit int, publish func(*domain.OutboxEvent)) (int, error) {
+	var events []*domain.OutboxEvent
+	err := withContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		query := tx.Where("published_at IS NULL").Order("id").Limit(limit)
+		if !isSQLite(tx) {
+			query = query.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"})
+		}
+		if err := query.Find(&events).Error; err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return nil
+		}
+
+		ids := make([]uint, len(events))
+		for i, event := range events {
+			publish(event)
+			ids[i] = event.ID
+		}
+		return tx.Model(&domain.OutboxEvent{}).Where("id IN ?", ids).Update("published_at", time.Now()).Error
+	})
+	if err != nil {
+		return 0, errors.New("failed to publish events")
+	}
+	return len(events), nil
+}
+
+// DeletePublishedBefore deletes the events published before the given time
+func (r *outboxRepository) DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := withContext(ctx, r.db).Where("published_at < ?", before).Delete(&domain.OutboxEvent{})
+	if result.Error != nil {
+		return 0, errors.New("failed to delete published events")
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,39 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"gorm.io/gorm"
+)
+
+// priceHistoryRepository implements the PriceHistoryRepository interface
+type priceHistoryRepository struct {
+	db *gorm.DB
+}
+
+// NewPriceHistoryRepository creates a new price history repository instance
+func NewPriceHistoryRepository(db *gorm.DB) ports.PriceHistoryRepository {
+	return &priceHistoryRepository{
+		db: db,
+	}
+}
+
+// Create records a price change
+func (r *priceHistoryRepository) Create(ctx context.Context, entry *domain.MangaPriceHistory) error {
+	if err := withContext(ctx, r.db).Create(entry).Error; err != nil {
+		return errors.New("failed to record price change")
+	}
+	return nil
+}
+
+// ListByMangaID retrieves a manga's price changes in chronological order
+func (r *priceHistoryRepository) ListByMangaID(ctx context.Context, mangaID uint) ([]*domain.MangaPriceHistory, error) {
+	var history []*domain.MangaPriceHistory
+	if err := withContext(ctx, r.db).Where("manga_id = ?", mangaID).Order("created_at, id").Find(&history).Error; err != nil {
+		return nil, errors.New("failed to get price history")
+	}
+	return history, nil
+}
@@ -0,0 +1,182 @@
+package repositories
+
+import (
+	"log/slog"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/thitiphongD/my-backend/internal/utils"
+	"gorm.io/gorm"
+)
+
+var (
+	queryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "db_query_duration_seconds",
+		Help:    "Duration of database statements by operation, table and calling service method.",
+		Buckets: []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+	}, []string{"operation", "table", "caller"})
+
+	queryRows = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "db_query_rows",
+		Help:    "Rows returned or affected by database statements by operation, table and calling service method.",
+		Buckets: []float64{0, 1, 10, 100, 1000, 10000},
+	}, []string{"operation", "table", "caller"})
+)
+
+// QueryMetrics is a GORM plugin recording the duration and row count of every
+// statement as Prometheus histograms, labeled with the service method that ran
+// it. Statements taking SlowThreshold or longer (zero = never) are logged with
+// their full SQL; with LogAll every statement is logged, without the SQL.
+type QueryMetrics struct {
+	SlowThreshold time.Duration
+	LogAll        bool
+}
+
+// queryStartKey is the statement setting holding the start of a running statement
+const queryStartKey = "query_metrics:start"
+
+// callerDepth bounds how many stack frames are searched for the caller
+const callerDepth = 48
+
+// Name returns the plugin name
+func (m QueryMetrics) Name() string {
+	return "query_metrics"
+}
+
+// Initialize registers the callbacks timing each statement
+func (m QueryMetrics) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+
+	if err := cb.Create().Before("*").Register("query_metrics:start", startQueryTimer); err != nil {
+		return err
+	}
+	if err := cb.Create().After("*").Register("query_metrics:stop", m.record("create")); err != nil {
+		return err
+	}
+	if err := cb.Query().Before("*").Register("query_metrics:start", startQueryTimer); err != nil {
+		return err
+	}
+	if err := cb.Query().After("*").Register("query_metrics:stop", m.record("query")); err != nil {
+		return err
+	}
+	if err := cb.Update().Before("*").Register("query_metrics:start", startQueryTimer); err != nil {
+		return err
+	}
+	if err := cb.Update().After("*").Register("query_metrics:stop", m.record("update")); err != nil {
+		return err
+	}
+	if err := cb.Delete().Before("*").Register("query_metrics:start", startQueryTimer); err != nil {
+		return err
+	}
+	if err := cb.Delete().After("*").Register("query_metrics:stop", m.record("delete")); err != nil {
+		return err
+	}
+	if err := cb.Raw().Before("*").Register("query_metrics:start", startQueryTimer); err != nil {
+		return err
+	}
+	if err := cb.Raw().After("*").Register("query_metrics:stop", m.record("raw")); err != nil {
+		return err
+	}
+	// Row and Scan are timed until their rows are handed back, not until they are read
+	if err := cb.Row().Before("*").Register("query_metrics:start", startQueryTimer); err != nil {
+		return err
+	}
+	return cb.Row().After("*").Register("query_metrics:stop", m.record("row"))
+}
+
+// startQueryTimer notes when the statement started
+func startQueryTimer(db *gorm.DB) {
+	db.Statement.Settings.Store(queryStartKey, time.Now())
+}
+
+// record returns a callback observing the finished statement
+func (m QueryMetrics) record(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.Statement.Settings.LoadAndDelete(queryStartKey)
+		if !ok {
+			return
+		}
+		duration := time.Since(value.(time.Time))
+		table := db.Statement.Table
+		if table == "" {
+			table = "unknown"
+		}
+		caller := queryCaller()
+
+		queryDuration.WithLabelValues(operation, table, caller).Observe(duration.Seconds())
+		queryRows.WithLabelValues(operation, table, caller).Observe(float64(db.Statement.RowsAffected))
+
+		slow := m.SlowThreshold > 0 && duration >= m.SlowThreshold
+		if !slow && !m.LogAll {
+			return
+		}
+		attrs := []any{
+			"operation", operation,
+			"table", table,
+			"caller", caller,
+			"duration_ms", float64(duration.Microseconds()) / 1000,
+			"rows", db.Statement.RowsAffected,
+		}
+		if requestID := utils.RequestIDFromContext(db.Statement.Context); requestID != "" {
+			attrs = append(attrs, "request_id", requestID)
+		}
+		if db.Error != nil {
+			attrs = append(attrs, "error", db.Error.Error())
+		}
+		if slow {
+			attrs = append(attrs, "sql", db.Dialector.Explain(db.Statement.SQL.String(), db.Statement.Vars...))
+			slog.WarnContext(db.Statement.Context, "slow query", attrs...)
+			return
+		}
+		slog.InfoContext(db.Statement.Context, "query", attrs...)
+	}
+}
+
+// queryCaller names the service method running the statement, such as
+// mangaService.GetMangas, or the repository method for statements run outside
+// services (background jobs, the seeder)
+func queryCaller() string {
+	pcs := make([]uintptr, callerDepth)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+
+	repository := ""
+	for {
+		frame, more := frames.Next()
+		switch {
+		case strings.Contains(frame.Function, "/internal/core/services."):
+			return functionName(frame.Function)
+		case repository == "" && strings.Contains(frame.Function, "/internal/adapters/database/repositories.") &&
+			!strings.HasSuffix(frame.File, "/query_metrics.go"):
+			repository = functionName(frame.Function)
+		}
+		if !more {
+			break
+		}
+	}
+
+	if repository == "" {
+		return "unknown"
+	}
+	return repository
+}
+
+// functionName shortens a fully qualified function name to its type and
+// method, dropping closures: ".../services.(*mangaService).GetMangas.func1"
+// becomes "mangaService.GetMangas"
+func functionName(function string) string {
+	name := function[strings.LastIndex(function, "/")+1:]
+	_, name, _ = strings.Cut(name, ".")
+	name = strings.NewReplacer("(*", "", ")", "", "[...]", "").Replace(name)
+
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		if i == 2 || strings.HasPrefix(part, "func") {
+			parts = parts[:i]
+			break
+		}
+	}
+	return strings.Join(parts, ".")
+}
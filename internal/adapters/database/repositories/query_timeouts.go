@@ -0,0 +1,127 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// QueryTimeouts is a GORM plugin putting a deadline on every statement, by
+// operation class, so a pathological query or a locked row can't hold a request
+// (or a background job) forever. Reads are queries, writes are inserts,
+// updates, deletes and raw statements, and reports are the aggregations and
+// exports repositories mark with asReport. A zero timeout leaves the class
+// bounded only by the caller's context.
+type QueryTimeouts struct {
+	Read   time.Duration
+	Write  time.Duration
+	Report time.Duration
+}
+
+// reportKey marks a context whose queries are reports
+type reportKey struct{}
+
+// queryDeadlineKey is the statement setting holding the deadline of a running statement
+const queryDeadlineKey = "query_timeouts:deadline"
+
+// queryDeadline is the deadline a statement runs with and how to lift it
+type queryDeadline struct {
+	parent context.Context
+	cancel context.CancelFunc
+}
+
+// asReport marks the queries run with ctx as reports, which may take longer
+// than ordinary reads
+func asReport(ctx context.Context) context.Context {
+	return context.WithValue(ctx, reportKey{}, true)
+}
+
+// Name returns the plugin name
+func (t QueryTimeouts) Name() string {
+	return "query_timeouts"
+}
+
+// Initialize registers the callbacks setting and lifting the deadlines
+func (t QueryTimeouts) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	write := func(context.Context) time.Duration { return t.Write }
+
+	if err := cb.Create().Before("*").Register("query_timeouts:start", t.start(write)); err != nil {
+		return err
+	}
+	if err := cb.Create().After("*").Register("query_timeouts:stop", stopQueryDeadline); err != nil {
+		return err
+	}
+	if err := cb.Update().Before("*").Register("query_timeouts:start", t.start(write)); err != nil {
+		return err
+	}
+	if err := cb.Update().After("*").Register("query_timeouts:stop", stopQueryDeadline); err != nil {
+		return err
+	}
+	if err := cb.Delete().Before("*").Register("query_timeouts:start", t.start(write)); err != nil {
+		return err
+	}
+	if err := cb.Delete().After("*").Register("query_timeouts:stop", stopQueryDeadline); err != nil {
+		return err
+	}
+	if err := cb.Raw().Before("*").Register("query_timeouts:start", t.start(write)); err != nil {
+		return err
+	}
+	if err := cb.Raw().After("*").Register("query_timeouts:stop", stopQueryDeadline); err != nil {
+		return err
+	}
+	if err := cb.Query().Before("*").Register("query_timeouts:start", t.start(t.read)); err != nil {
+		return err
+	}
+	if err := cb.Query().After("*").Register("query_timeouts:stop", stopQueryDeadline); err != nil {
+		return err
+	}
+	// Row and Scan hand back rows that are read after the callbacks return, so
+	// their deadline is only released when it expires
+	if err := cb.Row().Before("*").Register("query_timeouts:start", t.start(t.read)); err != nil {
+		return err
+	}
+	return cb.Row().After("*").Register("query_timeouts:stop", restoreQueryContext)
+}
+
+// read returns the timeout of a query run with ctx
+func (t QueryTimeouts) read(ctx context.Context) time.Duration {
+	if report, _ := ctx.Value(reportKey{}).(bool); report {
+		return t.Report
+	}
+	return t.Read
+}
+
+// start returns a callback running the statement under its class's deadline
+func (t QueryTimeouts) start(timeout func(context.Context) time.Duration) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		parent := db.Statement.Context
+		d := timeout(parent)
+		if d <= 0 {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(parent, d)
+		db.Statement.Context = ctx
+		db.Statement.Settings.Store(queryDeadlineKey, queryDeadline{parent: parent, cancel: cancel})
+	}
+}
+
+// stopQueryDeadline lifts the deadline once the statement is done and puts
+// back the caller's context, since chained statements share it
+func stopQueryDeadline(db *gorm.DB) {
+	if value, ok := db.Statement.Settings.LoadAndDelete(queryDeadlineKey); ok {
+		deadline := value.(queryDeadline)
+		deadline.cancel()
+		db.Statement.Context = deadline.parent
+	}
+}
+
+// restoreQueryContext puts back the caller's context but leaves the deadline
+// running for the rows still to be read; it is released when it expires
+func restoreQueryContext(db *gorm.DB) {
+	if value, ok := db.Statement.Settings.LoadAndDelete(queryDeadlineKey); ok {
+		db.Statement.Context = value.(queryDeadline).parent
+	}
+}
@@ -0,0 +1,71 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// quotaRepository implements the QuotaRepository interface
+type quotaRepository struct {
+	db *gorm.DB
+}
+
+// NewQuotaRepository creates a new quota repository instance
+func NewQuotaRepository(db *gorm.DB) ports.QuotaRepository {
+	return &quotaRepository{
+		db: db,
+	}
+}
+
+// Increment atomically increments the usage counter for a period and returns the new count
+func (r *quotaRepository) Increment(ctx context.Context, userID uint, period string, periodStart time.Time) (int64, error) {
+	usage := &domain.QuotaUsage{
+		UserID:      userID,
+		Period:      period,
+		PeriodStart: periodStart,
+		Count:       1,
+	}
+
+	err := withContext(ctx, r.db).Clauses(
+		clause.OnConflict{
+			Columns: []clause.Column{{Name: "user_id"}, {Name: "period"}, {Name: "period_start"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"count":      gorm.Expr("quota_usages.count + 1"),
+				"updated_at": time.Now(),
+			}),
+		},
+		clause.Returning{Columns: []clause.Column{{Name: "count"}}},
+	).Create(usage).Error
+	if err != nil {
+		return 0, errors.New("failed to increment quota usage")
+	}
+
+	return usage.Count, nil
+}
+
+// GetUsage retrieves the usage counter for a period
+func (r *quotaRepository) GetUsage(ctx context.Context, userID uint, period string, periodStart time.Time) (int64, error) {
+	var usage domain.QuotaUsage
+	err := withContext(ctx, r.db).Where("user_id = ? AND period = ? AND period_start = ?", userID, period, periodStart).First(&usage).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, errors.New("failed to get quota usage")
+	}
+	return usage.Count, nil
+}
+
+// Reset clears all usage counters for a user
+func (r *quotaRepository) Reset(ctx context.Context, userID uint) error {
+	if err := withContext(ctx, r.db).Where("user_id = ?", userID).Delete(&domain.QuotaUsage{}).Error; err != nil {
+		return errors.New("failed to reset quota usage")
+	}
+	return nil
+}
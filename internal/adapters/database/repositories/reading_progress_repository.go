@@ -0,0 +1,82 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// readingProgressRepository implements the ReadingProgressRepository interface
+type readingProgressRepository struct {
+	db *gorm.DB
+}
+
+// NewReadingProgressRepository creates a new reading progress repository instance
+func NewReadingProgressRepository(db *gorm.DB) ports.ReadingProgressRepository {
+	return &readingProgressRepository{
+		db: db,
+	}
+}
+
+// Upsert creates or updates the progress for a (user, manga) pair
+func (r *readingProgressRepository) Upsert(ctx context.Context, progress *domain.ReadingProgress) error {
+	err := withContext(ctx, r.db).Omit("Manga").Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}, {Name: "manga_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"chapter_id": progress.ChapterID,
+			"page":       progress.Page,
+			"status":     progress.Status,
+			"updated_at": time.Now(),
+		}),
+	}).Create(progress).Error
+	if err != nil {
+		return errors.New("failed to save reading progress")
+	}
+	return nil
+}
+
+// GetByUserAndManga retrieves a user's progress in a manga
+func (r *readingProgressRepository) GetByUserAndManga(ctx context.Context, userID, mangaID uint) (*domain.ReadingProgress, error) {
+	var progress domain.ReadingProgress
+	if err := withContext(ctx, r.db).Where("user_id = ? AND manga_id = ?", userID, mangaID).First(&progress).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("reading progress not found")
+		}
+		return nil, errors.New("failed to get reading progress")
+	}
+	return &progress, nil
+}
+
+// ListByUserIDPaginated retrieves a user's progress entries, most recently read first.
+// Entries for deleted mangas are skipped.
+func (r *readingProgressRepository) ListByUserIDPaginated(ctx context.Context, userID uint, status string, pagination *domain.PaginationRequest) ([]*domain.ReadingProgress, int64, error) {
+	var entries []*domain.ReadingProgress
+	var total int64
+
+	query := withContext(ctx, r.db).Model(&domain.ReadingProgress{}).
+		Where("user_id = ?", userID).
+		Where("manga_id IN (?)", r.db.Model(&domain.Manga{}).Select("id"))
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	// Count total entries
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.New("failed to count reading progress")
+	}
+
+	// Get paginated results
+	offset := pagination.GetOffset()
+	limit := pagination.GetLimit()
+
+	if err := query.Preload("Manga.Genres").Order("updated_at DESC").Offset(offset).Limit(limit).Find(&entries).Error; err != nil {
+		return nil, 0, errors.New("failed to get reading progress")
+	}
+
+	return entries, total, nil
+}
@@ -0,0 +1,100 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"gorm.io/gorm"
+)
+
+// rentalRepository implements the RentalRepository interface
+type rentalRepository struct {
+	db *gorm.DB
+}
+
+// NewRentalRepository creates a new rental repository instance
+func NewRentalRepository(db *gorm.DB) ports.RentalRepository {
+	return &rentalRepository{
+		db: db,
+	}
+}
+
+// Create creates a new rental in the database. A user has one active rental
+// of a manga at most, so their lapsed one is marked expired first, and a
+// concurrent rental of the same manga is refused.
+func (r *rentalRepository) Create(ctx context.Context, rental *domain.Rental) error {
+	err := withContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.Rental{}).
+			Where("user_id = ? AND manga_id = ? AND status = ? AND expires_at <= ?", rental.UserID, rental.MangaID, domain.RentalStatusActive, time.Now()).
+			Update("status", domain.RentalStatusExpired).Error; err != nil {
+			return err
+		}
+		return tx.Omit("Manga").Create(rental).Error
+	})
+	if err != nil {
+		if isDuplicateKey(r.db, err) {
+			return errors.New("you are already renting this manga")
+		}
+		return errors.New("failed to create rental")
+	}
+	return nil
+}
+
+// activeAt scopes a query to rentals still running at now
+func activeAt(now time.Time) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("status = ? AND expires_at > ?", domain.RentalStatusActive, now)
+	}
+}
+
+// GetActive retrieves the user's running rental of a manga
+func (r *rentalRepository) GetActive(ctx context.Context, userID, mangaID uint, now time.Time) (*domain.Rental, error) {
+	var rental domain.Rental
+	err := withContext(ctx, r.db).Scopes(activeAt(now)).
+		Where("user_id = ? AND manga_id = ?", userID, mangaID).
+		First(&rental).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("rental not found")
+		}
+		return nil, errors.New("failed to get rental")
+	}
+	return &rental, nil
+}
+
+// ListActiveByUserIDPaginated retrieves the user's running rentals with their mangas, soonest expiry first
+func (r *rentalRepository) ListActiveByUserIDPaginated(ctx context.Context, userID uint, now time.Time, pagination *domain.PaginationRequest) ([]*domain.Rental, int64, error) {
+	var rentals []*domain.Rental
+	var total int64
+
+	query := withContext(ctx, r.db).Model(&domain.Rental{}).Scopes(activeAt(now)).Where("user_id = ?", userID)
+
+	// Count total rentals
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, errors.New("failed to count rentals")
+	}
+
+	// Get paginated results
+	offset := pagination.GetOffset()
+	limit := pagination.GetLimit()
+
+	if err := query.Preload("Manga").Order("expires_at, id").Offset(offset).Limit(limit).Find(&rentals).Error; err != nil {
+		return nil, 0, errors.New("failed to get rentals")
+	}
+
+	return rentals, total, nil
+}
+
+// ExpireDue marks every active rental past its expiry as expired
+func (r *rentalRepository) ExpireDue(ctx context.Context, now time.Time) (int64, error) {
+	result := withContext(ctx, r.db).Model(&domain.Rental{}).
+		Where("status = ? AND expires_at <= ?", domain.RentalStatusActive, now).
+		Update("status", domain.RentalStatusExpired)
+	if result.Error != nil {
+		return 0, errors.New("failed to expire rentals")
+	}
+	return result.RowsAffected, nil
+}
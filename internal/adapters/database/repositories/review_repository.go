@@ -0,0 +1,117 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"gorm.io/gorm"
+)
+
+// reviewRepository implements the ReviewRepository interface
+type reviewRepository struct {
+	db *gorm.DB
+}
+
+// NewReviewRepository creates a new review repository instance
+func NewReviewRepository(db *gorm.DB) ports.ReviewRepository {
+	return &reviewRepository{
+		db: db,
+	}
+}
+
+// refreshMangaRating recomputes the manga's average rating and review count
+func refreshMangaRating(tx *gorm.DB, mangaID uint) error {
+	return tx.Exec(`UPDATE mangas SET
+		average_rating = (SELECT COALESCE(AVG(rating), 0) FROM reviews WHERE manga_id = ?),
+		review_count = (SELECT COUNT(*) FROM reviews WHERE manga_id = ?)
+		WHERE id = ?`, mangaID, mangaID, mangaID).Error
+}
+
+// Create creates a new review and refreshes the manga rating
+func (r *reviewRepository) Create(ctx context.Context, review *domain.Review) error {
+	err := withContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(review).Error; err != nil {
+			return err
+		}
+		return refreshMangaRating(tx, review.MangaID)
+	})
+	if err != nil {
+		return errors.New("failed to create review")
+	}
+	return nil
+}
+
+// GetByID retrieves a review by ID
+func (r *reviewRepository) GetByID(ctx context.Context, id uint) (*domain.Review, error) {
+	var review domain.Review
+	if err := withContext(ctx, r.db).First(&review, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("review not found")
+		}
+		return nil, errors.New("failed to get review")
+	}
+	return &review, nil
+}
+
+// GetByMangaAndUser retrieves a user's review of a manga
+func (r *reviewRepository) GetByMangaAndUser(ctx context.Context, mangaID, userID uint) (*domain.Review, error) {
+	var review domain.Review
+	if err := withContext(ctx, r.db).Where("manga_id = ? AND user_id = ?", mangaID, userID).First(&review).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("review not found")
+		}
+		return nil, errors.New("failed to get review")
+	}
+	return &review, nil
+}
+
+// ListByMangaIDPaginated retrieves a manga's reviews, newest first, with pagination
+func (r *reviewRepository) ListByMangaIDPaginated(ctx context.Context, mangaID uint, pagination *domain.PaginationRequest) ([]*domain.Review, int64, error) {
+	var reviews []*domain.Review
+	var total int64
+
+	// Count total reviews
+	if err := withContext(ctx, r.db).Model(&domain.Review{}).Where("manga_id = ?", mangaID).Count(&total).Error; err != nil {
+		return nil, 0, errors.New("failed to count reviews")
+	}
+
+	// Get paginated results
+	offset := pagination.GetOffset()
+	limit := pagination.GetLimit()
+
+	if err := withContext(ctx, r.db).Where("manga_id = ?", mangaID).Order("created_at DESC").Offset(offset).Limit(limit).Find(&reviews).Error; err != nil {
+		return nil, 0, errors.New("failed to get reviews")
+	}
+
+	return reviews, total, nil
+}
+
+// Update updates a review and refreshes the manga rating
+func (r *reviewRepository) Update(ctx context.Context, review *domain.Review) error {
+	err := withContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(review).Error; err != nil {
+			return err
+		}
+		return refreshMangaRating(tx, review.MangaID)
+	})
+	if err != nil {
+		return errors.New("failed to update review")
+	}
+	return nil
+}
+
+// Delete deletes a review and refreshes the manga rating
+func (r *reviewRepository) Delete(ctx context.Context, review *domain.Review) error {
+	err := withContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&domain.Review{}, review.ID).Error; err != nil {
+			return err
+		}
+		return refreshMangaRating(tx, review.MangaID)
+	})
+	if err != nil {
+		return errors.New("failed to delete review")
+	}
+	return nil
+}
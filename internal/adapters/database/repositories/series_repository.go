@@ -0,0 +1,123 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"gorm.io/gorm"
+)
+
+// seriesRepository implements the SeriesRepository interface
+type seriesRepository struct {
+	db *gorm.DB
+}
+
+// NewSeriesRepository creates a new series repository instance
+func NewSeriesRepository(db *gorm.DB) ports.SeriesRepository {
+	return &seriesRepository{
+		db: db,
+	}
+}
+
+// Create creates a new series in the database
+func (r *seriesRepository) Create(ctx context.Context, series *domain.Series) error {
+	if err := withContext(ctx, r.db).Create(series).Error; err != nil {
+		return errors.New("failed to create series")
+	}
+	return nil
+}
+
+// GetByID retrieves a series by ID
+func (r *seriesRepository) GetByID(ctx context.Context, id uint) (*domain.Series, error) {
+	var series domain.Series
+	if err := withContext(ctx, r.db).First(&series, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("series not found")
+		}
+		return nil, errors.New("failed to get series")
+	}
+	return &series, nil
+}
+
+// Update updates a series in the database
+func (r *seriesRepository) Update(ctx context.Context, series *domain.Series) error {
+	if err := withContext(ctx, r.db).Save(series).Error; err != nil {
+		return errors.New("failed to update series")
+	}
+	return nil
+}
+
+// Delete soft deletes a series and ungroups its volumes
+func (r *seriesRepository) Delete(ctx context.Context, id uint) error {
+	err := withContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.Manga{}).Where("series_id = ?", id).
+			Updates(map[string]interface{}{"series_id": nil, "volume_number": nil}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.Series{}, id).Error
+	})
+	if err != nil {
+		return errors.New("failed to delete series")
+	}
+	return nil
+}
+
+// GetVolumes retrieves the mangas in a series ordered by volume number
+func (r *seriesRepository) GetVolumes(ctx context.Context, seriesID uint, publishedOnly bool) ([]*domain.Manga, error) {
+	var mangas []*domain.Manga
+	query := withContext(ctx, r.db).Where("series_id = ?", seriesID)
+	if publishedOnly {
+		query = query.Where("status = ?", domain.MangaStatusPublished)
+	}
+	if err := query.Order("volume_number, id").Preload("Genres").Find(&mangas).Error; err != nil {
+		return nil, errors.New("failed to get series volumes")
+	}
+	return mangas, nil
+}
+
+// NextVolumeNumber returns the volume number after the series' last volume
+func (r *seriesRepository) NextVolumeNumber(ctx context.Context, seriesID uint) (int, error) {
+	var last int
+	if err := withContext(ctx, r.db).Model(&domain.Manga{}).Where("series_id = ?", seriesID).
+		Select("COALESCE(MAX(volume_number), 0)").Scan(&last).Error; err != nil {
+		return 0, errors.New("failed to get series volumes")
+	}
+	return last + 1, nil
+}
+
+// AddVolume puts a manga into a series at the given volume number
+func (r *seriesRepository) AddVolume(ctx context.Context, seriesID, mangaID uint, volumeNumber int) error {
+	if err := withContext(ctx, r.db).Model(&domain.Manga{}).Where("id = ?", mangaID).
+		Updates(map[string]interface{}{"series_id": seriesID, "volume_number": volumeNumber}).Error; err != nil {
+		return errors.New("failed to add series volume")
+	}
+	return nil
+}
+
+// RemoveVolume takes a manga out of a series
+func (r *seriesRepository) RemoveVolume(ctx context.Context, seriesID, mangaID uint) error {
+	if err := withContext(ctx, r.db).Model(&domain.Manga{}).Where("id = ? AND series_id = ?", mangaID, seriesID).
+		Updates(map[string]interface{}{"series_id": nil, "volume_number": nil}).Error; err != nil {
+		return errors.New("failed to remove series volume")
+	}
+	return nil
+}
+
+// ReorderVolumes renumbers the given volumes 1..n in order
+func (r *seriesRepository) ReorderVolumes(ctx context.Context, seriesID uint, mangaIDs []uint) error {
+	err := withContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		for i, mangaID := range mangaIDs {
+			if err := tx.Model(&domain.Manga{}).Where("id = ? AND series_id = ?", mangaID, seriesID).
+				UpdateColumn("volume_number", i+1).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.New("failed to reorder series volumes")
+	}
+	return nil
+}
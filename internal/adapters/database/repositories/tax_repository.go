@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"gorm.io/gorm"
+)
+
+// taxRateRepository implements the TaxRateRepository interface
+type taxRateRepository struct {
+	db *gorm.DB
+}
+
+// NewTaxRateRepository creates a new tax rate repository instance
+func NewTaxRateRepository(db *gorm.DB) ports.TaxRateRepository {
+	return &taxRateRepository{
+		db: db,
+	}
+}
+
+// Create creates a new tax rate in the database
+func (r *taxRateRepository) Create(ctx context.Context, rate *domain.TaxRate) error {
+	if err := withContext(ctx, r.db).Create(rate).Error; err != nil {
+		return errors.New("failed to create tax rate")
+	}
+	return nil
+}
+
+// GetByID retrieves a tax rate by ID
+func (r *taxRateRepository) GetByID(ctx context.Context, id uint) (*domain.TaxRate, error) {
+	var rate domain.TaxRate
+	if err := withContext(ctx, r.db).First(&rate, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("tax rate not found")
+		}
+		return nil, errors.New("failed to get tax rate")
+	}
+	return &rate, nil
+}
+
+// GetByLocation retrieves the tax rate defined for exactly this country and region
+func (r *taxRateRepository) GetByLocation(ctx context.Context, country, region string) (*domain.TaxRate, error) {
+	var rate domain.TaxRate
+	if err := withContext(ctx, r.db).Where("country = ? AND region = ?", country, region).First(&rate).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("tax rate not found")
+		}
+		return nil, errors.New("failed to get tax rate")
+	}
+	return &rate, nil
+}
+
+// FindForLocation retrieves the region's tax rate, falling back to the country's
+func (r *taxRateRepository) FindForLocation(ctx context.Context, country, region string) (*domain.TaxRate, error) {
+	var rate domain.TaxRate
+	// Region-specific rows sort before the country-wide row
+	err := withContext(ctx, r.db).Where("country = ? AND region IN ?", country, []string{region, ""}).
+		Order("region DESC").
+		First(&rate).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("tax rate not found")
+		}
+		return nil, errors.New("failed to get tax rate")
+	}
+	return &rate, nil
+}
+
+// List retrieves all tax rates ordered by location
+func (r *taxRateRepository) List(ctx context.Context) ([]*domain.TaxRate, error) {
+	var rates []*domain.TaxRate
+	if err := withContext(ctx, r.db).Order("country, region").Find(&rates).Error; err != nil {
+		return nil, errors.New("failed to get tax rates")
+	}
+	return rates, nil
+}
+
+// Update updates a tax rate in the database
+func (r *taxRateRepository) Update(ctx context.Context, rate *domain.TaxRate) error {
+	if err := withContext(ctx, r.db).Save(rate).Error; err != nil {
+		return errors.New("failed to update tax rate")
+	}
+	return nil
+}
+
+// Delete deletes a tax rate from the database
+func (r *taxRateRepository) Delete(ctx context.Context, id uint) error {
+	if err := withContext(ctx, r.db).Delete(&domain.TaxRate{}, id).Error; err != nil {
+		return errors.New("failed to delete tax rate")
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"gorm.io/gorm"
+)
+
+// teamRepository implements the TeamRepository interface
+type teamRepository struct {
+	db *gorm.DB
+}
+
+// NewTeamRepository creates a new team repository instance
+func NewTeamRepository(db *gorm.DB) ports.TeamRepository {
+	return &teamRepository{
+		db: db,
+	}
+}
+
+// Create creates a new team together with its owner membership
+func (r *teamRepository) Create(ctx context.Context, team *domain.Team) error {
+	err := withContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Members").Create(team).Error; err != nil {
+			return err
+		}
+
+		owner := &domain.TeamMember{
+			TeamID: team.ID,
+			UserID: team.OwnerID,
+			Role:   domain.TeamRoleOwner,
+		}
+		if err := tx.Create(owner).Error; err != nil {
+			return err
+		}
+
+		team.Members = []domain.TeamMember{*owner}
+		return nil
+	})
+	if err != nil {
+		return errors.New("failed to create team")
+	}
+	return nil
+}
+
+// GetByID retrieves a team by ID with its members
+func (r *teamRepository) GetByID(ctx context.Context, id uint) (*domain.Team, error) {
+	var team domain.Team
+	if err := withContext(ctx, r.db).Preload("Members").First(&team, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("team not found")
+		}
+		return nil, errors.New("failed to get team")
+	}
+	return &team, nil
+}
+
+// ListByUserID retrieves all teams the user is a member of
+func (r *teamRepository) ListByUserID(ctx context.Context, userID uint) ([]*domain.Team, error) {
+	var teams []*domain.Team
+	if err := withContext(ctx, r.db).
+		Where("id IN (?)", r.db.Model(&domain.TeamMember{}).Select("team_id").Where("user_id = ?", userID)).
+		Find(&teams).Error; err != nil {
+		return nil, errors.New("failed to get user teams")
+	}
+	return teams, nil
+}
+
+// Delete soft deletes a team and removes its memberships
+func (r *teamRepository) Delete(ctx context.Context, id uint) error {
+	err := withContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("team_id = ?", id).Delete(&domain.TeamMember{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.Team{}, id).Error
+	})
+	if err != nil {
+		return errors.New("failed to delete team")
+	}
+	return nil
+}
+
+// GetMember retrieves a user's membership in a team
+func (r *teamRepository) GetMember(ctx context.Context, teamID, userID uint) (*domain.TeamMember, error) {
+	var member domain.TeamMember
+	if err := withContext(ctx, r.db).Where("team_id = ? AND user_id = ?", teamID, userID).First(&member).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("team member not found")
+		}
+		return nil, errors.New("failed to get team member")
+	}
+	return &member, nil
+}
+
+// RemoveMember removes a user from a team
+func (r *teamRepository) RemoveMember(ctx context.Context, teamID, userID uint) error {
+	if err := withContext(ctx, r.db).Where("team_id = ? AND user_id = ?", teamID, userID).Delete(&domain.TeamMember{}).Error; err != nil {
+		return errors.New("failed to remove team member")
+	}
+	return nil
+}
+
+// CreateInvitation creates a new team invitation
+func (r *teamRepository) CreateInvitation(ctx context.Context, invitation *domain.TeamInvitation) error {
+	if err := withContext(ctx, r.db).Create(invitation).Error; err != nil {
+		return errors.New("failed to create invitation")
+	}
+	return nil
+}
+
+// GetInvitationByToken retrieves an invitation by its token
+func (r *teamRepository) GetInvitationByToken(ctx context.Context, token string) (*domain.TeamInvitation, error) {
+	var invitation domain.TeamInvitation
+	if err := withContext(ctx, r.db).Where("token = ?", token).First(&invitation).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("invitation not found")
+		}
+		return nil, errors.New("failed to get invitation")
+	}
+	return &invitation, nil
+}
+
+// AcceptInvitation marks the invitation accepted and adds the member in one transaction
+func (r *teamRepository) AcceptInvitation(ctx context.Context, invitation *domain.TeamInvitation, member *domain.TeamMember) error {
+	err := withContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(member).Error; err != nil {
+			return err
+		}
+		invitation.Status = domain.InvitationStatusAccepted
+		return tx.Save(invitation).Error
+	})
+	if err != nil {
+		return errors.New("failed to accept invitation")
+	}
+	return nil
+}
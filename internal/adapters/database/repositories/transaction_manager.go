@@ -0,0 +1,81 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"gorm.io/gorm"
+)
+
+// txKey is the context key of the transaction opened by the transaction manager
+type txKey struct{}
+
+// afterCommitKey is the context key of the functions to run once the
+// transaction opened by the transaction manager commits
+type afterCommitKey struct{}
+
+// afterCommit holds the functions to run after a transaction commits, and the
+// context of the outermost transaction to run them with
+type afterCommit struct {
+	ctx context.Context
+	fns []func(ctx context.Context)
+}
+
+// transactionManager implements the TransactionManager interface with GORM transactions
+type transactionManager struct {
+	db *gorm.DB
+}
+
+// NewTransactionManager creates a new transaction manager instance
+func NewTransactionManager(db *gorm.DB) ports.TransactionManager {
+	return &transactionManager{
+		db: db,
+	}
+}
+
+// WithinTransaction runs fn in a transaction carried by its context. Calls
+// nested in another transaction run in a savepoint of the outer one, and hand
+// their after-commit functions to it once the savepoint is released.
+func (m *transactionManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	outer, nested := ctx.Value(afterCommitKey{}).(*afterCommit)
+	hooks := &afterCommit{ctx: ctx}
+	if nested {
+		hooks.ctx = outer.ctx
+	}
+
+	err := withContext(ctx, m.db).Transaction(func(tx *gorm.DB) error {
+		ctx := context.WithValue(ctx, txKey{}, tx)
+		return fn(context.WithValue(ctx, afterCommitKey{}, hooks))
+	})
+	if err != nil {
+		return err
+	}
+
+	if nested {
+		outer.fns = append(outer.fns, hooks.fns...)
+		return nil
+	}
+	for _, fn := range hooks.fns {
+		fn(hooks.ctx)
+	}
+	return nil
+}
+
+// AfterCommit queues fn on the transaction carried by ctx, or runs it right
+// away outside a transaction
+func (m *transactionManager) AfterCommit(ctx context.Context, fn func(ctx context.Context)) {
+	if hooks, ok := ctx.Value(afterCommitKey{}).(*afterCommit); ok {
+		hooks.fns = append(hooks.fns, fn)
+		return
+	}
+	fn(ctx)
+}
+
+// withContext returns the transaction carried by ctx, so repositories join
+// it, or else db bound to ctx
+func withContext(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx
+	}
+	return db.WithContext(ctx)
+}
@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"gorm.io/gorm"
+)
+
+// uploadRepository implements the UploadRepository interface
+type uploadRepository struct {
+	db *gorm.DB
+}
+
+// NewUploadRepository creates a new upload repository instance
+func NewUploadRepository(db *gorm.DB) ports.UploadRepository {
+	return &uploadRepository{
+		db: db,
+	}
+}
+
+// Create stores an upload, joining the transaction carried by ctx
+func (r *uploadRepository) Create(ctx context.Context, upload *domain.Upload) error {
+	if err := withContext(ctx, r.db).Create(upload).Error; err != nil {
+		return errors.New("failed to create upload")
+	}
+	return nil
+}
+
+// GetByID retrieves an upload by ID
+func (r *uploadRepository) GetByID(ctx context.Context, id uint) (*domain.Upload, error) {
+	var upload domain.Upload
+	if err := withContext(ctx, r.db).First(&upload, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("upload not found")
+		}
+		return nil, errors.New("failed to get upload")
+	}
+	return &upload, nil
+}
+
+// ListByMangaID retrieves every upload made for a manga
+func (r *uploadRepository) ListByMangaID(ctx context.Context, mangaID uint) ([]*domain.Upload, error) {
+	var uploads []*domain.Upload
+	if err := withContext(ctx, r.db).Where("manga_id = ?", mangaID).Order("id").Find(&uploads).Error; err != nil {
+		return nil, errors.New("failed to get uploads")
+	}
+	return uploads, nil
+}
+
+// Confirm marks a pending upload confirmed, in one conditional update so an
+// upload is only ever confirmed once
+func (r *uploadRepository) Confirm(ctx context.Context, id uint, imageID uint) error {
+	result := withContext(ctx, r.db).Model(&domain.Upload{}).
+		Where("id = ? AND status = ?", id, domain.UploadStatusPending).
+		Updates(map[string]interface{}{
+			"status":         domain.UploadStatusConfirmed,
+			"manga_image_id": imageID,
+		})
+	if result.Error != nil {
+		return errors.New("failed to confirm upload")
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("upload has already been confirmed")
+	}
+	return nil
+}
+
+// Block marks a pending upload blocked for the malware found in its file
+func (r *uploadRepository) Block(ctx context.Context, id uint, signature string) error {
+	err := withContext(ctx, r.db).Model(&domain.Upload{}).
+		Where("id = ? AND status = ?", id, domain.UploadStatusPending).
+		Updates(map[string]interface{}{
+			"status":    domain.UploadStatusBlocked,
+			"signature": signature,
+		}).Error
+	if err != nil {
+		return errors.New("failed to block upload")
+	}
+	return nil
+}
+
+// Delete removes an upload
+func (r *uploadRepository) Delete(ctx context.Context, id uint) error {
+	if err := withContext(ctx, r.db).Delete(&domain.Upload{}, id).Error; err != nil {
+		return errors.New("failed to delete upload")
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// userPhoneRepository implements the UserPhoneRepository interface
+type userPhoneRepository struct {
+	db *gorm.DB
+}
+
+// NewUserPhoneRepository creates a new user phone repository instance
+func NewUserPhoneRepository(db *gorm.DB) ports.UserPhoneRepository {
+	return &userPhoneRepository{
+		db: db,
+	}
+}
+
+// GetByUserID retrieves the phone number of a user
+func (r *userPhoneRepository) GetByUserID(ctx context.Context, userID uint) (*domain.UserPhone, error) {
+	var phone domain.UserPhone
+	if err := withContext(ctx, r.db).Where("user_id = ?", userID).First(&phone).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("phone number not found")
+		}
+		return nil, errors.New("failed to get phone number")
+	}
+	return &phone, nil
+}
+
+// Save stores a user's phone number, replacing any stored before
+func (r *userPhoneRepository) Save(ctx context.Context, phone *domain.UserPhone) error {
+	phone.UpdatedAt = time.Now()
+	err := withContext(ctx, r.db).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "user_id"}}, UpdateAll: true}).
+		Create(phone).Error
+	if err != nil {
+		return errors.New("failed to save phone number")
+	}
+	return nil
+}
+
+// Delete removes a user's phone number
+func (r *userPhoneRepository) Delete(ctx context.Context, userID uint) error {
+	result := withContext(ctx, r.db).Where("user_id = ?", userID).Delete(&domain.UserPhone{})
+	if result.Error != nil {
+		return errors.New("failed to delete phone number")
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("phone number not found")
+	}
+	return nil
+}
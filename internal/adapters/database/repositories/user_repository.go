@@ -1,13 +1,50 @@
 package repositories
 
 import (
+	"context"
 	"errors"
+	"reflect"
+	"strings"
+	"time"
 
 	"github.com/thitiphongD/my-backend/internal/core/domain"
 	"github.com/thitiphongD/my-backend/internal/core/ports"
 	"gorm.io/gorm"
 )
 
+// ownedResource describes a table holding a foreign key to its owning user.
+// uniqueWith names the column that is unique together with the user column,
+// among the rows matching uniqueWhere if set; source rows colliding with one of
+// the target's rows are dropped on merge. single marks tables keyed by the user
+// column, where the source's row is dropped if the target has one. Personal
+// data is only reassigned on merge when merged is set.
+type ownedResource struct {
+	model       interface{}
+	table       string
+	column      string
+	uniqueWith  string
+	uniqueWhere string
+	single      bool
+	merged      bool
+}
+
+// userOwnedResources lists every resource reassigned when users are merged
+var userOwnedResources = []ownedResource{
+	{model: &domain.Manga{}, table: "mangas", column: "user_created"},
+	{model: &domain.Team{}, table: "teams", column: "owner_id"},
+	{model: &domain.TeamMember{}, table: "team_members", column: "user_id", uniqueWith: "team_id"},
+	{model: &domain.Webhook{}, table: "webhooks", column: "user_id"},
+	{model: &domain.Review{}, table: "reviews", column: "user_id", uniqueWith: "manga_id"},
+	{model: &domain.ReadingProgress{}, table: "reading_progresses", column: "user_id", uniqueWith: "manga_id"},
+	{model: &domain.Comment{}, table: "comments", column: "user_id"},
+	{model: &domain.Series{}, table: "series", column: "user_created"},
+	{model: &domain.Wishlist{}, table: "wishlists", column: "user_id"},
+	{model: &domain.Order{}, table: "orders", column: "user_id"},
+	{model: &domain.ArchivedOrder{}, table: "archived_orders", column: "user_id"},
+	{model: &domain.Rental{}, table: "rentals", column: "user_id", uniqueWith: "manga_id", uniqueWhere: "status = '" + domain.RentalStatusActive + "'"},
+	{model: &domain.OrderItem{}, table: "order_items", column: "seller_id"},
+}
+
 // userRepository implements the UserRepository interface
 type userRepository struct {
 	db *gorm.DB
@@ -21,17 +58,23 @@ func NewUserRepository(db *gorm.DB) ports.UserRepository {
 }
 
 // Create creates a new user in the database
-func (r *userRepository) Create(user *domain.User) error {
-	if err := r.db.Create(user).Error; err != nil {
+func (r *userRepository) Create(ctx context.Context, user *domain.User) error {
+	if err := withContext(ctx, r.db).Create(user).Error; err != nil {
 		return errors.New("failed to create user")
 	}
 	return nil
 }
 
+// CreateInBatches creates users batchSize at a time and reports the ones that
+// could not be created; the others get their IDs set
+func (r *userRepository) CreateInBatches(ctx context.Context, users []*domain.User, batchSize int) []domain.BatchCreateFailure {
+	return createInBatches(ctx, r.db, users, batchSize, func(u *domain.User) { u.ID = 0 }, "failed to create user")
+}
+
 // GetByID retrieves a user by ID
-func (r *userRepository) GetByID(id uint) (*domain.User, error) {
+func (r *userRepository) GetByID(ctx context.Context, id uint) (*domain.User, error) {
 	var user domain.User
-	if err := r.db.First(&user, id).Error; err != nil {
+	if err := withContext(ctx, r.db).First(&user, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New("user not found")
 		}
@@ -41,9 +84,9 @@ func (r *userRepository) GetByID(id uint) (*domain.User, error) {
 }
 
 // GetByEmail retrieves a user by email
-func (r *userRepository) GetByEmail(email string) (*domain.User, error) {
+func (r *userRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
 	var user domain.User
-	if err := r.db.Where("email = ?", email).First(&user).Error; err != nil {
+	if err := withContext(ctx, r.db).Where("email = ?", email).First(&user).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New("user not found")
 		}
@@ -53,34 +96,73 @@ func (r *userRepository) GetByEmail(email string) (*domain.User, error) {
 }
 
 // Update updates a user in the database
-func (r *userRepository) Update(user *domain.User) error {
-	if err := r.db.Save(user).Error; err != nil {
+func (r *userRepository) Update(ctx context.Context, user *domain.User) error {
+	if err := withContext(ctx, r.db).Save(user).Error; err != nil {
 		return errors.New("failed to update user")
 	}
 	return nil
 }
 
+// UpdateIfUnchanged saves a user like Update, in one conditional update, so
+// of two writers that read the same version only the first succeeds
+func (r *userRepository) UpdateIfUnchanged(ctx context.Context, user *domain.User, version time.Time) error {
+	result := withContext(ctx, r.db).Model(user).Where("updated_at = ?", version).
+		Select("*").Omit("ID", "CreatedAt").
+		Updates(user)
+	if result.Error != nil {
+		return errors.New("failed to update user")
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrPreconditionFailed
+	}
+	return nil
+}
+
 // Delete soft deletes a user from the database
-func (r *userRepository) Delete(id uint) error {
-	if err := r.db.Delete(&domain.User{}, id).Error; err != nil {
+func (r *userRepository) Delete(ctx context.Context, id uint) error {
+	if err := withContext(ctx, r.db).Delete(&domain.User{}, id).Error; err != nil {
 		return errors.New("failed to delete user")
 	}
 	return nil
 }
 
 // List retrieves all users from the database
-func (r *userRepository) List() ([]*domain.User, error) {
+func (r *userRepository) List(ctx context.Context) ([]*domain.User, error) {
 	var users []*domain.User
-	if err := r.db.Find(&users).Error; err != nil {
+	if err := withContext(ctx, r.db).Find(&users).Error; err != nil {
 		return nil, errors.New("failed to get users")
 	}
 	return users, nil
 }
 
+// ListAdmins retrieves the admins and super admins
+func (r *userRepository) ListAdmins(ctx context.Context) ([]*domain.User, error) {
+	var users []*domain.User
+	if err := withContext(ctx, r.db).Where("role IN ?", []string{domain.RoleAdmin, domain.RoleSuperAdmin}).Find(&users).Error; err != nil {
+		return nil, errors.New("failed to get admins")
+	}
+	return users, nil
+}
+
+// SearchByNamePrefix retrieves users whose name starts with the given prefix (case-insensitive)
+func (r *userRepository) SearchByNamePrefix(ctx context.Context, prefix string, limit int) ([]*domain.User, error) {
+	var users []*domain.User
+	pattern := escapeLike(strings.ToLower(prefix)) + "%"
+	db := withContext(ctx, r.db)
+	if err := db.Select("id", "name", "avatar_url").
+		Where(like(db, "lower(name)"), pattern).
+		Order("lower(name)").
+		Limit(limit).
+		Find(&users).Error; err != nil {
+		return nil, errors.New("failed to search users")
+	}
+	return users, nil
+}
+
 // FindByEmailAndPassword finds a user by email and password (for login)
-func (r *userRepository) FindByEmailAndPassword(email, password string) (*domain.User, error) {
+func (r *userRepository) FindByEmailAndPassword(ctx context.Context, email, password string) (*domain.User, error) {
 	var user domain.User
-	if err := r.db.Where("email = ? AND password = ?", email, password).First(&user).Error; err != nil {
+	if err := withContext(ctx, r.db).Where("email = ? AND password = ?", email, password).First(&user).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New("invalid credentials")
 		}
@@ -88,3 +170,200 @@ func (r *userRepository) FindByEmailAndPassword(email, password string) (*domain
 	}
 	return &user, nil
 }
+
+// mergedResources lists every table reassigned when users are merged: the
+// owned resources, then the personal data following the user
+func mergedResources() []ownedResource {
+	resources := append([]ownedResource{}, userOwnedResources...)
+	for _, res := range userPersonalData {
+		if res.merged {
+			resources = append(resources, res)
+		}
+	}
+	return resources
+}
+
+// CountOwnedResources counts the rows a merge would reassign from a user,
+// including soft-deleted ones
+func (r *userRepository) CountOwnedResources(ctx context.Context, userID uint) (map[string]int64, error) {
+	resources := mergedResources()
+	counts := make(map[string]int64, len(resources))
+	for _, res := range resources {
+		var count int64
+		if err := withContext(ctx, r.db).Unscoped().Model(res.model).Where(res.column+" = ?", userID).Count(&count).Error; err != nil {
+			return nil, errors.New("failed to count " + res.table)
+		}
+		counts[res.table] = count
+	}
+	return counts, nil
+}
+
+// MergeInto reassigns all resources from source to target and soft deletes source in one transaction
+func (r *userRepository) MergeInto(ctx context.Context, sourceID, targetID uint) (map[string]int64, error) {
+	resources := mergedResources()
+	counts := make(map[string]int64, len(resources))
+
+	err := withContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		for _, res := range resources {
+			if res.uniqueWith != "" {
+				existing := tx.Unscoped().Model(res.model).Select(res.uniqueWith).Where(res.column+" = ?", targetID)
+				colliding := tx.Unscoped()
+				if res.uniqueWhere != "" {
+					existing = existing.Where(res.uniqueWhere)
+					colliding = colliding.Where(res.uniqueWhere)
+				}
+				if err := colliding.Where(res.column+" = ? AND "+res.uniqueWith+" IN (?)", sourceID, existing).Delete(res.model).Error; err != nil {
+					return errors.New("failed to reassign " + res.table)
+				}
+			}
+			if res.single {
+				existing := tx.Unscoped().Model(res.model).Select(res.column).Where(res.column+" = ?", targetID)
+				if err := tx.Unscoped().Where(res.column+" = ? AND EXISTS (?)", sourceID, existing).Delete(res.model).Error; err != nil {
+					return errors.New("failed to reassign " + res.table)
+				}
+			}
+
+			result := tx.Unscoped().Model(res.model).Where(res.column+" = ?", sourceID).UpdateColumn(res.column, targetID)
+			if result.Error != nil {
+				return errors.New("failed to reassign " + res.table)
+			}
+			counts[res.table] = result.RowsAffected
+		}
+
+		if err := tx.Delete(&domain.User{}, sourceID).Error; err != nil {
+			return errors.New("failed to delete merged user")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+// UpdateLastLogin records the user's last login time without touching updated_at
+func (r *userRepository) UpdateLastLogin(ctx context.Context, id uint, at time.Time) error {
+	if err := withContext(ctx, r.db).Model(&domain.User{}).Where("id = ?", id).UpdateColumn("last_login_at", at).Error; err != nil {
+		return errors.New("failed to update last login")
+	}
+	return nil
+}
+
+// UpdateLastSeen records the user's last activity time without touching updated_at
+func (r *userRepository) UpdateLastSeen(ctx context.Context, id uint, at time.Time) error {
+	if err := withContext(ctx, r.db).Model(&domain.User{}).Where("id = ?", id).UpdateColumn("last_seen_at", at).Error; err != nil {
+		return errors.New("failed to update last seen")
+	}
+	return nil
+}
+
+// CountSeenSince counts users active since the given time
+func (r *userRepository) CountSeenSince(ctx context.Context, since time.Time) (int64, error) {
+	var count int64
+	if err := withContext(ctx, r.db).Model(&domain.User{}).Where("last_seen_at >= ?", since).Count(&count).Error; err != nil {
+		return 0, errors.New("failed to count online users")
+	}
+	return count, nil
+}
+
+// userPersonalData lists the tables whose rows are permanently removed with a
+// purged user; anything else the user owns keeps them from being purged
+var userPersonalData = []ownedResource{
+	{model: &domain.QuotaUsage{}, table: "quota_usages", column: "user_id"},
+	{model: &domain.NotificationPreferences{}, table: "notification_preferences", column: "user_id"},
+	{model: &domain.UserPhone{}, table: "user_phones", column: "user_id", single: true, merged: true},
+	{model: &domain.LineAccount{}, table: "line_accounts", column: "user_id", single: true, merged: true},
+	{model: &domain.DigestItem{}, table: "digest_items", column: "user_id"},
+	{model: &domain.Digest{}, table: "digests", column: "user_id"},
+	{model: &domain.APIKey{}, table: "api_keys", column: "user_id"},
+	{model: &domain.CartItem{}, table: "cart_items", column: "user_id", uniqueWith: "manga_id", merged: true},
+}
+
+// ExportPersonalData passes fn the user, then their rows of every owned
+// resource and personal data table, soft-deleted ones included. Models are
+// loaded as such, so encrypted columns come out decrypted.
+func (r *userRepository) ExportPersonalData(ctx context.Context, userID uint, fn func(table string, rows interface{}, tables int) error) error {
+	ctx = asReport(ctx)
+	tables := 1 + len(userOwnedResources) + len(userPersonalData)
+
+	var users []*domain.User
+	if err := withContext(ctx, r.db).Unscoped().Where("id = ?", userID).Find(&users).Error; err != nil {
+		return errors.New("failed to export users")
+	}
+	if len(users) == 0 {
+		return errors.New("user not found")
+	}
+	if err := fn("users", users, tables); err != nil {
+		return err
+	}
+
+	for _, res := range userOwnedResources {
+		rows := reflect.New(reflect.SliceOf(reflect.TypeOf(res.model)))
+		if err := withContext(ctx, r.db).Unscoped().Where(res.column+" = ?", userID).Order("id").Find(rows.Interface()).Error; err != nil {
+			return errors.New("failed to export " + res.table)
+		}
+		if err := fn(res.table, rows.Elem().Interface(), tables); err != nil {
+			return err
+		}
+	}
+
+	for _, res := range userPersonalData {
+		rows := reflect.New(reflect.SliceOf(reflect.TypeOf(res.model)))
+		if err := withContext(ctx, r.db).Where(res.column+" = ?", userID).Find(rows.Interface()).Error; err != nil {
+			return errors.New("failed to export " + res.table)
+		}
+		if err := fn(res.table, rows.Elem().Interface(), tables); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ownsNothing limits query to users without any owned resources, including
+// soft-deleted ones
+func ownsNothing(query *gorm.DB) *gorm.DB {
+	for _, res := range userOwnedResources {
+		query = query.Where("NOT EXISTS (SELECT 1 FROM " + res.table + " WHERE " + res.table + "." + res.column + " = users.id)")
+	}
+	return query
+}
+
+// GetPurgeableIDs retrieves up to limit IDs of users soft deleted before the
+// cutoff, longest deleted first. Users still owning resources are left out so
+// their mangas, orders and the like are never lost with them.
+func (r *userRepository) GetPurgeableIDs(ctx context.Context, deletedBefore time.Time, limit int) ([]uint, error) {
+	var ids []uint
+	query := withContext(ctx, r.db).Unscoped().Model(&domain.User{}).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", deletedBefore)
+	if err := ownsNothing(query).Order("deleted_at, id").Limit(limit).Pluck("id", &ids).Error; err != nil {
+		return nil, errors.New("failed to get purgeable users")
+	}
+	return ids, nil
+}
+
+// Purge permanently deletes a soft-deleted user owning no resources, together
+// with their personal data
+func (r *userRepository) Purge(ctx context.Context, id uint) error {
+	err := withContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		// Check again in the transaction, since the user may have been given
+		// resources (by a merge) since they were listed
+		result := ownsNothing(tx.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id)).Delete(&domain.User{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("user is not purgeable")
+		}
+		for _, res := range userPersonalData {
+			if err := tx.Exec("DELETE FROM "+res.table+" WHERE "+res.column+" = ?", id).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.New("failed to purge user")
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// viewRepository implements the ViewRepository interface
+type viewRepository struct {
+	db *gorm.DB
+}
+
+// NewViewRepository creates a new view repository instance
+func NewViewRepository(db *gorm.DB) ports.ViewRepository {
+	return &viewRepository{
+		db: db,
+	}
+}
+
+// IncrementViews adds the buffered view counts to the daily and total counters in one transaction
+func (r *viewRepository) IncrementViews(ctx context.Context, counts map[uint]int64, day time.Time) error {
+	err := withContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		for mangaID, count := range counts {
+			view := &domain.MangaView{MangaID: mangaID, Day: day, Count: count}
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "manga_id"}, {Name: "day"}},
+				DoUpdates: clause.Assignments(map[string]interface{}{"count": gorm.Expr("manga_views.count + ?", count)}),
+			}).Create(view).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Model(&domain.Manga{}).Where("id = ?", mangaID).
+				UpdateColumn("view_count", gorm.Expr("view_count + ?", count)).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.New("failed to record views")
+	}
+	return nil
+}
+
+// GetTrending ranks active published mangas by views since the given day, weighting recent days higher
+func (r *viewRepository) GetTrending(ctx context.Context, since time.Time, limit int) ([]*domain.Manga, error) {
+	ctx = asReport(ctx)
+	suspended := r.db.Model(&domain.User{}).
+		Select("id").
+		Where("suspended_at IS NOT NULL AND (suspended_until IS NULL OR suspended_until > ?)", time.Now())
+
+	db := withContext(ctx, r.db)
+	var mangas []*domain.Manga
+	err := db.Model(&domain.Manga{}).
+		Select("mangas.*").
+		Joins("JOIN manga_views ON manga_views.manga_id = mangas.id AND manga_views.day >= ?", since).
+		Where("mangas.is_active = ? AND mangas.status = ? AND mangas.user_created NOT IN (?)", true, domain.MangaStatusPublished, suspended).
+		Group("mangas.id").
+		Clauses(clause.OrderBy{Expression: clause.Expr{
+			SQL:                "SUM(manga_views.count * (" + daysSince(db, "manga_views.day") + " + 1)) DESC, mangas.id",
+			Vars:               []interface{}{since},
+			WithoutParentheses: true,
+		}}).
+		Limit(limit).
+		Preload("Genres").
+		Find(&mangas).Error
+	if err != nil {
+		return nil, errors.New("failed to get trending mangas")
+	}
+	return mangas, nil
+}
+
+// CountSellerViews sums the daily views of the seller's mangas, deleted ones
+// included, from the day from until the day to
+func (r *viewRepository) CountSellerViews(ctx context.Context, sellerID uint, from, to time.Time) (int64, error) {
+	var views int64
+	err := withContext(asReport(ctx), r.db).Model(&domain.MangaView{}).
+		Select("COALESCE(SUM(manga_views.count), 0)").
+		Joins("JOIN mangas ON mangas.id = manga_views.manga_id").
+		Where("mangas.user_created = ? AND manga_views.day >= ? AND manga_views.day < ?", sellerID, from, to).
+		Scan(&views).Error
+	if err != nil {
+		return 0, errors.New("failed to count views")
+	}
+	return views, nil
+}
+
+// Archive moves the oldest daily counts before the cutoff into
+// archived_manga_views in one transaction
+func (r *viewRepository) Archive(ctx context.Context, before time.Time, limit int) (int, error) {
+	var views []*domain.MangaView
+	err := withContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("day < ?", before).Order("day, manga_id").Limit(limit).Find(&views).Error; err != nil || len(views) == 0 {
+			return err
+		}
+
+		now := time.Now()
+		archived := make([]*domain.ArchivedMangaView, len(views))
+		for i, view := range views {
+			archived[i] = &domain.ArchivedMangaView{MangaID: view.MangaID, Day: view.Day, Count: view.Count, ArchivedAt: now}
+		}
+		// A day archived before is added to rather than duplicated
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "manga_id"}, {Name: "day"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{"count": gorm.Expr("archived_manga_views.count + excluded.count")}),
+		}).Create(&archived).Error
+		if err != nil {
+			return err
+		}
+
+		for _, view := range views {
+			if err := tx.Where("manga_id = ? AND day = ?", view.MangaID, view.Day).Delete(&domain.MangaView{}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, errors.New("failed to archive views")
+	}
+	return len(views), nil
+}
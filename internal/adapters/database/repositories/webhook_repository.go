@@ -0,0 +1,144 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"gorm.io/gorm"
+)
+
+// webhookRepository implements the WebhookRepository interface
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookRepository creates a new webhook repository instance
+func NewWebhookRepository(db *gorm.DB) ports.WebhookRepository {
+	return &webhookRepository{
+		db: db,
+	}
+}
+
+// Create creates a new webhook in the database
+func (r *webhookRepository) Create(ctx context.Context, webhook *domain.Webhook) error {
+	if err := withContext(ctx, r.db).Create(webhook).Error; err != nil {
+		return errors.New("failed to create webhook")
+	}
+	return nil
+}
+
+// GetByID retrieves a webhook by ID
+func (r *webhookRepository) GetByID(ctx context.Context, id uint) (*domain.Webhook, error) {
+	var webhook domain.Webhook
+	if err := withContext(ctx, r.db).First(&webhook, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("webhook not found")
+		}
+		return nil, errors.New("failed to get webhook")
+	}
+	return &webhook, nil
+}
+
+// ListByUserID retrieves all webhooks registered by a user
+func (r *webhookRepository) ListByUserID(ctx context.Context, userID uint) ([]*domain.Webhook, error) {
+	var webhooks []*domain.Webhook
+	if err := withContext(ctx, r.db).Where("user_id = ?", userID).Order("id").Find(&webhooks).Error; err != nil {
+		return nil, errors.New("failed to get webhooks")
+	}
+	return webhooks, nil
+}
+
+// Delete soft deletes a webhook from the database
+func (r *webhookRepository) Delete(ctx context.Context, id uint) error {
+	if err := withContext(ctx, r.db).Delete(&domain.Webhook{}, id).Error; err != nil {
+		return errors.New("failed to delete webhook")
+	}
+	return nil
+}
+
+// CreateDelivery records a new webhook delivery
+func (r *webhookRepository) CreateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	if err := withContext(ctx, r.db).Create(delivery).Error; err != nil {
+		return errors.New("failed to create webhook delivery")
+	}
+	return nil
+}
+
+// UpdateDelivery updates a webhook delivery record
+func (r *webhookRepository) UpdateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	if err := withContext(ctx, r.db).Omit("AttemptLog").Save(delivery).Error; err != nil {
+		return errors.New("failed to update webhook delivery")
+	}
+	return nil
+}
+
+// ListDeliveriesPaginated retrieves deliveries for a webhook, newest first, with pagination
+func (r *webhookRepository) ListDeliveriesPaginated(ctx context.Context, webhookID uint, pagination *domain.PaginationRequest) ([]*domain.WebhookDelivery, int64, error) {
+	var deliveries []*domain.WebhookDelivery
+	var total int64
+
+	// Count total deliveries
+	if err := withContext(ctx, r.db).Model(&domain.WebhookDelivery{}).Where("webhook_id = ?", webhookID).Count(&total).Error; err != nil {
+		return nil, 0, errors.New("failed to count webhook deliveries")
+	}
+
+	// Get paginated results
+	offset := pagination.GetOffset()
+	limit := pagination.GetLimit()
+
+	if err := withContext(ctx, r.db).Where("webhook_id = ?", webhookID).Order("id DESC").Offset(offset).Limit(limit).Find(&deliveries).Error; err != nil {
+		return nil, 0, errors.New("failed to get webhook deliveries")
+	}
+
+	return deliveries, total, nil
+}
+
+// GetDeliveryByID retrieves a webhook delivery with its attempts in order
+func (r *webhookRepository) GetDeliveryByID(ctx context.Context, id uint) (*domain.WebhookDelivery, error) {
+	var delivery domain.WebhookDelivery
+	if err := withContext(ctx, r.db).Preload("AttemptLog", func(db *gorm.DB) *gorm.DB {
+		return db.Order("attempt")
+	}).First(&delivery, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("webhook delivery not found")
+		}
+		return nil, errors.New("failed to get webhook delivery")
+	}
+	return &delivery, nil
+}
+
+// ListDeliveriesByStatusPaginated retrieves deliveries across webhooks, newest first, with pagination
+func (r *webhookRepository) ListDeliveriesByStatusPaginated(ctx context.Context, status string, pagination *domain.PaginationRequest) ([]*domain.WebhookDelivery, int64, error) {
+	var deliveries []*domain.WebhookDelivery
+	var total int64
+
+	query := withContext(ctx, r.db).Model(&domain.WebhookDelivery{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	// Count total deliveries
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.New("failed to count webhook deliveries")
+	}
+
+	// Get paginated results
+	offset := pagination.GetOffset()
+	limit := pagination.GetLimit()
+
+	if err := query.Order("id DESC").Offset(offset).Limit(limit).Find(&deliveries).Error; err != nil {
+		return nil, 0, errors.New("failed to get webhook deliveries")
+	}
+
+	return deliveries, total, nil
+}
+
+// CreateDeliveryAttempt records one send attempt of a delivery
+func (r *webhookRepository) CreateDeliveryAttempt(ctx context.Context, attempt *domain.WebhookDeliveryAttempt) error {
+	if err := withContext(ctx, r.db).Create(attempt).Error; err != nil {
+		return errors.New("failed to record webhook delivery attempt")
+	}
+	return nil
+}
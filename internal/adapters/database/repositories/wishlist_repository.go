@@ -0,0 +1,165 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"gorm.io/gorm"
+)
+
+// wishlistRepository implements the WishlistRepository interface
+type wishlistRepository struct {
+	db *gorm.DB
+}
+
+// NewWishlistRepository creates a new wishlist repository instance
+func NewWishlistRepository(db *gorm.DB) ports.WishlistRepository {
+	return &wishlistRepository{
+		db: db,
+	}
+}
+
+// withItems preloads a wishlist's items in order, with their mangas and genres
+func (r *wishlistRepository) withItems(ctx context.Context) *gorm.DB {
+	return withContext(ctx, r.db).
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("position, id") }).
+		Preload("Items.Manga").
+		Preload("Items.Manga.Genres")
+}
+
+// Create creates a new wishlist in the database
+func (r *wishlistRepository) Create(ctx context.Context, wishlist *domain.Wishlist) error {
+	if err := withContext(ctx, r.db).Create(wishlist).Error; err != nil {
+		return errors.New("failed to create wishlist")
+	}
+	return nil
+}
+
+// GetByID retrieves a wishlist by ID
+func (r *wishlistRepository) GetByID(ctx context.Context, id uint) (*domain.Wishlist, error) {
+	var wishlist domain.Wishlist
+	if err := r.withItems(ctx).First(&wishlist, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("wishlist not found")
+		}
+		return nil, errors.New("failed to get wishlist")
+	}
+	return &wishlist, nil
+}
+
+// GetByShareToken retrieves a wishlist by its share token
+func (r *wishlistRepository) GetByShareToken(ctx context.Context, token string) (*domain.Wishlist, error) {
+	var wishlist domain.Wishlist
+	if err := r.withItems(ctx).Where("share_token = ?", token).First(&wishlist).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("wishlist not found")
+		}
+		return nil, errors.New("failed to get wishlist")
+	}
+	return &wishlist, nil
+}
+
+// ListByUserID retrieves a user's wishlists, optionally only the public ones
+func (r *wishlistRepository) ListByUserID(ctx context.Context, userID uint, publicOnly bool) ([]*domain.Wishlist, error) {
+	var wishlists []*domain.Wishlist
+	query := r.withItems(ctx).Where("user_id = ?", userID)
+	if publicOnly {
+		query = query.Where("visibility = ?", domain.WishlistPublic)
+	}
+	if err := query.Order("id").Find(&wishlists).Error; err != nil {
+		return nil, errors.New("failed to get wishlists")
+	}
+	return wishlists, nil
+}
+
+// CountByUserID counts a user's wishlists
+func (r *wishlistRepository) CountByUserID(ctx context.Context, userID uint) (int64, error) {
+	var count int64
+	if err := withContext(ctx, r.db).Model(&domain.Wishlist{}).Where("user_id = ?", userID).Count(&count).Error; err != nil {
+		return 0, errors.New("failed to count wishlists")
+	}
+	return count, nil
+}
+
+// Update updates a wishlist's own fields
+func (r *wishlistRepository) Update(ctx context.Context, wishlist *domain.Wishlist) error {
+	if err := withContext(ctx, r.db).Omit("Items").Save(wishlist).Error; err != nil {
+		return errors.New("failed to update wishlist")
+	}
+	return nil
+}
+
+// Delete deletes a wishlist with its items
+func (r *wishlistRepository) Delete(ctx context.Context, id uint) error {
+	err := withContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("wishlist_id = ?", id).Delete(&domain.WishlistItem{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.Wishlist{}, id).Error
+	})
+	if err != nil {
+		return errors.New("failed to delete wishlist")
+	}
+	return nil
+}
+
+// AddItem appends a manga to the end of a wishlist
+func (r *wishlistRepository) AddItem(ctx context.Context, item *domain.WishlistItem) error {
+	err := withContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var last int
+		if err := tx.Model(&domain.WishlistItem{}).Where("wishlist_id = ?", item.WishlistID).
+			Select("COALESCE(MAX(position), 0)").Scan(&last).Error; err != nil {
+			return err
+		}
+		item.Position = last + 1
+		return tx.Omit("Manga").Create(item).Error
+	})
+	if err != nil {
+		return errors.New("failed to add wishlist item")
+	}
+	return nil
+}
+
+// RemoveItem removes a manga from a wishlist
+func (r *wishlistRepository) RemoveItem(ctx context.Context, wishlistID, mangaID uint) error {
+	result := withContext(ctx, r.db).Where("wishlist_id = ? AND manga_id = ?", wishlistID, mangaID).Delete(&domain.WishlistItem{})
+	if result.Error != nil {
+		return errors.New("failed to remove wishlist item")
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("wishlist item not found")
+	}
+	return nil
+}
+
+// ReorderItems renumbers the given items 1..n in order
+func (r *wishlistRepository) ReorderItems(ctx context.Context, wishlistID uint, mangaIDs []uint) error {
+	err := withContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		for i, mangaID := range mangaIDs {
+			if err := tx.Model(&domain.WishlistItem{}).Where("wishlist_id = ? AND manga_id = ?", wishlistID, mangaID).
+				UpdateColumn("position", i+1).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.New("failed to reorder wishlist")
+	}
+	return nil
+}
+
+// ListUserIDsByManga returns the users with the manga on any of their wishlists
+func (r *wishlistRepository) ListUserIDsByManga(ctx context.Context, mangaID uint) ([]uint, error) {
+	var userIDs []uint
+	if err := withContext(ctx, r.db).Model(&domain.Wishlist{}).
+		Distinct("wishlists.user_id").
+		Joins("JOIN wishlist_items ON wishlist_items.wishlist_id = wishlists.id").
+		Where("wishlist_items.manga_id = ?", mangaID).
+		Pluck("wishlists.user_id", &userIDs).Error; err != nil {
+		return nil, errors.New("failed to get wishlisting users")
+	}
+	return userIDs, nil
+}
@@ -0,0 +1,50 @@
+package database
+
+import (
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// mangaNormalizedName is the expression duplicate detection matches name
+// prefixes against; it must stay in sync with FindByNamePrefix
+const mangaNormalizedName = "regexp_replace(lower(name), '[^[:alnum:]]', '', 'g')"
+
+// searchIndexStatements create the Postgres search structures of mangas:
+//   - search_vector, a tsvector of the name Postgres keeps up to date, for
+//     word searches. The simple configuration neither stems nor drops stop
+//     words, since names come in many languages.
+//   - an index on the normalized name for duplicate detection's prefix lookups
+var searchIndexStatements = []string{
+	"ALTER TABLE mangas ADD COLUMN IF NOT EXISTS search_vector tsvector " +
+		"GENERATED ALWAYS AS (to_tsvector('simple', coalesce(name, ''))) STORED",
+	"CREATE INDEX IF NOT EXISTS idx_mangas_search_vector ON mangas USING gin (search_vector)",
+	"CREATE INDEX IF NOT EXISTS idx_mangas_name_normalized ON mangas (" + mangaNormalizedName + " text_pattern_ops)",
+}
+
+// migrateSearchIndexes creates the search structures on Postgres. The trigram
+// index serving substring (ILIKE '%...%') searches needs the pg_trgm extension;
+// when the database user may not create it, searches still work, only slower.
+// SQLite databases are small enough to scan.
+func migrateSearchIndexes(db *gorm.DB) error {
+	if db.Dialector.Name() == "sqlite" {
+		return nil
+	}
+
+	for _, statement := range searchIndexStatements {
+		if err := db.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+
+	// A failed statement aborts a Postgres transaction, so the extension is
+	// created in a transaction (or savepoint) of its own
+	err := db.Transaction(func(tx *gorm.DB) error {
+		return tx.Exec("CREATE EXTENSION IF NOT EXISTS pg_trgm").Error
+	})
+	if err != nil {
+		log.Printf("pg_trgm is unavailable, manga name searches will not use an index: %v", err)
+		return nil
+	}
+	return db.Exec("CREATE INDEX IF NOT EXISTS idx_mangas_name_trgm ON mangas USING gin (name gin_trgm_ops)").Error
+}
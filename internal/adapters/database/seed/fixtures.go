@@ -0,0 +1,79 @@
+package seed
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Fixtures is one environment's set of records to seed
+type Fixtures struct {
+	Users  []UserFixture  `json:"users" yaml:"users"`
+	Genres []GenreFixture `json:"genres" yaml:"genres"`
+	Mangas []MangaFixture `json:"mangas" yaml:"mangas"`
+}
+
+// UserFixture describes a user. The password is given directly, or read from
+// the environment variable named by PasswordEnv so real credentials stay out
+// of the repository.
+type UserFixture struct {
+	Name        string `json:"name" yaml:"name"`
+	Email       string `json:"email" yaml:"email"`
+	Password    string `json:"password" yaml:"password"`
+	PasswordEnv string `json:"password_env" yaml:"password_env"`
+	Role        string `json:"role" yaml:"role"`
+}
+
+// GenreFixture describes a genre, identified by its slug
+type GenreFixture struct {
+	Name string `json:"name" yaml:"name"`
+	Slug string `json:"slug" yaml:"slug"`
+}
+
+// MangaFixture describes a manga, identified by its name and owner. Owner is
+// the email of a user and Genres lists genre slugs.
+type MangaFixture struct {
+	Name              string   `json:"name" yaml:"name"`
+	Owner             string   `json:"owner" yaml:"owner"`
+	Price             float64  `json:"price" yaml:"price"`
+	Inactive          bool     `json:"inactive" yaml:"inactive"`
+	Stock             int      `json:"stock" yaml:"stock"`
+	PublicationStatus string   `json:"publication_status" yaml:"publication_status"`
+	ContentRating     string   `json:"content_rating" yaml:"content_rating"`
+	Genres            []string `json:"genres" yaml:"genres"`
+}
+
+// fixtureExtensions are the fixture file formats, in lookup order
+var fixtureExtensions = []string{".yaml", ".yml", ".json"}
+
+// LoadFixtures reads the fixture set of an environment from dir/<env>.yaml,
+// .yml or .json
+func LoadFixtures(dir, env string) (*Fixtures, error) {
+	for _, ext := range fixtureExtensions {
+		path := filepath.Join(dir, env+ext)
+		data, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		var fixtures Fixtures
+		if ext == ".json" {
+			err = json.Unmarshal(data, &fixtures)
+		} else {
+			err = yaml.Unmarshal(data, &fixtures)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid fixtures in %s: %w", path, err)
+		}
+		return &fixtures, nil
+	}
+
+	return nil, fmt.Errorf("no fixtures for environment %q in %s", env, dir)
+}
@@ -0,0 +1,225 @@
+package seed
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/thitiphongD/my-backend/internal/adapters/database/repositories"
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/utils"
+	"gorm.io/gorm"
+)
+
+// Counts tallies the records of one kind a seed run created and found existing
+type Counts struct {
+	Created  int
+	Existing int
+}
+
+// Report summarizes a seed run
+type Report struct {
+	Users  Counts
+	Genres Counts
+	Mangas Counts
+}
+
+// Run seeds the fixtures in a single transaction. Records are matched on their
+// natural key (user email, genre slug, manga name and owner); missing ones are
+// created batchSize at a time and existing ones are left untouched, so running
+// it again is a no-op.
+func Run(db *gorm.DB, fixtures *Fixtures, batchSize int) (*Report, error) {
+	report := &Report{}
+	ctx := context.Background()
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		users := make(map[string]*domain.User, len(fixtures.Users))
+		var newUsers []*domain.User
+		for _, fixture := range fixtures.Users {
+			if _, seen := users[fixture.Email]; seen {
+				report.Users.count(false)
+				continue
+			}
+			user, created, err := seedUser(tx, fixture)
+			if err != nil {
+				return err
+			}
+			users[user.Email] = user
+			if created {
+				newUsers = append(newUsers, user)
+			}
+			report.Users.count(created)
+		}
+		failures := repositories.NewUserRepository(tx).CreateInBatches(ctx, newUsers, batchSize)
+		if err := batchError(failures, func(i int) string { return newUsers[i].Email }); err != nil {
+			return err
+		}
+
+		genres := make(map[string]*domain.Genre, len(fixtures.Genres))
+		for _, fixture := range fixtures.Genres {
+			genre, created, err := seedGenre(tx, fixture)
+			if err != nil {
+				return err
+			}
+			genres[genre.Slug] = genre
+			report.Genres.count(created)
+		}
+
+		seen := make(map[string]bool, len(fixtures.Mangas))
+		var newMangas []*domain.Manga
+		for _, fixture := range fixtures.Mangas {
+			key := fixture.Owner + "\x00" + fixture.Name
+			if seen[key] {
+				report.Mangas.count(false)
+				continue
+			}
+			seen[key] = true
+			manga, err := seedManga(tx, fixture, users, genres)
+			if err != nil {
+				return err
+			}
+			if manga != nil {
+				newMangas = append(newMangas, manga)
+			}
+			report.Mangas.count(manga != nil)
+		}
+		failures = repositories.NewMangaRepository(tx).CreateInBatches(ctx, newMangas, batchSize)
+		return batchError(failures, func(i int) string { return newMangas[i].Name })
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// batchError turns the failures of a batched insert into an error naming the
+// failed records, or nil when there were none
+func batchError(failures []domain.BatchCreateFailure, name func(i int) string) error {
+	if len(failures) == 0 {
+		return nil
+	}
+	msgs := make([]string, len(failures))
+	for i, failure := range failures {
+		msgs[i] = fmt.Sprintf("%s: %s", name(failure.Index), failure.Error)
+	}
+	return errors.New(strings.Join(msgs, "; "))
+}
+
+// count records one seeded record
+func (c *Counts) count(created bool) {
+	if created {
+		c.Created++
+	} else {
+		c.Existing++
+	}
+}
+
+// seedUser finds the user by email or builds a new one with a hashed password,
+// left for the caller to create
+func seedUser(tx *gorm.DB, fixture UserFixture) (*domain.User, bool, error) {
+	if fixture.Email == "" {
+		return nil, false, errors.New("user fixture without email")
+	}
+
+	// Deleted users still hold their email, so they count as existing
+	var user domain.User
+	err := tx.Unscoped().Where("email = ?", fixture.Email).First(&user).Error
+	if err == nil {
+		return &user, false, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, fmt.Errorf("failed to look up user %s: %w", fixture.Email, err)
+	}
+
+	password := fixture.Password
+	if fixture.PasswordEnv != "" {
+		password = os.Getenv(fixture.PasswordEnv)
+	}
+	if password == "" {
+		return nil, false, fmt.Errorf("user %s has no password (set %s)", fixture.Email, cmp.Or(fixture.PasswordEnv, "password"))
+	}
+	hashed, err := utils.HashPassword(password)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to hash password of %s: %w", fixture.Email, err)
+	}
+
+	role := cmp.Or(fixture.Role, domain.RoleUser)
+	if role != domain.RoleUser && role != domain.RoleAdmin && role != domain.RoleSuperAdmin {
+		return nil, false, fmt.Errorf("user %s has unknown role %q", fixture.Email, role)
+	}
+
+	user = domain.User{
+		Name:     fixture.Name,
+		Email:    fixture.Email,
+		Password: hashed,
+		Role:     role,
+	}
+	if !user.IsValid() {
+		return nil, false, fmt.Errorf("invalid user fixture %s", fixture.Email)
+	}
+
+	return &user, true, nil
+}
+
+// seedGenre finds the genre by slug or creates it
+func seedGenre(tx *gorm.DB, fixture GenreFixture) (*domain.Genre, bool, error) {
+	genre := domain.Genre{Name: fixture.Name, Slug: fixture.Slug}
+	if !genre.IsValid() {
+		return nil, false, fmt.Errorf("invalid genre fixture %q", fixture.Slug)
+	}
+
+	result := tx.Where(domain.Genre{Slug: fixture.Slug}).FirstOrCreate(&genre)
+	if result.Error != nil {
+		return nil, false, fmt.Errorf("failed to seed genre %s: %w", fixture.Slug, result.Error)
+	}
+
+	return &genre, result.RowsAffected > 0, nil
+}
+
+// seedManga builds the manga for the caller to create, or returns nil when its
+// owner already has one by that name. Owners and genres must be seeded in the
+// same fixture set.
+func seedManga(tx *gorm.DB, fixture MangaFixture, users map[string]*domain.User, genres map[string]*domain.Genre) (*domain.Manga, error) {
+	owner, ok := users[fixture.Owner]
+	if !ok {
+		return nil, fmt.Errorf("manga %q has unknown owner %q", fixture.Name, fixture.Owner)
+	}
+
+	var count int64
+	if err := tx.Model(&domain.Manga{}).
+		Where("name = ? AND user_created = ?", fixture.Name, owner.ID).
+		Count(&count).Error; err != nil {
+		return nil, fmt.Errorf("failed to look up manga %q: %w", fixture.Name, err)
+	}
+	if count > 0 {
+		return nil, nil
+	}
+
+	manga := domain.Manga{
+		Name:        fixture.Name,
+		Price:       fixture.Price,
+		IsActive:    !fixture.Inactive,
+		UserCreated: owner.ID,
+		Status:      domain.MangaStatusPublished,
+
+		PublicationStatus: cmp.Or(fixture.PublicationStatus, domain.PublicationStatusOngoing),
+		ContentRating:     cmp.Or(fixture.ContentRating, domain.ContentRatingAllAges),
+		StockQuantity:     fixture.Stock,
+	}
+	if !manga.IsValid() || !domain.IsValidPublicationStatus(manga.PublicationStatus) || !domain.IsValidContentRating(manga.ContentRating) {
+		return nil, fmt.Errorf("invalid manga fixture %q", fixture.Name)
+	}
+	for _, slug := range fixture.Genres {
+		genre, ok := genres[slug]
+		if !ok {
+			return nil, fmt.Errorf("manga %q has unknown genre %q", fixture.Name, slug)
+		}
+		manga.Genres = append(manga.Genres, *genre)
+	}
+
+	return &manga, nil
+}
@@ -0,0 +1,79 @@
+package database
+
+import (
+	"database/sql"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/mattn/go-sqlite3"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// sqliteDriverName is the SQLite driver extended with the Postgres functions
+// the repositories use
+const sqliteDriverName = "sqlite3_extended"
+
+var registerSQLiteOnce sync.Once
+
+// sqliteDialector opens the SQLite database at path, or an in-memory database
+// for ":memory:", with foreign keys enforced as in Postgres
+func sqliteDialector(path string) gorm.Dialector {
+	registerSQLiteOnce.Do(func() {
+		sql.Register(sqliteDriverName, &sqlite3.SQLiteDriver{
+			ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+				return conn.RegisterFunc("regexp_replace", regexpReplace, true)
+			},
+		})
+	})
+
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+	dsn := path + separator + "_foreign_keys=on&_busy_timeout=5000"
+
+	return sqlite.New(sqlite.Config{DriverName: sqliteDriverName, DSN: dsn})
+}
+
+// configureSQLitePool keeps SQLite on one long-lived connection: SQLite allows
+// a single writer at a time, and an in-memory database lives only as long as
+// its connection
+func configureSQLitePool(db *sql.DB) {
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+	db.SetConnMaxLifetime(0)
+	db.SetConnMaxIdleTime(0)
+}
+
+// sqliteRegexps caches the patterns compiled by regexpReplace
+var sqliteRegexps sync.Map
+
+// regexpReplace implements Postgres' regexp_replace(source, pattern, replacement,
+// flags) for SQLite. Only the "g" flag (replace every match rather than the first)
+// is supported, and POSIX classes match Unicode letters and digits as in Postgres.
+func regexpReplace(source, pattern, replacement, flags string) (string, error) {
+	cached, ok := sqliteRegexps.Load(pattern)
+	if !ok {
+		translated := strings.NewReplacer("[:alnum:]", `\p{L}\p{N}`, "[:alpha:]", `\p{L}`, "[:digit:]", `\p{N}`).Replace(pattern)
+		re, err := regexp.Compile(translated)
+		if err != nil {
+			return "", err
+		}
+		cached, _ = sqliteRegexps.LoadOrStore(pattern, re)
+	}
+	re := cached.(*regexp.Regexp)
+
+	if strings.Contains(flags, "g") {
+		return re.ReplaceAllLiteralString(source, replacement), nil
+	}
+	replaced := false
+	return re.ReplaceAllStringFunc(source, func(match string) string {
+		if replaced {
+			return match
+		}
+		replaced = true
+		return replacement
+	}), nil
+}
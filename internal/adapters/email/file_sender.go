@@ -0,0 +1,53 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// fileSender implements the EmailSender interface by writing each message to
+// a .eml file, for inspecting emails in development
+type fileSender struct {
+	dir  string
+	from string
+}
+
+// NewFileSender creates an email sender that writes messages into dir
+func NewFileSender(dir, from string) ports.EmailSender {
+	return &fileSender{
+		dir:  dir,
+		from: from,
+	}
+}
+
+// Send writes the message to a file named after the time and the recipient,
+// which any mail client opens
+func (s *fileSender) Send(ctx context.Context, msg *domain.EmailMessage) error {
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return errors.New("failed to create email directory")
+	}
+
+	recipient := strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == os.PathSeparator {
+			return '_'
+		}
+		return r
+	}, msg.To)
+	name := fmt.Sprintf("%s-%s.eml", time.Now().UTC().Format("20060102T150405.000000000"), recipient)
+
+	file := filepath.Join(s.dir, name)
+	if err := os.WriteFile(file, formatMessage(s.from, msg), 0o644); err != nil {
+		return errors.New("failed to write email")
+	}
+	log.Printf("email to %s: %s (%s)", msg.To, msg.Subject, file)
+	return nil
+}
@@ -0,0 +1,24 @@
+package email
+
+import (
+	"context"
+	"log"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// logSender implements the EmailSender interface by logging messages, for
+// development setups without a mail provider
+type logSender struct{}
+
+// NewLogSender creates an email sender that only logs
+func NewLogSender() ports.EmailSender {
+	return &logSender{}
+}
+
+// Send logs the recipient and subject of the message
+func (s *logSender) Send(ctx context.Context, msg *domain.EmailMessage) error {
+	log.Printf("email to %s: %s", msg.To, msg.Subject)
+	return nil
+}
@@ -0,0 +1,59 @@
+package email
+
+import (
+	"errors"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/config"
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// NewSender creates the email sender selected by EMAIL_DRIVER
+func NewSender(cfg *config.Config) (ports.EmailSender, error) {
+	driver := cfg.EmailDriver
+	if driver == "" {
+		driver = "log"
+		if cfg.SMTPHost != "" {
+			driver = "smtp"
+		}
+	}
+
+	switch driver {
+	case "log":
+		return NewLogSender(), nil
+	case "file":
+		return NewFileSender(cfg.EmailDir, cfg.EmailFrom), nil
+	case "smtp":
+		if cfg.SMTPHost == "" {
+			return nil, errors.New("SMTP_HOST is required")
+		}
+		return NewSMTPSender(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.EmailFrom), nil
+	case "sendgrid":
+		if cfg.SendGridAPIKey == "" {
+			return nil, errors.New("SENDGRID_API_KEY is required")
+		}
+		return NewSendGridSender(cfg.SendGridAPIKey, cfg.EmailFrom, 10*time.Second), nil
+	case "ses":
+		return NewSESSender(SESConfig{
+			Region:          cfg.SESRegion,
+			AccessKeyID:     cfg.SESAccessKeyID,
+			SecretAccessKey: cfg.SESSecretAccessKey,
+		}, cfg.EmailFrom, 10*time.Second)
+	default:
+		return nil, errors.New("unsupported EMAIL_DRIVER " + driver + " (use log, file, smtp, sendgrid or ses)")
+	}
+}
+
+// extraHeaders returns the headers a message needs beyond the ones every
+// email has: List-Unsubscribe when it has an unsubscribe link, with the
+// one-click POST of RFC 8058
+func extraHeaders(msg *domain.EmailMessage) [][2]string {
+	if msg.UnsubscribeURL == "" {
+		return nil
+	}
+	return [][2]string{
+		{"List-Unsubscribe", "<" + msg.UnsubscribeURL + ">"},
+		{"List-Unsubscribe-Post", "List-Unsubscribe=One-Click"},
+	}
+}
@@ -0,0 +1,116 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// sendGridURL is the SendGrid v3 mail send endpoint
+const sendGridURL = "https://api.sendgrid.com/v3/mail/send"
+
+// sendGridSender implements the EmailSender interface on the SendGrid API
+type sendGridSender struct {
+	apiKey string
+	from   string
+	client *http.Client
+}
+
+// NewSendGridSender creates an email sender posting to SendGrid with the API key
+func NewSendGridSender(apiKey, from string, timeout time.Duration) ports.EmailSender {
+	return &sendGridSender{
+		apiKey: apiKey,
+		from:   from,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// sendGridAddress is an address in a SendGrid request
+type sendGridAddress struct {
+	Email string `json:"email"`
+}
+
+// sendGridContent is one body of a SendGrid request
+type sendGridContent struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// sendGridPersonalization lists the recipients of a SendGrid request
+type sendGridPersonalization struct {
+	To []sendGridAddress `json:"to"`
+}
+
+// sendGridRequest is the body of a SendGrid mail send request
+type sendGridRequest struct {
+	Personalizations []sendGridPersonalization `json:"personalizations"`
+	From             sendGridAddress           `json:"from"`
+	Subject          string                    `json:"subject"`
+	Content          []sendGridContent         `json:"content"`
+	Headers          map[string]string         `json:"headers,omitempty"`
+}
+
+// Send posts the message to SendGrid
+func (s *sendGridSender) Send(ctx context.Context, msg *domain.EmailMessage) error {
+	content := []sendGridContent{{Type: "text/plain", Value: msg.Body}}
+	if msg.HTML != "" {
+		content = append(content, sendGridContent{Type: "text/html", Value: msg.HTML})
+	}
+
+	var headers map[string]string
+	for _, header := range extraHeaders(msg) {
+		if headers == nil {
+			headers = make(map[string]string)
+		}
+		headers[header[0]] = header[1]
+	}
+
+	payload, err := json.Marshal(&sendGridRequest{
+		Personalizations: []sendGridPersonalization{{To: []sendGridAddress{{Email: msg.To}}}},
+		From:             sendGridAddress{Email: s.from},
+		Subject:          msg.Subject,
+		Content:          content,
+		Headers:          headers,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sendGridURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+s.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return checkAPIResponse("SendGrid", resp)
+}
+
+// checkAPIResponse fails on a non-2xx response of an email API. Client errors
+// other than throttling are rejections; the rest are transient.
+func checkAPIResponse(provider string, resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+		io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+
+	detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	err := fmt.Errorf("%s responded with status %d: %s", provider, resp.StatusCode, bytes.TrimSpace(detail))
+	if resp.StatusCode >= 400 && resp.StatusCode <= 499 && resp.StatusCode != http.StatusTooManyRequests {
+		return fmt.Errorf("%w: %v", domain.ErrEmailRejected, err)
+	}
+	return err
+}
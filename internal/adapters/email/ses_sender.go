@@ -0,0 +1,154 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// SESConfig configures the Amazon SES account email is sent through
+type SESConfig struct {
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// sesSender implements the EmailSender interface on the Amazon SES v2 API,
+// signing requests with AWS Signature Version 4
+type sesSender struct {
+	cfg    SESConfig
+	url    string
+	from   string
+	client *http.Client
+}
+
+// NewSESSender creates an email sender posting to SES in the configured region
+func NewSESSender(cfg SESConfig, from string, timeout time.Duration) (ports.EmailSender, error) {
+	if cfg.Region == "" || cfg.AccessKeyID == "" || cfg.SecretAccessKey == "" {
+		return nil, errors.New("SES region and credentials are required")
+	}
+	return &sesSender{
+		cfg:    cfg,
+		url:    "https://email." + cfg.Region + ".amazonaws.com/v2/email/outbound-emails",
+		from:   from,
+		client: &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// sesContent is a text part of an SES message
+type sesContent struct {
+	Data    string `json:"Data"`
+	Charset string `json:"Charset"`
+}
+
+// sesHeader is an extra header of an SES message
+type sesHeader struct {
+	Name  string `json:"Name"`
+	Value string `json:"Value"`
+}
+
+// sesRequest is the body of an SES SendEmail request
+type sesRequest struct {
+	FromEmailAddress string `json:"FromEmailAddress"`
+	Destination      struct {
+		ToAddresses []string `json:"ToAddresses"`
+	} `json:"Destination"`
+	Content struct {
+		Simple struct {
+			Subject sesContent `json:"Subject"`
+			Body    struct {
+				Text sesContent  `json:"Text"`
+				HTML *sesContent `json:"Html,omitempty"`
+			} `json:"Body"`
+			Headers []sesHeader `json:"Headers,omitempty"`
+		} `json:"Simple"`
+	} `json:"Content"`
+}
+
+// Send posts the message to SES
+func (s *sesSender) Send(ctx context.Context, msg *domain.EmailMessage) error {
+	var body sesRequest
+	body.FromEmailAddress = s.from
+	body.Destination.ToAddresses = []string{msg.To}
+	body.Content.Simple.Subject = sesContent{Data: msg.Subject, Charset: "UTF-8"}
+	body.Content.Simple.Body.Text = sesContent{Data: msg.Body, Charset: "UTF-8"}
+	if msg.HTML != "" {
+		body.Content.Simple.Body.HTML = &sesContent{Data: msg.HTML, Charset: "UTF-8"}
+	}
+	for _, header := range extraHeaders(msg) {
+		body.Content.Simple.Headers = append(body.Content.Simple.Headers, sesHeader{Name: header[0], Value: header[1]})
+	}
+
+	payload, err := json.Marshal(&body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	s.sign(req, payload, time.Now().UTC())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return checkAPIResponse("SES", resp)
+}
+
+// sign adds AWS Signature Version 4 headers to the request
+func (s *sesSender) sign(req *http.Request, payload []byte, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	scope := date + "/" + s.cfg.Region + "/ses/aws4_request"
+	payloadHash := sha256.Sum256(payload)
+	contentHash := hex.EncodeToString(payloadHash[:])
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", contentHash)
+
+	const signedHeaders = "content-type;host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		"content-type:" + req.Header.Get("Content-Type"),
+		"host:" + req.URL.Host,
+		"x-amz-content-sha256:" + contentHash,
+		"x-amz-date:" + amzDate,
+		"",
+		signedHeaders,
+		contentHash,
+	}, "\n")
+	canonicalHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(canonicalHash[:])
+
+	signingKey := hmacSHA256([]byte("AWS4"+s.cfg.SecretAccessKey), date)
+	signingKey = hmacSHA256(signingKey, s.cfg.Region)
+	signingKey = hmacSHA256(signingKey, "ses")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+s.cfg.AccessKeyID+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
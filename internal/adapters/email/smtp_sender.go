@@ -0,0 +1,92 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// smtpSender implements the EmailSender interface over SMTP
+type smtpSender struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+// NewSMTPSender creates a new SMTP email sender; authentication is skipped when username is empty
+func NewSMTPSender(host, port, username, password, from string) ports.EmailSender {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	return &smtpSender{
+		addr: net.JoinHostPort(host, port),
+		auth: auth,
+		from: from,
+	}
+}
+
+// Send delivers the message to the SMTP server. Permanent (5xx) replies are
+// reported as rejections; connection failures and 4xx replies are transient.
+func (s *smtpSender) Send(ctx context.Context, msg *domain.EmailMessage) error {
+	// net/smtp takes no context, so only sends not started yet are cancelled
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	err := smtp.SendMail(s.addr, s.auth, s.from, []string{msg.To}, formatMessage(s.from, msg))
+	var reply *textproto.Error
+	if errors.As(err, &reply) && reply.Code >= 500 {
+		return fmt.Errorf("%w: %v", domain.ErrEmailRejected, err)
+	}
+	return err
+}
+
+// formatMessage renders the message in Internet Message Format, as
+// multipart/alternative when it has an HTML part
+func formatMessage(from string, msg *domain.EmailMessage) []byte {
+	var body strings.Builder
+	fmt.Fprintf(&body, "From: %s\r\n", from)
+	fmt.Fprintf(&body, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&body, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&body, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	for _, header := range extraHeaders(msg) {
+		fmt.Fprintf(&body, "%s: %s\r\n", header[0], header[1])
+	}
+	body.WriteString("MIME-Version: 1.0\r\n")
+
+	if msg.HTML == "" {
+		body.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+		body.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+		return []byte(body.String())
+	}
+
+	parts := multipart.NewWriter(&body)
+	fmt.Fprintf(&body, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", parts.Boundary())
+	for _, part := range []struct{ contentType, content string }{
+		{"text/plain; charset=UTF-8", msg.Body},
+		{"text/html; charset=UTF-8", msg.HTML},
+	} {
+		// Quoted-printable keeps long HTML lines within the SMTP line limit
+		w, _ := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		qp := quotedprintable.NewWriter(w)
+		io.WriteString(qp, part.content)
+		qp.Close()
+	}
+	parts.Close()
+	return []byte(body.String())
+}
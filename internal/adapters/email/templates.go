@@ -0,0 +1,193 @@
+package email
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	htmltemplate "html/template"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+//go:embed templates
+var embeddedTemplates embed.FS
+
+// DefaultTemplates returns the email templates built into the binary
+func DefaultTemplates() fs.FS {
+	templates, _ := fs.Sub(embeddedTemplates, "templates")
+	return templates
+}
+
+// emailTemplate is one email in one locale
+type emailTemplate struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+// emailView is what the templates render: the email's data with its locale and subject
+type emailView struct {
+	*domain.EmailData
+	Locale  string
+	Subject string
+}
+
+// templateRenderer implements the EmailRenderer interface on text and HTML
+// templates. layout.txt and layout.html hold the blocks every email shares.
+// Each locale directory has <name>.txt, which defines the "subject" and
+// "body" of an email, and <name>.html, which defines its HTML "content". A
+// locale's own layout.txt and layout.html may redefine the layout blocks, such
+// as "footer".
+type templateRenderer struct {
+	fsys      fs.FS
+	reload    bool
+	templates map[string]map[string]*emailTemplate // by locale, then name
+}
+
+// NewTemplateRenderer parses the email templates in fsys. With reload, they
+// are parsed again for every email, so edits show up without a restart.
+func NewTemplateRenderer(fsys fs.FS, reload bool) (ports.EmailRenderer, error) {
+	templates, err := parseEmailTemplates(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &templateRenderer{
+		fsys:      fsys,
+		reload:    reload,
+		templates: templates,
+	}, nil
+}
+
+// Render renders the named email in the locale, falling back to English
+func (r *templateRenderer) Render(name, locale string, data *domain.EmailData) (*domain.EmailMessage, error) {
+	templates, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, ok := templates[locale][name]
+	if !ok {
+		locale = domain.LocaleEnglish
+		if tmpl, ok = templates[locale][name]; !ok {
+			return nil, errors.New("email template not found")
+		}
+	}
+
+	view := &emailView{EmailData: data, Locale: locale}
+	var subject, text, html bytes.Buffer
+	if err := tmpl.text.ExecuteTemplate(&subject, "subject", view); err != nil {
+		return nil, err
+	}
+	view.Subject = strings.TrimSpace(subject.String())
+	if err := tmpl.text.ExecuteTemplate(&text, "layout", view); err != nil {
+		return nil, err
+	}
+	if err := tmpl.html.ExecuteTemplate(&html, "layout", view); err != nil {
+		return nil, err
+	}
+
+	return &domain.EmailMessage{
+		Subject: view.Subject,
+		Body:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
+
+// Templates lists every email template with the locales it has variants in
+func (r *templateRenderer) Templates() (map[string][]string, error) {
+	templates, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+
+	locales := make(map[string][]string)
+	for locale, emails := range templates {
+		for name := range emails {
+			locales[name] = append(locales[name], locale)
+		}
+	}
+	for _, list := range locales {
+		slices.Sort(list)
+	}
+	return locales, nil
+}
+
+// load returns the parsed templates, parsing them again when reloading
+func (r *templateRenderer) load() (map[string]map[string]*emailTemplate, error) {
+	if r.reload {
+		return parseEmailTemplates(r.fsys)
+	}
+	return r.templates, nil
+}
+
+// parseEmailTemplates parses every email of every locale directory in fsys
+func parseEmailTemplates(fsys fs.FS) (map[string]map[string]*emailTemplate, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, errors.New("failed to read email templates")
+	}
+
+	templates := make(map[string]map[string]*emailTemplate)
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		locale := entry.Name()
+
+		files, err := fs.Glob(fsys, locale+"/*.txt")
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			name := strings.TrimSuffix(path.Base(file), ".txt")
+			if name == "layout" {
+				continue
+			}
+			tmpl, err := parseEmailTemplate(fsys, locale, name)
+			if err != nil {
+				return nil, errors.New("email template " + locale + "/" + name + ": " + err.Error())
+			}
+			if templates[locale] == nil {
+				templates[locale] = make(map[string]*emailTemplate)
+			}
+			templates[locale][name] = tmpl
+		}
+	}
+	return templates, nil
+}
+
+// parseEmailTemplate parses the layouts, then the locale's layout overrides,
+// then the email itself, so later files redefine the blocks of earlier ones
+func parseEmailTemplate(fsys fs.FS, locale, name string) (*emailTemplate, error) {
+	text := texttemplate.New("layout")
+	html := htmltemplate.New("layout")
+
+	for _, file := range []string{"layout", locale + "/layout", locale + "/" + name} {
+		source, err := fs.ReadFile(fsys, file+".txt")
+		if err == nil {
+			_, err = text.Parse(string(source))
+		} else if errors.Is(err, fs.ErrNotExist) && file == locale+"/layout" {
+			err = nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		source, err = fs.ReadFile(fsys, file+".html")
+		if err == nil {
+			_, err = html.Parse(string(source))
+		} else if errors.Is(err, fs.ErrNotExist) && file == locale+"/layout" {
+			err = nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &emailTemplate{text: text, html: html}, nil
+}
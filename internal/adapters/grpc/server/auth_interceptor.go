@@ -0,0 +1,73 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/internal/utils"
+	"github.com/thitiphongD/my-backend/pkg/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// userContextKey is the context key of the authenticated user
+type userContextKey struct{}
+
+// publicServices lists the services whose calls need no token
+var publicServices = []string{
+	pb.AuthService_ServiceDesc.ServiceName,
+}
+
+// AuthInterceptor authenticates calls with the bearer token in the
+// "authorization" metadata, like the HTTP auth middleware, and stores the
+// user in the call context, with the client IP, as the actor of audited actions
+func AuthInterceptor(authService ports.AuthService) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if p, ok := peer.FromContext(ctx); ok {
+			if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+				ctx = utils.WithClientIP(ctx, host)
+			}
+		}
+
+		for _, service := range publicServices {
+			if strings.HasPrefix(info.FullMethod, "/"+service+"/") {
+				return handler(ctx, req)
+			}
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		values := md.Get("authorization")
+		if len(values) == 0 {
+			return nil, status.Error(codes.Unauthenticated, "authorization metadata is required")
+		}
+
+		token, ok := strings.CutPrefix(values[0], "Bearer ")
+		if !ok || token == "" {
+			return nil, status.Error(codes.Unauthenticated, "authorization metadata must be a bearer token")
+		}
+
+		user, err := authService.ValidateToken(ctx, token)
+		if errors.Is(err, domain.ErrAccountSuspended) {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, "invalid or expired token")
+		}
+
+		ctx = utils.WithActor(ctx, user.ID, user.Role)
+		return handler(context.WithValue(ctx, userContextKey{}, user), req)
+	}
+}
+
+// userFromContext returns the user authenticated by the interceptor
+func userFromContext(ctx context.Context) *domain.User {
+	user, _ := ctx.Value(userContextKey{}).(*domain.User)
+	return user
+}
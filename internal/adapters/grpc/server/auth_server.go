@@ -0,0 +1,79 @@
+package server
+
+import (
+	"context"
+	"errors"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/pkg/pb"
+	"github.com/thitiphongD/my-backend/pkg/validator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AuthServer implements the AuthService gRPC service
+type AuthServer struct {
+	pb.UnimplementedAuthServiceServer
+	authService ports.AuthService
+}
+
+// NewAuthServer creates a new auth gRPC server instance
+func NewAuthServer(authService ports.AuthService) *AuthServer {
+	return &AuthServer{
+		authService: authService,
+	}
+}
+
+// Register creates an account and returns its token
+func (s *AuthServer) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.AuthResponse, error) {
+	registerReq := &domain.RegisterRequest{
+		Name:     req.GetName(),
+		Email:    req.GetEmail(),
+		Password: req.GetPassword(),
+	}
+	if err := validator.Validate(registerReq); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	resp, err := s.authService.Register(ctx, registerReq)
+	if err != nil {
+		return nil, statusFromError(err)
+	}
+
+	return &pb.AuthResponse{Token: resp.Token, User: toPBUser(resp.User, true)}, nil
+}
+
+// Login checks a user's credentials and returns a token
+func (s *AuthServer) Login(ctx context.Context, req *pb.LoginRequest) (*pb.AuthResponse, error) {
+	loginReq := &domain.LoginRequest{
+		Email:    req.GetEmail(),
+		Password: req.GetPassword(),
+	}
+	if err := validator.Validate(loginReq); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	resp, err := s.authService.Login(ctx, loginReq)
+	if err != nil {
+		if err.Error() == "invalid email or password" {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		return nil, statusFromError(err)
+	}
+
+	return &pb.AuthResponse{Token: resp.Token, User: toPBUser(resp.User, true)}, nil
+}
+
+// ValidateToken returns the user a token belongs to
+func (s *AuthServer) ValidateToken(ctx context.Context, req *pb.ValidateTokenRequest) (*pb.User, error) {
+	user, err := s.authService.ValidateToken(ctx, req.GetToken())
+	if err != nil {
+		if errors.Is(err, domain.ErrAccountSuspended) {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+		return nil, status.Error(codes.Unauthenticated, "invalid or expired token")
+	}
+
+	return toPBUser(user, true), nil
+}
@@ -0,0 +1,52 @@
+package server
+
+import (
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/pkg/pb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// toPBUser maps a user to its message; the email and role are only included
+// when private is set, for the user themselves and for admins
+func toPBUser(u *domain.User, private bool) *pb.User {
+	user := &pb.User{
+		Id:        uint32(u.ID),
+		Name:      u.Name,
+		AvatarUrl: u.AvatarURL,
+		CreatedAt: timestamppb.New(u.CreatedAt),
+		UpdatedAt: timestamppb.New(u.UpdatedAt),
+	}
+	if private {
+		user.Email = u.Email
+		user.Role = u.Role
+	}
+	return user
+}
+
+// toPBManga maps a manga to its message
+func toPBManga(m *domain.Manga) *pb.Manga {
+	manga := &pb.Manga{
+		Id:                uint32(m.ID),
+		Name:              m.Name,
+		Price:             m.Price,
+		EffectivePrice:    m.EffectivePrice,
+		IsActive:          m.IsActive,
+		UserCreated:       uint32(m.UserCreated),
+		Status:            m.Status,
+		PublicationStatus: m.PublicationStatus,
+		ContentRating:     m.ContentRating,
+		StockQuantity:     int32(m.StockQuantity),
+		AverageRating:     m.AverageRating,
+		ReviewCount:       int32(m.ReviewCount),
+		CreatedAt:         timestamppb.New(m.CreatedAt),
+		UpdatedAt:         timestamppb.New(m.UpdatedAt),
+	}
+	if m.TeamID != nil {
+		teamID := uint32(*m.TeamID)
+		manga.TeamId = &teamID
+	}
+	for _, genre := range m.Genres {
+		manga.Genres = append(manga.Genres, genre.Name)
+	}
+	return manga
+}
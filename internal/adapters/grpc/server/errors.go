@@ -0,0 +1,29 @@
+package server
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// statusFromError maps service errors to gRPC statuses, following the
+// conventions the HTTP handlers use for status codes
+func statusFromError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrAccountSuspended), errors.Is(err, domain.ErrAgeRestricted):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case strings.HasPrefix(err.Error(), "access denied"):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case strings.HasSuffix(err.Error(), "not found"):
+		return status.Error(codes.NotFound, err.Error())
+	case strings.HasSuffix(err.Error(), "already exists"):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return status.Error(codes.Internal, err.Error())
+	default:
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+}
@@ -0,0 +1,121 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/pkg/pb"
+	"github.com/thitiphongD/my-backend/pkg/validator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MangaServer implements the MangaService gRPC service
+type MangaServer struct {
+	pb.UnimplementedMangaServiceServer
+	mangaService ports.MangaService
+}
+
+// NewMangaServer creates a new manga gRPC server instance
+func NewMangaServer(mangaService ports.MangaService) *MangaServer {
+	return &MangaServer{
+		mangaService: mangaService,
+	}
+}
+
+// GetManga retrieves a published manga; mature mangas need an adult caller
+func (s *MangaServer) GetManga(ctx context.Context, req *pb.GetMangaRequest) (*pb.Manga, error) {
+	caller := userFromContext(ctx)
+
+	manga, err := s.mangaService.GetMangaByID(ctx, uint(req.GetId()), caller.IsAdultAt(time.Now()), nil)
+	if err != nil {
+		return nil, statusFromError(err)
+	}
+
+	return toPBManga(manga), nil
+}
+
+// ListMangas retrieves visible mangas with the same filters as the HTTP listing
+func (s *MangaServer) ListMangas(ctx context.Context, req *pb.ListMangasRequest) (*pb.ListMangasResponse, error) {
+	caller := userFromContext(ctx)
+
+	filter := &domain.MangaFilter{
+		Genre: strings.TrimSpace(req.GetGenre()),
+		Query: strings.TrimSpace(req.GetQuery()),
+
+		PublicationStatus: strings.ToLower(strings.TrimSpace(req.GetPublicationStatus())),
+		ContentRating:     strings.ToLower(strings.TrimSpace(req.GetContentRating())),
+
+		IncludeMature: caller.IsAdultAt(time.Now()),
+	}
+	if req.UserId != nil {
+		userID := uint(req.GetUserId())
+		filter.UserID = &userID
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	sort, err := domain.ParseSort(req.GetSort(), domain.MangaSortableFields)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	pagination := domain.NewPaginationRequest(int(req.GetPage()), int(req.GetPageSize()))
+
+	result, err := s.mangaService.GetMangas(ctx, filter, pagination, sort, nil, nil)
+	if err != nil {
+		return nil, statusFromError(err)
+	}
+
+	resp := &pb.ListMangasResponse{
+		Mangas: make([]*pb.Manga, len(result.Data)),
+		Pagination: &pb.Pagination{
+			CurrentPage: int32(result.Pagination.CurrentPage),
+			PageSize:    int32(result.Pagination.PageSize),
+			TotalItems:  result.Pagination.TotalItems,
+			TotalPages:  int32(result.Pagination.TotalPages),
+		},
+	}
+	for i, manga := range result.Data {
+		resp.Mangas[i] = toPBManga(manga)
+	}
+	return resp, nil
+}
+
+// CreateManga creates a manga owned by the caller
+func (s *MangaServer) CreateManga(ctx context.Context, req *pb.CreateMangaRequest) (*pb.Manga, error) {
+	caller := userFromContext(ctx)
+
+	createReq := &domain.CreateMangaRequest{
+		Name:     req.GetName(),
+		Price:    req.GetPrice(),
+		IsActive: req.GetIsActive(),
+		Stock:    int(req.GetStockQuantity()),
+		Submit:   req.GetSubmit(),
+		Force:    req.GetForce(),
+
+		PublicationStatus: req.GetPublicationStatus(),
+		ContentRating:     req.GetContentRating(),
+	}
+	if req.TeamId != nil {
+		teamID := uint(req.GetTeamId())
+		createReq.TeamID = &teamID
+	}
+	for _, genreID := range req.GetGenreIds() {
+		createReq.GenreIDs = append(createReq.GenreIDs, uint(genreID))
+	}
+	if err := validator.Validate(createReq); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	manga, err := s.mangaService.CreateManga(ctx, createReq, caller.ID)
+	if err != nil {
+		return nil, statusFromError(err)
+	}
+
+	return toPBManga(manga), nil
+}
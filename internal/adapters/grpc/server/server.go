@@ -0,0 +1,26 @@
+package server
+
+import (
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/pkg/pb"
+	"google.golang.org/grpc"
+)
+
+// Services holds the services exposed over gRPC
+type Services struct {
+	Auth  ports.AuthService
+	User  ports.UserService
+	Manga ports.MangaService
+}
+
+// NewServer creates a gRPC server with the Auth, User and Manga services
+// registered behind the token interceptor
+func NewServer(svc *Services) *grpc.Server {
+	s := grpc.NewServer(grpc.UnaryInterceptor(AuthInterceptor(svc.Auth)))
+
+	pb.RegisterAuthServiceServer(s, NewAuthServer(svc.Auth))
+	pb.RegisterUserServiceServer(s, NewUserServer(svc.User))
+	pb.RegisterMangaServiceServer(s, NewMangaServer(svc.Manga))
+
+	return s
+}
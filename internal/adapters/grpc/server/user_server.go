@@ -0,0 +1,52 @@
+package server
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/pkg/pb"
+)
+
+// UserServer implements the UserService gRPC service
+type UserServer struct {
+	pb.UnimplementedUserServiceServer
+	userService ports.UserService
+}
+
+// NewUserServer creates a new user gRPC server instance
+func NewUserServer(userService ports.UserService) *UserServer {
+	return &UserServer{
+		userService: userService,
+	}
+}
+
+// GetUser retrieves a user; email and role are only included for the user
+// themselves and for admins
+func (s *UserServer) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.User, error) {
+	user, err := s.userService.GetUserByID(ctx, uint(req.GetId()))
+	if err != nil {
+		return nil, statusFromError(err)
+	}
+
+	caller := userFromContext(ctx)
+	return toPBUser(user, caller.ID == user.ID || caller.IsAdmin()), nil
+}
+
+// SearchUsers finds users whose name or email matches the query
+func (s *UserServer) SearchUsers(ctx context.Context, req *pb.SearchUsersRequest) (*pb.SearchUsersResponse, error) {
+	limit := int(req.GetLimit())
+	if limit == 0 {
+		limit = 10
+	}
+
+	users, err := s.userService.SearchUsers(ctx, req.GetQuery(), limit)
+	if err != nil {
+		return nil, statusFromError(err)
+	}
+
+	resp := &pb.SearchUsersResponse{Users: make([]*pb.User, len(users))}
+	for i, user := range users {
+		resp.Users[i] = toPBUser(user, false)
+	}
+	return resp, nil
+}
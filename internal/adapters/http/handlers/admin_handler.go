@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/pkg/response"
+	"github.com/thitiphongD/my-backend/pkg/validator"
+)
+
+// AdminHandler handles admin-only HTTP requests
+type AdminHandler struct {
+	userService  ports.UserService
+	quotaService ports.QuotaService
+}
+
+// NewAdminHandler creates a new admin handler instance
+func NewAdminHandler(userService ports.UserService, quotaService ports.QuotaService) *AdminHandler {
+	return &AdminHandler{
+		userService:  userService,
+		quotaService: quotaService,
+	}
+}
+
+// GetUsers handles GET /api/v1/admin/users
+func (h *AdminHandler) GetUsers(c *fiber.Ctx) error {
+	users, err := h.userService.GetUsers(c.UserContext())
+	if err != nil {
+		return response.Error(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.Success(c, users, "Users retrieved successfully")
+}
+
+// SuspendUser handles POST /api/v1/admin/users/:id/suspend
+func (h *AdminHandler) SuspendUser(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid user ID")
+	}
+
+	var req domain.SuspendUserRequest
+	if err := validator.ParseAndValidate(c, &req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	adminID := c.Locals("userID").(uint)
+
+	user, err := h.userService.SuspendUser(c.UserContext(), uint(id), &req, adminID)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	return response.Success(c, user, "User suspended successfully")
+}
+
+// UnsuspendUser handles POST /api/v1/admin/users/:id/unsuspend
+func (h *AdminHandler) UnsuspendUser(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid user ID")
+	}
+
+	user, err := h.userService.UnsuspendUser(c.UserContext(), uint(id))
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	return response.Success(c, user, "User unsuspended successfully")
+}
+
+// MergeUsers handles POST /api/v1/admin/users/merge
+func (h *AdminHandler) MergeUsers(c *fiber.Ctx) error {
+	var req domain.MergeUsersRequest
+	if err := validator.ParseAndValidate(c, &req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	result, err := h.userService.MergeUsers(c.UserContext(), &req)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	if result.DryRun {
+		return response.Success(c, result, "User merge preview generated successfully")
+	}
+	return response.Success(c, result, "Users merged successfully")
+}
+
+// GetUserQuota handles GET /api/v1/admin/users/:id/quota
+func (h *AdminHandler) GetUserQuota(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid user ID")
+	}
+
+	status, err := h.quotaService.GetStatus(c.UserContext(), uint(id))
+	if err != nil {
+		return response.Error(c, fiber.StatusNotFound, err.Error())
+	}
+
+	return response.Success(c, status, "User quota retrieved successfully")
+}
+
+// ResetUserQuota handles POST /api/v1/admin/users/:id/quota/reset
+func (h *AdminHandler) ResetUserQuota(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid user ID")
+	}
+
+	if err := h.quotaService.Reset(c.UserContext(), uint(id)); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	return response.Success(c, nil, "User quota reset successfully")
+}
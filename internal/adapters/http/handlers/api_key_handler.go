@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/pkg/response"
+	"github.com/thitiphongD/my-backend/pkg/validator"
+)
+
+// APIKeyHandler handles HTTP requests for API keys
+type APIKeyHandler struct {
+	apiKeyService ports.APIKeyService
+}
+
+// NewAPIKeyHandler creates a new API key handler instance
+func NewAPIKeyHandler(apiKeyService ports.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyService: apiKeyService,
+	}
+}
+
+// CreateKey handles POST /api/v1/users/me/apikeys
+func (h *APIKeyHandler) CreateKey(c *fiber.Ctx) error {
+	var req domain.CreateAPIKeyRequest
+	if err := validator.ParseAndValidate(c, &req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	key, err := h.apiKeyService.CreateKey(c.UserContext(), userID, &req)
+	if err != nil {
+		return response.Error(c, statusForAPIKeyError(err), err.Error())
+	}
+
+	return response.Created(c, key, "API key created successfully. Store the key now, it will not be shown again")
+}
+
+// GetKeys handles GET /api/v1/users/me/apikeys
+func (h *APIKeyHandler) GetKeys(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	keys, err := h.apiKeyService.GetKeys(c.UserContext(), userID)
+	if err != nil {
+		return response.Error(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.Success(c, keys, "API keys retrieved successfully")
+}
+
+// UpdateKey handles PATCH /api/v1/users/me/apikeys/:id
+func (h *APIKeyHandler) UpdateKey(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid API key ID")
+	}
+
+	var req domain.UpdateAPIKeyRequest
+	if err := validator.ParseAndValidate(c, &req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	key, err := h.apiKeyService.UpdateKey(c.UserContext(), uint(id), userID, &req)
+	if err != nil {
+		return response.Error(c, statusForAPIKeyError(err), err.Error())
+	}
+
+	return response.Success(c, key, "API key updated successfully")
+}
+
+// RotateKey handles POST /api/v1/users/me/apikeys/:id/rotate
+func (h *APIKeyHandler) RotateKey(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid API key ID")
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	key, err := h.apiKeyService.RotateKey(c.UserContext(), uint(id), userID)
+	if err != nil {
+		return response.Error(c, statusForAPIKeyError(err), err.Error())
+	}
+
+	return response.Success(c, key, "API key rotated successfully. Store the new key now, it will not be shown again")
+}
+
+// RevokeKey handles DELETE /api/v1/users/me/apikeys/:id
+func (h *APIKeyHandler) RevokeKey(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid API key ID")
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	key, err := h.apiKeyService.RevokeKey(c.UserContext(), uint(id), userID)
+	if err != nil {
+		return response.Error(c, statusForAPIKeyError(err), err.Error())
+	}
+
+	return response.Success(c, key, "API key revoked successfully")
+}
+
+// ListKeys handles GET /api/v1/admin/apikeys?user_id=&status=&page=1&page_size=10
+func (h *APIKeyHandler) ListKeys(c *fiber.Ctx) error {
+	var userID uint
+	if raw := c.Query("user_id"); raw != "" {
+		id, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			return response.Error(c, fiber.StatusBadRequest, "Invalid user ID")
+		}
+		userID = uint(id)
+	}
+
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size", "10"))
+	pagination := domain.NewPaginationRequest(page, pageSize)
+
+	result, err := h.apiKeyService.ListKeys(c.UserContext(), userID, c.Query("status"), pagination)
+	if err != nil {
+		return response.Error(c, statusForAPIKeyError(err), err.Error())
+	}
+
+	return response.Success(c, withPageLinks(c, result), "API keys retrieved successfully")
+}
+
+// statusForAPIKeyError maps API key service errors to HTTP status codes
+func statusForAPIKeyError(err error) int {
+	switch {
+	case strings.HasSuffix(err.Error(), "not found"):
+		return fiber.StatusNotFound
+	case strings.HasPrefix(err.Error(), "only admins"):
+		return fiber.StatusForbidden
+	case strings.HasSuffix(err.Error(), "is revoked"):
+		return fiber.StatusConflict
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return fiber.StatusInternalServerError
+	default:
+		return fiber.StatusBadRequest
+	}
+}
@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/pkg/response"
+)
+
+// ArchiveHandler handles archiving old records
+type ArchiveHandler struct {
+	archiveService ports.ArchiveService
+}
+
+// NewArchiveHandler creates a new archive handler instance
+func NewArchiveHandler(archiveService ports.ArchiveService) *ArchiveHandler {
+	return &ArchiveHandler{
+		archiveService: archiveService,
+	}
+}
+
+// RunArchive handles POST /admin/archive
+func (h *ArchiveHandler) RunArchive(c *fiber.Ctx) error {
+	report, err := h.archiveService.Run(c.UserContext())
+	if err != nil {
+		return response.Error(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return response.Success(c, report, "Old records archived successfully")
+}
+
+// GetArchiveStats handles GET /admin/archive/stats
+func (h *ArchiveHandler) GetArchiveStats(c *fiber.Ctx) error {
+	return response.Success(c, h.archiveService.Stats(), "Archive stats retrieved successfully")
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/pkg/response"
+)
+
+// AuditHandler handles audit log requests
+type AuditHandler struct {
+	auditService ports.AuditService
+}
+
+// NewAuditHandler creates a new audit handler instance
+func NewAuditHandler(auditService ports.AuditService) *AuditHandler {
+	return &AuditHandler{
+		auditService: auditService,
+	}
+}
+
+// ListAuditLogs handles GET /api/v1/admin/audit-logs?actor_id=1&entity_type=manga&entity_id=2&action=manga.update&from=2026-01-01&to=2026-02-01T12:00:00Z&page=1&page_size=10
+func (h *AuditHandler) ListAuditLogs(c *fiber.Ctx) error {
+	filter := &domain.AuditLogFilter{
+		EntityType: c.Query("entity_type"),
+		Action:     c.Query("action"),
+	}
+
+	var err error
+	if filter.ActorID, err = parseAuditID(c.Query("actor_id")); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "actor_id must be a user ID", "Invalid audit log filter")
+	}
+	if filter.EntityID, err = parseAuditID(c.Query("entity_id")); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "entity_id must be an ID", "Invalid audit log filter")
+	}
+	if filter.From, err = parseAuditTime(c.Query("from")); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "from must be a date like 2026-01-01 or a time like 2026-01-01T12:00:00Z", "Invalid audit log filter")
+	}
+	if filter.To, err = parseAuditTime(c.Query("to")); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "to must be a date like 2026-01-31 or a time like 2026-01-31T12:00:00Z", "Invalid audit log filter")
+	}
+
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size", "10"))
+	pagination := domain.NewPaginationRequest(page, pageSize)
+
+	result, err := h.auditService.GetAuditLogs(c.UserContext(), filter, pagination)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	return response.Success(c, withPageLinks(c, result), "Audit logs retrieved successfully")
+}
+
+// parseAuditID parses an optional ID filter; an empty value matches any ID
+func parseAuditID(value string) (*uint, error) {
+	if value == "" {
+		return nil, nil
+	}
+	id, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return nil, err
+	}
+	parsed := uint(id)
+	return &parsed, nil
+}
+
+// parseAuditTime parses an optional RFC 3339 time, or a date meaning its
+// midnight UTC; an empty value leaves the range open
+func parseAuditTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		if t, err = time.Parse(time.DateOnly, value); err != nil {
+			return nil, err
+		}
+	}
+	return &t, nil
+}
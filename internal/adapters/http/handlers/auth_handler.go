@@ -1,22 +1,42 @@
 package handlers
 
 import (
+	"time"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/thitiphongD/my-backend/internal/core/domain"
 	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/internal/utils"
 	"github.com/thitiphongD/my-backend/pkg/response"
 	"github.com/thitiphongD/my-backend/pkg/validator"
 )
 
+// CookieConfig configures the cookies the API sets
+type CookieConfig struct {
+	// Secure cookies are only sent over HTTPS; turn it off for local
+	// development over plain HTTP
+	Secure bool
+
+	// SameSite is Lax, Strict or None; None lets a frontend on another site
+	// send the cookies, and needs Secure
+	SameSite string
+
+	// AuthMode is how clients send their JWT (utils.AuthModeHeader, Cookie or
+	// Both); login and registration set the auth cookie unless it is header
+	AuthMode string
+}
+
 // AuthHandler handles authentication-related HTTP requests
 type AuthHandler struct {
 	authService ports.AuthService
+	cookies     CookieConfig
 }
 
 // NewAuthHandler creates a new auth handler instance
-func NewAuthHandler(authService ports.AuthService) *AuthHandler {
+func NewAuthHandler(authService ports.AuthService, cookies CookieConfig) *AuthHandler {
 	return &AuthHandler{
 		authService: authService,
+		cookies:     cookies,
 	}
 }
 
@@ -27,13 +47,16 @@ func (h *AuthHandler) Register(c *fiber.Ctx) error {
 	if err := validator.ParseAndValidate(c, &req); err != nil {
 		return response.Error(c, fiber.StatusBadRequest, err.Error())
 	}
+	if req.Locale == "" {
+		req.Locale, _ = requestedLocale(c)
+	}
 
-	authResponse, err := h.authService.Register(&req)
+	authResponse, err := h.authService.Register(c.UserContext(), &req)
 	if err != nil {
 		return response.Error(c, fiber.StatusBadRequest, err.Error())
 	}
 
-	return response.Created(c, authResponse, "User registered successfully")
+	return response.Created(c, h.startSession(c, authResponse), "User registered successfully")
 }
 
 // Login handles user login
@@ -44,12 +67,84 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 		return response.Error(c, fiber.StatusBadRequest, err.Error())
 	}
 
-	authResponse, err := h.authService.Login(&req)
+	authResponse, err := h.authService.Login(c.UserContext(), &req)
 	if err != nil {
 		return response.Error(c, fiber.StatusUnauthorized, err.Error())
 	}
 
-	return response.Success(c, authResponse, "Login successful")
+	return response.Success(c, h.startSession(c, authResponse), "Login successful")
+}
+
+// Logout handles POST /api/v1/auth/logout. It clears the auth cookie; the
+// token itself stays valid until it expires, as tokens aren't stored.
+func (h *AuthHandler) Logout(c *fiber.Ctx) error {
+	c.Cookie(h.authCookie("", time.Unix(0, 0)))
+	return response.Success(c, nil, "Logout successful")
+}
+
+// startSession sets the auth cookie when the auth mode uses it, leaving the
+// token out of the response in cookie mode, so scripts never see it
+func (h *AuthHandler) startSession(c *fiber.Ctx, authResponse *domain.AuthResponse) *domain.AuthResponse {
+	if h.cookies.AuthMode != utils.AuthModeCookie && h.cookies.AuthMode != utils.AuthModeBoth {
+		return authResponse
+	}
+
+	c.Cookie(h.authCookie(authResponse.Token, time.Now().Add(utils.JWTLifetime)))
+	// CSRF tokens are bound to the session, so the new session gets its own
+	if claims, err := utils.ValidateJWT(authResponse.Token); err == nil {
+		if token, err := utils.GenerateCSRFToken(claims.Session()); err == nil {
+			c.Cookie(h.csrfCookie(token))
+		}
+	}
+	if h.cookies.AuthMode == utils.AuthModeCookie {
+		authResponse.Token = ""
+	}
+	return authResponse
+}
+
+// authCookie returns the HttpOnly cookie carrying token until expires
+func (h *AuthHandler) authCookie(token string, expires time.Time) *fiber.Cookie {
+	return &fiber.Cookie{
+		Name:     utils.AuthCookieName,
+		Value:    token,
+		Path:     "/",
+		Expires:  expires,
+		Secure:   h.cookies.Secure,
+		HTTPOnly: true,
+		SameSite: h.cookies.SameSite,
+	}
+}
+
+// GetCSRFToken handles GET /api/v1/auth/csrf. It issues a CSRF token for the
+// session of the auth cookie in the csrf_token cookie and the response;
+// cookie sessions send it back in the X-CSRF-Token header of state-changing
+// requests.
+func (h *AuthHandler) GetCSRFToken(c *fiber.Ctx) error {
+	claims, err := utils.ValidateJWT(c.Cookies(utils.AuthCookieName))
+	if err != nil {
+		return response.Error(c, fiber.StatusUnauthorized, "Sign in with a cookie session to get a CSRF token")
+	}
+
+	token, err := utils.GenerateCSRFToken(claims.Session())
+	if err != nil {
+		return response.Error(c, fiber.StatusInternalServerError, "Failed to issue CSRF token")
+	}
+	c.Cookie(h.csrfCookie(token))
+
+	return response.Success(c, fiber.Map{"csrf_token": token}, "CSRF token issued successfully")
+}
+
+// csrfCookie returns the cookie carrying a CSRF token. It is readable by
+// scripts, which copy it into the header, and sent to the same sites as the
+// auth cookie.
+func (h *AuthHandler) csrfCookie(token string) *fiber.Cookie {
+	return &fiber.Cookie{
+		Name:     utils.CSRFCookieName,
+		Value:    token,
+		Path:     "/",
+		Secure:   h.cookies.Secure,
+		SameSite: h.cookies.SameSite,
+	}
 }
 
 // GetMe returns the current authenticated user's information
@@ -59,7 +154,7 @@ func (h *AuthHandler) GetMe(c *fiber.Ctx) error {
 		return response.Error(c, fiber.StatusUnauthorized, "User not authenticated")
 	}
 
-	user, err := h.authService.GetUserByID(userID)
+	user, err := h.authService.GetUserByID(c.UserContext(), userID)
 	if err != nil {
 		return response.Error(c, fiber.StatusInternalServerError, err.Error())
 	}
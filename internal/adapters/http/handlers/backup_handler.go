@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/pkg/response"
+)
+
+// BackupHandler handles database backup and restore requests
+type BackupHandler struct {
+	backupService ports.BackupService
+}
+
+// NewBackupHandler creates a new backup handler instance
+func NewBackupHandler(backupService ports.BackupService) *BackupHandler {
+	return &BackupHandler{
+		backupService: backupService,
+	}
+}
+
+// CreateBackup handles POST /admin/backups
+func (h *BackupHandler) CreateBackup(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	job, err := h.backupService.StartBackup(c.UserContext(), &userID)
+	if err != nil {
+		return response.Error(c, statusForBackupError(err), err.Error())
+	}
+
+	return response.Accepted(c, job, "Backup started")
+}
+
+// ListBackupJobs handles GET /admin/backups?kind=backup&page=1&page_size=10
+func (h *BackupHandler) ListBackupJobs(c *fiber.Ctx) error {
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size", "10"))
+	pagination := domain.NewPaginationRequest(page, pageSize)
+
+	result, err := h.backupService.ListJobs(c.UserContext(), c.Query("kind"), pagination)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	return response.Success(c, withPageLinks(c, result), "Backup jobs retrieved successfully")
+}
+
+// GetBackupJob handles GET /admin/backups/:id
+func (h *BackupHandler) GetBackupJob(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid job ID")
+	}
+
+	job, err := h.backupService.GetJob(c.UserContext(), uint(id))
+	if err != nil {
+		return response.Error(c, fiber.StatusNotFound, err.Error())
+	}
+
+	return response.Success(c, job, "Backup job retrieved successfully")
+}
+
+// RestoreBackup handles POST /admin/backups/:id/restore
+func (h *BackupHandler) RestoreBackup(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid backup ID")
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	job, err := h.backupService.StartRestore(c.UserContext(), uint(id), &userID)
+	if err != nil {
+		return response.Error(c, statusForBackupError(err), err.Error())
+	}
+
+	return response.Accepted(c, job, "Restore started")
+}
+
+// statusForBackupError maps backup service errors to HTTP status codes
+func statusForBackupError(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrBackupInProgress):
+		return fiber.StatusConflict
+	case strings.HasSuffix(err.Error(), "not found"):
+		return fiber.StatusNotFound
+	case strings.HasPrefix(err.Error(), "only succeeded backups"):
+		return fiber.StatusUnprocessableEntity
+	default:
+		return fiber.StatusInternalServerError
+	}
+}
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/pkg/response"
+)
+
+// BookHandler handles HTTP requests for book catalogue lookups
+type BookHandler struct {
+	bookService ports.BookService
+}
+
+// NewBookHandler creates a new book handler instance
+func NewBookHandler(bookService ports.BookService) *BookHandler {
+	return &BookHandler{
+		bookService: bookService,
+	}
+}
+
+// LookupISBN handles GET /api/v1/mangas/lookup/isbn/:isbn
+func (h *BookHandler) LookupISBN(c *fiber.Ctx) error {
+	result, err := h.bookService.LookupISBN(c.UserContext(), c.Params("isbn"))
+	switch {
+	case errors.Is(err, domain.ErrInvalidISBN):
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	case errors.Is(err, domain.ErrBookNotFound):
+		return response.Error(c, fiber.StatusNotFound, err.Error())
+	case err != nil:
+		return response.Error(c, fiber.StatusBadGateway, "book catalogue is unavailable")
+	}
+
+	return response.Success(c, result, "Book found")
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/pkg/response"
+	"github.com/thitiphongD/my-backend/pkg/validator"
+)
+
+// CartHandler handles HTTP requests for users' carts
+type CartHandler struct {
+	cartService ports.CartService
+}
+
+// NewCartHandler creates a new cart handler instance
+func NewCartHandler(cartService ports.CartService) *CartHandler {
+	return &CartHandler{
+		cartService: cartService,
+	}
+}
+
+// GetCart handles GET /api/v1/users/me/cart
+func (h *CartHandler) GetCart(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	cart, err := h.cartService.GetCart(c.UserContext(), userID)
+	if err != nil {
+		return response.Error(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.Success(c, cart, "Cart retrieved successfully")
+}
+
+// SetItem handles PUT /api/v1/users/me/cart/items/:mangaID
+func (h *CartHandler) SetItem(c *fiber.Ctx) error {
+	mangaID, err := strconv.ParseUint(c.Params("mangaID"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid manga ID")
+	}
+
+	var req domain.SetCartItemRequest
+	if err := validator.ParseAndValidate(c, &req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	cart, err := h.cartService.SetItem(c.UserContext(), userID, uint(mangaID), &req)
+	if err != nil {
+		return response.Error(c, statusForCartError(err), err.Error())
+	}
+
+	return response.Success(c, cart, "Cart updated successfully")
+}
+
+// RemoveItem handles DELETE /api/v1/users/me/cart/items/:mangaID
+func (h *CartHandler) RemoveItem(c *fiber.Ctx) error {
+	mangaID, err := strconv.ParseUint(c.Params("mangaID"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid manga ID")
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	cart, err := h.cartService.RemoveItem(c.UserContext(), userID, uint(mangaID))
+	if err != nil {
+		return response.Error(c, statusForCartError(err), err.Error())
+	}
+
+	return response.Success(c, cart, "Manga removed from cart")
+}
+
+// ClearCart handles DELETE /api/v1/users/me/cart
+func (h *CartHandler) ClearCart(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	if err := h.cartService.ClearCart(c.UserContext(), userID); err != nil {
+		return response.Error(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.Success(c, nil, "Cart cleared successfully")
+}
+
+// statusForCartError maps cart service errors to HTTP statuses
+func statusForCartError(err error) int {
+	switch {
+	case strings.HasSuffix(err.Error(), "not found"):
+		return fiber.StatusNotFound
+	case strings.HasSuffix(err.Error(), "not available for purchase"):
+		return fiber.StatusUnprocessableEntity
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return fiber.StatusInternalServerError
+	default:
+		return fiber.StatusBadRequest
+	}
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/pkg/response"
+	"github.com/thitiphongD/my-backend/pkg/validator"
+)
+
+// ChapterHandler handles HTTP requests for manga chapters
+type ChapterHandler struct {
+	chapterService ports.ChapterService
+}
+
+// NewChapterHandler creates a new chapter handler instance
+func NewChapterHandler(chapterService ports.ChapterService) *ChapterHandler {
+	return &ChapterHandler{
+		chapterService: chapterService,
+	}
+}
+
+// GetChapters handles GET /api/v1/mangas/:id/chapters
+func (h *ChapterHandler) GetChapters(c *fiber.Ctx) error {
+	mangaID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid manga ID")
+	}
+
+	chapters, err := h.chapterService.GetChapters(c.UserContext(), uint(mangaID))
+	if err != nil {
+		return response.Error(c, fiber.StatusNotFound, err.Error())
+	}
+
+	return response.Success(c, chapters, "Chapters retrieved successfully")
+}
+
+// GetChapter handles GET /api/v1/mangas/:id/chapters/:chapterID
+func (h *ChapterHandler) GetChapter(c *fiber.Ctx) error {
+	mangaID, chapterID, err := parseChapterParams(c)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	chapter, err := h.chapterService.GetChapter(c.UserContext(), mangaID, chapterID)
+	if err != nil {
+		return response.Error(c, fiber.StatusNotFound, err.Error())
+	}
+
+	return response.Success(c, chapter, "Chapter retrieved successfully")
+}
+
+// CreateChapter handles POST /api/v1/mangas/:id/chapters
+func (h *ChapterHandler) CreateChapter(c *fiber.Ctx) error {
+	mangaID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid manga ID")
+	}
+
+	var req domain.ChapterRequest
+	if err := validator.ParseAndValidate(c, &req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	chapter, err := h.chapterService.CreateChapter(c.UserContext(), uint(mangaID), &req, userID)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	return response.Created(c, chapter, "Chapter created successfully")
+}
+
+// UpdateChapter handles PUT /api/v1/mangas/:id/chapters/:chapterID
+func (h *ChapterHandler) UpdateChapter(c *fiber.Ctx) error {
+	mangaID, chapterID, err := parseChapterParams(c)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	var req domain.ChapterRequest
+	if err := validator.ParseAndValidate(c, &req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	chapter, err := h.chapterService.UpdateChapter(c.UserContext(), mangaID, chapterID, &req, userID)
+	if err != nil {
+		return response.Error(c, fiber.StatusForbidden, err.Error())
+	}
+
+	return response.Success(c, chapter, "Chapter updated successfully")
+}
+
+// DeleteChapter handles DELETE /api/v1/mangas/:id/chapters/:chapterID
+func (h *ChapterHandler) DeleteChapter(c *fiber.Ctx) error {
+	mangaID, chapterID, err := parseChapterParams(c)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	if err := h.chapterService.DeleteChapter(c.UserContext(), mangaID, chapterID, userID); err != nil {
+		return response.Error(c, fiber.StatusForbidden, err.Error())
+	}
+
+	return response.Success(c, nil, "Chapter deleted successfully")
+}
+
+// parseChapterParams parses the manga and chapter IDs from the route
+func parseChapterParams(c *fiber.Ctx) (uint, uint, error) {
+	mangaID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return 0, 0, fiber.NewError(fiber.StatusBadRequest, "Invalid manga ID")
+	}
+
+	chapterID, err := strconv.ParseUint(c.Params("chapterID"), 10, 32)
+	if err != nil {
+		return 0, 0, fiber.NewError(fiber.StatusBadRequest, "Invalid chapter ID")
+	}
+
+	return uint(mangaID), uint(chapterID), nil
+}
@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/pkg/response"
+	"github.com/thitiphongD/my-backend/pkg/validator"
+)
+
+// CommentHandler handles HTTP requests for manga comments
+type CommentHandler struct {
+	commentService ports.CommentService
+}
+
+// NewCommentHandler creates a new comment handler instance
+func NewCommentHandler(commentService ports.CommentService) *CommentHandler {
+	return &CommentHandler{
+		commentService: commentService,
+	}
+}
+
+// GetComments handles GET /api/v1/mangas/:id/comments?page=1&page_size=10
+func (h *CommentHandler) GetComments(c *fiber.Ctx) error {
+	mangaID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid manga ID")
+	}
+
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size", "10"))
+	pagination := domain.NewPaginationRequest(page, pageSize)
+
+	result, err := h.commentService.GetCommentsPaginated(c.UserContext(), uint(mangaID), pagination)
+	if err != nil {
+		return response.Error(c, fiber.StatusNotFound, err.Error())
+	}
+
+	return response.Success(c, withPageLinks(c, result), "Comments retrieved successfully")
+}
+
+// CreateComment handles POST /api/v1/mangas/:id/comments
+func (h *CommentHandler) CreateComment(c *fiber.Ctx) error {
+	mangaID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid manga ID")
+	}
+
+	var req domain.CreateCommentRequest
+	if err := validator.ParseAndValidate(c, &req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	comment, err := h.commentService.CreateComment(c.UserContext(), uint(mangaID), &req, userID)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	return response.Created(c, comment, "Comment created successfully")
+}
+
+// UpdateComment handles PUT /api/v1/mangas/:id/comments/:commentID
+func (h *CommentHandler) UpdateComment(c *fiber.Ctx) error {
+	mangaID, commentID, err := parseCommentParams(c)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	var req domain.UpdateCommentRequest
+	if err := validator.ParseAndValidate(c, &req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	comment, err := h.commentService.UpdateComment(c.UserContext(), mangaID, commentID, &req, userID)
+	if err != nil {
+		return response.Error(c, fiber.StatusForbidden, err.Error())
+	}
+
+	return response.Success(c, comment, "Comment updated successfully")
+}
+
+// DeleteComment handles DELETE /api/v1/mangas/:id/comments/:commentID
+func (h *CommentHandler) DeleteComment(c *fiber.Ctx) error {
+	mangaID, commentID, err := parseCommentParams(c)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	if err := h.commentService.DeleteComment(c.UserContext(), mangaID, commentID, userID); err != nil {
+		return response.Error(c, fiber.StatusForbidden, err.Error())
+	}
+
+	return response.Success(c, nil, "Comment deleted successfully")
+}
+
+// ModerateDeleteComment handles DELETE /api/v1/admin/comments/:id
+func (h *CommentHandler) ModerateDeleteComment(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid comment ID")
+	}
+
+	if err := h.commentService.ModerateDeleteComment(c.UserContext(), uint(id)); err != nil {
+		return response.Error(c, fiber.StatusNotFound, err.Error())
+	}
+
+	return response.Success(c, nil, "Comment removed successfully")
+}
+
+// parseCommentParams parses the manga and comment IDs from the route
+func parseCommentParams(c *fiber.Ctx) (uint, uint, error) {
+	mangaID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return 0, 0, fiber.NewError(fiber.StatusBadRequest, "Invalid manga ID")
+	}
+
+	commentID, err := strconv.ParseUint(c.Params("commentID"), 10, 32)
+	if err != nil {
+		return 0, 0, fiber.NewError(fiber.StatusBadRequest, "Invalid comment ID")
+	}
+
+	return uint(mangaID), uint(commentID), nil
+}
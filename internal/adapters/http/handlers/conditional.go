@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// notModified sets the ETag and Last-Modified headers of a detail response and
+// reports whether the client's cached copy is still current, in which case the
+// handler should answer 304. If-None-Match takes precedence over If-Modified-Since.
+func notModified(c *fiber.Ctx, etag string, lastModified time.Time) bool {
+	c.Set(fiber.HeaderETag, etag)
+	c.Set(fiber.HeaderLastModified, lastModified.UTC().Format(http.TimeFormat))
+
+	if ifNoneMatch := c.Get(fiber.HeaderIfNoneMatch); ifNoneMatch != "" {
+		return domain.ETagMatches(ifNoneMatch, etag)
+	}
+
+	if ifModifiedSince := c.Get(fiber.HeaderIfModifiedSince); ifModifiedSince != "" {
+		since, err := http.ParseTime(ifModifiedSince)
+		// HTTP dates have second precision
+		return err == nil && !lastModified.Truncate(time.Second).After(since)
+	}
+
+	return false
+}
@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/pkg/response"
+)
+
+// DashboardHandler handles the admin dashboard
+type DashboardHandler struct {
+	dashboardService ports.DashboardService
+}
+
+// NewDashboardHandler creates a new dashboard handler instance
+func NewDashboardHandler(dashboardService ports.DashboardService) *DashboardHandler {
+	return &DashboardHandler{
+		dashboardService: dashboardService,
+	}
+}
+
+// GetDashboard handles GET /api/v1/admin/dashboard
+func (h *DashboardHandler) GetDashboard(c *fiber.Ctx) error {
+	stats, err := h.dashboardService.GetDashboard(c.UserContext())
+	if err != nil {
+		return response.Error(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.Success(c, stats, "Dashboard retrieved successfully")
+}
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/pkg/response"
+)
+
+// DatabaseHandler handles database introspection requests
+type DatabaseHandler struct {
+	stats ports.DatabaseStats
+}
+
+// NewDatabaseHandler creates a new database handler instance
+func NewDatabaseHandler(stats ports.DatabaseStats) *DatabaseHandler {
+	return &DatabaseHandler{
+		stats: stats,
+	}
+}
+
+// GetPoolStats handles GET /admin/db/pool
+func (h *DatabaseHandler) GetPoolStats(c *fiber.Ctx) error {
+	return response.Success(c, fiber.Map{
+		"pools": h.stats.PoolStats(),
+	}, "Database pool stats retrieved successfully")
+}
+
+// GetHealth handles GET /health, answering 503 when the primary database is down
+func (h *DatabaseHandler) GetHealth(c *fiber.Ctx) error {
+	health := h.stats.Health(c.UserContext())
+	if health.Status == domain.DatabaseStatusDown {
+		return response.Error(c, fiber.StatusServiceUnavailable, "Database unavailable")
+	}
+	return response.Success(c, fiber.Map{
+		"status": health.Status,
+	}, "Service is healthy")
+}
+
+// GetDetailedHealth handles GET /admin/health
+func (h *DatabaseHandler) GetDetailedHealth(c *fiber.Ctx) error {
+	health := h.stats.Health(c.UserContext())
+	if health.Status == domain.DatabaseStatusDown {
+		// Still include the pool stats, which help tell why
+		requestID, _ := c.Locals("requestID").(string)
+		return c.Status(fiber.StatusServiceUnavailable).JSON(response.APIResponse{
+			Success:   false,
+			Error:     health.Error,
+			Data:      health,
+			RequestID: requestID,
+		})
+	}
+	return response.Success(c, health, "Database health retrieved successfully")
+}
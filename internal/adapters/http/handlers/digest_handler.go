@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/pkg/response"
+)
+
+// DigestHandler handles HTTP requests for the user's in-app digests
+type DigestHandler struct {
+	digestService ports.DigestService
+}
+
+// NewDigestHandler creates a new digest handler instance
+func NewDigestHandler(digestService ports.DigestService) *DigestHandler {
+	return &DigestHandler{
+		digestService: digestService,
+	}
+}
+
+// GetDigests handles GET /api/v1/users/me/digests?page=1&page_size=10
+func (h *DigestHandler) GetDigests(c *fiber.Ctx) error {
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size", "10"))
+	pagination := domain.NewPaginationRequest(page, pageSize)
+
+	userID := c.Locals("userID").(uint)
+
+	result, err := h.digestService.GetDigests(c.UserContext(), userID, pagination)
+	if err != nil {
+		return response.Error(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.Success(c, withPageLinks(c, result), "Digests retrieved successfully")
+}
+
+// GetDigest handles GET /api/v1/users/me/digests/:id
+func (h *DigestHandler) GetDigest(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid digest ID")
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	digest, err := h.digestService.GetDigest(c.UserContext(), uint(id), userID)
+	if err != nil {
+		return digestError(c, err)
+	}
+
+	return response.Success(c, digest, "Digest retrieved successfully")
+}
+
+// MarkRead handles POST /api/v1/users/me/digests/:id/read
+func (h *DigestHandler) MarkRead(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid digest ID")
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	digest, err := h.digestService.MarkRead(c.UserContext(), uint(id), userID)
+	if err != nil {
+		return digestError(c, err)
+	}
+
+	return response.Success(c, digest, "Digest marked read")
+}
+
+// digestError responds with the status matching a digest service error
+func digestError(c *fiber.Ctx, err error) error {
+	if strings.HasSuffix(err.Error(), "not found") {
+		return response.Error(c, fiber.StatusNotFound, err.Error())
+	}
+	return response.Error(c, fiber.StatusInternalServerError, err.Error())
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/pkg/response"
+	"github.com/thitiphongD/my-backend/pkg/validator"
+)
+
+// DiscountHandler handles HTTP requests for discount campaigns
+type DiscountHandler struct {
+	discountService ports.DiscountService
+}
+
+// NewDiscountHandler creates a new discount handler instance
+func NewDiscountHandler(discountService ports.DiscountService) *DiscountHandler {
+	return &DiscountHandler{
+		discountService: discountService,
+	}
+}
+
+// GetDiscounts handles GET /api/v1/admin/discounts
+func (h *DiscountHandler) GetDiscounts(c *fiber.Ctx) error {
+	discounts, err := h.discountService.GetDiscounts(c.UserContext())
+	if err != nil {
+		return response.Error(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.Success(c, discounts, "Discounts retrieved successfully")
+}
+
+// CreateDiscount handles POST /api/v1/admin/discounts
+func (h *DiscountHandler) CreateDiscount(c *fiber.Ctx) error {
+	var req domain.DiscountRequest
+	if err := validator.ParseAndValidate(c, &req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	discount, err := h.discountService.CreateDiscount(c.UserContext(), &req)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	return response.Created(c, discount, "Discount created successfully")
+}
+
+// UpdateDiscount handles PUT /api/v1/admin/discounts/:id
+func (h *DiscountHandler) UpdateDiscount(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid discount ID")
+	}
+
+	var req domain.DiscountRequest
+	if err := validator.ParseAndValidate(c, &req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	discount, err := h.discountService.UpdateDiscount(c.UserContext(), uint(id), &req)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	return response.Success(c, discount, "Discount updated successfully")
+}
+
+// DeleteDiscount handles DELETE /api/v1/admin/discounts/:id
+func (h *DiscountHandler) DeleteDiscount(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid discount ID")
+	}
+
+	if err := h.discountService.DeleteDiscount(c.UserContext(), uint(id)); err != nil {
+		return response.Error(c, fiber.StatusNotFound, err.Error())
+	}
+
+	return response.Success(c, nil, "Discount deleted successfully")
+}
@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/pkg/response"
+)
+
+// EmailPreviewHandler renders email templates with sample data, for checking
+// their rendering in development
+type EmailPreviewHandler struct {
+	renderer ports.EmailRenderer
+}
+
+// NewEmailPreviewHandler creates a new email preview handler instance
+func NewEmailPreviewHandler(renderer ports.EmailRenderer) *EmailPreviewHandler {
+	return &EmailPreviewHandler{
+		renderer: renderer,
+	}
+}
+
+// ListTemplates handles GET /dev/emails
+func (h *EmailPreviewHandler) ListTemplates(c *fiber.Ctx) error {
+	templates, err := h.renderer.Templates()
+	if err != nil {
+		return response.Error(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.Success(c, templates, "Email templates retrieved successfully")
+}
+
+// PreviewTemplate handles GET /dev/emails/:name?locale=th&format=html
+func (h *EmailPreviewHandler) PreviewTemplate(c *fiber.Ctx) error {
+	format := c.Query("format", "html")
+	if format != "html" && format != "text" {
+		return response.Error(c, fiber.StatusBadRequest, "format must be html or text")
+	}
+
+	locale := strings.ToLower(c.Query("locale", domain.LocaleEnglish))
+	msg, err := h.renderer.Render(c.Params("name"), locale, sampleEmailData(locale))
+	if err != nil {
+		if strings.HasSuffix(err.Error(), "not found") {
+			return response.Error(c, fiber.StatusNotFound, err.Error())
+		}
+		// Template errors are what designers are looking for, so show them as is
+		return response.Error(c, fiber.StatusUnprocessableEntity, err.Error(), "Failed to render email")
+	}
+
+	c.Set("X-Email-Subject", msg.Subject)
+	if format == "text" {
+		c.Set(fiber.HeaderContentType, fiber.MIMETextPlainCharsetUTF8)
+		return c.SendString("Subject: " + msg.Subject + "\n\n" + msg.Body)
+	}
+	c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+	return c.SendString(msg.HTML)
+}
+
+// sampleEmailData is the made-up recipient, order and manga previews render
+func sampleEmailData(locale string) *domain.EmailData {
+	reservedUntil := time.Now().Add(15 * time.Minute)
+	uploadID := uint(77)
+	reportFrom, reportTo := domain.SellerReportPeriod(domain.SellerReportsWeekly, time.Now())
+	digestFrom, digestTo := domain.DigestPeriod(domain.DigestsDaily, time.Now())
+	return &domain.EmailData{
+		User: &domain.User{
+			ID:     1,
+			Name:   "Somchai",
+			Email:  "somchai@example.com",
+			Locale: locale,
+
+			PendingEmail: "somchai.new@example.com",
+		},
+		Order: &domain.Order{
+			ID:     1042,
+			UserID: 1,
+			Status: domain.OrderStatusPending,
+			Items: []domain.OrderItem{
+				{MangaID: 1, Name: "One Piece Vol. 1", UnitPrice: 120, Quantity: 2, Subtotal: 240},
+				{MangaID: 2, Name: "Naruto Vol. 1", UnitPrice: 95, Quantity: 1, Subtotal: 95},
+			},
+			Shipment: domain.Shipment{
+				Carrier:        "Thailand Post",
+				TrackingNumber: "EF123456789TH",
+				TrackingURL:    "https://track.thailandpost.co.th/?trackNumber=EF123456789TH",
+			},
+			Subtotal: 335,
+			TaxTotal: 21.92,
+			Total:    335,
+			TaxLines: []domain.OrderTaxLine{
+				{Name: "VAT", Country: "TH", Rate: 7, Inclusive: true, TaxableAmount: 313.08, Amount: 21.92},
+			},
+			ReservedUntil: &reservedUntil,
+		},
+		ActionURL:      "https://example.com/action?token=sample",
+		UnsubscribeURL: "https://example.com/api/v1/notifications/unsubscribe?token=sample",
+		Malware: &domain.MalwareReport{
+			Signature:     "Win.Test.EICAR_HDB-1",
+			UploaderID:    1,
+			MangaID:       1,
+			UploadID:      &uploadID,
+			StorageKey:    "mangas/1/sample.jpg",
+			QuarantineKey: domain.MalwareQuarantineKey("mangas/1/sample.jpg"),
+		},
+		Report: &domain.SellerReport{
+			Frequency:    domain.SellerReportsWeekly,
+			From:         reportFrom,
+			To:           reportTo,
+			SalesSummary: domain.SalesSummary{Orders: 12, ItemsSold: 17, Revenue: 1895},
+			Views:        430,
+			TopMangas: []*domain.MangaSales{
+				{MangaID: 1, Name: "One Piece Vol. 1", Sold: 9, Revenue: 1080},
+				{MangaID: 2, Name: "Naruto Vol. 1", Sold: 8, Revenue: 815},
+			},
+		},
+		Digest: &domain.Digest{
+			UserID:    1,
+			Frequency: domain.DigestsDaily,
+			From:      digestFrom,
+			To:        digestTo,
+			Items: []domain.DigestItem{
+				{Kind: domain.DigestItemPriceDrop, PriceDrop: &domain.WishlistPriceDrop{Manga: &domain.Manga{ID: 1, Name: "One Piece Vol. 1"}, OldPrice: 120, NewPrice: 99}},
+				{Kind: domain.DigestItemPriceDrop, PriceDrop: &domain.WishlistPriceDrop{Manga: &domain.Manga{ID: 2, Name: "Naruto Vol. 1"}, OldPrice: 95, NewPrice: 79}},
+			},
+		},
+		Manga: &domain.Manga{
+			ID:              1,
+			Name:            "One Piece Vol. 1",
+			Status:          domain.MangaStatusRejected,
+			RejectionReason: "The cover image is too blurry",
+		},
+		Comment: &domain.Comment{ID: 9, MangaID: 1, UserID: 1, Body: "Can't wait for the next volume!"},
+	}
+}
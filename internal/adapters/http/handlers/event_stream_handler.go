@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/pkg/response"
+)
+
+// streamHeartbeatInterval is how often an idle stream sends a comment line so
+// proxies keep the connection open and closed clients are noticed
+const streamHeartbeatInterval = 15 * time.Second
+
+// EventStreamHandler handles server-sent event streams
+type EventStreamHandler struct {
+	eventStream ports.EventStream
+}
+
+// NewEventStreamHandler creates a new event stream handler instance
+func NewEventStreamHandler(eventStream ports.EventStream) *EventStreamHandler {
+	return &EventStreamHandler{
+		eventStream: eventStream,
+	}
+}
+
+// Stream handles GET /api/v1/events/stream?types=order.placed,order.paid
+func (h *EventStreamHandler) Stream(c *fiber.Ctx) error {
+	var events []string
+	for _, event := range strings.Split(c.Query("types"), ",") {
+		event = strings.TrimSpace(event)
+		if event == "" {
+			continue
+		}
+		if !domain.IsValidWebhookEvent(event) {
+			return response.Error(c, fiber.StatusBadRequest, "unsupported event type: "+event)
+		}
+		events = append(events, event)
+	}
+
+	userID := c.Locals("userID").(uint)
+	ch, unsubscribe := h.eventStream.Subscribe(userID, events)
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer unsubscribe()
+
+		heartbeat := time.NewTicker(streamHeartbeatInterval)
+		defer heartbeat.Stop()
+
+		// Open with a comment so clients see the stream is established
+		fmt.Fprint(w, ": connected\n\n")
+		if err := w.Flush(); err != nil {
+			return
+		}
+
+		for {
+			select {
+			case event := <-ch:
+				data, err := json.Marshal(event.Data)
+				if err != nil {
+					log.Printf("Failed to encode stream event %s: %v", event.Event, err)
+					continue
+				}
+				fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Event, data)
+			case <-heartbeat.C:
+				fmt.Fprint(w, ": heartbeat\n\n")
+			}
+
+			// Flushing fails once the client has gone away
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	})
+
+	return nil
+}
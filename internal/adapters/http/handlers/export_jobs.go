@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// salesReportHeader is the header row of sales report files
+var salesReportHeader = []string{
+	"item_id", "order_id", "ordered_at", "status", "manga_id", "name",
+	"unit_price", "quantity", "subtotal", "tax_amount",
+}
+
+// RegisterExportJobs registers the handlers of the export jobs, which write
+// an export file to the exports storage while reporting their progress, and
+// of the delete_export jobs that remove the file once it expires
+func RegisterExportJobs(jobs ports.JobService, mangaService ports.MangaService, userService ports.UserService, orderService ports.OrderService, exports ports.FileStorage) {
+	jobs.Handle(domain.JobKindExportMangas, func(ctx context.Context, job *domain.Job) error {
+		var args domain.ExportMangasArgs
+		if err := job.DecodeArgs(&args); err != nil {
+			return err
+		}
+		if job.UserID == nil {
+			return fmt.Errorf("%w: export job has no user", domain.ErrPermanentJobFailure)
+		}
+		columns, err := parseMangaExportColumns(strings.Join(args.Columns, ","))
+		if err != nil {
+			return fmt.Errorf("%w: %v", domain.ErrPermanentJobFailure, err)
+		}
+
+		total, err := mangaService.CountExportMangas(ctx, *job.UserID, args.IsAdmin)
+		if err != nil {
+			return err
+		}
+
+		return saveExport(ctx, jobs, exports, job, args.Format, func(w *bufio.Writer) error {
+			var done int64
+			return writeMangaExport(w, args.Format, columns, func(fn func([]*domain.Manga) error) error {
+				return mangaService.ExportMangas(ctx, *job.UserID, args.IsAdmin, func(mangas []*domain.Manga) error {
+					if err := fn(mangas); err != nil {
+						return err
+					}
+					done += int64(len(mangas))
+					return jobs.ReportProgress(ctx, job, done, total)
+				})
+			})
+		})
+	})
+
+	jobs.Handle(domain.JobKindExportPersonalData, func(ctx context.Context, job *domain.Job) error {
+		if job.UserID == nil {
+			return fmt.Errorf("%w: export job has no user", domain.ErrPermanentJobFailure)
+		}
+
+		return saveExport(ctx, jobs, exports, job, "zip", func(w *bufio.Writer) error {
+			archive := zip.NewWriter(w)
+			done := 0
+			err := userService.ExportPersonalData(ctx, *job.UserID, func(table string, rows interface{}, tables int) error {
+				file, err := archive.Create(table + ".json")
+				if err != nil {
+					return err
+				}
+				encoder := json.NewEncoder(file)
+				encoder.SetIndent("", "  ")
+				if err := encoder.Encode(rows); err != nil {
+					return err
+				}
+				done++
+				return jobs.ReportProgress(ctx, job, int64(done), int64(tables))
+			})
+			if err != nil {
+				return err
+			}
+			if err := archive.Close(); err != nil {
+				return err
+			}
+			return w.Flush()
+		})
+	})
+
+	jobs.Handle(domain.JobKindExportSalesReport, func(ctx context.Context, job *domain.Job) error {
+		var args domain.ExportSalesReportArgs
+		if err := job.DecodeArgs(&args); err != nil {
+			return err
+		}
+		if job.UserID == nil {
+			return fmt.Errorf("%w: export job has no user", domain.ErrPermanentJobFailure)
+		}
+
+		return saveExport(ctx, jobs, exports, job, "csv", func(w *bufio.Writer) error {
+			writer := csv.NewWriter(w)
+			if err := writer.Write(salesReportHeader); err != nil {
+				return err
+			}
+			var done int64
+			err := orderService.ExportSales(ctx, *job.UserID, args.From, args.To, func(rows []*domain.SalesReportRow, total int64) error {
+				for _, row := range rows {
+					err := writer.Write([]string{
+						strconv.FormatUint(uint64(row.ItemID), 10),
+						strconv.FormatUint(uint64(row.OrderID), 10),
+						row.OrderedAt.Format(time.RFC3339),
+						row.Status,
+						strconv.FormatUint(uint64(row.MangaID), 10),
+						row.Name,
+						strconv.FormatFloat(row.UnitPrice, 'f', 2, 64),
+						strconv.Itoa(row.Quantity),
+						strconv.FormatFloat(row.Subtotal, 'f', 2, 64),
+						strconv.FormatFloat(row.TaxAmount, 'f', 2, 64),
+					})
+					if err != nil {
+						return err
+					}
+				}
+				writer.Flush()
+				if err := writer.Error(); err != nil {
+					return err
+				}
+				done += int64(len(rows))
+				return jobs.ReportProgress(ctx, job, done, total)
+			})
+			if err != nil {
+				return err
+			}
+			return w.Flush()
+		})
+	})
+
+	jobs.Handle(domain.JobKindDeleteExport, func(ctx context.Context, job *domain.Job) error {
+		var args domain.DeleteExportArgs
+		if err := job.DecodeArgs(&args); err != nil {
+			return err
+		}
+		return exports.Delete(domain.ExportKey(args.JobID, args.Format))
+	})
+}
+
+// saveExport streams the file write produces to the exports storage as the
+// file of the job, then schedules its deletion once it expires
+func saveExport(ctx context.Context, jobs ports.JobService, exports ports.FileStorage, job *domain.Job, format string, write func(w *bufio.Writer) error) error {
+	r, w := io.Pipe()
+	go func() {
+		w.CloseWithError(write(bufio.NewWriter(w)))
+	}()
+	_, err := exports.Save(domain.ExportKey(job.ID, format), r)
+	r.CloseWithError(err)
+	if err != nil {
+		return err
+	}
+
+	_, err = jobs.Enqueue(ctx, domain.DeleteExportArgs{JobID: job.ID, Format: format}, &domain.JobOptions{
+		UserID: job.UserID,
+		RunAt:  time.Now().Add(domain.ExportRetention),
+	})
+	return err
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/pkg/response"
+	"github.com/thitiphongD/my-backend/pkg/validator"
+)
+
+// GenreHandler handles HTTP requests for genre operations
+type GenreHandler struct {
+	genreService ports.GenreService
+}
+
+// NewGenreHandler creates a new genre handler instance
+func NewGenreHandler(genreService ports.GenreService) *GenreHandler {
+	return &GenreHandler{
+		genreService: genreService,
+	}
+}
+
+// GetGenres handles GET /api/v1/genres
+func (h *GenreHandler) GetGenres(c *fiber.Ctx) error {
+	genres, err := h.genreService.GetGenres(c.UserContext())
+	if err != nil {
+		return response.Error(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.Success(c, genres, "Genres retrieved successfully")
+}
+
+// CreateGenre handles POST /api/v1/genres
+func (h *GenreHandler) CreateGenre(c *fiber.Ctx) error {
+	var req domain.GenreRequest
+	if err := validator.ParseAndValidate(c, &req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	genre, err := h.genreService.CreateGenre(c.UserContext(), &req)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	return response.Created(c, genre, "Genre created successfully")
+}
+
+// UpdateGenre handles PUT /api/v1/genres/:id
+func (h *GenreHandler) UpdateGenre(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid genre ID")
+	}
+
+	var req domain.GenreRequest
+	if err := validator.ParseAndValidate(c, &req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	genre, err := h.genreService.UpdateGenre(c.UserContext(), uint(id), &req)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	return response.Success(c, genre, "Genre updated successfully")
+}
+
+// DeleteGenre handles DELETE /api/v1/genres/:id
+func (h *GenreHandler) DeleteGenre(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid genre ID")
+	}
+
+	if err := h.genreService.DeleteGenre(c.UserContext(), uint(id)); err != nil {
+		return response.Error(c, fiber.StatusNotFound, err.Error())
+	}
+
+	return response.Success(c, nil, "Genre deleted successfully")
+}
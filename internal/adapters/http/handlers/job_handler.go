@@ -0,0 +1,317 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/internal/utils"
+	"github.com/thitiphongD/my-backend/pkg/response"
+)
+
+// JobHandler handles background job requests
+type JobHandler struct {
+	jobService ports.JobService
+	exports    ports.FileStorage
+}
+
+// NewJobHandler creates a new job handler instance
+func NewJobHandler(jobService ports.JobService, exports ports.FileStorage) *JobHandler {
+	return &JobHandler{
+		jobService: jobService,
+		exports:    exports,
+	}
+}
+
+// StartMangaExport handles POST /api/v1/mangas/export/jobs?format=csv&columns=id,name,price
+func (h *JobHandler) StartMangaExport(c *fiber.Ctx) error {
+	format := c.Query("format", "csv")
+	if format != "csv" && format != "xlsx" {
+		return response.Error(c, fiber.StatusBadRequest, "format must be csv or xlsx", "Invalid export format")
+	}
+
+	columns, err := parseMangaExportColumns(c.Query("columns"))
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error(), "Invalid export columns")
+	}
+	names := make([]string, len(columns))
+	for i, col := range columns {
+		names[i] = col.name
+	}
+
+	user := c.Locals("user").(*domain.User)
+
+	job, err := h.jobService.Enqueue(c.UserContext(), domain.ExportMangasArgs{
+		Format:  format,
+		Columns: names,
+		IsAdmin: user.IsAdmin(),
+	}, &domain.JobOptions{UserID: &user.ID})
+	if err != nil {
+		return response.Error(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.Accepted(c, job, "Manga export started")
+}
+
+// StartPersonalDataExport handles POST /api/v1/users/me/export
+func (h *JobHandler) StartPersonalDataExport(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	job, err := h.jobService.Enqueue(c.UserContext(), domain.ExportPersonalDataArgs{}, &domain.JobOptions{UserID: &userID})
+	if err != nil {
+		return response.Error(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.Accepted(c, job, "Personal data export started")
+}
+
+// StartSalesReport handles POST /api/v1/orders/sales/export?from=2026-01-01&to=2026-01-31
+func (h *JobHandler) StartSalesReport(c *fiber.Ctx) error {
+	from, err := time.Parse(time.DateOnly, c.Query("from"))
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "from must be a date like 2026-01-01", "Invalid report period")
+	}
+	to, err := time.Parse(time.DateOnly, c.Query("to"))
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "to must be a date like 2026-01-31", "Invalid report period")
+	}
+	// The report includes the whole of its last day
+	to = to.AddDate(0, 0, 1)
+	if !from.Before(to) {
+		return response.Error(c, fiber.StatusBadRequest, "to must not be before from", "Invalid report period")
+	}
+	if to.Sub(from) > domain.MaxSalesReportPeriod {
+		return response.Error(c, fiber.StatusBadRequest, "report period must not exceed a year", "Invalid report period")
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	job, err := h.jobService.Enqueue(c.UserContext(), domain.ExportSalesReportArgs{From: from, To: to}, &domain.JobOptions{UserID: &userID})
+	if err != nil {
+		return response.Error(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.Accepted(c, job, "Sales report started")
+}
+
+// GetUserJob handles GET /api/v1/jobs/:id. Finished export jobs come with a
+// signed URL to download their file from.
+func (h *JobHandler) GetUserJob(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid job ID")
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	job, err := h.jobService.GetUserJob(c.UserContext(), uint(id), userID)
+	if err != nil {
+		return response.Error(c, statusForJobError(err), err.Error())
+	}
+
+	if _, err := domain.ExportFileOf(job); err == nil && job.Status == domain.JobStatusSucceeded && job.FinishedAt != nil {
+		expires := time.Now().Add(domain.ExportDownloadURLExpiry)
+		if expiry := job.FinishedAt.Add(domain.ExportRetention); expiry.Before(expires) {
+			expires = expiry
+		}
+		if time.Now().Before(expires) {
+			signature, err := utils.SignDownload(job.ID, expires)
+			if err != nil {
+				return response.Error(c, fiber.StatusInternalServerError, err.Error())
+			}
+			query := url.Values{}
+			query.Set("expires", strconv.FormatInt(expires.Unix(), 10))
+			query.Set("signature", signature)
+			job.DownloadURL = c.Path() + "/download?" + query.Encode()
+		}
+	}
+
+	return response.Success(c, job, "Job retrieved successfully")
+}
+
+// DownloadExport handles GET /api/v1/jobs/:id/download?expires=1767225600&signature=...
+// A signed URL from GetUserJob downloads the file without signing in;
+// otherwise only the user who ran the job may download it.
+func (h *JobHandler) DownloadExport(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid job ID")
+	}
+
+	var job *domain.Job
+	if signature := c.Query("signature"); signature != "" {
+		expires, _ := strconv.ParseInt(c.Query("expires"), 10, 64)
+		if err := utils.VerifyDownload(uint(id), expires, signature); err != nil {
+			return response.Error(c, fiber.StatusForbidden, err.Error())
+		}
+		job, err = h.jobService.GetJob(c.UserContext(), uint(id))
+	} else {
+		userID, ok := c.Locals("userID").(uint)
+		if !ok {
+			return response.Error(c, fiber.StatusUnauthorized, "Authorization header or download signature required")
+		}
+		job, err = h.jobService.GetUserJob(c.UserContext(), uint(id), userID)
+	}
+	if err != nil {
+		return response.Error(c, statusForJobError(err), err.Error())
+	}
+
+	export, err := domain.ExportFileOf(job)
+	if err != nil {
+		return response.Error(c, statusForJobError(err), err.Error())
+	}
+	if job.Status != domain.JobStatusSucceeded {
+		return response.Error(c, fiber.StatusConflict, "export is not ready", "Job is "+job.Status)
+	}
+	if job.FinishedAt != nil && time.Since(*job.FinishedAt) > domain.ExportRetention {
+		return response.Error(c, fiber.StatusGone, "export has expired")
+	}
+
+	file, err := h.exports.Open(domain.ExportKey(job.ID, export.Format))
+	if err != nil {
+		return response.Error(c, fiber.StatusNotFound, "export file not found")
+	}
+
+	c.Set(fiber.HeaderContentType, export.ContentType)
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="`+export.Name+`"`)
+
+	// The stream is closed once it has been sent
+	return c.SendStream(file)
+}
+
+// ListJobs handles GET /admin/jobs?status=pending,running&kind=send_email&page=1&page_size=10
+func (h *JobHandler) ListJobs(c *fiber.Ctx) error {
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size", "10"))
+	pagination := domain.NewPaginationRequest(page, pageSize)
+
+	var statuses []string
+	if status := c.Query("status"); status != "" {
+		statuses = strings.Split(status, ",")
+	}
+
+	result, err := h.jobService.GetJobs(c.UserContext(), statuses, c.Query("kind"), pagination)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	return response.Success(c, withPageLinks(c, result), "Jobs retrieved successfully")
+}
+
+// GetJob handles GET /admin/jobs/:id. The job comes with its payload.
+func (h *JobHandler) GetJob(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid job ID")
+	}
+
+	job, err := h.jobService.GetJob(c.UserContext(), uint(id))
+	if err != nil {
+		return response.Error(c, statusForJobError(err), err.Error())
+	}
+	job.Args = json.RawMessage(job.Payload)
+
+	return response.Success(c, job, "Job retrieved successfully")
+}
+
+// RetryJob handles POST /admin/jobs/:id/retry
+func (h *JobHandler) RetryJob(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid job ID")
+	}
+
+	job, err := h.jobService.RetryJob(c.UserContext(), uint(id))
+	if err != nil {
+		return response.Error(c, statusForJobError(err), err.Error())
+	}
+
+	return response.Accepted(c, job, "Job queued for retry")
+}
+
+// CancelJob handles POST /admin/jobs/:id/cancel
+func (h *JobHandler) CancelJob(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid job ID")
+	}
+
+	job, err := h.jobService.CancelJob(c.UserContext(), uint(id))
+	if err != nil {
+		return response.Error(c, statusForJobError(err), err.Error())
+	}
+
+	return response.Success(c, job, "Job cancelled successfully")
+}
+
+// DiscardJob handles DELETE /admin/jobs/:id
+func (h *JobHandler) DiscardJob(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid job ID")
+	}
+
+	if err := h.jobService.DiscardJob(c.UserContext(), uint(id)); err != nil {
+		return response.Error(c, statusForJobError(err), err.Error())
+	}
+
+	return response.Success(c, nil, "Job discarded successfully")
+}
+
+// RetryDeadJobs handles POST /admin/jobs/dead/retry?kind=send_email
+func (h *JobHandler) RetryDeadJobs(c *fiber.Ctx) error {
+	requeued, err := h.jobService.RetryDeadJobs(c.UserContext(), c.Query("kind"))
+	if err != nil {
+		return response.Error(c, statusForJobError(err), err.Error())
+	}
+
+	return response.Accepted(c, fiber.Map{"requeued": requeued}, "Dead jobs queued for retry")
+}
+
+// DiscardDeadJobs handles DELETE /admin/jobs/dead?kind=send_email
+func (h *JobHandler) DiscardDeadJobs(c *fiber.Ctx) error {
+	discarded, err := h.jobService.DiscardDeadJobs(c.UserContext(), c.Query("kind"))
+	if err != nil {
+		return response.Error(c, statusForJobError(err), err.Error())
+	}
+
+	return response.Success(c, fiber.Map{"discarded": discarded}, "Dead jobs discarded successfully")
+}
+
+// GetRetryPolicies handles GET /admin/jobs/retry-policies
+func (h *JobHandler) GetRetryPolicies(c *fiber.Ctx) error {
+	return response.Success(c, h.jobService.RetryPolicies(), "Retry policies retrieved successfully")
+}
+
+// ListScheduledTasks handles GET /admin/jobs/scheduled
+func (h *JobHandler) ListScheduledTasks(c *fiber.Ctx) error {
+	return response.Success(c, h.jobService.ScheduledTasks(), "Scheduled tasks retrieved successfully")
+}
+
+// RunScheduledTask handles POST /admin/jobs/scheduled/:task/run
+func (h *JobHandler) RunScheduledTask(c *fiber.Ctx) error {
+	job, err := h.jobService.RunScheduledTask(c.UserContext(), c.Params("task"))
+	if err != nil {
+		return response.Error(c, statusForJobError(err), err.Error())
+	}
+
+	return response.Accepted(c, job, "Scheduled task queued to run")
+}
+
+// statusForJobError maps job service errors to HTTP status codes
+func statusForJobError(err error) int {
+	switch {
+	case strings.HasSuffix(err.Error(), "not found"), strings.HasSuffix(err.Error(), "has no download"):
+		return fiber.StatusNotFound
+	case strings.HasPrefix(err.Error(), "only dead jobs"), strings.HasPrefix(err.Error(), "only pending or running jobs"):
+		return fiber.StatusConflict
+	default:
+		return fiber.StatusInternalServerError
+	}
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/pkg/response"
+	"github.com/thitiphongD/my-backend/pkg/validator"
+)
+
+// LineHandler handles HTTP requests for linking LINE accounts, and the
+// webhook of our LINE official account
+type LineHandler struct {
+	lineService ports.LineService
+}
+
+// NewLineHandler creates a new LINE handler instance
+func NewLineHandler(lineService ports.LineService) *LineHandler {
+	return &LineHandler{
+		lineService: lineService,
+	}
+}
+
+// GetAccount handles GET /api/v1/users/me/line
+func (h *LineHandler) GetAccount(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	account, err := h.lineService.GetAccount(c.UserContext(), userID)
+	if err != nil {
+		return lineError(c, err)
+	}
+
+	return response.Success(c, account, "LINE account retrieved successfully")
+}
+
+// StartLink handles POST /api/v1/users/me/line/link
+func (h *LineHandler) StartLink(c *fiber.Ctx) error {
+	var req domain.StartLineLinkRequest
+	if err := validator.ParseAndValidate(c, &req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	link, err := h.lineService.StartLink(c.UserContext(), userID, &req)
+	if err != nil {
+		return lineError(c, err)
+	}
+
+	return response.Success(c, link, "Confirm the link on LINE")
+}
+
+// Unlink handles DELETE /api/v1/users/me/line
+func (h *LineHandler) Unlink(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	if err := h.lineService.Unlink(c.UserContext(), userID); err != nil {
+		return lineError(c, err)
+	}
+
+	return response.Success(c, nil, "LINE account unlinked successfully")
+}
+
+// Webhook handles POST /api/v1/line/webhook
+func (h *LineHandler) Webhook(c *fiber.Ctx) error {
+	err := h.lineService.HandleWebhook(c.UserContext(), c.Body(), c.Get("X-Line-Signature"))
+	if err != nil {
+		if errors.Is(err, domain.ErrLineDisabled) {
+			return lineError(c, err)
+		}
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	return response.Success(c, nil, "Webhook received")
+}
+
+// lineError responds with the status matching a LINE service error
+func lineError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, domain.ErrLineDisabled):
+		return response.Error(c, fiber.StatusServiceUnavailable, err.Error())
+	case strings.HasSuffix(err.Error(), "not found"):
+		return response.Error(c, fiber.StatusNotFound, err.Error())
+	default:
+		return response.Error(c, fiber.StatusInternalServerError, err.Error())
+	}
+}
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// mangaSubResources maps link relations of a manga to the names of its sub-resource routes
+var mangaSubResources = map[string]string{
+	"chapters":     "mangas.chapters",
+	"reviews":      "mangas.reviews",
+	"comments":     "mangas.comments",
+	"images":       "mangas.images",
+	"translations": "mangas.translations",
+	"related":      "mangas.related",
+}
+
+// routePaths caches the path of each named route; routes are fixed once the app is serving
+var routePaths sync.Map
+
+// linkBuilder builds links from route names (see routes.SetupRoutes), preferring
+// the API version of the current request and falling back to v1 for routes
+// that have no newer version
+type linkBuilder struct {
+	c       *fiber.Ctx
+	version int
+}
+
+func newLinkBuilder(c *fiber.Ctx) *linkBuilder {
+	return &linkBuilder{c: c, version: apiVersion(c)}
+}
+
+// route returns the path of the named route with its parameters filled in, or
+// "" if no version of the route exists
+func (b *linkBuilder) route(name string, params map[string]string) string {
+	path := b.routePath("v" + strconv.Itoa(b.version) + "." + name)
+	if path == "" && b.version != 1 {
+		path = b.routePath("v1." + name)
+	}
+	if path == "" {
+		return ""
+	}
+
+	for key, value := range params {
+		path = strings.Replace(path, ":"+key, url.PathEscape(value), 1)
+	}
+	return path
+}
+
+func (b *linkBuilder) routePath(name string) string {
+	if path, ok := routePaths.Load(name); ok {
+		return path.(string)
+	}
+
+	route := b.c.App().GetRoute(name)
+	if route.Name == "" {
+		return ""
+	}
+	routePaths.Store(name, route.Path)
+	return route.Path
+}
+
+// manga returns the self link of a manga and links to its sub-resources
+func (b *linkBuilder) manga(id uint) domain.Links {
+	params := map[string]string{"id": strconv.FormatUint(uint64(id), 10)}
+
+	links := domain.Links{"self": b.route("mangas.show", params)}
+	for rel, name := range mangaSubResources {
+		if path := b.route(name, params); path != "" {
+			links[rel] = path
+		}
+	}
+	return links
+}
+
+// page returns the current request URL with the page query parameter set, so
+// filters, sorting and page_size carry over to the other pages
+func (b *linkBuilder) page(page int) string {
+	query, _ := url.ParseQuery(string(b.c.Request().URI().QueryString()))
+	query.Set("page", strconv.Itoa(page))
+	return b.c.Path() + "?" + query.Encode()
+}
+
+// pagination returns self, first and last links for a page of results, plus
+// next and prev when those pages exist
+func (b *linkBuilder) pagination(p *domain.PaginationResponse) domain.Links {
+	links := domain.Links{
+		"self":  b.page(p.CurrentPage),
+		"first": b.page(1),
+		"last":  b.page(max(p.TotalPages, 1)),
+	}
+	if p.NextPage != nil {
+		links["next"] = b.page(*p.NextPage)
+	}
+	if p.PreviousPage != nil {
+		links["prev"] = b.page(*p.PreviousPage)
+	}
+	return links
+}
+
+// withPageLinks sets the pagination links of a paginated result
+func withPageLinks[T any](c *fiber.Ctx, result *domain.PaginatedResult[T]) *domain.PaginatedResult[T] {
+	result.Links = newLinkBuilder(c).pagination(result.Pagination)
+	return result
+}
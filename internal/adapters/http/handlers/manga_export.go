@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/xuri/excelize/v2"
+)
+
+// mangaExportColumn describes a selectable export column
+type mangaExportColumn struct {
+	name  string
+	value func(m *domain.Manga) string
+}
+
+// mangaExportColumns lists every column that can be exported, in default order
+var mangaExportColumns = []mangaExportColumn{
+	{"id", func(m *domain.Manga) string { return strconv.FormatUint(uint64(m.ID), 10) }},
+	{"name", func(m *domain.Manga) string { return m.Name }},
+	{"price", func(m *domain.Manga) string { return strconv.FormatFloat(m.Price, 'f', 2, 64) }},
+	{"is_active", func(m *domain.Manga) string { return strconv.FormatBool(m.IsActive) }},
+	{"stock_quantity", func(m *domain.Manga) string { return strconv.Itoa(m.StockQuantity) }},
+	{"publication_status", func(m *domain.Manga) string { return m.PublicationStatus }},
+	{"content_rating", func(m *domain.Manga) string { return m.ContentRating }},
+	{"user_created", func(m *domain.Manga) string { return strconv.FormatUint(uint64(m.UserCreated), 10) }},
+	{"team_id", func(m *domain.Manga) string {
+		if m.TeamID == nil {
+			return ""
+		}
+		return strconv.FormatUint(uint64(*m.TeamID), 10)
+	}},
+	{"genres", func(m *domain.Manga) string {
+		slugs := make([]string, len(m.Genres))
+		for i, genre := range m.Genres {
+			slugs[i] = genre.Slug
+		}
+		return strings.Join(slugs, ";")
+	}},
+	{"average_rating", func(m *domain.Manga) string { return strconv.FormatFloat(m.AverageRating, 'f', 2, 64) }},
+	{"review_count", func(m *domain.Manga) string { return strconv.Itoa(m.ReviewCount) }},
+	{"created_at", func(m *domain.Manga) string { return m.CreatedAt.Format(time.RFC3339) }},
+	{"updated_at", func(m *domain.Manga) string { return m.UpdatedAt.Format(time.RFC3339) }},
+}
+
+// parseMangaExportColumns resolves a comma-separated column list; empty selects all columns
+func parseMangaExportColumns(raw string) ([]mangaExportColumn, error) {
+	if raw == "" {
+		return mangaExportColumns, nil
+	}
+
+	var columns []mangaExportColumn
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(name)
+		found := false
+		for _, col := range mangaExportColumns {
+			if col.name == name {
+				columns = append(columns, col)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, errors.New("unknown export column: " + name)
+		}
+	}
+
+	return columns, nil
+}
+
+// mangaExportWriter writes exported mangas in a specific file format
+type mangaExportWriter interface {
+	WriteRow(values []string) error
+	Close() error
+}
+
+// csvExportWriter writes rows as CSV, flushing after every chunk
+type csvExportWriter struct {
+	w *csv.Writer
+}
+
+func (e *csvExportWriter) WriteRow(values []string) error {
+	return e.w.Write(values)
+}
+
+func (e *csvExportWriter) Close() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// xlsxExportWriter writes rows through the excelize stream writer, which
+// spills to a temporary file instead of holding the sheet in memory
+type xlsxExportWriter struct {
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	out    io.Writer
+	row    int
+}
+
+func newXLSXExportWriter(out io.Writer) (*xlsxExportWriter, error) {
+	file := excelize.NewFile()
+	stream, err := file.NewStreamWriter("Sheet1")
+	if err != nil {
+		return nil, err
+	}
+	return &xlsxExportWriter{file: file, stream: stream, out: out}, nil
+}
+
+func (e *xlsxExportWriter) WriteRow(values []string) error {
+	e.row++
+	cells := make([]interface{}, len(values))
+	for i, v := range values {
+		cells[i] = v
+	}
+	cell, err := excelize.CoordinatesToCellName(1, e.row)
+	if err != nil {
+		return err
+	}
+	return e.stream.SetRow(cell, cells)
+}
+
+func (e *xlsxExportWriter) Close() error {
+	defer e.file.Close()
+	if err := e.stream.Flush(); err != nil {
+		return err
+	}
+	return e.file.Write(e.out)
+}
+
+// writeMangaExport streams mangas chunk by chunk into w
+func writeMangaExport(w *bufio.Writer, format string, columns []mangaExportColumn, export func(fn func([]*domain.Manga) error) error) error {
+	var writer mangaExportWriter
+	if format == "xlsx" {
+		xw, err := newXLSXExportWriter(w)
+		if err != nil {
+			return err
+		}
+		writer = xw
+	} else {
+		writer = &csvExportWriter{w: csv.NewWriter(w)}
+	}
+
+	header := make([]string, len(columns))
+	for i, col := range columns {
+		header[i] = col.name
+	}
+
+	err := writer.WriteRow(header)
+	if err == nil {
+		err = export(func(mangas []*domain.Manga) error {
+			values := make([]string, len(columns))
+			for _, manga := range mangas {
+				for i, col := range columns {
+					values[i] = col.value(manga)
+				}
+				if err := writer.WriteRow(values); err != nil {
+					return err
+				}
+			}
+			if csvWriter, ok := writer.(*csvExportWriter); ok {
+				csvWriter.w.Flush()
+				return w.Flush()
+			}
+			return nil
+		})
+	}
+	if err == nil {
+		err = writer.Close()
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	return err
+}
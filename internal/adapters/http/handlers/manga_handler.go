@@ -1,7 +1,14 @@
 package handlers
 
 import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/thitiphongD/my-backend/internal/core/domain"
@@ -13,16 +20,18 @@ import (
 // MangaHandler handles HTTP requests for manga operations
 type MangaHandler struct {
 	mangaService ports.MangaService
+	viewService  ports.ViewService
 }
 
 // NewMangaHandler creates a new manga handler instance
-func NewMangaHandler(mangaService ports.MangaService) *MangaHandler {
+func NewMangaHandler(mangaService ports.MangaService, viewService ports.ViewService) *MangaHandler {
 	return &MangaHandler{
 		mangaService: mangaService,
+		viewService:  viewService,
 	}
 }
 
-// CreateManga handles POST /api/v1/mangas
+// CreateManga handles POST /api/v1/mangas?force=true
 func (h *MangaHandler) CreateManga(c *fiber.Ctx) error {
 	var req domain.CreateMangaRequest
 
@@ -36,11 +45,20 @@ func (h *MangaHandler) CreateManga(c *fiber.Ctx) error {
 		return response.Error(c, fiber.StatusBadRequest, err, "Validation failed")
 	}
 
+	// Allow skipping duplicate detection from the query string as well as the body
+	if c.QueryBool("force") {
+		req.Force = true
+	}
+
 	// Get user ID from context (set by auth middleware)
 	userID := c.Locals("userID").(uint)
 
 	// Create manga
-	manga, err := h.mangaService.CreateManga(&req, userID)
+	manga, err := h.mangaService.CreateManga(c.UserContext(), &req, userID)
+	var duplicateErr *domain.DuplicateMangaError
+	if errors.As(err, &duplicateErr) {
+		return response.Error(c, fiber.StatusConflict, duplicateErr.Candidates, "Possible duplicate manga found; pass force=true to create anyway")
+	}
 	if err != nil {
 		return response.Error(c, fiber.StatusInternalServerError, err, "Failed to create manga")
 	}
@@ -48,44 +66,287 @@ func (h *MangaHandler) CreateManga(c *fiber.Ctx) error {
 	return response.Created(c, manga, "Manga created successfully")
 }
 
-// GetManga handles GET /api/v1/mangas/:id
+// ImportMangas handles POST /api/v1/mangas/import (multipart field "file", .csv or .json)
+func (h *MangaHandler) ImportMangas(c *fiber.Ctx) error {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error(), "Import file is required")
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error(), "Failed to read import file")
+	}
+	defer file.Close()
+
+	rows, err := parseMangaImport(fileHeader.Filename, file)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error(), "Invalid import file")
+	}
+
+	// Get user ID from context (set by auth middleware)
+	userID := c.Locals("userID").(uint)
+
+	report := h.mangaService.ImportMangas(c.UserContext(), rows, userID)
+
+	return response.Success(c, report, "Manga import completed")
+}
+
+// ExportMangas handles GET /api/v1/mangas/export?format=csv&columns=id,name,price
+func (h *MangaHandler) ExportMangas(c *fiber.Ctx) error {
+	format := c.Query("format", "csv")
+	if format != "csv" && format != "xlsx" {
+		return response.Error(c, fiber.StatusBadRequest, "format must be csv or xlsx", "Invalid export format")
+	}
+
+	columns, err := parseMangaExportColumns(c.Query("columns"))
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error(), "Invalid export columns")
+	}
+
+	user := c.Locals("user").(*domain.User)
+
+	if format == "xlsx" {
+		c.Set(fiber.HeaderContentType, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	} else {
+		c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	}
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="mangas.`+format+`"`)
+
+	// The body is written after the handler returns, when c is no longer usable
+	// and the request deadline no longer applies
+	ctx := context.WithoutCancel(c.UserContext())
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		err := writeMangaExport(w, format, columns, func(fn func([]*domain.Manga) error) error {
+			return h.mangaService.ExportMangas(ctx, user.ID, user.IsAdmin(), fn)
+		})
+		if err != nil {
+			// Headers are already sent, so the error can only be logged
+			log.Printf("manga export: %v", err)
+		}
+	})
+
+	return nil
+}
+
+// GetManga handles GET /api/v1/mangas/:id?include=creator&image_size=large&image_format=webp and GET /api/v2/mangas/:id
 func (h *MangaHandler) GetManga(c *fiber.Ctx) error {
 	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
 	if err != nil {
 		return response.Error(c, fiber.StatusBadRequest, err, "Invalid manga ID")
 	}
 
-	manga, err := h.mangaService.GetMangaByID(uint(id))
+	locale, err := requestedLocale(c)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error(), "Invalid lang parameter")
+	}
+
+	include, err := domain.ParseIncludes(c.Query("include"), domain.MangaIncludes)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error(), "Invalid include parameter")
+	}
+
+	imageSize, imageFormats, err := requestedImageVariant(c)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error(), "Invalid image parameter")
+	}
+
+	manga, err := h.mangaService.GetMangaByID(c.UserContext(), uint(id), isVerifiedAdult(c), include)
+	if errors.Is(err, domain.ErrAgeRestricted) {
+		return response.Error(c, fiber.StatusForbidden, err, "Age verification required")
+	}
 	if err != nil {
 		return response.Error(c, fiber.StatusNotFound, err, "Manga not found")
 	}
 
+	h.viewService.RecordView(manga.ID)
+
+	c.Vary(fiber.HeaderAcceptLanguage)
+	if notModified(c, manga.ETag(), manga.UpdatedAt) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
+	manga.Localize(locale)
+	if imageSize != "" {
+		manga.PickImageVariants(imageSize, imageFormats)
+	}
+	manga.Links = newLinkBuilder(c).manga(manga.ID)
+	if apiVersion(c) >= 2 {
+		return response.Success(c, domain.NewMangaV2Response(manga), "Manga retrieved successfully")
+	}
 	return response.Success(c, manga, "Manga retrieved successfully")
 }
 
-// GetMangas handles GET /api/v1/mangas
+// RecordCachedView counts a view of a manga detail served from the response cache
+func (h *MangaHandler) RecordCachedView(c *fiber.Ctx) {
+	if id, err := strconv.ParseUint(c.Params("id"), 10, 32); err == nil {
+		h.viewService.RecordView(uint(id))
+	}
+}
+
+// GetMangas handles GET /api/v1/mangas and GET /api/v2/mangas (v2 without fields):
+// /api/v1/mangas?is_active=true&min_price=10&max_price=50&user_id=3&genre=shonen&publication_status=ongoing&content_rating=teen&ungrouped=true&q=one+piece&sort=price:asc&page=1&page_size=10&fields=id,name,price&include=creator&lang=th&image_size=small&image_format=avif,webp
 func (h *MangaHandler) GetMangas(c *fiber.Ctx) error {
-	mangas, err := h.mangaService.GetMangas()
+	filter, err := parseMangaFilter(c)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error(), "Invalid filter parameter")
+	}
+
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size", "10"))
+	pagination := domain.NewPaginationRequest(page, pageSize)
+
+	sort, err := parseMangaSort(c)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err, "Invalid sort parameter")
+	}
+
+	fields, err := domain.ParseFields(c.Query("fields"), domain.MangaSelectableFields)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error(), "Invalid fields parameter")
+	}
+	if len(fields) > 0 && apiVersion(c) >= 2 {
+		return response.Error(c, fiber.StatusBadRequest, "fields is not supported in API v2", "Invalid fields parameter")
+	}
+
+	include, err := domain.ParseIncludes(c.Query("include"), domain.MangaIncludes)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error(), "Invalid include parameter")
+	}
+
+	locale, err := requestedLocale(c)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error(), "Invalid lang parameter")
+	}
+
+	imageSize, imageFormats, err := requestedImageVariant(c)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error(), "Invalid image parameter")
+	}
+
+	result, err := h.mangaService.GetMangas(c.UserContext(), filter, pagination, sort, fields, include)
 	if err != nil {
 		return response.Error(c, fiber.StatusInternalServerError, err, "Failed to get mangas")
 	}
+	links := newLinkBuilder(c)
+	for _, manga := range result.Data {
+		manga.Localize(locale)
+		if imageSize != "" {
+			manga.PickImageVariants(imageSize, imageFormats)
+		}
+		manga.Links = links.manga(manga.ID)
+	}
 
-	return response.Success(c, mangas, "Mangas retrieved successfully")
+	if len(fields) > 0 {
+		data, err := response.SelectFields(result.Data, fields)
+		if err != nil {
+			return response.Error(c, fiber.StatusInternalServerError, err.Error(), "Failed to select fields")
+		}
+		return response.Success(c, &domain.PaginatedResult[map[string]json.RawMessage]{
+			Data:       data,
+			Pagination: result.Pagination,
+			Links:      links.pagination(result.Pagination),
+		}, "Mangas retrieved successfully")
+	}
+	if apiVersion(c) >= 2 {
+		return response.Success(c, &domain.PaginatedResult[*domain.MangaV2Response]{
+			Data:       domain.NewMangaV2Responses(result.Data),
+			Pagination: result.Pagination,
+			Links:      links.pagination(result.Pagination),
+		}, "Mangas retrieved successfully")
+	}
+
+	return response.Success(c, withPageLinks(c, result), "Mangas retrieved successfully")
 }
 
-// GetMangasByUser handles GET /api/v1/mangas/user/:userID
-func (h *MangaHandler) GetMangasByUser(c *fiber.Ctx) error {
-	userID, err := strconv.ParseUint(c.Params("userID"), 10, 32)
+// GetMangaFacets handles GET /api/v1/mangas/facets with the same filters as GetMangas
+func (h *MangaHandler) GetMangaFacets(c *fiber.Ctx) error {
+	filter, err := parseMangaFilter(c)
 	if err != nil {
-		return response.Error(c, fiber.StatusBadRequest, err, "Invalid user ID")
+		return response.Error(c, fiber.StatusBadRequest, err.Error(), "Invalid filter parameter")
 	}
 
-	mangas, err := h.mangaService.GetMangasByUser(uint(userID))
+	facets, err := h.mangaService.GetMangaFacets(c.UserContext(), filter)
 	if err != nil {
-		return response.Error(c, fiber.StatusInternalServerError, err, "Failed to get user mangas")
+		return response.Error(c, fiber.StatusInternalServerError, err, "Failed to get manga facets")
+	}
+
+	return response.Success(c, facets, "Manga facets retrieved successfully")
+}
+
+// apiVersion returns the API version of the request's route group, 1 unless set by APIVersionMiddleware
+func apiVersion(c *fiber.Ctx) int {
+	if version, ok := c.Locals("apiVersion").(int); ok {
+		return version
+	}
+	return 1
+}
+
+// isVerifiedAdult reports whether the request comes from a signed-in user whose birth date shows they are an adult
+func isVerifiedAdult(c *fiber.Ctx) bool {
+	user, ok := c.Locals("user").(*domain.User)
+	return ok && user.IsAdultAt(time.Now())
+}
+
+// parseMangaFilter reads the listing filters from the query string
+func parseMangaFilter(c *fiber.Ctx) (*domain.MangaFilter, error) {
+	filter := &domain.MangaFilter{
+		Genre: strings.TrimSpace(c.Query("genre")),
+		Query: strings.TrimSpace(c.Query("q")),
+
+		PublicationStatus: strings.ToLower(strings.TrimSpace(c.Query("publication_status"))),
+		ContentRating:     strings.ToLower(strings.TrimSpace(c.Query("content_rating"))),
+
+		IncludeMature: isVerifiedAdult(c),
+	}
+
+	if raw := c.Query("is_active"); raw != "" {
+		isActive, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, errors.New("is_active must be true or false")
+		}
+		filter.IsActive = &isActive
+	}
+	if raw := c.Query("min_price"); raw != "" {
+		minPrice, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, errors.New("min_price must be a number")
+		}
+		filter.MinPrice = &minPrice
+	}
+	if raw := c.Query("max_price"); raw != "" {
+		maxPrice, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, errors.New("max_price must be a number")
+		}
+		filter.MaxPrice = &maxPrice
+	}
+	if raw := c.Query("series_id"); raw != "" {
+		seriesID, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			return nil, errors.New("series_id must be a positive integer")
+		}
+		id := uint(seriesID)
+		filter.SeriesID = &id
+	}
+	if raw := c.Query("ungrouped"); raw != "" {
+		ungrouped, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, errors.New("ungrouped must be true or false")
+		}
+		filter.Ungrouped = ungrouped
+	}
+	if raw := c.Query("user_id"); raw != "" {
+		userID, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			return nil, errors.New("user_id must be a positive integer")
+		}
+		id := uint(userID)
+		filter.UserID = &id
 	}
 
-	return response.Success(c, mangas, "User mangas retrieved successfully")
+	return filter, filter.Validate()
 }
 
 // UpdateManga handles PUT /api/v1/mangas/:id
@@ -107,175 +368,384 @@ func (h *MangaHandler) UpdateManga(c *fiber.Ctx) error {
 		return response.Error(c, fiber.StatusBadRequest, err, "Validation failed")
 	}
 
+	// Full updates must be based on the current version to avoid lost updates
+	req.IfMatch = c.Get(fiber.HeaderIfMatch)
+	if req.IfMatch == "" {
+		return response.Error(c, fiber.StatusPreconditionRequired, "If-Match header is required", "Send the ETag from your last GET")
+	}
+
 	// Get user ID from context (set by auth middleware)
 	userID := c.Locals("userID").(uint)
 
 	// Update manga
-	manga, err := h.mangaService.UpdateManga(uint(id), &req, userID)
+	manga, err := h.mangaService.UpdateManga(c.UserContext(), uint(id), &req, userID)
+	if errors.Is(err, domain.ErrPreconditionFailed) {
+		return response.Error(c, fiber.StatusPreconditionFailed, err.Error(), "Manga was modified by someone else")
+	}
 	if err != nil {
 		return response.Error(c, fiber.StatusForbidden, err, "Failed to update manga")
 	}
 
+	c.Set(fiber.HeaderETag, manga.ETag())
 	return response.Success(c, manga, "Manga updated successfully")
 }
 
-// DeleteManga handles DELETE /api/v1/mangas/:id
-func (h *MangaHandler) DeleteManga(c *fiber.Ctx) error {
+// GetPriceHistory handles GET /api/v1/mangas/:id/price-history
+func (h *MangaHandler) GetPriceHistory(c *fiber.Ctx) error {
 	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
 	if err != nil {
 		return response.Error(c, fiber.StatusBadRequest, err, "Invalid manga ID")
 	}
 
-	// Get user ID from context (set by auth middleware)
+	history, err := h.mangaService.GetPriceHistory(c.UserContext(), uint(id))
+	if err != nil {
+		return response.Error(c, fiber.StatusNotFound, err.Error(), "Manga not found")
+	}
+
+	return response.Success(c, history, "Price history retrieved successfully")
+}
+
+// GetMyMangas handles GET /api/v1/mangas/mine?status=draft&sort=created_at:desc
+func (h *MangaHandler) GetMyMangas(c *fiber.Ctx) error {
+	sort, err := parseMangaSort(c)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err, "Invalid sort parameter")
+	}
+
 	userID := c.Locals("userID").(uint)
 
-	// Delete manga
-	if err := h.mangaService.DeleteManga(uint(id), userID); err != nil {
-		return response.Error(c, fiber.StatusForbidden, err, "Failed to delete manga")
+	mangas, err := h.mangaService.GetMyMangas(c.UserContext(), userID, c.Query("status"), sort)
+	if err != nil {
+		return response.Error(c, fiber.StatusInternalServerError, err.Error(), "Failed to get mangas")
 	}
 
-	return response.Success(c, map[string]string{"message": "Manga deleted successfully"}, "Manga deleted successfully")
+	return response.Success(c, mangas, "Mangas retrieved successfully")
 }
 
-// GetActiveMangas handles GET /api/v1/mangas/active
-func (h *MangaHandler) GetActiveMangas(c *fiber.Ctx) error {
-	mangas, err := h.mangaService.GetActiveMangas()
+// SubmitManga handles POST /api/v1/mangas/:id/submit
+func (h *MangaHandler) SubmitManga(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
 	if err != nil {
-		return response.Error(c, fiber.StatusInternalServerError, err, "Failed to get active mangas")
+		return response.Error(c, fiber.StatusBadRequest, err, "Invalid manga ID")
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	manga, err := h.mangaService.SubmitManga(c.UserContext(), uint(id), userID)
+	if err != nil {
+		return response.Error(c, statusForTransitionError(err), err.Error(), "Failed to submit manga")
 	}
 
-	return response.Success(c, mangas, "Active mangas retrieved successfully")
+	return response.Success(c, manga, "Manga submitted for review")
 }
 
-// GetMangasByPriceRange handles GET /api/v1/mangas/price?min=0&max=1000
-func (h *MangaHandler) GetMangasByPriceRange(c *fiber.Ctx) error {
-	minStr := c.Query("min", "0")
-	maxStr := c.Query("max", "999999")
+// GetReviewQueue handles GET /api/v1/admin/mangas/review-queue?page=1&page_size=10
+func (h *MangaHandler) GetReviewQueue(c *fiber.Ctx) error {
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size", "10"))
+	pagination := domain.NewPaginationRequest(page, pageSize)
 
-	min, err := strconv.ParseFloat(minStr, 64)
+	result, err := h.mangaService.GetReviewQueue(c.UserContext(), pagination)
 	if err != nil {
-		return response.Error(c, fiber.StatusBadRequest, err, "Invalid min price")
+		return response.Error(c, fiber.StatusInternalServerError, err.Error(), "Failed to get review queue")
 	}
 
-	max, err := strconv.ParseFloat(maxStr, 64)
+	return response.Success(c, withPageLinks(c, result), "Review queue retrieved successfully")
+}
+
+// ApproveManga handles POST /api/v1/admin/mangas/:id/approve
+func (h *MangaHandler) ApproveManga(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
 	if err != nil {
-		return response.Error(c, fiber.StatusBadRequest, err, "Invalid max price")
+		return response.Error(c, fiber.StatusBadRequest, err, "Invalid manga ID")
 	}
 
-	mangas, err := h.mangaService.GetMangasByPriceRange(min, max)
+	manga, err := h.mangaService.ApproveManga(c.UserContext(), uint(id))
 	if err != nil {
-		return response.Error(c, fiber.StatusInternalServerError, err, "Failed to get mangas by price range")
+		return response.Error(c, statusForTransitionError(err), err.Error(), "Failed to approve manga")
 	}
 
-	return response.Success(c, mangas, "Mangas by price range retrieved successfully")
+	return response.Success(c, manga, "Manga approved successfully")
 }
 
-// GetMangasPaginated handles GET /api/v1/mangas/paginated?page=1&page_size=10
-func (h *MangaHandler) GetMangasPaginated(c *fiber.Ctx) error {
-	// Parse pagination parameters
-	page, _ := strconv.Atoi(c.Query("page", "1"))
-	pageSize, _ := strconv.Atoi(c.Query("page_size", "10"))
+// RejectManga handles POST /api/v1/admin/mangas/:id/reject
+func (h *MangaHandler) RejectManga(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err, "Invalid manga ID")
+	}
 
-	// Create pagination request
-	pagination := domain.NewPaginationRequest(page, pageSize)
+	var req domain.RejectMangaRequest
+	if err := validator.ParseAndValidate(c, &req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error(), "Validation failed")
+	}
+
+	manga, err := h.mangaService.RejectManga(c.UserContext(), uint(id), req.Reason)
+	if err != nil {
+		return response.Error(c, statusForTransitionError(err), err.Error(), "Failed to reject manga")
+	}
+
+	return response.Success(c, manga, "Manga rejected successfully")
+}
+
+// statusForTransitionError maps moderation and restore errors to HTTP status codes
+func statusForTransitionError(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidTransition):
+		return fiber.StatusConflict
+	case strings.HasPrefix(err.Error(), "access denied"):
+		return fiber.StatusForbidden
+	default:
+		return fiber.StatusNotFound
+	}
+}
 
-	// Validate pagination
-	if err := validator.ValidateStruct(pagination); err != nil {
-		return response.Error(c, fiber.StatusBadRequest, err, "Invalid pagination parameters")
+// BatchUpdateMangas handles PATCH /api/v1/mangas/batch
+func (h *MangaHandler) BatchUpdateMangas(c *fiber.Ctx) error {
+	var req domain.BatchUpdateMangasRequest
+	if err := validator.ParseAndValidate(c, &req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error(), "Validation failed")
 	}
 
-	// Get paginated mangas
-	result, err := h.mangaService.GetMangasPaginated(pagination)
+	userID := c.Locals("userID").(uint)
+
+	result, err := h.mangaService.BatchUpdateMangas(c.UserContext(), &req, userID)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error(), "Failed to update mangas")
+	}
+
+	return response.Success(c, result, "Batch update completed")
+}
+
+// BatchDeleteMangas handles DELETE /api/v1/mangas/batch
+func (h *MangaHandler) BatchDeleteMangas(c *fiber.Ctx) error {
+	var req domain.BatchDeleteMangasRequest
+	if err := validator.ParseAndValidate(c, &req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error(), "Validation failed")
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	result, err := h.mangaService.BatchDeleteMangas(c.UserContext(), req.IDs, userID)
 	if err != nil {
-		return response.Error(c, fiber.StatusInternalServerError, err, "Failed to get paginated mangas")
+		return response.Error(c, fiber.StatusInternalServerError, err.Error(), "Failed to delete mangas")
 	}
 
-	return response.Success(c, result, "Paginated mangas retrieved successfully")
+	return response.Success(c, result, "Batch delete completed")
 }
 
-// GetActiveMangasPaginated handles GET /api/v1/mangas/active/paginated?page=1&page_size=10
-func (h *MangaHandler) GetActiveMangasPaginated(c *fiber.Ctx) error {
+// GetDeletedMangas handles GET /api/v1/mangas/deleted?page=1&page_size=10
+func (h *MangaHandler) GetDeletedMangas(c *fiber.Ctx) error {
 	// Parse pagination parameters
 	page, _ := strconv.Atoi(c.Query("page", "1"))
 	pageSize, _ := strconv.Atoi(c.Query("page_size", "10"))
-
-	// Create pagination request
 	pagination := domain.NewPaginationRequest(page, pageSize)
 
-	// Validate pagination
-	if err := validator.ValidateStruct(pagination); err != nil {
-		return response.Error(c, fiber.StatusBadRequest, err, "Invalid pagination parameters")
+	user := c.Locals("user").(*domain.User)
+
+	result, err := h.mangaService.GetDeletedMangas(c.UserContext(), user.ID, user.IsAdmin(), pagination)
+	if err != nil {
+		return response.Error(c, fiber.StatusInternalServerError, err.Error(), "Failed to get deleted mangas")
+	}
+
+	return response.Success(c, withPageLinks(c, result), "Deleted mangas retrieved successfully")
+}
+
+// RestoreManga handles POST /api/v1/mangas/:id/restore
+func (h *MangaHandler) RestoreManga(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err, "Invalid manga ID")
 	}
 
-	// Get paginated active mangas
-	result, err := h.mangaService.GetActiveMangasPaginated(pagination)
+	user := c.Locals("user").(*domain.User)
+
+	manga, err := h.mangaService.RestoreManga(c.UserContext(), uint(id), user.ID, user.IsAdmin())
 	if err != nil {
-		return response.Error(c, fiber.StatusInternalServerError, err, "Failed to get paginated active mangas")
+		return response.Error(c, statusForTransitionError(err), err.Error(), "Failed to restore manga")
 	}
 
-	return response.Success(c, result, "Paginated active mangas retrieved successfully")
+	return response.Success(c, manga, "Manga restored successfully")
 }
 
-// GetMangasByUserPaginated handles GET /api/v1/mangas/user/:userID/paginated?page=1&page_size=10
-func (h *MangaHandler) GetMangasByUserPaginated(c *fiber.Ctx) error {
-	userID, err := strconv.ParseUint(c.Params("userID"), 10, 32)
+// PurgeManga handles DELETE /api/v1/admin/mangas/:id/purge
+func (h *MangaHandler) PurgeManga(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
 	if err != nil {
-		return response.Error(c, fiber.StatusBadRequest, err, "Invalid user ID")
+		return response.Error(c, fiber.StatusBadRequest, err, "Invalid manga ID")
 	}
 
-	// Parse pagination parameters
-	page, _ := strconv.Atoi(c.Query("page", "1"))
-	pageSize, _ := strconv.Atoi(c.Query("page_size", "10"))
+	if err := h.mangaService.PurgeManga(c.UserContext(), uint(id)); err != nil {
+		return response.Error(c, fiber.StatusNotFound, err.Error(), "Failed to purge manga")
+	}
 
-	// Create pagination request
-	pagination := domain.NewPaginationRequest(page, pageSize)
+	return response.Success(c, nil, "Manga purged permanently")
+}
 
-	// Validate pagination
-	if err := validator.ValidateStruct(pagination); err != nil {
-		return response.Error(c, fiber.StatusBadRequest, err, "Invalid pagination parameters")
+// GetMangaHistory handles GET /api/v1/mangas/:id/history
+func (h *MangaHandler) GetMangaHistory(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err, "Invalid manga ID")
 	}
 
-	// Get paginated user mangas
-	result, err := h.mangaService.GetMangasByUserPaginated(uint(userID), pagination)
+	userID := c.Locals("userID").(uint)
+
+	versions, err := h.mangaService.GetMangaHistory(c.UserContext(), uint(id), userID)
 	if err != nil {
-		return response.Error(c, fiber.StatusInternalServerError, err, "Failed to get paginated user mangas")
+		return response.Error(c, statusForTransitionError(err), err.Error(), "Failed to get manga history")
 	}
 
-	return response.Success(c, result, "Paginated user mangas retrieved successfully")
+	return response.Success(c, versions, "Manga history retrieved successfully")
 }
 
-// GetMangasByPriceRangePaginated handles GET /api/v1/mangas/price/paginated?min=0&max=1000&page=1&page_size=10
-func (h *MangaHandler) GetMangasByPriceRangePaginated(c *fiber.Ctx) error {
-	minStr := c.Query("min", "0")
-	maxStr := c.Query("max", "999999")
+// RevertManga handles POST /api/v1/mangas/:id/history/:version/revert
+func (h *MangaHandler) RevertManga(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err, "Invalid manga ID")
+	}
+
+	version, err := strconv.Atoi(c.Params("version"))
+	if err != nil || version < 1 {
+		return response.Error(c, fiber.StatusBadRequest, "version must be a positive integer", "Invalid version")
+	}
+
+	userID := c.Locals("userID").(uint)
 
-	min, err := strconv.ParseFloat(minStr, 64)
+	manga, err := h.mangaService.RevertManga(c.UserContext(), uint(id), version, userID)
 	if err != nil {
-		return response.Error(c, fiber.StatusBadRequest, err, "Invalid min price")
+		return response.Error(c, statusForTransitionError(err), err.Error(), "Failed to revert manga")
 	}
 
-	max, err := strconv.ParseFloat(maxStr, 64)
+	return response.Success(c, manga, "Manga reverted successfully")
+}
+
+// AdjustStock handles POST /api/v1/mangas/:id/stock/adjust
+func (h *MangaHandler) AdjustStock(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
 	if err != nil {
-		return response.Error(c, fiber.StatusBadRequest, err, "Invalid max price")
+		return response.Error(c, fiber.StatusBadRequest, err, "Invalid manga ID")
 	}
 
-	// Parse pagination parameters
-	page, _ := strconv.Atoi(c.Query("page", "1"))
-	pageSize, _ := strconv.Atoi(c.Query("page_size", "10"))
+	var req domain.StockAdjustRequest
+	if err := validator.ParseAndValidate(c, &req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error(), "Validation failed")
+	}
 
-	// Create pagination request
-	pagination := domain.NewPaginationRequest(page, pageSize)
+	userID := c.Locals("userID").(uint)
+
+	manga, err := h.mangaService.AdjustStock(c.UserContext(), uint(id), &req, userID)
+	if errors.Is(err, domain.ErrInsufficientStock) {
+		return response.Error(c, fiber.StatusConflict, err.Error(), "Failed to adjust stock")
+	}
+	if err != nil {
+		return response.Error(c, fiber.StatusForbidden, err.Error(), "Failed to adjust stock")
+	}
+
+	return response.Success(c, manga, "Stock adjusted successfully")
+}
+
+// GetLowStockMangas handles GET /api/v1/mangas/low-stock?threshold=5
+func (h *MangaHandler) GetLowStockMangas(c *fiber.Ctx) error {
+	threshold, err := strconv.Atoi(c.Query("threshold", "5"))
+	if err != nil || threshold < 0 {
+		return response.Error(c, fiber.StatusBadRequest, "threshold must be a non-negative integer", "Invalid threshold")
+	}
+
+	user := c.Locals("user").(*domain.User)
+
+	mangas, err := h.mangaService.GetLowStockMangas(c.UserContext(), user.ID, user.IsAdmin(), threshold)
+	if err != nil {
+		return response.Error(c, fiber.StatusInternalServerError, err.Error(), "Failed to get low stock mangas")
+	}
+
+	return response.Success(c, mangas, "Low stock mangas retrieved successfully")
+}
+
+// GetTrendingMangas handles GET /api/v1/mangas/trending?window=7d&limit=10
+func (h *MangaHandler) GetTrendingMangas(c *fiber.Ctx) error {
+	window, err := parseTrendingWindow(c.Query("window", "7d"))
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error(), "Invalid window parameter")
+	}
+
+	mangas, err := h.viewService.GetTrending(c.UserContext(), window, parseRecommendationLimit(c))
+	if err != nil {
+		return response.Error(c, fiber.StatusInternalServerError, err.Error(), "Failed to get trending mangas")
+	}
+
+	return response.Success(c, mangas, "Trending mangas retrieved successfully")
+}
 
-	// Validate pagination
-	if err := validator.ValidateStruct(pagination); err != nil {
-		return response.Error(c, fiber.StatusBadRequest, err, "Invalid pagination parameters")
+// parseTrendingWindow parses a window of whole days like "7d" (1 to 90 days)
+func parseTrendingWindow(raw string) (time.Duration, error) {
+	days, err := strconv.Atoi(strings.TrimSuffix(raw, "d"))
+	if err != nil || !strings.HasSuffix(raw, "d") || days < 1 || days > 90 {
+		return 0, errors.New("window must be between 1d and 90d")
 	}
+	return time.Duration(days) * 24 * time.Hour, nil
+}
 
-	// Get paginated mangas by price range
-	result, err := h.mangaService.GetMangasByPriceRangePaginated(min, max, pagination)
+// GetRecommendations handles GET /api/v1/mangas/:id/recommendations?limit=10
+func (h *MangaHandler) GetRecommendations(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
 	if err != nil {
-		return response.Error(c, fiber.StatusInternalServerError, err, "Failed to get paginated mangas by price range")
+		return response.Error(c, fiber.StatusBadRequest, err, "Invalid manga ID")
 	}
 
-	return response.Success(c, result, "Paginated mangas by price range retrieved successfully")
+	mangas, err := h.mangaService.GetRecommendations(c.UserContext(), uint(id), parseRecommendationLimit(c))
+	if err != nil {
+		return response.Error(c, fiber.StatusNotFound, err.Error(), "Failed to get recommendations")
+	}
+
+	return response.Success(c, mangas, "Recommendations retrieved successfully")
+}
+
+// GetUserRecommendations handles GET /api/v1/users/me/recommendations?limit=10
+func (h *MangaHandler) GetUserRecommendations(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	mangas, err := h.mangaService.GetUserRecommendations(c.UserContext(), userID, parseRecommendationLimit(c))
+	if err != nil {
+		return response.Error(c, fiber.StatusInternalServerError, err.Error(), "Failed to get recommendations")
+	}
+
+	return response.Success(c, mangas, "Recommendations retrieved successfully")
+}
+
+// parseRecommendationLimit parses the limit query parameter (default 10, max 50)
+func parseRecommendationLimit(c *fiber.Ctx) int {
+	limit, err := strconv.Atoi(c.Query("limit", "10"))
+	if err != nil || limit < 1 {
+		return 10
+	}
+	if limit > 50 {
+		return 50
+	}
+	return limit
+}
+
+// DeleteManga handles DELETE /api/v1/mangas/:id
+func (h *MangaHandler) DeleteManga(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err, "Invalid manga ID")
+	}
+
+	// Get user ID from context (set by auth middleware)
+	userID := c.Locals("userID").(uint)
+
+	// Delete manga
+	if err := h.mangaService.DeleteManga(c.UserContext(), uint(id), userID); err != nil {
+		return response.Error(c, fiber.StatusForbidden, err, "Failed to delete manga")
+	}
+
+	return response.Success(c, map[string]string{"message": "Manga deleted successfully"}, "Manga deleted successfully")
+}
+
+// parseMangaSort parses the ?sort= query parameter against the sortable manga fields
+func parseMangaSort(c *fiber.Ctx) (domain.Sort, error) {
+	return domain.ParseSort(c.Query("sort"), domain.MangaSortableFields)
 }
@@ -0,0 +1,201 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/pkg/response"
+	"github.com/thitiphongD/my-backend/pkg/validator"
+)
+
+// MangaImageHandler handles HTTP requests for manga galleries
+type MangaImageHandler struct {
+	imageService ports.MangaImageService
+}
+
+// NewMangaImageHandler creates a new manga image handler instance
+func NewMangaImageHandler(imageService ports.MangaImageService) *MangaImageHandler {
+	return &MangaImageHandler{
+		imageService: imageService,
+	}
+}
+
+// GetImages handles GET /api/v1/mangas/:id/images?image_size=small&image_format=avif,webp
+func (h *MangaImageHandler) GetImages(c *fiber.Ctx) error {
+	mangaID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid manga ID")
+	}
+
+	size, formats, err := requestedImageVariant(c)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	images, err := h.imageService.GetImages(c.UserContext(), uint(mangaID))
+	if err != nil {
+		return response.Error(c, statusForMangaImageError(err), err.Error())
+	}
+	if size != "" {
+		for _, image := range images {
+			image.PickSrcURL(size, formats)
+		}
+	}
+
+	return response.Success(c, images, "Manga images retrieved successfully")
+}
+
+// UploadImage handles POST /api/v1/mangas/:id/images (multipart fields "file" and "caption")
+func (h *MangaImageHandler) UploadImage(c *fiber.Ctx) error {
+	mangaID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid manga ID")
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Image file is required")
+	}
+	if fileHeader.Size > domain.MaxMangaImageSize {
+		return response.Error(c, fiber.StatusRequestEntityTooLarge, fmt.Sprintf("image must be at most %d MB", domain.MaxMangaImageSize>>20))
+	}
+
+	caption := strings.TrimSpace(c.FormValue("caption"))
+	if utf8.RuneCountInString(caption) > domain.MaxMangaImageCaption {
+		return response.Error(c, fiber.StatusBadRequest, fmt.Sprintf("caption must be at most %d characters", domain.MaxMangaImageCaption))
+	}
+
+	// Large uploads are spooled to a temporary file rather than held in memory
+	file, err := fileHeader.Open()
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Failed to read image file")
+	}
+	defer file.Close()
+
+	userID := c.Locals("userID").(uint)
+
+	image, err := h.imageService.UploadImage(c.UserContext(), uint(mangaID), &domain.UploadMangaImageRequest{File: file, Caption: caption}, userID)
+	if err != nil {
+		return response.Error(c, statusForMangaImageError(err), err.Error())
+	}
+
+	return response.Created(c, image, "Manga image uploaded successfully")
+}
+
+// UpdateImage handles PATCH /api/v1/mangas/:id/images/:imageID
+func (h *MangaImageHandler) UpdateImage(c *fiber.Ctx) error {
+	mangaID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid manga ID")
+	}
+
+	imageID, err := strconv.ParseUint(c.Params("imageID"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid image ID")
+	}
+
+	var req domain.UpdateMangaImageRequest
+	if err := validator.ParseAndValidate(c, &req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	image, err := h.imageService.UpdateImage(c.UserContext(), uint(mangaID), uint(imageID), &req, userID)
+	if err != nil {
+		return response.Error(c, statusForMangaImageError(err), err.Error())
+	}
+
+	return response.Success(c, image, "Manga image updated successfully")
+}
+
+// ReorderImages handles PUT /api/v1/mangas/:id/images/order
+func (h *MangaImageHandler) ReorderImages(c *fiber.Ctx) error {
+	mangaID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid manga ID")
+	}
+
+	var req domain.ReorderMangaImagesRequest
+	if err := validator.ParseAndValidate(c, &req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	images, err := h.imageService.ReorderImages(c.UserContext(), uint(mangaID), &req, userID)
+	if err != nil {
+		return response.Error(c, statusForMangaImageError(err), err.Error())
+	}
+
+	return response.Success(c, images, "Manga images reordered successfully")
+}
+
+// DeleteImage handles DELETE /api/v1/mangas/:id/images/:imageID
+func (h *MangaImageHandler) DeleteImage(c *fiber.Ctx) error {
+	mangaID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid manga ID")
+	}
+
+	imageID, err := strconv.ParseUint(c.Params("imageID"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid image ID")
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	if err := h.imageService.DeleteImage(c.UserContext(), uint(mangaID), uint(imageID), userID); err != nil {
+		return response.Error(c, statusForMangaImageError(err), err.Error())
+	}
+
+	return response.Success(c, nil, "Manga image deleted successfully")
+}
+
+// statusForMangaImageError maps manga image service errors to HTTP status codes
+func statusForMangaImageError(err error) int {
+	switch {
+	case strings.HasPrefix(err.Error(), "access denied"):
+		return fiber.StatusForbidden
+	case errors.Is(err, domain.ErrMalwareDetected):
+		return fiber.StatusUnprocessableEntity
+	case strings.HasPrefix(err.Error(), "malware scan"):
+		return fiber.StatusServiceUnavailable
+	case strings.HasSuffix(err.Error(), "not found"):
+		return fiber.StatusNotFound
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return fiber.StatusInternalServerError
+	default:
+		return fiber.StatusBadRequest
+	}
+}
+
+// requestedImageVariant reads the image_size a client shows gallery images
+// at and the image_format list of the formats it supports, best first, such
+// as image_format=avif,webp. Without image_size no variant is picked; JPEG is
+// the fallback format.
+func requestedImageVariant(c *fiber.Ctx) (string, []string, error) {
+	size := strings.ToLower(strings.TrimSpace(c.Query("image_size")))
+	if size != "" && !domain.IsValidImageSize(size) {
+		return "", nil, errors.New("image_size must be small, medium or large")
+	}
+
+	var formats []string
+	for _, format := range strings.Split(c.Query("image_format"), ",") {
+		format = strings.ToLower(strings.TrimSpace(format))
+		if format == "" {
+			continue
+		}
+		if _, ok := domain.ImageFormats[format]; !ok {
+			return "", nil, errors.New("image_format must list jpeg, webp or avif")
+		}
+		formats = append(formats, format)
+	}
+	return size, formats, nil
+}
@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/pkg/validator"
+)
+
+// maxImportRows caps the number of rows accepted in a single import file
+const maxImportRows = 5000
+
+// parseMangaImport parses a CSV or JSON import file into rows, detecting the
+// format from the file extension. Each row is validated against the
+// CreateMangaRequest rules; invalid rows carry their error instead of failing the import.
+func parseMangaImport(filename string, r io.Reader) ([]*domain.MangaImportRow, error) {
+	var rows []*domain.MangaImportRow
+	var err error
+
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		rows, err = parseMangaImportCSV(r)
+	case ".json":
+		rows, err = parseMangaImportJSON(r)
+	default:
+		return nil, errors.New("unsupported file type: must be .csv or .json")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, errors.New("import file contains no rows")
+	}
+	if len(rows) > maxImportRows {
+		return nil, errors.New("import file exceeds " + strconv.Itoa(maxImportRows) + " rows")
+	}
+
+	for _, row := range rows {
+		if row.Error != "" {
+			continue
+		}
+		if err := validator.Validate(row.Request); err != nil {
+			row.Error = err.Error()
+		}
+	}
+
+	return rows, nil
+}
+
+// parseMangaImportJSON parses a JSON array of CreateMangaRequest objects
+func parseMangaImportJSON(r io.Reader) ([]*domain.MangaImportRow, error) {
+	var raw []json.RawMessage
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, errors.New("invalid JSON: expected an array of mangas")
+	}
+
+	rows := make([]*domain.MangaImportRow, len(raw))
+	for i, item := range raw {
+		row := &domain.MangaImportRow{Row: i + 1, Request: &domain.CreateMangaRequest{}}
+		if err := json.Unmarshal(item, row.Request); err != nil {
+			row.Error = "invalid JSON object"
+		}
+		rows[i] = row
+	}
+
+	return rows, nil
+}
+
+// parseMangaImportCSV parses a CSV file with a header row. Supported columns are
+// name, price, is_active, stock_quantity, team_id, publication_status, content_rating
+// and genre_ids (IDs separated by ";").
+func parseMangaImportCSV(r io.Reader) ([]*domain.MangaImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.New("invalid CSV: missing header row")
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch name {
+		case "name", "price", "is_active", "stock_quantity", "team_id", "publication_status", "content_rating", "genre_ids":
+			columns[name] = i
+		default:
+			return nil, errors.New("invalid CSV: unknown column " + name)
+		}
+	}
+	if _, ok := columns["name"]; !ok {
+		return nil, errors.New("invalid CSV: name column is required")
+	}
+	if _, ok := columns["price"]; !ok {
+		return nil, errors.New("invalid CSV: price column is required")
+	}
+
+	var rows []*domain.MangaImportRow
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		row := &domain.MangaImportRow{Row: line, Request: &domain.CreateMangaRequest{}}
+		rows = append(rows, row)
+		if err != nil {
+			row.Error = "invalid CSV row"
+			continue
+		}
+
+		if err := parseMangaImportRecord(record, columns, row.Request); err != nil {
+			row.Error = err.Error()
+		}
+		if len(rows) > maxImportRows {
+			break
+		}
+	}
+
+	return rows, nil
+}
+
+// parseMangaImportRecord maps a CSV record onto a CreateMangaRequest
+func parseMangaImportRecord(record []string, columns map[string]int, req *domain.CreateMangaRequest) error {
+	field := func(name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	req.Name = field("name")
+
+	if v := field("price"); v != "" {
+		price, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return errors.New("price must be a number")
+		}
+		req.Price = price
+	}
+
+	if v := field("is_active"); v != "" {
+		active, err := strconv.ParseBool(v)
+		if err != nil {
+			return errors.New("is_active must be true or false")
+		}
+		req.IsActive = active
+	}
+
+	if v := field("stock_quantity"); v != "" {
+		stock, err := strconv.Atoi(v)
+		if err != nil {
+			return errors.New("stock_quantity must be an integer")
+		}
+		req.Stock = stock
+	}
+
+	if v := field("team_id"); v != "" {
+		teamID, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			return errors.New("team_id must be a positive integer")
+		}
+		id := uint(teamID)
+		req.TeamID = &id
+	}
+
+	req.PublicationStatus = strings.ToLower(field("publication_status"))
+	req.ContentRating = strings.ToLower(field("content_rating"))
+
+	if v := field("genre_ids"); v != "" {
+		for _, part := range strings.Split(v, ";") {
+			genreID, err := strconv.ParseUint(strings.TrimSpace(part), 10, 32)
+			if err != nil {
+				return errors.New("genre_ids must be positive integers separated by ;")
+			}
+			req.GenreIDs = append(req.GenreIDs, uint(genreID))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/pkg/response"
+	"github.com/thitiphongD/my-backend/pkg/validator"
+)
+
+// MangaRelationHandler handles HTTP requests for related mangas
+type MangaRelationHandler struct {
+	relationService ports.MangaRelationService
+}
+
+// NewMangaRelationHandler creates a new manga relation handler instance
+func NewMangaRelationHandler(relationService ports.MangaRelationService) *MangaRelationHandler {
+	return &MangaRelationHandler{
+		relationService: relationService,
+	}
+}
+
+// GetRelated handles GET /api/v1/mangas/:id/related
+func (h *MangaRelationHandler) GetRelated(c *fiber.Ctx) error {
+	mangaID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid manga ID")
+	}
+
+	relations, err := h.relationService.GetRelated(c.UserContext(), uint(mangaID))
+	if err != nil {
+		return response.Error(c, fiber.StatusNotFound, err.Error())
+	}
+
+	return response.Success(c, relations, "Related mangas retrieved successfully")
+}
+
+// CreateRelation handles POST /api/v1/mangas/:id/related
+func (h *MangaRelationHandler) CreateRelation(c *fiber.Ctx) error {
+	mangaID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid manga ID")
+	}
+
+	var req domain.CreateMangaRelationRequest
+	if err := validator.ParseAndValidate(c, &req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	relation, err := h.relationService.CreateRelation(c.UserContext(), uint(mangaID), &req, userID)
+	if err != nil {
+		return response.Error(c, statusForRelationError(err), err.Error())
+	}
+
+	return response.Created(c, relation, "Mangas linked successfully")
+}
+
+// DeleteRelation handles DELETE /api/v1/mangas/:id/related/:relatedID
+func (h *MangaRelationHandler) DeleteRelation(c *fiber.Ctx) error {
+	mangaID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid manga ID")
+	}
+
+	relatedID, err := strconv.ParseUint(c.Params("relatedID"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid related manga ID")
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	if err := h.relationService.DeleteRelation(c.UserContext(), uint(mangaID), uint(relatedID), userID); err != nil {
+		return response.Error(c, statusForRelationError(err), err.Error())
+	}
+
+	return response.Success(c, nil, "Mangas unlinked successfully")
+}
+
+// statusForRelationError maps relation service errors to HTTP status codes
+func statusForRelationError(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrRelationCycle):
+		return fiber.StatusConflict
+	case strings.HasPrefix(err.Error(), "access denied"):
+		return fiber.StatusForbidden
+	case strings.HasSuffix(err.Error(), "not found"):
+		return fiber.StatusNotFound
+	default:
+		return fiber.StatusBadRequest
+	}
+}
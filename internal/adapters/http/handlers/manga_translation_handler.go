@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/pkg/response"
+	"github.com/thitiphongD/my-backend/pkg/validator"
+)
+
+// MangaTranslationHandler handles HTTP requests for manga translations
+type MangaTranslationHandler struct {
+	translationService ports.MangaTranslationService
+}
+
+// NewMangaTranslationHandler creates a new manga translation handler instance
+func NewMangaTranslationHandler(translationService ports.MangaTranslationService) *MangaTranslationHandler {
+	return &MangaTranslationHandler{
+		translationService: translationService,
+	}
+}
+
+// GetTranslations handles GET /api/v1/mangas/:id/translations
+func (h *MangaTranslationHandler) GetTranslations(c *fiber.Ctx) error {
+	mangaID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid manga ID")
+	}
+
+	translations, err := h.translationService.GetTranslations(c.UserContext(), uint(mangaID))
+	if err != nil {
+		return response.Error(c, statusForMangaTranslationError(err), err.Error())
+	}
+
+	return response.Success(c, translations, "Manga translations retrieved successfully")
+}
+
+// SaveTranslation handles PUT /api/v1/mangas/:id/translations/:locale
+func (h *MangaTranslationHandler) SaveTranslation(c *fiber.Ctx) error {
+	mangaID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid manga ID")
+	}
+
+	var req domain.SaveMangaTranslationRequest
+	if err := validator.ParseAndValidate(c, &req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	translation, err := h.translationService.SaveTranslation(c.UserContext(), uint(mangaID), strings.ToLower(c.Params("locale")), &req, userID)
+	if err != nil {
+		return response.Error(c, statusForMangaTranslationError(err), err.Error())
+	}
+
+	return response.Success(c, translation, "Manga translation saved successfully")
+}
+
+// DeleteTranslation handles DELETE /api/v1/mangas/:id/translations/:locale
+func (h *MangaTranslationHandler) DeleteTranslation(c *fiber.Ctx) error {
+	mangaID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid manga ID")
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	if err := h.translationService.DeleteTranslation(c.UserContext(), uint(mangaID), strings.ToLower(c.Params("locale")), userID); err != nil {
+		return response.Error(c, statusForMangaTranslationError(err), err.Error())
+	}
+
+	return response.Success(c, nil, "Manga translation deleted successfully")
+}
+
+// requestedLocale picks the translation locale for a read request: the lang
+// query parameter when given, otherwise the best supported language in the
+// Accept-Language header. It returns "" when the original text should be used.
+func requestedLocale(c *fiber.Ctx) (string, error) {
+	if lang := strings.ToLower(strings.TrimSpace(c.Query("lang"))); lang != "" {
+		if !domain.IsValidMangaLocale(lang) {
+			return "", errors.New("lang must be one of " + strings.Join(domain.MangaLocales, ", "))
+		}
+		return lang, nil
+	}
+
+	best, bestQuality := "", 0.0
+	for _, part := range strings.Split(c.Get(fiber.HeaderAcceptLanguage), ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(q, 64); err == nil {
+				quality = parsed
+			}
+		}
+
+		// Regional variants such as th-TH fall back to their base language
+		base, _, _ := strings.Cut(strings.ToLower(tag), "-")
+		if domain.IsValidMangaLocale(base) && quality > bestQuality {
+			best, bestQuality = base, quality
+		}
+	}
+	return best, nil
+}
+
+// statusForMangaTranslationError maps manga translation service errors to HTTP status codes
+func statusForMangaTranslationError(err error) int {
+	switch {
+	case strings.HasPrefix(err.Error(), "access denied"):
+		return fiber.StatusForbidden
+	case strings.HasSuffix(err.Error(), "not found"):
+		return fiber.StatusNotFound
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return fiber.StatusInternalServerError
+	default:
+		return fiber.StatusBadRequest
+	}
+}
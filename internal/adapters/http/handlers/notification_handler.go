@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/pkg/response"
+	"github.com/thitiphongD/my-backend/pkg/validator"
+)
+
+// NotificationHandler handles HTTP requests for notification preferences
+type NotificationHandler struct {
+	notificationService ports.NotificationService
+}
+
+// NewNotificationHandler creates a new notification handler instance
+func NewNotificationHandler(notificationService ports.NotificationService) *NotificationHandler {
+	return &NotificationHandler{
+		notificationService: notificationService,
+	}
+}
+
+// GetPreferences handles GET /api/v1/users/me/notification-preferences
+func (h *NotificationHandler) GetPreferences(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	prefs, err := h.notificationService.GetPreferences(c.UserContext(), userID)
+	if err != nil {
+		return response.Error(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.Success(c, prefs, "Notification preferences retrieved successfully")
+}
+
+// UpdatePreferences handles PATCH /api/v1/users/me/notification-preferences
+func (h *NotificationHandler) UpdatePreferences(c *fiber.Ctx) error {
+	var req domain.UpdateNotificationPreferencesRequest
+	if err := validator.ParseAndValidate(c, &req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	prefs, err := h.notificationService.UpdatePreferences(c.UserContext(), userID, &req)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidNotificationRoute) {
+			return response.Error(c, fiber.StatusBadRequest, err.Error())
+		}
+		return response.Error(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.Success(c, prefs, "Notification preferences updated successfully")
+}
+
+// GetRoutes handles GET /api/v1/notifications/routes
+func (h *NotificationHandler) GetRoutes(c *fiber.Ctx) error {
+	return response.Success(c, fiber.Map{
+		"channels": domain.NotificationChannels,
+		"defaults": domain.DefaultNotificationRoutes,
+	}, "Notification routes retrieved successfully")
+}
+
+// Unsubscribe handles GET and POST /api/v1/notifications/unsubscribe?token=...
+// from the links in emails. POST is the one-click unsubscribe of mail
+// clients (RFC 8058).
+func (h *NotificationHandler) Unsubscribe(c *fiber.Ctx) error {
+	token := c.Query("token")
+	if token == "" {
+		return response.Error(c, fiber.StatusBadRequest, "Unsubscribe token is required")
+	}
+
+	category, err := h.notificationService.Unsubscribe(c.UserContext(), token)
+	if err != nil {
+		if err.Error() == "invalid unsubscribe token" {
+			return response.Error(c, fiber.StatusBadRequest, err.Error())
+		}
+		return response.Error(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.Success(c, fiber.Map{"category": category}, "Unsubscribed successfully")
+}
@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/pkg/response"
+	"github.com/thitiphongD/my-backend/pkg/validator"
+)
+
+// OrderHandler handles HTTP requests for orders
+type OrderHandler struct {
+	orderService ports.OrderService
+}
+
+// NewOrderHandler creates a new order handler instance
+func NewOrderHandler(orderService ports.OrderService) *OrderHandler {
+	return &OrderHandler{
+		orderService: orderService,
+	}
+}
+
+// Checkout handles POST /api/v1/orders, ordering the user's cart
+func (h *OrderHandler) Checkout(c *fiber.Ctx) error {
+	var req domain.CheckoutRequest
+	if err := validator.ParseAndValidate(c, &req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	order, err := h.orderService.Checkout(c.UserContext(), &req, userID)
+	if err != nil {
+		return response.Error(c, statusForOrderError(err), err.Error())
+	}
+
+	return response.Created(c, order, "Order placed successfully")
+}
+
+// GetMyOrders handles GET /api/v1/orders?page=1&page_size=10&archived=true
+func (h *OrderHandler) GetMyOrders(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size", "10"))
+	pagination := domain.NewPaginationRequest(page, pageSize)
+
+	userID := c.Locals("userID").(uint)
+
+	result, err := h.orderService.GetMyOrders(c.UserContext(), userID, pagination, c.QueryBool("archived"))
+	if err != nil {
+		return response.Error(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.Success(c, withPageLinks(c, result), "Orders retrieved successfully")
+}
+
+// GetSellerOrders handles GET /api/v1/orders/sales?page=1&page_size=10
+func (h *OrderHandler) GetSellerOrders(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size", "10"))
+	pagination := domain.NewPaginationRequest(page, pageSize)
+
+	userID := c.Locals("userID").(uint)
+
+	result, err := h.orderService.GetSellerOrders(c.UserContext(), userID, pagination)
+	if err != nil {
+		return response.Error(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.Success(c, withPageLinks(c, result), "Orders retrieved successfully")
+}
+
+// GetOrder handles GET /api/v1/orders/:id
+func (h *OrderHandler) GetOrder(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid order ID")
+	}
+
+	user := c.Locals("user").(*domain.User)
+
+	order, err := h.orderService.GetOrder(c.UserContext(), uint(id), user.ID, user.IsAdmin())
+	if err != nil {
+		return response.Error(c, statusForOrderError(err), err.Error())
+	}
+
+	return response.Success(c, order, "Order retrieved successfully")
+}
+
+// GetInvoice handles GET /api/v1/orders/:id/invoice
+func (h *OrderHandler) GetInvoice(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid order ID")
+	}
+
+	user := c.Locals("user").(*domain.User)
+
+	invoice, err := h.orderService.GetInvoice(c.UserContext(), uint(id), user.ID, user.IsAdmin())
+	if err != nil {
+		return response.Error(c, statusForOrderError(err), err.Error())
+	}
+
+	return response.Success(c, invoice, "Invoice retrieved successfully")
+}
+
+// ShipOrder handles PUT /api/v1/orders/:id/shipment
+func (h *OrderHandler) ShipOrder(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid order ID")
+	}
+
+	var req domain.ShipOrderRequest
+	if err := validator.ParseAndValidate(c, &req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	user := c.Locals("user").(*domain.User)
+
+	order, err := h.orderService.ShipOrder(c.UserContext(), uint(id), &req, user.ID, user.IsAdmin())
+	if err != nil {
+		return response.Error(c, statusForOrderError(err), err.Error())
+	}
+
+	return response.Success(c, order, "Order shipped successfully")
+}
+
+// UpdateOrderStatus handles PATCH /api/v1/orders/:id/status
+func (h *OrderHandler) UpdateOrderStatus(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid order ID")
+	}
+
+	var req domain.UpdateOrderStatusRequest
+	if err := validator.ParseAndValidate(c, &req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	user := c.Locals("user").(*domain.User)
+
+	order, err := h.orderService.UpdateOrderStatus(c.UserContext(), uint(id), &req, user.ID, user.IsAdmin())
+	if err != nil {
+		return response.Error(c, statusForOrderError(err), err.Error())
+	}
+
+	return response.Success(c, order, "Order status updated successfully")
+}
+
+// statusForOrderError maps order service errors to HTTP status codes
+func statusForOrderError(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInsufficientStock), errors.Is(err, domain.ErrInvalidOrderTransition),
+		errors.Is(err, domain.ErrReservationExpired), errors.Is(err, domain.ErrOrderNotPaid):
+		return fiber.StatusConflict
+	case strings.HasPrefix(err.Error(), "access denied"):
+		return fiber.StatusForbidden
+	case strings.HasSuffix(err.Error(), "not found"):
+		return fiber.StatusNotFound
+	case strings.HasSuffix(err.Error(), "not available for purchase"), err.Error() == "cart is empty":
+		return fiber.StatusUnprocessableEntity
+	default:
+		return fiber.StatusInternalServerError
+	}
+}
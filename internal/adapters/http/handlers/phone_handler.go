@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/pkg/response"
+	"github.com/thitiphongD/my-backend/pkg/validator"
+)
+
+// PhoneHandler handles HTTP requests for the user's phone number and their
+// consent to texts
+type PhoneHandler struct {
+	smsService ports.SMSService
+}
+
+// NewPhoneHandler creates a new phone handler instance
+func NewPhoneHandler(smsService ports.SMSService) *PhoneHandler {
+	return &PhoneHandler{
+		smsService: smsService,
+	}
+}
+
+// GetPhone handles GET /api/v1/users/me/phone
+func (h *PhoneHandler) GetPhone(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	phone, err := h.smsService.GetPhone(c.UserContext(), userID)
+	if err != nil {
+		return phoneError(c, err)
+	}
+
+	return response.Success(c, phone, "Phone number retrieved successfully")
+}
+
+// SetPhone handles PUT /api/v1/users/me/phone
+func (h *PhoneHandler) SetPhone(c *fiber.Ctx) error {
+	var req domain.SetPhoneRequest
+	if err := validator.ParseAndValidate(c, &req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	phone, err := h.smsService.SetPhone(c.UserContext(), userID, &req)
+	if err != nil {
+		return phoneError(c, err)
+	}
+
+	return response.Success(c, phone, "Verification code sent")
+}
+
+// VerifyPhone handles POST /api/v1/users/me/phone/verify
+func (h *PhoneHandler) VerifyPhone(c *fiber.Ctx) error {
+	var req domain.VerifyPhoneRequest
+	if err := validator.ParseAndValidate(c, &req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	phone, err := h.smsService.VerifyPhone(c.UserContext(), userID, &req)
+	if err != nil {
+		return phoneError(c, err)
+	}
+
+	return response.Success(c, phone, "Phone number verified successfully")
+}
+
+// UpdateConsent handles PUT /api/v1/users/me/phone/consent
+func (h *PhoneHandler) UpdateConsent(c *fiber.Ctx) error {
+	var req domain.UpdateSMSConsentRequest
+	if err := validator.ParseAndValidate(c, &req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	phone, err := h.smsService.UpdateConsent(c.UserContext(), userID, &req)
+	if err != nil {
+		return phoneError(c, err)
+	}
+
+	return response.Success(c, phone, "Text message consent updated successfully")
+}
+
+// RemovePhone handles DELETE /api/v1/users/me/phone
+func (h *PhoneHandler) RemovePhone(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	if err := h.smsService.RemovePhone(c.UserContext(), userID); err != nil {
+		return phoneError(c, err)
+	}
+
+	return response.Success(c, nil, "Phone number removed successfully")
+}
+
+// phoneError responds with the status matching an SMS service error
+func phoneError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, domain.ErrSMSRateLimited):
+		return response.Error(c, fiber.StatusTooManyRequests, err.Error())
+	case strings.HasSuffix(err.Error(), "not found"):
+		return response.Error(c, fiber.StatusNotFound, err.Error())
+	case err.Error() == "invalid code" || strings.HasPrefix(err.Error(), "code is expired"):
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	default:
+		return response.Error(c, fiber.StatusInternalServerError, err.Error())
+	}
+}
@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/pkg/response"
+)
+
+// PurgeHandler handles purging soft-deleted records
+type PurgeHandler struct {
+	purgeService ports.PurgeService
+}
+
+// NewPurgeHandler creates a new purge handler instance
+func NewPurgeHandler(purgeService ports.PurgeService) *PurgeHandler {
+	return &PurgeHandler{
+		purgeService: purgeService,
+	}
+}
+
+// RunPurge handles POST /admin/purge; ?dry_run=true only reports what would be purged
+func (h *PurgeHandler) RunPurge(c *fiber.Ctx) error {
+	report, err := h.purgeService.Run(c.UserContext(), c.QueryBool("dry_run"))
+	if err != nil {
+		return response.Error(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	message := "Soft-deleted records purged successfully"
+	if report.DryRun {
+		message = "Purge dry run completed successfully"
+	}
+	return response.Success(c, report, message)
+}
+
+// GetPurgeStats handles GET /admin/purge/stats
+func (h *PurgeHandler) GetPurgeStats(c *fiber.Ctx) error {
+	return response.Success(c, h.purgeService.Stats(), "Purge stats retrieved successfully")
+}
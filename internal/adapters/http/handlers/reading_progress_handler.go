@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/pkg/response"
+	"github.com/thitiphongD/my-backend/pkg/validator"
+)
+
+// ReadingProgressHandler handles HTTP requests for reading progress
+type ReadingProgressHandler struct {
+	progressService ports.ReadingProgressService
+}
+
+// NewReadingProgressHandler creates a new reading progress handler instance
+func NewReadingProgressHandler(progressService ports.ReadingProgressService) *ReadingProgressHandler {
+	return &ReadingProgressHandler{
+		progressService: progressService,
+	}
+}
+
+// UpdateProgress handles PUT /api/v1/mangas/:id/progress
+func (h *ReadingProgressHandler) UpdateProgress(c *fiber.Ctx) error {
+	mangaID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid manga ID")
+	}
+
+	var req domain.UpdateProgressRequest
+	if err := validator.ParseAndValidate(c, &req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	progress, err := h.progressService.UpdateProgress(c.UserContext(), uint(mangaID), &req, userID)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	return response.Success(c, progress, "Reading progress saved successfully")
+}
+
+// GetProgress handles GET /api/v1/mangas/:id/progress
+func (h *ReadingProgressHandler) GetProgress(c *fiber.Ctx) error {
+	mangaID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid manga ID")
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	progress, err := h.progressService.GetProgress(c.UserContext(), uint(mangaID), userID)
+	if err != nil {
+		return response.Error(c, fiber.StatusNotFound, err.Error())
+	}
+
+	return response.Success(c, progress, "Reading progress retrieved successfully")
+}
+
+// GetContinueReading handles GET /api/v1/users/me/reading?page=1&page_size=10
+func (h *ReadingProgressHandler) GetContinueReading(c *fiber.Ctx) error {
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size", "10"))
+	pagination := domain.NewPaginationRequest(page, pageSize)
+
+	userID := c.Locals("userID").(uint)
+
+	result, err := h.progressService.GetContinueReading(c.UserContext(), userID, pagination)
+	if err != nil {
+		return response.Error(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.Success(c, withPageLinks(c, result), "Continue reading list retrieved successfully")
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/pkg/response"
+	"github.com/thitiphongD/my-backend/pkg/validator"
+)
+
+// RentalHandler handles HTTP requests for manga rentals
+type RentalHandler struct {
+	rentalService ports.RentalService
+}
+
+// NewRentalHandler creates a new rental handler instance
+func NewRentalHandler(rentalService ports.RentalService) *RentalHandler {
+	return &RentalHandler{
+		rentalService: rentalService,
+	}
+}
+
+// RentManga handles POST /api/v1/mangas/:id/rent
+func (h *RentalHandler) RentManga(c *fiber.Ctx) error {
+	mangaID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid manga ID")
+	}
+
+	// The body is optional; an empty one rents for the default period
+	var req domain.RentMangaRequest
+	if len(c.Body()) > 0 {
+		if err := validator.ParseAndValidate(c, &req); err != nil {
+			return response.Error(c, fiber.StatusBadRequest, err.Error())
+		}
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	rental, err := h.rentalService.RentManga(c.UserContext(), uint(mangaID), &req, userID)
+	if err != nil {
+		return response.Error(c, statusForRentalError(err), err.Error())
+	}
+
+	return response.Created(c, rental, "Manga rented successfully")
+}
+
+// GetMyRentals handles GET /api/v1/users/me/rentals?page=1&page_size=10
+func (h *RentalHandler) GetMyRentals(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size", "10"))
+	pagination := domain.NewPaginationRequest(page, pageSize)
+
+	userID := c.Locals("userID").(uint)
+
+	result, err := h.rentalService.GetActiveRentals(c.UserContext(), userID, pagination)
+	if err != nil {
+		return response.Error(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.Success(c, withPageLinks(c, result), "Rentals retrieved successfully")
+}
+
+// statusForRentalError maps rental service errors to HTTP status codes
+func statusForRentalError(err error) int {
+	switch {
+	case strings.HasSuffix(err.Error(), "not found"):
+		return fiber.StatusNotFound
+	case strings.HasPrefix(err.Error(), "you are already"):
+		return fiber.StatusConflict
+	default:
+		return fiber.StatusBadRequest
+	}
+}
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/pkg/response"
+	"github.com/thitiphongD/my-backend/pkg/validator"
+)
+
+// ReviewHandler handles HTTP requests for manga reviews
+type ReviewHandler struct {
+	reviewService ports.ReviewService
+}
+
+// NewReviewHandler creates a new review handler instance
+func NewReviewHandler(reviewService ports.ReviewService) *ReviewHandler {
+	return &ReviewHandler{
+		reviewService: reviewService,
+	}
+}
+
+// GetReviews handles GET /api/v1/mangas/:id/reviews?page=1&page_size=10
+func (h *ReviewHandler) GetReviews(c *fiber.Ctx) error {
+	mangaID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid manga ID")
+	}
+
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size", "10"))
+	pagination := domain.NewPaginationRequest(page, pageSize)
+
+	result, err := h.reviewService.GetReviewsPaginated(c.UserContext(), uint(mangaID), pagination)
+	if err != nil {
+		return response.Error(c, fiber.StatusNotFound, err.Error())
+	}
+
+	return response.Success(c, withPageLinks(c, result), "Reviews retrieved successfully")
+}
+
+// CreateReview handles POST /api/v1/mangas/:id/reviews
+func (h *ReviewHandler) CreateReview(c *fiber.Ctx) error {
+	mangaID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid manga ID")
+	}
+
+	var req domain.ReviewRequest
+	if err := validator.ParseAndValidate(c, &req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	review, err := h.reviewService.CreateReview(c.UserContext(), uint(mangaID), &req, userID)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	return response.Created(c, review, "Review created successfully")
+}
+
+// UpdateReview handles PUT /api/v1/mangas/:id/reviews/:reviewID
+func (h *ReviewHandler) UpdateReview(c *fiber.Ctx) error {
+	mangaID, reviewID, err := parseReviewParams(c)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	var req domain.ReviewRequest
+	if err := validator.ParseAndValidate(c, &req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	review, err := h.reviewService.UpdateReview(c.UserContext(), mangaID, reviewID, &req, userID)
+	if err != nil {
+		return response.Error(c, fiber.StatusForbidden, err.Error())
+	}
+
+	return response.Success(c, review, "Review updated successfully")
+}
+
+// DeleteReview handles DELETE /api/v1/mangas/:id/reviews/:reviewID
+func (h *ReviewHandler) DeleteReview(c *fiber.Ctx) error {
+	mangaID, reviewID, err := parseReviewParams(c)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	if err := h.reviewService.DeleteReview(c.UserContext(), mangaID, reviewID, userID); err != nil {
+		return response.Error(c, fiber.StatusForbidden, err.Error())
+	}
+
+	return response.Success(c, nil, "Review deleted successfully")
+}
+
+// parseReviewParams parses the manga and review IDs from the route
+func parseReviewParams(c *fiber.Ctx) (uint, uint, error) {
+	mangaID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return 0, 0, fiber.NewError(fiber.StatusBadRequest, "Invalid manga ID")
+	}
+
+	reviewID, err := strconv.ParseUint(c.Params("reviewID"), 10, 32)
+	if err != nil {
+		return 0, 0, fiber.NewError(fiber.StatusBadRequest, "Invalid review ID")
+	}
+
+	return uint(mangaID), uint(reviewID), nil
+}
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/pkg/response"
+)
+
+// Auth requirement levels reported by the route listing
+const (
+	RouteAuthPublic        = "public"
+	RouteAuthAuthenticated = "authenticated"
+	RouteAuthAdmin         = "admin"
+	RouteAuthSuperAdmin    = "super_admin"
+)
+
+// RouteInfo describes a registered route for the route listing
+type RouteInfo struct {
+	Method     string   `json:"method"`
+	Path       string   `json:"path"`
+	Name       string   `json:"name,omitempty"`
+	Auth       string   `json:"auth"`
+	RateLimits []string `json:"rate_limits,omitempty"` // the ROUTE_LIMITS entries matching the route
+	Handlers   []string `json:"handlers"`
+}
+
+// RouteHandler handles route introspection requests
+type RouteHandler struct {
+	app         *fiber.App
+	routeLimits []*domain.RouteLimit
+}
+
+// NewRouteHandler creates a new route handler instance
+func NewRouteHandler(app *fiber.App, routeLimits []*domain.RouteLimit) *RouteHandler {
+	return &RouteHandler{
+		app:         app,
+		routeLimits: routeLimits,
+	}
+}
+
+// ListRoutes handles GET /admin/routes
+func (h *RouteHandler) ListRoutes(c *fiber.Ctx) error {
+	routes := h.app.GetRoutes(true)
+
+	infos := make([]RouteInfo, 0, len(routes))
+	for _, route := range routes {
+		// Fiber registers HEAD automatically for every GET route
+		if route.Method == fiber.MethodHead {
+			continue
+		}
+		infos = append(infos, describeRoute(route, h.routeLimits))
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		if infos[i].Path == infos[j].Path {
+			return infos[i].Method < infos[j].Method
+		}
+		return infos[i].Path < infos[j].Path
+	})
+
+	return response.Success(c, fiber.Map{
+		"total":  len(infos),
+		"routes": infos,
+	}, "Routes retrieved successfully")
+}
+
+// describeRoute builds route metadata from the route's handler chain and the
+// route limits matching it
+func describeRoute(route fiber.Route, routeLimits []*domain.RouteLimit) RouteInfo {
+	info := RouteInfo{
+		Method:   route.Method,
+		Path:     route.Path,
+		Name:     route.Name,
+		Auth:     RouteAuthPublic,
+		Handlers: make([]string, 0, len(route.Handlers)),
+	}
+
+	for _, handler := range route.Handlers {
+		name := handlerName(handler)
+		info.Handlers = append(info.Handlers, name)
+
+		switch {
+		case strings.HasPrefix(name, "middleware.SuperAdminMiddleware"):
+			info.Auth = RouteAuthSuperAdmin
+		case strings.HasPrefix(name, "middleware.AdminMiddleware") && info.Auth != RouteAuthSuperAdmin:
+			info.Auth = RouteAuthAdmin
+		case strings.HasPrefix(name, "middleware.AuthMiddleware") && info.Auth == RouteAuthPublic:
+			info.Auth = RouteAuthAuthenticated
+		}
+	}
+
+	for _, limit := range routeLimits {
+		if limit.Matches(route.Method, route.Path) {
+			info.RateLimits = append(info.RateLimits, limit.Route()+"="+limit.Limit)
+		}
+	}
+
+	return info
+}
+
+// handlerName returns a short package-qualified name for a handler function
+func handlerName(handler fiber.Handler) string {
+	fn := runtime.FuncForPC(reflect.ValueOf(handler).Pointer())
+	if fn == nil {
+		return "unknown"
+	}
+
+	name := fn.Name()
+	if idx := strings.LastIndex(name, "/"); idx >= 0 {
+		name = name[idx+1:]
+	}
+	return strings.TrimSuffix(name, "-fm")
+}
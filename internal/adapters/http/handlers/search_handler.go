@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/pkg/response"
+)
+
+// SearchHandler handles manga search requests
+type SearchHandler struct {
+	searchService ports.MangaSearchService
+}
+
+// NewSearchHandler creates a new search handler instance
+func NewSearchHandler(searchService ports.MangaSearchService) *SearchHandler {
+	return &SearchHandler{
+		searchService: searchService,
+	}
+}
+
+// SearchMangas handles GET /api/v1/mangas/search with the filters of GetMangas,
+// best matches first, with genre, publication status and content rating counts:
+// /api/v1/mangas/search?q=one+pice&genre=shonen&page=1&page_size=10&lang=th
+func (h *SearchHandler) SearchMangas(c *fiber.Ctx) error {
+	filter, err := parseMangaFilter(c)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error(), "Invalid filter parameter")
+	}
+
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size", "10"))
+	pagination := domain.NewPaginationRequest(page, pageSize)
+
+	locale, err := requestedLocale(c)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error(), "Invalid lang parameter")
+	}
+
+	result, err := h.searchService.SearchMangas(c.UserContext(), filter, pagination)
+	if err != nil {
+		return response.Error(c, fiber.StatusInternalServerError, err, "Failed to search mangas")
+	}
+	links := newLinkBuilder(c)
+	for _, manga := range result.Data {
+		manga.Localize(locale)
+		manga.Links = links.manga(manga.ID)
+	}
+	result.Links = links.pagination(result.Pagination)
+
+	return response.Success(c, result, "Mangas retrieved successfully")
+}
+
+// Reindex handles POST /admin/search/reindex
+func (h *SearchHandler) Reindex(c *fiber.Ctx) error {
+	indexed, err := h.searchService.Reindex(c.UserContext())
+	if err != nil {
+		return response.Error(c, fiber.StatusInternalServerError, err.Error(), "Failed to reindex mangas")
+	}
+	return response.Success(c, fiber.Map{"indexed": indexed}, "Mangas reindexed successfully")
+}
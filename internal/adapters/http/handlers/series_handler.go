@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/pkg/response"
+	"github.com/thitiphongD/my-backend/pkg/validator"
+)
+
+// SeriesHandler handles HTTP requests for manga series
+type SeriesHandler struct {
+	seriesService ports.SeriesService
+}
+
+// NewSeriesHandler creates a new series handler instance
+func NewSeriesHandler(seriesService ports.SeriesService) *SeriesHandler {
+	return &SeriesHandler{
+		seriesService: seriesService,
+	}
+}
+
+// CreateSeries handles POST /api/v1/series
+func (h *SeriesHandler) CreateSeries(c *fiber.Ctx) error {
+	var req domain.SeriesRequest
+	if err := validator.ParseAndValidate(c, &req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	series, err := h.seriesService.CreateSeries(c.UserContext(), &req, userID)
+	if err != nil {
+		return response.Error(c, statusForSeriesError(err), err.Error())
+	}
+
+	return response.Created(c, series, "Series created successfully")
+}
+
+// GetSeries handles GET /api/v1/series/:id
+func (h *SeriesHandler) GetSeries(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid series ID")
+	}
+
+	series, err := h.seriesService.GetSeries(c.UserContext(), uint(id))
+	if err != nil {
+		return response.Error(c, fiber.StatusNotFound, err.Error())
+	}
+
+	return response.Success(c, series, "Series retrieved successfully")
+}
+
+// UpdateSeries handles PUT /api/v1/series/:id
+func (h *SeriesHandler) UpdateSeries(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid series ID")
+	}
+
+	var req domain.SeriesRequest
+	if err := validator.ParseAndValidate(c, &req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	series, err := h.seriesService.UpdateSeries(c.UserContext(), uint(id), &req, userID)
+	if err != nil {
+		return response.Error(c, statusForSeriesError(err), err.Error())
+	}
+
+	return response.Success(c, series, "Series updated successfully")
+}
+
+// DeleteSeries handles DELETE /api/v1/series/:id
+func (h *SeriesHandler) DeleteSeries(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid series ID")
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	if err := h.seriesService.DeleteSeries(c.UserContext(), uint(id), userID); err != nil {
+		return response.Error(c, statusForSeriesError(err), err.Error())
+	}
+
+	return response.Success(c, nil, "Series deleted successfully")
+}
+
+// AddVolume handles POST /api/v1/series/:id/volumes
+func (h *SeriesHandler) AddVolume(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid series ID")
+	}
+
+	var req domain.AddSeriesVolumeRequest
+	if err := validator.ParseAndValidate(c, &req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	series, err := h.seriesService.AddVolume(c.UserContext(), uint(id), &req, userID)
+	if err != nil {
+		return response.Error(c, statusForSeriesError(err), err.Error())
+	}
+
+	return response.Success(c, series, "Volume added successfully")
+}
+
+// RemoveVolume handles DELETE /api/v1/series/:id/volumes/:mangaID
+func (h *SeriesHandler) RemoveVolume(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid series ID")
+	}
+
+	mangaID, err := strconv.ParseUint(c.Params("mangaID"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid manga ID")
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	if err := h.seriesService.RemoveVolume(c.UserContext(), uint(id), uint(mangaID), userID); err != nil {
+		return response.Error(c, statusForSeriesError(err), err.Error())
+	}
+
+	return response.Success(c, nil, "Volume removed successfully")
+}
+
+// ReorderVolumes handles PUT /api/v1/series/:id/volumes/order
+func (h *SeriesHandler) ReorderVolumes(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid series ID")
+	}
+
+	var req domain.ReorderSeriesVolumesRequest
+	if err := validator.ParseAndValidate(c, &req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	series, err := h.seriesService.ReorderVolumes(c.UserContext(), uint(id), &req, userID)
+	if err != nil {
+		return response.Error(c, statusForSeriesError(err), err.Error())
+	}
+
+	return response.Success(c, series, "Volumes reordered successfully")
+}
+
+// statusForSeriesError maps series service errors to HTTP status codes
+func statusForSeriesError(err error) int {
+	switch {
+	case strings.HasPrefix(err.Error(), "access denied"):
+		return fiber.StatusForbidden
+	case strings.HasSuffix(err.Error(), "not found"):
+		return fiber.StatusNotFound
+	default:
+		return fiber.StatusBadRequest
+	}
+}
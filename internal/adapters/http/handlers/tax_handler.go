@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/pkg/response"
+	"github.com/thitiphongD/my-backend/pkg/validator"
+)
+
+// TaxRateHandler handles HTTP requests for tax rates
+type TaxRateHandler struct {
+	taxService ports.TaxRateService
+}
+
+// NewTaxRateHandler creates a new tax rate handler instance
+func NewTaxRateHandler(taxService ports.TaxRateService) *TaxRateHandler {
+	return &TaxRateHandler{
+		taxService: taxService,
+	}
+}
+
+// GetTaxRates handles GET /api/v1/admin/tax-rates
+func (h *TaxRateHandler) GetTaxRates(c *fiber.Ctx) error {
+	rates, err := h.taxService.GetTaxRates(c.UserContext())
+	if err != nil {
+		return response.Error(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.Success(c, rates, "Tax rates retrieved successfully")
+}
+
+// CreateTaxRate handles POST /api/v1/admin/tax-rates
+func (h *TaxRateHandler) CreateTaxRate(c *fiber.Ctx) error {
+	var req domain.TaxRateRequest
+	if err := validator.ParseAndValidate(c, &req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	rate, err := h.taxService.CreateTaxRate(c.UserContext(), &req)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	return response.Created(c, rate, "Tax rate created successfully")
+}
+
+// UpdateTaxRate handles PUT /api/v1/admin/tax-rates/:id
+func (h *TaxRateHandler) UpdateTaxRate(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid tax rate ID")
+	}
+
+	var req domain.TaxRateRequest
+	if err := validator.ParseAndValidate(c, &req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	rate, err := h.taxService.UpdateTaxRate(c.UserContext(), uint(id), &req)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	return response.Success(c, rate, "Tax rate updated successfully")
+}
+
+// DeleteTaxRate handles DELETE /api/v1/admin/tax-rates/:id
+func (h *TaxRateHandler) DeleteTaxRate(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid tax rate ID")
+	}
+
+	if err := h.taxService.DeleteTaxRate(c.UserContext(), uint(id)); err != nil {
+		return response.Error(c, fiber.StatusNotFound, err.Error())
+	}
+
+	return response.Success(c, nil, "Tax rate deleted successfully")
+}
@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/pkg/response"
+	"github.com/thitiphongD/my-backend/pkg/validator"
+)
+
+// TeamHandler handles HTTP requests for team operations
+type TeamHandler struct {
+	teamService  ports.TeamService
+	mangaService ports.MangaService
+}
+
+// NewTeamHandler creates a new team handler instance
+func NewTeamHandler(teamService ports.TeamService, mangaService ports.MangaService) *TeamHandler {
+	return &TeamHandler{
+		teamService:  teamService,
+		mangaService: mangaService,
+	}
+}
+
+// CreateTeam handles POST /api/v1/teams
+func (h *TeamHandler) CreateTeam(c *fiber.Ctx) error {
+	var req domain.CreateTeamRequest
+	if err := validator.ParseAndValidate(c, &req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	team, err := h.teamService.CreateTeam(c.UserContext(), &req, userID)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	return response.Created(c, team, "Team created successfully")
+}
+
+// GetMyTeams handles GET /api/v1/teams
+func (h *TeamHandler) GetMyTeams(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	teams, err := h.teamService.GetTeamsByUser(c.UserContext(), userID)
+	if err != nil {
+		return response.Error(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.Success(c, teams, "Teams retrieved successfully")
+}
+
+// GetTeam handles GET /api/v1/teams/:id
+func (h *TeamHandler) GetTeam(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid team ID")
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	team, err := h.teamService.GetTeam(c.UserContext(), uint(id), userID)
+	if err != nil {
+		return response.Error(c, fiber.StatusForbidden, err.Error())
+	}
+
+	return response.Success(c, team, "Team retrieved successfully")
+}
+
+// DeleteTeam handles DELETE /api/v1/teams/:id
+func (h *TeamHandler) DeleteTeam(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid team ID")
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	if err := h.teamService.DeleteTeam(c.UserContext(), uint(id), userID); err != nil {
+		return response.Error(c, fiber.StatusForbidden, err.Error())
+	}
+
+	return response.Success(c, nil, "Team deleted successfully")
+}
+
+// GetTeamMangas handles GET /api/v1/teams/:id/mangas
+func (h *TeamHandler) GetTeamMangas(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid team ID")
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	sort, err := parseMangaSort(c)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	mangas, err := h.mangaService.GetMangasByTeam(c.UserContext(), uint(id), userID, sort)
+	if err != nil {
+		return response.Error(c, fiber.StatusForbidden, err.Error())
+	}
+
+	return response.Success(c, mangas, "Team mangas retrieved successfully")
+}
+
+// InviteMember handles POST /api/v1/teams/:id/invitations
+func (h *TeamHandler) InviteMember(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid team ID")
+	}
+
+	var req domain.InviteTeamMemberRequest
+	if err := validator.ParseAndValidate(c, &req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	invitation, err := h.teamService.InviteMember(c.UserContext(), uint(id), &req, userID)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	return response.Created(c, invitation, "Invitation created successfully")
+}
+
+// AcceptInvitation handles POST /api/v1/teams/invitations/:token/accept
+func (h *TeamHandler) AcceptInvitation(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	team, err := h.teamService.AcceptInvitation(c.UserContext(), c.Params("token"), userID)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	return response.Success(c, team, "Invitation accepted successfully")
+}
+
+// RemoveMember handles DELETE /api/v1/teams/:id/members/:userID
+func (h *TeamHandler) RemoveMember(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid team ID")
+	}
+
+	memberID, err := strconv.ParseUint(c.Params("userID"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid user ID")
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	if err := h.teamService.RemoveMember(c.UserContext(), uint(id), uint(memberID), userID); err != nil {
+		return response.Error(c, fiber.StatusForbidden, err.Error())
+	}
+
+	return response.Success(c, nil, "Team member removed successfully")
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/pkg/response"
+	"github.com/thitiphongD/my-backend/pkg/validator"
+)
+
+// UploadHandler handles HTTP requests for presigned uploads
+type UploadHandler struct {
+	uploadService ports.UploadService
+}
+
+// NewUploadHandler creates a new upload handler instance
+func NewUploadHandler(uploadService ports.UploadService) *UploadHandler {
+	return &UploadHandler{
+		uploadService: uploadService,
+	}
+}
+
+// PresignUpload handles POST /api/v1/uploads/presign
+func (h *UploadHandler) PresignUpload(c *fiber.Ctx) error {
+	var req domain.PresignUploadRequest
+	if err := validator.ParseAndValidate(c, &req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	presigned, err := h.uploadService.Presign(c.UserContext(), &req, userID)
+	if err != nil {
+		return response.Error(c, statusForUploadError(err), err.Error())
+	}
+
+	return response.Created(c, presigned, "Upload URL created successfully. PUT the file to it, then confirm the upload")
+}
+
+// ConfirmUpload handles POST /api/v1/uploads/:id/confirm
+func (h *UploadHandler) ConfirmUpload(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid upload ID")
+	}
+
+	var req domain.ConfirmUploadRequest
+	if len(c.Body()) > 0 {
+		if err := validator.ParseAndValidate(c, &req); err != nil {
+			return response.Error(c, fiber.StatusBadRequest, err.Error())
+		}
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	image, err := h.uploadService.Confirm(c.UserContext(), uint(id), &req, userID)
+	if err != nil {
+		return response.Error(c, statusForUploadError(err), err.Error())
+	}
+
+	return response.Created(c, image, "Manga image uploaded successfully")
+}
+
+// statusForUploadError maps upload service errors to HTTP statuses
+func statusForUploadError(err error) int {
+	switch {
+	case strings.HasPrefix(err.Error(), "presigned uploads need"):
+		return fiber.StatusNotImplemented
+	case strings.HasPrefix(err.Error(), "upload has"):
+		return fiber.StatusConflict
+	case strings.HasPrefix(err.Error(), "image must be at most"):
+		return fiber.StatusRequestEntityTooLarge
+	default:
+		return statusForMangaImageError(err)
+	}
+}
@@ -1,7 +1,9 @@
 package handlers
 
 import (
+	"errors"
 	"strconv"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/thitiphongD/my-backend/internal/core/domain"
@@ -12,13 +14,15 @@ import (
 
 // UserHandler handles user-related HTTP requests
 type UserHandler struct {
-	userService ports.UserService
+	userService     ports.UserService
+	presenceService ports.PresenceService
 }
 
 // NewUserHandler creates a new user handler instance
-func NewUserHandler(userService ports.UserService) *UserHandler {
+func NewUserHandler(userService ports.UserService, presenceService ports.PresenceService) *UserHandler {
 	return &UserHandler{
-		userService: userService,
+		userService:     userService,
+		presenceService: presenceService,
 	}
 }
 
@@ -30,7 +34,7 @@ func (h *UserHandler) CreateUser(c *fiber.Ctx) error {
 		return response.Error(c, fiber.StatusBadRequest, err.Error())
 	}
 
-	user, err := h.userService.CreateUser(&req)
+	user, err := h.userService.CreateUser(c.UserContext(), &req)
 	if err != nil {
 		return response.Error(c, fiber.StatusBadRequest, err.Error())
 	}
@@ -40,12 +44,24 @@ func (h *UserHandler) CreateUser(c *fiber.Ctx) error {
 
 // GetUsers handles retrieving all users
 func (h *UserHandler) GetUsers(c *fiber.Ctx) error {
-	users, err := h.userService.GetUsers()
+	users, err := h.userService.GetUsers(c.UserContext())
 	if err != nil {
 		return response.Error(c, fiber.StatusInternalServerError, err.Error())
 	}
 
-	return response.Success(c, users, "Users retrieved successfully")
+	return response.Success(c, domain.NewPublicUserResponses(users), "Users retrieved successfully")
+}
+
+// SearchUsers handles GET /api/v1/users/search?q=ali&limit=10
+func (h *UserHandler) SearchUsers(c *fiber.Ctx) error {
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+
+	users, err := h.userService.SearchUsers(c.UserContext(), c.Query("q"), limit)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	return response.Success(c, domain.NewUserSuggestions(users), "Users found successfully")
 }
 
 // GetUserByID handles retrieving a user by ID
@@ -56,12 +72,16 @@ func (h *UserHandler) GetUserByID(c *fiber.Ctx) error {
 		return response.Error(c, fiber.StatusBadRequest, "Invalid user ID")
 	}
 
-	user, err := h.userService.GetUserByID(uint(id))
+	user, err := h.userService.GetUserByID(c.UserContext(), uint(id))
 	if err != nil {
 		return response.Error(c, fiber.StatusNotFound, err.Error())
 	}
 
-	return response.Success(c, user, "User retrieved successfully")
+	if notModified(c, user.ETag(), user.UpdatedAt) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
+	return response.Success(c, domain.NewPublicUserResponse(user), "User retrieved successfully")
 }
 
 // UpdateUser handles user updates
@@ -77,14 +97,77 @@ func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
 		return response.Error(c, fiber.StatusBadRequest, err.Error())
 	}
 
-	user, err := h.userService.UpdateUser(uint(id), &req)
+	// Full updates must be based on the current version to avoid lost updates
+	req.IfMatch = c.Get(fiber.HeaderIfMatch)
+	if req.IfMatch == "" {
+		return response.Error(c, fiber.StatusPreconditionRequired, "If-Match header is required; send the ETag from your last GET")
+	}
+
+	caller := c.Locals("user").(*domain.User)
+
+	user, err := h.userService.UpdateUser(c.UserContext(), uint(id), &req, caller.ID, caller.IsAdmin())
 	if err != nil {
-		return response.Error(c, fiber.StatusBadRequest, err.Error())
+		return response.Error(c, statusForUserError(err), err.Error())
 	}
 
+	c.Set(fiber.HeaderETag, user.ETag())
 	return response.Success(c, user, "User updated successfully")
 }
 
+// PatchUser handles PATCH /api/v1/users/:id, partial updates of the user's
+// own account, or of any account by admins
+func (h *UserHandler) PatchUser(c *fiber.Ctx) error {
+	idParam := c.Params("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid user ID")
+	}
+
+	return h.patchUser(c, uint(id))
+}
+
+// PatchMe handles PATCH /api/v1/users/me
+func (h *UserHandler) PatchMe(c *fiber.Ctx) error {
+	return h.patchUser(c, c.Locals("userID").(uint))
+}
+
+// patchUser applies the partial update in the request body to the user
+func (h *UserHandler) patchUser(c *fiber.Ctx, id uint) error {
+	var req domain.UpdateUserRequest
+	if err := validator.ParseAndValidate(c, &req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	caller := c.Locals("user").(*domain.User)
+
+	user, err := h.userService.PatchUser(c.UserContext(), id, &req, caller.ID, caller.IsAdmin())
+	if err != nil {
+		return response.Error(c, statusForUserError(err), err.Error())
+	}
+
+	message := "User updated successfully"
+	if user.PendingEmail != "" && req.Email != nil {
+		message = "User updated successfully; follow the link sent to the new email address to confirm it"
+	}
+	return response.Success(c, user, message)
+}
+
+// ConfirmEmailChange handles GET /api/v1/users/email/confirm?token=... from
+// the link emailed to a new address
+func (h *UserHandler) ConfirmEmailChange(c *fiber.Ctx) error {
+	token := c.Query("token")
+	if token == "" {
+		return response.Error(c, fiber.StatusBadRequest, "Email change token is required")
+	}
+
+	user, err := h.userService.ConfirmEmailChange(c.UserContext(), token)
+	if err != nil {
+		return response.Error(c, statusForUserError(err), err.Error())
+	}
+
+	return response.Success(c, user, "Email address changed successfully")
+}
+
 // DeleteUser handles user deletion
 func (h *UserHandler) DeleteUser(c *fiber.Ctx) error {
 	idParam := c.Params("id")
@@ -93,9 +176,37 @@ func (h *UserHandler) DeleteUser(c *fiber.Ctx) error {
 		return response.Error(c, fiber.StatusBadRequest, "Invalid user ID")
 	}
 
-	if err := h.userService.DeleteUser(uint(id)); err != nil {
-		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	caller := c.Locals("user").(*domain.User)
+
+	if err := h.userService.DeleteUser(c.UserContext(), uint(id), caller.ID, caller.IsAdmin()); err != nil {
+		return response.Error(c, statusForUserError(err), err.Error())
 	}
 
 	return response.Success(c, nil, "User deleted successfully")
 }
+
+// GetOnlineCount handles GET /api/v1/users/online
+func (h *UserHandler) GetOnlineCount(c *fiber.Ctx) error {
+	count, err := h.presenceService.CountOnline(c.UserContext())
+	if err != nil {
+		return response.Error(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.Success(c, fiber.Map{"online": count}, "Online users counted successfully")
+}
+
+// statusForUserError maps user service errors to HTTP statuses
+func statusForUserError(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrPreconditionFailed):
+		return fiber.StatusPreconditionFailed
+	case strings.HasPrefix(err.Error(), "access denied"):
+		return fiber.StatusForbidden
+	case strings.HasSuffix(err.Error(), "not found"):
+		return fiber.StatusNotFound
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return fiber.StatusInternalServerError
+	default:
+		return fiber.StatusBadRequest
+	}
+}
@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/pkg/response"
+	"github.com/thitiphongD/my-backend/pkg/validator"
+)
+
+// WebhookHandler handles HTTP requests for user webhooks
+type WebhookHandler struct {
+	webhookService ports.WebhookService
+}
+
+// NewWebhookHandler creates a new webhook handler instance
+func NewWebhookHandler(webhookService ports.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+	}
+}
+
+// CreateWebhook handles POST /api/v1/users/me/webhooks
+func (h *WebhookHandler) CreateWebhook(c *fiber.Ctx) error {
+	var req domain.CreateWebhookRequest
+	if err := validator.ParseAndValidate(c, &req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	webhook, err := h.webhookService.CreateWebhook(c.UserContext(), &req, userID)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	return response.Created(c, webhook, "Webhook created successfully. Store the secret now, it will not be shown again")
+}
+
+// GetWebhooks handles GET /api/v1/users/me/webhooks
+func (h *WebhookHandler) GetWebhooks(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	webhooks, err := h.webhookService.GetWebhooks(c.UserContext(), userID)
+	if err != nil {
+		return response.Error(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.Success(c, webhooks, "Webhooks retrieved successfully")
+}
+
+// DeleteWebhook handles DELETE /api/v1/users/me/webhooks/:id
+func (h *WebhookHandler) DeleteWebhook(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid webhook ID")
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	if err := h.webhookService.DeleteWebhook(c.UserContext(), uint(id), userID); err != nil {
+		return response.Error(c, fiber.StatusNotFound, err.Error())
+	}
+
+	return response.Success(c, nil, "Webhook deleted successfully")
+}
+
+// GetDeliveries handles GET /api/v1/users/me/webhooks/:id/deliveries?page=1&page_size=10
+func (h *WebhookHandler) GetDeliveries(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid webhook ID")
+	}
+
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size", "10"))
+	pagination := domain.NewPaginationRequest(page, pageSize)
+
+	userID := c.Locals("userID").(uint)
+
+	result, err := h.webhookService.GetDeliveriesPaginated(c.UserContext(), uint(id), userID, pagination)
+	if err != nil {
+		return response.Error(c, fiber.StatusNotFound, err.Error())
+	}
+
+	return response.Success(c, withPageLinks(c, result), "Webhook deliveries retrieved successfully")
+}
+
+// GetWebhookEvents handles GET /api/v1/users/me/webhooks/events
+func (h *WebhookHandler) GetWebhookEvents(c *fiber.Ctx) error {
+	return response.Success(c, domain.WebhookEvents, "Webhook events retrieved successfully")
+}
+
+// GetDelivery handles GET /api/v1/users/me/webhooks/:id/deliveries/:deliveryID
+func (h *WebhookHandler) GetDelivery(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid webhook ID")
+	}
+
+	deliveryID, err := strconv.ParseUint(c.Params("deliveryID"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid delivery ID")
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	delivery, err := h.webhookService.GetDelivery(c.UserContext(), uint(id), uint(deliveryID), userID)
+	if err != nil {
+		return response.Error(c, statusForWebhookError(err), err.Error())
+	}
+
+	return response.Success(c, delivery, "Webhook delivery retrieved successfully")
+}
+
+// RedeliverDelivery handles POST /api/v1/users/me/webhooks/:id/deliveries/:deliveryID/redeliver
+func (h *WebhookHandler) RedeliverDelivery(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid webhook ID")
+	}
+
+	deliveryID, err := strconv.ParseUint(c.Params("deliveryID"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid delivery ID")
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	delivery, err := h.webhookService.RedeliverDelivery(c.UserContext(), uint(id), uint(deliveryID), userID)
+	if err != nil {
+		return response.Error(c, statusForWebhookError(err), err.Error())
+	}
+
+	return response.Accepted(c, delivery, "Webhook delivery queued for redelivery")
+}
+
+// GetAllDeliveries handles GET /api/v1/admin/webhook-deliveries?status=dead_lettered&page=1&page_size=10
+func (h *WebhookHandler) GetAllDeliveries(c *fiber.Ctx) error {
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size", "10"))
+	pagination := domain.NewPaginationRequest(page, pageSize)
+
+	result, err := h.webhookService.GetAllDeliveries(c.UserContext(), c.Query("status"), pagination)
+	if err != nil {
+		return response.Error(c, statusForWebhookError(err), err.Error())
+	}
+
+	return response.Success(c, withPageLinks(c, result), "Webhook deliveries retrieved successfully")
+}
+
+// GetAnyDelivery handles GET /api/v1/admin/webhook-deliveries/:id
+func (h *WebhookHandler) GetAnyDelivery(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid delivery ID")
+	}
+
+	delivery, err := h.webhookService.GetAnyDelivery(c.UserContext(), uint(id))
+	if err != nil {
+		return response.Error(c, statusForWebhookError(err), err.Error())
+	}
+
+	return response.Success(c, delivery, "Webhook delivery retrieved successfully")
+}
+
+// RedeliverAnyDelivery handles POST /api/v1/admin/webhook-deliveries/:id/redeliver
+func (h *WebhookHandler) RedeliverAnyDelivery(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid delivery ID")
+	}
+
+	delivery, err := h.webhookService.RedeliverAnyDelivery(c.UserContext(), uint(id))
+	if err != nil {
+		return response.Error(c, statusForWebhookError(err), err.Error())
+	}
+
+	return response.Accepted(c, delivery, "Webhook delivery queued for redelivery")
+}
+
+// statusForWebhookError maps webhook service errors to HTTP status codes
+func statusForWebhookError(err error) int {
+	switch {
+	case strings.HasSuffix(err.Error(), "not found"):
+		return fiber.StatusNotFound
+	case strings.HasSuffix(err.Error(), "in progress"):
+		return fiber.StatusConflict
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return fiber.StatusInternalServerError
+	default:
+		return fiber.StatusBadRequest
+	}
+}
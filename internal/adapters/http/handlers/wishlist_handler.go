@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/pkg/response"
+	"github.com/thitiphongD/my-backend/pkg/validator"
+)
+
+// WishlistHandler handles HTTP requests for wishlists
+type WishlistHandler struct {
+	wishlistService ports.WishlistService
+}
+
+// NewWishlistHandler creates a new wishlist handler instance
+func NewWishlistHandler(wishlistService ports.WishlistService) *WishlistHandler {
+	return &WishlistHandler{
+		wishlistService: wishlistService,
+	}
+}
+
+// GetMyWishlists handles GET /api/v1/users/me/wishlists
+func (h *WishlistHandler) GetMyWishlists(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	wishlists, err := h.wishlistService.GetMyWishlists(c.UserContext(), userID)
+	if err != nil {
+		return response.Error(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.Success(c, wishlists, "Wishlists retrieved successfully")
+}
+
+// GetUserWishlists handles GET /api/v1/users/:id/wishlists
+func (h *WishlistHandler) GetUserWishlists(c *fiber.Ctx) error {
+	userID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid user ID")
+	}
+
+	wishlists, err := h.wishlistService.GetPublicWishlists(c.UserContext(), uint(userID))
+	if err != nil {
+		return response.Error(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.Success(c, wishlists, "Wishlists retrieved successfully")
+}
+
+// CreateWishlist handles POST /api/v1/wishlists
+func (h *WishlistHandler) CreateWishlist(c *fiber.Ctx) error {
+	var req domain.WishlistRequest
+	if err := validator.ParseAndValidate(c, &req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	wishlist, err := h.wishlistService.CreateWishlist(c.UserContext(), &req, userID)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	return response.Created(c, wishlist, "Wishlist created successfully")
+}
+
+// GetWishlist handles GET /api/v1/wishlists/:id (owner, or anyone for public lists)
+func (h *WishlistHandler) GetWishlist(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid wishlist ID")
+	}
+
+	// Anonymous viewers have no user ID and only see public lists
+	viewerID, _ := c.Locals("userID").(uint)
+
+	wishlist, err := h.wishlistService.GetWishlist(c.UserContext(), uint(id), viewerID)
+	if err != nil {
+		return response.Error(c, fiber.StatusNotFound, err.Error())
+	}
+
+	return response.Success(c, wishlist, "Wishlist retrieved successfully")
+}
+
+// GetSharedWishlist handles GET /api/v1/wishlists/shared/:token
+func (h *WishlistHandler) GetSharedWishlist(c *fiber.Ctx) error {
+	wishlist, err := h.wishlistService.GetSharedWishlist(c.UserContext(), c.Params("token"))
+	if err != nil {
+		return response.Error(c, fiber.StatusNotFound, err.Error())
+	}
+
+	return response.Success(c, wishlist, "Wishlist retrieved successfully")
+}
+
+// UpdateWishlist handles PUT /api/v1/wishlists/:id
+func (h *WishlistHandler) UpdateWishlist(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid wishlist ID")
+	}
+
+	var req domain.WishlistRequest
+	if err := validator.ParseAndValidate(c, &req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	wishlist, err := h.wishlistService.UpdateWishlist(c.UserContext(), uint(id), &req, userID)
+	if err != nil {
+		return response.Error(c, statusForWishlistError(err), err.Error())
+	}
+
+	return response.Success(c, wishlist, "Wishlist updated successfully")
+}
+
+// RotateShareToken handles POST /api/v1/wishlists/:id/share
+func (h *WishlistHandler) RotateShareToken(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid wishlist ID")
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	wishlist, err := h.wishlistService.RotateShareToken(c.UserContext(), uint(id), userID)
+	if err != nil {
+		return response.Error(c, statusForWishlistError(err), err.Error())
+	}
+
+	return response.Success(c, wishlist, "Share link regenerated successfully")
+}
+
+// DeleteWishlist handles DELETE /api/v1/wishlists/:id
+func (h *WishlistHandler) DeleteWishlist(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid wishlist ID")
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	if err := h.wishlistService.DeleteWishlist(c.UserContext(), uint(id), userID); err != nil {
+		return response.Error(c, statusForWishlistError(err), err.Error())
+	}
+
+	return response.Success(c, nil, "Wishlist deleted successfully")
+}
+
+// AddItem handles POST /api/v1/wishlists/:id/items
+func (h *WishlistHandler) AddItem(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid wishlist ID")
+	}
+
+	var req domain.AddWishlistItemRequest
+	if err := validator.ParseAndValidate(c, &req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	wishlist, err := h.wishlistService.AddItem(c.UserContext(), uint(id), &req, userID)
+	if err != nil {
+		return response.Error(c, statusForWishlistError(err), err.Error())
+	}
+
+	return response.Success(c, wishlist, "Manga added to wishlist")
+}
+
+// RemoveItem handles DELETE /api/v1/wishlists/:id/items/:mangaID
+func (h *WishlistHandler) RemoveItem(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid wishlist ID")
+	}
+
+	mangaID, err := strconv.ParseUint(c.Params("mangaID"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid manga ID")
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	wishlist, err := h.wishlistService.RemoveItem(c.UserContext(), uint(id), uint(mangaID), userID)
+	if err != nil {
+		return response.Error(c, statusForWishlistError(err), err.Error())
+	}
+
+	return response.Success(c, wishlist, "Manga removed from wishlist")
+}
+
+// ReorderItems handles PUT /api/v1/wishlists/:id/items/order
+func (h *WishlistHandler) ReorderItems(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.Error(c, fiber.StatusBadRequest, "Invalid wishlist ID")
+	}
+
+	var req domain.ReorderWishlistRequest
+	if err := validator.ParseAndValidate(c, &req); err != nil {
+		return response.Error(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	wishlist, err := h.wishlistService.ReorderItems(c.UserContext(), uint(id), &req, userID)
+	if err != nil {
+		return response.Error(c, statusForWishlistError(err), err.Error())
+	}
+
+	return response.Success(c, wishlist, "Wishlist reordered successfully")
+}
+
+// statusForWishlistError maps wishlist service errors to HTTP status codes
+func statusForWishlistError(err error) int {
+	if strings.HasSuffix(err.Error(), "not found") {
+		return fiber.StatusNotFound
+	}
+	return fiber.StatusBadRequest
+}
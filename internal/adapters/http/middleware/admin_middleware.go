@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/pkg/response"
+)
+
+// AdminMiddleware restricts access to admin users (must run after AuthMiddleware)
+func AdminMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		user, ok := c.Locals("user").(*domain.User)
+		if !ok {
+			return response.Error(c, fiber.StatusUnauthorized, "User not authenticated")
+		}
+
+		if !user.IsAdmin() {
+			return response.Error(c, fiber.StatusForbidden, "Admin access required")
+		}
+
+		return c.Next()
+	}
+}
+
+// SuperAdminMiddleware restricts access to super admin users (must run after AuthMiddleware)
+func SuperAdminMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		user, ok := c.Locals("user").(*domain.User)
+		if !ok {
+			return response.Error(c, fiber.StatusUnauthorized, "User not authenticated")
+		}
+
+		if !user.IsSuperAdmin() {
+			return response.Error(c, fiber.StatusForbidden, "Super admin access required")
+		}
+
+		return c.Next()
+	}
+}
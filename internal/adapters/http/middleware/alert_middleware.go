@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// ServerErrorAlertMiddleware reports 5xx responses to the alerter, which
+// alerts on bursts of them. It runs the handler chain first; errors returned
+// to the error handler count with the status it will respond with.
+func ServerErrorAlertMiddleware(alerter ports.Alerter) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		err := c.Next()
+
+		if status := responseStatus(c, err); status >= fiber.StatusInternalServerError {
+			alerter.RecordServerError(c.Method(), c.Route().Path, status)
+		}
+
+		return err
+	}
+}
+
+// responseStatus returns the status the request is answered with, counting
+// errors returned to the error handler with the status it will respond with
+func responseStatus(c *fiber.Ctx, err error) int {
+	if err == nil {
+		return c.Response().StatusCode()
+	}
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return fiberErr.Code
+	}
+	return fiber.StatusInternalServerError
+}
@@ -1,16 +1,28 @@
 package middleware
 
 import (
+	"errors"
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/internal/core/domain"
 	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/internal/utils"
 	"github.com/thitiphongD/my-backend/pkg/response"
 )
 
-// AuthMiddleware creates authentication middleware
+// AuthMiddleware creates authentication middleware. Requests already
+// authenticated by APIKeyMiddleware are let through when the key's scopes
+// allow their method.
 func AuthMiddleware(authService ports.AuthService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
+		if _, ok := c.Locals("apiKey").(*domain.APIKey); ok {
+			if !apiKeyAllows(c) {
+				return response.Error(c, fiber.StatusForbidden, "API key is missing the scope for this request")
+			}
+			return c.Next()
+		}
+
 		// Get Authorization header
 		authHeader := c.Get("Authorization")
 		if authHeader == "" {
@@ -29,15 +41,69 @@ func AuthMiddleware(authService ports.AuthService) fiber.Handler {
 		}
 
 		// Validate token
-		user, err := authService.ValidateToken(token)
+		user, err := authService.ValidateToken(c.UserContext(), token)
+		if errors.Is(err, domain.ErrAccountSuspended) {
+			return response.Error(c, fiber.StatusForbidden, err.Error())
+		}
 		if err != nil {
 			return response.Error(c, fiber.StatusUnauthorized, "Invalid or expired token")
 		}
 
-		// Store user ID in context
+		// Store user ID in context, and the user as the actor of audited actions
 		c.Locals("userID", user.ID)
 		c.Locals("user", user)
+		c.SetUserContext(utils.WithActor(c.UserContext(), user.ID, user.Role))
+
+		return c.Next()
+	}
+}
 
+// OptionalAuthMiddleware authenticates the request when an Authorization header is
+// sent and lets anonymous requests through, for routes that serve both
+func OptionalAuthMiddleware(authService ports.AuthService) fiber.Handler {
+	required := AuthMiddleware(authService)
+	return func(c *fiber.Ctx) error {
+		if c.Get("Authorization") == "" {
+			return c.Next()
+		}
+		return required(c)
+	}
+}
+
+// QueryTokenMiddleware lets clients that cannot set headers, such as the
+// browser EventSource, pass their token in the access_token query parameter
+func QueryTokenMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if token := c.Query("access_token"); token != "" && c.Get("Authorization") == "" {
+			c.Request().Header.Set("Authorization", "Bearer "+token)
+		}
+		return c.Next()
+	}
+}
+
+// CookieTokenMiddleware lets browser sessions send their token in the
+// HttpOnly auth cookie, which scripts can't read, when mode allows it. The
+// request is then marked a cookie session, which CSRFMiddleware protects. An
+// Authorization header takes precedence in both mode; cookie mode ignores
+// tokens sent in it, though API keys still work.
+func CookieTokenMiddleware(mode string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if mode != utils.AuthModeCookie && mode != utils.AuthModeBoth {
+			return c.Next()
+		}
+
+		authHeader := c.Get("Authorization")
+		if mode == utils.AuthModeCookie && authHeader != "" {
+			if _, ok := apiKeyFromHeader(c); !ok {
+				c.Request().Header.Del("Authorization")
+				authHeader = ""
+			}
+		}
+
+		if token := c.Cookies(utils.AuthCookieName); token != "" && authHeader == "" {
+			c.Request().Header.Set("Authorization", "Bearer "+token)
+			c.Locals("cookieSession", true)
+		}
 		return c.Next()
 	}
 }
@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/pkg/response"
+)
+
+// The server streams request bodies larger than its in-memory buffer (see
+// fiber.Config.StreamRequestBody) instead of rejecting them, so these
+// middlewares enforce the actual limits before handlers read a body.
+
+// BodyLimitMiddleware rejects request bodies over limit bytes with 413.
+// Multipart uploads are limited by UploadLimitMiddleware instead.
+func BodyLimitMiddleware(limit int64) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if isMultipart(c) {
+			return c.Next()
+		}
+		return limitBody(c, limit)
+	}
+}
+
+// UploadLimitMiddleware rejects multipart uploads over limit bytes with 413.
+// Uploads must declare their Content-Length, so the check happens before any
+// of the body is spooled to disk.
+func UploadLimitMiddleware(limit int64) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if !isMultipart(c) {
+			return c.Next()
+		}
+		if c.Request().Header.ContentLength() < 0 {
+			return response.Error(c, fiber.StatusLengthRequired, "Uploads must set Content-Length")
+		}
+		return limitBody(c, limit)
+	}
+}
+
+func isMultipart(c *fiber.Ctx) bool {
+	return len(c.Request().Header.MultipartFormBoundary()) > 0
+}
+
+// limitBody checks the declared Content-Length against limit. Chunked bodies
+// have no declared length, so they are read up to the limit into memory.
+func limitBody(c *fiber.Ctx, limit int64) error {
+	if limit <= 0 {
+		return c.Next()
+	}
+
+	if length := c.Request().Header.ContentLength(); length >= 0 {
+		if int64(length) > limit {
+			return bodyTooLarge(c, limit)
+		}
+		return c.Next()
+	}
+
+	if stream := c.Context().RequestBodyStream(); stream != nil {
+		var body bytes.Buffer
+		if _, err := io.Copy(&body, io.LimitReader(stream, limit+1)); err != nil {
+			return response.Error(c, fiber.StatusBadRequest, "Failed to read request body")
+		}
+		if int64(body.Len()) > limit {
+			return bodyTooLarge(c, limit)
+		}
+		c.Request().SetBody(body.Bytes())
+	}
+
+	return c.Next()
+}
+
+// bodyTooLarge answers 413 and closes the connection, since the rest of the
+// body is left unread
+func bodyTooLarge(c *fiber.Ctx, limit int64) error {
+	c.Set(fiber.HeaderConnection, "close")
+	return response.Error(c, fiber.StatusRequestEntityTooLarge, "Request body must be at most "+formatBytes(limit))
+}
+
+// formatBytes renders a byte count in the largest whole unit
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<20 && n%(1<<20) == 0:
+		return fmt.Sprintf("%d MB", n>>20)
+	case n >= 1<<10 && n%(1<<10) == 0:
+		return fmt.Sprintf("%d KB", n>>10)
+	default:
+		return fmt.Sprintf("%d bytes", n)
+	}
+}
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+)
+
+// Compression levels accepted by CompressionMiddleware
+const (
+	CompressionDisabled        = "disabled"
+	CompressionBestSpeed       = "best_speed"
+	CompressionDefault         = "default"
+	CompressionBestCompression = "best_compression"
+)
+
+// CompressionMiddleware compresses JSON responses of at least minSize bytes
+// with brotli or gzip, whichever the client accepts. Streamed responses, such
+// as server-sent events and exports, and non-JSON bodies are left untouched.
+func CompressionMiddleware(level string, minSize int) fiber.Handler {
+	var brotliLevel, gzipLevel int
+	switch level {
+	case CompressionDisabled:
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	case CompressionBestSpeed:
+		brotliLevel, gzipLevel = fasthttp.CompressBrotliBestSpeed, fasthttp.CompressBestSpeed
+	case CompressionBestCompression:
+		brotliLevel, gzipLevel = fasthttp.CompressBrotliBestCompression, fasthttp.CompressBestCompression
+	default:
+		brotliLevel, gzipLevel = fasthttp.CompressBrotliDefaultCompression, fasthttp.CompressDefaultCompression
+	}
+	compress := fasthttp.CompressHandlerBrotliLevel(func(*fasthttp.RequestCtx) {}, brotliLevel, gzipLevel)
+
+	return func(c *fiber.Ctx) error {
+		if err := c.Next(); err != nil {
+			return err
+		}
+
+		resp := c.Response()
+		if resp.IsBodyStream() || len(resp.Body()) < minSize {
+			return nil
+		}
+		if !strings.HasPrefix(string(resp.Header.ContentType()), fiber.MIMEApplicationJSON) {
+			return nil
+		}
+
+		compress(c.Context())
+		return nil
+	}
+}
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"crypto/subtle"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/internal/utils"
+	"github.com/thitiphongD/my-backend/pkg/response"
+)
+
+// CSRFMiddleware protects cookie sessions from cross-site request forgery
+// with double-submit tokens: state-changing requests authenticated by the
+// auth cookie (see CookieTokenMiddleware, which must run first) must send the
+// token of the CSRF cookie in the X-CSRF-Token header, which other sites can
+// neither read nor set. Tokens are bound to the session of the auth cookie,
+// so a token planted by another site, or issued to another session, is
+// refused. Requests sending an Authorization header themselves, such as
+// those of API clients and API keys, are exempt, since browsers never add one
+// on their own.
+func CSRFMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		switch c.Method() {
+		case fiber.MethodGet, fiber.MethodHead, fiber.MethodOptions, fiber.MethodTrace:
+			return c.Next()
+		}
+		if cookieSession, _ := c.Locals("cookieSession").(bool); !cookieSession {
+			return c.Next()
+		}
+
+		claims, err := utils.ValidateJWT(c.Cookies(utils.AuthCookieName))
+		if err != nil {
+			// The cookie doesn't sign the request in, so there is nothing to
+			// forge; protected routes reject the request
+			return c.Next()
+		}
+
+		cookie := c.Cookies(utils.CSRFCookieName)
+		header := c.Get(utils.CSRFHeaderName)
+		if header == "" || subtle.ConstantTimeCompare([]byte(header), []byte(cookie)) != 1 || !utils.ValidCSRFToken(cookie, claims.Session()) {
+			return response.Error(c, fiber.StatusForbidden, "Missing or invalid CSRF token")
+		}
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,113 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/internal/utils"
+	"github.com/thitiphongD/my-backend/pkg/response"
+	"github.com/valyala/fasthttp"
+)
+
+// reportedHeaders are the request headers sent with error reports; the
+// others may carry credentials
+var reportedHeaders = []string{"Accept", "Accept-Language", "Content-Type", "Content-Length", "Origin", "Referer", "User-Agent", "X-Request-ID"}
+
+// filteredQueryParams are query parameters carrying credentials, whose values
+// are left out of error reports
+var filteredQueryParams = []string{"access_token", "token", "signature"}
+
+// ErrorReportingMiddleware reports panics and 5xx responses to the error
+// tracker with the request, the user and the breadcrumbs logged while the
+// request ran. A panic is reported with its stack and raised again, for the
+// recover middleware outside to answer it.
+func ErrorReportingMiddleware(reporter ports.ErrorReporter) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := utils.WithBreadcrumbs(c.UserContext())
+		c.SetUserContext(ctx)
+
+		defer func() {
+			if r := recover(); r != nil {
+				pcs := make([]uintptr, 64)
+				n := runtime.Callers(2, pcs)
+				reporter.Capture(ctx, newErrorReport(c, fmt.Errorf("%v", r), true, pcs[:n]))
+				panic(r)
+			}
+		}()
+
+		err := c.Next()
+
+		status := c.Response().StatusCode()
+		reported := err
+		if err != nil {
+			status = fiber.StatusInternalServerError
+			var fiberErr *fiber.Error
+			if errors.As(err, &fiberErr) {
+				status = fiberErr.Code
+			}
+		} else if serverErr := c.Locals(response.ServerErrorKey); serverErr != nil {
+			reported = fmt.Errorf("%v", serverErr)
+		}
+		if status >= fiber.StatusInternalServerError {
+			if reported == nil {
+				reported = errors.New(http.StatusText(status))
+			}
+			reporter.Capture(ctx, newErrorReport(c, reported, false, nil))
+		}
+
+		return err
+	}
+}
+
+// newErrorReport describes an error raised in the request
+func newErrorReport(c *fiber.Ctx, err error, panicked bool, stack []uintptr) *domain.ErrorReport {
+	headers := make(map[string]string)
+	for _, name := range reportedHeaders {
+		if value := c.Get(name); value != "" {
+			headers[name] = value
+		}
+	}
+
+	report := &domain.ErrorReport{
+		Err:   err,
+		Level: domain.ErrorLevelError,
+		Panic: panicked,
+		Stack: stack,
+		Request: &domain.ErrorReportRequest{
+			Method:      c.Method(),
+			URL:         c.BaseURL() + c.Path(),
+			QueryString: filteredQueryString(c),
+			Headers:     headers,
+			ClientIP:    c.IP(),
+		},
+		Tags: map[string]string{"route": c.Method() + " " + c.Route().Path},
+		Time: time.Now(),
+	}
+	if panicked {
+		report.Level = domain.ErrorLevelFatal
+	}
+	if userID, ok := c.Locals("userID").(uint); ok {
+		report.UserID = &userID
+	}
+	return report
+}
+
+// filteredQueryString returns the query string of the request with the values
+// of credential parameters replaced
+func filteredQueryString(c *fiber.Ctx) string {
+	args := fasthttp.AcquireArgs()
+	defer fasthttp.ReleaseArgs(args)
+	c.Request().URI().QueryArgs().CopyTo(args)
+	for _, name := range filteredQueryParams {
+		if args.Has(name) {
+			args.Set(name, "[Filtered]")
+		}
+	}
+	return args.String()
+}
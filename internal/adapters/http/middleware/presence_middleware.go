@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/internal/utils"
+)
+
+// PresenceMiddleware records activity for authenticated requests.
+// It runs the handler chain first so the user set by AuthMiddleware is available.
+func PresenceMiddleware(presenceService ports.PresenceService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		err := c.Next()
+
+		if userID, ok := c.Locals("userID").(uint); ok {
+			if touchErr := presenceService.Touch(c.UserContext(), userID); touchErr != nil {
+				utils.Logf(c.UserContext(), "Failed to record presence for user %d: %v", userID, touchErr)
+			}
+		}
+
+		return err
+	}
+}
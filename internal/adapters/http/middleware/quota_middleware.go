@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/internal/utils"
+	"github.com/thitiphongD/my-backend/pkg/response"
+)
+
+// QuotaMiddleware enforces per-user request quotas for requests carrying a valid token
+// or API key. Anonymous requests pass through; AuthMiddleware still decides access per route.
+func QuotaMiddleware(quotaService ports.QuotaService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, ok := quotaUserID(c)
+		if !ok {
+			return c.Next()
+		}
+
+		status, err := quotaService.Consume(c.UserContext(), userID)
+		if err != nil {
+			// Fail open: quota tracking problems must not take the API down
+			utils.Logf(c.UserContext(), "Failed to consume quota for user %d: %v", userID, err)
+			return c.Next()
+		}
+
+		if remaining := status.Remaining(); remaining >= 0 {
+			c.Set("X-Quota-Remaining", strconv.FormatInt(remaining, 10))
+		}
+
+		if status.Exceeded() {
+			return response.Error(c, fiber.StatusTooManyRequests, "Request quota exceeded")
+		}
+
+		return c.Next()
+	}
+}
+
+// quotaUserID returns the user the request counts against: the owner of the
+// API key it was made with, or the user its token was issued to
+func quotaUserID(c *fiber.Ctx) (uint, bool) {
+	if _, ok := c.Locals("apiKey").(*domain.APIKey); ok {
+		return c.Locals("userID").(uint), true
+	}
+
+	authHeader := c.Get("Authorization")
+	if !strings.HasPrefix(authHeader, "Bearer ") {
+		return 0, false
+	}
+	claims, err := utils.ValidateJWT(strings.TrimPrefix(authHeader, "Bearer "))
+	if err != nil {
+		return 0, false
+	}
+	return claims.UserID, true
+}
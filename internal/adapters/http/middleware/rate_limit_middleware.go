@@ -0,0 +1,105 @@
+package middleware
+
+import (
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/internal/utils"
+	"github.com/thitiphongD/my-backend/pkg/response"
+)
+
+// RateLimit is the number of requests allowed per window; zero disables the limit
+type RateLimit struct {
+	Requests int64
+	Window   time.Duration
+}
+
+// RateLimitConfig configures one rate limiting bucket. Requests with a valid
+// token are counted per user, anonymous requests per client IP.
+type RateLimitConfig struct {
+	Bucket        string
+	Anonymous     RateLimit
+	Authenticated RateLimit
+}
+
+// RateLimitMiddleware rejects requests over the bucket's limit with 429 and a
+// Retry-After header, and reports the remaining allowance on every response.
+// Requests made with an API key are left to the key's own limit.
+func RateLimitMiddleware(store ports.RateLimitStore, cfg RateLimitConfig) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if _, ok := c.Locals("apiKey").(*domain.APIKey); ok {
+			return c.Next()
+		}
+
+		subject, signedIn := requestSubject(c)
+		limit := cfg.Anonymous
+		if signedIn {
+			limit = cfg.Authenticated
+		}
+		return limitRequest(c, store, cfg.Bucket, "ratelimit:"+cfg.Bucket+":"+subject, limit)
+	}
+}
+
+// requestSubject returns who a request counts against, "user:<id>" when it
+// carries a valid token or API key and "ip:<client IP>" otherwise, and whether
+// it is a user
+func requestSubject(c *fiber.Ctx) (string, bool) {
+	if userID, ok := c.Locals("userID").(uint); ok {
+		return "user:" + strconv.FormatUint(uint64(userID), 10), true
+	}
+	if authHeader := c.Get("Authorization"); strings.HasPrefix(authHeader, "Bearer ") {
+		if claims, err := utils.ValidateJWT(strings.TrimPrefix(authHeader, "Bearer ")); err == nil {
+			return "user:" + strconv.FormatUint(uint64(claims.UserID), 10), true
+		}
+	}
+	return "ip:" + c.IP(), false
+}
+
+// limitRequest counts the request against key, rejecting it with 429 once
+// over the limit, and reports the remaining allowance in the response
+func limitRequest(c *fiber.Ctx, store ports.RateLimitStore, bucket string, key string, limit RateLimit) error {
+	if !allowRequest(c, store, bucket, key, limit) {
+		return response.Error(c, fiber.StatusTooManyRequests, "Too many requests, please try again later")
+	}
+	return c.Next()
+}
+
+// allowRequest counts the request against key and reports whether it is
+// within the limit, setting the rate limit headers, and Retry-After once over
+func allowRequest(c *fiber.Ctx, store ports.RateLimitStore, bucket string, key string, limit RateLimit) bool {
+	if limit.Requests <= 0 {
+		return true
+	}
+
+	count, resetAt, err := store.Increment(key, limit.Window)
+	if err != nil {
+		// Fail open: rate limiting problems must not take the API down
+		log.Printf("Failed to apply %s rate limit: %v", bucket, err)
+		return true
+	}
+
+	remaining := limit.Requests - count
+	if remaining < 0 {
+		remaining = 0
+	}
+	c.Set("X-RateLimit-Limit", strconv.FormatInt(limit.Requests, 10))
+	c.Set("X-RateLimit-Remaining", strconv.FormatInt(remaining, 10))
+	c.Set("X-RateLimit-Reset", strconv.FormatInt(resetAt.Unix(), 10))
+
+	if count > limit.Requests {
+		retryAfter := int64(math.Ceil(time.Until(resetAt).Seconds()))
+		if retryAfter < 1 {
+			retryAfter = 1
+		}
+		c.Set(fiber.HeaderRetryAfter, strconv.FormatInt(retryAfter, 10))
+		return false
+	}
+
+	return true
+}
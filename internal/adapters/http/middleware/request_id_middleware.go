@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/internal/utils"
+)
+
+// maxRequestIDLength bounds incoming request IDs so clients cannot bloat logs
+const maxRequestIDLength = 128
+
+// RequestIDMiddleware assigns every request an ID, reusing a well-formed
+// X-Request-ID sent by the client or a proxy, and exposes it in the response
+// header, in c.Locals("requestID") and in the request's user context. The
+// user context also carries the client IP, for the audit log.
+func RequestIDMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		requestID := c.Get(utils.RequestIDHeader)
+		if !isValidRequestID(requestID) {
+			generated, err := utils.GenerateRandomToken(16)
+			if err != nil {
+				log.Printf("Failed to generate request ID: %v", err)
+			}
+			requestID = generated
+		}
+
+		c.Locals("requestID", requestID)
+		c.Set(utils.RequestIDHeader, requestID)
+		ctx := utils.WithRequestID(c.UserContext(), requestID)
+		c.SetUserContext(utils.WithClientIP(ctx, c.IP()))
+
+		return c.Next()
+	}
+}
+
+// isValidRequestID accepts IDs made of letters, digits, dashes, underscores and dots
+func isValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,16 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// RequestStatsMiddleware counts every response by its status, for the error
+// rates of the admin dashboard
+func RequestStatsMiddleware(stats ports.RequestStats) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		err := c.Next()
+		stats.Record(responseStatus(c, err))
+		return err
+	}
+}
@@ -0,0 +1,134 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// cachedResponseHeaders are the response headers stored along with a cached body
+var cachedResponseHeaders = []string{
+	fiber.HeaderContentType,
+	fiber.HeaderETag,
+	fiber.HeaderLastModified,
+	fiber.HeaderVary,
+}
+
+// ResponseCacheConfig configures caching for a group of GET routes
+type ResponseCacheConfig struct {
+	// TTL is how long responses are cached; zero disables caching
+	TTL time.Duration
+	// Tags returns the invalidation tags of the request's response
+	Tags func(c *fiber.Ctx) []string
+	// OnHit runs for requests served from the cache, for side effects of the
+	// handler that must not be skipped (such as counting views)
+	OnHit func(c *fiber.Ctx)
+}
+
+// cachedResponse is a successful response as stored in the cache
+type cachedResponse struct {
+	Headers map[string]string `json:"headers"`
+	Body    []byte            `json:"body"`
+}
+
+// ResponseCacheMiddleware serves repeated GET requests from the cache. Entries
+// are keyed by the normalized URL, the authenticated user and the requested
+// language, so it must run after OptionalAuthMiddleware. Only 200 responses are
+// cached; an X-Cache header reports HIT or MISS. Requests with Cache-Control:
+// no-cache skip the lookup and refresh the entry.
+func ResponseCacheMiddleware(cache ports.ResponseCache, cfg ResponseCacheConfig) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if cfg.TTL <= 0 || c.Method() != fiber.MethodGet {
+			return c.Next()
+		}
+
+		var tags []string
+		if cfg.Tags != nil {
+			tags = cfg.Tags(c)
+		}
+		generations, err := cache.Generations(tags...)
+		if err != nil {
+			// Fail open: caching problems must not take the API down
+			log.Printf("Failed to read response cache: %v", err)
+			return c.Next()
+		}
+		key := responseCacheKey(c, generations)
+
+		if !strings.Contains(c.Get(fiber.HeaderCacheControl), "no-cache") {
+			raw, ok, err := cache.Get(key)
+			if err != nil {
+				log.Printf("Failed to read response cache: %v", err)
+			}
+			var cached cachedResponse
+			if ok && json.Unmarshal(raw, &cached) == nil {
+				for name, value := range cached.Headers {
+					c.Set(name, value)
+				}
+				c.Set("X-Cache", "HIT")
+				if cfg.OnHit != nil {
+					cfg.OnHit(c)
+				}
+
+				if etag := cached.Headers[fiber.HeaderETag]; etag != "" && domain.ETagMatches(c.Get(fiber.HeaderIfNoneMatch), etag) {
+					return c.SendStatus(fiber.StatusNotModified)
+				}
+				return c.Send(cached.Body)
+			}
+		}
+
+		c.Set("X-Cache", "MISS")
+		if err := c.Next(); err != nil {
+			return err
+		}
+		if c.Response().StatusCode() != fiber.StatusOK || c.Response().IsBodyStream() {
+			return nil
+		}
+
+		cached := cachedResponse{
+			Headers: make(map[string]string, len(cachedResponseHeaders)),
+			Body:    c.Response().Body(),
+		}
+		for _, name := range cachedResponseHeaders {
+			if value := c.GetRespHeader(name); value != "" {
+				cached.Headers[name] = value
+			}
+		}
+		raw, err := json.Marshal(cached)
+		if err != nil {
+			return nil
+		}
+		if err := cache.Set(key, raw, cfg.TTL); err != nil {
+			log.Printf("Failed to write response cache: %v", err)
+		}
+
+		return nil
+	}
+}
+
+// responseCacheKey hashes everything a cached response depends on: the path
+// with its query parameters in canonical order, the authenticated user, the
+// requested language and the generations of the response's tags
+func responseCacheKey(c *fiber.Ctx, generations []int64) string {
+	query, _ := url.ParseQuery(string(c.Request().URI().QueryString()))
+
+	user := "anonymous"
+	if u, ok := c.Locals("user").(*domain.User); ok {
+		user = "user:" + strconv.FormatUint(uint64(u.ID), 10)
+	}
+
+	h := sha256.New()
+	h.Write([]byte(c.Path() + "?" + query.Encode() + "\n" + user + "\n" + c.Get(fiber.HeaderAcceptLanguage) + "\n"))
+	for _, generation := range generations {
+		h.Write([]byte(strconv.FormatInt(generation, 10) + ","))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
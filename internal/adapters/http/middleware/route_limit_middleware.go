@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"log"
+	"net"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/internal/utils"
+	"github.com/thitiphongD/my-backend/pkg/response"
+)
+
+// concurrencySlotTTL bounds how long a request may hold a concurrency slot,
+// so the slots of requests that never released theirs (on a crashed instance,
+// or whose client left during a streamed body) are freed again
+const concurrencySlotTTL = 10 * time.Minute
+
+// RouteLimitMiddleware enforces the route limits matching each request, in
+// order, rejecting requests over any of them with 429. Rate limits count
+// requests like RateLimitMiddleware does; concurrency limits hold a slot until
+// the response has been sent. Counters live in store, so a shared store
+// enforces the limits across instances. API key requests count against the
+// key's user.
+func RouteLimitMiddleware(store ports.RateLimitStore, limits []*domain.RouteLimit) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var matching []*domain.RouteLimit
+		for _, limit := range limits {
+			if limit.Matches(c.Method(), c.Path()) {
+				matching = append(matching, limit)
+			}
+		}
+		if len(matching) == 0 {
+			return c.Next()
+		}
+		return applyRouteLimits(c, store, matching)
+	}
+}
+
+// applyRouteLimits enforces the first limit and then, recursively, the rest,
+// so the concurrency slots taken are released however the request ends
+func applyRouteLimits(c *fiber.Ctx, store ports.RateLimitStore, limits []*domain.RouteLimit) error {
+	if len(limits) == 0 {
+		return c.Next()
+	}
+	limit, rest := limits[0], limits[1:]
+
+	subject, _ := requestSubject(c)
+	if limit.PerIP {
+		subject = "ip:" + c.IP()
+	}
+	bucket := "route " + limit.Route()
+
+	if limit.Window > 0 {
+		rate := RateLimit{Requests: limit.Requests, Window: limit.Window}
+		if !allowRequest(c, store, bucket, "ratelimit:route:"+limit.Route()+":"+subject, rate) {
+			return response.Error(c, fiber.StatusTooManyRequests, "Too many requests, please try again later")
+		}
+		return applyRouteLimits(c, store, rest)
+	}
+
+	key := "concurrency:route:" + limit.Route() + ":" + subject
+	holder, err := utils.GenerateRandomToken(8)
+	if err != nil {
+		log.Printf("Failed to apply %s concurrency limit: %v", bucket, err)
+		return applyRouteLimits(c, store, rest)
+	}
+	acquired, err := store.Acquire(key, holder, limit.Concurrent, concurrencySlotTTL)
+	if err != nil {
+		// Fail open: rate limiting problems must not take the API down
+		log.Printf("Failed to apply %s concurrency limit: %v", bucket, err)
+		return applyRouteLimits(c, store, rest)
+	}
+	if !acquired {
+		return response.Error(c, fiber.StatusTooManyRequests, "Too many concurrent requests, please wait for the others to finish")
+	}
+
+	release := func() {
+		if err := store.Release(key, holder); err != nil {
+			log.Printf("Failed to release %s concurrency slot: %v", bucket, err)
+		}
+	}
+
+	// Deferred, so the slot is released even when a later handler panics
+	hijacked := false
+	defer func() {
+		if !hijacked {
+			release()
+		}
+	}()
+
+	err = applyRouteLimits(c, store, rest)
+	if c.Response().IsBodyStream() {
+		// A streamed body is written after the handler returns, and replacing
+		// the stream would close it, so the slot is released from a hijack
+		// handler, which runs once the response has been sent and then closes
+		// the connection
+		c.Context().Hijack(func(net.Conn) { release() })
+		hijacked = true
+	}
+	return err
+}
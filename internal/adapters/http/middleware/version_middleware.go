@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// APIVersionMiddleware records the API version of a route group in
+// c.Locals("apiVersion") so shared handlers can map responses per version
+func APIVersionMiddleware(version int) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Locals("apiVersion", version)
+		return c.Next()
+	}
+}
+
+// DeprecatedMiddleware marks an endpoint as deprecated since deprecatedAt
+// (Deprecation, RFC 9745) and to be removed at sunset (Sunset, RFC 8594), and
+// links to the same path in the successor API version. It must run after
+// APIVersionMiddleware.
+func DeprecatedMiddleware(deprecatedAt, sunset time.Time, successorVersion int) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Set("Deprecation", "@"+strconv.FormatInt(deprecatedAt.Unix(), 10))
+		c.Set("Sunset", sunset.UTC().Format(http.TimeFormat))
+
+		if version, ok := c.Locals("apiVersion").(int); ok {
+			current := "/api/v" + strconv.Itoa(version) + "/"
+			successor := "/api/v" + strconv.Itoa(successorVersion) + "/"
+			if url := c.OriginalURL(); strings.HasPrefix(url, current) {
+				c.Append(fiber.HeaderLink, "<"+successor+strings.TrimPrefix(url, current)+`>; rel="successor-version"`)
+			}
+		}
+
+		return c.Next()
+	}
+}
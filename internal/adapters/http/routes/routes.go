@@ -1,19 +1,128 @@
 package routes
 
 import (
+	"strconv"
+	"time"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/thitiphongD/my-backend/internal/adapters/http/handlers"
 	"github.com/thitiphongD/my-backend/internal/adapters/http/middleware"
+	"github.com/thitiphongD/my-backend/internal/core/domain"
 	"github.com/thitiphongD/my-backend/internal/core/ports"
 	"github.com/thitiphongD/my-backend/pkg/response"
 )
 
-// SetupRoutes configures all application routes
-func SetupRoutes(app *fiber.App, authService ports.AuthService, userService ports.UserService, mangaService ports.MangaService) {
+// v1 manga read endpoints are deprecated in favour of v2 and will be removed at the sunset date
+var (
+	v1MangaDeprecatedAt = time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC)
+	v1MangaSunset       = time.Date(2027, time.April, 30, 0, 0, 0, 0, time.UTC)
+)
+
+// Services groups the core services the HTTP layer depends on
+type Services struct {
+	Auth        ports.AuthService
+	User        ports.UserService
+	Manga       ports.MangaService
+	Presence    ports.PresenceService
+	Team        ports.TeamService
+	Quota       ports.QuotaService
+	Webhook     ports.WebhookService
+	APIKey      ports.APIKeyService
+	Dashboard   ports.DashboardService
+	Genre       ports.GenreService
+	Chapter     ports.ChapterService
+	Review      ports.ReviewService
+	Progress    ports.ReadingProgressService
+	Discount    ports.DiscountService
+	View        ports.ViewService
+	Comment     ports.CommentService
+	Book        ports.BookService
+	Relation    ports.MangaRelationService
+	Series      ports.SeriesService
+	Wishlist    ports.WishlistService
+	Cart        ports.CartService
+	Order       ports.OrderService
+	Rental      ports.RentalService
+	Tax         ports.TaxRateService
+	Image       ports.MangaImageService
+	Translation ports.MangaTranslationService
+	Events      ports.EventStream
+	Database    ports.DatabaseStats
+	Purge       ports.PurgeService
+	Archive     ports.ArchiveService
+	Backup      ports.BackupService
+	Search      ports.MangaSearchService
+	Jobs        ports.JobService
+	Audit       ports.AuditService
+	Exports     ports.FileStorage // keeps the files written by export jobs
+
+	Notification ports.NotificationService
+	Digest       ports.DigestService
+	SMS          ports.SMSService
+	Line         ports.LineService
+	Upload       ports.UploadService
+
+	// EmailPreview renders the email previews at /dev/emails; nil (outside
+	// development) leaves them out
+	EmailPreview ports.EmailRenderer
+}
+
+// SetupRoutes configures all application routes. Public manga reads are cached
+// in responseCache for responseCacheTTL (0 disables caching). Cookies the API
+// sets follow cookies. routeLimits are listed with the routes they throttle.
+func SetupRoutes(app *fiber.App, svc *Services, responseCache ports.ResponseCache, responseCacheTTL time.Duration, cookies handlers.CookieConfig, routeLimits []*domain.RouteLimit) {
+	authService := svc.Auth
+
 	// Initialize handlers
-	authHandler := handlers.NewAuthHandler(authService)
-	userHandler := handlers.NewUserHandler(userService)
-	mangaHandler := handlers.NewMangaHandler(mangaService)
+	authHandler := handlers.NewAuthHandler(svc.Auth, cookies)
+	userHandler := handlers.NewUserHandler(svc.User, svc.Presence)
+	mangaHandler := handlers.NewMangaHandler(svc.Manga, svc.View)
+	routeHandler := handlers.NewRouteHandler(app, routeLimits)
+	databaseHandler := handlers.NewDatabaseHandler(svc.Database)
+	purgeHandler := handlers.NewPurgeHandler(svc.Purge)
+	archiveHandler := handlers.NewArchiveHandler(svc.Archive)
+	backupHandler := handlers.NewBackupHandler(svc.Backup)
+	searchHandler := handlers.NewSearchHandler(svc.Search)
+	jobHandler := handlers.NewJobHandler(svc.Jobs, svc.Exports)
+	auditHandler := handlers.NewAuditHandler(svc.Audit)
+	notificationHandler := handlers.NewNotificationHandler(svc.Notification)
+	digestHandler := handlers.NewDigestHandler(svc.Digest)
+	phoneHandler := handlers.NewPhoneHandler(svc.SMS)
+	lineHandler := handlers.NewLineHandler(svc.Line)
+	uploadHandler := handlers.NewUploadHandler(svc.Upload)
+	adminHandler := handlers.NewAdminHandler(svc.User, svc.Quota)
+	teamHandler := handlers.NewTeamHandler(svc.Team, svc.Manga)
+	webhookHandler := handlers.NewWebhookHandler(svc.Webhook)
+	apiKeyHandler := handlers.NewAPIKeyHandler(svc.APIKey)
+	dashboardHandler := handlers.NewDashboardHandler(svc.Dashboard)
+	genreHandler := handlers.NewGenreHandler(svc.Genre)
+	chapterHandler := handlers.NewChapterHandler(svc.Chapter)
+	reviewHandler := handlers.NewReviewHandler(svc.Review)
+	progressHandler := handlers.NewReadingProgressHandler(svc.Progress)
+	discountHandler := handlers.NewDiscountHandler(svc.Discount)
+	commentHandler := handlers.NewCommentHandler(svc.Comment)
+	bookHandler := handlers.NewBookHandler(svc.Book)
+	relationHandler := handlers.NewMangaRelationHandler(svc.Relation)
+	seriesHandler := handlers.NewSeriesHandler(svc.Series)
+	wishlistHandler := handlers.NewWishlistHandler(svc.Wishlist)
+	cartHandler := handlers.NewCartHandler(svc.Cart)
+	orderHandler := handlers.NewOrderHandler(svc.Order)
+	rentalHandler := handlers.NewRentalHandler(svc.Rental)
+	taxHandler := handlers.NewTaxRateHandler(svc.Tax)
+	imageHandler := handlers.NewMangaImageHandler(svc.Image)
+	translationHandler := handlers.NewMangaTranslationHandler(svc.Translation)
+	eventStreamHandler := handlers.NewEventStreamHandler(svc.Events)
+
+	// Public manga reads are cached; the manga service invalidates them on changes
+	mangaListCache := middleware.ResponseCacheMiddleware(responseCache, middleware.ResponseCacheConfig{
+		TTL:  responseCacheTTL,
+		Tags: mangaListCacheTags,
+	})
+	mangaCache := middleware.ResponseCacheMiddleware(responseCache, middleware.ResponseCacheConfig{
+		TTL:   responseCacheTTL,
+		Tags:  mangaCacheTags,
+		OnHit: mangaHandler.RecordCachedView,
+	})
 
 	// Health check route
 	app.Get("/", func(c *fiber.Ctx) error {
@@ -23,6 +132,9 @@ func SetupRoutes(app *fiber.App, authService ports.AuthService, userService port
 		})
 	})
 
+	// Database health; the detailed report is admin only
+	app.Get("/health", databaseHandler.GetHealth)
+
 	// Basic routes (demo purposes)
 	app.Get("/say-hi/:name", func(c *fiber.Ctx) error {
 		name := c.Params("name")
@@ -52,39 +164,299 @@ func SetupRoutes(app *fiber.App, authService ports.AuthService, userService port
 		})
 	})
 
+	// Email template previews, development only
+	if svc.EmailPreview != nil {
+		emailPreviewHandler := handlers.NewEmailPreviewHandler(svc.EmailPreview)
+		dev := app.Group("/dev")
+		dev.Get("/emails", emailPreviewHandler.ListTemplates)         // Dev: List email templates and their locales
+		dev.Get("/emails/:name", emailPreviewHandler.PreviewTemplate) // Dev: Render an email with sample data (?locale=th&format=text)
+	}
+
+	// Admin routes
+	admin := app.Group("/admin")
+	admin.Get("/routes", middleware.AuthMiddleware(authService), middleware.AdminMiddleware(), routeHandler.ListRoutes)               // Admin: List registered routes
+	admin.Get("/db/pool", middleware.AuthMiddleware(authService), middleware.AdminMiddleware(), databaseHandler.GetPoolStats)         // Admin: Database connection pool stats
+	admin.Get("/health", middleware.AuthMiddleware(authService), middleware.AdminMiddleware(), databaseHandler.GetDetailedHealth)     // Admin: Database health with pools, replication lag and schema version
+	admin.Post("/purge", middleware.AuthMiddleware(authService), middleware.AdminMiddleware(), purgeHandler.RunPurge)                 // Admin: Purge soft-deleted records now
+	admin.Get("/purge/stats", middleware.AuthMiddleware(authService), middleware.AdminMiddleware(), purgeHandler.GetPurgeStats)       // Admin: Purge counters
+	admin.Post("/archive", middleware.AuthMiddleware(authService), middleware.AdminMiddleware(), archiveHandler.RunArchive)           // Admin: Archive old orders and view counts now
+	admin.Get("/archive/stats", middleware.AuthMiddleware(authService), middleware.AdminMiddleware(), archiveHandler.GetArchiveStats) // Admin: Archival counters
+	admin.Post("/search/reindex", middleware.AuthMiddleware(authService), middleware.AdminMiddleware(), searchHandler.Reindex)        // Admin: Send every manga to the search index again
+
+	// Background jobs
+	admin.Get("/jobs", middleware.AuthMiddleware(authService), middleware.AdminMiddleware(), jobHandler.ListJobs)                              // Admin: List jobs (?status=dead for the dead-letter queue, or several like pending,running; &kind=)
+	admin.Get("/jobs/retry-policies", middleware.AuthMiddleware(authService), middleware.AdminMiddleware(), jobHandler.GetRetryPolicies)       // Admin: Retry policy of each job kind
+	admin.Post("/jobs/dead/retry", middleware.AuthMiddleware(authService), middleware.AdminMiddleware(), jobHandler.RetryDeadJobs)             // Admin: Run all dead jobs (?kind= of one kind) again
+	admin.Delete("/jobs/dead", middleware.AuthMiddleware(authService), middleware.AdminMiddleware(), jobHandler.DiscardDeadJobs)               // Admin: Discard all dead jobs (?kind= of one kind)
+	admin.Get("/jobs/scheduled", middleware.AuthMiddleware(authService), middleware.AdminMiddleware(), jobHandler.ListScheduledTasks)          // Admin: Scheduled tasks that can be run at once
+	admin.Post("/jobs/scheduled/:task/run", middleware.AuthMiddleware(authService), middleware.AdminMiddleware(), jobHandler.RunScheduledTask) // Admin: Run a scheduled task now, as a job
+	admin.Get("/jobs/:id", middleware.AuthMiddleware(authService), middleware.AdminMiddleware(), jobHandler.GetJob)                            // Admin: Job status, payload and error log
+	admin.Post("/jobs/:id/retry", middleware.AuthMiddleware(authService), middleware.AdminMiddleware(), jobHandler.RetryJob)                   // Admin: Run a dead job again
+	admin.Post("/jobs/:id/cancel", middleware.AuthMiddleware(authService), middleware.AdminMiddleware(), jobHandler.CancelJob)                 // Admin: Cancel a pending or running job
+	admin.Delete("/jobs/:id", middleware.AuthMiddleware(authService), middleware.AdminMiddleware(), jobHandler.DiscardJob)                     // Admin: Discard a dead job
+
+	// Backups expose all data and restores overwrite it, so they are for super admins only
+	admin.Post("/backups", middleware.AuthMiddleware(authService), middleware.SuperAdminMiddleware(), backupHandler.CreateBackup)              // Super admin: Start a database backup
+	admin.Get("/backups", middleware.AuthMiddleware(authService), middleware.SuperAdminMiddleware(), backupHandler.ListBackupJobs)             // Super admin: List backup and restore jobs
+	admin.Get("/backups/:id", middleware.AuthMiddleware(authService), middleware.SuperAdminMiddleware(), backupHandler.GetBackupJob)           // Super admin: Backup or restore job status
+	admin.Post("/backups/:id/restore", middleware.AuthMiddleware(authService), middleware.SuperAdminMiddleware(), backupHandler.RestoreBackup) // Super admin: Restore the database from a backup
+
 	// API v1 routes
-	v1 := app.Group("/api/v1")
+	v1 := app.Group("/api/v1", middleware.APIVersionMiddleware(1)).Name("v1.")
+
+	// v1 manga reads are superseded by v2, which changes the manga representation
+	v1MangaDeprecation := middleware.DeprecatedMiddleware(v1MangaDeprecatedAt, v1MangaSunset, 2)
 
 	// Auth routes (public)
 	auth := v1.Group("/auth")
 	auth.Post("/register", authHandler.Register)
 	auth.Post("/login", authHandler.Login)
+	auth.Post("/logout", authHandler.Logout)
+	auth.Get("/csrf", authHandler.GetCSRFToken)
 	auth.Get("/me", middleware.AuthMiddleware(authService), authHandler.GetMe)
 
+	// Unsubscribe links in emails (public, the token identifies the user), and
+	// the notification routes users pick from
+	notifications := v1.Group("/notifications")
+	notifications.Get("/unsubscribe", notificationHandler.Unsubscribe)  // Public: Unsubscribe from a category of email
+	notifications.Post("/unsubscribe", notificationHandler.Unsubscribe) // Public: One-click unsubscribe from mail clients
+	notifications.Get("/routes", notificationHandler.GetRoutes)         // Public: Channels each notification type can be routed to, and the defaults
+
+	// Webhook of our LINE official account (public, LINE signs the requests)
+	v1.Post("/line/webhook", lineHandler.Webhook) // Public: LINE events
+
 	// User routes
 	users := v1.Group("/users")
-	users.Get("/", userHandler.GetUsers)                                                 // Public: Get all users
-	users.Get("/:id", userHandler.GetUserByID)                                           // Public: Get user by ID
-	users.Post("/", middleware.AuthMiddleware(authService), userHandler.CreateUser)      // Protected: Create user
-	users.Put("/:id", middleware.AuthMiddleware(authService), userHandler.UpdateUser)    // Protected: Update user
-	users.Delete("/:id", middleware.AuthMiddleware(authService), userHandler.DeleteUser) // Protected: Delete user
+	users.Get("/", userHandler.GetUsers)                                                                                                      // Public: Get all users
+	users.Get("/online", userHandler.GetOnlineCount)                                                                                          // Public: Count online users
+	users.Get("/search", userHandler.SearchUsers)                                                                                             // Public: Typeahead user search
+	users.Patch("/me", middleware.AuthMiddleware(authService), userHandler.PatchMe)                                                           // Protected: Partially update my account; a new email is only pending until confirmed
+	users.Get("/email/confirm", userHandler.ConfirmEmailChange)                                                                               // Public: Confirm a new email address from the link emailed to it
+	users.Get("/me/reading", middleware.AuthMiddleware(authService), progressHandler.GetContinueReading)                                      // Protected: Continue reading list
+	users.Get("/me/recommendations", middleware.AuthMiddleware(authService), mangaHandler.GetUserRecommendations)                             // Protected: Personal manga recommendations
+	users.Get("/me/webhooks", middleware.AuthMiddleware(authService), webhookHandler.GetWebhooks)                                             // Protected: Get my webhooks
+	users.Get("/me/webhooks/events", middleware.AuthMiddleware(authService), webhookHandler.GetWebhookEvents)                                 // Protected: List subscribable webhook events
+	users.Post("/me/webhooks", middleware.AuthMiddleware(authService), webhookHandler.CreateWebhook)                                          // Protected: Register webhook
+	users.Delete("/me/webhooks/:id", middleware.AuthMiddleware(authService), webhookHandler.DeleteWebhook)                                    // Protected: Delete webhook
+	users.Get("/me/webhooks/:id/deliveries", middleware.AuthMiddleware(authService), webhookHandler.GetDeliveries)                            // Protected: Webhook delivery log
+	users.Get("/me/webhooks/:id/deliveries/:deliveryID", middleware.AuthMiddleware(authService), webhookHandler.GetDelivery)                  // Protected: Webhook delivery with attempts
+	users.Post("/me/webhooks/:id/deliveries/:deliveryID/redeliver", middleware.AuthMiddleware(authService), webhookHandler.RedeliverDelivery) // Protected: Resend webhook delivery
+	users.Get("/me/apikeys", middleware.AuthMiddleware(authService), middleware.SessionOnlyMiddleware(), apiKeyHandler.GetKeys)               // Protected: Get my API keys (not with an API key)
+	users.Post("/me/apikeys", middleware.AuthMiddleware(authService), middleware.SessionOnlyMiddleware(), apiKeyHandler.CreateKey)            // Protected: Create API key, shown once
+	users.Patch("/me/apikeys/:id", middleware.AuthMiddleware(authService), middleware.SessionOnlyMiddleware(), apiKeyHandler.UpdateKey)       // Protected: Rename API key or change its scopes or rate limit
+	users.Delete("/me/apikeys/:id", middleware.AuthMiddleware(authService), middleware.SessionOnlyMiddleware(), apiKeyHandler.RevokeKey)      // Protected: Revoke API key
+	users.Post("/me/apikeys/:id/rotate", middleware.AuthMiddleware(authService), middleware.SessionOnlyMiddleware(), apiKeyHandler.RotateKey) // Protected: Replace API key, shown once
+	users.Get("/me/wishlists", middleware.AuthMiddleware(authService), wishlistHandler.GetMyWishlists)                                        // Protected: Get my wishlists
+	users.Get("/me/cart", middleware.AuthMiddleware(authService), cartHandler.GetCart)                                                        // Protected: Get my cart, priced with current discounts
+	users.Put("/me/cart/items/:mangaID", middleware.AuthMiddleware(authService), cartHandler.SetItem)                                         // Protected: Put a manga in my cart or change its quantity
+	users.Delete("/me/cart/items/:mangaID", middleware.AuthMiddleware(authService), cartHandler.RemoveItem)                                   // Protected: Remove a manga from my cart
+	users.Delete("/me/cart", middleware.AuthMiddleware(authService), cartHandler.ClearCart)                                                   // Protected: Empty my cart
+	users.Get("/me/rentals", middleware.AuthMiddleware(authService), rentalHandler.GetMyRentals)                                              // Protected: Get my active rentals
+	users.Post("/me/export", middleware.AuthMiddleware(authService), jobHandler.StartPersonalDataExport)                                      // Protected: Export all my personal data in the background, as a ZIP of JSON files
+	users.Get("/me/notification-preferences", middleware.AuthMiddleware(authService), notificationHandler.GetPreferences)                     // Protected: Get my email preferences
+	users.Patch("/me/notification-preferences", middleware.AuthMiddleware(authService), notificationHandler.UpdatePreferences)                // Protected: Change my email preferences
+	users.Get("/me/digests", middleware.AuthMiddleware(authService), digestHandler.GetDigests)                                                // Protected: Get my digests of low-priority notifications
+	users.Get("/me/digests/:id", middleware.AuthMiddleware(authService), digestHandler.GetDigest)                                             // Protected: Get one of my digests
+	users.Post("/me/digests/:id/read", middleware.AuthMiddleware(authService), digestHandler.MarkRead)                                        // Protected: Mark one of my digests read
+	users.Get("/me/phone", middleware.AuthMiddleware(authService), phoneHandler.GetPhone)                                                     // Protected: Get my phone number
+	users.Put("/me/phone", middleware.AuthMiddleware(authService), phoneHandler.SetPhone)                                                     // Protected: Set my phone number and text it a verification code
+	users.Post("/me/phone/verify", middleware.AuthMiddleware(authService), phoneHandler.VerifyPhone)                                          // Protected: Verify my phone number with the texted code
+	users.Put("/me/phone/consent", middleware.AuthMiddleware(authService), phoneHandler.UpdateConsent)                                        // Protected: Opt into or out of order alerts by text
+	users.Delete("/me/phone", middleware.AuthMiddleware(authService), phoneHandler.RemovePhone)                                               // Protected: Remove my phone number
+	users.Get("/me/line", middleware.AuthMiddleware(authService), lineHandler.GetAccount)                                                     // Protected: Get my LINE account link
+	users.Post("/me/line/link", middleware.AuthMiddleware(authService), lineHandler.StartLink)                                                // Protected: Link my LINE account with the link token from LINE
+	users.Delete("/me/line", middleware.AuthMiddleware(authService), lineHandler.Unlink)                                                      // Protected: Unlink my LINE account
+	users.Get("/:id", userHandler.GetUserByID)                                                                                                // Public: Get user by ID
+	users.Get("/:id/wishlists", wishlistHandler.GetUserWishlists)                                                                             // Public: Get a user's public wishlists
+	users.Post("/", middleware.AuthMiddleware(authService), userHandler.CreateUser)                                                           // Protected: Create user
+	users.Put("/:id", middleware.AuthMiddleware(authService), userHandler.UpdateUser)                                                         // Protected: Update own user (any for admins)
+	users.Patch("/:id", middleware.AuthMiddleware(authService), userHandler.PatchUser)                                                        // Protected: Partially update own user (any for admins)
+	users.Delete("/:id", middleware.AuthMiddleware(authService), userHandler.DeleteUser)                                                      // Protected: Delete own user (any for admins)
+
+	// Admin API routes
+	adminAPI := v1.Group("/admin")
+	adminAPI.Get("/dashboard", middleware.AuthMiddleware(authService), middleware.AdminMiddleware(), dashboardHandler.GetDashboard)                               // Admin: Key figures for the ops dashboard (users, mangas, orders, revenue, error rates, job queue)
+	adminAPI.Get("/users", middleware.AuthMiddleware(authService), middleware.AdminMiddleware(), adminHandler.GetUsers)                                           // Admin: Get all users with full records
+	adminAPI.Post("/users/merge", middleware.AuthMiddleware(authService), middleware.AdminMiddleware(), adminHandler.MergeUsers)                                  // Admin: Merge duplicate users
+	adminAPI.Post("/users/:id/suspend", middleware.AuthMiddleware(authService), middleware.AdminMiddleware(), adminHandler.SuspendUser)                           // Admin: Suspend user
+	adminAPI.Post("/users/:id/unsuspend", middleware.AuthMiddleware(authService), middleware.AdminMiddleware(), adminHandler.UnsuspendUser)                       // Admin: Lift user suspension
+	adminAPI.Get("/users/:id/quota", middleware.AuthMiddleware(authService), middleware.AdminMiddleware(), adminHandler.GetUserQuota)                             // Admin: View user quota usage
+	adminAPI.Post("/users/:id/quota/reset", middleware.AuthMiddleware(authService), middleware.AdminMiddleware(), adminHandler.ResetUserQuota)                    // Admin: Reset user quota usage
+	adminAPI.Get("/discounts", middleware.AuthMiddleware(authService), middleware.AdminMiddleware(), discountHandler.GetDiscounts)                                // Admin: Get discount campaigns
+	adminAPI.Post("/discounts", middleware.AuthMiddleware(authService), middleware.AdminMiddleware(), discountHandler.CreateDiscount)                             // Admin: Create discount campaign
+	adminAPI.Put("/discounts/:id", middleware.AuthMiddleware(authService), middleware.AdminMiddleware(), discountHandler.UpdateDiscount)                          // Admin: Update discount campaign
+	adminAPI.Delete("/discounts/:id", middleware.AuthMiddleware(authService), middleware.AdminMiddleware(), discountHandler.DeleteDiscount)                       // Admin: Delete discount campaign
+	adminAPI.Get("/tax-rates", middleware.AuthMiddleware(authService), middleware.AdminMiddleware(), taxHandler.GetTaxRates)                                      // Admin: Get tax rates
+	adminAPI.Post("/tax-rates", middleware.AuthMiddleware(authService), middleware.AdminMiddleware(), taxHandler.CreateTaxRate)                                   // Admin: Create tax rate
+	adminAPI.Put("/tax-rates/:id", middleware.AuthMiddleware(authService), middleware.AdminMiddleware(), taxHandler.UpdateTaxRate)                                // Admin: Update tax rate
+	adminAPI.Delete("/tax-rates/:id", middleware.AuthMiddleware(authService), middleware.AdminMiddleware(), taxHandler.DeleteTaxRate)                             // Admin: Delete tax rate
+	adminAPI.Get("/webhook-deliveries", middleware.AuthMiddleware(authService), middleware.AdminMiddleware(), webhookHandler.GetAllDeliveries)                    // Admin: Webhook deliveries (filter dead-lettered)
+	adminAPI.Get("/webhook-deliveries/:id", middleware.AuthMiddleware(authService), middleware.AdminMiddleware(), webhookHandler.GetAnyDelivery)                  // Admin: Webhook delivery with attempts
+	adminAPI.Post("/webhook-deliveries/:id/redeliver", middleware.AuthMiddleware(authService), middleware.AdminMiddleware(), webhookHandler.RedeliverAnyDelivery) // Admin: Resend webhook delivery
+	adminAPI.Delete("/comments/:id", middleware.AuthMiddleware(authService), middleware.AdminMiddleware(), commentHandler.ModerateDeleteComment)                  // Admin: Remove comment (moderation)
+	adminAPI.Get("/mangas/review-queue", middleware.AuthMiddleware(authService), middleware.AdminMiddleware(), mangaHandler.GetReviewQueue)                       // Admin: Mangas awaiting review
+	adminAPI.Post("/mangas/:id/approve", middleware.AuthMiddleware(authService), middleware.AdminMiddleware(), mangaHandler.ApproveManga)                         // Admin: Publish manga
+	adminAPI.Post("/mangas/:id/reject", middleware.AuthMiddleware(authService), middleware.AdminMiddleware(), mangaHandler.RejectManga)                           // Admin: Reject manga
+	adminAPI.Delete("/mangas/:id/purge", middleware.AuthMiddleware(authService), middleware.AdminMiddleware(), mangaHandler.PurgeManga)                           // Admin: Permanently delete manga
+	adminAPI.Get("/audit-logs", middleware.AuthMiddleware(authService), middleware.AdminMiddleware(), auditHandler.ListAuditLogs)                                 // Admin: Audit log (filter by actor, entity, action and time range)
+	adminAPI.Get("/apikeys", middleware.AuthMiddleware(authService), middleware.AdminMiddleware(), apiKeyHandler.ListKeys)                                        // Admin: API keys of all users (filter by user and status)
 
 	// Manga routes
-	mangas := v1.Group("/mangas")
-	mangas.Get("/", mangaHandler.GetMangas) // Public: Get all mangas
-
-	// Manga pagination routes (must be before /:id to avoid conflicts)
-	mangas.Get("/paginated", mangaHandler.GetMangasPaginated)                    // Public: Get paginated mangas
-	mangas.Get("/active", mangaHandler.GetActiveMangas)                          // Public: Get active mangas
-	mangas.Get("/active/paginated", mangaHandler.GetActiveMangasPaginated)       // Public: Get paginated active mangas
-	mangas.Get("/price", mangaHandler.GetMangasByPriceRange)                     // Public: Get mangas by price range
-	mangas.Get("/price/paginated", mangaHandler.GetMangasByPriceRangePaginated)  // Public: Get paginated mangas by price range
-	mangas.Get("/user/:userID", mangaHandler.GetMangasByUser)                    // Public: Get mangas by user
-	mangas.Get("/user/:userID/paginated", mangaHandler.GetMangasByUserPaginated) // Public: Get paginated mangas by user
+	mangas := v1.Group("/mangas").Name("mangas.")
+	mangas.Get("/", v1MangaDeprecation, middleware.OptionalAuthMiddleware(authService), mangaListCache, mangaHandler.GetMangas).Name("list") // Public: List mangas with filters, sort and pagination
+
+	// Search results aren't cached: an external index only catches up after
+	// the change that invalidated the cache (must be before /:id as well)
+	mangas.Get("/search", middleware.OptionalAuthMiddleware(authService), searchHandler.SearchMangas) // Public: Typo-tolerant search with facet counts
+
+	// Static manga routes (must be before /:id to avoid conflicts)
+	mangas.Get("/trending", mangaHandler.GetTrendingMangas)                                                            // Public: Get trending mangas by recent views
+	mangas.Get("/facets", middleware.OptionalAuthMiddleware(authService), mangaListCache, mangaHandler.GetMangaFacets) // Public: Count listed mangas per publication status
+	mangas.Get("/export", middleware.AuthMiddleware(authService), mangaHandler.ExportMangas)                           // Protected: Export my mangas (all for admins) as CSV/XLSX
+	mangas.Post("/export/jobs", middleware.AuthMiddleware(authService), jobHandler.StartMangaExport)                   // Protected: Export my mangas in the background, to download from the job
+	mangas.Get("/mine", middleware.AuthMiddleware(authService), mangaHandler.GetMyMangas)                              // Protected: Get my mangas in any status
+	mangas.Get("/deleted", middleware.AuthMiddleware(authService), mangaHandler.GetDeletedMangas)                      // Protected: Get my deleted mangas (all for admins)
+	mangas.Patch("/batch", middleware.AuthMiddleware(authService), mangaHandler.BatchUpdateMangas)                     // Protected: Batch update mangas (per-item ownership)
+	mangas.Delete("/batch", middleware.AuthMiddleware(authService), mangaHandler.BatchDeleteMangas)                    // Protected: Batch delete mangas (per-item ownership)
+	mangas.Get("/low-stock", middleware.AuthMiddleware(authService), mangaHandler.GetLowStockMangas)                   // Protected: Get my low stock mangas (all for admins)
+	mangas.Get("/lookup/isbn/:isbn", middleware.AuthMiddleware(authService), bookHandler.LookupISBN)                   // Protected: Look up book data by ISBN to pre-fill a manga
 
 	// Individual manga routes (must be after specific routes)
-	mangas.Get("/:id", mangaHandler.GetManga)                                               // Public: Get manga by ID
-	mangas.Post("/", middleware.AuthMiddleware(authService), mangaHandler.CreateManga)      // Protected: Create manga
-	mangas.Put("/:id", middleware.AuthMiddleware(authService), mangaHandler.UpdateManga)    // Protected: Update manga (ownership)
-	mangas.Delete("/:id", middleware.AuthMiddleware(authService), mangaHandler.DeleteManga) // Protected: Delete manga (ownership)
+	mangas.Get("/:id", v1MangaDeprecation, middleware.OptionalAuthMiddleware(authService), mangaCache, mangaHandler.GetManga).Name("show") // Public: Get manga by ID
+	mangas.Post("/", middleware.AuthMiddleware(authService), mangaHandler.CreateManga)                                                     // Protected: Create manga
+	mangas.Post("/import", middleware.AuthMiddleware(authService), mangaHandler.ImportMangas)                                              // Protected: Bulk import mangas from CSV/JSON
+	mangas.Put("/:id", middleware.AuthMiddleware(authService), mangaHandler.UpdateManga)                                                   // Protected: Update manga (ownership)
+	mangas.Delete("/:id", middleware.AuthMiddleware(authService), mangaHandler.DeleteManga)                                                // Protected: Delete manga (ownership)
+	mangas.Get("/:id/price-history", mangaHandler.GetPriceHistory)                                                                         // Public: Get price history and summary
+	mangas.Get("/:id/history", middleware.AuthMiddleware(authService), mangaHandler.GetMangaHistory)                                       // Protected: Get version history (ownership)
+	mangas.Post("/:id/history/:version/revert", middleware.AuthMiddleware(authService), mangaHandler.RevertManga)                          // Protected: Revert to version (ownership)
+	mangas.Get("/:id/recommendations", mangaHandler.GetRecommendations)                                                                    // Public: Get similar mangas
+	mangas.Get("/:id/related", relationHandler.GetRelated).Name("related")                                                                 // Public: Get related mangas
+	mangas.Post("/:id/related", middleware.AuthMiddleware(authService), relationHandler.CreateRelation)                                    // Protected: Link related manga (ownership)
+	mangas.Delete("/:id/related/:relatedID", middleware.AuthMiddleware(authService), relationHandler.DeleteRelation)                       // Protected: Unlink related manga (ownership)
+	mangas.Post("/:id/submit", middleware.AuthMiddleware(authService), mangaHandler.SubmitManga)                                           // Protected: Submit manga for review (ownership)
+	mangas.Post("/:id/restore", middleware.AuthMiddleware(authService), mangaHandler.RestoreManga)                                         // Protected: Restore deleted manga (ownership or admin)
+	mangas.Post("/:id/stock/adjust", middleware.AuthMiddleware(authService), mangaHandler.AdjustStock)                                     // Protected: Adjust stock (ownership)
+
+	// Chapter routes
+	mangas.Get("/:id/chapters", chapterHandler.GetChapters).Name("chapters")                                        // Public: Get manga chapters
+	mangas.Get("/:id/chapters/:chapterID", chapterHandler.GetChapter)                                               // Public: Get chapter
+	mangas.Post("/:id/chapters", middleware.AuthMiddleware(authService), chapterHandler.CreateChapter)              // Protected: Create chapter (ownership)
+	mangas.Put("/:id/chapters/:chapterID", middleware.AuthMiddleware(authService), chapterHandler.UpdateChapter)    // Protected: Update chapter (ownership)
+	mangas.Delete("/:id/chapters/:chapterID", middleware.AuthMiddleware(authService), chapterHandler.DeleteChapter) // Protected: Delete chapter (ownership)
+
+	// Review routes
+	mangas.Get("/:id/reviews", reviewHandler.GetReviews).Name("reviews")                                        // Public: Get manga reviews
+	mangas.Post("/:id/reviews", middleware.AuthMiddleware(authService), reviewHandler.CreateReview)             // Protected: Review manga (one per user)
+	mangas.Put("/:id/reviews/:reviewID", middleware.AuthMiddleware(authService), reviewHandler.UpdateReview)    // Protected: Update own review
+	mangas.Delete("/:id/reviews/:reviewID", middleware.AuthMiddleware(authService), reviewHandler.DeleteReview) // Protected: Delete own review
+
+	// Comment routes
+	mangas.Get("/:id/comments", commentHandler.GetComments).Name("comments")                                        // Public: Get manga comment threads
+	mangas.Post("/:id/comments", middleware.AuthMiddleware(authService), commentHandler.CreateComment)              // Protected: Post comment or reply
+	mangas.Put("/:id/comments/:commentID", middleware.AuthMiddleware(authService), commentHandler.UpdateComment)    // Protected: Edit own comment (edit window)
+	mangas.Delete("/:id/comments/:commentID", middleware.AuthMiddleware(authService), commentHandler.DeleteComment) // Protected: Delete own comment
+
+	// Reading progress routes
+	mangas.Get("/:id/progress", middleware.AuthMiddleware(authService), progressHandler.GetProgress)    // Protected: Get my reading progress
+	mangas.Put("/:id/progress", middleware.AuthMiddleware(authService), progressHandler.UpdateProgress) // Protected: Record reading progress
+
+	// Rental routes
+	mangas.Post("/:id/rent", middleware.AuthMiddleware(authService), rentalHandler.RentManga) // Protected: Rent manga for a limited time
+
+	// Gallery routes
+	mangas.Get("/:id/images", imageHandler.GetImages).Name("images")                                                                                               // Public: Get manga gallery
+	mangas.Post("/:id/images", middleware.AuthMiddleware(authService), middleware.UploadLimitMiddleware(domain.MaxMangaImageUploadSize), imageHandler.UploadImage) // Protected: Upload gallery image (ownership)
+	mangas.Put("/:id/images/order", middleware.AuthMiddleware(authService), imageHandler.ReorderImages)                                                            // Protected: Reorder gallery (ownership)
+	mangas.Patch("/:id/images/:imageID", middleware.AuthMiddleware(authService), imageHandler.UpdateImage)                                                         // Protected: Update image caption (ownership)
+	mangas.Delete("/:id/images/:imageID", middleware.AuthMiddleware(authService), imageHandler.DeleteImage)                                                        // Protected: Delete gallery image (ownership)
+	mangas.Get("/:id/translations", translationHandler.GetTranslations).Name("translations")                                                                       // Public: List manga translations
+	mangas.Put("/:id/translations/:locale", middleware.AuthMiddleware(authService), translationHandler.SaveTranslation)                                            // Protected: Set translation for a locale (ownership)
+	mangas.Delete("/:id/translations/:locale", middleware.AuthMiddleware(authService), translationHandler.DeleteTranslation)                                       // Protected: Delete translation (ownership)
+
+	// Genre routes
+	genres := v1.Group("/genres")
+	genres.Get("/", genreHandler.GetGenres)                                                                               // Public: Get genres with manga counts
+	genres.Post("/", middleware.AuthMiddleware(authService), middleware.AdminMiddleware(), genreHandler.CreateGenre)      // Admin: Create genre
+	genres.Put("/:id", middleware.AuthMiddleware(authService), middleware.AdminMiddleware(), genreHandler.UpdateGenre)    // Admin: Update genre
+	genres.Delete("/:id", middleware.AuthMiddleware(authService), middleware.AdminMiddleware(), genreHandler.DeleteGenre) // Admin: Delete genre
+
+	// Series routes
+	series := v1.Group("/series")
+	series.Post("/", middleware.AuthMiddleware(authService), seriesHandler.CreateSeries)                       // Protected: Create series
+	series.Get("/:id", seriesHandler.GetSeries)                                                                // Public: Get series with volumes, count and total price
+	series.Put("/:id", middleware.AuthMiddleware(authService), seriesHandler.UpdateSeries)                     // Protected: Update series (ownership)
+	series.Delete("/:id", middleware.AuthMiddleware(authService), seriesHandler.DeleteSeries)                  // Protected: Delete series, ungrouping volumes (ownership)
+	series.Post("/:id/volumes", middleware.AuthMiddleware(authService), seriesHandler.AddVolume)               // Protected: Add manga to series (ownership)
+	series.Put("/:id/volumes/order", middleware.AuthMiddleware(authService), seriesHandler.ReorderVolumes)     // Protected: Reorder series volumes (ownership)
+	series.Delete("/:id/volumes/:mangaID", middleware.AuthMiddleware(authService), seriesHandler.RemoveVolume) // Protected: Remove manga from series (ownership)
+
+	// Wishlist routes
+	wishlists := v1.Group("/wishlists")
+	wishlists.Post("/", middleware.AuthMiddleware(authService), wishlistHandler.CreateWishlist)                 // Protected: Create wishlist
+	wishlists.Get("/shared/:token", wishlistHandler.GetSharedWishlist)                                          // Public: View wishlist through its share link
+	wishlists.Get("/:id", middleware.OptionalAuthMiddleware(authService), wishlistHandler.GetWishlist)          // Public: Get own or public wishlist
+	wishlists.Put("/:id", middleware.AuthMiddleware(authService), wishlistHandler.UpdateWishlist)               // Protected: Rename wishlist or change visibility
+	wishlists.Delete("/:id", middleware.AuthMiddleware(authService), wishlistHandler.DeleteWishlist)            // Protected: Delete wishlist
+	wishlists.Post("/:id/share", middleware.AuthMiddleware(authService), wishlistHandler.RotateShareToken)      // Protected: Regenerate share link
+	wishlists.Post("/:id/items", middleware.AuthMiddleware(authService), wishlistHandler.AddItem)               // Protected: Add manga to wishlist
+	wishlists.Put("/:id/items/order", middleware.AuthMiddleware(authService), wishlistHandler.ReorderItems)     // Protected: Reorder wishlist
+	wishlists.Delete("/:id/items/:mangaID", middleware.AuthMiddleware(authService), wishlistHandler.RemoveItem) // Protected: Remove manga from wishlist
+
+	// Order routes (all protected)
+	orders := v1.Group("/orders")
+	orders.Post("/", middleware.AuthMiddleware(authService), orderHandler.Checkout)                     // Protected: Order my cart
+	orders.Get("/", middleware.AuthMiddleware(authService), orderHandler.GetMyOrders)                   // Protected: Get my orders (?archived=true for archived ones)
+	orders.Get("/sales", middleware.AuthMiddleware(authService), orderHandler.GetSellerOrders)          // Protected: Get orders containing my mangas
+	orders.Post("/sales/export", middleware.AuthMiddleware(authService), jobHandler.StartSalesReport)   // Protected: Report my sales (?from=&to= dates) as CSV in the background
+	orders.Get("/:id", middleware.AuthMiddleware(authService), orderHandler.GetOrder)                   // Protected: Get order (buyer, seller or admin)
+	orders.Get("/:id/invoice", middleware.AuthMiddleware(authService), orderHandler.GetInvoice)         // Protected: Get a paid order's invoice (buyer, seller or admin)
+	orders.Patch("/:id/status", middleware.AuthMiddleware(authService), orderHandler.UpdateOrderStatus) // Protected: Fulfill or cancel an order (mark paid: admin)
+	orders.Put("/:id/shipment", middleware.AuthMiddleware(authService), orderHandler.ShipOrder)         // Protected: Add tracking details (seller)
+
+	// Event stream routes
+	events := v1.Group("/events")
+	events.Get("/stream", middleware.QueryTokenMiddleware(), middleware.AuthMiddleware(authService), eventStreamHandler.Stream) // Protected: Server-sent event stream of my events
+
+	// Job routes
+	jobs := v1.Group("/jobs")
+	jobs.Get("/:id", middleware.AuthMiddleware(authService), jobHandler.GetUserJob)                      // Protected: Get the status of my job
+	jobs.Get("/:id/download", middleware.OptionalAuthMiddleware(authService), jobHandler.DownloadExport) // Protected: Download the file of my finished export job (public with a signed URL)
+
+	// Presigned upload routes (all protected)
+	uploads := v1.Group("/uploads")
+	uploads.Post("/presign", middleware.AuthMiddleware(authService), uploadHandler.PresignUpload)     // Protected: Get a URL to upload a gallery image straight to storage
+	uploads.Post("/:id/confirm", middleware.AuthMiddleware(authService), uploadHandler.ConfirmUpload) // Protected: Validate an uploaded image and add it to the gallery
+
+	// Team routes (all protected)
+	teams := v1.Group("/teams")
+	teams.Get("/", middleware.AuthMiddleware(authService), teamHandler.GetMyTeams)                                 // Protected: Get my teams
+	teams.Post("/", middleware.AuthMiddleware(authService), teamHandler.CreateTeam)                                // Protected: Create team
+	teams.Post("/invitations/:token/accept", middleware.AuthMiddleware(authService), teamHandler.AcceptInvitation) // Protected: Accept invitation
+	teams.Get("/:id", middleware.AuthMiddleware(authService), teamHandler.GetTeam)                                 // Protected: Get team (members only)
+	teams.Delete("/:id", middleware.AuthMiddleware(authService), teamHandler.DeleteTeam)                           // Protected: Delete team (owner)
+	teams.Get("/:id/mangas", middleware.AuthMiddleware(authService), teamHandler.GetTeamMangas)                    // Protected: Get team mangas (members only)
+	teams.Post("/:id/invitations", middleware.AuthMiddleware(authService), teamHandler.InviteMember)               // Protected: Invite member (owner)
+	teams.Delete("/:id/members/:userID", middleware.AuthMiddleware(authService), teamHandler.RemoveMember)         // Protected: Remove member (owner or self)
+
+	// API v2 routes
+	v2 := app.Group("/api/v2", middleware.APIVersionMiddleware(2)).Name("v2.")
+
+	v2Mangas := v2.Group("/mangas").Name("mangas.")
+	v2Mangas.Get("/", middleware.OptionalAuthMiddleware(authService), mangaListCache, mangaHandler.GetMangas).Name("list") // Public: List mangas (v2 representation)
+	v2Mangas.Get("/:id", middleware.OptionalAuthMiddleware(authService), mangaCache, mangaHandler.GetManga).Name("show")   // Public: Get manga by ID (v2 representation)
+}
+
+// mangaListCacheTags tags cached manga listings
+func mangaListCacheTags(c *fiber.Ctx) []string {
+	return []string{domain.MangaListCacheTag}
+}
+
+// mangaCacheTags tags a cached manga detail with the manga's ID
+func mangaCacheTags(c *fiber.Ctx) []string {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return nil
+	}
+	return []string{domain.MangaCacheTag(uint(id))}
 }
@@ -0,0 +1,110 @@
+package imaging
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/png"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/thitiphongD/my-backend/internal/config"
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// commandEncoder implements the ImageEncoder interface with a command line
+// encoder. Images are passed to it in a PNG file, which carries no metadata,
+// and read back from the file it writes.
+type commandEncoder struct {
+	format string
+	path   string
+	args   func(in, out string) []string
+}
+
+// NewImageEncoders returns an encoder for each image format whose encoder is
+// configured and installed, leaving out the others with a warning
+func NewImageEncoders(cfg *config.Config) []ports.ImageEncoder {
+	candidates := []*commandEncoder{
+		{
+			format: domain.ImageFormatWebP,
+			path:   cfg.ImageWebPEncoderPath,
+			args: func(in, out string) []string {
+				return []string{"-quiet", "-q", "80", "-metadata", "none", in, "-o", out}
+			},
+		},
+		{
+			format: domain.ImageFormatAVIF,
+			path:   cfg.ImageAVIFEncoderPath,
+			args: func(in, out string) []string {
+				return []string{"--speed", "6", "--min", "20", "--max", "40", "--ignore-exif", "--ignore-xmp", in, out}
+			},
+		},
+	}
+
+	var encoders []ports.ImageEncoder
+	for _, encoder := range candidates {
+		if encoder.path == "" {
+			continue
+		}
+		path, err := exec.LookPath(encoder.path)
+		if err != nil {
+			log.Printf("Images get no %s variants: %s is not installed", encoder.format, encoder.path)
+			continue
+		}
+		encoder.path = path
+		encoders = append(encoders, encoder)
+	}
+	return encoders
+}
+
+// Format is the format the encoder writes
+func (e *commandEncoder) Format() string {
+	return e.format
+}
+
+// Encode runs the encoder on the image
+func (e *commandEncoder) Encode(ctx context.Context, img image.Image) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "image-encode-")
+	if err != nil {
+		return nil, errors.New("failed to create a temporary directory")
+	}
+	defer os.RemoveAll(dir)
+
+	in := filepath.Join(dir, "in.png")
+	out := filepath.Join(dir, "out."+domain.ImageFormats[e.format])
+
+	var source bytes.Buffer
+	if err := (&png.Encoder{CompressionLevel: png.BestSpeed}).Encode(&source, img); err != nil {
+		return nil, errors.New("failed to encode image")
+	}
+	if err := os.WriteFile(in, source.Bytes(), 0o600); err != nil {
+		return nil, errors.New("failed to write image for " + e.format + " encoding")
+	}
+
+	// Encoders report errors on either stream, so they fail with the end of both
+	var output bytes.Buffer
+	cmd := exec.CommandContext(ctx, e.path, e.args(in, out)...)
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	if err := cmd.Run(); err != nil {
+		message := strings.TrimSpace(output.String())
+		if len(message) > 1000 {
+			message = "..." + message[len(message)-1000:]
+		}
+		if message == "" {
+			return nil, errors.New(e.path + " failed: " + err.Error())
+		}
+		return nil, errors.New(e.path + " failed: " + message)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		return nil, errors.New(e.path + " wrote no image")
+	}
+	return data, nil
+}
@@ -0,0 +1,177 @@
+package line
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// LINE Messaging API endpoints
+const (
+	lineAPIURL         = "https://api.line.me/v2/bot"
+	lineAccountLinkURL = "https://access.line.me/dialog/bot/accountLink"
+)
+
+// messagingClient implements the LineMessenger interface on the LINE
+// Messaging API of one channel
+type messagingClient struct {
+	accessToken   string
+	channelSecret string
+	client        *http.Client
+}
+
+// NewMessagingClient creates a LINE messenger for the channel with the access
+// token and secret; the secret checks the signatures of webhook requests
+func NewMessagingClient(accessToken, channelSecret string, timeout time.Duration) (ports.LineMessenger, error) {
+	if accessToken == "" || channelSecret == "" {
+		return nil, errors.New("LINE_CHANNEL_ACCESS_TOKEN and LINE_CHANNEL_SECRET are required")
+	}
+	return &messagingClient{
+		accessToken:   accessToken,
+		channelSecret: channelSecret,
+		client:        &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// lineWebhook is the body of a LINE webhook request
+type lineWebhook struct {
+	Events []struct {
+		Type       string `json:"type"`
+		ReplyToken string `json:"replyToken"`
+		Source     struct {
+			Type   string `json:"type"`
+			UserID string `json:"userId"`
+		} `json:"source"`
+		Link struct {
+			Result string `json:"result"`
+			Nonce  string `json:"nonce"`
+		} `json:"link"`
+	} `json:"events"`
+}
+
+// ParseWebhook checks that the body is signed with the channel secret, as
+// the base64 HMAC-SHA256 in the X-Line-Signature header, and decodes the
+// events of users; events of groups and rooms are skipped
+func (c *messagingClient) ParseWebhook(body []byte, signature string) ([]domain.LineEvent, error) {
+	mac := hmac.New(sha256.New, []byte(c.channelSecret))
+	mac.Write(body)
+	expected := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return nil, errors.New("invalid LINE signature")
+	}
+
+	var webhook lineWebhook
+	if err := json.Unmarshal(body, &webhook); err != nil {
+		return nil, errors.New("invalid LINE webhook body")
+	}
+	events := make([]domain.LineEvent, 0, len(webhook.Events))
+	for _, e := range webhook.Events {
+		if e.Source.Type != "user" || e.Source.UserID == "" {
+			continue
+		}
+		events = append(events, domain.LineEvent{
+			Type:       e.Type,
+			ReplyToken: e.ReplyToken,
+			UserID:     e.Source.UserID,
+			LinkResult: e.Link.Result,
+			LinkNonce:  e.Link.Nonce,
+		})
+	}
+	return events, nil
+}
+
+// IssueLinkToken asks LINE for a link token of the LINE user
+func (c *messagingClient) IssueLinkToken(ctx context.Context, lineUserID string) (string, error) {
+	var result struct {
+		LinkToken string `json:"linkToken"`
+	}
+	if err := c.post(ctx, "/user/"+url.PathEscape(lineUserID)+"/linkToken", nil, &result); err != nil {
+		return "", err
+	}
+	return result.LinkToken, nil
+}
+
+// LinkURL is the LINE page where the user confirms linking their account
+func (c *messagingClient) LinkURL(linkToken, nonce string) string {
+	return lineAccountLinkURL + "?" + url.Values{"linkToken": {linkToken}, "nonce": {nonce}}.Encode()
+}
+
+// lineTextMessage is a text message of the Messaging API
+type lineTextMessage struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// Reply answers an event with a text message through its reply token
+func (c *messagingClient) Reply(ctx context.Context, replyToken, text string) error {
+	return c.post(ctx, "/message/reply", map[string]interface{}{
+		"replyToken": replyToken,
+		"messages":   []lineTextMessage{{Type: "text", Text: text}},
+	}, nil)
+}
+
+// Push sends a text message to the LINE user
+func (c *messagingClient) Push(ctx context.Context, lineUserID, text string) error {
+	return c.post(ctx, "/message/push", map[string]interface{}{
+		"to":       lineUserID,
+		"messages": []lineTextMessage{{Type: "text", Text: text}},
+	}, nil)
+}
+
+// post calls the Messaging API, decoding the response into result when it
+// isn't nil. Client errors other than throttling are rejections; the rest
+// are transient.
+func (c *messagingClient) post(ctx context.Context, path string, payload, result interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		encoded, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, lineAPIURL+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.accessToken)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("LINE unreachable: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		err := fmt.Errorf("LINE responded with status %d: %s", resp.StatusCode, bytes.TrimSpace(detail))
+		if resp.StatusCode >= 400 && resp.StatusCode <= 499 && resp.StatusCode != http.StatusTooManyRequests {
+			return fmt.Errorf("%w: %v", domain.ErrLineRejected, err)
+		}
+		return err
+	}
+	if result == nil {
+		io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return errors.New("invalid LINE response")
+	}
+	return nil
+}
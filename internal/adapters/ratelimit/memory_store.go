@@ -0,0 +1,101 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// memorySweepInterval is how often expired windows are dropped from memory
+const memorySweepInterval = time.Minute
+
+// memoryWindow is one open counting window
+type memoryWindow struct {
+	count   int64
+	resetAt time.Time
+}
+
+// memoryStore implements the RateLimitStore interface for a single instance
+type memoryStore struct {
+	mu        sync.Mutex
+	windows   map[string]*memoryWindow
+	lastSweep time.Time
+
+	// slots holds when each holder's concurrency slot expires, by key
+	slots map[string]map[string]time.Time
+}
+
+// NewMemoryStore creates a rate limit store that keeps counters in process memory
+func NewMemoryStore() ports.RateLimitStore {
+	return &memoryStore{
+		windows:   make(map[string]*memoryWindow),
+		lastSweep: time.Now(),
+		slots:     make(map[string]map[string]time.Time),
+	}
+}
+
+// Increment counts a request in the key's current window
+func (s *memoryStore) Increment(key string, window time.Duration) (int64, time.Time, error) {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.lastSweep) >= memorySweepInterval {
+		for k, w := range s.windows {
+			if !now.Before(w.resetAt) {
+				delete(s.windows, k)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	w, ok := s.windows[key]
+	if !ok || !now.Before(w.resetAt) {
+		w = &memoryWindow{resetAt: now.Add(window)}
+		s.windows[key] = w
+	}
+	w.count++
+
+	return w.count, w.resetAt, nil
+}
+
+// Acquire takes one of the key's concurrency slots, dropping expired ones first
+func (s *memoryStore) Acquire(key, holder string, limit int64, ttl time.Duration) (bool, error) {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	holders, ok := s.slots[key]
+	if !ok {
+		holders = make(map[string]time.Time)
+		s.slots[key] = holders
+	}
+	for h, expiresAt := range holders {
+		if !now.Before(expiresAt) {
+			delete(holders, h)
+		}
+	}
+	if int64(len(holders)) >= limit {
+		return false, nil
+	}
+	holders[holder] = now.Add(ttl)
+
+	return true, nil
+}
+
+// Release frees the holder's concurrency slot
+func (s *memoryStore) Release(key, holder string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if holders, ok := s.slots[key]; ok {
+		delete(holders, holder)
+		if len(holders) == 0 {
+			delete(s.slots, key)
+		}
+	}
+	return nil
+}
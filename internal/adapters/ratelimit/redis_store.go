@@ -0,0 +1,86 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// redisTimeout bounds each counter update so a slow Redis does not stall requests
+const redisTimeout = 500 * time.Millisecond
+
+// incrementScript counts a request and opens the window on the first one,
+// atomically so concurrent instances agree on the window
+var incrementScript = redis.NewScript(`
+local count = redis.call("INCR", KEYS[1])
+if count == 1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return {count, redis.call("PTTL", KEYS[1])}
+`)
+
+// acquireScript takes a concurrency slot unless all are taken. Slots are the
+// members of a sorted set scored by when they expire, so expired ones are
+// dropped before counting.
+var acquireScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", now)
+if redis.call("ZCARD", KEYS[1]) >= tonumber(ARGV[3]) then
+	return 0
+end
+redis.call("ZADD", KEYS[1], now + tonumber(ARGV[4]), ARGV[2])
+redis.call("PEXPIRE", KEYS[1], ARGV[4])
+return 1
+`)
+
+// redisStore implements the RateLimitStore interface on Redis, shared by every instance
+type redisStore struct {
+	client *redis.Client
+}
+
+// NewRedisStore creates a rate limit store that keeps counters in Redis
+func NewRedisStore(client *redis.Client) ports.RateLimitStore {
+	return &redisStore{
+		client: client,
+	}
+}
+
+// Increment counts a request in the key's current window
+func (s *redisStore) Increment(key string, window time.Duration) (int64, time.Time, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	result, err := incrementScript.Run(ctx, s.client, []string{key}, window.Milliseconds()).Int64Slice()
+	if err != nil || len(result) != 2 {
+		return 0, time.Time{}, errors.New("failed to update rate limit counter")
+	}
+
+	return result[0], time.Now().Add(time.Duration(result[1]) * time.Millisecond), nil
+}
+
+// Acquire takes one of the key's concurrency slots
+func (s *redisStore) Acquire(key, holder string, limit int64, ttl time.Duration) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	acquired, err := acquireScript.Run(ctx, s.client, []string{key}, time.Now().UnixMilli(), holder, limit, ttl.Milliseconds()).Int64()
+	if err != nil {
+		return false, errors.New("failed to acquire concurrency slot")
+	}
+
+	return acquired == 1, nil
+}
+
+// Release frees the holder's concurrency slot
+func (s *redisStore) Release(key, holder string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	if err := s.client.ZRem(ctx, key, holder).Err(); err != nil {
+		return errors.New("failed to release concurrency slot")
+	}
+	return nil
+}
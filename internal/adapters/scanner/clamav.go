@@ -0,0 +1,114 @@
+package scanner
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// clamAVChunkSize is how much of the file is sent to clamd at a time
+const clamAVChunkSize = 32 << 10
+
+// clamAVScanner implements the MalwareScanner interface with clamd's INSTREAM
+// command, which streams the file to the daemon so it needs no shared disk
+type clamAVScanner struct {
+	network string
+	address string
+	timeout time.Duration
+}
+
+// NewClamAVScanner creates a scanner talking to clamd at address: host:port
+// for TCP, or the path of its Unix socket. A scan is given up after timeout.
+func NewClamAVScanner(address string, timeout time.Duration) ports.MalwareScanner {
+	network := "tcp"
+	if strings.HasPrefix(address, "/") {
+		network = "unix"
+	}
+	return &clamAVScanner{
+		network: network,
+		address: address,
+		timeout: timeout,
+	}
+}
+
+// errScannerUnavailable is returned when clamd can't be reached or fails to scan
+var errScannerUnavailable = errors.New("malware scanner unavailable")
+
+// Scan streams the file to clamd and reads its verdict
+func (s *clamAVScanner) Scan(ctx context.Context, r io.Reader) (*domain.ScanResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	conn, err := (&net.Dialer{}).DialContext(ctx, s.network, s.address)
+	if err != nil {
+		return nil, errScannerUnavailable
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	// Each chunk is prefixed with its length; an empty chunk ends the file
+	w := bufio.NewWriterSize(conn, clamAVChunkSize+4)
+	if _, err := w.WriteString("zINSTREAM\x00"); err != nil {
+		return nil, errScannerUnavailable
+	}
+	chunk := make([]byte, clamAVChunkSize)
+	for {
+		n, err := io.ReadFull(r, chunk)
+		if n > 0 {
+			if err := binary.Write(w, binary.BigEndian, uint32(n)); err != nil {
+				return nil, errScannerUnavailable
+			}
+			if _, err := w.Write(chunk[:n]); err != nil {
+				return nil, errScannerUnavailable
+			}
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			break
+		}
+		if err != nil {
+			return nil, errors.New("failed to read file for scanning")
+		}
+	}
+	if err := binary.Write(w, binary.BigEndian, uint32(0)); err != nil {
+		return nil, errScannerUnavailable
+	}
+	if err := w.Flush(); err != nil {
+		return nil, errScannerUnavailable
+	}
+
+	reply, err := bufio.NewReader(conn).ReadBytes(0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, errScannerUnavailable
+	}
+	return parseClamAVReply(string(bytes.TrimRight(reply, "\x00\n")))
+}
+
+// parseClamAVReply reads a reply such as "stream: OK" or
+// "stream: Eicar-Test-Signature FOUND"
+func parseClamAVReply(reply string) (*domain.ScanResult, error) {
+	_, verdict, _ := strings.Cut(reply, ": ")
+	switch {
+	case verdict == "OK":
+		return &domain.ScanResult{}, nil
+	case strings.HasSuffix(verdict, " FOUND"):
+		return &domain.ScanResult{
+			Infected:  true,
+			Signature: strings.TrimSuffix(verdict, " FOUND"),
+		}, nil
+	default:
+		// Such as "INSTREAM size limit exceeded. ERROR", when the file is
+		// larger than clamd's StreamMaxLength
+		return nil, errors.New("malware scan failed: " + reply)
+	}
+}
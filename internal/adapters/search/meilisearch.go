@@ -0,0 +1,252 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// MeilisearchConfig configures the Meilisearch index mangas are kept in
+type MeilisearchConfig struct {
+	URL    string // e.g. http://localhost:7700
+	APIKey string
+	Index  string
+}
+
+// meilisearchSettings make every filter of MangaFilter and every facet
+// available; only names are searched
+var meilisearchSettings = map[string]interface{}{
+	"searchableAttributes": []string{"name"},
+	"filterableAttributes": []string{
+		"is_active", "price", "user_created", "status", "genre",
+		"publication_status", "content_rating", "series_id",
+	},
+	"sortableAttributes": []string{"id"},
+}
+
+// meilisearch implements the SearchService interface on a Meilisearch index,
+// which matches names with typo tolerance and counts facets. The index only
+// decides which mangas match and in what order: hits are loaded from the
+// database, so mangas of suspended owners, or changed since they were indexed,
+// are never served from the index.
+type meilisearch struct {
+	cfg       MeilisearchConfig
+	endpoint  *url.URL
+	client    *http.Client
+	mangaRepo ports.MangaRepository
+
+	// configured is set once the index settings were applied
+	configured atomic.Bool
+}
+
+// NewMeilisearch creates a search backend on a Meilisearch index
+func NewMeilisearch(cfg MeilisearchConfig, mangaRepo ports.MangaRepository, timeout time.Duration) (ports.SearchService, error) {
+	endpoint, err := url.Parse(strings.TrimSuffix(cfg.URL, "/"))
+	if err != nil || endpoint.Host == "" {
+		return nil, errors.New("invalid Meilisearch URL")
+	}
+	if cfg.Index == "" {
+		return nil, errors.New("Meilisearch index is required")
+	}
+
+	return &meilisearch{
+		cfg:       cfg,
+		endpoint:  endpoint,
+		client:    &http.Client{Timeout: timeout},
+		mangaRepo: mangaRepo,
+	}, nil
+}
+
+// meilisearchResponse is the subset of a search response we use
+type meilisearchResponse struct {
+	Hits []struct {
+		ID uint `json:"id"`
+	} `json:"hits"`
+	TotalHits         int64                       `json:"totalHits"`
+	FacetDistribution map[string]map[string]int64 `json:"facetDistribution"`
+}
+
+// Search asks the index for a page of matching IDs and facet counts, then
+// loads the mangas in the order of the hits
+func (s *meilisearch) Search(ctx context.Context, filter *domain.MangaFilter, pagination *domain.PaginationRequest) (*domain.MangaSearchResult, error) {
+	if err := s.configure(ctx); err != nil {
+		return nil, err
+	}
+
+	var found meilisearchResponse
+	err := s.do(ctx, http.MethodPost, "/search", map[string]interface{}{
+		"q":                    filter.Query,
+		"filter":               meilisearchFilter(filter),
+		"facets":               domain.MangaSearchFacets,
+		"sort":                 []string{"id:asc"},
+		"page":                 pagination.Page,
+		"hitsPerPage":          pagination.PageSize,
+		"attributesToRetrieve": []string{"id"},
+	}, &found)
+	if err != nil {
+		return nil, errors.New("failed to search mangas")
+	}
+
+	ids := make([]uint, len(found.Hits))
+	for i, hit := range found.Hits {
+		ids[i] = hit.ID
+	}
+	loaded, err := s.mangaRepo.GetVisibleByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]*domain.Manga, len(loaded))
+	for _, manga := range loaded {
+		byID[manga.ID] = manga
+	}
+	mangas := make([]*domain.Manga, 0, len(ids))
+	for _, id := range ids {
+		if manga, ok := byID[id]; ok {
+			mangas = append(mangas, manga)
+		}
+	}
+
+	facets := make(map[string]map[string]int64, len(domain.MangaSearchFacets))
+	for _, facet := range domain.MangaSearchFacets {
+		facets[facet] = found.FacetDistribution[facet]
+		if facets[facet] == nil {
+			facets[facet] = map[string]int64{}
+		}
+	}
+
+	return &domain.MangaSearchResult{
+		Data:       mangas,
+		Pagination: domain.NewPaginationResponse(pagination.Page, pagination.PageSize, found.TotalHits),
+		Facets:     facets,
+	}, nil
+}
+
+// Index adds or replaces the documents of the mangas. Meilisearch applies
+// them in the background, so they become searchable shortly after.
+func (s *meilisearch) Index(ctx context.Context, mangas ...*domain.Manga) error {
+	if len(mangas) == 0 {
+		return nil
+	}
+	if err := s.configure(ctx); err != nil {
+		return err
+	}
+
+	documents := make([]*domain.MangaSearchDocument, len(mangas))
+	for i, manga := range mangas {
+		documents[i] = domain.NewMangaSearchDocument(manga)
+	}
+	if err := s.do(ctx, http.MethodPost, "/documents?primaryKey=id", documents, nil); err != nil {
+		return fmt.Errorf("failed to index mangas: %w", err)
+	}
+	return nil
+}
+
+// Remove deletes the documents of the mangas
+func (s *meilisearch) Remove(ctx context.Context, ids ...uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	if err := s.do(ctx, http.MethodPost, "/documents/delete-batch", ids, nil); err != nil {
+		return fmt.Errorf("failed to remove mangas from the search index: %w", err)
+	}
+	return nil
+}
+
+// configure applies the index settings, creating the index if needed, once
+// per process. Filtering on attributes that aren't filterable fails, so
+// nothing is searched or indexed before the settings are in place.
+func (s *meilisearch) configure(ctx context.Context) error {
+	if s.configured.Load() {
+		return nil
+	}
+	if err := s.do(ctx, http.MethodPatch, "/settings", meilisearchSettings, nil); err != nil {
+		return fmt.Errorf("failed to configure the search index: %w", err)
+	}
+	s.configured.Store(true)
+	return nil
+}
+
+// do sends a JSON request to path under the index and decodes the response into out
+func (s *meilisearch) do(ctx context.Context, method, path string, body, out interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	target := s.endpoint.String() + "/indexes/" + url.PathEscape(s.cfg.Index) + path
+	req, err := http.NewRequestWithContext(ctx, method, target, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.cfg.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+s.cfg.APIKey)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		_ = json.NewDecoder(io.LimitReader(resp.Body, 1<<16)).Decode(&apiErr)
+		return fmt.Errorf("Meilisearch responded with status %d: %s", resp.StatusCode, apiErr.Message)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// meilisearchFilter translates a manga filter into filter expressions, which
+// Meilisearch combines with AND. Only published mangas are listed, like in
+// the database search; suspended owners are filtered out when loading hits.
+func meilisearchFilter(filter *domain.MangaFilter) []string {
+	expressions := []string{fmt.Sprintf("status = %q", domain.MangaStatusPublished)}
+	if filter.IsActive != nil {
+		expressions = append(expressions, fmt.Sprintf("is_active = %t", *filter.IsActive))
+	}
+	if filter.MinPrice != nil {
+		expressions = append(expressions, fmt.Sprintf("price >= %v", *filter.MinPrice))
+	}
+	if filter.MaxPrice != nil {
+		expressions = append(expressions, fmt.Sprintf("price <= %v", *filter.MaxPrice))
+	}
+	if filter.UserID != nil {
+		expressions = append(expressions, fmt.Sprintf("user_created = %d", *filter.UserID))
+	}
+	if filter.Genre != "" {
+		expressions = append(expressions, fmt.Sprintf("genre = %q", filter.Genre))
+	}
+	if filter.PublicationStatus != "" {
+		expressions = append(expressions, fmt.Sprintf("publication_status = %q", filter.PublicationStatus))
+	}
+	if filter.ContentRating != "" {
+		expressions = append(expressions, fmt.Sprintf("content_rating = %q", filter.ContentRating))
+	}
+	if !filter.IncludeMature {
+		expressions = append(expressions, fmt.Sprintf("content_rating != %q", domain.ContentRatingMature))
+	}
+	if filter.SeriesID != nil {
+		expressions = append(expressions, fmt.Sprintf("series_id = %d", *filter.SeriesID))
+	}
+	if filter.Ungrouped {
+		expressions = append(expressions, "series_id IS NULL")
+	}
+	return expressions
+}
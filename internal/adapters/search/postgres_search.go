@@ -0,0 +1,55 @@
+package search
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// postgresSearch implements the SearchService interface on the database the
+// mangas live in, with the full text and trigram indexes of the migrations.
+// It has no index of its own to maintain, and is not typo tolerant.
+type postgresSearch struct {
+	mangaRepo ports.MangaRepository
+}
+
+// NewPostgresSearch creates a search backend querying the mangas table
+func NewPostgresSearch(mangaRepo ports.MangaRepository) ports.SearchService {
+	return &postgresSearch{
+		mangaRepo: mangaRepo,
+	}
+}
+
+// Search ranks the mangas by relevance to the query and counts every facet
+func (s *postgresSearch) Search(ctx context.Context, filter *domain.MangaFilter, pagination *domain.PaginationRequest) (*domain.MangaSearchResult, error) {
+	mangas, total, err := s.mangaRepo.Search(ctx, filter, pagination, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	facets := make(map[string]map[string]int64, len(domain.MangaSearchFacets))
+	for _, facet := range domain.MangaSearchFacets {
+		counts, err := s.mangaRepo.CountByFacet(ctx, filter, facet)
+		if err != nil {
+			return nil, err
+		}
+		facets[facet] = counts
+	}
+
+	return &domain.MangaSearchResult{
+		Data:       mangas,
+		Pagination: domain.NewPaginationResponse(pagination.Page, pagination.PageSize, total),
+		Facets:     facets,
+	}, nil
+}
+
+// Index does nothing, the mangas table is always up to date
+func (s *postgresSearch) Index(ctx context.Context, mangas ...*domain.Manga) error {
+	return nil
+}
+
+// Remove does nothing, the mangas table is always up to date
+func (s *postgresSearch) Remove(ctx context.Context, ids ...uint) error {
+	return nil
+}
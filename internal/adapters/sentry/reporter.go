@@ -0,0 +1,412 @@
+package sentry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/internal/utils"
+)
+
+// reportQueueSize is how many events may wait to be sent; more are dropped
+const reportQueueSize = 100
+
+// modulePath marks the stack frames of our own code, which Sentry shows
+// expanded and groups errors by
+const modulePath = "github.com/thitiphongD/my-backend"
+
+// Config configures the Sentry project errors are reported to
+type Config struct {
+	DSN         string // https://<public key>@<host>/<project ID>
+	Environment string
+	// Release is the version of the running code; the VCS revision the binary
+	// was built from is used when empty
+	Release string
+}
+
+// reporter implements the ErrorReporter interface on the envelope endpoint of
+// Sentry's ingestion API
+type reporter struct {
+	endpoint    string
+	auth        string
+	environment string
+	release     string
+	serverName  string
+	client      *http.Client
+	queue       chan *event
+
+	// pausedUntil is set when Sentry rate limits us; events are dropped until then
+	pausedUntil time.Time
+}
+
+// NewReporter creates an error reporter sending to the Sentry project of the
+// DSN on a background goroutine; a request is given up after timeout
+func NewReporter(cfg Config, timeout time.Duration) (ports.ErrorReporter, error) {
+	dsn, err := url.Parse(cfg.DSN)
+	if err != nil || (dsn.Scheme != "https" && dsn.Scheme != "http") || dsn.Host == "" || dsn.User == nil {
+		return nil, errors.New("invalid Sentry DSN")
+	}
+	path, projectID, _ := cutLast(strings.TrimSuffix(dsn.Path, "/"), "/")
+	if projectID == "" || dsn.User.Username() == "" {
+		return nil, errors.New("invalid Sentry DSN")
+	}
+
+	auth := "Sentry sentry_version=7, sentry_client=my-backend/1.0, sentry_key=" + dsn.User.Username()
+	if secret, ok := dsn.User.Password(); ok {
+		auth += ", sentry_secret=" + secret
+	}
+	release := cfg.Release
+	if release == "" {
+		release = buildRevision()
+	}
+	serverName, _ := os.Hostname()
+
+	r := &reporter{
+		endpoint:    dsn.Scheme + "://" + dsn.Host + path + "/api/" + projectID + "/envelope/",
+		auth:        auth,
+		environment: cfg.Environment,
+		release:     release,
+		serverName:  serverName,
+		client:      &http.Client{Timeout: timeout},
+		queue:       make(chan *event, reportQueueSize),
+	}
+	go r.send()
+	return r, nil
+}
+
+// cutLast slices s around the last instance of sep
+func cutLast(s, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return "", s, false
+}
+
+// buildRevision returns the VCS revision the binary was built from, or "" if
+// it wasn't built from a checkout
+func buildRevision() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	for _, setting := range info.Settings {
+		if setting.Key == "vcs.revision" {
+			return setting.Value
+		}
+	}
+	return ""
+}
+
+// event is a Sentry event, as sent in an envelope
+type event struct {
+	EventID     string                    `json:"event_id"`
+	Timestamp   time.Time                 `json:"timestamp"`
+	Platform    string                    `json:"platform"`
+	Level       string                    `json:"level"`
+	Logger      string                    `json:"logger"`
+	Release     string                    `json:"release,omitempty"`
+	Environment string                    `json:"environment,omitempty"`
+	ServerName  string                    `json:"server_name,omitempty"`
+	Exception   eventExceptions           `json:"exception"`
+	Request     *eventRequest             `json:"request,omitempty"`
+	User        *eventUser                `json:"user,omitempty"`
+	Tags        map[string]string         `json:"tags,omitempty"`
+	Breadcrumbs *eventBreadcrumbs         `json:"breadcrumbs,omitempty"`
+	Contexts    map[string]runtimeContext `json:"contexts"`
+}
+
+// eventExceptions lists the exceptions of an event; ours have one
+type eventExceptions struct {
+	Values []eventException `json:"values"`
+}
+
+// eventException is an error with where it was caught
+type eventException struct {
+	Type       string           `json:"type"`
+	Value      string           `json:"value"`
+	Mechanism  eventMechanism   `json:"mechanism"`
+	Stacktrace *eventStacktrace `json:"stacktrace,omitempty"`
+}
+
+// eventMechanism tells Sentry how an error was caught; unhandled errors are
+// the panics
+type eventMechanism struct {
+	Type    string `json:"type"`
+	Handled bool   `json:"handled"`
+}
+
+// eventStacktrace lists stack frames, outermost first
+type eventStacktrace struct {
+	Frames []eventFrame `json:"frames"`
+}
+
+// eventFrame is one function call of a stack
+type eventFrame struct {
+	Function string `json:"function"`
+	Module   string `json:"module,omitempty"`
+	Filename string `json:"filename"`
+	AbsPath  string `json:"abs_path"`
+	Lineno   int    `json:"lineno"`
+	InApp    bool   `json:"in_app"`
+}
+
+// eventRequest is the HTTP request of an event
+type eventRequest struct {
+	Method      string            `json:"method"`
+	URL         string            `json:"url"`
+	QueryString string            `json:"query_string,omitempty"`
+	Headers     map[string]string `json:"headers,omitempty"`
+	Env         map[string]string `json:"env,omitempty"`
+}
+
+// eventUser is the signed-in user of an event
+type eventUser struct {
+	ID        string `json:"id,omitempty"`
+	IPAddress string `json:"ip_address,omitempty"`
+}
+
+// eventBreadcrumbs lists the breadcrumbs of an event, oldest first
+type eventBreadcrumbs struct {
+	Values []eventBreadcrumb `json:"values"`
+}
+
+// eventBreadcrumb is one step taken before the error
+type eventBreadcrumb struct {
+	Timestamp time.Time `json:"timestamp"`
+	Category  string    `json:"category"`
+	Message   string    `json:"message"`
+	Level     string    `json:"level"`
+}
+
+// runtimeContext is the runtime context of an event
+type runtimeContext struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// Capture turns the report into an event and queues it, dropping it when the
+// queue is full
+func (r *reporter) Capture(ctx context.Context, report *domain.ErrorReport) {
+	if report.Err == nil {
+		return
+	}
+	id, err := utils.GenerateRandomToken(16)
+	if err != nil {
+		return
+	}
+	e := r.newEvent(ctx, id, report)
+	select {
+	case r.queue <- e:
+	default:
+		log.Printf("Sentry queue full, dropping event %s", e.EventID)
+	}
+}
+
+// newEvent builds the event of a report
+func (r *reporter) newEvent(ctx context.Context, id string, report *domain.ErrorReport) *event {
+	e := &event{
+		EventID:     id,
+		Timestamp:   report.Time.UTC(),
+		Platform:    "go",
+		Level:       report.Level,
+		Logger:      "my-backend",
+		Release:     r.release,
+		Environment: r.environment,
+		ServerName:  r.serverName,
+		Tags:        make(map[string]string, len(report.Tags)+1),
+		Contexts:    map[string]runtimeContext{"runtime": {Name: "go", Version: runtime.Version()}},
+	}
+	if e.Timestamp.IsZero() {
+		e.Timestamp = time.Now().UTC()
+	}
+	if e.Level == "" {
+		e.Level = domain.ErrorLevelError
+	}
+
+	exception := eventException{
+		Type:      fmt.Sprintf("%T", report.Err),
+		Value:     report.Err.Error(),
+		Mechanism: eventMechanism{Type: "generic", Handled: !report.Panic},
+	}
+	if report.Panic {
+		exception.Type = "panic"
+		exception.Mechanism.Type = "recover"
+	}
+	if frames := stackFrames(report.Stack); len(frames) > 0 {
+		exception.Stacktrace = &eventStacktrace{Frames: frames}
+	}
+	e.Exception.Values = []eventException{exception}
+
+	for key, value := range report.Tags {
+		e.Tags[key] = value
+	}
+	if requestID := utils.RequestIDFromContext(ctx); requestID != "" {
+		e.Tags["request_id"] = requestID
+	}
+
+	if req := report.Request; req != nil {
+		e.Request = &eventRequest{
+			Method:      req.Method,
+			URL:         req.URL,
+			QueryString: req.QueryString,
+			Headers:     req.Headers,
+		}
+		if req.ClientIP != "" {
+			e.Request.Env = map[string]string{"REMOTE_ADDR": req.ClientIP}
+		}
+	}
+	if report.UserID != nil || (report.Request != nil && report.Request.ClientIP != "") {
+		e.User = &eventUser{}
+		if report.UserID != nil {
+			e.User.ID = strconv.FormatUint(uint64(*report.UserID), 10)
+		}
+		if report.Request != nil {
+			e.User.IPAddress = report.Request.ClientIP
+		}
+	}
+
+	if crumbs := utils.BreadcrumbsFromContext(ctx); len(crumbs) > 0 {
+		e.Breadcrumbs = &eventBreadcrumbs{}
+		for _, crumb := range crumbs {
+			e.Breadcrumbs.Values = append(e.Breadcrumbs.Values, eventBreadcrumb{
+				Timestamp: crumb.Time.UTC(),
+				Category:  crumb.Category,
+				Message:   crumb.Message,
+				Level:     "info",
+			})
+		}
+	}
+	return e
+}
+
+// stackFrames resolves program counters into Sentry frames, outermost first,
+// leaving out the frames of the Go runtime
+func stackFrames(pcs []uintptr) []eventFrame {
+	if len(pcs) == 0 {
+		return nil
+	}
+	var frames []eventFrame
+	callers := runtime.CallersFrames(pcs)
+	for {
+		frame, more := callers.Next()
+		if frame.Function != "" && !strings.HasPrefix(frame.Function, "runtime.") {
+			module, function := splitFunction(frame.Function)
+			inApp := strings.HasPrefix(module, modulePath)
+			filename := frame.File
+			if inApp {
+				filename = trimModulePath(filename)
+			}
+			frames = append(frames, eventFrame{
+				Function: function,
+				Module:   module,
+				Filename: filename,
+				AbsPath:  frame.File,
+				Lineno:   frame.Line,
+				InApp:    inApp,
+			})
+		}
+		if !more {
+			break
+		}
+	}
+	for i, j := 0, len(frames)-1; i < j; i, j = i+1, j-1 {
+		frames[i], frames[j] = frames[j], frames[i]
+	}
+	return frames
+}
+
+// splitFunction splits a qualified function name, such as
+// "github.com/a/b/pkg.(*T).Method", into its package and its name
+func splitFunction(name string) (module, function string) {
+	slash := strings.LastIndex(name, "/")
+	dot := strings.Index(name[slash+1:], ".")
+	if dot < 0 {
+		return "", name
+	}
+	dot += slash + 1
+	return name[:dot], name[dot+1:]
+}
+
+// trimModulePath makes the files of our own code relative to the repository
+func trimModulePath(file string) string {
+	for _, dir := range []string{"/internal/", "/cmd/", "/pkg/"} {
+		if i := strings.LastIndex(file, dir); i >= 0 {
+			return file[i+1:]
+		}
+	}
+	return file
+}
+
+// send posts queued events one at a time
+func (r *reporter) send() {
+	for e := range r.queue {
+		if time.Now().Before(r.pausedUntil) {
+			continue
+		}
+		if err := r.post(e); err != nil {
+			log.Printf("Failed to send event %s to Sentry: %v", e.EventID, err)
+		}
+	}
+}
+
+// post sends an event in an envelope
+func (r *reporter) post(e *event) error {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return errors.New("failed to encode event")
+	}
+	header, err := json.Marshal(map[string]string{
+		"event_id": e.EventID,
+		"sent_at":  time.Now().UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return errors.New("failed to encode event")
+	}
+
+	var envelope bytes.Buffer
+	envelope.Write(header)
+	fmt.Fprintf(&envelope, "\n{\"type\":\"event\",\"length\":%d}\n", len(payload))
+	envelope.Write(payload)
+	envelope.WriteString("\n")
+
+	req, err := http.NewRequest(http.MethodPost, r.endpoint, &envelope)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-sentry-envelope")
+	req.Header.Set("X-Sentry-Auth", r.auth)
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+		if err != nil || seconds <= 0 {
+			seconds = 60
+		}
+		r.pausedUntil = time.Now().Add(time.Duration(seconds) * time.Second)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("Sentry responded with status %d: %s", resp.StatusCode, bytes.TrimSpace(detail))
+	}
+	io.Copy(io.Discard, resp.Body)
+	return nil
+}
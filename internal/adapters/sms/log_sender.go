@@ -0,0 +1,25 @@
+package sms
+
+import (
+	"context"
+	"log"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// logSender implements the SMSSender interface by logging messages, for
+// development setups without an SMS provider
+type logSender struct{}
+
+// NewLogSender creates an SMS sender that only logs
+func NewLogSender() ports.SMSSender {
+	return &logSender{}
+}
+
+// Send logs the recipient and text of the message, so codes can be entered
+// in development
+func (s *logSender) Send(ctx context.Context, msg *domain.SMSMessage) error {
+	log.Printf("sms to %s: %s", msg.To, msg.Body)
+	return nil
+}
@@ -0,0 +1,55 @@
+package sms
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/config"
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// NewSender creates the SMS sender selected by SMS_DRIVER
+func NewSender(cfg *config.Config) (ports.SMSSender, error) {
+	switch cfg.SMSDriver {
+	case "log":
+		return NewLogSender(), nil
+	case "twilio":
+		if cfg.TwilioAccountSID == "" || cfg.TwilioAuthToken == "" {
+			return nil, errors.New("TWILIO_ACCOUNT_SID and TWILIO_AUTH_TOKEN are required")
+		}
+		if cfg.SMSFrom == "" {
+			return nil, errors.New("SMS_FROM is required")
+		}
+		return NewTwilioSender(cfg.TwilioAccountSID, cfg.TwilioAuthToken, cfg.SMSFrom, 10*time.Second), nil
+	case "vonage":
+		if cfg.VonageAPIKey == "" || cfg.VonageAPISecret == "" {
+			return nil, errors.New("VONAGE_API_KEY and VONAGE_API_SECRET are required")
+		}
+		if cfg.SMSFrom == "" {
+			return nil, errors.New("SMS_FROM is required")
+		}
+		return NewVonageSender(cfg.VonageAPIKey, cfg.VonageAPISecret, cfg.SMSFrom, 10*time.Second), nil
+	default:
+		return nil, errors.New("unsupported SMS_DRIVER " + cfg.SMSDriver + " (use log, twilio or vonage)")
+	}
+}
+
+// checkAPIResponse fails on a non-2xx response of an SMS API. Client errors
+// other than throttling are rejections; the rest are transient.
+func checkAPIResponse(provider string, resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+		return nil
+	}
+
+	detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	err := fmt.Errorf("%s responded with status %d: %s", provider, resp.StatusCode, bytes.TrimSpace(detail))
+	if resp.StatusCode >= 400 && resp.StatusCode <= 499 && resp.StatusCode != http.StatusTooManyRequests {
+		return fmt.Errorf("%w: %v", domain.ErrSMSRejected, err)
+	}
+	return err
+}
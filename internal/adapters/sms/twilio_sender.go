@@ -0,0 +1,69 @@
+package sms
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// twilioBaseURL is the Twilio REST API the Messages resource is under
+const twilioBaseURL = "https://api.twilio.com/2010-04-01/Accounts/"
+
+// twilioSender implements the SMSSender interface on the Twilio Messages API
+type twilioSender struct {
+	accountSID string
+	authToken  string
+	from       string
+	client     *http.Client
+}
+
+// NewTwilioSender creates an SMS sender posting to Twilio with the account's
+// SID and auth token. from is a Twilio number, or a messaging service SID
+// (MG...) that picks the number itself.
+func NewTwilioSender(accountSID, authToken, from string, timeout time.Duration) ports.SMSSender {
+	return &twilioSender{
+		accountSID: accountSID,
+		authToken:  authToken,
+		from:       from,
+		client:     &http.Client{Timeout: timeout},
+	}
+}
+
+// Send posts the message to Twilio
+func (s *twilioSender) Send(ctx context.Context, msg *domain.SMSMessage) error {
+	form := url.Values{
+		"To":   {msg.To},
+		"Body": {msg.Body},
+	}
+	if strings.HasPrefix(s.from, "MG") {
+		form.Set("MessagingServiceSid", s.from)
+	} else {
+		form.Set("From", s.from)
+	}
+
+	endpoint := twilioBaseURL + url.PathEscape(s.accountSID) + "/Messages.json"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(s.accountSID, s.authToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := checkAPIResponse("Twilio", resp); err != nil {
+		return err
+	}
+	io.Copy(io.Discard, resp.Body)
+	return nil
+}
@@ -0,0 +1,94 @@
+package sms
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// vonageURL is the Vonage SMS API endpoint
+const vonageURL = "https://rest.nexmo.com/sms/json"
+
+// vonageTransientStatuses are the message statuses of Vonage worth retrying:
+// throttled and internal error. Every other failure is a rejection.
+var vonageTransientStatuses = map[string]bool{"1": true, "5": true}
+
+// vonageSender implements the SMSSender interface on the Vonage SMS API
+type vonageSender struct {
+	apiKey    string
+	apiSecret string
+	from      string
+	client    *http.Client
+}
+
+// NewVonageSender creates an SMS sender posting to Vonage with the API key
+// and secret. from is a Vonage number or an alphanumeric sender ID.
+func NewVonageSender(apiKey, apiSecret, from string, timeout time.Duration) ports.SMSSender {
+	return &vonageSender{
+		apiKey:    apiKey,
+		apiSecret: apiSecret,
+		from:      strings.TrimPrefix(from, "+"),
+		client:    &http.Client{Timeout: timeout},
+	}
+}
+
+// vonageResponse is the body Vonage answers a send with; it reports each
+// part of a long message apart
+type vonageResponse struct {
+	Messages []struct {
+		Status    string `json:"status"`
+		ErrorText string `json:"error-text"`
+	} `json:"messages"`
+}
+
+// Send posts the message to Vonage. Vonage answers 200 whether or not the
+// message was accepted, so the status of each part is checked.
+func (s *vonageSender) Send(ctx context.Context, msg *domain.SMSMessage) error {
+	form := url.Values{
+		"api_key":    {s.apiKey},
+		"api_secret": {s.apiSecret},
+		"from":       {s.from},
+		"to":         {strings.TrimPrefix(msg.To, "+")},
+		"text":       {msg.Body},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, vonageURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := checkAPIResponse("Vonage", resp); err != nil {
+		return err
+	}
+
+	var result vonageResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return errors.New("invalid Vonage response")
+	}
+	for _, part := range result.Messages {
+		if part.Status == "0" {
+			continue
+		}
+		err := fmt.Errorf("Vonage answered with message status %s: %s", part.Status, part.ErrorText)
+		if vonageTransientStatuses[part.Status] {
+			return err
+		}
+		return fmt.Errorf("%w: %v", domain.ErrSMSRejected, err)
+	}
+	return nil
+}
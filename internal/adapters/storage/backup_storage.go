@@ -0,0 +1,25 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/config"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// NewBackupStorage creates the storage database backups are kept in: a local
+// directory that is never served, or a private S3 bucket
+func NewBackupStorage(cfg *config.Config) (ports.FileStorage, error) {
+	if cfg.BackupStorageDriver != "s3" {
+		return NewLocalStorage(cfg.BackupDir, ""), nil
+	}
+
+	// Dumps of large databases take a while to transfer
+	return NewS3Storage(S3Config{
+		Endpoint:        cfg.S3Endpoint,
+		Region:          cfg.S3Region,
+		Bucket:          cfg.BackupS3Bucket,
+		AccessKeyID:     cfg.S3AccessKeyID,
+		SecretAccessKey: cfg.S3SecretAccessKey,
+	}, time.Hour)
+}
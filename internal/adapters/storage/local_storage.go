@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// localStorage implements the FileStorage interface on the local filesystem
+type localStorage struct {
+	dir     string
+	baseURL string
+}
+
+// NewLocalStorage creates a storage writing under dir, with files served from baseURL
+func NewLocalStorage(dir, baseURL string) ports.FileStorage {
+	return &localStorage{
+		dir:     dir,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+// cleanKey normalizes a key to a slash-separated path that cannot escape the storage directory
+func cleanKey(key string) (string, error) {
+	clean := strings.TrimPrefix(path.Clean("/"+key), "/")
+	if clean == "" {
+		return "", errors.New("invalid storage key")
+	}
+	return clean, nil
+}
+
+// Save streams the file to a temporary file next to its destination and
+// renames it into place, so readers never see a partly written file
+func (s *localStorage) Save(key string, r io.Reader) (string, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	file := filepath.Join(s.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return "", errors.New("failed to store file")
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(file), ".upload-*")
+	if err != nil {
+		return "", errors.New("failed to store file")
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = io.Copy(tmp, r)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), 0o644)
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), file)
+	}
+	if err != nil {
+		return "", errors.New("failed to store file")
+	}
+	return s.baseURL + "/" + key, nil
+}
+
+// Open opens the file for reading
+func (s *localStorage) Open(key string) (io.ReadCloser, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(filepath.Join(s.dir, filepath.FromSlash(key)))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, errors.New("file not found")
+	}
+	if err != nil {
+		return nil, errors.New("failed to open file")
+	}
+	return file, nil
+}
+
+// Delete removes the file; deleting a missing file is not an error
+func (s *localStorage) Delete(key string) error {
+	key, err := cleanKey(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(filepath.Join(s.dir, filepath.FromSlash(key))); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return errors.New("failed to delete file")
+	}
+	return nil
+}
@@ -0,0 +1,319 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// s3UnsignedPayload lets uploads stream without hashing the body first
+const s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+
+// S3Config configures an S3-compatible bucket (AWS S3, MinIO, R2, ...)
+type S3Config struct {
+	Endpoint        string // e.g. https://s3.ap-southeast-1.amazonaws.com
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// PublicBaseURL is where stored files are served from, e.g. a CDN in front of the bucket
+	PublicBaseURL string
+}
+
+// s3Storage implements the FileStorage interface on an S3-compatible bucket,
+// addressing objects path-style and signing requests with AWS Signature Version 4
+type s3Storage struct {
+	cfg      S3Config
+	endpoint *url.URL
+	client   *http.Client
+}
+
+// NewS3Storage creates a storage writing to an S3-compatible bucket
+func NewS3Storage(cfg S3Config, timeout time.Duration) (ports.FileStorage, error) {
+	endpoint, err := url.Parse(strings.TrimSuffix(cfg.Endpoint, "/"))
+	if err != nil || endpoint.Host == "" {
+		return nil, errors.New("invalid S3 endpoint")
+	}
+	if cfg.Bucket == "" || cfg.Region == "" {
+		return nil, errors.New("S3 bucket and region are required")
+	}
+	cfg.PublicBaseURL = strings.TrimSuffix(cfg.PublicBaseURL, "/")
+
+	return &s3Storage{
+		cfg:      cfg,
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// Save streams the file to the bucket. S3 needs the object size up front, so
+// readers that can't seek (to measure themselves) are buffered in memory.
+func (s *s3Storage) Save(key string, r io.Reader) (string, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	size, err := readerSize(r)
+	if err != nil {
+		var buf bytes.Buffer
+		if _, err := io.Copy(&buf, r); err != nil {
+			return "", errors.New("failed to store file")
+		}
+		r, size = &buf, int64(buf.Len())
+	}
+
+	// The caller owns r, so the HTTP client must not close it
+	req, err := s.newRequest(http.MethodPut, key, io.NopCloser(r))
+	if err != nil {
+		return "", errors.New("failed to store file")
+	}
+	req.ContentLength = size
+	if size == 0 {
+		req.Body = http.NoBody
+	}
+	if contentType := mime.TypeByExtension(path.Ext(key)); contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	if err := s.do(req); err != nil {
+		return "", errors.New("failed to store file")
+	}
+	return s.cfg.PublicBaseURL + "/" + key, nil
+}
+
+// Open streams the object from the bucket
+func (s *s3Storage) Open(key string) (io.ReadCloser, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := s.newRequest(http.MethodGet, key, nil)
+	if err != nil {
+		return nil, errors.New("failed to open file")
+	}
+	s.sign(req, time.Now().UTC())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, errors.New("failed to open file")
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, errors.New("file not found")
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		resp.Body.Close()
+		return nil, errors.New("failed to open file")
+	}
+	return resp.Body, nil
+}
+
+// Delete removes the object; S3 reports success for missing objects too
+func (s *s3Storage) Delete(key string) error {
+	key, err := cleanKey(key)
+	if err != nil {
+		return err
+	}
+
+	req, err := s.newRequest(http.MethodDelete, key, nil)
+	if err != nil {
+		return errors.New("failed to delete file")
+	}
+	if err := s.do(req); err != nil {
+		return errors.New("failed to delete file")
+	}
+	return nil
+}
+
+// newRequest builds a request for the object at key
+func (s *s3Storage) newRequest(method, key string, body io.Reader) (*http.Request, error) {
+	u := *s.endpoint
+	u.Path = s.endpoint.Path + "/" + s.cfg.Bucket + "/" + key
+	u.RawPath = s.endpoint.Path + "/" + s3Escape(s.cfg.Bucket) + "/" + s3Escape(key)
+	return http.NewRequest(method, u.String(), body)
+}
+
+// do signs and sends the request, failing on any non-2xx status
+func (s *s3Storage) do(req *http.Request) error {
+	s.sign(req, time.Now().UTC())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.New("unexpected status " + resp.Status)
+	}
+	return nil
+}
+
+// sign adds AWS Signature Version 4 headers to the request
+func (s *s3Storage) sign(req *http.Request, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	scope := date + "/" + s.cfg.Region + "/s3/aws4_request"
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", s3UnsignedPayload)
+
+	const signedHeaders = "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		"host:" + req.URL.Host,
+		"x-amz-content-sha256:" + s3UnsignedPayload,
+		"x-amz-date:" + amzDate,
+		"",
+		signedHeaders,
+		s3UnsignedPayload,
+	}, "\n")
+	canonicalHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(canonicalHash[:])
+
+	signature := hex.EncodeToString(hmacSHA256(s.signingKey(date), stringToSign))
+
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+s.cfg.AccessKeyID+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+// PresignPut returns a URL that stores one file under key without
+// credentials until it expires. The upload must send exactly size bytes
+// with the given Content-Type, since both are signed.
+func (s *s3Storage) PresignPut(key, contentType string, size int64, expires time.Duration) (string, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := s.newRequest(http.MethodPut, key, nil)
+	if err != nil {
+		return "", errors.New("failed to presign upload")
+	}
+	headers := map[string]string{
+		"content-length": strconv.FormatInt(size, 10),
+		"content-type":   contentType,
+	}
+	return s.presign(req.Method, req.URL, headers, expires, time.Now().UTC()), nil
+}
+
+// presign signs a request to u in its query string (Signature Version 4
+// query parameters), with the given headers and host signed
+func (s *s3Storage) presign(method string, u *url.URL, headers map[string]string, expires time.Duration, now time.Time) string {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	scope := date + "/" + s.cfg.Region + "/s3/aws4_request"
+
+	names := []string{"host"}
+	values := map[string]string{"host": u.Host}
+	for name, value := range headers {
+		names = append(names, name)
+		values[name] = value
+	}
+	slices.Sort(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(values[name]) + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	query := url.Values{}
+	query.Set("X-Amz-Algorithm", "AWS4-HMAC-SHA256")
+	query.Set("X-Amz-Credential", s.cfg.AccessKeyID+"/"+scope)
+	query.Set("X-Amz-Date", amzDate)
+	query.Set("X-Amz-Expires", strconv.FormatInt(int64(expires/time.Second), 10))
+	query.Set("X-Amz-SignedHeaders", signedHeaders)
+	// Encode sorts by key, as the canonical query string must be; only
+	// spaces would be escaped differently, and none of the values have one
+	canonicalQuery := query.Encode()
+
+	canonicalRequest := strings.Join([]string{
+		method,
+		u.EscapedPath(),
+		canonicalQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		s3UnsignedPayload,
+	}, "\n")
+	canonicalHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(canonicalHash[:])
+	signature := hex.EncodeToString(hmacSHA256(s.signingKey(date), stringToSign))
+
+	presigned := *u
+	presigned.RawQuery = canonicalQuery + "&X-Amz-Signature=" + signature
+	return presigned.String()
+}
+
+// URL returns the public URL of the file stored under key
+func (s *s3Storage) URL(key string) string {
+	key, _ = cleanKey(key)
+	return s.cfg.PublicBaseURL + "/" + key
+}
+
+// signingKey derives the Signature Version 4 key for a day
+func (s *s3Storage) signingKey(date string) []byte {
+	key := hmacSHA256([]byte("AWS4"+s.cfg.SecretAccessKey), date)
+	key = hmacSHA256(key, s.cfg.Region)
+	key = hmacSHA256(key, "s3")
+	return hmacSHA256(key, "aws4_request")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// s3Escape percent-encodes a path the way Signature Version 4 expects: every
+// byte except unreserved characters and the slashes between segments
+func s3Escape(p string) string {
+	var b strings.Builder
+	for i := 0; i < len(p); i++ {
+		c := p[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' ||
+			c == '-' || c == '_' || c == '.' || c == '~' || c == '/' {
+			b.WriteByte(c)
+			continue
+		}
+		b.WriteString("%" + strings.ToUpper(hex.EncodeToString([]byte{c})))
+	}
+	return b.String()
+}
+
+// readerSize returns the number of bytes left in a seekable reader
+func readerSize(r io.Reader) (int64, error) {
+	seeker, ok := r.(io.Seeker)
+	if !ok {
+		return 0, errors.New("reader is not seekable")
+	}
+	current, err := seeker.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0, err
+	}
+	end, err := seeker.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := seeker.Seek(current, io.SeekStart); err != nil {
+		return 0, err
+	}
+	return end - current, nil
+}
@@ -0,0 +1,78 @@
+package webhook
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/internal/utils"
+)
+
+// httpSender implements the WebhookSender interface over HTTP
+type httpSender struct {
+	client *http.Client
+}
+
+// NewHTTPSender creates a new HTTP webhook sender. It only connects to public
+// addresses, checked when dialing, so neither a URL registered before the
+// check existed nor a name that later resolves to an internal address, nor a
+// redirect, can reach internal services. Requests don't go through a proxy,
+// which would dial on the sender's behalf.
+func NewHTTPSender(timeout time.Duration) ports.WebhookSender {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: dialPublicOnly,
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &httpSender{
+		client: &http.Client{Timeout: timeout, Transport: transport},
+	}
+}
+
+// dialPublicOnly refuses connections to addresses that aren't public, once
+// the host has been resolved
+func dialPublicOnly(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || !utils.IsPublicIP(ip) {
+		return errors.New("webhook endpoint " + host + " is not a public address")
+	}
+	return nil
+}
+
+// Send posts the payload signed with the secret and returns the response status code
+func (s *httpSender) Send(url, secret string, headers map[string]string, payload []byte) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "my-backend-webhooks/1.0")
+	req.Header.Set("X-Webhook-Signature", "sha256="+utils.SignPayload(secret, payload))
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("webhook endpoint responded with status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
@@ -1,15 +1,27 @@
 package config
 
 import (
+	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
 
 // Config holds all configuration for the application
 type Config struct {
+	// AppEnv is production or development; development turns on the email
+	// template previews and reloads the templates on every email
+	AppEnv string
+	// AppBaseURL is the public URL of the API, which links in emails point to
+	AppBaseURL string
+
 	Port             string
+	GRPCPort         string
+	DBDriver         string
+	DBSQLitePath     string
 	DBHost           string
 	DBPort           string
 	DBUser           string
@@ -17,40 +29,518 @@ type Config struct {
 	DBName           string
 	DBSSLMode        string
 	DBChannelBinding string
+	DBReplicaHosts   []string
 	JWTSecret        string
+
+	// AuthMode is how clients send their JWT: "header" (Authorization header),
+	// "cookie" (HttpOnly cookie set at login, for browsers) or "both"
+	AuthMode string
+
+	// Cookies the API sets, such as the auth cookie and CSRF tokens, are
+	// Secure (HTTPS only) unless CookieSecure is false, for local development
+	// over HTTP. CookieSameSite is Lax, Strict or None (for a frontend on
+	// another site).
+	CookieSecure   bool
+	CookieSameSite string
+
+	// Sensitive columns are encrypted with AES-GCM under EncryptionKeyID (the
+	// first key when empty). EncryptionKeys holds "<id>:<base64 key>" entries;
+	// retired keys stay listed until cmd/reencrypt has rewritten their values.
+	EncryptionKeys  []string
+	EncryptionKeyID string
+
+	// Connection pool of the primary and each replica. Idle connections are
+	// closed after DBConnMaxIdleTimeSeconds and all of them are recycled after
+	// DBConnMaxLifetimeSeconds; pool stats are logged every
+	// DBPoolStatsLogSeconds (0 = off).
+	DBMaxOpenConns           int64
+	DBMaxIdleConns           int64
+	DBConnMaxLifetimeSeconds int64
+	DBConnMaxIdleTimeSeconds int64
+	DBPoolStatsLogSeconds    int64
+
+	// Each database statement may run for DBReadTimeoutSeconds (queries),
+	// DBWriteTimeoutSeconds (inserts, updates, deletes) or
+	// DBReportTimeoutSeconds (aggregations and exports); 0 = no limit
+	DBReadTimeoutSeconds   int64
+	DBWriteTimeoutSeconds  int64
+	DBReportTimeoutSeconds int64
+
+	// Statements taking DBSlowQueryMs or longer (0 = off) are logged with their
+	// SQL; DBLogQueries logs every statement. Query metrics are served at
+	// /metrics unless MetricsEnabled is false.
+	DBSlowQueryMs  int64
+	DBLogQueries   bool
+	MetricsEnabled bool
+
+	// Requests are given RequestTimeoutSeconds (0 = no limit) before their
+	// database queries are cancelled
+	RequestTimeoutSeconds int64
+
+	// Imports and seeding insert records BulkInsertBatchSize at a time
+	BulkInsertBatchSize int64
+
+	// Domain events are recorded in an outbox and relayed to webhooks and
+	// event streams every OutboxRelayIntervalMs (0 = this instance doesn't
+	// relay), OutboxBatchSize at a time; published events are kept for
+	// OutboxRetentionDays
+	OutboxRelayIntervalMs int64
+	OutboxBatchSize       int64
+	OutboxRetentionDays   int64
+
+	// Background jobs (email, webhook deliveries, thumbnails, exports) run on
+	// JobWorkers workers per server (0 = only -worker processes run them),
+	// which look for due jobs every JobPollIntervalMs. A job running longer
+	// than JobTimeoutSeconds is given up and retried; succeeded jobs are kept
+	// for JobRetentionDays, dead ones until an admin discards them.
+	JobWorkers        int64
+	JobPollIntervalMs int64
+	JobTimeoutSeconds int64
+	JobRetentionDays  int64
+
+	// Mangas and users soft deleted more than PurgeRetentionDays ago are
+	// deleted for good every PurgeIntervalMinutes (0 = off), at most
+	// PurgeBatchSize of each per run. With PurgeDryRun the scheduled purge
+	// only logs what it would delete.
+	PurgeRetentionDays   int64
+	PurgeIntervalMinutes int64
+	PurgeBatchSize       int64
+	PurgeDryRun          bool
+
+	// Fulfilled and cancelled orders and daily view counts older than
+	// ArchiveAfterMonths are moved to archive tables every
+	// ArchiveIntervalMinutes (0 = off), at most ArchiveBatchSize of each per run
+	ArchiveAfterMonths     int64
+	ArchiveIntervalMinutes int64
+	ArchiveBatchSize       int64
+
+	// Due seller report emails are queued every SellerReportIntervalMinutes (0 = off)
+	SellerReportIntervalMinutes int64
+
+	// Due daily and weekly digests are made every DigestIntervalMinutes (0 = off)
+	DigestIntervalMinutes int64
+
+	// The admin dashboard's figures are computed at most once every
+	// DashboardCacheSeconds (0 = on every request)
+	DashboardCacheSeconds int64
+
+	// Per-user request quotas by role (0 = unlimited)
+	QuotaUserDaily    int64
+	QuotaUserMonthly  int64
+	QuotaAdminDaily   int64
+	QuotaAdminMonthly int64
+
+	// Requests per minute allowed by the rate limiter (0 = unlimited); the auth
+	// limit applies to login and registration. Counters live in memory unless
+	// RateLimitStore is "redis", which shares them between instances.
+	RateLimitAnonymous     int64
+	RateLimitAuthenticated int64
+	RateLimitAuth          int64
+	RateLimitStore         string
+	RedisURL               string
+
+	// RouteLimits throttle groups of routes further, each entry a route and
+	// its limit: "/api/v1/auth/*=10/1m per ip" (requests per window) or
+	// "GET /api/v1/mangas/export=2 concurrent per user" (requests at a time).
+	// They share RateLimitStore with the limits above.
+	RouteLimits []string
+
+	// Requests per minute an API key may make unless it has a lower limit of
+	// its own; keys may not be given a higher one (0 = unlimited)
+	APIKeyRateLimit int64
+
+	// Public manga GET responses are cached for ResponseCacheTTLSeconds (0 = off),
+	// in memory unless ResponseCacheStore is "redis"
+	ResponseCacheTTLSeconds int64
+	ResponseCacheStore      string
+
+	// JSON responses of at least CompressionMinSize bytes are compressed;
+	// CompressionLevel is disabled, best_speed, default or best_compression
+	CompressionLevel   string
+	CompressionMinSize int64
+
+	// Outgoing email is sent from EmailFrom through EmailDriver: log, file
+	// (.eml files in EmailDir), smtp, sendgrid or ses. An empty driver selects
+	// smtp when SMTPHost is set and log otherwise.
+	EmailDriver string
+	EmailFrom   string
+	EmailDir    string
+
+	// Emails are rendered from the templates in EmailTemplateDir, or from
+	// the ones built into the binary when it is empty
+	EmailTemplateDir string
+
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+
+	SendGridAPIKey string
+
+	SESRegion          string
+	SESAccessKeyID     string
+	SESSecretAccessKey string
+
+	// Text messages are sent from SMSFrom through SMSDriver: log, twilio or
+	// vonage. Each user is texted at most SMSCodesPerHour codes an hour, and
+	// each number at most SMSPerNumberDaily texts a day (0 = unlimited).
+	SMSDriver         string
+	SMSFrom           string
+	SMSCodesPerHour   int64
+	SMSPerNumberDaily int64
+
+	TwilioAccountSID string
+	TwilioAuthToken  string
+
+	VonageAPIKey    string
+	VonageAPISecret string
+
+	// Users are notified on LINE through the official account of the
+	// Messaging API channel with LineChannelAccessToken and LineChannelSecret;
+	// LINE is off while the token is empty. LineLinkURL is the frontend page
+	// that signs LINE users in to link their account.
+	LineChannelAccessToken string
+	LineChannelSecret      string
+	LineLinkURL            string
+
+	// Request bodies may be up to BodyLimit bytes, multipart uploads (images,
+	// imports) up to UploadLimit bytes; larger uploads are spooled to disk
+	BodyLimit   int64
+	UploadLimit int64
+
+	// Uploaded files are stored under UploadDir and served from UploadBaseURL,
+	// or in an S3-compatible bucket when StorageDriver is "s3". With S3,
+	// clients may upload gallery images straight to the bucket on presigned
+	// URLs valid for UploadPresignExpirySeconds.
+	StorageDriver     string
+	UploadDir         string
+	UploadBaseURL     string
+	S3Endpoint        string
+	S3Region          string
+	S3Bucket          string
+	S3AccessKeyID     string
+	S3SecretAccessKey string
+	S3PublicBaseURL   string
+
+	UploadPresignExpirySeconds int64
+
+	// Gallery images get WebP and AVIF variants when the cwebp and avifenc
+	// encoders are installed at these paths; empty paths turn a format off
+	ImageWebPEncoderPath string
+	ImageAVIFEncoderPath string
+
+	// Uploaded files are scanned by the ClamAV daemon at ClamAVAddress when
+	// MalwareScanDriver is "clamav", and not at all when it is "none"
+	MalwareScanDriver    string
+	ClamAVAddress        string
+	ClamAVTimeoutSeconds int64
+
+	// Database backups are made with pg_dump and restored with pg_restore (at
+	// PGDumpPath and PGRestorePath) and kept under BackupDir, or in
+	// BackupS3Bucket of the S3 account above when BackupStorageDriver is "s3".
+	// Backups must never be stored where uploads are served from.
+	BackupStorageDriver string
+	BackupDir           string
+	BackupS3Bucket      string
+	PGDumpPath          string
+	PGRestorePath       string
+
+	// Manga search runs on the database, or on the Meilisearch index
+	// MeilisearchIndex at MeilisearchURL when SearchDriver is "meilisearch"
+	SearchDriver      string
+	MeilisearchURL    string
+	MeilisearchAPIKey string
+	MeilisearchIndex  string
+
+	// Domain events are published as CloudEvents to NATS (at NATSURL) or to
+	// Kafka (through the REST proxy at KafkaRESTURL) when EventPublisherDriver
+	// is "nats" or "kafka", and not at all when it is "none". Each event goes
+	// to EventTopicPrefix followed by its name, unless EventTopics maps it or
+	// its group ("order.*") to another topic, or to none.
+	EventPublisherDriver       string
+	EventTopicPrefix           string
+	EventTopics                map[string]string
+	EventPublishTimeoutSeconds int64
+	NATSURL                    string
+	NATSJetStream              bool
+	KafkaRESTURL               string
+
+	// Operational alerts (5xx bursts, dead jobs, failed payment webhooks and
+	// database outages) are posted to the Slack and Discord webhook URLs that
+	// are set. Alerts with the same key are sent once per AlertDedupSeconds,
+	// at most AlertMaxPerMinute a minute; AlertServerErrorThreshold 5xx
+	// responses within AlertServerErrorWindowSeconds make a burst.
+	AlertSlackWebhookURL          string
+	AlertDiscordWebhookURL        string
+	AlertDedupSeconds             int64
+	AlertMaxPerMinute             int64
+	AlertServerErrorThreshold     int64
+	AlertServerErrorWindowSeconds int64
+	AlertDBCheckSeconds           int64
+
+	// Panics and 5xx responses are reported to Sentry when SentryDSN is set,
+	// tagged with SentryRelease (the VCS revision of the build when empty)
+	SentryDSN     string
+	SentryRelease string
+
+	// parseProblems are the settings LoadConfig couldn't parse
+	parseProblems []Problem
 }
 
-// LoadConfig loads configuration from environment variables
+// LoadConfig loads configuration from environment variables. Settings that
+// can't be parsed fall back to their defaults; Validate reports them.
 func LoadConfig() *Config {
 	// Load .env file if it exists (for development)
 	if err := godotenv.Load(); err != nil {
 		log.Println("No .env file found, using environment variables")
 	}
 
+	env := &envReader{}
 	config := &Config{
-		Port:             getEnv("PORT", "8080"),
-		DBHost:           getEnv("DB_HOST", "localhost"),
-		DBPort:           getEnv("DB_PORT", "5432"),
-		DBUser:           getEnv("DB_USER", "postgres"),
-		DBPass:           getEnv("DB_PASS", "password"),
-		DBName:           getEnv("DB_NAME", "mydb"),
-		DBSSLMode:        getEnv("DB_SSL_MODE", "disable"),
-		DBChannelBinding: getEnv("DB_CHANNEL_BINDING", ""),
-		JWTSecret:        getEnv("JWT_SECRET", "your-secret-key"),
-	}
+		AppEnv:     env.getEnv("APP_ENV", "production"),
+		AppBaseURL: env.getEnv("APP_BASE_URL", "http://localhost:8080"),
+
+		Port:             env.getEnv("PORT", "8080"),
+		GRPCPort:         env.getEnv("GRPC_PORT", "9090"),
+		DBDriver:         env.getEnv("DB_DRIVER", "postgres"),
+		DBSQLitePath:     env.getEnv("DB_SQLITE_PATH", "my-backend.db"),
+		DBHost:           env.getEnv("DB_HOST", "localhost"),
+		DBPort:           env.getEnv("DB_PORT", "5432"),
+		DBUser:           env.getEnv("DB_USER", "postgres"),
+		DBPass:           env.getEnv("DB_PASS", "password"),
+		DBName:           env.getEnv("DB_NAME", "mydb"),
+		DBSSLMode:        env.getEnv("DB_SSL_MODE", "disable"),
+		DBChannelBinding: env.getEnv("DB_CHANNEL_BINDING", ""),
+		DBReplicaHosts:   env.getEnvList("DB_REPLICA_HOSTS"),
+		JWTSecret:        env.getEnv("JWT_SECRET", ""),
+
+		AuthMode:       env.getEnv("AUTH_MODE", "header"),
+		CookieSecure:   env.getEnvBool("COOKIE_SECURE", true),
+		CookieSameSite: env.getEnv("COOKIE_SAMESITE", "Lax"),
+
+		EncryptionKeys:  env.getEnvList("ENCRYPTION_KEYS"),
+		EncryptionKeyID: env.getEnv("ENCRYPTION_KEY_ID", ""),
+
+		DBMaxOpenConns:           env.getEnvInt("DB_MAX_OPEN_CONNS", 25),
+		DBMaxIdleConns:           env.getEnvInt("DB_MAX_IDLE_CONNS", 10),
+		DBConnMaxLifetimeSeconds: env.getEnvInt("DB_CONN_MAX_LIFETIME_SECONDS", 1800),
+		DBConnMaxIdleTimeSeconds: env.getEnvInt("DB_CONN_MAX_IDLE_TIME_SECONDS", 300),
+		DBPoolStatsLogSeconds:    env.getEnvInt("DB_POOL_STATS_LOG_SECONDS", 60),
+
+		DBReadTimeoutSeconds:   env.getEnvInt("DB_READ_TIMEOUT_SECONDS", 5),
+		DBWriteTimeoutSeconds:  env.getEnvInt("DB_WRITE_TIMEOUT_SECONDS", 10),
+		DBReportTimeoutSeconds: env.getEnvInt("DB_REPORT_TIMEOUT_SECONDS", 60),
+
+		DBSlowQueryMs:  env.getEnvInt("DB_SLOW_QUERY_MS", 200),
+		DBLogQueries:   env.getEnvBool("DB_LOG_QUERIES", false),
+		MetricsEnabled: env.getEnvBool("METRICS_ENABLED", true),
+
+		RequestTimeoutSeconds: env.getEnvInt("REQUEST_TIMEOUT_SECONDS", 30),
+
+		BulkInsertBatchSize: env.getEnvInt("BULK_INSERT_BATCH_SIZE", 100),
+
+		OutboxRelayIntervalMs: env.getEnvInt("OUTBOX_RELAY_INTERVAL_MS", 500),
+		OutboxBatchSize:       env.getEnvInt("OUTBOX_BATCH_SIZE", 100),
+		OutboxRetentionDays:   env.getEnvInt("OUTBOX_RETENTION_DAYS", 7),
 
-	// Validate required configuration
-	if config.JWTSecret == "your-secret-key" {
-		log.Println("WARNING: Using default JWT secret. Please set JWT_SECRET environment variable in production")
+		JobWorkers:        env.getEnvInt("JOB_WORKERS", 2),
+		JobPollIntervalMs: env.getEnvInt("JOB_POLL_INTERVAL_MS", 1000),
+		JobTimeoutSeconds: env.getEnvInt("JOB_TIMEOUT_SECONDS", 300),
+		JobRetentionDays:  env.getEnvInt("JOB_RETENTION_DAYS", 7),
+
+		PurgeRetentionDays:   env.getEnvInt("PURGE_RETENTION_DAYS", 30),
+		PurgeIntervalMinutes: env.getEnvInt("PURGE_INTERVAL_MINUTES", 60),
+		PurgeBatchSize:       env.getEnvInt("PURGE_BATCH_SIZE", 100),
+		PurgeDryRun:          env.getEnvBool("PURGE_DRY_RUN", false),
+
+		ArchiveAfterMonths:     env.getEnvInt("ARCHIVE_AFTER_MONTHS", 12),
+		ArchiveIntervalMinutes: env.getEnvInt("ARCHIVE_INTERVAL_MINUTES", 1440),
+		ArchiveBatchSize:       env.getEnvInt("ARCHIVE_BATCH_SIZE", 500),
+
+		SellerReportIntervalMinutes: env.getEnvInt("SELLER_REPORT_INTERVAL_MINUTES", 60),
+
+		DigestIntervalMinutes: env.getEnvInt("DIGEST_INTERVAL_MINUTES", 60),
+
+		DashboardCacheSeconds: env.getEnvInt("DASHBOARD_CACHE_SECONDS", 60),
+
+		QuotaUserDaily:    env.getEnvInt("QUOTA_USER_DAILY", 10000),
+		QuotaUserMonthly:  env.getEnvInt("QUOTA_USER_MONTHLY", 200000),
+		QuotaAdminDaily:   env.getEnvInt("QUOTA_ADMIN_DAILY", 0),
+		QuotaAdminMonthly: env.getEnvInt("QUOTA_ADMIN_MONTHLY", 0),
+
+		RateLimitAnonymous:     env.getEnvInt("RATE_LIMIT_ANONYMOUS", 60),
+		RateLimitAuthenticated: env.getEnvInt("RATE_LIMIT_AUTHENTICATED", 300),
+		RateLimitAuth:          env.getEnvInt("RATE_LIMIT_AUTH", 10),
+		RateLimitStore:         env.getEnv("RATE_LIMIT_STORE", "memory"),
+		RedisURL:               env.getEnv("REDIS_URL", "redis://localhost:6379/0"),
+
+		RouteLimits: env.getEnvList("ROUTE_LIMITS"),
+
+		APIKeyRateLimit: env.getEnvInt("API_KEY_RATE_LIMIT", 300),
+
+		ResponseCacheTTLSeconds: env.getEnvInt("RESPONSE_CACHE_TTL_SECONDS", 30),
+		ResponseCacheStore:      env.getEnv("RESPONSE_CACHE_STORE", "memory"),
+
+		CompressionLevel:   env.getEnv("COMPRESSION_LEVEL", "default"),
+		CompressionMinSize: env.getEnvInt("COMPRESSION_MIN_SIZE", 1024),
+
+		EmailDriver: env.getEnv("EMAIL_DRIVER", ""),
+		EmailFrom:   env.getEnv("EMAIL_FROM", env.getEnv("SMTP_FROM", "no-reply@localhost")),
+		EmailDir:    env.getEnv("EMAIL_DIR", "./emails"),
+
+		EmailTemplateDir: env.getEnv("EMAIL_TEMPLATE_DIR", ""),
+
+		SMTPHost:     env.getEnv("SMTP_HOST", ""),
+		SMTPPort:     env.getEnv("SMTP_PORT", "587"),
+		SMTPUsername: env.getEnv("SMTP_USERNAME", ""),
+		SMTPPassword: env.getEnv("SMTP_PASSWORD", ""),
+
+		SendGridAPIKey: env.getEnv("SENDGRID_API_KEY", ""),
+
+		SESRegion:          env.getEnv("SES_REGION", ""),
+		SESAccessKeyID:     env.getEnv("SES_ACCESS_KEY_ID", ""),
+		SESSecretAccessKey: env.getEnv("SES_SECRET_ACCESS_KEY", ""),
+
+		SMSDriver:         env.getEnv("SMS_DRIVER", "log"),
+		SMSFrom:           env.getEnv("SMS_FROM", ""),
+		SMSCodesPerHour:   env.getEnvInt("SMS_CODES_PER_HOUR", 5),
+		SMSPerNumberDaily: env.getEnvInt("SMS_PER_NUMBER_DAILY", 10),
+
+		TwilioAccountSID: env.getEnv("TWILIO_ACCOUNT_SID", ""),
+		TwilioAuthToken:  env.getEnv("TWILIO_AUTH_TOKEN", ""),
+
+		VonageAPIKey:    env.getEnv("VONAGE_API_KEY", ""),
+		VonageAPISecret: env.getEnv("VONAGE_API_SECRET", ""),
+
+		LineChannelAccessToken: env.getEnv("LINE_CHANNEL_ACCESS_TOKEN", ""),
+		LineChannelSecret:      env.getEnv("LINE_CHANNEL_SECRET", ""),
+		LineLinkURL:            env.getEnv("LINE_LINK_URL", "http://localhost:3000/line/link"),
+
+		BodyLimit:   env.getEnvInt("BODY_LIMIT_BYTES", 4<<20),
+		UploadLimit: env.getEnvInt("UPLOAD_LIMIT_BYTES", 32<<20),
+
+		StorageDriver:     env.getEnv("STORAGE_DRIVER", "local"),
+		UploadDir:         env.getEnv("UPLOAD_DIR", "./uploads"),
+		UploadBaseURL:     env.getEnv("UPLOAD_BASE_URL", "/uploads"),
+		S3Endpoint:        env.getEnv("S3_ENDPOINT", ""),
+		S3Region:          env.getEnv("S3_REGION", ""),
+		S3Bucket:          env.getEnv("S3_BUCKET", ""),
+		S3AccessKeyID:     env.getEnv("S3_ACCESS_KEY_ID", ""),
+		S3SecretAccessKey: env.getEnv("S3_SECRET_ACCESS_KEY", ""),
+		S3PublicBaseURL:   env.getEnv("S3_PUBLIC_BASE_URL", ""),
+
+		UploadPresignExpirySeconds: env.getEnvInt("UPLOAD_PRESIGN_EXPIRY_SECONDS", 900),
+
+		ImageWebPEncoderPath: env.getEnv("IMAGE_WEBP_ENCODER_PATH", "cwebp"),
+		ImageAVIFEncoderPath: env.getEnv("IMAGE_AVIF_ENCODER_PATH", "avifenc"),
+
+		MalwareScanDriver:    env.getEnv("MALWARE_SCAN_DRIVER", "clamav"),
+		ClamAVAddress:        env.getEnv("CLAMAV_ADDRESS", "localhost:3310"),
+		ClamAVTimeoutSeconds: env.getEnvInt("CLAMAV_TIMEOUT_SECONDS", 30),
+
+		BackupStorageDriver: env.getEnv("BACKUP_STORAGE_DRIVER", "local"),
+		BackupDir:           env.getEnv("BACKUP_DIR", "./backups"),
+		BackupS3Bucket:      env.getEnv("BACKUP_S3_BUCKET", ""),
+		PGDumpPath:          env.getEnv("PG_DUMP_PATH", "pg_dump"),
+		PGRestorePath:       env.getEnv("PG_RESTORE_PATH", "pg_restore"),
+
+		SearchDriver:      env.getEnv("SEARCH_DRIVER", "postgres"),
+		MeilisearchURL:    env.getEnv("MEILISEARCH_URL", "http://localhost:7700"),
+		MeilisearchAPIKey: env.getEnv("MEILISEARCH_API_KEY", ""),
+		MeilisearchIndex:  env.getEnv("MEILISEARCH_INDEX", "mangas"),
+
+		EventPublisherDriver:       env.getEnv("EVENT_PUBLISHER_DRIVER", "none"),
+		EventTopicPrefix:           env.getEnv("EVENT_TOPIC_PREFIX", "my-backend."),
+		EventTopics:                env.getEnvMap("EVENT_TOPICS"),
+		EventPublishTimeoutSeconds: env.getEnvInt("EVENT_PUBLISH_TIMEOUT_SECONDS", 10),
+		NATSURL:                    env.getEnv("NATS_URL", "nats://localhost:4222"),
+		NATSJetStream:              env.getEnvBool("NATS_JETSTREAM", false),
+		KafkaRESTURL:               env.getEnv("KAFKA_REST_URL", "http://localhost:8082"),
+
+		AlertSlackWebhookURL:          env.getEnv("ALERT_SLACK_WEBHOOK_URL", ""),
+		AlertDiscordWebhookURL:        env.getEnv("ALERT_DISCORD_WEBHOOK_URL", ""),
+		AlertDedupSeconds:             env.getEnvInt("ALERT_DEDUP_SECONDS", 900),
+		AlertMaxPerMinute:             env.getEnvInt("ALERT_MAX_PER_MINUTE", 10),
+		AlertServerErrorThreshold:     env.getEnvInt("ALERT_5XX_THRESHOLD", 20),
+		AlertServerErrorWindowSeconds: env.getEnvInt("ALERT_5XX_WINDOW_SECONDS", 60),
+		AlertDBCheckSeconds:           env.getEnvInt("ALERT_DB_CHECK_SECONDS", 30),
+
+		SentryDSN:     env.getEnv("SENTRY_DSN", ""),
+		SentryRelease: env.getEnv("SENTRY_RELEASE", ""),
 	}
 
+	// Settings that couldn't be parsed are reported by Validate
+	config.parseProblems = env.problems
+
 	return config
 }
 
+// IsDevelopment reports whether the application runs in development
+func (c *Config) IsDevelopment() bool {
+	return c.AppEnv == "development"
+}
+
+// envReader reads environment variables, collecting the values it couldn't
+// parse and fell back to the default for
+type envReader struct {
+	problems []Problem
+}
+
 // getEnv gets an environment variable with a fallback value
-func getEnv(key, fallback string) string {
+func (e *envReader) getEnv(key, fallback string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
 	}
 	return fallback
 }
+
+// getEnvInt gets an integer environment variable with a fallback value
+func (e *envReader) getEnvInt(key string, fallback int64) int64 {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return parsed
+		}
+		log.Printf("WARNING: Invalid integer for %s, using default %d", key, fallback)
+		e.problems = append(e.problems, Problem{Key: key, Message: fmt.Sprintf("%q is not an integer", value)})
+	}
+	return fallback
+}
+
+// getEnvBool gets a boolean environment variable with a fallback value
+func (e *envReader) getEnvBool(key string, fallback bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.ParseBool(value); err == nil {
+			return parsed
+		}
+		log.Printf("WARNING: Invalid boolean for %s, using default %t", key, fallback)
+		e.problems = append(e.problems, Problem{Key: key, Message: fmt.Sprintf("%q is not a boolean (use true or false)", value)})
+	}
+	return fallback
+}
+
+// getEnvList gets a comma-separated environment variable, skipping empty items
+func (e *envReader) getEnvList(key string) []string {
+	var items []string
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// getEnvMap gets a comma-separated environment variable of key=value items,
+// skipping items without a key; values may be empty
+func (e *envReader) getEnvMap(key string) map[string]string {
+	items := make(map[string]string)
+	for _, item := range e.getEnvList(key) {
+		name, value, ok := strings.Cut(item, "=")
+		if name = strings.TrimSpace(name); !ok || name == "" {
+			log.Printf("WARNING: Invalid item %q in %s, skipping it", item, key)
+			e.problems = append(e.problems, Problem{Key: key, Message: fmt.Sprintf("item %q is not a key=value pair", item)})
+			continue
+		}
+		items[name] = strings.TrimSpace(value)
+	}
+	return items
+}
@@ -0,0 +1,391 @@
+package config
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// MinJWTSecretLength is the shortest JWT_SECRET accepted, in characters
+const MinJWTSecretLength = 32
+
+// placeholderSecrets are the example JWT secrets of the docs, which must never
+// sign real tokens
+var placeholderSecrets = []string{
+	"your-secret-key",
+	"your-jwt-secret",
+	"your-super-secret-jwt-key",
+	"your-super-secret-jwt-key-change-this-in-production",
+}
+
+// Problem is a setting that is missing or invalid
+type Problem struct {
+	Key     string `json:"key"`
+	Message string `json:"message"`
+}
+
+// ValidationError lists every problem Validate found in the configuration
+type ValidationError struct {
+	Problems []Problem
+}
+
+// Error lists the problems, one per line
+func (e *ValidationError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "invalid configuration, %d problem(s):", len(e.Problems))
+	for _, problem := range e.Problems {
+		fmt.Fprintf(&b, "\n  %s: %s", problem.Key, problem.Message)
+	}
+	return b.String()
+}
+
+// Validate checks the whole configuration and returns a *ValidationError
+// listing every problem found, or nil when there is none. Settings are
+// checked as the adapters that use them would, so a broken setting stops the
+// server at startup rather than the first time it is needed.
+func (c *Config) Validate() error {
+	v := &checker{problems: slices.Clone(c.parseProblems)}
+
+	c.validateApp(v)
+	c.validateDatabase(v)
+	c.validateAuth(v)
+	c.validateLimits(v)
+	c.validateMessaging(v)
+	c.validateStorage(v)
+	c.validateIntegrations(v)
+
+	if len(v.problems) > 0 {
+		return &ValidationError{Problems: v.problems}
+	}
+	return nil
+}
+
+func (c *Config) validateApp(v *checker) {
+	v.oneOf("APP_ENV", c.AppEnv, "production", "development")
+	v.httpURL("APP_BASE_URL", c.AppBaseURL)
+	v.port("PORT", c.Port)
+	v.port("GRPC_PORT", c.GRPCPort)
+	if c.Port == c.GRPCPort {
+		v.add("GRPC_PORT", "must differ from PORT")
+	}
+}
+
+func (c *Config) validateDatabase(v *checker) {
+	switch c.DBDriver {
+	case "postgres":
+		v.required("DB_HOST", c.DBHost)
+		v.port("DB_PORT", c.DBPort)
+		v.required("DB_USER", c.DBUser)
+		v.required("DB_NAME", c.DBName)
+		if !c.IsDevelopment() && c.DBPass == "password" {
+			v.add("DB_PASS", "must not be the default password outside development")
+		}
+		v.oneOf("DB_SSL_MODE", c.DBSSLMode, "disable", "allow", "prefer", "require", "verify-ca", "verify-full")
+		if c.DBChannelBinding != "" {
+			v.oneOf("DB_CHANNEL_BINDING", c.DBChannelBinding, "disable", "prefer", "require")
+		}
+		if c.DBChannelBinding == "require" && c.DBSSLMode == "disable" {
+			v.add("DB_CHANNEL_BINDING", "require needs DB_SSL_MODE other than disable")
+		}
+		for _, replica := range c.DBReplicaHosts {
+			if host, port, err := net.SplitHostPort(replica); err == nil {
+				if host == "" || !validPort(port) {
+					v.add("DB_REPLICA_HOSTS", fmt.Sprintf("%q is not a host[:port]", replica))
+				}
+			}
+		}
+	case "sqlite":
+		v.required("DB_SQLITE_PATH", c.DBSQLitePath)
+		if len(c.DBReplicaHosts) > 0 {
+			v.add("DB_REPLICA_HOSTS", "read replicas need DB_DRIVER=postgres")
+		}
+	default:
+		v.oneOf("DB_DRIVER", c.DBDriver, "postgres", "sqlite")
+	}
+
+	v.atLeast("DB_MAX_OPEN_CONNS", c.DBMaxOpenConns, 0)
+	v.atLeast("DB_MAX_IDLE_CONNS", c.DBMaxIdleConns, 0)
+	if c.DBMaxOpenConns > 0 && c.DBMaxIdleConns > c.DBMaxOpenConns {
+		v.add("DB_MAX_IDLE_CONNS", "must not exceed DB_MAX_OPEN_CONNS")
+	}
+	v.atLeast("DB_CONN_MAX_LIFETIME_SECONDS", c.DBConnMaxLifetimeSeconds, 0)
+	v.atLeast("DB_CONN_MAX_IDLE_TIME_SECONDS", c.DBConnMaxIdleTimeSeconds, 0)
+	v.atLeast("DB_POOL_STATS_LOG_SECONDS", c.DBPoolStatsLogSeconds, 0)
+	v.atLeast("DB_READ_TIMEOUT_SECONDS", c.DBReadTimeoutSeconds, 0)
+	v.atLeast("DB_WRITE_TIMEOUT_SECONDS", c.DBWriteTimeoutSeconds, 0)
+	v.atLeast("DB_REPORT_TIMEOUT_SECONDS", c.DBReportTimeoutSeconds, 0)
+	v.atLeast("DB_SLOW_QUERY_MS", c.DBSlowQueryMs, 0)
+
+	// Same rules as the database's encryption codec
+	ids := make(map[string]bool, len(c.EncryptionKeys))
+	for _, entry := range c.EncryptionKeys {
+		id, encoded, found := strings.Cut(entry, ":")
+		if !found || id == "" {
+			v.add("ENCRYPTION_KEYS", "entries must be given as <id>:<base64 key>")
+			continue
+		}
+		if ids[id] {
+			v.add("ENCRYPTION_KEYS", fmt.Sprintf("duplicate key %q", id))
+		}
+		ids[id] = true
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			v.add("ENCRYPTION_KEYS", fmt.Sprintf("key %q is not valid base64", id))
+		} else if n := len(key); n != 16 && n != 24 && n != 32 {
+			v.add("ENCRYPTION_KEYS", fmt.Sprintf("key %q must be 16, 24 or 32 bytes", id))
+		}
+	}
+	if c.EncryptionKeyID != "" && !ids[c.EncryptionKeyID] {
+		v.add("ENCRYPTION_KEY_ID", fmt.Sprintf("key %q is not in ENCRYPTION_KEYS", c.EncryptionKeyID))
+	}
+}
+
+func (c *Config) validateAuth(v *checker) {
+	switch {
+	case c.JWTSecret == "":
+		v.add("JWT_SECRET", "is required")
+	case slices.Contains(placeholderSecrets, c.JWTSecret):
+		v.add("JWT_SECRET", "is the example value from the docs, generate one with openssl rand -hex 32")
+	case len(c.JWTSecret) < MinJWTSecretLength:
+		v.add("JWT_SECRET", fmt.Sprintf("must be at least %d characters", MinJWTSecretLength))
+	}
+
+	v.oneOf("AUTH_MODE", c.AuthMode, "header", "cookie", "both")
+	v.oneOf("COOKIE_SAMESITE", c.CookieSameSite, "Lax", "Strict", "None")
+	if c.CookieSameSite == "None" && !c.CookieSecure {
+		v.add("COOKIE_SAMESITE", "None needs COOKIE_SECURE=true, browsers drop such cookies otherwise")
+	}
+}
+
+func (c *Config) validateLimits(v *checker) {
+	v.atLeast("REQUEST_TIMEOUT_SECONDS", c.RequestTimeoutSeconds, 0)
+	v.atLeast("BULK_INSERT_BATCH_SIZE", c.BulkInsertBatchSize, 1)
+
+	v.atLeast("OUTBOX_RELAY_INTERVAL_MS", c.OutboxRelayIntervalMs, 0)
+	v.atLeast("OUTBOX_BATCH_SIZE", c.OutboxBatchSize, 1)
+	v.atLeast("OUTBOX_RETENTION_DAYS", c.OutboxRetentionDays, 0)
+
+	v.atLeast("JOB_WORKERS", c.JobWorkers, 0)
+	v.atLeast("JOB_POLL_INTERVAL_MS", c.JobPollIntervalMs, 1)
+	v.atLeast("JOB_TIMEOUT_SECONDS", c.JobTimeoutSeconds, 1)
+	v.atLeast("JOB_RETENTION_DAYS", c.JobRetentionDays, 0)
+
+	v.atLeast("PURGE_RETENTION_DAYS", c.PurgeRetentionDays, 0)
+	v.atLeast("PURGE_INTERVAL_MINUTES", c.PurgeIntervalMinutes, 0)
+	v.atLeast("PURGE_BATCH_SIZE", c.PurgeBatchSize, 1)
+	v.atLeast("ARCHIVE_AFTER_MONTHS", c.ArchiveAfterMonths, 1)
+	v.atLeast("ARCHIVE_INTERVAL_MINUTES", c.ArchiveIntervalMinutes, 0)
+	v.atLeast("ARCHIVE_BATCH_SIZE", c.ArchiveBatchSize, 1)
+	v.atLeast("SELLER_REPORT_INTERVAL_MINUTES", c.SellerReportIntervalMinutes, 0)
+	v.atLeast("DIGEST_INTERVAL_MINUTES", c.DigestIntervalMinutes, 0)
+	v.atLeast("DASHBOARD_CACHE_SECONDS", c.DashboardCacheSeconds, 0)
+
+	v.atLeast("QUOTA_USER_DAILY", c.QuotaUserDaily, 0)
+	v.atLeast("QUOTA_USER_MONTHLY", c.QuotaUserMonthly, 0)
+	v.atLeast("QUOTA_ADMIN_DAILY", c.QuotaAdminDaily, 0)
+	v.atLeast("QUOTA_ADMIN_MONTHLY", c.QuotaAdminMonthly, 0)
+
+	v.atLeast("RATE_LIMIT_ANONYMOUS", c.RateLimitAnonymous, 0)
+	v.atLeast("RATE_LIMIT_AUTHENTICATED", c.RateLimitAuthenticated, 0)
+	v.atLeast("RATE_LIMIT_AUTH", c.RateLimitAuth, 0)
+	v.atLeast("API_KEY_RATE_LIMIT", c.APIKeyRateLimit, 0)
+	for _, entry := range c.RouteLimits {
+		if _, err := domain.ParseRouteLimit(entry); err != nil {
+			v.add("ROUTE_LIMITS", err.Error())
+		}
+	}
+	v.oneOf("RATE_LIMIT_STORE", c.RateLimitStore, "memory", "redis")
+
+	v.atLeast("RESPONSE_CACHE_TTL_SECONDS", c.ResponseCacheTTLSeconds, 0)
+	v.oneOf("RESPONSE_CACHE_STORE", c.ResponseCacheStore, "memory", "redis")
+
+	if c.RateLimitStore == "redis" || c.ResponseCacheStore == "redis" {
+		if u, err := url.Parse(c.RedisURL); err != nil || (u.Scheme != "redis" && u.Scheme != "rediss") || u.Host == "" {
+			v.add("REDIS_URL", "must be a redis:// or rediss:// URL")
+		}
+	}
+
+	v.oneOf("COMPRESSION_LEVEL", c.CompressionLevel, "disabled", "best_speed", "default", "best_compression")
+	v.atLeast("COMPRESSION_MIN_SIZE", c.CompressionMinSize, 0)
+
+	v.atLeast("BODY_LIMIT_BYTES", c.BodyLimit, 1)
+	v.atLeast("UPLOAD_LIMIT_BYTES", c.UploadLimit, 1)
+}
+
+func (c *Config) validateMessaging(v *checker) {
+	if _, err := mail.ParseAddress(c.EmailFrom); err != nil {
+		v.add("EMAIL_FROM", "must be an email address")
+	}
+	driver := c.EmailDriver
+	if driver == "" && c.SMTPHost != "" {
+		driver = "smtp"
+	}
+	switch driver {
+	case "", "log":
+	case "file":
+		v.required("EMAIL_DIR", c.EmailDir)
+	case "smtp":
+		v.required("SMTP_HOST", c.SMTPHost)
+		v.port("SMTP_PORT", c.SMTPPort)
+	case "sendgrid":
+		v.required("SENDGRID_API_KEY", c.SendGridAPIKey)
+	case "ses":
+		v.required("SES_REGION", c.SESRegion)
+	default:
+		v.oneOf("EMAIL_DRIVER", c.EmailDriver, "log", "file", "smtp", "sendgrid", "ses")
+	}
+
+	switch c.SMSDriver {
+	case "log":
+	case "twilio":
+		v.required("TWILIO_ACCOUNT_SID", c.TwilioAccountSID)
+		v.required("TWILIO_AUTH_TOKEN", c.TwilioAuthToken)
+		v.required("SMS_FROM", c.SMSFrom)
+	case "vonage":
+		v.required("VONAGE_API_KEY", c.VonageAPIKey)
+		v.required("VONAGE_API_SECRET", c.VonageAPISecret)
+		v.required("SMS_FROM", c.SMSFrom)
+	default:
+		v.oneOf("SMS_DRIVER", c.SMSDriver, "log", "twilio", "vonage")
+	}
+	v.atLeast("SMS_CODES_PER_HOUR", c.SMSCodesPerHour, 0)
+	v.atLeast("SMS_PER_NUMBER_DAILY", c.SMSPerNumberDaily, 0)
+
+	if c.LineChannelAccessToken != "" {
+		v.required("LINE_CHANNEL_SECRET", c.LineChannelSecret)
+		v.httpURL("LINE_LINK_URL", c.LineLinkURL)
+	}
+}
+
+func (c *Config) validateStorage(v *checker) {
+	switch c.StorageDriver {
+	case "local":
+		v.required("UPLOAD_DIR", c.UploadDir)
+	case "s3":
+		v.required("S3_BUCKET", c.S3Bucket)
+		v.required("S3_REGION", c.S3Region)
+	default:
+		v.oneOf("STORAGE_DRIVER", c.StorageDriver, "local", "s3")
+	}
+	if c.S3Endpoint != "" {
+		v.httpURL("S3_ENDPOINT", c.S3Endpoint)
+	}
+	v.atLeast("UPLOAD_PRESIGN_EXPIRY_SECONDS", c.UploadPresignExpirySeconds, 1)
+
+	switch c.MalwareScanDriver {
+	case "none":
+	case "clamav":
+		v.required("CLAMAV_ADDRESS", c.ClamAVAddress)
+		v.atLeast("CLAMAV_TIMEOUT_SECONDS", c.ClamAVTimeoutSeconds, 1)
+	default:
+		v.oneOf("MALWARE_SCAN_DRIVER", c.MalwareScanDriver, "clamav", "none")
+	}
+
+	switch c.BackupStorageDriver {
+	case "local":
+		v.required("BACKUP_DIR", c.BackupDir)
+		if c.StorageDriver == "local" && c.BackupDir != "" && filepath.Clean(c.BackupDir) == filepath.Clean(c.UploadDir) {
+			v.add("BACKUP_DIR", "must differ from UPLOAD_DIR, backups must never be served")
+		}
+	case "s3":
+		v.required("BACKUP_S3_BUCKET", c.BackupS3Bucket)
+		v.required("S3_REGION", c.S3Region)
+		if c.StorageDriver == "s3" && c.BackupS3Bucket == c.S3Bucket {
+			v.add("BACKUP_S3_BUCKET", "must differ from S3_BUCKET, backups must never be served")
+		}
+	default:
+		v.oneOf("BACKUP_STORAGE_DRIVER", c.BackupStorageDriver, "local", "s3")
+	}
+}
+
+func (c *Config) validateIntegrations(v *checker) {
+	switch c.SearchDriver {
+	case "postgres":
+	case "meilisearch":
+		v.httpURL("MEILISEARCH_URL", c.MeilisearchURL)
+		v.required("MEILISEARCH_INDEX", c.MeilisearchIndex)
+	default:
+		v.oneOf("SEARCH_DRIVER", c.SearchDriver, "postgres", "meilisearch")
+	}
+
+	switch c.EventPublisherDriver {
+	case "none":
+	case "nats":
+		v.required("NATS_URL", c.NATSURL)
+	case "kafka":
+		v.httpURL("KAFKA_REST_URL", c.KafkaRESTURL)
+	default:
+		v.oneOf("EVENT_PUBLISHER_DRIVER", c.EventPublisherDriver, "none", "nats", "kafka")
+	}
+	v.atLeast("EVENT_PUBLISH_TIMEOUT_SECONDS", c.EventPublishTimeoutSeconds, 1)
+
+	if c.AlertSlackWebhookURL != "" {
+		v.httpURL("ALERT_SLACK_WEBHOOK_URL", c.AlertSlackWebhookURL)
+	}
+	if c.AlertDiscordWebhookURL != "" {
+		v.httpURL("ALERT_DISCORD_WEBHOOK_URL", c.AlertDiscordWebhookURL)
+	}
+	v.atLeast("ALERT_DEDUP_SECONDS", c.AlertDedupSeconds, 0)
+	v.atLeast("ALERT_MAX_PER_MINUTE", c.AlertMaxPerMinute, 0)
+	v.atLeast("ALERT_5XX_THRESHOLD", c.AlertServerErrorThreshold, 0)
+	v.atLeast("ALERT_5XX_WINDOW_SECONDS", c.AlertServerErrorWindowSeconds, 1)
+	v.atLeast("ALERT_DB_CHECK_SECONDS", c.AlertDBCheckSeconds, 0)
+
+	if c.SentryDSN != "" {
+		v.httpURL("SENTRY_DSN", c.SentryDSN)
+	}
+}
+
+// checker collects the problems found by Validate
+type checker struct {
+	problems []Problem
+}
+
+func (v *checker) add(key, message string) {
+	v.problems = append(v.problems, Problem{Key: key, Message: message})
+}
+
+func (v *checker) required(key, value string) {
+	if value == "" {
+		v.add(key, "is required")
+	}
+}
+
+func (v *checker) oneOf(key, value string, allowed ...string) {
+	if !slices.Contains(allowed, value) {
+		v.add(key, fmt.Sprintf("%q is not one of %s", value, strings.Join(allowed, ", ")))
+	}
+}
+
+func (v *checker) port(key, value string) {
+	if !validPort(value) {
+		v.add(key, fmt.Sprintf("%q is not a port number (1-65535)", value))
+	}
+}
+
+func (v *checker) httpURL(key, value string) {
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		v.add(key, fmt.Sprintf("%q is not an http(s) URL", value))
+	}
+}
+
+func (v *checker) atLeast(key string, value, min int64) {
+	if value < min {
+		v.add(key, fmt.Sprintf("must be at least %d", min))
+	}
+}
+
+// validPort reports whether value is a TCP port number
+func validPort(value string) bool {
+	port, err := strconv.Atoi(value)
+	return err == nil && port >= 1 && port <= 65535
+}
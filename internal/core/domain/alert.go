@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+// Alert severities
+const (
+	AlertCritical = "critical"
+	AlertWarning  = "warning"
+	// AlertResolved reports that the problem of an earlier alert is over
+	AlertResolved = "resolved"
+)
+
+// Alert is an operational alert posted to the team's chat channels. Alerts
+// with the same Key report the same problem, and are deduplicated; Suppressed
+// counts the alerts of the key dropped since the last one was sent.
+type Alert struct {
+	Key        string
+	Severity   string
+	Title      string
+	Message    string
+	Fields     []AlertField
+	Time       time.Time
+	Suppressed int
+}
+
+// AlertField is a labelled detail shown with an alert
+type AlertField struct {
+	Name  string
+	Value string
+}
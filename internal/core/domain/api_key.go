@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// APIKeyPrefix starts every API key, so keys are told apart from session
+// tokens and found by secret scanners
+const APIKeyPrefix = "mbk_"
+
+// MaxAPIKeysPerUser is how many active API keys a user may have
+const MaxAPIKeysPerUser = 20
+
+// APIKeyLastUsedPrecision is how stale the last used time of a key may get,
+// so keys in steady use aren't written on every request
+const APIKeyLastUsedPrecision = time.Minute
+
+// API key scopes
+const (
+	APIKeyScopeRead  = "read"  // GET and HEAD requests
+	APIKeyScopeWrite = "write" // every other method
+	APIKeyScopeAdmin = "admin" // the admin routes, for keys of admins only
+)
+
+// APIKeyScopes lists every API key scope
+var APIKeyScopes = []string{APIKeyScopeRead, APIKeyScopeWrite, APIKeyScopeAdmin}
+
+// API key statuses, to filter keys on
+const (
+	APIKeyStatusActive  = "active"
+	APIKeyStatusRevoked = "revoked"
+)
+
+// APIKey lets scripts and integrations call the API as a user, with the
+// scopes it was given. Only a hash of the key is kept; the key itself is
+// shown once, when it is created or rotated. Revoked keys are kept for
+// security reviews.
+type APIKey struct {
+	ID     uint     `json:"id" gorm:"primarykey"`
+	UserID uint     `json:"user_id" gorm:"not null;index"`
+	Name   string   `json:"name" gorm:"not null"`
+	Prefix string   `json:"prefix" gorm:"not null"` // the start of the key, to recognise it by
+	Hash   string   `json:"-" gorm:"not null;uniqueIndex"`
+	Scopes []string `json:"scopes" gorm:"serializer:json;type:jsonb"`
+
+	// RateLimit is how many requests a minute the key may make; 0 is the
+	// API_KEY_RATE_LIMIT default
+	RateLimit int64 `json:"rate_limit" gorm:"not null;default:0"`
+
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RotatedAt  *time.Time `json:"rotated_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" gorm:"index"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+
+	// Key is the key itself, filled in only when it is created or rotated
+	Key string `json:"key,omitempty" gorm:"-"`
+}
+
+// IsRevoked reports whether the key no longer works
+func (k *APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+// HasScope reports whether the key was given the scope
+func (k *APIKey) HasScope(scope string) bool {
+	return slices.Contains(k.Scopes, scope)
+}
+
+// CreateAPIKeyRequest represents the request body for creating an API key
+type CreateAPIKeyRequest struct {
+	Name      string   `json:"name" validate:"required,max=100"`
+	Scopes    []string `json:"scopes" validate:"required,min=1,dive,oneof=read write admin"`
+	RateLimit int64    `json:"rate_limit" validate:"min=0"`
+}
+
+// UpdateAPIKeyRequest represents the request body for renaming an API key or
+// changing its scopes or rate limit
+type UpdateAPIKeyRequest struct {
+	Name      *string  `json:"name" validate:"omitempty,min=1,max=100"`
+	Scopes    []string `json:"scopes" validate:"omitempty,min=1,dive,oneof=read write admin"`
+	RateLimit *int64   `json:"rate_limit" validate:"omitempty,min=0"`
+}
@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ArchivableOrderStatuses are the final order statuses; only orders that can
+// no longer change are archived
+var ArchivableOrderStatuses = []string{OrderStatusFulfilled, OrderStatusCancelled}
+
+// ArchivedOrder is an order moved out of the orders table once it is old and
+// final. It keeps the order's ID, and the order with its items and tax lines
+// as a JSON snapshot, so it reads back exactly as it was.
+type ArchivedOrder struct {
+	ID         uint      `json:"id" gorm:"primarykey;autoIncrement:false"`
+	UserID     uint      `json:"user_id" gorm:"not null;index"`
+	Status     string    `json:"status" gorm:"not null"`
+	Total      float64   `json:"total" gorm:"not null"`
+	Snapshot   string    `json:"-" gorm:"type:text;not null"`
+	CreatedAt  time.Time `json:"created_at" gorm:"index"`
+	ArchivedAt time.Time `json:"archived_at" gorm:"not null"`
+}
+
+// ArchivedMangaView is a daily view count moved out of manga_views. Trending
+// only looks at recent days, so older counts are kept here for reporting.
+type ArchivedMangaView struct {
+	MangaID    uint      `json:"manga_id" gorm:"primaryKey;autoIncrement:false"`
+	Day        time.Time `json:"day" gorm:"primaryKey;type:date"`
+	Count      int64     `json:"count" gorm:"not null"`
+	ArchivedAt time.Time `json:"archived_at" gorm:"not null"`
+}
+
+// NewArchivedOrder snapshots the order for the archive
+func NewArchivedOrder(order *Order, archivedAt time.Time) (*ArchivedOrder, error) {
+	snapshot, err := json.Marshal(order)
+	if err != nil {
+		return nil, err
+	}
+	return &ArchivedOrder{
+		ID:         order.ID,
+		UserID:     order.UserID,
+		Status:     order.Status,
+		Total:      order.Total,
+		Snapshot:   string(snapshot),
+		CreatedAt:  order.CreatedAt,
+		ArchivedAt: archivedAt,
+	}, nil
+}
+
+// Order restores the archived order. The buyer comes from the archive row,
+// which follows user merges, rather than from the snapshot.
+func (a *ArchivedOrder) Order() (*Order, error) {
+	var order Order
+	if err := json.Unmarshal([]byte(a.Snapshot), &order); err != nil {
+		return nil, err
+	}
+	order.UserID = a.UserID
+	order.Archived = true
+	return &order, nil
+}
+
+// ArchiveReport describes one run of the archival job
+type ArchiveReport struct {
+	Cutoff     time.Time `json:"cutoff"`
+	Orders     int       `json:"orders"`
+	MangaViews int       `json:"manga_views"`
+	// Complete is false when a batch was full, so more records may be due
+	Complete bool `json:"complete"`
+}
+
+// ArchiveStats are the archival counters since startup
+type ArchiveStats struct {
+	Runs               int64          `json:"runs"`
+	FailedRuns         int64          `json:"failed_runs"`
+	OrdersArchived     int64          `json:"orders_archived"`
+	MangaViewsArchived int64          `json:"manga_views_archived"`
+	LastRunAt          *time.Time     `json:"last_run_at,omitempty"`
+	LastReport         *ArchiveReport `json:"last_report,omitempty"`
+}
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditActorSystem is the actor role of changes made by background jobs and
+// schedulers rather than by a signed-in user
+const AuditActorSystem = "system"
+
+// AuditLog is an entry of the append-only audit log: one privileged or
+// mutating action, who made it, and the entity before and after. Action is
+// the entity type and a verb, such as "manga.update"; Before is null for
+// created entities and After for deleted ones.
+type AuditLog struct {
+	ID         uint            `json:"id" gorm:"primarykey"`
+	ActorID    *uint           `json:"actor_id,omitempty" gorm:"index"`
+	ActorRole  string          `json:"actor_role"`
+	Action     string          `json:"action" gorm:"not null;index"`
+	EntityType string          `json:"entity_type" gorm:"not null;index:idx_audit_logs_entity"`
+	EntityID   *uint           `json:"entity_id,omitempty" gorm:"index:idx_audit_logs_entity"`
+	Before     json.RawMessage `json:"before,omitempty" gorm:"serializer:json;type:jsonb"`
+	After      json.RawMessage `json:"after,omitempty" gorm:"serializer:json;type:jsonb"`
+	IP         string          `json:"ip,omitempty"`
+	RequestID  string          `json:"request_id,omitempty"`
+	CreatedAt  time.Time       `json:"created_at" gorm:"index"`
+}
+
+// AuditLogFilter narrows the audit log; zero fields match every entry
+type AuditLogFilter struct {
+	ActorID    *uint
+	EntityType string
+	EntityID   *uint
+	Action     string
+	From       *time.Time
+	To         *time.Time
+}
@@ -11,17 +11,22 @@ type RegisterRequest struct {
 	Name     string `json:"name" validate:"required"`
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required,min=6"`
+	Locale   string `json:"locale" validate:"omitempty,oneof=en th ja"` // defaults to the request's Accept-Language
 }
 
 // CreateUserRequest represents the request body for creating a user
 type CreateUserRequest struct {
 	Name  string `json:"name" validate:"required"`
 	Email string `json:"email" validate:"required,email"`
+
+	// IfMatch is the ETag a full update is based on; the update is rejected
+	// when the user has changed since. Empty skips the check.
+	IfMatch string `json:"-"`
 }
 
 // AuthResponse represents the response for login/register
 type AuthResponse struct {
-	Token string `json:"token"`
+	Token string `json:"token,omitempty"` // left out when it is only set in the auth cookie
 	User  *User  `json:"user"`
 }
 
@@ -0,0 +1,42 @@
+package domain
+
+import "time"
+
+// Backup job kinds
+const (
+	BackupJobBackup  = "backup"
+	BackupJobRestore = "restore"
+)
+
+// Backup job statuses
+const (
+	BackupStatusPending   = "pending"
+	BackupStatusRunning   = "running"
+	BackupStatusSucceeded = "succeeded"
+	BackupStatusFailed    = "failed"
+)
+
+// BackupJob tracks a logical backup of the database or a restore from one. A
+// succeeded backup job is the record of the backup itself: restores refer to
+// it by ID. Backup jobs are left out of backups, so restoring keeps them.
+type BackupJob struct {
+	ID     uint   `json:"id" gorm:"primarykey"`
+	Kind   string `json:"kind" gorm:"not null;index"`
+	Status string `json:"status" gorm:"not null;default:pending;index"`
+	// StorageKey is where the dump was written (backups) or read from (restores)
+	StorageKey string `json:"storage_key,omitempty"`
+	SizeBytes  int64  `json:"size_bytes"`
+	// BackupID is the backup job a restore restored
+	BackupID    *uint      `json:"backup_id,omitempty"`
+	Error       string     `json:"error,omitempty" gorm:"type:text"`
+	RequestedBy *uint      `json:"requested_by,omitempty"` // nil when started from the command line
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	FinishedAt  *time.Time `json:"finished_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// IsFinished reports whether the job has succeeded or failed
+func (j *BackupJob) IsFinished() bool {
+	return j.Status == BackupStatusSucceeded || j.Status == BackupStatusFailed
+}
@@ -0,0 +1,8 @@
+package domain
+
+// BatchCreateFailure reports a record a batched insert could not create.
+// Index is the record's position in the slice passed to CreateInBatches.
+type BatchCreateFailure struct {
+	Index int
+	Error string
+}
@@ -0,0 +1,17 @@
+package domain
+
+// BookMetadata is bibliographic data returned by an external book catalogue
+type BookMetadata struct {
+	ISBN          string   `json:"isbn"`
+	Title         string   `json:"title"`
+	Authors       []string `json:"authors"`
+	Publisher     string   `json:"publisher,omitempty"`
+	PublishedDate string   `json:"published_date,omitempty"`
+	CoverURL      string   `json:"cover_url,omitempty"`
+}
+
+// ISBNLookupResponse pairs the catalogue data with a pre-filled create-manga request
+type ISBNLookupResponse struct {
+	Book    *BookMetadata       `json:"book"`
+	Prefill *CreateMangaRequest `json:"prefill"`
+}
@@ -0,0 +1,11 @@
+package domain
+
+import "strconv"
+
+// MangaListCacheTag tags cached manga listings, which any manga change can affect
+const MangaListCacheTag = "mangas"
+
+// MangaCacheTag tags cached responses about a single manga
+func MangaCacheTag(id uint) string {
+	return "manga:" + strconv.FormatUint(uint64(id), 10)
+}
@@ -0,0 +1,49 @@
+package domain
+
+import "time"
+
+// MaxCartItems caps the different mangas in a cart, as many as an order may have
+const MaxCartItems = 50
+
+// CartItem is a manga in a user's cart with the number of copies they want.
+// Checkout orders the whole cart and empties it.
+type CartItem struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	UserID    uint      `json:"user_id" gorm:"not null;uniqueIndex:idx_cart_items_user_manga"`
+	MangaID   uint      `json:"manga_id" gorm:"not null;uniqueIndex:idx_cart_items_user_manga;index"`
+	Quantity  int       `json:"quantity" gorm:"not null"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Manga *Manga `json:"manga,omitempty"`
+}
+
+// Cart is the mangas a user is about to order. Subtotal prices them at their
+// current effective price, before tax; checkout prices them again.
+type Cart struct {
+	Items    []*CartItem `json:"items"`
+	Subtotal float64     `json:"subtotal"`
+}
+
+// NewCart prices the items, whose mangas must be loaded
+func NewCart(items []*CartItem) *Cart {
+	cart := &Cart{Items: items}
+	if cart.Items == nil {
+		cart.Items = []*CartItem{}
+	}
+	for _, item := range items {
+		price := item.Manga.Price
+		if item.Manga.EffectivePrice != nil {
+			price = *item.Manga.EffectivePrice
+		}
+		cart.Subtotal += roundPrice(price * float64(item.Quantity))
+	}
+	cart.Subtotal = roundPrice(cart.Subtotal)
+	return cart
+}
+
+// SetCartItemRequest represents the request body for putting a manga in the
+// cart, or changing how many copies of it the cart holds
+type SetCartItemRequest struct {
+	Quantity int `json:"quantity" validate:"required,min=1,max=100"`
+}
@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Chapter represents a chapter belonging to a manga
+type Chapter struct {
+	ID          uint           `json:"id" gorm:"primarykey"`
+	MangaID     uint           `json:"manga_id" gorm:"not null;index;uniqueIndex:idx_chapters_manga_number"`
+	Number      float64        `json:"number" gorm:"not null;uniqueIndex:idx_chapters_manga_number"`
+	Title       string         `json:"title"`
+	PageCount   int            `json:"page_count" gorm:"not null;default:0"`
+	ReleaseDate *time.Time     `json:"release_date,omitempty"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
+}
+
+// IsValid checks if the chapter has valid data
+func (c *Chapter) IsValid() bool {
+	return c.MangaID > 0 && c.Number > 0 && c.PageCount >= 0
+}
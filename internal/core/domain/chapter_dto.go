@@ -0,0 +1,11 @@
+package domain
+
+import "time"
+
+// ChapterRequest represents the request body for creating or updating a chapter
+type ChapterRequest struct {
+	Number      float64    `json:"number" validate:"required,gt=0"`
+	Title       string     `json:"title" validate:"max=255"`
+	PageCount   int        `json:"page_count" validate:"min=0"`
+	ReleaseDate *time.Time `json:"release_date"`
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// CloudEvents attributes of published domain events
+const (
+	// CloudEventsSpecVersion is the CloudEvents version events are published in
+	CloudEventsSpecVersion = "1.0"
+	// CloudEventsContentType is the media type of an event in the structured mode
+	CloudEventsContentType = "application/cloudevents+json"
+	// CloudEventTypePrefix makes a domain event's name its reverse-DNS CloudEvents type
+	CloudEventTypePrefix = "com.github.thitiphongd.my-backend."
+)
+
+// CloudEvent is a domain event in the structured JSON format of CloudEvents,
+// as published to message brokers. Subject names the changed resource, such
+// as "orders/12"; it also keys Kafka messages, so the events of one resource
+// stay on one partition.
+type CloudEvent struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Subject         string          `json:"subject,omitempty"`
+	Time            time.Time       `json:"time"`
+	DataContentType string          `json:"datacontenttype"`
+	Data            json.RawMessage `json:"data"`
+}
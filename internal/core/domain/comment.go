@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// CommentEditWindow is how long after posting the author may edit a comment
+const CommentEditWindow = 15 * time.Minute
+
+// Comment represents a user's comment on a manga. Replies reference a
+// top-level comment through ParentID, so threads are one level deep.
+type Comment struct {
+	ID        uint           `json:"id" gorm:"primarykey"`
+	MangaID   uint           `json:"manga_id" gorm:"not null;index"`
+	UserID    uint           `json:"user_id" gorm:"not null;index"`
+	ParentID  *uint          `json:"parent_id,omitempty" gorm:"index"`
+	Body      string         `json:"body" gorm:"type:text;not null"`
+	Replies   []Comment      `json:"replies,omitempty" gorm:"foreignKey:ParentID"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
+}
+
+// IsValid checks if the comment has valid data
+func (c *Comment) IsValid() bool {
+	return c.MangaID > 0 && c.UserID > 0 && c.Body != ""
+}
+
+// IsEditable reports whether the comment is still within its edit window
+func (c *Comment) IsEditable(now time.Time) bool {
+	return now.Sub(c.CreatedAt) <= CommentEditWindow
+}
@@ -0,0 +1,12 @@
+package domain
+
+// CreateCommentRequest represents the request body for posting a comment or reply
+type CreateCommentRequest struct {
+	Body     string `json:"body" validate:"required,max=2000"`
+	ParentID *uint  `json:"parent_id"`
+}
+
+// UpdateCommentRequest represents the request body for editing a comment
+type UpdateCommentRequest struct {
+	Body string `json:"body" validate:"required,max=2000"`
+}
@@ -0,0 +1,61 @@
+package domain
+
+import "time"
+
+// RequestStatsWindow is how far back the dashboard's request counts go
+const RequestStatsWindow = time.Hour
+
+// DashboardCounts counts the records of a kind, and those created within the
+// last day and the last week
+type DashboardCounts struct {
+	Total    int64 `json:"total"`
+	LastDay  int64 `json:"last_day"`
+	LastWeek int64 `json:"last_week"`
+}
+
+// DashboardMangas counts the mangas, with those waiting for review
+type DashboardMangas struct {
+	DashboardCounts
+	PendingReview int64 `json:"pending_review"`
+}
+
+// DashboardRevenue sums the totals of the paid, shipped and fulfilled orders,
+// archived ones included, and of those placed within the last day and week
+type DashboardRevenue struct {
+	Total    float64 `json:"total"`
+	LastDay  float64 `json:"last_day"`
+	LastWeek float64 `json:"last_week"`
+}
+
+// RequestCounts counts the responses an instance served by class of status
+type RequestCounts struct {
+	WindowSeconds int64 `json:"window_seconds"`
+	Requests      int64 `json:"requests"`
+	ClientErrors  int64 `json:"client_errors"` // 4xx
+	ServerErrors  int64 `json:"server_errors"` // 5xx
+	// ServerErrorRate is the share of requests answered with a 5xx, 0 to 1
+	ServerErrorRate float64 `json:"server_error_rate"`
+}
+
+// DashboardJobs is the depth of the background job queue
+type DashboardJobs struct {
+	Pending int64 `json:"pending"`
+	// Due are the pending jobs whose run time has come, waiting for a worker
+	Due     int64 `json:"due"`
+	Running int64 `json:"running"`
+	Dead    int64 `json:"dead"`
+	// OldestDueAt is the run time of the job that has waited longest, if any
+	OldestDueAt *time.Time `json:"oldest_due_at,omitempty"`
+}
+
+// DashboardStats are the key figures of the ops dashboard. Request counts are
+// of the instance that answered.
+type DashboardStats struct {
+	Users       DashboardCounts  `json:"users"`
+	Mangas      DashboardMangas  `json:"mangas"`
+	Orders      DashboardCounts  `json:"orders"`
+	Revenue     DashboardRevenue `json:"revenue"`
+	Requests    RequestCounts    `json:"requests"`
+	Jobs        DashboardJobs    `json:"jobs"`
+	GeneratedAt time.Time        `json:"generated_at"`
+}
@@ -0,0 +1,52 @@
+package domain
+
+import "time"
+
+// DatabasePoolStats is a snapshot of one database server's connection pool
+type DatabasePoolStats struct {
+	Name               string `json:"name"`
+	MaxOpenConnections int    `json:"max_open_connections"`
+	OpenConnections    int    `json:"open_connections"`
+	InUse              int    `json:"in_use"`
+	Idle               int    `json:"idle"`
+	// WaitCount and WaitDurationMs add up the times a query had to wait for a
+	// free connection since startup; steady growth means the pool is too small
+	WaitCount         int64 `json:"wait_count"`
+	WaitDurationMs    int64 `json:"wait_duration_ms"`
+	MaxIdleClosed     int64 `json:"max_idle_closed"`
+	MaxIdleTimeClosed int64 `json:"max_idle_time_closed"`
+	MaxLifetimeClosed int64 `json:"max_lifetime_closed"`
+}
+
+// Database health statuses
+const (
+	DatabaseStatusOK       = "ok"
+	DatabaseStatusDegraded = "degraded" // the primary answers but a replica does not
+	DatabaseStatusDown     = "down"
+)
+
+// DatabaseHealth is the result of a database health check
+type DatabaseHealth struct {
+	Status   string                  `json:"status"`
+	Error    string                  `json:"error,omitempty"`
+	Pools    []DatabasePoolStats     `json:"pools"`
+	Replicas []DatabaseReplicaHealth `json:"replicas,omitempty"`
+	Schema   *DatabaseSchemaVersion  `json:"schema,omitempty"`
+}
+
+// DatabaseReplicaHealth reports how far a read replica is behind the primary.
+// LagSeconds is the age of the last transaction it replayed, so it also grows
+// while the primary is idle; it is nil when the replica has replayed nothing yet.
+type DatabaseReplicaHealth struct {
+	Name       string   `json:"name"`
+	LagSeconds *float64 `json:"lag_seconds"`
+	Error      string   `json:"error,omitempty"`
+}
+
+// DatabaseSchemaVersion is the schema version the database was last migrated to
+type DatabaseSchemaVersion struct {
+	Version   int64     `json:"version"`
+	AppliedAt time.Time `json:"applied_at"`
+	// Current is false when the running code expects another version
+	Current bool `json:"current"`
+}
@@ -0,0 +1,79 @@
+package domain
+
+import "time"
+
+// How often users get a digest of their low-priority notifications
+const (
+	DigestsOff    = "off"
+	DigestsDaily  = "daily"
+	DigestsWeekly = "weekly"
+)
+
+// DigestFrequencies lists the frequencies digests are made at
+var DigestFrequencies = []string{DigestsDaily, DigestsWeekly}
+
+// Where digests are delivered. In-app digests are only kept for the user to
+// read in the app; email digests are kept there too.
+const (
+	DigestDeliveryEmail = "email"
+	DigestDeliveryInApp = "in_app"
+)
+
+// Kinds of digest items
+const (
+	DigestItemPriceDrop = "price_drop" // a manga on one of the user's wishlists got cheaper
+)
+
+// DigestPeriod returns the last whole period of the frequency before now, in
+// UTC: yesterday for daily digests, last Monday to Sunday for weekly ones.
+// The period ends before to.
+func DigestPeriod(frequency string, now time.Time) (from, to time.Time) {
+	if frequency == DigestsWeekly {
+		return SellerReportPeriod(SellerReportsWeekly, now)
+	}
+	y, m, d := now.UTC().Date()
+	to = time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	return to.AddDate(0, 0, -1), to
+}
+
+// DigestItem is a low-priority notification, held for the user's next digest
+// instead of being sent on its own. It is pending until DigestID is set.
+type DigestItem struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	UserID    uint      `json:"user_id" gorm:"not null;index"`
+	DigestID  *uint     `json:"digest_id,omitempty" gorm:"index"`
+	Kind      string    `json:"kind" gorm:"not null"`
+	CreatedAt time.Time `json:"created_at" gorm:"index"`
+
+	// What the item is about, by kind
+	PriceDrop *WishlistPriceDrop `json:"price_drop,omitempty" gorm:"serializer:json;type:jsonb"`
+}
+
+// Digest batches the items a user got over a period into one notification,
+// read in the app and, if the user wants, emailed
+type Digest struct {
+	ID        uint         `json:"id" gorm:"primarykey"`
+	UserID    uint         `json:"user_id" gorm:"not null;index"`
+	Frequency string       `json:"frequency" gorm:"not null"`
+	From      time.Time    `json:"from"`
+	To        time.Time    `json:"to"`
+	ReadAt    *time.Time   `json:"read_at,omitempty"`
+	CreatedAt time.Time    `json:"created_at"`
+	Items     []DigestItem `json:"items,omitempty" gorm:"foreignKey:DigestID"`
+}
+
+// LastDay is the last day the digest covers
+func (d *Digest) LastDay() time.Time {
+	return d.To.AddDate(0, 0, -1)
+}
+
+// PriceDrops are the price drop items of the digest
+func (d *Digest) PriceDrops() []*WishlistPriceDrop {
+	var drops []*WishlistPriceDrop
+	for _, item := range d.Items {
+		if item.Kind == DigestItemPriceDrop && item.PriceDrop != nil {
+			drops = append(drops, item.PriceDrop)
+		}
+	}
+	return drops
+}
@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// Discount types
+const (
+	DiscountPercentage = "percentage"
+	DiscountFixed      = "fixed"
+)
+
+// Discount represents a sale campaign applying to specific mangas or genres
+type Discount struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	Name      string    `json:"name" gorm:"not null"`
+	Type      string    `json:"type" gorm:"not null"`
+	Value     float64   `json:"value" gorm:"not null"`
+	StartsAt  time.Time `json:"starts_at" gorm:"not null;index"`
+	EndsAt    time.Time `json:"ends_at" gorm:"not null;index"`
+	Mangas    []Manga   `json:"-" gorm:"many2many:discount_mangas"`
+	MangaIDs  []uint    `json:"manga_ids" gorm:"-"`
+	Genres    []Genre   `json:"genres" gorm:"many2many:discount_genres"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// IsValid checks if the discount has valid data
+func (d *Discount) IsValid() bool {
+	switch d.Type {
+	case DiscountPercentage:
+		if d.Value > 100 {
+			return false
+		}
+	case DiscountFixed:
+	default:
+		return false
+	}
+	return d.Name != "" && d.Value > 0 && d.EndsAt.After(d.StartsAt)
+}
+
+// FillMangaIDs exposes the IDs of the targeted mangas for API responses
+func (d *Discount) FillMangaIDs() {
+	d.MangaIDs = make([]uint, len(d.Mangas))
+	for i, manga := range d.Mangas {
+		d.MangaIDs[i] = manga.ID
+	}
+}
+
+// IsActiveAt reports whether the campaign is running at the given time
+func (d *Discount) IsActiveAt(now time.Time) bool {
+	return !now.Before(d.StartsAt) && now.Before(d.EndsAt)
+}
+
+// AppliesTo reports whether the discount targets the manga directly or through one of its genres
+func (d *Discount) AppliesTo(m *Manga) bool {
+	for _, target := range d.Mangas {
+		if target.ID == m.ID {
+			return true
+		}
+	}
+	for _, target := range d.Genres {
+		for _, genre := range m.Genres {
+			if target.ID == genre.ID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Apply returns the discounted price, never below zero, rounded to cents
+func (d *Discount) Apply(price float64) float64 {
+	discounted := price
+	switch d.Type {
+	case DiscountPercentage:
+		discounted = price * (1 - d.Value/100)
+	case DiscountFixed:
+		discounted = price - d.Value
+	}
+	if discounted < 0 {
+		discounted = 0
+	}
+	return math.Round(discounted*100) / 100
+}
+
+// ApplyDiscounts sets EffectivePrice on each manga covered by one of the discounts,
+// using the lowest resulting price when several discounts apply
+func ApplyDiscounts(mangas []*Manga, discounts []*Discount) {
+	for _, manga := range mangas {
+		manga.EffectivePrice = nil
+		for _, discount := range discounts {
+			if !discount.AppliesTo(manga) {
+				continue
+			}
+			price := discount.Apply(manga.Price)
+			if manga.EffectivePrice == nil || price < *manga.EffectivePrice {
+				manga.EffectivePrice = &price
+			}
+		}
+	}
+}
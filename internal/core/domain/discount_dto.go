@@ -0,0 +1,14 @@
+package domain
+
+import "time"
+
+// DiscountRequest represents the request body for creating or updating a discount
+type DiscountRequest struct {
+	Name     string    `json:"name" validate:"required,max=100"`
+	Type     string    `json:"type" validate:"required,oneof=percentage fixed"`
+	Value    float64   `json:"value" validate:"required,gt=0"`
+	StartsAt time.Time `json:"starts_at" validate:"required"`
+	EndsAt   time.Time `json:"ends_at" validate:"required"`
+	MangaIDs []uint    `json:"manga_ids"`
+	GenreIDs []uint    `json:"genre_ids"`
+}
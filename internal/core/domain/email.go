@@ -0,0 +1,65 @@
+package domain
+
+import "errors"
+
+// EmailMessage is an email to a single recipient, in plain text and
+// optionally HTML
+type EmailMessage struct {
+	To      string
+	Subject string
+	Body    string
+	HTML    string // empty sends the plain text only
+
+	// UnsubscribeURL is sent in a List-Unsubscribe header, so mail clients
+	// can offer a one-click unsubscribe; empty for emails that are always sent
+	UnsubscribeURL string
+}
+
+// ErrEmailRejected marks a send failure that sending the message again won't
+// fix, such as an invalid recipient or a rejected sender; other failures are
+// transient and retried
+var ErrEmailRejected = errors.New("email rejected")
+
+// Email templates
+const (
+	EmailWelcome        = "welcome"
+	EmailOnboardingDay1 = "onboarding_day1"
+	EmailOnboardingDay3 = "onboarding_day3"
+	EmailPasswordReset  = "password_reset"
+	EmailChange         = "email_change"
+	EmailOrderPlaced    = "order_placed"
+	EmailOrderPaid      = "order_paid"
+	EmailOrderShipped   = "order_shipped"
+	EmailSellerReport   = "seller_report"
+	EmailDigest         = "digest"
+	EmailMangaReviewed  = "manga_reviewed"
+	EmailCommentRemoved = "comment_removed"
+
+	// Alerts to admins
+	EmailMalwareDetected = "malware_detected"
+)
+
+// EmailData is what email templates render: the recipient, and whatever else
+// their template uses
+type EmailData struct {
+	User      *User
+	Order     *Order // order emails
+	ActionURL string // the link the email asks the user to follow, such as a password reset link
+
+	// UnsubscribeURL stops emails of the category this one belongs to; the
+	// layout links to it when set
+	UnsubscribeURL string
+
+	// Malware is the flagged upload a malware alert is about
+	Malware *MalwareReport
+
+	// Report is the period a seller report email covers
+	Report *SellerReport
+
+	// Digest is the digest a digest email sends
+	Digest *Digest
+
+	// Manga and Comment are what a moderation email is about
+	Manga   *Manga
+	Comment *Comment
+}
@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+// Error report levels
+const (
+	ErrorLevelError = "error"
+	// ErrorLevelFatal marks panics
+	ErrorLevelFatal = "fatal"
+)
+
+// ErrorReport is an error sent to the error tracker. The request ID and
+// breadcrumbs are read from the context it is captured with.
+type ErrorReport struct {
+	Err   error
+	Level string
+	// Panic marks errors recovered from a panic rather than returned
+	Panic bool
+	// Stack holds the program counters of the goroutine the error was caught
+	// on, from runtime.Callers; for panics it shows where they were raised
+	Stack   []uintptr
+	Request *ErrorReportRequest
+	UserID  *uint
+	// Tags are searchable labels, such as the route or the job kind
+	Tags map[string]string
+	Time time.Time
+}
+
+// ErrorReportRequest is the HTTP request an error was raised in
+type ErrorReportRequest struct {
+	Method      string
+	URL         string
+	QueryString string
+	Headers     map[string]string
+	ClientIP    string
+}
@@ -0,0 +1,20 @@
+package domain
+
+import "errors"
+
+// Domain errors that callers need to tell apart
+var (
+	ErrAccountSuspended       = errors.New("account is suspended")
+	ErrInsufficientStock      = errors.New("insufficient stock")
+	ErrInvalidTransition      = errors.New("invalid manga status transition")
+	ErrBookNotFound           = errors.New("book not found")
+	ErrInvalidISBN            = errors.New("invalid ISBN")
+	ErrRelationCycle          = errors.New("relation would create a sequel cycle")
+	ErrInvalidOrderTransition = errors.New("invalid order status transition")
+	ErrAgeRestricted          = errors.New("age verification required for mature content")
+	ErrReservationExpired     = errors.New("stock reservation has expired")
+	ErrPreconditionFailed     = errors.New("resource has changed since it was fetched")
+	ErrBackupInProgress       = errors.New("another backup or restore is running")
+	ErrOrderNotFound          = errors.New("order not found")
+	ErrOrderNotPaid           = errors.New("order has not been paid")
+)
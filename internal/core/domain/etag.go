@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+)
+
+// VersionETag returns a weak entity tag identifying one version of a record.
+// It changes whenever the record is saved, which bumps UpdatedAt, and
+// whenever any of state changes: the values shown with the record that are
+// written without bumping UpdatedAt.
+func VersionETag(id uint, updatedAt time.Time, state ...interface{}) string {
+	if len(state) == 0 {
+		return fmt.Sprintf(`W/"%d-%x"`, id, updatedAt.UnixNano())
+	}
+	hash := fnv.New64a()
+	fmt.Fprint(hash, state...)
+	return fmt.Sprintf(`W/"%d-%x-%x"`, id, updatedAt.UnixNano(), hash.Sum64())
+}
+
+// ETag returns the manga's current entity tag. Stock, views and ratings are
+// updated on their own, and the effective price follows the discounts, so
+// they are part of the tag.
+func (m *Manga) ETag() string {
+	var effectivePrice interface{}
+	if m.EffectivePrice != nil {
+		effectivePrice = *m.EffectivePrice
+	}
+	return VersionETag(m.ID, m.UpdatedAt, m.StockQuantity, m.ViewCount, m.AverageRating, m.ReviewCount, effectivePrice)
+}
+
+// ETag returns the user's current entity tag
+func (u *User) ETag() string {
+	return VersionETag(u.ID, u.UpdatedAt)
+}
+
+// ETagMatches reports whether a comma-separated If-Match or If-None-Match
+// value names the entity tag or is "*". Tags are compared weakly.
+func ETagMatches(list, etag string) bool {
+	for _, candidate := range strings.Split(list, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
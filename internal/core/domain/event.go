@@ -0,0 +1,113 @@
+package domain
+
+// Event is a typed domain event published on the event bus. Its name selects
+// the subscribers it is passed to; events that are also sent to webhooks use
+// the webhook event type as their name.
+type Event interface {
+	EventName() string
+}
+
+// EventUserRegistered is the name of the event published when an account is
+// created; it is not sent to webhooks
+const EventUserRegistered = "user.registered"
+
+// EventMangaPriceDropped is the name of the event published when a published
+// manga gets cheaper; wishlisters get it as EventWishlistPriceDropped
+const EventMangaPriceDropped = "manga.price_dropped"
+
+// Names of the moderation events, which are not sent to webhooks
+const (
+	EventMangaReviewed  = "manga.reviewed"
+	EventCommentRemoved = "comment.removed"
+)
+
+// UserRegistered is published when a user signs up
+type UserRegistered struct {
+	User *User
+}
+
+// EventName implements Event
+func (UserRegistered) EventName() string { return EventUserRegistered }
+
+// MangaCreated is published when a manga is created, imported or restored
+type MangaCreated struct {
+	Manga *Manga
+}
+
+// EventName implements Event
+func (MangaCreated) EventName() string { return EventMangaCreated }
+
+// MangaUpdated is published when a manga is edited or changes status
+type MangaUpdated struct {
+	Manga *Manga
+}
+
+// EventName implements Event
+func (MangaUpdated) EventName() string { return EventMangaUpdated }
+
+// MangaDeleted is published when a manga is deleted
+type MangaDeleted struct {
+	Manga *Manga
+}
+
+// EventName implements Event
+func (MangaDeleted) EventName() string { return EventMangaDeleted }
+
+// MangaPriceDropped is published when a published manga gets cheaper, before
+// the MangaUpdated of the same change
+type MangaPriceDropped struct {
+	Manga    *Manga
+	OldPrice float64
+}
+
+// EventName implements Event
+func (MangaPriceDropped) EventName() string { return EventMangaPriceDropped }
+
+// MangaReviewed is published when a moderator approves or rejects a manga
+// awaiting review; its status and rejection reason tell which
+type MangaReviewed struct {
+	Manga *Manga
+}
+
+// EventName implements Event
+func (MangaReviewed) EventName() string { return EventMangaReviewed }
+
+// CommentRemoved is published when a moderator deletes a comment
+type CommentRemoved struct {
+	Comment *Comment
+}
+
+// EventName implements Event
+func (CommentRemoved) EventName() string { return EventCommentRemoved }
+
+// OrderPlaced is published when a buyer checks out
+type OrderPlaced struct {
+	Order *Order
+}
+
+// EventName implements Event
+func (OrderPlaced) EventName() string { return EventOrderPlaced }
+
+// OrderPaid is published when an order is paid
+type OrderPaid struct {
+	Order *Order
+}
+
+// EventName implements Event
+func (OrderPaid) EventName() string { return EventOrderPaid }
+
+// OrderShipped is published when a paid order is shipped with its tracking details
+type OrderShipped struct {
+	Order *Order
+}
+
+// EventName implements Event
+func (OrderShipped) EventName() string { return EventOrderShipped }
+
+// OrderDelivered is published when an order is fulfilled
+type OrderDelivered struct {
+	Order *Order
+}
+
+// EventName implements Event
+func (OrderDelivered) EventName() string { return EventOrderDelivered }
@@ -0,0 +1,12 @@
+package domain
+
+import "time"
+
+// StreamEvent is one event delivered to a user's server-sent event stream.
+// Stream events carry the same event types and data as webhooks.
+type StreamEvent struct {
+	ID        uint64      `json:"id"`
+	Event     string      `json:"event"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+// Fields is a client-selected subset of a resource's JSON fields
+type Fields []string
+
+// MangaSelectableFields maps the manga JSON fields clients may select to their
+// columns. Fields without a column are loaded or computed outside the mangas table.
+var MangaSelectableFields = map[string]string{
+	"id":           "id",
+	"name":         "name",
+	"price":        "price",
+	"is_active":    "is_active",
+	"user_created": "user_created",
+	"team_id":      "team_id",
+	"status":       "status",
+
+	"publication_status": "publication_status",
+	"content_rating":     "content_rating",
+	"series_id":          "series_id",
+	"volume_number":      "volume_number",
+	"stock_quantity":     "stock_quantity",
+	"view_count":         "view_count",
+	"average_rating":     "average_rating",
+	"review_count":       "review_count",
+	"created_at":         "created_at",
+	"updated_at":         "updated_at",
+
+	"genres":          "",
+	"images":          "",
+	"effective_price": "",
+	"creator":         "",
+}
+
+// ParseFields parses a field list like "id,name,price", accepting only whitelisted fields
+func ParseFields(raw string, allowed map[string]string) (Fields, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+
+	var fields Fields
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(raw, ",") {
+		field := strings.ToLower(strings.TrimSpace(part))
+		if _, ok := allowed[field]; !ok {
+			return nil, errors.New("cannot select field: " + field)
+		}
+		if seen[field] {
+			continue
+		}
+		seen[field] = true
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+// Has reports whether the field was selected; an empty selection selects everything
+func (f Fields) Has(field string) bool {
+	if len(f) == 0 {
+		return true
+	}
+	for _, selected := range f {
+		if selected == field {
+			return true
+		}
+	}
+	return false
+}
+
+// MangaColumns returns the mangas columns needed to serve the selection. The primary
+// key is always loaded, effective_price needs the price to compute a discount and
+// the creator is loaded through user_created.
+func (f Fields) MangaColumns() []string {
+	columns := []string{"id"}
+	for _, field := range f {
+		if column := MangaSelectableFields[field]; column != "" && column != "id" {
+			columns = append(columns, column)
+		}
+	}
+	if f.Has("effective_price") && !f.Has("price") {
+		columns = append(columns, "price")
+	}
+	if f.Has("creator") && !f.Has("user_created") {
+		columns = append(columns, "user_created")
+	}
+	return columns
+}
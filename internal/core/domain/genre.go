@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+// Genre represents a manga category used for browsing and filtering
+type Genre struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	Name      string    `json:"name" gorm:"not null"`
+	Slug      string    `json:"slug" gorm:"not null;uniqueIndex"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// GenreCount represents a genre with the number of mangas assigned to it
+type GenreCount struct {
+	ID         uint   `json:"id"`
+	Name       string `json:"name"`
+	Slug       string `json:"slug"`
+	MangaCount int64  `json:"manga_count"`
+}
+
+// IsValid checks if the genre has valid data
+func (g *Genre) IsValid() bool {
+	return g.Name != "" && g.Slug != ""
+}
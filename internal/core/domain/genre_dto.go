@@ -0,0 +1,7 @@
+package domain
+
+// GenreRequest represents the request body for creating or updating a genre
+type GenreRequest struct {
+	Name string `json:"name" validate:"required"`
+	Slug string `json:"slug" validate:"omitempty,max=64"`
+}
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+// Includes lists the related resources a client asked to embed in a response
+type Includes []string
+
+// MangaIncludes are the relations that may be embedded in manga responses
+var MangaIncludes = []string{"creator"}
+
+// ParseIncludes parses an include list like "creator", accepting only whitelisted relations
+func ParseIncludes(raw string, allowed []string) (Includes, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+
+	var includes Includes
+	for _, part := range strings.Split(raw, ",") {
+		include := strings.ToLower(strings.TrimSpace(part))
+		if !slices.Contains(allowed, include) {
+			return nil, errors.New("cannot include: " + include)
+		}
+		if !slices.Contains(includes, include) {
+			includes = append(includes, include)
+		}
+	}
+
+	return includes, nil
+}
+
+// Has reports whether the relation was asked for; unlike Fields, an empty list includes nothing
+func (i Includes) Has(include string) bool {
+	return slices.Contains(i, include)
+}
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Invoice is the bill for a paid order, or for a seller's part of it, with
+// its tax breakdown. It is built from the prices and tax lines copied at
+// checkout, so it reads the same however often it is fetched.
+type Invoice struct {
+	Number   string         `json:"number"`
+	OrderID  uint           `json:"order_id"`
+	BuyerID  uint           `json:"buyer_id"`
+	SellerID *uint          `json:"seller_id,omitempty"` // set on a seller's part of an order
+	IssuedAt time.Time      `json:"issued_at"`
+	Items    []OrderItem    `json:"items"`
+	TaxLines []OrderTaxLine `json:"tax_lines"`
+	Subtotal float64        `json:"subtotal"`
+	TaxTotal float64        `json:"tax_total"`
+	Total    float64        `json:"total"`
+}
+
+// InvoiceableOrderStatuses are the statuses of orders that have been paid
+var InvoiceableOrderStatuses = []string{OrderStatusPaid, OrderStatusShipped, OrderStatusFulfilled}
+
+// NewInvoice builds the invoice of an order, dated when the order was placed.
+// With a seller, the order is expected to hold only that seller's items, as
+// Order.ForSeller returns it.
+func NewInvoice(order *Order, sellerID *uint) *Invoice {
+	number := fmt.Sprintf("INV-%06d", order.ID)
+	if sellerID != nil {
+		number += fmt.Sprintf("-%d", *sellerID)
+	}
+	return &Invoice{
+		Number:   number,
+		OrderID:  order.ID,
+		BuyerID:  order.UserID,
+		SellerID: sellerID,
+		IssuedAt: order.CreatedAt,
+		Items:    order.Items,
+		TaxLines: order.TaxLines,
+		Subtotal: order.Subtotal,
+		TaxTotal: order.TaxTotal,
+		Total:    order.Total,
+	}
+}
@@ -0,0 +1,317 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Job statuses
+const (
+	JobStatusPending   = "pending" // waiting for its run time or a free worker
+	JobStatusRunning   = "running"
+	JobStatusSucceeded = "succeeded"
+	JobStatusDead      = "dead"      // failed every attempt, or failed for good; the dead-letter queue
+	JobStatusCancelled = "cancelled" // stopped by an admin before it finished
+)
+
+// JobStatuses lists every job status
+var JobStatuses = []string{JobStatusPending, JobStatusRunning, JobStatusSucceeded, JobStatusDead, JobStatusCancelled}
+
+// DefaultJobMaxAttempts is how many times a job runs before it is dead, unless
+// its kind's retry policy or its options set another limit
+const DefaultJobMaxAttempts = 5
+
+// ErrPermanentJobFailure marks a job error that retrying won't fix; wrap it
+// to make the job dead right away
+var ErrPermanentJobFailure = errors.New("permanent job failure")
+
+// Job is a unit of background work kept in the jobs table. Workers claim due
+// jobs, run the handler of their kind with the payload, and run failed jobs
+// again with the backoff of their kind's retry policy until MaxAttempts. A
+// running job whose worker stopped is claimed again once LockedUntil has
+// passed. Dead jobs stay until an admin requeues or discards them. Admins
+// may cancel pending and running jobs; a running job's handler is stopped
+// through its context.
+type Job struct {
+	ID          uint       `json:"id" gorm:"primarykey"`
+	Kind        string     `json:"kind" gorm:"not null;index"`
+	UserID      *uint      `json:"user_id,omitempty" gorm:"index"` // who the job runs for, if anyone
+	Payload     string     `json:"-" gorm:"type:text;not null"`    // JSON of the job args; may hold personal data
+	Status      string     `json:"status" gorm:"not null;index:idx_jobs_due,priority:1"`
+	Attempts    int        `json:"attempts" gorm:"not null;default:0"`
+	MaxAttempts int        `json:"max_attempts" gorm:"not null"`
+	RunAt       time.Time  `json:"run_at" gorm:"not null;index:idx_jobs_due,priority:2"`
+	LockedUntil *time.Time `json:"locked_until,omitempty"`
+	LastError   string     `json:"last_error,omitempty"`
+	FinishedAt  *time.Time `json:"finished_at,omitempty" gorm:"index"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+
+	// Progress is the percentage of the work done, for handlers that report
+	// it; it is 100 once the job has succeeded
+	Progress int `json:"progress" gorm:"not null;default:0"`
+
+	// ErrorLog lists the errors of every failed attempt, loaded only when a
+	// single job is inspected
+	ErrorLog []JobError `json:"error_log,omitempty" gorm:"foreignKey:JobID"`
+
+	// DownloadURL is the signed URL of the file of a finished export job,
+	// filled in for its user
+	DownloadURL string `json:"download_url,omitempty" gorm:"-"`
+
+	// Args is the payload, filled in when an admin inspects the job
+	Args json.RawMessage `json:"args,omitempty" gorm:"-"`
+}
+
+// JobArgs is the typed payload of a job. Its kind selects the handler that
+// runs it; the args are stored as JSON, so every field must survive a round trip.
+type JobArgs interface {
+	JobKind() string
+}
+
+// DecodeArgs decodes the job's payload into args, a pointer to its JobArgs; a
+// payload that doesn't decode is a permanent failure
+func (j *Job) DecodeArgs(args interface{}) error {
+	if err := json.Unmarshal([]byte(j.Payload), args); err != nil {
+		return fmt.Errorf("%w: invalid payload", ErrPermanentJobFailure)
+	}
+	return nil
+}
+
+// JobOptions adjust how an enqueued job runs; nil runs it as soon as possible
+// with the number of attempts of its kind's retry policy
+type JobOptions struct {
+	UserID      *uint
+	RunAt       time.Time
+	MaxAttempts int
+}
+
+// Job kinds
+const (
+	JobKindSendEmail           = "send_email"
+	JobKindSendSMS             = "send_sms"
+	JobKindPushLineMessage     = "push_line_message"
+	JobKindSendOnboardingEmail = "send_onboarding_email"
+	JobKindSendSellerReport    = "send_seller_report"
+	JobKindSendDigest          = "send_digest"
+	JobKindDeliverNotification = "deliver_notification"
+	JobKindRunScheduledTask    = "run_scheduled_task"
+	JobKindDeliverWebhook      = "deliver_webhook"
+	JobKindMakeThumbnail       = "make_thumbnail"
+	JobKindProcessImage        = "process_image"
+	JobKindExportMangas        = "export_mangas"
+	JobKindExportPersonalData  = "export_personal_data"
+	JobKindExportSalesReport   = "export_sales_report"
+	JobKindDeleteExport        = "delete_export"
+	JobKindExpireUpload        = "expire_upload"
+	JobKindPublishEvent        = "publish_event"
+)
+
+// SendEmailArgs sends an email
+type SendEmailArgs struct {
+	Message EmailMessage `json:"message"`
+}
+
+// JobKind implements JobArgs
+func (SendEmailArgs) JobKind() string { return JobKindSendEmail }
+
+// SendSMSArgs sends a text message
+type SendSMSArgs struct {
+	Message SMSMessage `json:"message"`
+}
+
+// JobKind implements JobArgs
+func (SendSMSArgs) JobKind() string { return JobKindSendSMS }
+
+// PushLineMessageArgs pushes a text message to a user's linked LINE account,
+// unless they have unlinked it by then
+type PushLineMessageArgs struct {
+	UserID uint   `json:"user_id"`
+	Text   string `json:"text"`
+}
+
+// JobKind implements JobArgs
+func (PushLineMessageArgs) JobKind() string { return JobKindPushLineMessage }
+
+// SendOnboardingEmailArgs sends an email of the onboarding sequence, unless
+// the user has unsubscribed from onboarding emails by then
+type SendOnboardingEmailArgs struct {
+	UserID uint   `json:"user_id"`
+	Email  string `json:"email"`
+}
+
+// JobKind implements JobArgs
+func (SendOnboardingEmailArgs) JobKind() string { return JobKindSendOnboardingEmail }
+
+// SendSellerReportArgs emails a seller their report of the period from From
+// until To, unless they have stopped seller reports by then
+type SendSellerReportArgs struct {
+	UserID    uint      `json:"user_id"`
+	Frequency string    `json:"frequency"`
+	From      time.Time `json:"from"`
+	To        time.Time `json:"to"`
+}
+
+// JobKind implements JobArgs
+func (SendSellerReportArgs) JobKind() string { return JobKindSendSellerReport }
+
+// SendDigestArgs emails a user their digest, unless they have stopped
+// digest emails by then
+type SendDigestArgs struct {
+	DigestID uint `json:"digest_id"`
+}
+
+// JobKind implements JobArgs
+func (SendDigestArgs) JobKind() string { return JobKindSendDigest }
+
+// DeliverNotificationArgs delivers a notification held through the user's
+// quiet hours on one channel
+type DeliverNotificationArgs struct {
+	Channel      string       `json:"channel"`
+	Notification Notification `json:"notification"`
+}
+
+// JobKind implements JobArgs
+func (DeliverNotificationArgs) JobKind() string { return JobKindDeliverNotification }
+
+// Scheduled tasks, which run in the background at their interval, and which
+// admins can also run at once
+const (
+	ScheduledTaskPurge         = "purge"
+	ScheduledTaskArchive       = "archive"
+	ScheduledTaskSellerReports = "seller_reports"
+	ScheduledTaskDigests       = "digests"
+)
+
+// RunScheduledTaskArgs runs a scheduled task once, outside its schedule
+type RunScheduledTaskArgs struct {
+	Task string `json:"task"`
+}
+
+// JobKind implements JobArgs
+func (RunScheduledTaskArgs) JobKind() string { return JobKindRunScheduledTask }
+
+// DeliverWebhookArgs makes one attempt at a recorded webhook delivery
+type DeliverWebhookArgs struct {
+	DeliveryID uint `json:"delivery_id"`
+}
+
+// JobKind implements JobArgs
+func (DeliverWebhookArgs) JobKind() string { return JobKindDeliverWebhook }
+
+// MakeThumbnailArgs makes the thumbnail of an uploaded manga image. Jobs of
+// this kind were queued before images had variants; they now process the
+// image as ProcessImageArgs does.
+type MakeThumbnailArgs struct {
+	ImageID uint `json:"image_id"`
+}
+
+// JobKind implements JobArgs
+func (MakeThumbnailArgs) JobKind() string { return JobKindMakeThumbnail }
+
+// ProcessImageArgs strips the metadata of an uploaded manga image and makes
+// its variants in every size and format
+type ProcessImageArgs struct {
+	ImageID uint `json:"image_id"`
+}
+
+// JobKind implements JobArgs
+func (ProcessImageArgs) JobKind() string { return JobKindProcessImage }
+
+// ExportMangasArgs exports the mangas of the job's user (all mangas for
+// admins) to a file they download once the job has succeeded
+type ExportMangasArgs struct {
+	Format  string   `json:"format"`
+	Columns []string `json:"columns,omitempty"`
+	IsAdmin bool     `json:"is_admin"`
+}
+
+// JobKind implements JobArgs
+func (ExportMangasArgs) JobKind() string { return JobKindExportMangas }
+
+// ExportPersonalDataArgs exports everything kept about the job's user to a
+// ZIP archive of JSON files, one per table
+type ExportPersonalDataArgs struct{}
+
+// JobKind implements JobArgs
+func (ExportPersonalDataArgs) JobKind() string { return JobKindExportPersonalData }
+
+// ExportSalesReportArgs exports the items the job's user sold in orders
+// placed from From until To to a CSV file
+type ExportSalesReportArgs struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// JobKind implements JobArgs
+func (ExportSalesReportArgs) JobKind() string { return JobKindExportSalesReport }
+
+const (
+	// ExportRetention is how long the file of an export job can be downloaded
+	ExportRetention = 24 * time.Hour
+	// ExportDownloadURLExpiry is how long a signed download URL of an export
+	// file is valid, within the retention period
+	ExportDownloadURLExpiry = 15 * time.Minute
+)
+
+// ExportKey is where the file of an export job is kept
+func ExportKey(jobID uint, format string) string {
+	return fmt.Sprintf("exports/%d.%s", jobID, format)
+}
+
+// ExportFile describes the file an export job writes
+type ExportFile struct {
+	Format      string // the file extension
+	ContentType string
+	Name        string // the file name downloads are saved as
+}
+
+// ExportFileOf describes the file of an export job; other jobs have none
+func ExportFileOf(job *Job) (*ExportFile, error) {
+	switch job.Kind {
+	case JobKindExportMangas:
+		var args ExportMangasArgs
+		if err := job.DecodeArgs(&args); err != nil {
+			return nil, err
+		}
+		if args.Format == "xlsx" {
+			return &ExportFile{Format: "xlsx", ContentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", Name: "mangas.xlsx"}, nil
+		}
+		return &ExportFile{Format: "csv", ContentType: "text/csv; charset=utf-8", Name: "mangas.csv"}, nil
+	case JobKindExportPersonalData:
+		return &ExportFile{Format: "zip", ContentType: "application/zip", Name: "personal-data.zip"}, nil
+	case JobKindExportSalesReport:
+		return &ExportFile{Format: "csv", ContentType: "text/csv; charset=utf-8", Name: "sales-report.csv"}, nil
+	default:
+		return nil, errors.New("job has no download")
+	}
+}
+
+// DeleteExportArgs deletes the file of an export job once it has expired
+type DeleteExportArgs struct {
+	JobID  uint   `json:"job_id"`
+	Format string `json:"format"`
+}
+
+// JobKind implements JobArgs
+func (DeleteExportArgs) JobKind() string { return JobKindDeleteExport }
+
+// ExpireUploadArgs removes a presigned upload once it can no longer be
+// confirmed, deleting its file unless it was confirmed
+type ExpireUploadArgs struct {
+	UploadID uint `json:"upload_id"`
+}
+
+// JobKind implements JobArgs
+func (ExpireUploadArgs) JobKind() string { return JobKindExpireUpload }
+
+// PublishEventArgs publishes a domain event to a message broker topic
+type PublishEventArgs struct {
+	Topic string      `json:"topic"`
+	Event *CloudEvent `json:"event"`
+}
+
+// JobKind implements JobArgs
+func (PublishEventArgs) JobKind() string { return JobKindPublishEvent }
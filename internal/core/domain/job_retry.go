@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Backoff curves, how the delay before a failed job runs again grows with
+// each failed attempt
+const (
+	BackoffExponential = "exponential" // doubles after each attempt
+	BackoffLinear      = "linear"      // grows by the initial delay after each attempt
+	BackoffConstant    = "constant"    // stays the same
+)
+
+// RetryPolicy is how a kind of job is retried: up to MaxAttempts runs, the
+// delays between them starting at Backoff and growing along Curve to at most
+// MaxBackoff
+type RetryPolicy struct {
+	MaxAttempts int
+	Backoff     time.Duration
+	MaxBackoff  time.Duration
+	Curve       string
+}
+
+// MarshalJSON writes the delays as durations like "1m30s"
+func (p RetryPolicy) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"max_attempts": p.MaxAttempts,
+		"backoff":      p.Backoff.String(),
+		"max_backoff":  p.MaxBackoff.String(),
+		"curve":        p.Curve,
+	})
+}
+
+// DefaultRetryPolicy applies to the job kinds without a policy of their own
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: DefaultJobMaxAttempts,
+	Backoff:     10 * time.Second,
+	MaxBackoff:  time.Hour,
+	Curve:       BackoffExponential,
+}
+
+// JobRetryPolicies are the retry policies of the job kinds that differ from
+// the default
+var JobRetryPolicies = map[string]RetryPolicy{
+	// Email providers and webhook endpoints may be down for hours
+	JobKindSendEmail:      {MaxAttempts: 8, Backoff: 30 * time.Second, MaxBackoff: 6 * time.Hour, Curve: BackoffExponential},
+	JobKindDeliverWebhook: {MaxAttempts: 8, Backoff: 30 * time.Second, MaxBackoff: 6 * time.Hour, Curve: BackoffExponential},
+	// Texts carry codes that expire within minutes, so they are not sent late
+	JobKindSendSMS: {MaxAttempts: 4, Backoff: 10 * time.Second, MaxBackoff: 2 * time.Minute, Curve: BackoffExponential},
+	// Brokers are usually back within minutes; events published late delay every consumer
+	JobKindPublishEvent: {MaxAttempts: 12, Backoff: 5 * time.Second, MaxBackoff: 15 * time.Minute, Curve: BackoffExponential},
+	// A broken image or export rarely fixes itself
+	JobKindProcessImage:       {MaxAttempts: 3, Backoff: 30 * time.Second, MaxBackoff: 5 * time.Minute, Curve: BackoffLinear},
+	JobKindMakeThumbnail:      {MaxAttempts: 3, Backoff: 30 * time.Second, MaxBackoff: 5 * time.Minute, Curve: BackoffLinear},
+	JobKindExportMangas:       {MaxAttempts: 3, Backoff: time.Minute, MaxBackoff: 10 * time.Minute, Curve: BackoffLinear},
+	JobKindExportPersonalData: {MaxAttempts: 3, Backoff: time.Minute, MaxBackoff: 10 * time.Minute, Curve: BackoffLinear},
+	JobKindExportSalesReport:  {MaxAttempts: 3, Backoff: time.Minute, MaxBackoff: 10 * time.Minute, Curve: BackoffLinear},
+	// Cleanups must happen eventually, but are never urgent
+	JobKindDeleteExport: {MaxAttempts: 10, Backoff: 15 * time.Minute, MaxBackoff: 15 * time.Minute, Curve: BackoffConstant},
+	JobKindExpireUpload: {MaxAttempts: 10, Backoff: 15 * time.Minute, MaxBackoff: 15 * time.Minute, Curve: BackoffConstant},
+}
+
+// RetryPolicyFor returns the retry policy of a job kind
+func RetryPolicyFor(kind string) RetryPolicy {
+	if policy, ok := JobRetryPolicies[kind]; ok {
+		return policy
+	}
+	return DefaultRetryPolicy
+}
+
+// Delay is how long a job waits after its given number of failed attempts
+func (p RetryPolicy) Delay(attempts int) time.Duration {
+	steps := max(attempts, 1) - 1
+	delay := p.Backoff
+	switch p.Curve {
+	case BackoffLinear:
+		delay = p.Backoff * time.Duration(steps+1)
+	case BackoffExponential:
+		for i := 0; i < steps && delay < p.MaxBackoff; i++ {
+			delay *= 2
+		}
+	}
+	if p.MaxBackoff > 0 {
+		delay = min(delay, p.MaxBackoff)
+	}
+	return delay
+}
+
+// JobError records a failed attempt at a job, kept for inspecting dead jobs
+type JobError struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	JobID     uint      `json:"job_id" gorm:"not null;index"`
+	Attempt   int       `json:"attempt" gorm:"not null"`
+	Error     string    `json:"error" gorm:"type:text;not null"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrLineDisabled is returned by the LINE features when no channel is configured
+var ErrLineDisabled = errors.New("LINE is not configured")
+
+// ErrLineRejected marks a LINE API failure that sending the request again
+// won't fix, such as a user who blocked the official account; other
+// failures are transient and retried
+var ErrLineRejected = errors.New("LINE request rejected")
+
+// LineLinkTTL is how long a user has to finish linking their LINE account,
+// which is how long LINE keeps a link token valid
+const LineLinkTTL = 10 * time.Minute
+
+// LINE webhook event types we act on
+const (
+	LineEventFollow      = "follow"      // the user added the official account as a friend
+	LineEventUnfollow    = "unfollow"    // the user blocked the official account
+	LineEventMessage     = "message"     // the user sent the official account a message
+	LineEventAccountLink = "accountLink" // the user finished or failed linking their account
+)
+
+// LineAccount links a user to their LINE account, so our official account
+// can push them notifications. The link is started by the user with a link
+// token from LINE, and finished by LINE with the nonce it was given.
+type LineAccount struct {
+	UserID     uint       `json:"user_id" gorm:"primarykey;autoIncrement:false"`
+	LineUserID *string    `json:"line_user_id,omitempty" gorm:"uniqueIndex"` // nil until linked
+	LinkedAt   *time.Time `json:"linked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+
+	// Nonce identifies the link in progress until NonceExpiresAt
+	Nonce          string     `json:"-" gorm:"index"`
+	NonceExpiresAt *time.Time `json:"-"`
+}
+
+// IsLinked reports whether notifications can be pushed to the user on LINE
+func (a *LineAccount) IsLinked() bool {
+	return a.LineUserID != nil
+}
+
+// LineEvent is an event of a LINE webhook request, reduced to what we use
+type LineEvent struct {
+	Type       string
+	ReplyToken string
+	UserID     string // the LINE user ID of the sender
+
+	// LinkResult ("ok" or "failed") and LinkNonce are set on accountLink events
+	LinkResult string
+	LinkNonce  string
+}
+
+// StartLineLinkRequest represents the request body for linking the signed-in
+// user to the LINE account the link token was issued for
+type StartLineLinkRequest struct {
+	LinkToken string `json:"link_token" validate:"required"`
+}
+
+// StartLineLinkResponse is where to send the user to confirm the link on LINE
+type StartLineLinkResponse struct {
+	RedirectURL string    `json:"redirect_url"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
@@ -0,0 +1,5 @@
+package domain
+
+// Links maps link relations (self, next, prev, chapters, ...) to URL paths so
+// clients can navigate the API without building URLs themselves
+type Links map[string]string
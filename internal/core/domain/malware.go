@@ -0,0 +1,28 @@
+package domain
+
+import "errors"
+
+// ErrMalwareDetected rejects an upload the malware scanner flagged
+var ErrMalwareDetected = errors.New("file was flagged as malware")
+
+// ScanResult is a malware scanner's verdict on a file
+type ScanResult struct {
+	Infected  bool
+	Signature string // the name of the malware found, when infected
+}
+
+// MalwareQuarantineKey is where a flagged file is kept in the private
+// storage, for admins to inspect
+func MalwareQuarantineKey(key string) string {
+	return "quarantine/" + key
+}
+
+// MalwareReport describes a flagged upload to the admins
+type MalwareReport struct {
+	Signature     string
+	UploaderID    uint
+	MangaID       uint
+	UploadID      *uint // the presigned upload, for files uploaded straight to storage
+	StorageKey    string
+	QuarantineKey string
+}
@@ -1,21 +1,131 @@
 package domain
 
 import (
+	"slices"
 	"time"
 
 	"gorm.io/gorm"
 )
 
+// Manga listing statuses
+const (
+	MangaStatusDraft         = "draft"
+	MangaStatusPendingReview = "pending_review"
+	MangaStatusPublished     = "published"
+	MangaStatusRejected      = "rejected"
+)
+
+// mangaStatusTransitions lists the statuses each status may move to
+var mangaStatusTransitions = map[string][]string{
+	MangaStatusDraft:         {MangaStatusPendingReview},
+	MangaStatusPendingReview: {MangaStatusPublished, MangaStatusRejected},
+	MangaStatusRejected:      {MangaStatusPendingReview},
+}
+
+// MangaStatusesFrom returns the statuses that may transition to the given status
+func MangaStatusesFrom(to string) []string {
+	var from []string
+	for status, targets := range mangaStatusTransitions {
+		for _, target := range targets {
+			if target == to {
+				from = append(from, status)
+			}
+		}
+	}
+	return from
+}
+
+// Manga publication statuses describe the series' run, independent of the
+// listing status and the is_active toggle
+const (
+	PublicationStatusOngoing   = "ongoing"
+	PublicationStatusCompleted = "completed"
+	PublicationStatusHiatus    = "hiatus"
+	PublicationStatusCancelled = "cancelled"
+)
+
+// PublicationStatuses lists every publication status in display order
+var PublicationStatuses = []string{
+	PublicationStatusOngoing,
+	PublicationStatusCompleted,
+	PublicationStatusHiatus,
+	PublicationStatusCancelled,
+}
+
+// IsValidPublicationStatus reports whether s is a known publication status
+func IsValidPublicationStatus(s string) bool {
+	return slices.Contains(PublicationStatuses, s)
+}
+
+// Manga content ratings; mature mangas are only shown to verified adults
+const (
+	ContentRatingAllAges = "all_ages"
+	ContentRatingTeen    = "teen"
+	ContentRatingMature  = "mature"
+)
+
+// ContentRatings lists every content rating from least to most restricted
+var ContentRatings = []string{
+	ContentRatingAllAges,
+	ContentRatingTeen,
+	ContentRatingMature,
+}
+
+// IsValidContentRating reports whether s is a known content rating
+func IsValidContentRating(s string) bool {
+	return slices.Contains(ContentRatings, s)
+}
+
 // Manga represents the manga entity in the domain
 type Manga struct {
-	ID          uint           `json:"id" gorm:"primarykey"`
-	Name        string         `json:"name" gorm:"not null"`
-	Price       float64        `json:"price" gorm:"not null"`
-	IsActive    bool           `json:"is_active" gorm:"default:true"`
-	UserCreated uint           `json:"user_created" gorm:"not null"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
+	ID          uint    `json:"id" gorm:"primarykey"`
+	Name        string  `json:"name" gorm:"not null"`
+	Price       float64 `json:"price" gorm:"not null"`
+	IsActive    bool    `json:"is_active" gorm:"default:true"`
+	UserCreated uint    `json:"user_created" gorm:"not null"`
+	TeamID      *uint   `json:"team_id,omitempty" gorm:"index"`
+	Genres      []Genre `json:"genres,omitempty" gorm:"many2many:manga_genres"`
+
+	// Owner is only loaded when requested with ?include=creator, and exposed
+	// as Creator, its public profile. Migrations add no foreign key for it.
+	Owner   *User               `json:"-" gorm:"foreignKey:UserCreated;constraint:-"`
+	Creator *PublicUserResponse `json:"creator,omitempty" gorm:"-"`
+
+	// Gallery images in display order, managed through the manga image endpoints
+	Images []MangaImage `json:"images,omitempty"`
+
+	// Localized titles and descriptions, managed through the manga translation
+	// endpoints; Translation is the one picked for the current request
+	Translations []MangaTranslation `json:"-"`
+	Translation  *MangaTranslation  `json:"translation,omitempty" gorm:"-"`
+
+	// Series membership, maintained by the series repository
+	SeriesID     *uint `json:"series_id,omitempty" gorm:"index"`
+	VolumeNumber *int  `json:"volume_number,omitempty"`
+
+	// Listing status; only published mangas are publicly visible
+	Status          string `json:"status" gorm:"not null;default:published;index"`
+	RejectionReason string `json:"rejection_reason,omitempty"`
+
+	PublicationStatus string `json:"publication_status" gorm:"not null;default:ongoing;index"`
+	ContentRating     string `json:"content_rating" gorm:"not null;default:all_ages;index"`
+
+	StockQuantity int   `json:"stock_quantity" gorm:"not null;default:0;index"`
+	ViewCount     int64 `json:"view_count" gorm:"not null;default:0"`
+
+	// EffectivePrice is the price after the best active discount, set only when one applies
+	EffectivePrice *float64 `json:"effective_price,omitempty" gorm:"-"`
+
+	// Denormalized review summary, maintained by the review repository
+	AverageRating float64 `json:"average_rating" gorm:"not null;default:0"`
+	ReviewCount   int     `json:"review_count" gorm:"not null;default:0"`
+
+	// Links to the manga and its sub-resources, set by the HTTP layer
+	Links Links `json:"links,omitempty" gorm:"-"`
+
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
 }
 
 // IsValid checks if the manga has valid data
@@ -23,6 +133,14 @@ func (m *Manga) IsValid() bool {
 	return m.Name != "" && m.Price >= 0 && m.UserCreated > 0
 }
 
+// creator returns the public profile of the loaded owner, if any
+func (m *Manga) creator() *PublicUserResponse {
+	if m.Owner != nil {
+		return NewPublicUserResponse(m.Owner)
+	}
+	return m.Creator
+}
+
 // Sanitize removes sensitive data from manga before returning
 func (m *Manga) Sanitize() *Manga {
 	return &Manga{
@@ -31,7 +149,34 @@ func (m *Manga) Sanitize() *Manga {
 		Price:       m.Price,
 		IsActive:    m.IsActive,
 		UserCreated: m.UserCreated,
-		CreatedAt:   m.CreatedAt,
-		UpdatedAt:   m.UpdatedAt,
+		TeamID:      m.TeamID,
+		Genres:      m.Genres,
+		Creator:     m.creator(),
+
+		Images: m.Images,
+
+		Translations: m.Translations,
+		Translation:  m.Translation,
+
+		SeriesID:     m.SeriesID,
+		VolumeNumber: m.VolumeNumber,
+
+		Status:          m.Status,
+		RejectionReason: m.RejectionReason,
+
+		PublicationStatus: m.PublicationStatus,
+		ContentRating:     m.ContentRating,
+
+		StockQuantity:  m.StockQuantity,
+		ViewCount:      m.ViewCount,
+		EffectivePrice: m.EffectivePrice,
+
+		AverageRating: m.AverageRating,
+		ReviewCount:   m.ReviewCount,
+
+		Links: m.Links,
+
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
 	}
 }
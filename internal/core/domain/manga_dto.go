@@ -5,6 +5,14 @@ type CreateMangaRequest struct {
 	Name     string  `json:"name" validate:"required"`
 	Price    float64 `json:"price" validate:"required,min=0"`
 	IsActive bool    `json:"is_active"`
+	Stock    int     `json:"stock_quantity" validate:"min=0"`
+	TeamID   *uint   `json:"team_id"`
+	GenreIDs []uint  `json:"genre_ids"`
+	Submit   bool    `json:"submit"` // submit for review right away instead of saving as draft
+	Force    bool    `json:"force"`  // create even if likely duplicates exist
+
+	PublicationStatus string `json:"publication_status" validate:"omitempty,oneof=ongoing completed hiatus cancelled"` // defaults to ongoing
+	ContentRating     string `json:"content_rating" validate:"omitempty,oneof=all_ages teen mature"`                   // defaults to all_ages
 }
 
 // UpdateMangaRequest represents the request body for updating a manga
@@ -12,6 +20,89 @@ type UpdateMangaRequest struct {
 	Name     string  `json:"name" validate:"required"`
 	Price    float64 `json:"price" validate:"required,min=0"`
 	IsActive bool    `json:"is_active"`
+	GenreIDs []uint  `json:"genre_ids"` // nil leaves genres unchanged, empty clears them
+
+	PublicationStatus string `json:"publication_status" validate:"omitempty,oneof=ongoing completed hiatus cancelled"` // empty leaves it unchanged
+	ContentRating     string `json:"content_rating" validate:"omitempty,oneof=all_ages teen mature"`                   // empty leaves it unchanged
+
+	// IfMatch is the ETag the update is based on; the update is rejected when
+	// the manga has changed since. Empty skips the check.
+	IfMatch string `json:"-"`
+}
+
+// StockAdjustRequest represents the request body for adjusting a manga's stock
+type StockAdjustRequest struct {
+	Delta  int    `json:"delta" validate:"required"`
+	Reason string `json:"reason" validate:"max=255"` // recorded in the audit log
+}
+
+// DuplicateCandidate describes an existing manga that looks like a duplicate
+type DuplicateCandidate struct {
+	ID         uint    `json:"id"`
+	Name       string  `json:"name"`
+	OwnerID    uint    `json:"owner_id"`
+	SameOwner  bool    `json:"same_owner"`
+	Similarity float64 `json:"similarity"`
+}
+
+// DuplicateMangaError is returned when a new manga likely duplicates existing ones
+type DuplicateMangaError struct {
+	Candidates []DuplicateCandidate
+}
+
+func (e *DuplicateMangaError) Error() string {
+	return "possible duplicate manga found"
+}
+
+// MangaPatch represents a partial manga update; nil fields are left unchanged
+type MangaPatch struct {
+	Name     *string  `json:"name" validate:"omitempty,min=1"`
+	Price    *float64 `json:"price" validate:"omitempty,min=0"`
+	IsActive *bool    `json:"is_active"`
+
+	PublicationStatus *string `json:"publication_status" validate:"omitempty,oneof=ongoing completed hiatus cancelled"`
+	ContentRating     *string `json:"content_rating" validate:"omitempty,oneof=all_ages teen mature"`
+}
+
+// IsEmpty reports whether the patch changes nothing
+func (p *MangaPatch) IsEmpty() bool {
+	return p.Name == nil && p.Price == nil && p.IsActive == nil && p.PublicationStatus == nil &&
+		p.ContentRating == nil
+}
+
+// BatchUpdateMangasRequest represents the request body for updating several mangas at once
+type BatchUpdateMangasRequest struct {
+	IDs    []uint     `json:"ids" validate:"required,min=1,max=100"`
+	Update MangaPatch `json:"update"`
+}
+
+// BatchDeleteMangasRequest represents the request body for deleting several mangas at once
+type BatchDeleteMangasRequest struct {
+	IDs []uint `json:"ids" validate:"required,min=1,max=100"`
+}
+
+// BatchItemResult reports the outcome of a batch operation for one manga
+type BatchItemResult struct {
+	ID      uint   `json:"id"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// BatchResult summarizes a batch operation
+type BatchResult struct {
+	Succeeded int               `json:"succeeded"`
+	Failed    int               `json:"failed"`
+	Results   []BatchItemResult `json:"results"`
+}
+
+// MangaFacets reports how many listed mangas fall into each facet value
+type MangaFacets struct {
+	PublicationStatus map[string]int64 `json:"publication_status"`
+}
+
+// RejectMangaRequest represents the request body for rejecting a manga listing
+type RejectMangaRequest struct {
+	Reason string `json:"reason" validate:"required,max=500"`
 }
 
 // MangaResponse represents manga data for API responses
@@ -0,0 +1,53 @@
+package domain
+
+import "errors"
+
+// maxMangaQueryLength bounds the free-text search term
+const maxMangaQueryLength = 100
+
+// MangaFilter holds the combinable filters accepted by manga listings.
+// Nil and empty fields do not constrain the result.
+type MangaFilter struct {
+	IsActive *bool
+	MinPrice *float64
+	MaxPrice *float64
+	UserID   *uint
+	Genre    string
+	Query    string
+
+	PublicationStatus string
+	ContentRating     string
+
+	// IncludeMature lets verified adults see mature mangas, which are hidden otherwise
+	IncludeMature bool
+
+	// SeriesID limits results to one series; Ungrouped to volumes in no series
+	SeriesID  *uint
+	Ungrouped bool
+}
+
+// Validate checks that the filter values are consistent
+func (f *MangaFilter) Validate() error {
+	if f.MinPrice != nil && *f.MinPrice < 0 {
+		return errors.New("min_price must not be negative")
+	}
+	if f.MaxPrice != nil && *f.MaxPrice < 0 {
+		return errors.New("max_price must not be negative")
+	}
+	if f.MinPrice != nil && f.MaxPrice != nil && *f.MinPrice > *f.MaxPrice {
+		return errors.New("min_price must not exceed max_price")
+	}
+	if f.PublicationStatus != "" && !IsValidPublicationStatus(f.PublicationStatus) {
+		return errors.New("publication_status must be one of ongoing, completed, hiatus, cancelled")
+	}
+	if f.ContentRating != "" && !IsValidContentRating(f.ContentRating) {
+		return errors.New("content_rating must be one of all_ages, teen, mature")
+	}
+	if f.SeriesID != nil && f.Ungrouped {
+		return errors.New("series_id and ungrouped cannot be combined")
+	}
+	if len(f.Query) > maxMangaQueryLength {
+		return errors.New("q must be at most 100 characters")
+	}
+	return nil
+}
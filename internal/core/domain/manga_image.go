@@ -0,0 +1,142 @@
+package domain
+
+import (
+	"path"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Manga gallery limits
+const (
+	MaxMangaImages       = 20
+	MaxMangaImageSize    = 3 << 20 // bytes
+	MangaThumbnailSize   = 320     // pixels on the longer side
+	MaxMangaImageCaption = 500
+
+	// MaxMangaImageUploadSize bounds the whole upload request: the image plus
+	// the caption and multipart framing
+	MaxMangaImageUploadSize = MaxMangaImageSize + 64<<10
+)
+
+// MangaImageTypes maps the accepted image content types to their file extensions
+var MangaImageTypes = map[string]string{
+	"image/jpeg": "jpg",
+	"image/png":  "png",
+	"image/gif":  "gif",
+}
+
+// Image variant sizes
+const (
+	ImageSizeSmall  = "small"
+	ImageSizeMedium = "medium"
+	ImageSizeLarge  = "large"
+)
+
+// ImageSize is a variant size and the pixels on its longer side
+type ImageSize struct {
+	Name    string
+	MaxSize int
+}
+
+// ImageSizes lists the variant sizes, smallest first. The medium JPEG is the
+// image's thumbnail.
+var ImageSizes = []ImageSize{
+	{ImageSizeSmall, 160},
+	{ImageSizeMedium, MangaThumbnailSize},
+	{ImageSizeLarge, 1024},
+}
+
+// IsValidImageSize reports whether name is one of the variant sizes
+func IsValidImageSize(name string) bool {
+	return slices.ContainsFunc(ImageSizes, func(size ImageSize) bool { return size.Name == name })
+}
+
+// Image variant formats. JPEG variants are always made; the others only when
+// an encoder for them is installed.
+const (
+	ImageFormatJPEG = "jpeg"
+	ImageFormatWebP = "webp"
+	ImageFormatAVIF = "avif"
+)
+
+// ImageFormats maps the variant formats to their file extensions
+var ImageFormats = map[string]string{
+	ImageFormatJPEG: "jpg",
+	ImageFormatWebP: "webp",
+	ImageFormatAVIF: "avif",
+}
+
+// MangaImage is one picture in a manga's ordered gallery
+type MangaImage struct {
+	ID           uint                `json:"id" gorm:"primarykey"`
+	MangaID      uint                `json:"manga_id" gorm:"not null;index"`
+	Position     int                 `json:"position" gorm:"not null"`
+	Caption      string              `json:"caption"`
+	URL          string              `json:"url" gorm:"not null"`
+	ThumbnailURL string              `json:"thumbnail_url" gorm:"not null"`
+	Width        int                 `json:"width,omitempty"`
+	Height       int                 `json:"height,omitempty"`
+	Variants     []MangaImageVariant `json:"variants,omitempty" gorm:"serializer:json;type:jsonb"`
+	StorageKey   string              `json:"-" gorm:"not null"`
+	ThumbnailKey string              `json:"-" gorm:"not null"`
+	CreatedAt    time.Time           `json:"created_at"`
+	UpdatedAt    time.Time           `json:"updated_at"`
+
+	// SrcURL is the URL of the variant picked for the client by PickSrcURL
+	SrcURL string `json:"src_url,omitempty" gorm:"-"`
+}
+
+// MangaImageVariant is a resized copy of a gallery image in one format
+type MangaImageVariant struct {
+	Size   string `json:"size"`
+	Format string `json:"format"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	URL    string `json:"url"`
+}
+
+// VariantKey is where the image's variant of the given size and format is stored
+func (i *MangaImage) VariantKey(size, format string) string {
+	return strings.TrimSuffix(i.StorageKey, path.Ext(i.StorageKey)) + "_" + size + "." + ImageFormats[format]
+}
+
+// PickVariant returns the image's variant of the given size in the first of
+// the formats it has, falling back to JPEG; nil when it has no such variant
+func (i *MangaImage) PickVariant(size string, formats []string) *MangaImageVariant {
+	var picked *MangaImageVariant
+	pickedRank := len(formats) + 1
+	for j := range i.Variants {
+		variant := &i.Variants[j]
+		if variant.Size != size {
+			continue
+		}
+		rank := slices.Index(formats, variant.Format)
+		if rank < 0 {
+			if variant.Format != ImageFormatJPEG {
+				continue
+			}
+			rank = len(formats)
+		}
+		if rank < pickedRank {
+			picked, pickedRank = variant, rank
+		}
+	}
+	return picked
+}
+
+// PickSrcURL sets SrcURL to the URL of the image's variant picked by
+// PickVariant, or to its original URL while it is still being processed
+func (i *MangaImage) PickSrcURL(size string, formats []string) {
+	i.SrcURL = i.URL
+	if variant := i.PickVariant(size, formats); variant != nil {
+		i.SrcURL = variant.URL
+	}
+}
+
+// PickImageVariants sets the SrcURL of each of the manga's images
+func (m *Manga) PickImageVariants(size string, formats []string) {
+	for i := range m.Images {
+		m.Images[i].PickSrcURL(size, formats)
+	}
+}
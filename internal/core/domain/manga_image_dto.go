@@ -0,0 +1,28 @@
+package domain
+
+import "io"
+
+// UploadMangaImageRequest carries an uploaded gallery image and its caption.
+// The file is read more than once, so it must be seekable.
+type UploadMangaImageRequest struct {
+	File    io.ReadSeeker
+	Caption string
+}
+
+// AddStoredMangaImageRequest points at a gallery image a client uploaded
+// straight to storage
+type AddStoredMangaImageRequest struct {
+	StorageKey string
+	URL        string
+	Caption    string
+}
+
+// UpdateMangaImageRequest represents the request body for changing an image's caption
+type UpdateMangaImageRequest struct {
+	Caption string `json:"caption" validate:"max=500"`
+}
+
+// ReorderMangaImagesRequest lists every image of a gallery in its new order
+type ReorderMangaImagesRequest struct {
+	ImageIDs []uint `json:"image_ids" validate:"required,min=1"`
+}
@@ -0,0 +1,24 @@
+package domain
+
+// MangaImportRow is one parsed row of a bulk manga import file
+type MangaImportRow struct {
+	Row     int
+	Request *CreateMangaRequest
+	Error   string // parse or validation error, set before import
+}
+
+// MangaImportRowResult reports the outcome of importing a single row
+type MangaImportRowResult struct {
+	Row     int    `json:"row"`
+	Success bool   `json:"success"`
+	MangaID uint   `json:"manga_id,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// MangaImportReport summarizes a bulk manga import
+type MangaImportReport struct {
+	Total    int                    `json:"total"`
+	Imported int                    `json:"imported"`
+	Failed   int                    `json:"failed"`
+	Rows     []MangaImportRowResult `json:"rows"`
+}
@@ -0,0 +1,44 @@
+package domain
+
+import "time"
+
+// Manga relation types. A relation reads "RelatedID is the Type of MangaID",
+// e.g. {MangaID: 1, RelatedID: 2, Type: sequel} means manga 2 is the sequel of manga 1.
+const (
+	MangaRelationSequel   = "sequel"
+	MangaRelationPrequel  = "prequel"
+	MangaRelationSpinOff  = "spin_off"
+	MangaRelationOriginal = "original"
+)
+
+// mangaRelationInverses maps each relation type to the type stored in the other direction
+var mangaRelationInverses = map[string]string{
+	MangaRelationSequel:   MangaRelationPrequel,
+	MangaRelationPrequel:  MangaRelationSequel,
+	MangaRelationSpinOff:  MangaRelationOriginal,
+	MangaRelationOriginal: MangaRelationSpinOff,
+}
+
+// InverseMangaRelation returns the relation type seen from the related manga
+func InverseMangaRelation(relationType string) string {
+	return mangaRelationInverses[relationType]
+}
+
+// MangaRelation links two mangas. Every link is stored in both directions
+// so either side can list it.
+type MangaRelation struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	MangaID   uint      `json:"manga_id" gorm:"not null;uniqueIndex:idx_manga_relations_pair"`
+	RelatedID uint      `json:"related_id" gorm:"not null;uniqueIndex:idx_manga_relations_pair;index"`
+	Type      string    `json:"type" gorm:"not null"`
+	CreatedBy uint      `json:"created_by" gorm:"not null"`
+	CreatedAt time.Time `json:"created_at"`
+
+	Related *Manga `json:"related,omitempty" gorm:"foreignKey:RelatedID"`
+}
+
+// CreateMangaRelationRequest represents the request body for linking two mangas
+type CreateMangaRelationRequest struct {
+	RelatedID uint   `json:"related_id" validate:"required"`
+	Type      string `json:"type" validate:"required,oneof=sequel prequel spin_off original"`
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// Supported translation locales
+const (
+	LocaleEnglish  = "en"
+	LocaleThai     = "th"
+	LocaleJapanese = "ja"
+)
+
+// MangaLocales lists every locale a manga may be translated into
+var MangaLocales = []string{
+	LocaleEnglish,
+	LocaleThai,
+	LocaleJapanese,
+}
+
+// IsValidMangaLocale reports whether s is a supported translation locale
+func IsValidMangaLocale(s string) bool {
+	return slices.Contains(MangaLocales, s)
+}
+
+// MangaTranslation is a manga's title and description in one locale
+type MangaTranslation struct {
+	ID          uint      `json:"-" gorm:"primarykey"`
+	MangaID     uint      `json:"manga_id" gorm:"not null;uniqueIndex:idx_manga_translations_locale"`
+	Locale      string    `json:"locale" gorm:"size:8;not null;uniqueIndex:idx_manga_translations_locale"`
+	Name        string    `json:"name" gorm:"not null"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Localize replaces the manga's name with its translation in the given
+// locale, when it has one, and exposes the translation alongside
+func (m *Manga) Localize(locale string) {
+	for i := range m.Translations {
+		if m.Translations[i].Locale == locale {
+			m.Name = m.Translations[i].Name
+			m.Translation = &m.Translations[i]
+			return
+		}
+	}
+}
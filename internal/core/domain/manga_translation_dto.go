@@ -0,0 +1,7 @@
+package domain
+
+// SaveMangaTranslationRequest represents the request body for setting a manga's translation in one locale
+type SaveMangaTranslationRequest struct {
+	Name        string `json:"name" validate:"required,min=1,max=255"`
+	Description string `json:"description" validate:"max=5000"`
+}
@@ -0,0 +1,100 @@
+package domain
+
+import "time"
+
+// MangaPriceV2 groups a manga's list price with the price buyers pay
+type MangaPriceV2 struct {
+	Amount     float64 `json:"amount"`
+	Effective  float64 `json:"effective"`
+	Discounted bool    `json:"discounted"`
+}
+
+// MangaRatingV2 groups a manga's review summary
+type MangaRatingV2 struct {
+	Average float64 `json:"average"`
+	Count   int     `json:"count"`
+}
+
+// MangaV2Response is the API v2 representation of a manga. Compared to v1 the
+// owner is named owner_id, the price and review summary are grouped, and the
+// effective price is always present.
+type MangaV2Response struct {
+	ID       uint         `json:"id"`
+	Name     string       `json:"name"`
+	OwnerID  uint         `json:"owner_id"`
+	TeamID   *uint        `json:"team_id,omitempty"`
+	IsActive bool         `json:"is_active"`
+	Genres   []Genre      `json:"genres"`
+	Images   []MangaImage `json:"images,omitempty"`
+
+	SeriesID     *uint `json:"series_id,omitempty"`
+	VolumeNumber *int  `json:"volume_number,omitempty"`
+
+	Status            string `json:"status"`
+	PublicationStatus string `json:"publication_status"`
+	ContentRating     string `json:"content_rating"`
+
+	Price         MangaPriceV2  `json:"price"`
+	Rating        MangaRatingV2 `json:"rating"`
+	StockQuantity int           `json:"stock_quantity"`
+	ViewCount     int64         `json:"view_count"`
+
+	Creator     *PublicUserResponse `json:"creator,omitempty"`
+	Translation *MangaTranslation   `json:"translation,omitempty"`
+	Links       Links               `json:"links,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewMangaV2Response maps a manga to its v2 representation
+func NewMangaV2Response(m *Manga) *MangaV2Response {
+	price := MangaPriceV2{Amount: m.Price, Effective: m.Price}
+	if m.EffectivePrice != nil {
+		price.Effective = *m.EffectivePrice
+		price.Discounted = true
+	}
+
+	genres := m.Genres
+	if genres == nil {
+		genres = []Genre{}
+	}
+
+	return &MangaV2Response{
+		ID:       m.ID,
+		Name:     m.Name,
+		OwnerID:  m.UserCreated,
+		TeamID:   m.TeamID,
+		IsActive: m.IsActive,
+		Genres:   genres,
+		Images:   m.Images,
+
+		SeriesID:     m.SeriesID,
+		VolumeNumber: m.VolumeNumber,
+
+		Status:            m.Status,
+		PublicationStatus: m.PublicationStatus,
+		ContentRating:     m.ContentRating,
+
+		Price:         price,
+		Rating:        MangaRatingV2{Average: m.AverageRating, Count: m.ReviewCount},
+		StockQuantity: m.StockQuantity,
+		ViewCount:     m.ViewCount,
+
+		Creator:     m.Creator,
+		Translation: m.Translation,
+		Links:       m.Links,
+
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
+
+// NewMangaV2Responses maps a list of mangas to their v2 representation
+func NewMangaV2Responses(mangas []*Manga) []*MangaV2Response {
+	responses := make([]*MangaV2Response, len(mangas))
+	for i, manga := range mangas {
+		responses[i] = NewMangaV2Response(manga)
+	}
+	return responses
+}
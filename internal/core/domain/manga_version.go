@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// MangaSnapshot captures the user-editable state of a manga at one version
+type MangaSnapshot struct {
+	Name     string  `json:"name"`
+	Price    float64 `json:"price"`
+	IsActive bool    `json:"is_active"`
+	GenreIDs []uint  `json:"genre_ids"`
+
+	PublicationStatus string `json:"publication_status,omitempty"`
+	ContentRating     string `json:"content_rating,omitempty"`
+}
+
+// FieldChange describes how a single field changed between two versions
+type FieldChange struct {
+	Field string      `json:"field"`
+	Old   interface{} `json:"old"`
+	New   interface{} `json:"new"`
+}
+
+// MangaVersion is a numbered snapshot of a manga recorded on create and on every change
+type MangaVersion struct {
+	ID        uint          `json:"id" gorm:"primarykey"`
+	MangaID   uint          `json:"manga_id" gorm:"not null;uniqueIndex:idx_manga_versions_manga_version"`
+	Version   int           `json:"version" gorm:"not null;uniqueIndex:idx_manga_versions_manga_version"`
+	ChangedBy *uint         `json:"changed_by,omitempty"`
+	Snapshot  MangaSnapshot `json:"snapshot" gorm:"serializer:json;type:jsonb;not null"`
+	Changes   []FieldChange `json:"changes,omitempty" gorm:"serializer:json;type:jsonb"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+// NewMangaSnapshot captures the current state of a manga
+func NewMangaSnapshot(m *Manga) MangaSnapshot {
+	genreIDs := make([]uint, len(m.Genres))
+	for i, genre := range m.Genres {
+		genreIDs[i] = genre.ID
+	}
+	slices.Sort(genreIDs)
+
+	return MangaSnapshot{
+		Name:     m.Name,
+		Price:    m.Price,
+		IsActive: m.IsActive,
+		GenreIDs: genreIDs,
+
+		PublicationStatus: m.PublicationStatus,
+		ContentRating:     m.ContentRating,
+	}
+}
+
+// Diff lists the fields that differ from an earlier snapshot
+func (s MangaSnapshot) Diff(old MangaSnapshot) []FieldChange {
+	var changes []FieldChange
+	if s.Name != old.Name {
+		changes = append(changes, FieldChange{Field: "name", Old: old.Name, New: s.Name})
+	}
+	if s.Price != old.Price {
+		changes = append(changes, FieldChange{Field: "price", Old: old.Price, New: s.Price})
+	}
+	if s.IsActive != old.IsActive {
+		changes = append(changes, FieldChange{Field: "is_active", Old: old.IsActive, New: s.IsActive})
+	}
+	if s.PublicationStatus != old.PublicationStatus {
+		changes = append(changes, FieldChange{Field: "publication_status", Old: old.PublicationStatus, New: s.PublicationStatus})
+	}
+	if s.ContentRating != old.ContentRating {
+		changes = append(changes, FieldChange{Field: "content_rating", Old: old.ContentRating, New: s.ContentRating})
+	}
+	if !slices.Equal(s.GenreIDs, old.GenreIDs) {
+		changes = append(changes, FieldChange{Field: "genre_ids", Old: old.GenreIDs, New: s.GenreIDs})
+	}
+	return changes
+}
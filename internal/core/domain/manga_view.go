@@ -0,0 +1,10 @@
+package domain
+
+import "time"
+
+// MangaView holds the number of views a manga received on a given day
+type MangaView struct {
+	MangaID uint      `json:"manga_id" gorm:"primaryKey"`
+	Day     time.Time `json:"day" gorm:"primaryKey;type:date;index"`
+	Count   int64     `json:"count" gorm:"not null;default:0"`
+}
@@ -0,0 +1,115 @@
+package domain
+
+import "time"
+
+// Email categories users can unsubscribe from. Account and order emails are
+// always sent.
+const (
+	NotificationOnboarding    = "onboarding"
+	NotificationSellerReports = "seller_reports"
+	NotificationDigests       = "digests"
+)
+
+// NotificationCategories lists the email categories users can unsubscribe from
+var NotificationCategories = []string{NotificationOnboarding, NotificationSellerReports, NotificationDigests}
+
+// NotificationPreferences are the email categories a user receives. Users
+// without stored preferences receive every category but seller reports,
+// which users opt into, and get daily digests by email.
+type NotificationPreferences struct {
+	UserID           uint      `json:"user_id" gorm:"primarykey;autoIncrement:false"`
+	OnboardingEmails bool      `json:"onboarding_emails" gorm:"not null"`
+	UpdatedAt        time.Time `json:"updated_at"`
+
+	// SellerReports is how often the user is emailed a report of their
+	// sales: off, weekly or monthly
+	SellerReports string `json:"seller_reports" gorm:"not null;default:off;index"`
+	// SellerReportSentUntil is the end of the period of the last seller report
+	// queued for the user
+	SellerReportSentUntil *time.Time `json:"-"`
+
+	// Digests is how often the user's low-priority notifications, such as
+	// price drops on their wishlists, are batched into a digest: off, daily
+	// or weekly. DigestDelivery is email or in_app.
+	Digests        string `json:"digests" gorm:"not null;default:daily"`
+	DigestDelivery string `json:"digest_delivery" gorm:"not null;default:email"`
+
+	// Routes are the channels of the notification types the user routed
+	// themselves; the others follow DefaultNotificationRoutes
+	Routes map[string][]string `json:"routes" gorm:"serializer:json;type:jsonb"`
+
+	// Notifications are held from QuietHoursStart until QuietHoursEnd, such
+	// as "22:00" and "07:00" in TimeZone, except in the app; empty is off
+	QuietHoursStart string `json:"quiet_hours_start"`
+	QuietHoursEnd   string `json:"quiet_hours_end"`
+	TimeZone        string `json:"time_zone" gorm:"not null;default:Asia/Bangkok"`
+}
+
+// DefaultNotificationPreferences returns the preferences of a user who has
+// not changed them
+func DefaultNotificationPreferences(userID uint) *NotificationPreferences {
+	return &NotificationPreferences{
+		UserID:           userID,
+		OnboardingEmails: true,
+		SellerReports:    SellerReportsOff,
+		Digests:          DigestsDaily,
+		DigestDelivery:   DigestDeliveryEmail,
+		TimeZone:         DefaultTimeZone,
+	}
+}
+
+// Allows reports whether the user receives emails of the category
+func (p *NotificationPreferences) Allows(category string) bool {
+	switch category {
+	case NotificationOnboarding:
+		return p.OnboardingEmails
+	case NotificationSellerReports:
+		return p.SellerReports != SellerReportsOff
+	case NotificationDigests:
+		return p.Digests != DigestsOff && p.DigestDelivery == DigestDeliveryEmail
+	}
+	return true
+}
+
+// Unsubscribe stops emails of the category
+func (p *NotificationPreferences) Unsubscribe(category string) {
+	switch category {
+	case NotificationOnboarding:
+		p.OnboardingEmails = false
+	case NotificationSellerReports:
+		p.SellerReports = SellerReportsOff
+	case NotificationDigests:
+		// Digests are still kept in the app
+		p.DigestDelivery = DigestDeliveryInApp
+	}
+}
+
+// UpdateNotificationPreferencesRequest represents the request body for
+// changing notification preferences; omitted fields are left unchanged
+type UpdateNotificationPreferencesRequest struct {
+	OnboardingEmails *bool   `json:"onboarding_emails"`
+	SellerReports    *string `json:"seller_reports" validate:"omitempty,oneof=off weekly monthly"`
+	Digests          *string `json:"digests" validate:"omitempty,oneof=off daily weekly"`
+	DigestDelivery   *string `json:"digest_delivery" validate:"omitempty,oneof=email in_app"`
+
+	// Routes replaces the channels of the types given; an empty list turns
+	// the type off. Empty quiet hours turn them off.
+	Routes          map[string][]string `json:"routes" validate:"omitempty,dive,keys,oneof=order_updates moderation price_drops,endkeys,dive,oneof=email sms line in_app"`
+	QuietHoursStart *string             `json:"quiet_hours_start" validate:"omitempty,len=0|datetime=15:04"`
+	QuietHoursEnd   *string             `json:"quiet_hours_end" validate:"omitempty,len=0|datetime=15:04"`
+	TimeZone        *string             `json:"time_zone" validate:"omitempty,timezone"`
+}
+
+// OnboardingStep is an email of the onboarding sequence, sent Delay after
+// registration
+type OnboardingStep struct {
+	Email string
+	Delay time.Duration
+}
+
+// OnboardingSequence lists the emails a new user receives
+var OnboardingSequence = []OnboardingStep{
+	{Email: EmailWelcome},
+	{Email: EmailOnboardingDay1, Delay: 24 * time.Hour},
+	{Email: EmailOnboardingDay3, Delay: 72 * time.Hour},
+}
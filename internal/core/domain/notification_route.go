@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"time"
+)
+
+// Notification channels
+const (
+	ChannelEmail = "email"
+	ChannelSMS   = "sms"    // to a verified number whose user opted into texts
+	ChannelLine  = "line"   // to a linked LINE account
+	ChannelInApp = "in_app" // held for the user's next digest
+)
+
+// Notification types, which users route to channels
+const (
+	NotificationOrderUpdates = "order_updates" // an order of theirs was paid, shipped or delivered
+	NotificationModeration   = "moderation"    // a moderator reviewed their manga or removed their comment
+	NotificationPriceDrops   = "price_drops"   // a manga on one of their wishlists got cheaper
+)
+
+// NotificationChannels lists the channels each notification type can be
+// routed to
+var NotificationChannels = map[string][]string{
+	NotificationOrderUpdates: {ChannelEmail, ChannelSMS, ChannelLine},
+	NotificationModeration:   {ChannelEmail, ChannelLine},
+	NotificationPriceDrops:   {ChannelInApp, ChannelLine},
+}
+
+// DefaultNotificationRoutes are the channels of each notification type for
+// users who haven't routed it themselves
+var DefaultNotificationRoutes = map[string][]string{
+	NotificationOrderUpdates: {ChannelEmail, ChannelSMS, ChannelLine},
+	NotificationModeration:   {ChannelEmail, ChannelLine},
+	NotificationPriceDrops:   {ChannelInApp},
+}
+
+// DefaultTimeZone is the time zone of quiet hours for users who haven't set theirs
+const DefaultTimeZone = "Asia/Bangkok"
+
+// ErrInvalidNotificationRoute is returned for a route to a channel the
+// notification type can't be sent on
+var ErrInvalidNotificationRoute = errors.New("invalid notification route")
+
+// Notification is something to tell a user about. The dispatcher delivers it
+// on each channel the user routes its type to.
+type Notification struct {
+	UserID uint   `json:"user_id"`
+	Type   string `json:"type"`
+	Event  string `json:"event"` // the event it tells about, such as order.shipped
+
+	// What it is about, by event
+	Order     *Order             `json:"order,omitempty"`
+	Manga     *Manga             `json:"manga,omitempty"`
+	Comment   *Comment           `json:"comment,omitempty"`
+	PriceDrop *WishlistPriceDrop `json:"price_drop,omitempty"`
+}
+
+// RouteFor returns the channels the user gets notifications of the type on
+func (p *NotificationPreferences) RouteFor(notificationType string) []string {
+	if channels, ok := p.Routes[notificationType]; ok {
+		return channels
+	}
+	return DefaultNotificationRoutes[notificationType]
+}
+
+// QuietUntil returns when the user's quiet hours around t end, or the zero
+// time when t is outside them. Quiet hours may span midnight, and are off
+// unless both ends are set and differ.
+func (p *NotificationPreferences) QuietUntil(t time.Time) time.Time {
+	start, err := time.Parse("15:04", p.QuietHoursStart)
+	if err != nil {
+		return time.Time{}
+	}
+	end, err := time.Parse("15:04", p.QuietHoursEnd)
+	if err != nil || start.Equal(end) {
+		return time.Time{}
+	}
+	loc, err := time.LoadLocation(p.TimeZone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	local := t.In(loc)
+	minute := local.Hour()*60 + local.Minute()
+	startMinute := start.Hour()*60 + start.Minute()
+	endMinute := end.Hour()*60 + end.Minute()
+	quiet := minute >= startMinute && minute < endMinute
+	if startMinute > endMinute {
+		quiet = minute >= startMinute || minute < endMinute
+	}
+	if !quiet {
+		return time.Time{}
+	}
+
+	y, m, d := local.Date()
+	until := time.Date(y, m, d, end.Hour(), end.Minute(), 0, 0, loc)
+	if !until.After(local) {
+		until = until.AddDate(0, 0, 1)
+	}
+	return until
+}
+
+// CanRoute reports whether notifications of the type can be sent on the channel
+func CanRoute(notificationType, channel string) bool {
+	return slices.Contains(NotificationChannels[notificationType], channel)
+}
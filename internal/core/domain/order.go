@@ -0,0 +1,166 @@
+package domain
+
+import (
+	"math"
+	"slices"
+	"time"
+)
+
+// Order statuses
+const (
+	OrderStatusPending   = "pending"
+	OrderStatusPaid      = "paid"
+	OrderStatusShipped   = "shipped"
+	OrderStatusFulfilled = "fulfilled" // delivered to the buyer
+	OrderStatusCancelled = "cancelled"
+)
+
+// orderStatusTransitions lists the statuses each order status may move to
+var orderStatusTransitions = map[string][]string{
+	OrderStatusPending: {OrderStatusPaid, OrderStatusCancelled},
+	OrderStatusPaid:    {OrderStatusShipped, OrderStatusFulfilled, OrderStatusCancelled},
+	OrderStatusShipped: {OrderStatusFulfilled},
+}
+
+// OrderStatusesFrom returns the statuses that may transition to the given status
+func OrderStatusesFrom(to string) []string {
+	var from []string
+	for status, targets := range orderStatusTransitions {
+		if slices.Contains(targets, to) {
+			from = append(from, status)
+		}
+	}
+	return from
+}
+
+// Order is a buyer's purchase of one or more mangas
+type Order struct {
+	ID       uint        `json:"id" gorm:"primarykey"`
+	UserID   uint        `json:"user_id" gorm:"not null;index"`
+	Status   string      `json:"status" gorm:"not null;default:pending;index"`
+	Items    []OrderItem `json:"items" gorm:"constraint:OnDelete:CASCADE"`
+	Shipment Shipment    `json:"shipment" gorm:"embedded"`
+
+	// Subtotal is the sum of the item prices; Total adds any exclusive tax on top
+	Subtotal float64        `json:"subtotal" gorm:"not null;default:0"`
+	TaxTotal float64        `json:"tax_total" gorm:"not null;default:0"`
+	Total    float64        `json:"total" gorm:"not null"`
+	TaxLines []OrderTaxLine `json:"tax_lines" gorm:"constraint:OnDelete:CASCADE"`
+
+	// ReservedUntil is when the stock held for a pending order is released
+	// and the order cancelled unless it has been paid
+	ReservedUntil *time.Time `json:"reserved_until,omitempty" gorm:"index"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Archived is set on orders read back from the archive, which can't change
+	Archived bool `json:"archived,omitempty" gorm:"-"`
+}
+
+// Shipment holds the tracking details sellers attach to an order
+type Shipment struct {
+	Carrier        string     `json:"carrier,omitempty"`
+	TrackingNumber string     `json:"tracking_number,omitempty"`
+	TrackingURL    string     `json:"tracking_url,omitempty"`
+	ShippedAt      *time.Time `json:"shipped_at,omitempty"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+}
+
+// OrderItem is one manga line of an order. Name and price are copied at
+// checkout so the order keeps its history when the manga changes later.
+type OrderItem struct {
+	ID        uint    `json:"id" gorm:"primarykey"`
+	OrderID   uint    `json:"order_id" gorm:"not null;index"`
+	MangaID   uint    `json:"manga_id" gorm:"not null;index"`
+	SellerID  uint    `json:"seller_id" gorm:"not null;index"`
+	Name      string  `json:"name" gorm:"not null"`
+	UnitPrice float64 `json:"unit_price" gorm:"not null"`
+	Quantity  int     `json:"quantity" gorm:"not null"`
+	Subtotal  float64 `json:"subtotal" gorm:"not null"`
+	TaxAmount float64 `json:"tax_amount" gorm:"not null;default:0"`
+}
+
+// SoldBy reports whether every item of the order was sold by the seller
+func (o *Order) SoldBy(sellerID uint) bool {
+	for _, item := range o.Items {
+		if item.SellerID != sellerID {
+			return false
+		}
+	}
+	return len(o.Items) > 0
+}
+
+// ForSeller returns a copy of the order holding only the seller's items,
+// with the totals and tax lines recomputed over those items
+func (o *Order) ForSeller(sellerID uint) *Order {
+	view := *o
+	view.Items = nil
+	for _, item := range o.Items {
+		if item.SellerID == sellerID {
+			view.Items = append(view.Items, item)
+		}
+	}
+	view.TaxLines = append([]OrderTaxLine(nil), o.TaxLines...)
+	view.recalculate()
+	return &view
+}
+
+// AddItem appends a line for the manga at its effective price and updates the totals
+func (o *Order) AddItem(manga *Manga, quantity int) {
+	price := manga.Price
+	if manga.EffectivePrice != nil {
+		price = *manga.EffectivePrice
+	}
+	subtotal := roundPrice(price * float64(quantity))
+	o.Items = append(o.Items, OrderItem{
+		MangaID:   manga.ID,
+		SellerID:  manga.UserCreated,
+		Name:      manga.Name,
+		UnitPrice: price,
+		Quantity:  quantity,
+		Subtotal:  subtotal,
+	})
+	o.recalculate()
+}
+
+// ApplyTax taxes every item at the rate and records it as the order's tax line
+func (o *Order) ApplyTax(rate *TaxRate) {
+	for i := range o.Items {
+		o.Items[i].TaxAmount = rate.TaxOn(o.Items[i].Subtotal)
+	}
+	o.TaxLines = []OrderTaxLine{{
+		Name:      rate.Name,
+		Country:   rate.Country,
+		Region:    rate.Region,
+		Rate:      rate.Rate,
+		Inclusive: rate.Inclusive,
+	}}
+	o.recalculate()
+}
+
+// recalculate derives the order's totals and tax line amounts from its items.
+// Every tax line covers all items, as orders are taxed at a single rate.
+func (o *Order) recalculate() {
+	var subtotal, tax float64
+	for _, item := range o.Items {
+		subtotal += item.Subtotal
+		tax += item.TaxAmount
+	}
+	o.Subtotal = roundPrice(subtotal)
+	o.TaxTotal = roundPrice(tax)
+	o.Total = o.Subtotal
+
+	for i := range o.TaxLines {
+		o.TaxLines[i].TaxableAmount = o.Subtotal
+		o.TaxLines[i].Amount = o.TaxTotal
+		if !o.TaxLines[i].Inclusive {
+			o.Total = roundPrice(o.Total + o.TaxTotal)
+		}
+	}
+}
+
+// roundPrice rounds an amount to whole cents
+func roundPrice(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
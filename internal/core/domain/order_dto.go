@@ -0,0 +1,20 @@
+package domain
+
+// CheckoutRequest represents the request body for ordering the user's cart
+type CheckoutRequest struct {
+	// Country and Region locate the buyer for tax; orders without a country are not taxed
+	Country string `json:"country" validate:"omitempty,iso3166_1_alpha2"`
+	Region  string `json:"region" validate:"max=100"`
+}
+
+// ShipOrderRequest represents the request body for attaching tracking details to an order
+type ShipOrderRequest struct {
+	Carrier        string `json:"carrier" validate:"required,max=100"`
+	TrackingNumber string `json:"tracking_number" validate:"required,max=100"`
+	TrackingURL    string `json:"tracking_url" validate:"omitempty,url,max=500"`
+}
+
+// UpdateOrderStatusRequest represents the request body for moving an order to a new status
+type UpdateOrderStatusRequest struct {
+	Status string `json:"status" validate:"required,oneof=paid fulfilled cancelled"`
+}
@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+// OutboxEvent is a domain event recorded in the same transaction as the change
+// it describes. The outbox relay publishes it to webhooks and event streams
+// once that transaction has committed, so events are neither lost when the
+// process stops nor sent for changes that were rolled back.
+type OutboxEvent struct {
+	ID          uint       `json:"id" gorm:"primarykey"`
+	UserID      uint       `json:"user_id" gorm:"not null"`
+	Event       string     `json:"event" gorm:"not null"`
+	Payload     string     `json:"payload" gorm:"type:text;not null"` // JSON of the event data
+	PublishedAt *time.Time `json:"published_at,omitempty" gorm:"index"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
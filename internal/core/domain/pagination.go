@@ -22,6 +22,7 @@ type PaginationResponse struct {
 type PaginatedResult[T any] struct {
 	Data       []T                 `json:"data"`
 	Pagination *PaginationResponse `json:"pagination"`
+	Links      Links               `json:"links,omitempty"`
 }
 
 // NewPaginationRequest creates a new pagination request with default values
@@ -0,0 +1,61 @@
+package domain
+
+import "time"
+
+// MangaPriceHistory records a single change of a manga's price
+type MangaPriceHistory struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	MangaID   uint      `json:"manga_id" gorm:"not null;index"`
+	OldPrice  float64   `json:"old_price" gorm:"not null"`
+	NewPrice  float64   `json:"new_price" gorm:"not null"`
+	ChangedBy uint      `json:"changed_by" gorm:"not null"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// PriceSummary aggregates the prices a manga has had
+type PriceSummary struct {
+	CurrentPrice float64 `json:"current_price"`
+	MinPrice     float64 `json:"min_price"`
+	MaxPrice     float64 `json:"max_price"`
+	AvgPrice     float64 `json:"avg_price"`
+	ChangeCount  int     `json:"change_count"`
+}
+
+// PriceHistoryResponse represents a manga's price history with its summary
+type PriceHistoryResponse struct {
+	Summary PriceSummary         `json:"summary"`
+	History []*MangaPriceHistory `json:"history"`
+}
+
+// NewPriceSummary computes the summary over the initial price and every subsequent
+// price, given the history in chronological order and the current price
+func NewPriceSummary(history []*MangaPriceHistory, current float64) PriceSummary {
+	prices := []float64{current}
+	if len(history) > 0 {
+		prices = []float64{history[0].OldPrice}
+		for _, entry := range history {
+			prices = append(prices, entry.NewPrice)
+		}
+	}
+
+	summary := PriceSummary{
+		CurrentPrice: current,
+		MinPrice:     prices[0],
+		MaxPrice:     prices[0],
+		ChangeCount:  len(history),
+	}
+
+	var total float64
+	for _, price := range prices {
+		if price < summary.MinPrice {
+			summary.MinPrice = price
+		}
+		if price > summary.MaxPrice {
+			summary.MaxPrice = price
+		}
+		total += price
+	}
+	summary.AvgPrice = total / float64(len(prices))
+
+	return summary
+}
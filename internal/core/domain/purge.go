@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+// PurgeReport describes one run of the soft-delete purge. In a dry run nothing
+// is deleted and the IDs are the records a real run would have purged.
+type PurgeReport struct {
+	DryRun   bool      `json:"dry_run"`
+	Cutoff   time.Time `json:"cutoff"`
+	MangaIDs []uint    `json:"manga_ids"`
+	UserIDs  []uint    `json:"user_ids"`
+	// Complete is false when a batch was full, so more records may be due
+	Complete bool `json:"complete"`
+}
+
+// PurgeStats are the purge counters since startup
+type PurgeStats struct {
+	Runs         int64        `json:"runs"`
+	FailedRuns   int64        `json:"failed_runs"`
+	MangasPurged int64        `json:"mangas_purged"`
+	UsersPurged  int64        `json:"users_purged"`
+	LastRunAt    *time.Time   `json:"last_run_at,omitempty"`
+	LastReport   *PurgeReport `json:"last_report,omitempty"`
+}
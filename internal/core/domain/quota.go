@@ -0,0 +1,65 @@
+package domain
+
+import "time"
+
+// Quota periods
+const (
+	QuotaPeriodDaily   = "daily"
+	QuotaPeriodMonthly = "monthly"
+)
+
+// QuotaUsage represents a user's request count for one quota period
+type QuotaUsage struct {
+	ID          uint      `json:"id" gorm:"primarykey"`
+	UserID      uint      `json:"user_id" gorm:"not null;uniqueIndex:idx_quota_usage_period"`
+	Period      string    `json:"period" gorm:"not null;uniqueIndex:idx_quota_usage_period"`
+	PeriodStart time.Time `json:"period_start" gorm:"not null;uniqueIndex:idx_quota_usage_period"`
+	Count       int64     `json:"count" gorm:"not null;default:0"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// QuotaLimits holds request limits per period (0 means unlimited)
+type QuotaLimits struct {
+	Daily   int64 `json:"daily"`
+	Monthly int64 `json:"monthly"`
+}
+
+// QuotaStatus describes a user's current quota consumption
+type QuotaStatus struct {
+	UserID       uint        `json:"user_id"`
+	Limits       QuotaLimits `json:"limits"`
+	DailyUsed    int64       `json:"daily_used"`
+	MonthlyUsed  int64       `json:"monthly_used"`
+	DailyReset   time.Time   `json:"daily_reset"`
+	MonthlyReset time.Time   `json:"monthly_reset"`
+}
+
+// QuotaPeriodStart returns the start of the quota period containing t (in UTC)
+func QuotaPeriodStart(period string, t time.Time) time.Time {
+	t = t.UTC()
+	if period == QuotaPeriodMonthly {
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// Exceeded checks if either period limit has been exceeded
+func (s *QuotaStatus) Exceeded() bool {
+	return (s.Limits.Daily > 0 && s.DailyUsed > s.Limits.Daily) ||
+		(s.Limits.Monthly > 0 && s.MonthlyUsed > s.Limits.Monthly)
+}
+
+// Remaining returns the smallest remaining allowance, or -1 when unlimited
+func (s *QuotaStatus) Remaining() int64 {
+	remaining := int64(-1)
+	if s.Limits.Daily > 0 {
+		remaining = max(s.Limits.Daily-s.DailyUsed, 0)
+	}
+	if s.Limits.Monthly > 0 {
+		monthly := max(s.Limits.Monthly-s.MonthlyUsed, 0)
+		if remaining < 0 || monthly < remaining {
+			remaining = monthly
+		}
+	}
+	return remaining
+}
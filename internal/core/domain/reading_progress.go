@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+// Reading statuses
+const (
+	ReadingStatusReading   = "reading"
+	ReadingStatusCompleted = "completed"
+	ReadingStatusDropped   = "dropped"
+)
+
+// ReadingProgress records a user's reading position in a manga
+type ReadingProgress struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	UserID    uint      `json:"user_id" gorm:"not null;uniqueIndex:idx_reading_progress_user_manga"`
+	MangaID   uint      `json:"manga_id" gorm:"not null;uniqueIndex:idx_reading_progress_user_manga"`
+	ChapterID *uint     `json:"chapter_id,omitempty"`
+	Page      int       `json:"page" gorm:"not null;default:0"`
+	Status    string    `json:"status" gorm:"not null;default:reading;index"`
+	Manga     *Manga    `json:"manga,omitempty" gorm:"foreignKey:MangaID"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// IsValid checks if the reading progress has valid data
+func (p *ReadingProgress) IsValid() bool {
+	switch p.Status {
+	case ReadingStatusReading, ReadingStatusCompleted, ReadingStatusDropped:
+	default:
+		return false
+	}
+	return p.UserID > 0 && p.MangaID > 0 && p.Page >= 0
+}
@@ -0,0 +1,8 @@
+package domain
+
+// UpdateProgressRequest represents the request body for recording reading progress
+type UpdateProgressRequest struct {
+	ChapterID *uint  `json:"chapter_id"`
+	Page      int    `json:"page" validate:"min=0"`
+	Status    string `json:"status" validate:"required,oneof=reading completed dropped"`
+}
@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+// Rental statuses
+const (
+	RentalStatusActive  = "active"
+	RentalStatusExpired = "expired"
+)
+
+// Rental limits
+const (
+	DefaultRentalDays = 7
+	MaxRentalDays     = 30
+)
+
+// Rental grants a user time-boxed access to a manga. Rentals past their
+// expiry are treated as expired right away; the sweeper only updates their status.
+type Rental struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	UserID    uint      `json:"user_id" gorm:"not null;index:idx_rentals_user_manga"`
+	MangaID   uint      `json:"manga_id" gorm:"not null;index:idx_rentals_user_manga"`
+	Status    string    `json:"status" gorm:"not null;default:active;index"`
+	ExpiresAt time.Time `json:"expires_at" gorm:"not null;index"`
+	Manga     *Manga    `json:"manga,omitempty" gorm:"foreignKey:MangaID"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// IsActiveAt reports whether the rental still grants access at the given time
+func (r *Rental) IsActiveAt(now time.Time) bool {
+	return r.Status == RentalStatusActive && now.Before(r.ExpiresAt)
+}
@@ -0,0 +1,6 @@
+package domain
+
+// RentMangaRequest represents the request body for renting a manga
+type RentMangaRequest struct {
+	Days int `json:"days" validate:"omitempty,min=1,max=30"` // defaults to DefaultRentalDays
+}
@@ -0,0 +1,19 @@
+package domain
+
+import "time"
+
+// Review represents a user's star rating and review of a manga
+type Review struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	MangaID   uint      `json:"manga_id" gorm:"not null;uniqueIndex:idx_reviews_manga_user"`
+	UserID    uint      `json:"user_id" gorm:"not null;uniqueIndex:idx_reviews_manga_user;index"`
+	Rating    int       `json:"rating" gorm:"not null"`
+	Text      string    `json:"text" gorm:"type:text"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// IsValid checks if the review has valid data
+func (r *Review) IsValid() bool {
+	return r.MangaID > 0 && r.UserID > 0 && r.Rating >= 1 && r.Rating <= 5
+}
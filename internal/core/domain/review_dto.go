@@ -0,0 +1,7 @@
+package domain
+
+// ReviewRequest represents the request body for creating or updating a review
+type ReviewRequest struct {
+	Rating int    `json:"rating" validate:"required,min=1,max=5"`
+	Text   string `json:"text" validate:"max=5000"`
+}
@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// routeLimitMethods are the methods a route limit may be restricted to
+var routeLimitMethods = []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
+
+// RouteLimit throttles the requests to the routes matching Pattern, of Method
+// only unless it is empty: to Requests per Window, or, when Window is zero, to
+// Concurrent at a time. Requests count against their user, or their client IP
+// when anonymous or when PerIP is set.
+type RouteLimit struct {
+	Method     string
+	Pattern    string // path with :param segments, ending in /* for any rest
+	Requests   int64
+	Window     time.Duration
+	Concurrent int64
+	PerIP      bool
+	Limit      string // the limit as configured, such as "10/1m per ip"
+}
+
+// ParseRouteLimit parses a route limit entry such as
+// "/api/v1/auth/*=10/1m per ip" or "GET /api/v1/mangas/export=2 concurrent per user"
+func ParseRouteLimit(entry string) (*RouteLimit, error) {
+	route, limit, ok := strings.Cut(entry, "=")
+	if !ok {
+		return nil, fmt.Errorf("route limit %q must be <route>=<limit>", entry)
+	}
+
+	l := &RouteLimit{Limit: strings.Join(strings.Fields(limit), " ")}
+	switch fields := strings.Fields(route); len(fields) {
+	case 1:
+		l.Pattern = fields[0]
+	case 2:
+		l.Method, l.Pattern = strings.ToUpper(fields[0]), fields[1]
+		if !slices.Contains(routeLimitMethods, l.Method) {
+			return nil, fmt.Errorf("route limit %q has an unknown method %s", entry, fields[0])
+		}
+	default:
+		return nil, fmt.Errorf("route limit %q must name a path, optionally after a method", entry)
+	}
+	if !strings.HasPrefix(l.Pattern, "/") {
+		return nil, fmt.Errorf("route limit %q must name a path starting with /", entry)
+	}
+
+	fields := strings.Fields(limit)
+	if n := len(fields); n >= 2 && fields[n-2] == "per" {
+		switch fields[n-1] {
+		case "ip":
+			l.PerIP = true
+		case "user":
+		default:
+			return nil, fmt.Errorf("route limit %q must be per ip or per user", entry)
+		}
+		fields = fields[:n-2]
+	}
+
+	switch {
+	case len(fields) == 1:
+		count, window, ok := strings.Cut(fields[0], "/")
+		if !ok {
+			return nil, fmt.Errorf("route limit %q must limit to <requests>/<window> or <n> concurrent", entry)
+		}
+		requests, err := strconv.ParseInt(count, 10, 64)
+		if err != nil || requests < 1 {
+			return nil, fmt.Errorf("route limit %q must allow at least 1 request", entry)
+		}
+		d, err := time.ParseDuration(window)
+		if err != nil || d < time.Second {
+			return nil, fmt.Errorf("route limit %q must have a window of at least 1s, such as 1m", entry)
+		}
+		l.Requests, l.Window = requests, d
+	case len(fields) == 2 && fields[1] == "concurrent":
+		concurrent, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil || concurrent < 1 {
+			return nil, fmt.Errorf("route limit %q must allow at least 1 concurrent request", entry)
+		}
+		l.Concurrent = concurrent
+	default:
+		return nil, fmt.Errorf("route limit %q must limit to <requests>/<window> or <n> concurrent", entry)
+	}
+
+	return l, nil
+}
+
+// ParseRouteLimits parses every route limit entry, failing on the first invalid one
+func ParseRouteLimits(entries []string) ([]*RouteLimit, error) {
+	limits := make([]*RouteLimit, 0, len(entries))
+	for _, entry := range entries {
+		l, err := ParseRouteLimit(entry)
+		if err != nil {
+			return nil, err
+		}
+		limits = append(limits, l)
+	}
+	return limits, nil
+}
+
+// Route returns the method and pattern of the limit, identifying it
+func (l *RouteLimit) Route() string {
+	if l.Method == "" {
+		return l.Pattern
+	}
+	return l.Method + " " + l.Pattern
+}
+
+// Matches reports whether a request is to one of the limit's routes. Paths
+// match case-insensitively and regardless of a trailing slash, as routes do.
+func (l *RouteLimit) Matches(method, path string) bool {
+	if l.Method != "" && l.Method != method && !(l.Method == "GET" && method == "HEAD") {
+		return false
+	}
+
+	pattern := strings.Split(strings.Trim(l.Pattern, "/"), "/")
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, part := range pattern {
+		if part == "*" && i == len(pattern)-1 {
+			return true
+		}
+		if i >= len(segments) {
+			return false
+		}
+		if !strings.HasPrefix(part, ":") && !strings.EqualFold(part, segments[i]) {
+			return false
+		}
+	}
+	return len(segments) == len(pattern)
+}
@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// MaxSalesReportPeriod bounds the period one sales report covers
+const MaxSalesReportPeriod = 366 * 24 * time.Hour
+
+// SalesReportRow is one item a seller sold, a line of their sales report
+type SalesReportRow struct {
+	ItemID    uint
+	OrderID   uint
+	OrderedAt time.Time
+	Status    string // of the order
+	MangaID   uint
+	Name      string
+	UnitPrice float64
+	Quantity  int
+	Subtotal  float64
+	TaxAmount float64
+}
@@ -0,0 +1,60 @@
+package domain
+
+// Manga search facets, named after the filter parameter each one counts
+const (
+	MangaFacetGenre             = "genre"
+	MangaFacetPublicationStatus = "publication_status"
+	MangaFacetContentRating     = "content_rating"
+)
+
+// MangaSearchFacets lists the facets reported with every search
+var MangaSearchFacets = []string{
+	MangaFacetGenre,
+	MangaFacetPublicationStatus,
+	MangaFacetContentRating,
+}
+
+// MangaSearchResult is a page of search hits, best matches first, with the
+// number of matching mangas per facet value
+type MangaSearchResult struct {
+	Data       []*Manga                    `json:"data"`
+	Pagination *PaginationResponse         `json:"pagination"`
+	Facets     map[string]map[string]int64 `json:"facets"`
+	Links      Links                       `json:"links,omitempty"`
+}
+
+// MangaSearchDocument is a manga as kept in an external search index. Only
+// what is searched, filtered on or counted is indexed; hits are loaded from the
+// database, so the index never serves stale listings.
+type MangaSearchDocument struct {
+	ID                uint     `json:"id"`
+	Name              string   `json:"name"`
+	Price             float64  `json:"price"`
+	IsActive          bool     `json:"is_active"`
+	UserCreated       uint     `json:"user_created"`
+	Status            string   `json:"status"`
+	Genres            []string `json:"genre"`
+	PublicationStatus string   `json:"publication_status"`
+	ContentRating     string   `json:"content_rating"`
+	SeriesID          *uint    `json:"series_id"`
+}
+
+// NewMangaSearchDocument builds the index document of a manga loaded with its genres
+func NewMangaSearchDocument(m *Manga) *MangaSearchDocument {
+	genres := make([]string, len(m.Genres))
+	for i, genre := range m.Genres {
+		genres[i] = genre.Slug
+	}
+	return &MangaSearchDocument{
+		ID:                m.ID,
+		Name:              m.Name,
+		Price:             m.Price,
+		IsActive:          m.IsActive,
+		UserCreated:       m.UserCreated,
+		Status:            m.Status,
+		Genres:            genres,
+		PublicationStatus: m.PublicationStatus,
+		ContentRating:     m.ContentRating,
+		SeriesID:          m.SeriesID,
+	}
+}
@@ -0,0 +1,72 @@
+package domain
+
+import "time"
+
+// How often sellers are emailed a report of their sales
+const (
+	SellerReportsOff     = "off"
+	SellerReportsWeekly  = "weekly"
+	SellerReportsMonthly = "monthly"
+)
+
+// SellerReportFrequencies lists the frequencies seller reports are sent at
+var SellerReportFrequencies = []string{SellerReportsWeekly, SellerReportsMonthly}
+
+// SellerReportTopMangas is the number of best-selling mangas a seller report lists
+const SellerReportTopMangas = 5
+
+// SellerReportPeriod returns the last whole period of the frequency before
+// now, in UTC: last Monday to Sunday for weekly reports, last calendar month
+// for monthly ones. The period ends before to.
+func SellerReportPeriod(frequency string, now time.Time) (from, to time.Time) {
+	y, m, d := now.UTC().Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	if frequency == SellerReportsMonthly {
+		to = time.Date(y, m, 1, 0, 0, 0, 0, time.UTC)
+		return to.AddDate(0, -1, 0), to
+	}
+
+	// Weeks start on Monday
+	to = today.AddDate(0, 0, -(int(today.Weekday())+6)%7)
+	return to.AddDate(0, 0, -7), to
+}
+
+// SoldOrderStatuses are the statuses of orders that count as sales
+var SoldOrderStatuses = []string{OrderStatusPaid, OrderStatusShipped, OrderStatusFulfilled}
+
+// SalesSummary sums up a seller's sales over a period. Pending and cancelled
+// orders are not sales.
+type SalesSummary struct {
+	Orders    int64
+	ItemsSold int64
+	Revenue   float64
+}
+
+// MangaSales are the sales of one manga over a period
+type MangaSales struct {
+	MangaID uint
+	Name    string
+	Sold    int64
+	Revenue float64
+}
+
+// SellerReport is what a seller report email shows: the seller's sales and
+// the views of their mangas over the period from From until To
+type SellerReport struct {
+	Frequency string
+	From      time.Time
+	To        time.Time
+	SalesSummary
+	Views     int64
+	TopMangas []*MangaSales
+}
+
+// LastDay is the last day the report covers
+func (r *SellerReport) LastDay() time.Time {
+	return r.To.AddDate(0, 0, -1)
+}
+
+// IsEmpty reports whether nothing happened in the period, so the report is not sent
+func (r *SellerReport) IsEmpty() bool {
+	return r.Orders == 0 && r.Views == 0
+}
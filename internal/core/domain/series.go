@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Series groups manga volumes into an ordered collection
+type Series struct {
+	ID          uint           `json:"id" gorm:"primarykey"`
+	Name        string         `json:"name" gorm:"not null"`
+	Description string         `json:"description" gorm:"type:text"`
+	UserCreated uint           `json:"user_created" gorm:"not null;index"`
+	TeamID      *uint          `json:"team_id,omitempty" gorm:"index"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
+}
+
+// IsValid checks if the series has valid data
+func (s *Series) IsValid() bool {
+	return s.Name != "" && s.UserCreated > 0
+}
+
+// SeriesDetail is a series with its published volumes in order and their totals
+type SeriesDetail struct {
+	*Series
+	Volumes     []*Manga `json:"volumes"`
+	VolumeCount int      `json:"volume_count"`
+	TotalPrice  float64  `json:"total_price"`
+}
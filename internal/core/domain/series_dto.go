@@ -0,0 +1,19 @@
+package domain
+
+// SeriesRequest represents the request body for creating or updating a series
+type SeriesRequest struct {
+	Name        string `json:"name" validate:"required,max=255"`
+	Description string `json:"description" validate:"max=5000"`
+	TeamID      *uint  `json:"team_id"` // only used on create
+}
+
+// AddSeriesVolumeRequest represents the request body for adding a manga to a series
+type AddSeriesVolumeRequest struct {
+	MangaID      uint `json:"manga_id" validate:"required"`
+	VolumeNumber *int `json:"volume_number" validate:"omitempty,min=1"` // defaults to after the last volume
+}
+
+// ReorderSeriesVolumesRequest lists every volume of a series in its new order
+type ReorderSeriesVolumesRequest struct {
+	MangaIDs []uint `json:"manga_ids" validate:"required,min=1"`
+}
@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// SMSMessage is a text message to a single phone number in E.164 format
+type SMSMessage struct {
+	To   string `json:"to"`
+	Body string `json:"body"`
+}
+
+// ErrSMSRejected marks a send failure that sending the message again won't
+// fix, such as an invalid or unreachable number; other failures are
+// transient and retried
+var ErrSMSRejected = errors.New("sms rejected")
+
+// ErrSMSRateLimited is returned when too many texts were sent to a user or a
+// number lately
+var ErrSMSRateLimited = errors.New("too many text messages, try again later")
+
+// SMS code purposes
+const (
+	SMSCodeVerify = "verify" // confirms the user owns the number
+)
+
+// SMSCodeLength is how many digits the codes texted to users have
+const SMSCodeLength = 6
+
+// UserPhone is a user's mobile number. Codes are texted to it once it is
+// verified, and order alerts only while the user has opted into them as well.
+type UserPhone struct {
+	UserID     uint       `json:"user_id" gorm:"primarykey;autoIncrement:false"`
+	Number     string     `json:"number" gorm:"type:text;not null;serializer:encrypted"` // E.164; encrypted at rest
+	VerifiedAt *time.Time `json:"verified_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+
+	// SMSConsentAt is when the user opted into order alerts by text, from
+	// SMSConsentIP; nil while they haven't, or since they opted out
+	SMSConsentAt *time.Time `json:"sms_consent_at,omitempty"`
+	SMSConsentIP string     `json:"sms_consent_ip,omitempty"`
+
+	// The code last texted to the number, hashed, what it is for, until when
+	// it is valid, and how many wrong guesses it has had
+	CodeHash      string     `json:"-"`
+	CodePurpose   string     `json:"-"`
+	CodeExpiresAt *time.Time `json:"-"`
+	CodeAttempts  int        `json:"-"`
+}
+
+// IsVerified reports whether the user has confirmed they own the number
+func (p *UserPhone) IsVerified() bool {
+	return p.VerifiedAt != nil
+}
+
+// AllowsAlerts reports whether order alerts may be texted to the number
+func (p *UserPhone) AllowsAlerts() bool {
+	return p.IsVerified() && p.SMSConsentAt != nil
+}
+
+// ClearCode forgets the last code texted to the number
+func (p *UserPhone) ClearCode() {
+	p.CodeHash = ""
+	p.CodePurpose = ""
+	p.CodeExpiresAt = nil
+	p.CodeAttempts = 0
+}
+
+// SetPhoneRequest represents the request body for setting the user's number;
+// the number is texted a verification code
+type SetPhoneRequest struct {
+	Number     string `json:"number" validate:"required,e164"`
+	SMSConsent bool   `json:"sms_consent"`
+}
+
+// VerifyPhoneRequest represents the request body for confirming a number with
+// the code texted to it
+type VerifyPhoneRequest struct {
+	Code string `json:"code" validate:"required,len=6,numeric"`
+}
+
+// UpdateSMSConsentRequest represents the request body for opting into or out
+// of order alerts by text
+type UpdateSMSConsentRequest struct {
+	SMSConsent *bool `json:"sms_consent" validate:"required"`
+}
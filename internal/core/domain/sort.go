@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+// SortField represents a single column ordering
+type SortField struct {
+	Field string `json:"field"`
+	Desc  bool   `json:"desc"`
+}
+
+// Sort represents an ordered list of column orderings
+type Sort []SortField
+
+// MangaSortableFields lists the manga columns clients may sort by
+var MangaSortableFields = map[string]bool{
+	"id":         true,
+	"name":       true,
+	"price":      true,
+	"created_at": true,
+	"updated_at": true,
+
+	"average_rating": true,
+	"review_count":   true,
+	"view_count":     true,
+	"volume_number":  true,
+}
+
+// ParseSort parses a sort expression like "price:asc,created_at:desc",
+// accepting only whitelisted fields
+func ParseSort(raw string, allowed map[string]bool) (Sort, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+
+	var sort Sort
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(raw, ",") {
+		field, direction, _ := strings.Cut(strings.TrimSpace(part), ":")
+		field = strings.ToLower(strings.TrimSpace(field))
+		direction = strings.ToLower(strings.TrimSpace(direction))
+
+		if !allowed[field] {
+			return nil, errors.New("cannot sort by field: " + field)
+		}
+		if seen[field] {
+			return nil, errors.New("duplicate sort field: " + field)
+		}
+		seen[field] = true
+
+		switch direction {
+		case "", "asc":
+			sort = append(sort, SortField{Field: field})
+		case "desc":
+			sort = append(sort, SortField{Field: field, Desc: true})
+		default:
+			return nil, errors.New("invalid sort direction: " + direction)
+		}
+	}
+
+	return sort, nil
+}
@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+// Stock reservation statuses
+const (
+	ReservationHeld      = "held"
+	ReservationConfirmed = "confirmed"
+	ReservationReleased  = "released"
+)
+
+// StockReservation records stock taken out for one item of an order. Stock is
+// decremented when the reservation is held, kept when it is confirmed by
+// payment, and put back when it is released by cancellation or expiry.
+type StockReservation struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	OrderID   uint      `json:"order_id" gorm:"not null;index"`
+	MangaID   uint      `json:"manga_id" gorm:"not null;index"`
+	Quantity  int       `json:"quantity" gorm:"not null"`
+	Status    string    `json:"status" gorm:"not null;default:held;index"`
+	ExpiresAt time.Time `json:"expires_at" gorm:"not null"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
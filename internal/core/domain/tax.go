@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// TaxRate is a VAT or sales tax rate for a country, or for one region of it.
+// A region's rate takes precedence over its country's rate.
+type TaxRate struct {
+	ID      uint    `json:"id" gorm:"primarykey"`
+	Country string  `json:"country" gorm:"size:2;not null;uniqueIndex:idx_tax_rates_location"`
+	Region  string  `json:"region" gorm:"not null;default:'';uniqueIndex:idx_tax_rates_location"` // empty for the whole country
+	Name    string  `json:"name" gorm:"not null"`
+	Rate    float64 `json:"rate" gorm:"not null"` // percent
+	// Inclusive rates are already part of listed prices; exclusive rates are added on top
+	Inclusive bool      `json:"inclusive"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NormalizeTaxLocation canonicalizes a country code and region name for rate lookups
+func NormalizeTaxLocation(country, region string) (string, string) {
+	return strings.ToUpper(strings.TrimSpace(country)), strings.TrimSpace(region)
+}
+
+// TaxOn returns the tax contained in (inclusive) or owed on top of (exclusive) an amount
+func (t *TaxRate) TaxOn(amount float64) float64 {
+	if t.Inclusive {
+		return roundPrice(amount - amount/(1+t.Rate/100))
+	}
+	return roundPrice(amount * t.Rate / 100)
+}
+
+// OrderTaxLine is one tax applied to an order, kept as it was at checkout
+type OrderTaxLine struct {
+	ID            uint    `json:"id" gorm:"primarykey"`
+	OrderID       uint    `json:"order_id" gorm:"not null;index"`
+	Name          string  `json:"name" gorm:"not null"`
+	Country       string  `json:"country" gorm:"size:2;not null"`
+	Region        string  `json:"region,omitempty"`
+	Rate          float64 `json:"rate" gorm:"not null"`
+	Inclusive     bool    `json:"inclusive"`
+	TaxableAmount float64 `json:"taxable_amount" gorm:"not null"`
+	Amount        float64 `json:"amount" gorm:"not null"`
+}
@@ -0,0 +1,10 @@
+package domain
+
+// TaxRateRequest represents the request body for creating or updating a tax rate
+type TaxRateRequest struct {
+	Country   string  `json:"country" validate:"required,iso3166_1_alpha2"`
+	Region    string  `json:"region" validate:"max=100"`
+	Name      string  `json:"name" validate:"required,max=100"`
+	Rate      float64 `json:"rate" validate:"gte=0,lte=100"`
+	Inclusive bool    `json:"inclusive"`
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Team membership roles
+const (
+	TeamRoleOwner  = "owner"
+	TeamRoleMember = "member"
+)
+
+// Team invitation statuses
+const (
+	InvitationStatusPending  = "pending"
+	InvitationStatusAccepted = "accepted"
+)
+
+// Team represents a group of users sharing a manga catalog
+type Team struct {
+	ID        uint           `json:"id" gorm:"primarykey"`
+	Name      string         `json:"name" gorm:"not null"`
+	OwnerID   uint           `json:"owner_id" gorm:"not null;index"`
+	Members   []TeamMember   `json:"members,omitempty" gorm:"constraint:OnDelete:CASCADE"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
+}
+
+// TeamMember represents a user's membership in a team
+type TeamMember struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	TeamID    uint      `json:"team_id" gorm:"not null;uniqueIndex:idx_team_members_team_user"`
+	UserID    uint      `json:"user_id" gorm:"not null;uniqueIndex:idx_team_members_team_user;index"`
+	Role      string    `json:"role" gorm:"not null;default:member"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TeamInvitation represents a pending invitation for a user to join a team
+type TeamInvitation struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	TeamID    uint      `json:"team_id" gorm:"not null;index"`
+	Email     string    `json:"email" gorm:"not null"`
+	Token     string    `json:"token,omitempty" gorm:"not null;uniqueIndex"`
+	InvitedBy uint      `json:"invited_by" gorm:"not null"`
+	Status    string    `json:"status" gorm:"not null;default:pending"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// IsValid checks if the team has valid data
+func (t *Team) IsValid() bool {
+	return t.Name != "" && t.OwnerID > 0
+}
+
+// IsOwner checks if the membership grants owner rights
+func (m *TeamMember) IsOwner() bool {
+	return m.Role == TeamRoleOwner
+}
+
+// IsUsable checks if the invitation can still be accepted at the given time
+func (i *TeamInvitation) IsUsable(now time.Time) bool {
+	return i.Status == InvitationStatusPending && i.ExpiresAt.After(now)
+}
@@ -0,0 +1,11 @@
+package domain
+
+// CreateTeamRequest represents the request body for creating a team
+type CreateTeamRequest struct {
+	Name string `json:"name" validate:"required"`
+}
+
+// InviteTeamMemberRequest represents the request body for inviting a user to a team
+type InviteTeamMemberRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
@@ -0,0 +1,61 @@
+package domain
+
+import "time"
+
+// Upload purposes: what a presigned upload becomes once confirmed
+const (
+	UploadPurposeMangaImage = "manga_image"
+)
+
+// Upload statuses
+const (
+	UploadStatusPending   = "pending"
+	UploadStatusConfirmed = "confirmed"
+	UploadStatusBlocked   = "blocked" // the malware scanner flagged the file
+)
+
+// MaxPresignedImageSize is the largest gallery image clients may upload
+// straight to storage; such uploads never pass through the API, so they may
+// be larger than the ones posted to it
+const MaxPresignedImageSize = 20 << 20 // bytes
+
+// Upload is a file a client was given a presigned URL to upload straight to
+// storage. It is confirmed once the client reports the upload done and the
+// file passes validation; unconfirmed files are deleted when the upload expires.
+type Upload struct {
+	ID           uint      `json:"id" gorm:"primarykey"`
+	UserID       uint      `json:"user_id" gorm:"not null;index"`
+	Purpose      string    `json:"purpose" gorm:"not null"`
+	MangaID      uint      `json:"manga_id" gorm:"not null"`
+	StorageKey   string    `json:"-" gorm:"not null"`
+	ContentType  string    `json:"content_type" gorm:"not null"`
+	Size         int64     `json:"size" gorm:"not null"`
+	Status       string    `json:"status" gorm:"not null;default:pending"`
+	MangaImageID *uint     `json:"manga_image_id,omitempty"` // the gallery image a confirmed upload became
+	Signature    string    `json:"signature,omitempty"`      // the malware found in a blocked upload
+	ExpiresAt    time.Time `json:"expires_at" gorm:"not null"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// PresignUploadRequest represents the request body for a presigned upload
+type PresignUploadRequest struct {
+	Purpose     string `json:"purpose" validate:"required,oneof=manga_image"`
+	MangaID     uint   `json:"manga_id" validate:"required"`
+	ContentType string `json:"content_type" validate:"required"`
+	Size        int64  `json:"size" validate:"required,min=1"`
+}
+
+// PresignedUpload tells the client where and how to upload the file: a PUT
+// of the file to URL with the headers, before the upload expires
+type PresignedUpload struct {
+	Upload  *Upload           `json:"upload"`
+	Method  string            `json:"method"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers"`
+}
+
+// ConfirmUploadRequest represents the request body for confirming an upload
+type ConfirmUploadRequest struct {
+	Caption string `json:"caption" validate:"max=500"`
+}
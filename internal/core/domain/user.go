@@ -6,15 +6,42 @@ import (
 	"gorm.io/gorm"
 )
 
+// AdultAge is the age at which users may view mature content
+const AdultAge = 18
+
+// User roles. Super admins are admins who may also back up and restore the database.
+const (
+	RoleUser       = "user"
+	RoleAdmin      = "admin"
+	RoleSuperAdmin = "super_admin"
+)
+
 // User represents the user entity in the domain
 type User struct {
 	ID        uint           `json:"id" gorm:"primarykey"`
-	Name      string         `json:"name" gorm:"not null"`
+	Name      string         `json:"name" gorm:"not null"` // prefix-searched through idx_users_name_prefix, see database.Migrate
 	Email     string         `json:"email" gorm:"unique;not null"`
 	Password  string         `json:"-" gorm:"not null"` // "-" excludes from JSON serialization
+	Role      string         `json:"role" gorm:"not null;default:user"`
+	AvatarURL string         `json:"avatar_url"`
+	BirthDate *time.Time     `json:"birth_date,omitempty" gorm:"type:text;serializer:encrypted"` // confirms age for mature content; encrypted at rest
+	Locale    string         `json:"locale,omitempty" gorm:"size:8"`                             // language of the emails the user receives; empty is English
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
+
+	// Activity tracking
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+	LastSeenAt  *time.Time `json:"last_seen_at,omitempty" gorm:"index"`
+
+	// Suspension
+	SuspendedAt      *time.Time `json:"suspended_at,omitempty" gorm:"index"`
+	SuspendedUntil   *time.Time `json:"suspended_until,omitempty"`
+	SuspensionReason string     `json:"suspension_reason,omitempty"`
+
+	// PendingEmail is the address the user asked to change theirs to, until
+	// they confirm it from the link sent there
+	PendingEmail string `json:"pending_email,omitempty"`
 }
 
 // IsValid checks if the user has valid data
@@ -22,13 +49,52 @@ func (u *User) IsValid() bool {
 	return u.Name != "" && u.Email != "" && u.Password != ""
 }
 
+// IsAdmin checks if the user has the admin or super admin role
+func (u *User) IsAdmin() bool {
+	return u.Role == RoleAdmin || u.Role == RoleSuperAdmin
+}
+
+// IsSuperAdmin checks if the user has the super admin role
+func (u *User) IsSuperAdmin() bool {
+	return u.Role == RoleSuperAdmin
+}
+
+// IsAdultAt reports whether the user's birth date shows they are of adult age at the given time
+func (u *User) IsAdultAt(now time.Time) bool {
+	if u.BirthDate == nil {
+		return false
+	}
+	return !u.BirthDate.AddDate(AdultAge, 0, 0).After(now)
+}
+
+// IsSuspended checks if the user is suspended at the given time
+func (u *User) IsSuspended(now time.Time) bool {
+	if u.SuspendedAt == nil {
+		return false
+	}
+	return u.SuspendedUntil == nil || u.SuspendedUntil.After(now)
+}
+
 // Sanitize removes sensitive data from user before returning
 func (u *User) Sanitize() *User {
 	return &User{
 		ID:        u.ID,
 		Name:      u.Name,
 		Email:     u.Email,
+		Role:      u.Role,
+		AvatarURL: u.AvatarURL,
+		BirthDate: u.BirthDate,
+		Locale:    u.Locale,
 		CreatedAt: u.CreatedAt,
 		UpdatedAt: u.UpdatedAt,
+
+		LastLoginAt: u.LastLoginAt,
+		LastSeenAt:  u.LastSeenAt,
+
+		SuspendedAt:      u.SuspendedAt,
+		SuspendedUntil:   u.SuspendedUntil,
+		SuspensionReason: u.SuspensionReason,
+
+		PendingEmail: u.PendingEmail,
 	}
 }
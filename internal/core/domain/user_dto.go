@@ -0,0 +1,81 @@
+package domain
+
+import "time"
+
+// UpdateUserRequest represents the request body for partially updating a user.
+// Nil fields are left unchanged.
+type UpdateUserRequest struct {
+	Name      *string `json:"name" validate:"omitempty,min=1"`
+	Email     *string `json:"email" validate:"omitempty,email"`
+	AvatarURL *string `json:"avatar_url" validate:"omitempty,url"`
+	BirthDate *string `json:"birth_date" validate:"omitempty,datetime=2006-01-02"` // only admins can change it once set
+	Locale    *string `json:"locale" validate:"omitempty,oneof=en th ja"`
+}
+
+// SuspendUserRequest represents the request body for suspending a user
+type SuspendUserRequest struct {
+	Reason    string     `json:"reason" validate:"required"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// MergeUsersRequest represents the request body for merging a duplicate user into another
+type MergeUsersRequest struct {
+	SourceID uint `json:"source_id" validate:"required"`
+	TargetID uint `json:"target_id" validate:"required"`
+	DryRun   bool `json:"dry_run"`
+}
+
+// MergeUsersResult describes the outcome (or preview) of a user merge
+type MergeUsersResult struct {
+	Source     *User            `json:"source"`
+	Target     *User            `json:"target"`
+	Reassigned map[string]int64 `json:"reassigned"`
+	DryRun     bool             `json:"dry_run"`
+}
+
+// PublicUserResponse represents the user data that is safe to expose publicly
+type PublicUserResponse struct {
+	ID        uint   `json:"id"`
+	Name      string `json:"name"`
+	AvatarURL string `json:"avatar_url,omitempty"`
+	JoinedAt  string `json:"joined_at"`
+}
+
+// NewPublicUserResponse maps a user to its public representation
+func NewPublicUserResponse(u *User) *PublicUserResponse {
+	return &PublicUserResponse{
+		ID:        u.ID,
+		Name:      u.Name,
+		AvatarURL: u.AvatarURL,
+		JoinedAt:  u.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// NewPublicUserResponses maps a list of users to their public representation
+func NewPublicUserResponses(users []*User) []*PublicUserResponse {
+	responses := make([]*PublicUserResponse, len(users))
+	for i, user := range users {
+		responses[i] = NewPublicUserResponse(user)
+	}
+	return responses
+}
+
+// UserSuggestion represents a lightweight user match for typeahead search
+type UserSuggestion struct {
+	ID        uint   `json:"id"`
+	Name      string `json:"name"`
+	AvatarURL string `json:"avatar_url,omitempty"`
+}
+
+// NewUserSuggestions maps a list of users to typeahead suggestions
+func NewUserSuggestions(users []*User) []*UserSuggestion {
+	suggestions := make([]*UserSuggestion, len(users))
+	for i, user := range users {
+		suggestions[i] = &UserSuggestion{
+			ID:        user.ID,
+			Name:      user.Name,
+			AvatarURL: user.AvatarURL,
+		}
+	}
+	return suggestions
+}
@@ -0,0 +1,147 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Webhook event types
+const (
+	EventMangaCreated   = "manga.created"
+	EventMangaUpdated   = "manga.updated"
+	EventMangaDeleted   = "manga.deleted"
+	EventMangaPurchased = "manga.purchased"
+
+	// Order events are sent to the buyer and to every seller with items in the order
+	EventOrderPlaced    = "order.placed"
+	EventOrderPaid      = "order.paid"
+	EventOrderShipped   = "order.shipped"
+	EventOrderDelivered = "order.delivered"
+
+	// EventWishlistPriceDropped is sent to users whose wishlist contains a manga that got cheaper
+	EventWishlistPriceDropped = "wishlist.price_dropped"
+)
+
+// WebhookEvents lists the event types users can subscribe to
+var WebhookEvents = []string{
+	EventMangaCreated,
+	EventMangaUpdated,
+	EventMangaDeleted,
+	EventMangaPurchased,
+	EventOrderPlaced,
+	EventOrderPaid,
+	EventOrderShipped,
+	EventOrderDelivered,
+	EventWishlistPriceDropped,
+}
+
+// PaymentWebhookEvents lists the event types telling sellers they were paid;
+// operators are alerted when one can't be delivered
+var PaymentWebhookEvents = []string{
+	EventMangaPurchased,
+	EventOrderPaid,
+}
+
+// Webhook delivery statuses; a delivery is dead-lettered once every retry has
+// failed and stays that way until it is redelivered
+const (
+	WebhookDeliveryPending      = "pending"
+	WebhookDeliverySucceeded    = "succeeded"
+	WebhookDeliveryDeadLettered = "dead_lettered"
+)
+
+// WebhookDeliveryStatuses lists every webhook delivery status
+var WebhookDeliveryStatuses = []string{
+	WebhookDeliveryPending,
+	WebhookDeliverySucceeded,
+	WebhookDeliveryDeadLettered,
+}
+
+// Webhook represents a user-registered URL that receives signed event notifications
+type Webhook struct {
+	ID        uint           `json:"id" gorm:"primarykey"`
+	UserID    uint           `json:"user_id" gorm:"not null;index"`
+	URL       string         `json:"url" gorm:"not null"`
+	Secret    string         `json:"secret,omitempty" gorm:"type:text;not null;serializer:encrypted"` // encrypted at rest
+	Events    string         `json:"events" gorm:"not null"`                                          // Comma-separated event types
+	IsActive  bool           `json:"is_active" gorm:"default:true"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
+}
+
+// WebhookDelivery records one event delivery to a webhook
+type WebhookDelivery struct {
+	ID          uint       `json:"id" gorm:"primarykey"`
+	WebhookID   uint       `json:"webhook_id" gorm:"not null;index"`
+	Event       string     `json:"event" gorm:"not null"`
+	Payload     string     `json:"payload" gorm:"type:text"`
+	Status      string     `json:"status" gorm:"not null;default:pending;index"`
+	Attempts    int        `json:"attempts"`
+	StatusCode  int        `json:"status_code"`
+	Success     bool       `json:"success"`
+	LastError   string     `json:"last_error,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	DeliveredAt *time.Time `json:"delivered_at,omitempty"`
+
+	// AttemptLog lists every send attempt, loaded only when a single delivery is inspected
+	AttemptLog []WebhookDeliveryAttempt `json:"attempt_log,omitempty" gorm:"foreignKey:DeliveryID"`
+}
+
+// WebhookDeliveryAttempt records one try at sending a delivery
+type WebhookDeliveryAttempt struct {
+	ID         uint      `json:"id" gorm:"primarykey"`
+	DeliveryID uint      `json:"delivery_id" gorm:"not null;index"`
+	Attempt    int       `json:"attempt" gorm:"not null"`
+	StatusCode int       `json:"status_code"`
+	Error      string    `json:"error,omitempty"`
+	DurationMs int64     `json:"duration_ms"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// WebhookPayload is the JSON body sent to webhook endpoints
+type WebhookPayload struct {
+	DeliveryID uint        `json:"delivery_id"`
+	Event      string      `json:"event"`
+	CreatedAt  time.Time   `json:"created_at"`
+	Data       interface{} `json:"data"`
+}
+
+// IsValidWebhookEvent checks if the event type is supported
+func IsValidWebhookEvent(event string) bool {
+	for _, e := range WebhookEvents {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidWebhookDeliveryStatus checks if the delivery status is known
+func IsValidWebhookDeliveryStatus(status string) bool {
+	for _, s := range WebhookDeliveryStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// Subscribes checks if the webhook is subscribed to the event type
+func (w *Webhook) Subscribes(event string) bool {
+	for _, e := range strings.Split(w.Events, ",") {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// Sanitize removes the signing secret from the webhook before returning
+func (w *Webhook) Sanitize() *Webhook {
+	sanitized := *w
+	sanitized.Secret = ""
+	return &sanitized
+}
@@ -0,0 +1,8 @@
+package domain
+
+// CreateWebhookRequest represents the request body for registering a webhook
+type CreateWebhookRequest struct {
+	URL    string   `json:"url" validate:"required,url"`
+	Secret string   `json:"secret" validate:"omitempty,min=16"`
+	Events []string `json:"events" validate:"required,min=1"`
+}
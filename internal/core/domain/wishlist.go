@@ -0,0 +1,66 @@
+package domain
+
+import "time"
+
+// Wishlist visibility settings
+const (
+	WishlistPrivate  = "private"  // only the owner can see the list
+	WishlistUnlisted = "unlisted" // anyone with the share link can see the list
+	WishlistPublic   = "public"   // the list is also shown on the owner's profile
+)
+
+// Wishlist limits
+const (
+	MaxWishlistsPerUser = 20
+	MaxWishlistItems    = 500
+)
+
+// Wishlist is a named, ordered list of mangas a user intends to buy
+type Wishlist struct {
+	ID         uint           `json:"id" gorm:"primarykey"`
+	UserID     uint           `json:"user_id" gorm:"not null;index"`
+	Name       string         `json:"name" gorm:"not null"`
+	Visibility string         `json:"visibility" gorm:"not null;default:private"`
+	ShareToken string         `json:"share_token,omitempty" gorm:"not null;uniqueIndex"`
+	Items      []WishlistItem `json:"items,omitempty"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+}
+
+// IsShared reports whether the list can be viewed through its share link
+func (w *Wishlist) IsShared() bool {
+	return w.Visibility == WishlistUnlisted || w.Visibility == WishlistPublic
+}
+
+// ReadOnly returns a copy for viewers other than the owner, without the share
+// token and with only the given items
+func (w *Wishlist) ReadOnly(items []WishlistItem) *Wishlist {
+	return &Wishlist{
+		ID:         w.ID,
+		UserID:     w.UserID,
+		Name:       w.Name,
+		Visibility: w.Visibility,
+		Items:      items,
+		CreatedAt:  w.CreatedAt,
+		UpdatedAt:  w.UpdatedAt,
+	}
+}
+
+// WishlistItem is a manga on a wishlist, remembering its price when added
+type WishlistItem struct {
+	ID             uint      `json:"id" gorm:"primarykey"`
+	WishlistID     uint      `json:"wishlist_id" gorm:"not null;uniqueIndex:idx_wishlist_items_wishlist_manga"`
+	MangaID        uint      `json:"manga_id" gorm:"not null;uniqueIndex:idx_wishlist_items_wishlist_manga;index"`
+	Position       int       `json:"position" gorm:"not null"`
+	PriceWhenAdded float64   `json:"price_when_added"`
+	CreatedAt      time.Time `json:"created_at"`
+
+	Manga *Manga `json:"manga,omitempty"`
+}
+
+// WishlistPriceDrop is the payload sent to wishlisters when a manga gets cheaper
+type WishlistPriceDrop struct {
+	Manga    *Manga  `json:"manga"`
+	OldPrice float64 `json:"old_price"`
+	NewPrice float64 `json:"new_price"`
+}
@@ -0,0 +1,17 @@
+package domain
+
+// WishlistRequest represents the request body for creating or updating a wishlist
+type WishlistRequest struct {
+	Name       string `json:"name" validate:"required,max=100"`
+	Visibility string `json:"visibility" validate:"omitempty,oneof=private unlisted public"` // defaults to private
+}
+
+// AddWishlistItemRequest represents the request body for adding a manga to a wishlist
+type AddWishlistItemRequest struct {
+	MangaID uint `json:"manga_id" validate:"required"`
+}
+
+// ReorderWishlistRequest lists every manga on a wishlist in its new order
+type ReorderWishlistRequest struct {
+	MangaIDs []uint `json:"manga_ids" validate:"required,min=1"`
+}
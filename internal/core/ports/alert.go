@@ -0,0 +1,28 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// AlertSender defines the interface for posting alerts to a chat channel,
+// such as a Slack or Discord webhook
+type AlertSender interface {
+	// SendAlert posts the alert, returning once the channel has accepted it
+	SendAlert(ctx context.Context, alert *domain.Alert) error
+}
+
+// Alerter defines the interface for raising operational alerts. Alerts are
+// sent in the background, deduplicated by key and throttled, so callers never
+// wait on the chat service and a failing dependency can't flood the channel.
+type Alerter interface {
+	// Alert queues an alert, dropping it if one with the same key was sent recently
+	Alert(alert *domain.Alert)
+	// RecordServerError counts a 5xx response, alerting when they come in a burst
+	RecordServerError(method, path string, status int)
+	// StartDatabaseMonitor checks the database at the given interval, alerting
+	// when it goes down and again when it is reconnected
+	StartDatabaseMonitor(stats DatabaseStats, interval time.Duration)
+}
@@ -0,0 +1,48 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// APIKeyRepository defines the interface for API key data access
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *domain.APIKey) error
+	GetByID(ctx context.Context, id uint) (*domain.APIKey, error)
+	// GetByHash retrieves the key with the hash, revoked or not
+	GetByHash(ctx context.Context, hash string) (*domain.APIKey, error)
+	// ListByUser lists a user's keys, newest first, revoked ones included
+	ListByUser(ctx context.Context, userID uint) ([]*domain.APIKey, error)
+	// ListPaginated lists the keys of every user, newest first, optionally of
+	// one user and one status only
+	ListPaginated(ctx context.Context, userID uint, status string, pagination *domain.PaginationRequest) ([]*domain.APIKey, int64, error)
+	// CountActive counts a user's keys that aren't revoked
+	CountActive(ctx context.Context, userID uint) (int64, error)
+	Update(ctx context.Context, key *domain.APIKey) error
+	// TouchLastUsed records that the key was used at the given time
+	TouchLastUsed(ctx context.Context, id uint, at time.Time) error
+}
+
+// APIKeyService defines the interface for API key operations
+type APIKeyService interface {
+	// CreateKey creates a key for the user; the key is only returned now
+	CreateKey(ctx context.Context, userID uint, req *domain.CreateAPIKeyRequest) (*domain.APIKey, error)
+	// GetKeys lists the user's keys, revoked ones included
+	GetKeys(ctx context.Context, userID uint) ([]*domain.APIKey, error)
+	// UpdateKey renames one of the user's keys, or changes its scopes or rate limit
+	UpdateKey(ctx context.Context, id uint, userID uint, req *domain.UpdateAPIKeyRequest) (*domain.APIKey, error)
+	// RotateKey replaces the secret of one of the user's keys; the old one
+	// stops working at once and the new one is only returned now
+	RotateKey(ctx context.Context, id uint, userID uint) (*domain.APIKey, error)
+	// RevokeKey stops one of the user's keys for good
+	RevokeKey(ctx context.Context, id uint, userID uint) (*domain.APIKey, error)
+
+	// ListKeys lists the keys of every user, for security reviews
+	ListKeys(ctx context.Context, userID uint, status string, pagination *domain.PaginationRequest) (*domain.PaginatedResult[*domain.APIKey], error)
+
+	// Authenticate returns the key and the user it belongs to, recording
+	// that it was used
+	Authenticate(ctx context.Context, key string) (*domain.APIKey, *domain.User, error)
+}
@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// ArchiveService defines the interface for moving old records out of the live tables
+type ArchiveService interface {
+	Run(ctx context.Context) (*domain.ArchiveReport, error)
+	Stats() domain.ArchiveStats
+	// StartScheduler runs the archival in the background at the given interval
+	StartScheduler(interval time.Duration)
+}
@@ -0,0 +1,25 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// AuditLogRepository defines the interface for the audit log. Entries are
+// only ever added; there is no way to change or delete them.
+type AuditLogRepository interface {
+	// Create appends an entry, joining the transaction carried by ctx
+	Create(ctx context.Context, entry *domain.AuditLog) error
+	ListPaginated(ctx context.Context, filter *domain.AuditLogFilter, pagination *domain.PaginationRequest) ([]*domain.AuditLog, int64, error)
+}
+
+// AuditService defines the interface for recording and querying privileged
+// and mutating actions
+type AuditService interface {
+	// Record appends an entry for the action on the entity with the given ID
+	// (0 for actions on no single entity), made by the actor of ctx. Before
+	// and after are encoded as JSON; nil leaves them out.
+	Record(ctx context.Context, action string, entityID uint, before, after interface{}) error
+	GetAuditLogs(ctx context.Context, filter *domain.AuditLogFilter, pagination *domain.PaginationRequest) (*domain.PaginatedResult[*domain.AuditLog], error)
+}
@@ -1,20 +1,40 @@
 package ports
 
-import "github.com/thitiphongD/my-backend/internal/core/domain"
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
 
 // AuthService defines the interface for authentication operations
 type AuthService interface {
-	Register(req *domain.RegisterRequest) (*domain.AuthResponse, error)
-	Login(req *domain.LoginRequest) (*domain.AuthResponse, error)
-	GetUserByID(userID uint) (*domain.User, error)
-	ValidateToken(token string) (*domain.User, error)
+	Register(ctx context.Context, req *domain.RegisterRequest) (*domain.AuthResponse, error)
+	Login(ctx context.Context, req *domain.LoginRequest) (*domain.AuthResponse, error)
+	GetUserByID(ctx context.Context, userID uint) (*domain.User, error)
+	ValidateToken(ctx context.Context, token string) (*domain.User, error)
 }
 
 // UserService defines the interface for user operations
 type UserService interface {
-	CreateUser(req *domain.CreateUserRequest) (*domain.User, error)
-	GetUserByID(id uint) (*domain.User, error)
-	GetUsers() ([]*domain.User, error)
-	UpdateUser(id uint, req *domain.CreateUserRequest) (*domain.User, error)
-	DeleteUser(id uint) error
+	CreateUser(ctx context.Context, req *domain.CreateUserRequest) (*domain.User, error)
+	GetUserByID(ctx context.Context, id uint) (*domain.User, error)
+	GetUsers(ctx context.Context) ([]*domain.User, error)
+	SearchUsers(ctx context.Context, query string, limit int) ([]*domain.User, error)
+	// UpdateUser, PatchUser and DeleteUser change the user's account, which
+	// only the user themselves or an admin may. A new email address is only
+	// pending until confirmed with ConfirmEmailChange.
+	UpdateUser(ctx context.Context, id uint, req *domain.CreateUserRequest, callerID uint, isAdmin bool) (*domain.User, error)
+	PatchUser(ctx context.Context, id uint, req *domain.UpdateUserRequest, callerID uint, isAdmin bool) (*domain.User, error)
+	DeleteUser(ctx context.Context, id uint, callerID uint, isAdmin bool) error
+	// ConfirmEmailChange completes an email change with the token of the
+	// link emailed to the new address
+	ConfirmEmailChange(ctx context.Context, token string) (*domain.User, error)
+	// ExportPersonalData passes fn every table's rows of the user's data, for
+	// a personal data archive; tables is how many there are
+	ExportPersonalData(ctx context.Context, id uint, fn func(table string, rows interface{}, tables int) error) error
+
+	// Moderation operations
+	SuspendUser(ctx context.Context, id uint, req *domain.SuspendUserRequest, adminID uint) (*domain.User, error)
+	UnsuspendUser(ctx context.Context, id uint) (*domain.User, error)
+	MergeUsers(ctx context.Context, req *domain.MergeUsersRequest) (*domain.MergeUsersResult, error)
 }
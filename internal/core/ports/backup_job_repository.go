@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// BackupJobRepository defines the interface for backup job data access
+type BackupJobRepository interface {
+	Create(ctx context.Context, job *domain.BackupJob) error
+	Update(ctx context.Context, job *domain.BackupJob) error
+	GetByID(ctx context.Context, id uint) (*domain.BackupJob, error)
+	ListPaginated(ctx context.Context, kind string, pagination *domain.PaginationRequest) ([]*domain.BackupJob, int64, error)
+	// FailUnfinished marks pending and running jobs as failed with the given reason
+	FailUnfinished(ctx context.Context, reason string) (int64, error)
+}
@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// BackupService defines the interface for database backup and restore operations.
+// Only one backup or restore runs at a time.
+type BackupService interface {
+	// StartBackup and StartRestore queue the job and run it in the background
+	StartBackup(ctx context.Context, requestedBy *uint) (*domain.BackupJob, error)
+	StartRestore(ctx context.Context, backupID uint, requestedBy *uint) (*domain.BackupJob, error)
+	// Backup and Restore run the job and return once it is finished
+	Backup(ctx context.Context, requestedBy *uint) (*domain.BackupJob, error)
+	Restore(ctx context.Context, backupID uint, requestedBy *uint) (*domain.BackupJob, error)
+	GetJob(ctx context.Context, id uint) (*domain.BackupJob, error)
+	ListJobs(ctx context.Context, kind string, pagination *domain.PaginationRequest) (*domain.PaginatedResult[*domain.BackupJob], error)
+	// FailInterrupted marks jobs left unfinished by a previous process as failed
+	FailInterrupted(ctx context.Context) error
+}
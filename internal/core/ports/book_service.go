@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// BookLookup defines the interface for querying an external book catalogue.
+// It returns domain.ErrBookNotFound when the catalogue has no such ISBN.
+type BookLookup interface {
+	LookupISBN(ctx context.Context, isbn string) (*domain.BookMetadata, error)
+}
+
+// BookService defines the interface for book metadata operations
+type BookService interface {
+	LookupISBN(ctx context.Context, isbn string) (*domain.ISBNLookupResponse, error)
+}
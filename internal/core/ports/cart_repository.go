@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// CartRepository defines the interface for cart data access. Items are
+// loaded with their mangas, oldest first; items of deleted mangas are skipped.
+type CartRepository interface {
+	ListByUserID(ctx context.Context, userID uint) ([]*domain.CartItem, error)
+	// SetItem puts the manga in the user's cart, or changes its quantity
+	SetItem(ctx context.Context, item *domain.CartItem) error
+	RemoveItem(ctx context.Context, userID, mangaID uint) error
+	Clear(ctx context.Context, userID uint) error
+}
@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// CartService defines the interface for users' carts, which checkout orders
+type CartService interface {
+	GetCart(ctx context.Context, userID uint) (*domain.Cart, error)
+	SetItem(ctx context.Context, userID, mangaID uint, req *domain.SetCartItemRequest) (*domain.Cart, error)
+	RemoveItem(ctx context.Context, userID, mangaID uint) (*domain.Cart, error)
+	ClearCart(ctx context.Context, userID uint) error
+}
@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// ChapterRepository defines the interface for chapter data access
+type ChapterRepository interface {
+	Create(ctx context.Context, chapter *domain.Chapter) error
+	GetByID(ctx context.Context, id uint) (*domain.Chapter, error)
+	GetByMangaAndNumber(ctx context.Context, mangaID uint, number float64) (*domain.Chapter, error)
+	ListByMangaID(ctx context.Context, mangaID uint) ([]*domain.Chapter, error)
+	Update(ctx context.Context, chapter *domain.Chapter) error
+	Delete(ctx context.Context, id uint) error
+}
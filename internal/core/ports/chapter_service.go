@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// ChapterService defines the interface for chapter business operations
+type ChapterService interface {
+	CreateChapter(ctx context.Context, mangaID uint, req *domain.ChapterRequest, userID uint) (*domain.Chapter, error)
+	GetChapter(ctx context.Context, mangaID, chapterID uint) (*domain.Chapter, error)
+	GetChapters(ctx context.Context, mangaID uint) ([]*domain.Chapter, error)
+	UpdateChapter(ctx context.Context, mangaID, chapterID uint, req *domain.ChapterRequest, userID uint) (*domain.Chapter, error)
+	DeleteChapter(ctx context.Context, mangaID, chapterID uint, userID uint) error
+}
@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// CommentRepository defines the interface for comment data access
+type CommentRepository interface {
+	Create(ctx context.Context, comment *domain.Comment) error
+	GetByID(ctx context.Context, id uint) (*domain.Comment, error)
+	ListThreadsPaginated(ctx context.Context, mangaID uint, pagination *domain.PaginationRequest) ([]*domain.Comment, int64, error)
+	Update(ctx context.Context, comment *domain.Comment) error
+	Delete(ctx context.Context, id uint) error
+}
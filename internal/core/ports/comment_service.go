@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// CommentService defines the interface for comment business operations
+type CommentService interface {
+	CreateComment(ctx context.Context, mangaID uint, req *domain.CreateCommentRequest, userID uint) (*domain.Comment, error)
+	GetCommentsPaginated(ctx context.Context, mangaID uint, pagination *domain.PaginationRequest) (*domain.PaginatedResult[*domain.Comment], error)
+	UpdateComment(ctx context.Context, mangaID, commentID uint, req *domain.UpdateCommentRequest, userID uint) (*domain.Comment, error)
+	DeleteComment(ctx context.Context, mangaID, commentID uint, userID uint) error
+	ModerateDeleteComment(ctx context.Context, commentID uint) error
+}
@@ -0,0 +1,31 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// DashboardRepository defines the interface for the aggregations behind the
+// admin dashboard
+type DashboardRepository interface {
+	// Stats counts the users, mangas, orders, revenue and jobs; records
+	// created since day and week are counted apart
+	Stats(ctx context.Context, now, day, week time.Time) (*domain.DashboardStats, error)
+}
+
+// RequestStats defines the interface for counting the responses this instance served
+type RequestStats interface {
+	// Record counts a response with the status
+	Record(status int)
+	// Recent returns the counts of the last domain.RequestStatsWindow
+	Recent() domain.RequestCounts
+}
+
+// DashboardService defines the interface for the admin dashboard
+type DashboardService interface {
+	// GetDashboard returns the dashboard figures, computed at most once per
+	// cache period
+	GetDashboard(ctx context.Context) (*domain.DashboardStats, error)
+}
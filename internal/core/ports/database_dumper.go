@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+	"io"
+)
+
+// DatabaseDumper defines the interface for logical backups of the database
+type DatabaseDumper interface {
+	// Dump writes a backup of the whole database to w
+	Dump(ctx context.Context, w io.Writer) error
+	// Restore replaces the database's contents with the backup read from r
+	Restore(ctx context.Context, r io.Reader) error
+}
@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// DatabaseStats defines the interface for inspecting the database connection pools
+type DatabaseStats interface {
+	// PoolStats returns the stats of the primary's pool followed by each replica's
+	PoolStats() []domain.DatabasePoolStats
+	// Health checks that the primary and replicas answer and reports the pools,
+	// replication lag and schema version
+	Health(ctx context.Context) *domain.DatabaseHealth
+}
@@ -0,0 +1,46 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// DigestRepository defines the interface for digest data access
+type DigestRepository interface {
+	AddItem(ctx context.Context, item *domain.DigestItem) error
+	// ListDueUsers lists up to limit users getting digests at the frequency
+	// who have items pending since before until
+	ListDueUsers(ctx context.Context, frequency string, until time.Time, limit int) ([]uint, error)
+	// DiscardOffItems deletes the pending items of users who turned digests off
+	DiscardOffItems(ctx context.Context) (int64, error)
+	Create(ctx context.Context, digest *domain.Digest) error
+	// AttachPendingItems puts the user's items pending since before until in
+	// the digest, returning how many there were
+	AttachPendingItems(ctx context.Context, digestID, userID uint, until time.Time) (int64, error)
+	// GetByID retrieves a digest with its items
+	GetByID(ctx context.Context, id uint) (*domain.Digest, error)
+	// ListByUser lists a user's digests with their items, newest first
+	ListByUser(ctx context.Context, userID uint, pagination *domain.PaginationRequest) ([]*domain.Digest, int64, error)
+	MarkRead(ctx context.Context, id uint, at time.Time) error
+}
+
+// DigestService defines the interface for the digests of low-priority
+// notifications
+type DigestService interface {
+	// The in_app channel: notifications held for the user's next digest
+	NotificationChannel
+
+	GetDigests(ctx context.Context, userID uint, pagination *domain.PaginationRequest) (*domain.PaginatedResult[*domain.Digest], error)
+	// GetDigest retrieves one of the user's digests
+	GetDigest(ctx context.Context, id uint, userID uint) (*domain.Digest, error)
+	// MarkRead marks one of the user's digests read
+	MarkRead(ctx context.Context, id uint, userID uint) (*domain.Digest, error)
+
+	// QueueDueDigests makes the digest of every user with items pending
+	// since before the end of their last period, returning how many were made
+	QueueDueDigests(ctx context.Context) (int, error)
+	// StartScheduler makes due digests in the background at the given interval
+	StartScheduler(interval time.Duration)
+}
@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// DiscountRepository defines the interface for discount data access
+type DiscountRepository interface {
+	Create(ctx context.Context, discount *domain.Discount) error
+	GetByID(ctx context.Context, id uint) (*domain.Discount, error)
+	List(ctx context.Context) ([]*domain.Discount, error)
+	ListActive(ctx context.Context, now time.Time) ([]*domain.Discount, error)
+	Update(ctx context.Context, discount *domain.Discount) error
+	Delete(ctx context.Context, id uint) error
+}
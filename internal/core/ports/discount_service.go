@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// DiscountService defines the interface for discount business operations
+type DiscountService interface {
+	CreateDiscount(ctx context.Context, req *domain.DiscountRequest) (*domain.Discount, error)
+	GetDiscounts(ctx context.Context) ([]*domain.Discount, error)
+	UpdateDiscount(ctx context.Context, id uint, req *domain.DiscountRequest) (*domain.Discount, error)
+	DeleteDiscount(ctx context.Context, id uint) error
+}
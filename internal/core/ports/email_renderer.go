@@ -0,0 +1,12 @@
+package ports
+
+import "github.com/thitiphongD/my-backend/internal/core/domain"
+
+// EmailRenderer defines the interface for rendering transactional emails from templates
+type EmailRenderer interface {
+	// Render renders the named email in the locale, or in English when the
+	// template has no variant in it; the message has no recipient yet
+	Render(name, locale string, data *domain.EmailData) (*domain.EmailMessage, error)
+	// Templates lists every email template with the locales it has variants in
+	Templates() (map[string][]string, error)
+}
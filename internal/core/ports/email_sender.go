@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// EmailSender defines the interface for sending transactional email
+type EmailSender interface {
+	Send(ctx context.Context, msg *domain.EmailMessage) error
+}
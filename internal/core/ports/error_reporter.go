@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// ErrorReporter defines the interface for sending errors to an error tracker,
+// such as Sentry
+type ErrorReporter interface {
+	// Capture sends the error in the background, with the request ID and
+	// breadcrumbs carried by ctx; it never blocks the caller
+	Capture(ctx context.Context, report *domain.ErrorReport)
+}
@@ -0,0 +1,26 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// EventHandler handles one published domain event
+type EventHandler func(ctx context.Context, event domain.Event) error
+
+// EventBus defines the interface for publishing domain events to in-process
+// subscribers, so features reacting to a change subscribe to it rather than
+// being called by the service making the change
+type EventBus interface {
+	// Publish passes the event to the subscribers of its name, in the order
+	// they subscribed. Services publish within the transaction of the change.
+	Publish(ctx context.Context, event domain.Event) error
+	// Subscribe adds a handler that runs in the publisher's transaction; its
+	// error fails the publish and rolls the change back
+	Subscribe(name string, handler EventHandler)
+	// SubscribeAfterCommit adds a handler that runs once the publisher's
+	// transaction has committed, and never for changes rolled back; its error
+	// is only logged
+	SubscribeAfterCommit(name string, handler EventHandler)
+}
@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// EventPublisher defines the interface for publishing events to a message
+// broker, such as Kafka or NATS, for consumers outside this service
+type EventPublisher interface {
+	// Publish sends the event to the topic, returning once the broker has accepted it
+	Publish(ctx context.Context, topic string, event *domain.CloudEvent) error
+}
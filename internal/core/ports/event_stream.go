@@ -0,0 +1,13 @@
+package ports
+
+import "github.com/thitiphongD/my-backend/internal/core/domain"
+
+// EventStream defines the interface for live, in-process event subscriptions.
+// Dispatching an event delivers it to the user's current subscribers.
+type EventStream interface {
+	WebhookDispatcher
+
+	// Subscribe returns a channel of the user's events of the given types, or of
+	// every type when events is empty, and a function that ends the subscription
+	Subscribe(userID uint, events []string) (<-chan *domain.StreamEvent, func())
+}
@@ -0,0 +1,25 @@
+package ports
+
+import (
+	"io"
+	"time"
+)
+
+// FileStorage defines the interface for storing uploaded files
+type FileStorage interface {
+	// Save streams the file from r into storage under key and returns its public URL
+	Save(key string, r io.Reader) (string, error)
+	// Open reads back the file stored under key; the caller closes it
+	Open(key string) (io.ReadCloser, error)
+	Delete(key string) error
+}
+
+// PresignedStorage is a FileStorage that clients can upload files to directly
+type PresignedStorage interface {
+	FileStorage
+	// PresignPut returns a URL that accepts a PUT of exactly size bytes of
+	// the content type, storing them under key, until it expires
+	PresignPut(key, contentType string, size int64, expires time.Duration) (string, error)
+	// URL returns the public URL of the file stored under key
+	URL(key string) string
+}
@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// GenreRepository defines the interface for genre data access
+type GenreRepository interface {
+	Create(ctx context.Context, genre *domain.Genre) error
+	GetByID(ctx context.Context, id uint) (*domain.Genre, error)
+	GetBySlug(ctx context.Context, slug string) (*domain.Genre, error)
+	GetByIDs(ctx context.Context, ids []uint) ([]*domain.Genre, error)
+	ListWithCounts(ctx context.Context) ([]*domain.GenreCount, error)
+	Update(ctx context.Context, genre *domain.Genre) error
+	Delete(ctx context.Context, id uint) error
+}
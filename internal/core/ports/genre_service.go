@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// GenreService defines the interface for genre business operations
+type GenreService interface {
+	CreateGenre(ctx context.Context, req *domain.GenreRequest) (*domain.Genre, error)
+	GetGenres(ctx context.Context) ([]*domain.GenreCount, error)
+	UpdateGenre(ctx context.Context, id uint, req *domain.GenreRequest) (*domain.Genre, error)
+	DeleteGenre(ctx context.Context, id uint) error
+}
@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+	"image"
+)
+
+// ImageEncoder defines the interface for encoding images in a format the
+// standard library cannot write, such as WebP
+type ImageEncoder interface {
+	// Format is the domain.ImageFormat the encoder writes
+	Format() string
+	Encode(ctx context.Context, img image.Image) ([]byte, error)
+}
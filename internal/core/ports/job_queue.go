@@ -0,0 +1,101 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// JobRepository defines the interface for background job data access
+type JobRepository interface {
+	// Create stores a job, joining the transaction carried by ctx
+	Create(ctx context.Context, job *domain.Job) error
+	// Claim marks up to limit due jobs of the given kinds running until
+	// lockedUntil, counting an attempt, and returns them oldest first
+	Claim(ctx context.Context, kinds []string, limit int, lockedUntil time.Time) ([]*domain.Job, error)
+	Update(ctx context.Context, job *domain.Job) error
+	// UpdateRunning saves the outcome of a running job, reporting false when
+	// it is no longer running, such as when an admin cancelled it
+	UpdateRunning(ctx context.Context, job *domain.Job) (bool, error)
+	// UpdateProgress records the progress of a running job
+	UpdateProgress(ctx context.Context, id uint, progress int) error
+	GetByID(ctx context.Context, id uint) (*domain.Job, error)
+	// GetStatus retrieves the status of a job alone
+	GetStatus(ctx context.Context, id uint) (string, error)
+	// GetWithErrorLog retrieves a job with the errors of its failed attempts
+	GetWithErrorLog(ctx context.Context, id uint) (*domain.Job, error)
+	// ListPaginated lists jobs, optionally of some statuses and one kind only
+	ListPaginated(ctx context.Context, statuses []string, kind string, pagination *domain.PaginationRequest) ([]*domain.Job, int64, error)
+	// CreateError records the error of a failed attempt
+	CreateError(ctx context.Context, jobErr *domain.JobError) error
+	// RequeueDead makes the dead jobs, of one kind or all, pending again with
+	// a fresh set of attempts
+	RequeueDead(ctx context.Context, kind string) (int64, error)
+	// Cancel marks a pending or running job cancelled
+	Cancel(ctx context.Context, id uint) error
+	// DeleteDead deletes a dead job with its error log
+	DeleteDead(ctx context.Context, id uint) error
+	// DeleteAllDead deletes the dead jobs, of one kind or all, with their error logs
+	DeleteAllDead(ctx context.Context, kind string) (int64, error)
+	// DeleteFinishedBefore deletes the jobs that succeeded or were cancelled
+	// before the given time with their error logs
+	DeleteFinishedBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// JobQueue defines the interface for enqueuing background jobs. Jobs are
+// stored with the caller's transaction, so they only run once it commits.
+type JobQueue interface {
+	Enqueue(ctx context.Context, args domain.JobArgs, opts *domain.JobOptions) (*domain.Job, error)
+}
+
+// JobHandler runs one job. An error runs the job again later, unless it wraps
+// domain.ErrPermanentJobFailure or the job is out of attempts.
+type JobHandler func(ctx context.Context, job *domain.Job) error
+
+// ScheduledTask is the work a scheduler does at each interval
+type ScheduledTask func(ctx context.Context) error
+
+// JobService defines the interface for the background job queue
+type JobService interface {
+	JobQueue
+
+	// Handle sets the handler of a job kind; jobs are only claimed for kinds with a handler
+	Handle(kind string, handler JobHandler)
+	// Work claims and runs due jobs, one at a time, until none are left; it
+	// returns how many ran
+	Work(ctx context.Context) (int, error)
+	// StartWorkers runs jobs in the background on concurrency workers, which
+	// look for due jobs at the given interval when idle, and prunes finished jobs
+	StartWorkers(concurrency int, pollInterval time.Duration)
+	// ReportProgress records that a running job has done done of total units
+	// of work
+	ReportProgress(ctx context.Context, job *domain.Job, done, total int64) error
+
+	// GetJobs lists jobs, optionally of some statuses and one kind only
+	GetJobs(ctx context.Context, statuses []string, kind string, pagination *domain.PaginationRequest) (*domain.PaginatedResult[*domain.Job], error)
+	// GetJob retrieves a job with its error log
+	GetJob(ctx context.Context, id uint) (*domain.Job, error)
+	// GetUserJob retrieves a job run for the user
+	GetUserJob(ctx context.Context, id uint, userID uint) (*domain.Job, error)
+	// RetryPolicies returns the retry policy of every job kind with a handler
+	RetryPolicies() map[string]domain.RetryPolicy
+
+	// RetryJob runs a dead job again with a fresh set of attempts
+	RetryJob(ctx context.Context, id uint) (*domain.Job, error)
+	// DiscardJob deletes a dead job
+	DiscardJob(ctx context.Context, id uint) error
+	// CancelJob stops a pending or running job
+	CancelJob(ctx context.Context, id uint) (*domain.Job, error)
+	// RetryDeadJobs runs the dead jobs, of one kind or all, again
+	RetryDeadJobs(ctx context.Context, kind string) (int64, error)
+	// DiscardDeadJobs deletes the dead jobs, of one kind or all
+	DiscardDeadJobs(ctx context.Context, kind string) (int64, error)
+
+	// HandleScheduledTask registers the work of a scheduler, so admins can run it at once
+	HandleScheduledTask(name string, task ScheduledTask)
+	// ScheduledTasks lists the names of the registered scheduled tasks
+	ScheduledTasks() []string
+	// RunScheduledTask queues a run of a scheduled task as a job
+	RunScheduledTask(ctx context.Context, name string) (*domain.Job, error)
+}
@@ -0,0 +1,49 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// LineMessenger defines the interface for the LINE Messaging API of our
+// official account
+type LineMessenger interface {
+	// ParseWebhook checks the signature of a webhook request body and returns its events
+	ParseWebhook(body []byte, signature string) ([]domain.LineEvent, error)
+	// IssueLinkToken issues a token for linking the LINE user to one of ours
+	IssueLinkToken(ctx context.Context, lineUserID string) (string, error)
+	// LinkURL is the LINE page confirming a link, given the link token and our nonce
+	LinkURL(linkToken, nonce string) string
+	// Reply answers an event with a text message
+	Reply(ctx context.Context, replyToken, text string) error
+	// Push sends a text message to the LINE user
+	Push(ctx context.Context, lineUserID, text string) error
+}
+
+// LineAccountRepository defines the interface for LINE account link data access
+type LineAccountRepository interface {
+	GetByUserID(ctx context.Context, userID uint) (*domain.LineAccount, error)
+	GetByLineUserID(ctx context.Context, lineUserID string) (*domain.LineAccount, error)
+	Save(ctx context.Context, account *domain.LineAccount) error
+	// Link finishes the link in progress with the unexpired nonce, unlinking
+	// the LINE user from any other user
+	Link(ctx context.Context, nonce, lineUserID string) (*domain.LineAccount, error)
+	Delete(ctx context.Context, userID uint) error
+	DeleteByLineUserID(ctx context.Context, lineUserID string) error
+}
+
+// LineService defines the interface for linking LINE accounts and notifying
+// users on LINE
+type LineService interface {
+	// The line channel: notifications pushed to linked accounts
+	NotificationChannel
+
+	GetAccount(ctx context.Context, userID uint) (*domain.LineAccount, error)
+	// StartLink starts linking the user to the LINE account the link token
+	// was issued for, returning the LINE page to send them to
+	StartLink(ctx context.Context, userID uint, req *domain.StartLineLinkRequest) (*domain.StartLineLinkResponse, error)
+	Unlink(ctx context.Context, userID uint) error
+	// HandleWebhook handles the events of a webhook request from LINE
+	HandleWebhook(ctx context.Context, body []byte, signature string) error
+}
@@ -0,0 +1,25 @@
+package ports
+
+import (
+	"context"
+	"io"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// MalwareScanner defines the interface for scanning files for malware
+type MalwareScanner interface {
+	// Scan reads the file from r; it fails when the file could not be
+	// scanned, so callers can reject files they don't know to be clean
+	Scan(ctx context.Context, r io.Reader) (*domain.ScanResult, error)
+}
+
+// MalwareScanService defines the interface for screening uploaded files. A
+// flagged file is moved to quarantine, the admins are notified, and the scan
+// fails with an error wrapping domain.ErrMalwareDetected.
+type MalwareScanService interface {
+	// Scan scans a file before it is stored under report.StorageKey
+	Scan(ctx context.Context, file io.ReadSeeker, report *domain.MalwareReport) error
+	// ScanStored scans the file stored under report.StorageKey
+	ScanStored(ctx context.Context, report *domain.MalwareReport) error
+}
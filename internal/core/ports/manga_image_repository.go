@@ -0,0 +1,23 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// MangaImageRepository defines the interface for manga gallery data access
+type MangaImageRepository interface {
+	// Create adds the image at the end of the manga's gallery
+	Create(ctx context.Context, image *domain.MangaImage) error
+	GetByID(ctx context.Context, id uint) (*domain.MangaImage, error)
+	ListByMangaID(ctx context.Context, mangaID uint) ([]*domain.MangaImage, error)
+	CountByMangaID(ctx context.Context, mangaID uint) (int64, error)
+	Update(ctx context.Context, image *domain.MangaImage) error
+	// SetVariants saves the size, variants and thumbnail URL of a processed
+	// image, if it still exists
+	SetVariants(ctx context.Context, image *domain.MangaImage) error
+	Delete(ctx context.Context, id uint) error
+	// Reorder sets each image's position to its index in imageIDs
+	Reorder(ctx context.Context, mangaID uint, imageIDs []uint) error
+}
@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// MangaImageService defines the interface for manga gallery business operations
+type MangaImageService interface {
+	GetImages(ctx context.Context, mangaID uint) ([]*domain.MangaImage, error)
+	UploadImage(ctx context.Context, mangaID uint, req *domain.UploadMangaImageRequest, userID uint) (*domain.MangaImage, error)
+	// AddStoredImage adds an image already in storage, such as a presigned upload
+	AddStoredImage(ctx context.Context, mangaID uint, req *domain.AddStoredMangaImageRequest, userID uint) (*domain.MangaImage, error)
+	UpdateImage(ctx context.Context, mangaID, imageID uint, req *domain.UpdateMangaImageRequest, userID uint) (*domain.MangaImage, error)
+	ReorderImages(ctx context.Context, mangaID uint, req *domain.ReorderMangaImagesRequest, userID uint) ([]*domain.MangaImage, error)
+	DeleteImage(ctx context.Context, mangaID, imageID uint, userID uint) error
+}
@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// MangaRelationRepository defines the interface for manga relation data access.
+// Create and Delete maintain both directions of a link together.
+type MangaRelationRepository interface {
+	Create(ctx context.Context, relation *domain.MangaRelation) error
+	GetByPair(ctx context.Context, mangaID, relatedID uint) (*domain.MangaRelation, error)
+	ListByMangaID(ctx context.Context, mangaID uint) ([]*domain.MangaRelation, error)
+	ListRelatedIDs(ctx context.Context, mangaID uint, relationType string) ([]uint, error)
+	Delete(ctx context.Context, mangaID, relatedID uint) error
+}
@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// MangaRelationService defines the interface for manga relation business operations
+type MangaRelationService interface {
+	CreateRelation(ctx context.Context, mangaID uint, req *domain.CreateMangaRelationRequest, userID uint) (*domain.MangaRelation, error)
+	GetRelated(ctx context.Context, mangaID uint) ([]*domain.MangaRelation, error)
+	DeleteRelation(ctx context.Context, mangaID, relatedID uint, userID uint) error
+}
@@ -1,24 +1,64 @@
 package ports
 
-import "github.com/thitiphongD/my-backend/internal/core/domain"
+import (
+	"context"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
 
 // MangaRepository defines the interface for manga data access
 type MangaRepository interface {
 	// Manga CRUD operations
-	Create(manga *domain.Manga) error
-	GetByID(id uint) (*domain.Manga, error)
-	GetByUserID(userID uint) ([]*domain.Manga, error)
-	List() ([]*domain.Manga, error)
-	Update(manga *domain.Manga) error
-	Delete(id uint) error
-
-	// Additional queries
-	GetActiveMangas() ([]*domain.Manga, error)
-	GetMangasByPriceRange(min, max float64) ([]*domain.Manga, error)
-
-	// Paginated queries
-	ListPaginated(pagination *domain.PaginationRequest) ([]*domain.Manga, int64, error)
-	GetActiveMangasPaginated(pagination *domain.PaginationRequest) ([]*domain.Manga, int64, error)
-	GetMangasByUserIDPaginated(userID uint, pagination *domain.PaginationRequest) ([]*domain.Manga, int64, error)
-	GetMangasByPriceRangePaginated(min, max float64, pagination *domain.PaginationRequest) ([]*domain.Manga, int64, error)
+	Create(ctx context.Context, manga *domain.Manga) error
+	CreateInBatches(ctx context.Context, mangas []*domain.Manga, batchSize int) []domain.BatchCreateFailure
+	GetByID(ctx context.Context, id uint) (*domain.Manga, error)
+	GetByIDIncluding(ctx context.Context, id uint, include domain.Includes) (*domain.Manga, error)
+	GetByTeamID(ctx context.Context, teamID uint, sort domain.Sort) ([]*domain.Manga, error)
+	Update(ctx context.Context, manga *domain.Manga) error
+	// UpdateIfUnchanged is Update, failing with domain.ErrPreconditionFailed
+	// when the manga was saved since it was read at the version (UpdatedAt)
+	UpdateIfUnchanged(ctx context.Context, manga *domain.Manga, version time.Time) error
+	Delete(ctx context.Context, id uint) error
+	UpdateMany(ctx context.Context, mangas []*domain.Manga) error
+	DeleteMany(ctx context.Context, ids []uint) error
+	GetDeletedByID(ctx context.Context, id uint) (*domain.Manga, error)
+	GetDeletedPaginated(ctx context.Context, userID *uint, pagination *domain.PaginationRequest) ([]*domain.Manga, int64, error)
+	Restore(ctx context.Context, manga *domain.Manga) error
+	Purge(ctx context.Context, id uint) error
+	GetPurgeableIDs(ctx context.Context, deletedBefore time.Time, limit int) ([]uint, error)
+	ReplaceGenres(ctx context.Context, manga *domain.Manga, genres []*domain.Genre) error
+
+	// Stock operations are atomic and never let stock go below zero
+	AdjustStock(ctx context.Context, id uint, delta int) error
+	DecrementStock(ctx context.Context, id uint, quantity int) error
+	GetLowStock(ctx context.Context, userID *uint, threshold int) ([]*domain.Manga, error)
+
+	// ExportInBatches streams mangas to fn in chunks; a nil userID exports all mangas
+	ExportInBatches(ctx context.Context, userID *uint, batchSize int, fn func([]*domain.Manga) error) error
+	// CountForExport counts the mangas ExportInBatches exports
+	CountForExport(ctx context.Context, userID *uint) (int64, error)
+
+	// Moderation workflow
+	UpdateStatus(ctx context.Context, id uint, from []string, to string, reason string) error
+	GetByStatusPaginated(ctx context.Context, status string, pagination *domain.PaginationRequest) ([]*domain.Manga, int64, error)
+	GetOwnedByUser(ctx context.Context, userID uint, status string, sort domain.Sort) ([]*domain.Manga, error)
+
+	// FindByNamePrefix retrieves the user's own mangas and all published mangas whose
+	// normalized name starts with prefix, as candidates for duplicate detection
+	FindByNamePrefix(ctx context.Context, prefix string, userID uint, limit int) ([]*domain.Manga, error)
+
+	// Recommendation queries
+	GetSimilar(ctx context.Context, manga *domain.Manga, limit int) ([]*domain.Manga, error)
+	GetRecommendedForUser(ctx context.Context, userID uint, limit int) ([]*domain.Manga, error)
+
+	// Search retrieves visible mangas matching the filter with pagination,
+	// loading only the columns needed for the selected fields
+	Search(ctx context.Context, filter *domain.MangaFilter, pagination *domain.PaginationRequest, sort domain.Sort, fields domain.Fields, include domain.Includes) ([]*domain.Manga, int64, error)
+	// CountByFacet counts the visible mangas matching the filter per value of
+	// one of the domain.MangaSearchFacets
+	CountByFacet(ctx context.Context, filter *domain.MangaFilter, facet string) (map[string]int64, error)
+	// GetVisibleByIDs retrieves the visible mangas among ids, in no particular
+	// order, for hits of an external search index
+	GetVisibleByIDs(ctx context.Context, ids []uint) ([]*domain.Manga, error)
 }
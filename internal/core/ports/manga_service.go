@@ -1,21 +1,41 @@
 package ports
 
-import "github.com/thitiphongD/my-backend/internal/core/domain"
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
 
 // MangaService defines the interface for manga business operations
 type MangaService interface {
-	CreateManga(req *domain.CreateMangaRequest, userID uint) (*domain.Manga, error)
-	GetMangaByID(id uint) (*domain.Manga, error)
-	GetMangas() ([]*domain.Manga, error)
-	GetMangasByUser(userID uint) ([]*domain.Manga, error)
-	UpdateManga(id uint, req *domain.UpdateMangaRequest, userID uint) (*domain.Manga, error)
-	DeleteManga(id uint, userID uint) error
-	GetActiveMangas() ([]*domain.Manga, error)
-	GetMangasByPriceRange(min, max float64) ([]*domain.Manga, error)
+	CreateManga(ctx context.Context, req *domain.CreateMangaRequest, userID uint) (*domain.Manga, error)
+	ImportMangas(ctx context.Context, rows []*domain.MangaImportRow, userID uint) *domain.MangaImportReport
+	ExportMangas(ctx context.Context, userID uint, isAdmin bool, fn func([]*domain.Manga) error) error
+	// CountExportMangas counts the mangas ExportMangas exports
+	CountExportMangas(ctx context.Context, userID uint, isAdmin bool) (int64, error)
+	GetMangaByID(ctx context.Context, id uint, isAdult bool, include domain.Includes) (*domain.Manga, error)
+	GetMangaFacets(ctx context.Context, filter *domain.MangaFilter) (*domain.MangaFacets, error)
+	GetMangas(ctx context.Context, filter *domain.MangaFilter, pagination *domain.PaginationRequest, sort domain.Sort, fields domain.Fields, include domain.Includes) (*domain.PaginatedResult[*domain.Manga], error)
+	GetMangasByTeam(ctx context.Context, teamID uint, userID uint, sort domain.Sort) ([]*domain.Manga, error)
+	UpdateManga(ctx context.Context, id uint, req *domain.UpdateMangaRequest, userID uint) (*domain.Manga, error)
+	DeleteManga(ctx context.Context, id uint, userID uint) error
+	BatchUpdateMangas(ctx context.Context, req *domain.BatchUpdateMangasRequest, userID uint) (*domain.BatchResult, error)
+	BatchDeleteMangas(ctx context.Context, ids []uint, userID uint) (*domain.BatchResult, error)
+	GetDeletedMangas(ctx context.Context, userID uint, isAdmin bool, pagination *domain.PaginationRequest) (*domain.PaginatedResult[*domain.Manga], error)
+	RestoreManga(ctx context.Context, id uint, userID uint, isAdmin bool) (*domain.Manga, error)
+	PurgeManga(ctx context.Context, id uint) error
+	GetPriceHistory(ctx context.Context, id uint) (*domain.PriceHistoryResponse, error)
+	GetMangaHistory(ctx context.Context, id uint, userID uint) ([]*domain.MangaVersion, error)
+	RevertManga(ctx context.Context, id uint, version int, userID uint) (*domain.Manga, error)
+	GetMyMangas(ctx context.Context, userID uint, status string, sort domain.Sort) ([]*domain.Manga, error)
 
-	// Paginated operations
-	GetMangasPaginated(pagination *domain.PaginationRequest) (*domain.PaginatedResult[*domain.Manga], error)
-	GetActiveMangasPaginated(pagination *domain.PaginationRequest) (*domain.PaginatedResult[*domain.Manga], error)
-	GetMangasByUserPaginated(userID uint, pagination *domain.PaginationRequest) (*domain.PaginatedResult[*domain.Manga], error)
-	GetMangasByPriceRangePaginated(min, max float64, pagination *domain.PaginationRequest) (*domain.PaginatedResult[*domain.Manga], error)
+	// Moderation workflow
+	SubmitManga(ctx context.Context, id uint, userID uint) (*domain.Manga, error)
+	ApproveManga(ctx context.Context, id uint) (*domain.Manga, error)
+	RejectManga(ctx context.Context, id uint, reason string) (*domain.Manga, error)
+	GetReviewQueue(ctx context.Context, pagination *domain.PaginationRequest) (*domain.PaginatedResult[*domain.Manga], error)
+	AdjustStock(ctx context.Context, id uint, req *domain.StockAdjustRequest, userID uint) (*domain.Manga, error)
+	GetLowStockMangas(ctx context.Context, userID uint, isAdmin bool, threshold int) ([]*domain.Manga, error)
+	GetRecommendations(ctx context.Context, id uint, limit int) ([]*domain.Manga, error)
+	GetUserRecommendations(ctx context.Context, userID uint, limit int) ([]*domain.Manga, error)
 }
@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// MangaTranslationRepository defines the interface for manga translation data access
+type MangaTranslationRepository interface {
+	// Upsert creates or replaces the manga's translation in the translation's locale
+	Upsert(ctx context.Context, translation *domain.MangaTranslation) error
+	GetByLocale(ctx context.Context, mangaID uint, locale string) (*domain.MangaTranslation, error)
+	ListByMangaID(ctx context.Context, mangaID uint) ([]*domain.MangaTranslation, error)
+	Delete(ctx context.Context, mangaID uint, locale string) error
+}
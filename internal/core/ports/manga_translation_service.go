@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// MangaTranslationService defines the interface for manga translation business operations
+type MangaTranslationService interface {
+	GetTranslations(ctx context.Context, mangaID uint) ([]*domain.MangaTranslation, error)
+	SaveTranslation(ctx context.Context, mangaID uint, locale string, req *domain.SaveMangaTranslationRequest, userID uint) (*domain.MangaTranslation, error)
+	DeleteTranslation(ctx context.Context, mangaID uint, locale string, userID uint) error
+}
@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// MangaVersionRepository defines the interface for manga version history data access
+type MangaVersionRepository interface {
+	// Create stores the version, assigning it the next version number for the manga
+	Create(ctx context.Context, version *domain.MangaVersion) error
+	GetByVersion(ctx context.Context, mangaID uint, version int) (*domain.MangaVersion, error)
+	ListByMangaID(ctx context.Context, mangaID uint) ([]*domain.MangaVersion, error)
+	CountByMangaID(ctx context.Context, mangaID uint) (int64, error)
+}
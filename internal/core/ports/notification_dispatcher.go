@@ -0,0 +1,23 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// NotificationChannel defines the interface for a channel notifications are
+// delivered on, such as email or LINE
+type NotificationChannel interface {
+	// Deliver sends the notification to its user; notifications the channel
+	// has no message for, or that the user can't get on it, are skipped
+	Deliver(ctx context.Context, n *domain.Notification) error
+}
+
+// NotificationDispatcher defines the interface for delivering notifications
+// on the channels each user routes them to
+type NotificationDispatcher interface {
+	// Dispatch delivers the notification on the user's channels for its
+	// type, holding it until the end of their quiet hours
+	Dispatch(ctx context.Context, n *domain.Notification) error
+}
@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// NotificationPreferenceRepository defines the interface for notification preference data access
+type NotificationPreferenceRepository interface {
+	GetByUserID(ctx context.Context, userID uint) (*domain.NotificationPreferences, error)
+	Save(ctx context.Context, prefs *domain.NotificationPreferences) error
+	// ListDueSellerReports lists up to limit users receiving seller reports
+	// at the frequency whose last report was for a period ending before until
+	ListDueSellerReports(ctx context.Context, frequency string, until time.Time, limit int) ([]uint, error)
+	// MarkSellerReportQueued records that the user's report of the period
+	// ending at until is queued, reporting false when it already was
+	MarkSellerReportQueued(ctx context.Context, userID uint, until time.Time) (bool, error)
+}
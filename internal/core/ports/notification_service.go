@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// NotificationService defines the interface for notification preferences and
+// the onboarding emails
+type NotificationService interface {
+	GetPreferences(ctx context.Context, userID uint) (*domain.NotificationPreferences, error)
+	UpdatePreferences(ctx context.Context, userID uint, req *domain.UpdateNotificationPreferencesRequest) (*domain.NotificationPreferences, error)
+	// Unsubscribe stops the category of email an unsubscribe token was
+	// issued for, returning the category
+	Unsubscribe(ctx context.Context, token string) (string, error)
+
+	// StartOnboarding queues the onboarding sequence of a new user
+	StartOnboarding(ctx context.Context, user *domain.User) error
+}
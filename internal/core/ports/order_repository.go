@@ -0,0 +1,46 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// OrderRepository defines the interface for order data access.
+// Orders are loaded with their items.
+type OrderRepository interface {
+	// Create saves the order and holds a stock reservation for every item until
+	// order.ReservedUntil, in one transaction. Stock is decremented with guarded
+	// updates, so concurrent checkouts fail with ErrInsufficientStock instead of overselling.
+	Create(ctx context.Context, order *domain.Order) error
+	GetByID(ctx context.Context, id uint) (*domain.Order, error)
+	ListByUserIDPaginated(ctx context.Context, userID uint, pagination *domain.PaginationRequest) ([]*domain.Order, int64, error)
+	// ListBySellerPaginated retrieves orders containing at least one of the seller's mangas
+	ListBySellerPaginated(ctx context.Context, sellerID uint, pagination *domain.PaginationRequest) ([]*domain.Order, int64, error)
+	// ExportSellerSales streams the items the seller sold in orders placed
+	// from from until to to fn in chunks, with the number of items overall
+	ExportSellerSales(ctx context.Context, sellerID uint, from, to time.Time, batchSize int, fn func(rows []*domain.SalesReportRow, total int64) error) error
+	// SummarizeSellerSales sums up the seller's sales in orders placed from
+	// from until to
+	SummarizeSellerSales(ctx context.Context, sellerID uint, from, to time.Time) (*domain.SalesSummary, error)
+	// GetTopSellerMangas ranks the seller's mangas by copies sold in orders
+	// placed from from until to
+	GetTopSellerMangas(ctx context.Context, sellerID uint, from, to time.Time, limit int) ([]*domain.MangaSales, error)
+
+	// UpdateStatus moves an order to a new status only if its current status is one of from.
+	// Paying confirms the order's reservations, failing with ErrReservationExpired once they
+	// lapse; cancelling releases them and puts the items back in stock; fulfilling records the delivery time.
+	UpdateStatus(ctx context.Context, id uint, from []string, to string) error
+	// Ship stores tracking details on a paid or shipped order and marks it shipped
+	Ship(ctx context.Context, id uint, shipment *domain.Shipment) error
+	// ListExpiredReservations returns the IDs of pending orders whose reservation lapsed before now
+	ListExpiredReservations(ctx context.Context, now time.Time) ([]uint, error)
+
+	// Archive moves up to limit final orders created before the cutoff into the
+	// archive, dropping their items, tax lines and reservations from the live
+	// tables, and returns how many were moved
+	Archive(ctx context.Context, createdBefore time.Time, limit int) (int, error)
+	GetArchivedByID(ctx context.Context, id uint) (*domain.Order, error)
+	ListArchivedByUserIDPaginated(ctx context.Context, userID uint, pagination *domain.PaginationRequest) ([]*domain.Order, int64, error)
+}
@@ -0,0 +1,28 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// OrderService defines the interface for order business operations
+type OrderService interface {
+	// Checkout orders the user's cart and empties it
+	Checkout(ctx context.Context, req *domain.CheckoutRequest, userID uint) (*domain.Order, error)
+	GetOrder(ctx context.Context, id uint, userID uint, isAdmin bool) (*domain.Order, error)
+	// GetInvoice returns the invoice of a paid order: the whole order for its
+	// buyer and admins, their part of it for sellers
+	GetInvoice(ctx context.Context, id uint, userID uint, isAdmin bool) (*domain.Invoice, error)
+	// GetMyOrders lists the user's live orders, or archived ones with archived
+	GetMyOrders(ctx context.Context, userID uint, pagination *domain.PaginationRequest, archived bool) (*domain.PaginatedResult[*domain.Order], error)
+	GetSellerOrders(ctx context.Context, userID uint, pagination *domain.PaginationRequest) (*domain.PaginatedResult[*domain.Order], error)
+	// ExportSales streams the items the user sold in orders placed from from
+	// until to to fn in chunks, with the number of items overall
+	ExportSales(ctx context.Context, userID uint, from, to time.Time, fn func(rows []*domain.SalesReportRow, total int64) error) error
+	ShipOrder(ctx context.Context, id uint, req *domain.ShipOrderRequest, userID uint, isAdmin bool) (*domain.Order, error)
+	UpdateOrderStatus(ctx context.Context, id uint, req *domain.UpdateOrderStatusRequest, userID uint, isAdmin bool) (*domain.Order, error)
+	// StartReservationSweeper cancels pending orders with lapsed reservations in the background
+	StartReservationSweeper(interval time.Duration)
+}
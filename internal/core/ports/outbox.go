@@ -0,0 +1,34 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// OutboxRepository defines the interface for outbox event data access
+type OutboxRepository interface {
+	Create(ctx context.Context, event *domain.OutboxEvent) error
+	// PublishPending passes up to limit unpublished events to publish, oldest
+	// first, and marks them published; it returns how many there were
+	PublishPending(ctx context.Context, limit int, publish func(*domain.OutboxEvent)) (int, error)
+	DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// EventOutbox defines the interface for recording the events sent to webhooks
+// and event streams. Events are written with the caller's transaction and
+// published after it commits.
+type EventOutbox interface {
+	Record(ctx context.Context, userID uint, event string, data interface{}) error
+}
+
+// OutboxService defines the interface for recording and relaying outbox events
+type OutboxService interface {
+	EventOutbox
+
+	// Relay publishes one batch of pending events, returning how many it published
+	Relay(ctx context.Context) (int, error)
+	// StartRelay publishes pending events in the background at the given interval
+	StartRelay(interval time.Duration)
+}
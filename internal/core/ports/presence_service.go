@@ -0,0 +1,9 @@
+package ports
+
+import "context"
+
+// PresenceService defines the interface for tracking user activity
+type PresenceService interface {
+	Touch(ctx context.Context, userID uint) error
+	CountOnline(ctx context.Context) (int64, error)
+}
@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// PriceHistoryRepository defines the interface for manga price history data access
+type PriceHistoryRepository interface {
+	Create(ctx context.Context, entry *domain.MangaPriceHistory) error
+	ListByMangaID(ctx context.Context, mangaID uint) ([]*domain.MangaPriceHistory, error)
+}
@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// PurgeService defines the interface for permanently deleting soft-deleted records
+type PurgeService interface {
+	Run(ctx context.Context, dryRun bool) (*domain.PurgeReport, error)
+	Stats() domain.PurgeStats
+	// StartScheduler runs the purge in the background at the given interval
+	StartScheduler(interval time.Duration, dryRun bool)
+}
@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"time"
+)
+
+// QuotaRepository defines the interface for quota usage data access
+type QuotaRepository interface {
+	Increment(ctx context.Context, userID uint, period string, periodStart time.Time) (int64, error)
+	GetUsage(ctx context.Context, userID uint, period string, periodStart time.Time) (int64, error)
+	Reset(ctx context.Context, userID uint) error
+}
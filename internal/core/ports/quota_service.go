@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// QuotaService defines the interface for per-user request quotas
+type QuotaService interface {
+	Consume(ctx context.Context, userID uint) (*domain.QuotaStatus, error)
+	GetStatus(ctx context.Context, userID uint) (*domain.QuotaStatus, error)
+	Reset(ctx context.Context, userID uint) error
+}
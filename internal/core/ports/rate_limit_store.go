@@ -0,0 +1,19 @@
+package ports
+
+import "time"
+
+// RateLimitStore defines the interface for the fixed-window counters and
+// concurrency slots behind rate limiting. Shared stores let several instances
+// enforce one limit.
+type RateLimitStore interface {
+	// Increment counts a request against key in the current window, starting a
+	// window of the given length if none is open, and returns the count so far
+	// and when the window ends
+	Increment(key string, window time.Duration) (int64, time.Time, error)
+	// Acquire takes one of the limit slots of key for holder, reporting false
+	// when all are taken. Slots not released within ttl are freed, so those
+	// of a crashed instance don't stay taken.
+	Acquire(key, holder string, limit int64, ttl time.Duration) (bool, error)
+	// Release frees the holder's slot of key
+	Release(key, holder string) error
+}
@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// ReadingProgressRepository defines the interface for reading progress data access
+type ReadingProgressRepository interface {
+	Upsert(ctx context.Context, progress *domain.ReadingProgress) error
+	GetByUserAndManga(ctx context.Context, userID, mangaID uint) (*domain.ReadingProgress, error)
+	ListByUserIDPaginated(ctx context.Context, userID uint, status string, pagination *domain.PaginationRequest) ([]*domain.ReadingProgress, int64, error)
+}
@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// ReadingProgressService defines the interface for reading progress business operations
+type ReadingProgressService interface {
+	UpdateProgress(ctx context.Context, mangaID uint, req *domain.UpdateProgressRequest, userID uint) (*domain.ReadingProgress, error)
+	GetProgress(ctx context.Context, mangaID, userID uint) (*domain.ReadingProgress, error)
+	GetContinueReading(ctx context.Context, userID uint, pagination *domain.PaginationRequest) (*domain.PaginatedResult[*domain.ReadingProgress], error)
+}
@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// RentalRepository defines the interface for rental data access
+type RentalRepository interface {
+	Create(ctx context.Context, rental *domain.Rental) error
+	// GetActive retrieves the user's rental of the manga that is still running at now
+	GetActive(ctx context.Context, userID, mangaID uint, now time.Time) (*domain.Rental, error)
+	// ListActiveByUserIDPaginated retrieves the user's running rentals, soonest expiry first
+	ListActiveByUserIDPaginated(ctx context.Context, userID uint, now time.Time, pagination *domain.PaginationRequest) ([]*domain.Rental, int64, error)
+	// ExpireDue marks active rentals past their expiry as expired and returns how many were updated
+	ExpireDue(ctx context.Context, now time.Time) (int64, error)
+}
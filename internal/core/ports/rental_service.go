@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// RentalService defines the interface for rental business operations
+type RentalService interface {
+	RentManga(ctx context.Context, mangaID uint, req *domain.RentMangaRequest, userID uint) (*domain.Rental, error)
+	GetActiveRentals(ctx context.Context, userID uint, pagination *domain.PaginationRequest) (*domain.PaginatedResult[*domain.Rental], error)
+	// StartSweeper expires overdue rentals in the background at the given interval
+	StartSweeper(interval time.Duration)
+}
@@ -0,0 +1,25 @@
+package ports
+
+import "time"
+
+// CacheInvalidator defines the interface services use to drop cached data that
+// an update made stale
+type CacheInvalidator interface {
+	// Invalidate makes every entry tagged with any of the given tags stale
+	Invalidate(tags ...string) error
+}
+
+// ResponseCache defines the interface for the store behind HTTP response
+// caching. Entries are invalidated by tag: each tag has a generation that
+// Invalidate advances, and callers include the generations of an entry's tags
+// in its key so stale entries are never read again and simply expire.
+type ResponseCache interface {
+	CacheInvalidator
+
+	// Get returns the entry stored under key, if any
+	Get(key string) ([]byte, bool, error)
+	// Set stores an entry under key for ttl
+	Set(key string, value []byte, ttl time.Duration) error
+	// Generations returns the current generation of each tag, in order
+	Generations(tags ...string) ([]int64, error)
+}
@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// ReviewRepository defines the interface for review data access.
+// Writes also refresh the manga's denormalized rating summary.
+type ReviewRepository interface {
+	Create(ctx context.Context, review *domain.Review) error
+	GetByID(ctx context.Context, id uint) (*domain.Review, error)
+	GetByMangaAndUser(ctx context.Context, mangaID, userID uint) (*domain.Review, error)
+	ListByMangaIDPaginated(ctx context.Context, mangaID uint, pagination *domain.PaginationRequest) ([]*domain.Review, int64, error)
+	Update(ctx context.Context, review *domain.Review) error
+	Delete(ctx context.Context, review *domain.Review) error
+}
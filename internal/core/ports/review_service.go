@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// ReviewService defines the interface for review business operations
+type ReviewService interface {
+	CreateReview(ctx context.Context, mangaID uint, req *domain.ReviewRequest, userID uint) (*domain.Review, error)
+	GetReviewsPaginated(ctx context.Context, mangaID uint, pagination *domain.PaginationRequest) (*domain.PaginatedResult[*domain.Review], error)
+	UpdateReview(ctx context.Context, mangaID, reviewID uint, req *domain.ReviewRequest, userID uint) (*domain.Review, error)
+	DeleteReview(ctx context.Context, mangaID, reviewID uint, userID uint) error
+}
@@ -0,0 +1,28 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// SearchService defines the interface for manga search backends. Backends
+// keeping their own index are fed through Index and Remove; the others ignore them.
+type SearchService interface {
+	// Search retrieves a page of visible mangas matching the filter, best
+	// matches first, with facet counts over every match
+	Search(ctx context.Context, filter *domain.MangaFilter, pagination *domain.PaginationRequest) (*domain.MangaSearchResult, error)
+	// Index adds the mangas, loaded with their genres, or replaces them
+	Index(ctx context.Context, mangas ...*domain.Manga) error
+	Remove(ctx context.Context, ids ...uint) error
+}
+
+// MangaSearchService defines the interface for manga search operations. As a
+// dispatcher it keeps the search index up to date from relayed manga events.
+type MangaSearchService interface {
+	WebhookDispatcher
+
+	SearchMangas(ctx context.Context, filter *domain.MangaFilter, pagination *domain.PaginationRequest) (*domain.MangaSearchResult, error)
+	// Reindex indexes every manga again, returning how many were indexed
+	Reindex(ctx context.Context) (int, error)
+}
@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+// SellerReportService defines the interface for the sales reports emailed to sellers
+type SellerReportService interface {
+	// QueueDueReports queues the report of every seller whose report of the
+	// last period is due, returning how many were queued
+	QueueDueReports(ctx context.Context) (int, error)
+	// StartScheduler queues due reports in the background at the given interval
+	StartScheduler(interval time.Duration)
+}
@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// SeriesRepository defines the interface for series data access
+type SeriesRepository interface {
+	Create(ctx context.Context, series *domain.Series) error
+	GetByID(ctx context.Context, id uint) (*domain.Series, error)
+	Update(ctx context.Context, series *domain.Series) error
+	Delete(ctx context.Context, id uint) error
+
+	// Volume membership; GetVolumes orders by volume number
+	GetVolumes(ctx context.Context, seriesID uint, publishedOnly bool) ([]*domain.Manga, error)
+	NextVolumeNumber(ctx context.Context, seriesID uint) (int, error)
+	AddVolume(ctx context.Context, seriesID, mangaID uint, volumeNumber int) error
+	RemoveVolume(ctx context.Context, seriesID, mangaID uint) error
+	ReorderVolumes(ctx context.Context, seriesID uint, mangaIDs []uint) error
+}
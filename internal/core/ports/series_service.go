@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// SeriesService defines the interface for series business operations
+type SeriesService interface {
+	CreateSeries(ctx context.Context, req *domain.SeriesRequest, userID uint) (*domain.Series, error)
+	GetSeries(ctx context.Context, id uint) (*domain.SeriesDetail, error)
+	UpdateSeries(ctx context.Context, id uint, req *domain.SeriesRequest, userID uint) (*domain.Series, error)
+	DeleteSeries(ctx context.Context, id uint, userID uint) error
+
+	AddVolume(ctx context.Context, id uint, req *domain.AddSeriesVolumeRequest, userID uint) (*domain.SeriesDetail, error)
+	RemoveVolume(ctx context.Context, id, mangaID uint, userID uint) error
+	ReorderVolumes(ctx context.Context, id uint, req *domain.ReorderSeriesVolumesRequest, userID uint) (*domain.SeriesDetail, error)
+}
@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// SMSSender defines the interface for sending text messages, such as through
+// Twilio or Vonage
+type SMSSender interface {
+	Send(ctx context.Context, msg *domain.SMSMessage) error
+}
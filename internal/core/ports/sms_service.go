@@ -0,0 +1,23 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// SMSService defines the interface for users' phone numbers and the texts
+// sent to them
+type SMSService interface {
+	// The sms channel: order updates, texted to verified numbers whose
+	// users opted in
+	NotificationChannel
+
+	GetPhone(ctx context.Context, userID uint) (*domain.UserPhone, error)
+	// SetPhone stores a new, unverified number for the user and texts it a
+	// verification code; setting the same number again sends a new code
+	SetPhone(ctx context.Context, userID uint, req *domain.SetPhoneRequest) (*domain.UserPhone, error)
+	VerifyPhone(ctx context.Context, userID uint, req *domain.VerifyPhoneRequest) (*domain.UserPhone, error)
+	UpdateConsent(ctx context.Context, userID uint, req *domain.UpdateSMSConsentRequest) (*domain.UserPhone, error)
+	RemovePhone(ctx context.Context, userID uint) error
+}
@@ -0,0 +1,21 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// TaxRateRepository defines the interface for tax rate data access
+type TaxRateRepository interface {
+	Create(ctx context.Context, rate *domain.TaxRate) error
+	GetByID(ctx context.Context, id uint) (*domain.TaxRate, error)
+	// GetByLocation retrieves the rate defined for exactly this country and region
+	GetByLocation(ctx context.Context, country, region string) (*domain.TaxRate, error)
+	// FindForLocation retrieves the rate that applies to an address: the region's if
+	// one is defined, otherwise the country's
+	FindForLocation(ctx context.Context, country, region string) (*domain.TaxRate, error)
+	List(ctx context.Context) ([]*domain.TaxRate, error)
+	Update(ctx context.Context, rate *domain.TaxRate) error
+	Delete(ctx context.Context, id uint) error
+}
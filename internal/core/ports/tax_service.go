@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// TaxRateService defines the interface for tax rate business operations
+type TaxRateService interface {
+	CreateTaxRate(ctx context.Context, req *domain.TaxRateRequest) (*domain.TaxRate, error)
+	GetTaxRates(ctx context.Context) ([]*domain.TaxRate, error)
+	UpdateTaxRate(ctx context.Context, id uint, req *domain.TaxRateRequest) (*domain.TaxRate, error)
+	DeleteTaxRate(ctx context.Context, id uint) error
+}
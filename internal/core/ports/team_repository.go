@@ -0,0 +1,25 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// TeamRepository defines the interface for team data access
+type TeamRepository interface {
+	// Team CRUD operations
+	Create(ctx context.Context, team *domain.Team) error
+	GetByID(ctx context.Context, id uint) (*domain.Team, error)
+	ListByUserID(ctx context.Context, userID uint) ([]*domain.Team, error)
+	Delete(ctx context.Context, id uint) error
+
+	// Membership
+	GetMember(ctx context.Context, teamID, userID uint) (*domain.TeamMember, error)
+	RemoveMember(ctx context.Context, teamID, userID uint) error
+
+	// Invitations
+	CreateInvitation(ctx context.Context, invitation *domain.TeamInvitation) error
+	GetInvitationByToken(ctx context.Context, token string) (*domain.TeamInvitation, error)
+	AcceptInvitation(ctx context.Context, invitation *domain.TeamInvitation, member *domain.TeamMember) error
+}
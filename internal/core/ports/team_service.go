@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// TeamService defines the interface for team business operations
+type TeamService interface {
+	CreateTeam(ctx context.Context, req *domain.CreateTeamRequest, userID uint) (*domain.Team, error)
+	GetTeam(ctx context.Context, id uint, userID uint) (*domain.Team, error)
+	GetTeamsByUser(ctx context.Context, userID uint) ([]*domain.Team, error)
+	DeleteTeam(ctx context.Context, id uint, userID uint) error
+
+	// Membership operations
+	InviteMember(ctx context.Context, teamID uint, req *domain.InviteTeamMemberRequest, userID uint) (*domain.TeamInvitation, error)
+	AcceptInvitation(ctx context.Context, token string, userID uint) (*domain.Team, error)
+	RemoveMember(ctx context.Context, teamID, memberID, userID uint) error
+}
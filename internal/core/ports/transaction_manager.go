@@ -0,0 +1,16 @@
+package ports
+
+import "context"
+
+// TransactionManager defines the interface for running operations that span
+// several repositories atomically
+type TransactionManager interface {
+	// WithinTransaction runs fn in a transaction that repository calls made with
+	// the context passed to fn take part in. The transaction commits when fn
+	// returns nil and rolls back when it returns an error, which is passed on.
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+	// AfterCommit runs fn once the transaction carried by ctx has committed,
+	// with the context the transaction was opened with, or right away outside
+	// a transaction. fn is dropped when the transaction rolls back.
+	AfterCommit(ctx context.Context, fn func(ctx context.Context))
+}
@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// UploadRepository defines the interface for presigned upload data access
+type UploadRepository interface {
+	Create(ctx context.Context, upload *domain.Upload) error
+	GetByID(ctx context.Context, id uint) (*domain.Upload, error)
+	ListByMangaID(ctx context.Context, mangaID uint) ([]*domain.Upload, error)
+	// Confirm marks a pending upload confirmed as the gallery image it became;
+	// it fails when the upload is no longer pending
+	Confirm(ctx context.Context, id uint, imageID uint) error
+	// Block marks a pending upload blocked for the malware found in its file
+	Block(ctx context.Context, id uint, signature string) error
+	Delete(ctx context.Context, id uint) error
+}
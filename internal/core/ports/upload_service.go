@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// UploadService defines the interface for uploads that clients send straight to storage
+type UploadService interface {
+	// Presign records an upload and returns the URL the client uploads the file to
+	Presign(ctx context.Context, req *domain.PresignUploadRequest, userID uint) (*domain.PresignedUpload, error)
+	// Confirm validates an uploaded file and adds it where it was uploaded for
+	Confirm(ctx context.Context, id uint, req *domain.ConfirmUploadRequest, userID uint) (*domain.MangaImage, error)
+}
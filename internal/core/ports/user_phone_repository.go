@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// UserPhoneRepository defines the interface for user phone number data access
+type UserPhoneRepository interface {
+	GetByUserID(ctx context.Context, userID uint) (*domain.UserPhone, error)
+	Save(ctx context.Context, phone *domain.UserPhone) error
+	Delete(ctx context.Context, userID uint) error
+}
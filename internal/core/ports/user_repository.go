@@ -1,17 +1,47 @@
 package ports
 
-import "github.com/thitiphongD/my-backend/internal/core/domain"
+import (
+	"context"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
 
 // UserRepository defines the interface for user data access
 type UserRepository interface {
 	// User CRUD operations
-	Create(user *domain.User) error
-	GetByID(id uint) (*domain.User, error)
-	GetByEmail(email string) (*domain.User, error)
-	Update(user *domain.User) error
-	Delete(id uint) error
-	List() ([]*domain.User, error)
+	Create(ctx context.Context, user *domain.User) error
+	CreateInBatches(ctx context.Context, users []*domain.User, batchSize int) []domain.BatchCreateFailure
+	GetByID(ctx context.Context, id uint) (*domain.User, error)
+	GetByEmail(ctx context.Context, email string) (*domain.User, error)
+	Update(ctx context.Context, user *domain.User) error
+	// UpdateIfUnchanged is Update, failing with domain.ErrPreconditionFailed
+	// when the user was saved since it was read at the version (UpdatedAt)
+	UpdateIfUnchanged(ctx context.Context, user *domain.User, version time.Time) error
+	Delete(ctx context.Context, id uint) error
+	List(ctx context.Context) ([]*domain.User, error)
+	// ListAdmins retrieves the admins and super admins
+	ListAdmins(ctx context.Context) ([]*domain.User, error)
+	SearchByNamePrefix(ctx context.Context, prefix string, limit int) ([]*domain.User, error)
 
 	// Authentication related
-	FindByEmailAndPassword(email, password string) (*domain.User, error)
+	FindByEmailAndPassword(ctx context.Context, email, password string) (*domain.User, error)
+
+	// Account merging
+	CountOwnedResources(ctx context.Context, userID uint) (map[string]int64, error)
+	MergeInto(ctx context.Context, sourceID, targetID uint) (map[string]int64, error)
+
+	// Activity tracking
+	UpdateLastLogin(ctx context.Context, id uint, at time.Time) error
+	UpdateLastSeen(ctx context.Context, id uint, at time.Time) error
+	CountSeenSince(ctx context.Context, since time.Time) (int64, error)
+
+	// ExportPersonalData passes fn the user's rows of every table holding
+	// their data, table by table, as a slice of models or of column maps;
+	// tables is how many there are
+	ExportPersonalData(ctx context.Context, userID uint, fn func(table string, rows interface{}, tables int) error) error
+
+	// Purging soft-deleted users
+	GetPurgeableIDs(ctx context.Context, deletedBefore time.Time, limit int) ([]uint, error)
+	Purge(ctx context.Context, id uint) error
 }
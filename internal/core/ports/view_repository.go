@@ -0,0 +1,21 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// ViewRepository defines the interface for manga view count data access
+type ViewRepository interface {
+	// IncrementViews adds the buffered view counts for a day to the daily and total counters
+	IncrementViews(ctx context.Context, counts map[uint]int64, day time.Time) error
+	GetTrending(ctx context.Context, since time.Time, limit int) ([]*domain.Manga, error)
+	// CountSellerViews sums the daily views of the seller's mangas from the
+	// day from until the day to
+	CountSellerViews(ctx context.Context, sellerID uint, from, to time.Time) (int64, error)
+	// Archive moves up to limit daily view counts from before the cutoff into
+	// the archive and returns how many were moved
+	Archive(ctx context.Context, before time.Time, limit int) (int, error)
+}
@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// ViewService defines the interface for counting manga views and ranking trending mangas
+type ViewService interface {
+	RecordView(mangaID uint)
+	Flush() error
+	StartFlusher(interval time.Duration)
+	GetTrending(ctx context.Context, window time.Duration, limit int) ([]*domain.Manga, error)
+}
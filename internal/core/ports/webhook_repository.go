@@ -0,0 +1,26 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// WebhookRepository defines the interface for webhook data access
+type WebhookRepository interface {
+	// Webhook CRUD operations
+	Create(ctx context.Context, webhook *domain.Webhook) error
+	GetByID(ctx context.Context, id uint) (*domain.Webhook, error)
+	ListByUserID(ctx context.Context, userID uint) ([]*domain.Webhook, error)
+	Delete(ctx context.Context, id uint) error
+
+	// Delivery log
+	CreateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error
+	UpdateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error
+	ListDeliveriesPaginated(ctx context.Context, webhookID uint, pagination *domain.PaginationRequest) ([]*domain.WebhookDelivery, int64, error)
+	// GetDeliveryByID retrieves a delivery with its attempt log
+	GetDeliveryByID(ctx context.Context, id uint) (*domain.WebhookDelivery, error)
+	// ListDeliveriesByStatusPaginated retrieves deliveries of every webhook, newest first; an empty status matches all
+	ListDeliveriesByStatusPaginated(ctx context.Context, status string, pagination *domain.PaginationRequest) ([]*domain.WebhookDelivery, int64, error)
+	CreateDeliveryAttempt(ctx context.Context, attempt *domain.WebhookDeliveryAttempt) error
+}
@@ -0,0 +1,34 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// WebhookDispatcher defines the interface for emitting events to user webhooks
+type WebhookDispatcher interface {
+	Dispatch(userID uint, event string, data interface{})
+}
+
+// WebhookService defines the interface for webhook business operations
+type WebhookService interface {
+	WebhookDispatcher
+
+	CreateWebhook(ctx context.Context, req *domain.CreateWebhookRequest, userID uint) (*domain.Webhook, error)
+	GetWebhooks(ctx context.Context, userID uint) ([]*domain.Webhook, error)
+	DeleteWebhook(ctx context.Context, id uint, userID uint) error
+	GetDeliveriesPaginated(ctx context.Context, webhookID uint, userID uint, pagination *domain.PaginationRequest) (*domain.PaginatedResult[*domain.WebhookDelivery], error)
+	GetDelivery(ctx context.Context, webhookID, deliveryID uint, userID uint) (*domain.WebhookDelivery, error)
+	RedeliverDelivery(ctx context.Context, webhookID, deliveryID uint, userID uint) (*domain.WebhookDelivery, error)
+
+	// Admin operations across every webhook
+	GetAllDeliveries(ctx context.Context, status string, pagination *domain.PaginationRequest) (*domain.PaginatedResult[*domain.WebhookDelivery], error)
+	GetAnyDelivery(ctx context.Context, id uint) (*domain.WebhookDelivery, error)
+	RedeliverAnyDelivery(ctx context.Context, id uint) (*domain.WebhookDelivery, error)
+}
+
+// WebhookSender defines the interface for delivering signed payloads over the network
+type WebhookSender interface {
+	Send(url, secret string, headers map[string]string, payload []byte) (int, error)
+}
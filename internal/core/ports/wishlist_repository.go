@@ -0,0 +1,27 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// WishlistRepository defines the interface for wishlist data access.
+// Wishlists are loaded with their items and mangas, ordered by position.
+type WishlistRepository interface {
+	Create(ctx context.Context, wishlist *domain.Wishlist) error
+	GetByID(ctx context.Context, id uint) (*domain.Wishlist, error)
+	GetByShareToken(ctx context.Context, token string) (*domain.Wishlist, error)
+	ListByUserID(ctx context.Context, userID uint, publicOnly bool) ([]*domain.Wishlist, error)
+	CountByUserID(ctx context.Context, userID uint) (int64, error)
+	Update(ctx context.Context, wishlist *domain.Wishlist) error
+	Delete(ctx context.Context, id uint) error
+
+	// Item operations
+	AddItem(ctx context.Context, item *domain.WishlistItem) error
+	RemoveItem(ctx context.Context, wishlistID, mangaID uint) error
+	ReorderItems(ctx context.Context, wishlistID uint, mangaIDs []uint) error
+
+	// ListUserIDsByManga returns the users with the manga on any of their wishlists
+	ListUserIDsByManga(ctx context.Context, mangaID uint) ([]uint, error)
+}
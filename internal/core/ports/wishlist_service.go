@@ -0,0 +1,23 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+)
+
+// WishlistService defines the interface for wishlist business operations
+type WishlistService interface {
+	CreateWishlist(ctx context.Context, req *domain.WishlistRequest, userID uint) (*domain.Wishlist, error)
+	GetMyWishlists(ctx context.Context, userID uint) ([]*domain.Wishlist, error)
+	GetPublicWishlists(ctx context.Context, userID uint) ([]*domain.Wishlist, error)
+	GetWishlist(ctx context.Context, id uint, viewerID uint) (*domain.Wishlist, error)
+	GetSharedWishlist(ctx context.Context, token string) (*domain.Wishlist, error)
+	UpdateWishlist(ctx context.Context, id uint, req *domain.WishlistRequest, userID uint) (*domain.Wishlist, error)
+	RotateShareToken(ctx context.Context, id uint, userID uint) (*domain.Wishlist, error)
+	DeleteWishlist(ctx context.Context, id uint, userID uint) error
+
+	AddItem(ctx context.Context, id uint, req *domain.AddWishlistItemRequest, userID uint) (*domain.Wishlist, error)
+	RemoveItem(ctx context.Context, id, mangaID uint, userID uint) (*domain.Wishlist, error)
+	ReorderItems(ctx context.Context, id uint, req *domain.ReorderWishlistRequest, userID uint) (*domain.Wishlist, error)
+}
@@ -0,0 +1,206 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// alertQueueSize is how many alerts may wait to be sent; more are dropped
+const alertQueueSize = 64
+
+// alertSendTimeout bounds the sending of one alert to all channels
+const alertSendTimeout = 30 * time.Second
+
+// AlertLimits keeps alerts from flooding the chat channels
+type AlertLimits struct {
+	// DedupWindow is how long after an alert others with the same key and
+	// severity are suppressed
+	DedupWindow time.Duration
+	// MaxPerMinute caps the alerts sent each minute across all keys
+	MaxPerMinute int
+	// ServerErrorThreshold 5xx responses within ServerErrorWindow raise an
+	// alert; zero turns the alert off
+	ServerErrorThreshold int
+	ServerErrorWindow    time.Duration
+}
+
+// alertService implements the Alerter interface. It keeps its state in
+// memory and never touches the database, so alerts still go out when the
+// database is what failed.
+type alertService struct {
+	senders []ports.AlertSender
+	limits  AlertLimits
+	queue   chan *domain.Alert
+
+	mu          sync.Mutex
+	lastSent    map[string]time.Time
+	suppressed  map[string]int
+	minuteStart time.Time
+	minuteSent  int
+
+	serverErrors      int
+	serverErrorsSince time.Time
+}
+
+// NewAlertService creates a new alert service instance posting to every sender
+// on a background goroutine. Alerts are logged as well, so with no senders
+// they are only logged.
+func NewAlertService(senders []ports.AlertSender, limits AlertLimits) ports.Alerter {
+	s := &alertService{
+		senders:    senders,
+		limits:     limits,
+		queue:      make(chan *domain.Alert, alertQueueSize),
+		lastSent:   make(map[string]time.Time),
+		suppressed: make(map[string]int),
+	}
+	go s.send()
+	return s
+}
+
+// Alert queues the alert unless an alert with the same key and severity was
+// sent within the dedup window, or the cap of alerts per minute is reached.
+// Dropped alerts are counted, and the count is shown with the next one sent.
+func (s *alertService) Alert(alert *domain.Alert) {
+	if alert.Time.IsZero() {
+		alert.Time = time.Now()
+	}
+	dedupKey := alert.Key + "/" + alert.Severity
+
+	s.mu.Lock()
+	if last, ok := s.lastSent[dedupKey]; ok && alert.Time.Sub(last) < s.limits.DedupWindow {
+		s.suppressed[dedupKey]++
+		s.mu.Unlock()
+		return
+	}
+	if alert.Time.Sub(s.minuteStart) >= time.Minute {
+		s.minuteStart, s.minuteSent = alert.Time, 0
+	}
+	if s.limits.MaxPerMinute > 0 && s.minuteSent >= s.limits.MaxPerMinute {
+		s.suppressed[dedupKey]++
+		s.mu.Unlock()
+		log.Printf("alert throttled: %s", alert.Title)
+		return
+	}
+	s.minuteSent++
+	s.lastSent[dedupKey] = alert.Time
+	alert.Suppressed = s.suppressed[dedupKey]
+	delete(s.suppressed, dedupKey)
+	s.mu.Unlock()
+
+	log.Printf("ALERT [%s] %s: %s", alert.Severity, alert.Title, alert.Message)
+	select {
+	case s.queue <- alert:
+	default:
+		log.Printf("alert queue full, dropping alert: %s", alert.Title)
+	}
+}
+
+// send posts queued alerts to every sender, one at a time
+func (s *alertService) send() {
+	for alert := range s.queue {
+		ctx, cancel := context.WithTimeout(context.Background(), alertSendTimeout)
+		for _, sender := range s.senders {
+			if err := sender.SendAlert(ctx, alert); err != nil {
+				log.Printf("failed to send alert %q: %v", alert.Key, err)
+			}
+		}
+		cancel()
+	}
+}
+
+// RecordServerError counts 5xx responses in fixed windows, alerting once a
+// window reaches the threshold
+func (s *alertService) RecordServerError(method, path string, status int) {
+	if s.limits.ServerErrorThreshold <= 0 {
+		return
+	}
+
+	now := time.Now()
+	s.mu.Lock()
+	if now.Sub(s.serverErrorsSince) >= s.limits.ServerErrorWindow {
+		s.serverErrors, s.serverErrorsSince = 0, now
+	}
+	s.serverErrors++
+	count := s.serverErrors
+	s.mu.Unlock()
+
+	if count != s.limits.ServerErrorThreshold {
+		return
+	}
+	s.Alert(&domain.Alert{
+		Key:      "http_5xx",
+		Severity: domain.AlertCritical,
+		Title:    "Burst of server errors",
+		Message:  fmt.Sprintf("%d requests failed with a 5xx status within %s.", count, s.limits.ServerErrorWindow),
+		Fields: []domain.AlertField{
+			{Name: "Latest", Value: method + " " + path + " → " + strconv.Itoa(status)},
+		},
+		Time: now,
+	})
+}
+
+// StartDatabaseMonitor checks the database health in the background, alerting
+// when the primary goes down or a replica fails, and when it is reconnected
+func (s *alertService) StartDatabaseMonitor(stats ports.DatabaseStats, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		status := domain.DatabaseStatusOK
+		var since time.Time
+		for range ticker.C {
+			health := stats.Health(context.Background())
+			if health.Status == status {
+				continue
+			}
+
+			now := time.Now()
+			switch health.Status {
+			case domain.DatabaseStatusDown:
+				s.Alert(&domain.Alert{
+					Key:      "database",
+					Severity: domain.AlertCritical,
+					Title:    "Database unreachable",
+					Message:  health.Error,
+					Time:     now,
+				})
+			case domain.DatabaseStatusDegraded:
+				alert := &domain.Alert{
+					Key:      "database",
+					Severity: domain.AlertWarning,
+					Title:    "Database replica failing",
+					Message:  "The primary answers but a read replica does not.",
+					Time:     now,
+				}
+				for _, replica := range health.Replicas {
+					if replica.Error != "" {
+						alert.Fields = append(alert.Fields, domain.AlertField{Name: replica.Name, Value: replica.Error})
+					}
+				}
+				s.Alert(alert)
+			default:
+				s.Alert(&domain.Alert{
+					Key:      "database",
+					Severity: domain.AlertResolved,
+					Title:    "Database reconnected",
+					Message:  "The database answers again.",
+					Fields: []domain.AlertField{
+						{Name: "Outage", Value: now.Sub(since).Round(time.Second).String()},
+					},
+					Time: now,
+				})
+			}
+			if status == domain.DatabaseStatusOK {
+				since = now
+			}
+			status = health.Status
+		}
+	}()
+}
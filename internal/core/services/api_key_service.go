@@ -0,0 +1,278 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/internal/utils"
+)
+
+// apiKeyService implements the APIKeyService interface
+type apiKeyService struct {
+	apiKeyRepo   ports.APIKeyRepository
+	userRepo     ports.UserRepository
+	audit        ports.AuditService
+	maxRateLimit int64
+}
+
+// NewAPIKeyService creates a new API key service instance. Keys may not be
+// given a rate limit above maxRateLimit, requests a minute (0 = unlimited).
+func NewAPIKeyService(apiKeyRepo ports.APIKeyRepository, userRepo ports.UserRepository, audit ports.AuditService, maxRateLimit int64) ports.APIKeyService {
+	return &apiKeyService{
+		apiKeyRepo:   apiKeyRepo,
+		userRepo:     userRepo,
+		audit:        audit,
+		maxRateLimit: maxRateLimit,
+	}
+}
+
+// CreateKey creates a key for the user; the key is only returned here
+func (s *apiKeyService) CreateKey(ctx context.Context, userID uint, req *domain.CreateAPIKeyRequest) (*domain.APIKey, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkScopes(user, req.Scopes); err != nil {
+		return nil, err
+	}
+	if err := s.checkRateLimit(req.RateLimit); err != nil {
+		return nil, err
+	}
+
+	count, err := s.apiKeyRepo.CountActive(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= domain.MaxAPIKeysPerUser {
+		return nil, fmt.Errorf("at most %d API keys may be active, revoke one first", domain.MaxAPIKeysPerUser)
+	}
+
+	key := &domain.APIKey{
+		UserID:    userID,
+		Name:      req.Name,
+		Scopes:    uniqueScopes(req.Scopes),
+		RateLimit: req.RateLimit,
+	}
+	if err := setAPIKeySecret(key); err != nil {
+		return nil, err
+	}
+	if err := s.apiKeyRepo.Create(ctx, key); err != nil {
+		return nil, err
+	}
+
+	recordAudit(ctx, s.audit, "api_key.create", key.ID, nil, withoutSecret(key))
+	return key, nil
+}
+
+// GetKeys lists the user's keys, revoked ones included
+func (s *apiKeyService) GetKeys(ctx context.Context, userID uint) ([]*domain.APIKey, error) {
+	return s.apiKeyRepo.ListByUser(ctx, userID)
+}
+
+// UpdateKey renames one of the user's keys, or changes its scopes or rate limit
+func (s *apiKeyService) UpdateKey(ctx context.Context, id uint, userID uint, req *domain.UpdateAPIKeyRequest) (*domain.APIKey, error) {
+	key, err := s.getOwnedKey(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if key.IsRevoked() {
+		return nil, errors.New("API key is revoked")
+	}
+	before := *key
+
+	if req.Name != nil {
+		key.Name = *req.Name
+	}
+	if req.Scopes != nil {
+		user, err := s.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.checkScopes(user, req.Scopes); err != nil {
+			return nil, err
+		}
+		key.Scopes = uniqueScopes(req.Scopes)
+	}
+	if req.RateLimit != nil {
+		if err := s.checkRateLimit(*req.RateLimit); err != nil {
+			return nil, err
+		}
+		key.RateLimit = *req.RateLimit
+	}
+
+	if err := s.apiKeyRepo.Update(ctx, key); err != nil {
+		return nil, err
+	}
+	recordAudit(ctx, s.audit, "api_key.update", key.ID, &before, key)
+	return key, nil
+}
+
+// RotateKey replaces the secret of one of the user's keys, keeping its name,
+// scopes and rate limit. The old secret stops working at once.
+func (s *apiKeyService) RotateKey(ctx context.Context, id uint, userID uint) (*domain.APIKey, error) {
+	key, err := s.getOwnedKey(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if key.IsRevoked() {
+		return nil, errors.New("API key is revoked")
+	}
+	before := *key
+
+	if err := setAPIKeySecret(key); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	key.RotatedAt = &now
+
+	if err := s.apiKeyRepo.Update(ctx, key); err != nil {
+		return nil, err
+	}
+	recordAudit(ctx, s.audit, "api_key.rotate", key.ID, &before, withoutSecret(key))
+	return key, nil
+}
+
+// RevokeKey stops one of the user's keys for good. The key is kept, so
+// security reviews can still see it.
+func (s *apiKeyService) RevokeKey(ctx context.Context, id uint, userID uint) (*domain.APIKey, error) {
+	key, err := s.getOwnedKey(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if key.IsRevoked() {
+		return key, nil
+	}
+	before := *key
+
+	now := time.Now()
+	key.RevokedAt = &now
+	if err := s.apiKeyRepo.Update(ctx, key); err != nil {
+		return nil, err
+	}
+	recordAudit(ctx, s.audit, "api_key.revoke", key.ID, &before, key)
+	return key, nil
+}
+
+// ListKeys lists the keys of every user, or of one when userID isn't 0, for
+// security reviews
+func (s *apiKeyService) ListKeys(ctx context.Context, userID uint, status string, pagination *domain.PaginationRequest) (*domain.PaginatedResult[*domain.APIKey], error) {
+	if status != "" && status != domain.APIKeyStatusActive && status != domain.APIKeyStatusRevoked {
+		return nil, errors.New("status must be active or revoked")
+	}
+
+	keys, total, err := s.apiKeyRepo.ListPaginated(ctx, userID, status, pagination)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.PaginatedResult[*domain.APIKey]{
+		Data:       keys,
+		Pagination: domain.NewPaginationResponse(pagination.Page, pagination.PageSize, total),
+	}, nil
+}
+
+// Authenticate returns the key and the user it belongs to. The key's last
+// used time is recorded, at most once every APIKeyLastUsedPrecision.
+func (s *apiKeyService) Authenticate(ctx context.Context, secret string) (*domain.APIKey, *domain.User, error) {
+	key, err := s.apiKeyRepo.GetByHash(ctx, hashAPIKey(secret))
+	if err != nil {
+		return nil, nil, errors.New("invalid API key")
+	}
+	if key.IsRevoked() {
+		return nil, nil, errors.New("invalid API key")
+	}
+
+	user, err := s.userRepo.GetByID(ctx, key.UserID)
+	if err != nil {
+		return nil, nil, errors.New("invalid API key")
+	}
+	now := time.Now()
+	if user.IsSuspended(now) {
+		return nil, nil, domain.ErrAccountSuspended
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= domain.APIKeyLastUsedPrecision {
+		// Not recording the use must not fail the request
+		if err := s.apiKeyRepo.TouchLastUsed(ctx, key.ID, now); err != nil {
+			utils.Logf(ctx, "Failed to record use of API key %d: %v", key.ID, err)
+		} else {
+			key.LastUsedAt = &now
+		}
+	}
+
+	return key, user.Sanitize(), nil
+}
+
+// checkScopes rejects the admin scope for keys of users who aren't admins
+func (s *apiKeyService) checkScopes(user *domain.User, scopes []string) error {
+	if slices.Contains(scopes, domain.APIKeyScopeAdmin) && !user.IsAdmin() {
+		return errors.New("only admins may create API keys with the admin scope")
+	}
+	return nil
+}
+
+// checkRateLimit rejects rate limits above the configured maximum
+func (s *apiKeyService) checkRateLimit(rateLimit int64) error {
+	if s.maxRateLimit > 0 && rateLimit > s.maxRateLimit {
+		return fmt.Errorf("rate_limit may be at most %d requests a minute", s.maxRateLimit)
+	}
+	return nil
+}
+
+// getOwnedKey retrieves a key, reporting other users' keys as not found
+func (s *apiKeyService) getOwnedKey(ctx context.Context, id uint, userID uint) (*domain.APIKey, error) {
+	key, err := s.apiKeyRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if key.UserID != userID {
+		return nil, errors.New("API key not found")
+	}
+
+	return key, nil
+}
+
+// setAPIKeySecret gives key a new secret, keeping only its hash and prefix;
+// the secret itself is left in key.Key to be shown once
+func setAPIKeySecret(key *domain.APIKey) error {
+	token, err := utils.GenerateRandomToken(24)
+	if err != nil {
+		return errors.New("failed to generate API key")
+	}
+	key.Key = domain.APIKeyPrefix + token
+	key.Prefix = key.Key[:len(domain.APIKeyPrefix)+8]
+	key.Hash = hashAPIKey(key.Key)
+	return nil
+}
+
+// hashAPIKey returns the hash API keys are stored and looked up by. Keys are
+// random, so a plain SHA-256 is enough.
+func hashAPIKey(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// withoutSecret returns a copy of key without the secret, for the audit log
+func withoutSecret(key *domain.APIKey) *domain.APIKey {
+	copied := *key
+	copied.Key = ""
+	return &copied
+}
+
+// uniqueScopes returns scopes without repeats, in the order of APIKeyScopes
+func uniqueScopes(scopes []string) []string {
+	unique := []string{}
+	for _, scope := range domain.APIKeyScopes {
+		if slices.Contains(scopes, scope) {
+			unique = append(unique, scope)
+		}
+	}
+	return unique
+}
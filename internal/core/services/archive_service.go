@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// archiveService implements the ArchiveService interface. Final orders and
+// daily view counts older than the retention period are moved to archive
+// tables, at most batchSize of each per run so a backlog is worked off over
+// several runs without long transactions.
+type archiveService struct {
+	orderRepo ports.OrderRepository
+	viewRepo  ports.ViewRepository
+	months    int
+	batchSize int
+	audit     ports.AuditService
+
+	// runMu keeps scheduled and on-demand runs from overlapping
+	runMu sync.Mutex
+	mu    sync.Mutex
+	stats domain.ArchiveStats
+}
+
+// NewArchiveService creates a new archive service instance archiving records
+// older than the given number of months. Runs that moved anything are
+// recorded in the audit log.
+func NewArchiveService(orderRepo ports.OrderRepository, viewRepo ports.ViewRepository, months int, batchSize int, audit ports.AuditService) ports.ArchiveService {
+	return &archiveService{
+		orderRepo: orderRepo,
+		viewRepo:  viewRepo,
+		months:    months,
+		batchSize: batchSize,
+		audit:     audit,
+	}
+}
+
+// Run archives the records due, stopping at the first failure
+func (s *archiveService) Run(ctx context.Context) (*domain.ArchiveReport, error) {
+	s.runMu.Lock()
+	defer s.runMu.Unlock()
+
+	now := time.Now()
+	report := &domain.ArchiveReport{Cutoff: now.AddDate(0, -s.months, 0)}
+	err := s.archive(ctx, report)
+
+	s.mu.Lock()
+	s.stats.Runs++
+	if err != nil {
+		s.stats.FailedRuns++
+	}
+	s.stats.OrdersArchived += int64(report.Orders)
+	s.stats.MangaViewsArchived += int64(report.MangaViews)
+	s.stats.LastRunAt = &now
+	s.stats.LastReport = report
+	s.mu.Unlock()
+
+	if report.Orders+report.MangaViews > 0 {
+		recordAudit(ctx, s.audit, "archive.run", 0, nil, report)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// archive fills the report with the number of records moved
+func (s *archiveService) archive(ctx context.Context, report *domain.ArchiveReport) error {
+	orders, err := s.orderRepo.Archive(ctx, report.Cutoff, s.batchSize)
+	if err != nil {
+		return err
+	}
+	report.Orders = orders
+
+	views, err := s.viewRepo.Archive(ctx, report.Cutoff, s.batchSize)
+	if err != nil {
+		return err
+	}
+	report.MangaViews = views
+
+	report.Complete = orders < s.batchSize && views < s.batchSize
+	return nil
+}
+
+// Stats returns the archival counters since startup
+func (s *archiveService) Stats() domain.ArchiveStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stats
+}
+
+// StartScheduler runs the archival in the background at the given interval
+func (s *archiveService) StartScheduler(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			report, err := s.Run(context.Background())
+			if err != nil {
+				log.Printf("archival failed: %v", err)
+				continue
+			}
+			if report.Orders > 0 || report.MangaViews > 0 {
+				log.Printf("archived %d orders and %d daily view counts", report.Orders, report.MangaViews)
+			}
+		}
+	}()
+}
@@ -1,7 +1,9 @@
 package services
 
 import (
+	"context"
 	"errors"
+	"time"
 
 	"github.com/thitiphongD/my-backend/internal/core/domain"
 	"github.com/thitiphongD/my-backend/internal/core/ports"
@@ -11,19 +13,24 @@ import (
 // authService implements the AuthService interface
 type authService struct {
 	userRepo ports.UserRepository
+	bus      ports.EventBus
+	audit    ports.AuditService
 }
 
-// NewAuthService creates a new auth service instance
-func NewAuthService(userRepo ports.UserRepository) ports.AuthService {
+// NewAuthService creates a new auth service instance. New users are published
+// on bus, for their onboarding emails among others, and recorded in the audit log.
+func NewAuthService(userRepo ports.UserRepository, bus ports.EventBus, audit ports.AuditService) ports.AuthService {
 	return &authService{
 		userRepo: userRepo,
+		bus:      bus,
+		audit:    audit,
 	}
 }
 
 // Register creates a new user account
-func (s *authService) Register(req *domain.RegisterRequest) (*domain.AuthResponse, error) {
+func (s *authService) Register(ctx context.Context, req *domain.RegisterRequest) (*domain.AuthResponse, error) {
 	// Check if user already exists
-	_, err := s.userRepo.GetByEmail(req.Email)
+	_, err := s.userRepo.GetByEmail(ctx, req.Email)
 	if err == nil {
 		return nil, errors.New("user with this email already exists")
 	}
@@ -39,15 +46,25 @@ func (s *authService) Register(req *domain.RegisterRequest) (*domain.AuthRespons
 		Name:     req.Name,
 		Email:    req.Email,
 		Password: hashedPassword,
+		Role:     domain.RoleUser,
+		Locale:   req.Locale,
 	}
 
 	if !user.IsValid() {
 		return nil, errors.New("invalid user data")
 	}
 
-	if err := s.userRepo.Create(user); err != nil {
+	if err := s.userRepo.Create(ctx, user); err != nil {
 		return nil, err
 	}
+	// The new user is the actor of their own registration
+	recordAudit(utils.WithActor(ctx, user.ID, user.Role), s.audit, "user.register", user.ID, nil, user.Sanitize())
+
+	// The account exists either way, so a failing subscriber doesn't fail
+	// the registration
+	if err := s.bus.Publish(ctx, domain.UserRegistered{User: user}); err != nil {
+		utils.Logf(ctx, "Failed to publish the registration of user %d: %v", user.ID, err)
+	}
 
 	// Generate JWT token
 	token, err := utils.GenerateJWT(user.ID, user.Email)
@@ -62,9 +79,9 @@ func (s *authService) Register(req *domain.RegisterRequest) (*domain.AuthRespons
 }
 
 // Login authenticates a user
-func (s *authService) Login(req *domain.LoginRequest) (*domain.AuthResponse, error) {
+func (s *authService) Login(ctx context.Context, req *domain.LoginRequest) (*domain.AuthResponse, error) {
 	// Find user by email
-	user, err := s.userRepo.GetByEmail(req.Email)
+	user, err := s.userRepo.GetByEmail(ctx, req.Email)
 	if err != nil {
 		return nil, errors.New("invalid email or password")
 	}
@@ -74,6 +91,17 @@ func (s *authService) Login(req *domain.LoginRequest) (*domain.AuthResponse, err
 		return nil, errors.New("invalid email or password")
 	}
 
+	if user.IsSuspended(time.Now()) {
+		return nil, domain.ErrAccountSuspended
+	}
+
+	// Record login time
+	now := time.Now()
+	if err := s.userRepo.UpdateLastLogin(ctx, user.ID, now); err != nil {
+		return nil, err
+	}
+	user.LastLoginAt = &now
+
 	// Generate JWT token
 	token, err := utils.GenerateJWT(user.ID, user.Email)
 	if err != nil {
@@ -87,8 +115,8 @@ func (s *authService) Login(req *domain.LoginRequest) (*domain.AuthResponse, err
 }
 
 // GetUserByID retrieves a user by ID
-func (s *authService) GetUserByID(userID uint) (*domain.User, error) {
-	user, err := s.userRepo.GetByID(userID)
+func (s *authService) GetUserByID(ctx context.Context, userID uint) (*domain.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -96,16 +124,20 @@ func (s *authService) GetUserByID(userID uint) (*domain.User, error) {
 }
 
 // ValidateToken validates a JWT token and returns the user
-func (s *authService) ValidateToken(token string) (*domain.User, error) {
+func (s *authService) ValidateToken(ctx context.Context, token string) (*domain.User, error) {
 	claims, err := utils.ValidateJWT(token)
 	if err != nil {
 		return nil, errors.New("invalid token")
 	}
 
-	user, err := s.userRepo.GetByID(claims.UserID)
+	user, err := s.userRepo.GetByID(ctx, claims.UserID)
 	if err != nil {
 		return nil, errors.New("user not found")
 	}
 
+	if user.IsSuspended(time.Now()) {
+		return nil, domain.ErrAccountSuspended
+	}
+
 	return user.Sanitize(), nil
 }
@@ -0,0 +1,264 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// backupService implements the BackupService interface. Dumps are spooled to a
+// temporary file before they are stored, so a failed dump never leaves a
+// partial backup behind and storage learns the size up front.
+type backupService struct {
+	jobRepo ports.BackupJobRepository
+	dumper  ports.DatabaseDumper
+	storage ports.FileStorage
+	audit   ports.AuditService
+
+	// running is held for the duration of a job
+	running sync.Mutex
+}
+
+// NewBackupService creates a new backup service instance. Started backups
+// and restores are recorded in the audit log.
+func NewBackupService(jobRepo ports.BackupJobRepository, dumper ports.DatabaseDumper, storage ports.FileStorage, audit ports.AuditService) ports.BackupService {
+	return &backupService{
+		jobRepo: jobRepo,
+		dumper:  dumper,
+		storage: storage,
+		audit:   audit,
+	}
+}
+
+// StartBackup queues a backup and runs it in the background
+func (s *backupService) StartBackup(ctx context.Context, requestedBy *uint) (*domain.BackupJob, error) {
+	job, err := s.lockAndCreate(ctx, &domain.BackupJob{Kind: domain.BackupJobBackup, RequestedBy: requestedBy})
+	if err != nil {
+		return nil, err
+	}
+
+	queued := *job
+	go func() {
+		defer s.running.Unlock()
+		s.runBackup(context.Background(), job)
+	}()
+	return &queued, nil
+}
+
+// StartRestore queues a restore of a succeeded backup and runs it in the background
+func (s *backupService) StartRestore(ctx context.Context, backupID uint, requestedBy *uint) (*domain.BackupJob, error) {
+	backup, err := s.getRestorableBackup(ctx, backupID)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := s.lockAndCreate(ctx, &domain.BackupJob{Kind: domain.BackupJobRestore, RequestedBy: requestedBy, BackupID: &backup.ID, StorageKey: backup.StorageKey})
+	if err != nil {
+		return nil, err
+	}
+
+	queued := *job
+	go func() {
+		defer s.running.Unlock()
+		s.runRestore(context.Background(), job)
+	}()
+	return &queued, nil
+}
+
+// Backup runs a backup and returns the finished job
+func (s *backupService) Backup(ctx context.Context, requestedBy *uint) (*domain.BackupJob, error) {
+	job, err := s.lockAndCreate(ctx, &domain.BackupJob{Kind: domain.BackupJobBackup, RequestedBy: requestedBy})
+	if err != nil {
+		return nil, err
+	}
+	defer s.running.Unlock()
+
+	s.runBackup(ctx, job)
+	return job, nil
+}
+
+// Restore restores a succeeded backup and returns the finished job
+func (s *backupService) Restore(ctx context.Context, backupID uint, requestedBy *uint) (*domain.BackupJob, error) {
+	backup, err := s.getRestorableBackup(ctx, backupID)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := s.lockAndCreate(ctx, &domain.BackupJob{Kind: domain.BackupJobRestore, RequestedBy: requestedBy, BackupID: &backup.ID, StorageKey: backup.StorageKey})
+	if err != nil {
+		return nil, err
+	}
+	defer s.running.Unlock()
+
+	s.runRestore(ctx, job)
+	return job, nil
+}
+
+// GetJob retrieves a backup or restore job
+func (s *backupService) GetJob(ctx context.Context, id uint) (*domain.BackupJob, error) {
+	return s.jobRepo.GetByID(ctx, id)
+}
+
+// ListJobs retrieves backup and restore jobs, newest first; kind limits the list to one kind
+func (s *backupService) ListJobs(ctx context.Context, kind string, pagination *domain.PaginationRequest) (*domain.PaginatedResult[*domain.BackupJob], error) {
+	if kind != "" && kind != domain.BackupJobBackup && kind != domain.BackupJobRestore {
+		return nil, errors.New("invalid job kind")
+	}
+
+	jobs, total, err := s.jobRepo.ListPaginated(ctx, kind, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.PaginatedResult[*domain.BackupJob]{
+		Data:       jobs,
+		Pagination: domain.NewPaginationResponse(pagination.Page, pagination.PageSize, total),
+	}, nil
+}
+
+// FailInterrupted marks jobs left unfinished by a previous process as failed
+func (s *backupService) FailInterrupted(ctx context.Context) error {
+	failed, err := s.jobRepo.FailUnfinished(ctx, "interrupted by a server restart")
+	if err != nil {
+		return err
+	}
+	if failed > 0 {
+		log.Printf("marked %d interrupted backup jobs as failed", failed)
+	}
+	return nil
+}
+
+// lockAndCreate takes the job lock and records the job as pending. The lock
+// stays held on success; the caller releases it once the job has run.
+func (s *backupService) lockAndCreate(ctx context.Context, job *domain.BackupJob) (*domain.BackupJob, error) {
+	if !s.running.TryLock() {
+		return nil, domain.ErrBackupInProgress
+	}
+
+	job.Status = domain.BackupStatusPending
+	if err := s.jobRepo.Create(ctx, job); err != nil {
+		s.running.Unlock()
+		return nil, err
+	}
+	recordAudit(ctx, s.audit, "backup_job."+job.Kind, job.ID, nil, job)
+	return job, nil
+}
+
+// getRestorableBackup retrieves a backup job that can be restored
+func (s *backupService) getRestorableBackup(ctx context.Context, backupID uint) (*domain.BackupJob, error) {
+	backup, err := s.jobRepo.GetByID(ctx, backupID)
+	if err != nil {
+		return nil, errors.New("backup not found")
+	}
+	if backup.Kind != domain.BackupJobBackup {
+		return nil, errors.New("backup not found")
+	}
+	if backup.Status != domain.BackupStatusSucceeded {
+		return nil, errors.New("only succeeded backups can be restored")
+	}
+	return backup, nil
+}
+
+// runBackup dumps the database to a temporary file and stores it
+func (s *backupService) runBackup(ctx context.Context, job *domain.BackupJob) {
+	s.start(ctx, job)
+
+	err := func() error {
+		tmp, err := os.CreateTemp("", "backup-*.dump")
+		if err != nil {
+			return errors.New("failed to create temporary file")
+		}
+		defer os.Remove(tmp.Name())
+		defer tmp.Close()
+
+		if err := s.dumper.Dump(ctx, tmp); err != nil {
+			return err
+		}
+		size, err := tmp.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return errors.New("failed to read dump")
+		}
+		if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+			return errors.New("failed to read dump")
+		}
+
+		key := fmt.Sprintf("backup-%s-%d.dump", job.StartedAt.UTC().Format("20060102T150405Z"), job.ID)
+		if _, err := s.storage.Save(key, tmp); err != nil {
+			return err
+		}
+		job.StorageKey = key
+		job.SizeBytes = size
+		return nil
+	}()
+
+	s.finish(ctx, job, err)
+}
+
+// runRestore streams the backup from storage into the database
+func (s *backupService) runRestore(ctx context.Context, job *domain.BackupJob) {
+	s.start(ctx, job)
+
+	err := func() error {
+		dump, err := s.storage.Open(job.StorageKey)
+		if err != nil {
+			return err
+		}
+		defer dump.Close()
+
+		counted := &countingReader{r: dump}
+		err = s.dumper.Restore(ctx, counted)
+		job.SizeBytes = counted.n
+		return err
+	}()
+
+	s.finish(ctx, job, err)
+}
+
+// start records that the job is running
+func (s *backupService) start(ctx context.Context, job *domain.BackupJob) {
+	now := time.Now()
+	job.Status = domain.BackupStatusRunning
+	job.StartedAt = &now
+	if err := s.jobRepo.Update(ctx, job); err != nil {
+		log.Printf("Failed to update %s job %d: %v", job.Kind, job.ID, err)
+	}
+}
+
+// finish records the outcome of the job
+func (s *backupService) finish(ctx context.Context, job *domain.BackupJob, err error) {
+	now := time.Now()
+	job.FinishedAt = &now
+	job.Status = domain.BackupStatusSucceeded
+	if err != nil {
+		job.Status = domain.BackupStatusFailed
+		job.Error = err.Error()
+		log.Printf("%s job %d failed: %v", job.Kind, job.ID, err)
+	} else {
+		log.Printf("%s job %d succeeded (%d bytes)", job.Kind, job.ID, job.SizeBytes)
+	}
+
+	// The job's context may be the one that was cancelled
+	if err := s.jobRepo.Update(context.WithoutCancel(ctx), job); err != nil {
+		log.Printf("Failed to update %s job %d: %v", job.Kind, job.ID, err)
+	}
+}
+
+// countingReader counts the bytes read through it
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
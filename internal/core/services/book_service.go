@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/internal/utils"
+)
+
+// maxBookCacheEntries bounds the lookup cache; expired entries are swept when it fills up
+const maxBookCacheEntries = 10000
+
+// bookCacheEntry is a cached catalogue answer; a nil book records a miss
+type bookCacheEntry struct {
+	book      *domain.BookMetadata
+	expiresAt time.Time
+}
+
+// bookService implements the BookService interface, caching catalogue
+// lookups in memory so repeated ISBNs don't hit the external API
+type bookService struct {
+	lookup   ports.BookLookup
+	cacheTTL time.Duration
+
+	mu    sync.Mutex
+	cache map[string]bookCacheEntry
+}
+
+// NewBookService creates a new book service instance
+func NewBookService(lookup ports.BookLookup, cacheTTL time.Duration) ports.BookService {
+	return &bookService{
+		lookup:   lookup,
+		cacheTTL: cacheTTL,
+		cache:    make(map[string]bookCacheEntry),
+	}
+}
+
+// LookupISBN returns catalogue data for an ISBN along with pre-filled create-manga data
+func (s *bookService) LookupISBN(ctx context.Context, raw string) (*domain.ISBNLookupResponse, error) {
+	isbn, ok := utils.NormalizeISBN(raw)
+	if !ok {
+		return nil, domain.ErrInvalidISBN
+	}
+
+	book, err := s.cachedLookup(ctx, isbn)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.ISBNLookupResponse{
+		Book: book,
+		Prefill: &domain.CreateMangaRequest{
+			Name:     book.Title,
+			IsActive: true,
+		},
+	}, nil
+}
+
+// cachedLookup serves a lookup from the cache, or queries the catalogue and caches
+// the result. Misses are cached too; upstream failures are not.
+func (s *bookService) cachedLookup(ctx context.Context, isbn string) (*domain.BookMetadata, error) {
+	now := time.Now()
+
+	s.mu.Lock()
+	entry, ok := s.cache[isbn]
+	if ok && now.After(entry.expiresAt) {
+		delete(s.cache, isbn)
+		ok = false
+	}
+	s.mu.Unlock()
+
+	if ok {
+		if entry.book == nil {
+			return nil, domain.ErrBookNotFound
+		}
+		return entry.book, nil
+	}
+
+	book, err := s.lookup.LookupISBN(ctx, isbn)
+	if err != nil && !errors.Is(err, domain.ErrBookNotFound) {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	if len(s.cache) >= maxBookCacheEntries {
+		for key, cached := range s.cache {
+			if now.After(cached.expiresAt) {
+				delete(s.cache, key)
+			}
+		}
+		if len(s.cache) >= maxBookCacheEntries {
+			s.cache = make(map[string]bookCacheEntry)
+		}
+	}
+	s.cache[isbn] = bookCacheEntry{book: book, expiresAt: now.Add(s.cacheTTL)}
+	s.mu.Unlock()
+
+	if book == nil {
+		return nil, domain.ErrBookNotFound
+	}
+	return book, nil
+}
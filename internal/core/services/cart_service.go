@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// cartService implements the CartService interface
+type cartService struct {
+	cartRepo     ports.CartRepository
+	mangaRepo    ports.MangaRepository
+	discountRepo ports.DiscountRepository
+}
+
+// NewCartService creates a new cart service instance
+func NewCartService(cartRepo ports.CartRepository, mangaRepo ports.MangaRepository, discountRepo ports.DiscountRepository) ports.CartService {
+	return &cartService{
+		cartRepo:     cartRepo,
+		mangaRepo:    mangaRepo,
+		discountRepo: discountRepo,
+	}
+}
+
+// GetCart retrieves the user's cart, priced with the discounts active now
+func (s *cartService) GetCart(ctx context.Context, userID uint) (*domain.Cart, error) {
+	items, err := s.cartRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	mangas := make([]*domain.Manga, len(items))
+	for i, item := range items {
+		item.Manga = item.Manga.Sanitize()
+		mangas[i] = item.Manga
+	}
+	if discounts, err := s.discountRepo.ListActive(ctx, time.Now()); err == nil {
+		domain.ApplyDiscounts(mangas, discounts)
+	}
+
+	return domain.NewCart(items), nil
+}
+
+// SetItem puts a manga on sale in the user's cart, or changes how many copies
+// of it the cart holds. Stock is only checked, and taken, at checkout.
+func (s *cartService) SetItem(ctx context.Context, userID, mangaID uint, req *domain.SetCartItemRequest) (*domain.Cart, error) {
+	manga, err := s.mangaRepo.GetByID(ctx, mangaID)
+	if err != nil {
+		return nil, err
+	}
+	if manga.Status != domain.MangaStatusPublished || !manga.IsActive {
+		return nil, errors.New("manga is not available for purchase")
+	}
+
+	items, err := s.cartRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	inCart := false
+	for _, item := range items {
+		inCart = inCart || item.MangaID == mangaID
+	}
+	if !inCart && len(items) >= domain.MaxCartItems {
+		return nil, errors.New("cart is full")
+	}
+
+	item := &domain.CartItem{
+		UserID:   userID,
+		MangaID:  mangaID,
+		Quantity: req.Quantity,
+	}
+	if err := s.cartRepo.SetItem(ctx, item); err != nil {
+		return nil, err
+	}
+
+	return s.GetCart(ctx, userID)
+}
+
+// RemoveItem takes a manga out of the user's cart
+func (s *cartService) RemoveItem(ctx context.Context, userID, mangaID uint) (*domain.Cart, error) {
+	if err := s.cartRepo.RemoveItem(ctx, userID, mangaID); err != nil {
+		return nil, err
+	}
+	return s.GetCart(ctx, userID)
+}
+
+// ClearCart empties the user's cart
+func (s *cartService) ClearCart(ctx context.Context, userID uint) error {
+	return s.cartRepo.Clear(ctx, userID)
+}
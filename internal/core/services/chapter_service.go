@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// chapterService implements the ChapterService interface
+type chapterService struct {
+	chapterRepo ports.ChapterRepository
+	mangaRepo   ports.MangaRepository
+	teamRepo    ports.TeamRepository
+	audit       ports.AuditService
+}
+
+// NewChapterService creates a new chapter service instance
+func NewChapterService(chapterRepo ports.ChapterRepository, mangaRepo ports.MangaRepository, teamRepo ports.TeamRepository, audit ports.AuditService) ports.ChapterService {
+	return &chapterService{
+		chapterRepo: chapterRepo,
+		mangaRepo:   mangaRepo,
+		teamRepo:    teamRepo,
+		audit:       audit,
+	}
+}
+
+// CreateChapter adds a chapter to a manga (owner only)
+func (s *chapterService) CreateChapter(ctx context.Context, mangaID uint, req *domain.ChapterRequest, userID uint) (*domain.Chapter, error) {
+	if err := s.requireManager(ctx, mangaID, userID); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.chapterRepo.GetByMangaAndNumber(ctx, mangaID, req.Number); err == nil {
+		return nil, errors.New("chapter with this number already exists")
+	}
+
+	chapter := &domain.Chapter{
+		MangaID:     mangaID,
+		Number:      req.Number,
+		Title:       strings.TrimSpace(req.Title),
+		PageCount:   req.PageCount,
+		ReleaseDate: req.ReleaseDate,
+	}
+
+	if !chapter.IsValid() {
+		return nil, errors.New("invalid chapter data")
+	}
+
+	if err := s.chapterRepo.Create(ctx, chapter); err != nil {
+		return nil, err
+	}
+	recordAudit(ctx, s.audit, "chapter.create", chapter.ID, nil, chapter)
+
+	return chapter, nil
+}
+
+// GetChapter retrieves a chapter of a manga
+func (s *chapterService) GetChapter(ctx context.Context, mangaID, chapterID uint) (*domain.Chapter, error) {
+	chapter, err := s.chapterRepo.GetByID(ctx, chapterID)
+	if err != nil {
+		return nil, err
+	}
+
+	if chapter.MangaID != mangaID {
+		return nil, errors.New("chapter not found")
+	}
+
+	return chapter, nil
+}
+
+// GetChapters retrieves all chapters of a manga ordered by number
+func (s *chapterService) GetChapters(ctx context.Context, mangaID uint) ([]*domain.Chapter, error) {
+	if _, err := s.mangaRepo.GetByID(ctx, mangaID); err != nil {
+		return nil, err
+	}
+
+	return s.chapterRepo.ListByMangaID(ctx, mangaID)
+}
+
+// UpdateChapter updates a chapter of a manga (owner only)
+func (s *chapterService) UpdateChapter(ctx context.Context, mangaID, chapterID uint, req *domain.ChapterRequest, userID uint) (*domain.Chapter, error) {
+	if err := s.requireManager(ctx, mangaID, userID); err != nil {
+		return nil, err
+	}
+
+	chapter, err := s.GetChapter(ctx, mangaID, chapterID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Number != chapter.Number {
+		if _, err := s.chapterRepo.GetByMangaAndNumber(ctx, mangaID, req.Number); err == nil {
+			return nil, errors.New("chapter with this number already exists")
+		}
+	}
+
+	before := auditState(chapter)
+	chapter.Number = req.Number
+	chapter.Title = strings.TrimSpace(req.Title)
+	chapter.PageCount = req.PageCount
+	chapter.ReleaseDate = req.ReleaseDate
+
+	if !chapter.IsValid() {
+		return nil, errors.New("invalid chapter data")
+	}
+
+	if err := s.chapterRepo.Update(ctx, chapter); err != nil {
+		return nil, err
+	}
+	recordAudit(ctx, s.audit, "chapter.update", chapter.ID, before, chapter)
+
+	return chapter, nil
+}
+
+// DeleteChapter deletes a chapter of a manga (owner only)
+func (s *chapterService) DeleteChapter(ctx context.Context, mangaID, chapterID uint, userID uint) error {
+	if err := s.requireManager(ctx, mangaID, userID); err != nil {
+		return err
+	}
+
+	chapter, err := s.GetChapter(ctx, mangaID, chapterID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.chapterRepo.Delete(ctx, chapterID); err != nil {
+		return err
+	}
+	recordAudit(ctx, s.audit, "chapter.delete", chapterID, chapter, nil)
+	return nil
+}
+
+// requireManager checks that the user can manage the manga
+func (s *chapterService) requireManager(ctx context.Context, mangaID, userID uint) error {
+	manga, err := s.mangaRepo.GetByID(ctx, mangaID)
+	if err != nil {
+		return err
+	}
+
+	if !canManageManga(ctx, s.teamRepo, manga, userID) {
+		return errors.New("access denied: you can only manage chapters of your own manga")
+	}
+
+	return nil
+}
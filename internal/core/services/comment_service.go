@@ -0,0 +1,156 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/internal/utils"
+)
+
+// commentService implements the CommentService interface
+type commentService struct {
+	commentRepo ports.CommentRepository
+	mangaRepo   ports.MangaRepository
+	bus         ports.EventBus
+	audit       ports.AuditService
+}
+
+// NewCommentService creates a new comment service instance. Comments deleted
+// by moderators are published on bus, so their authors can be told, and
+// recorded in the audit log.
+func NewCommentService(commentRepo ports.CommentRepository, mangaRepo ports.MangaRepository, bus ports.EventBus, audit ports.AuditService) ports.CommentService {
+	return &commentService{
+		commentRepo: commentRepo,
+		mangaRepo:   mangaRepo,
+		bus:         bus,
+		audit:       audit,
+	}
+}
+
+// CreateComment posts a comment on a manga, or a reply when ParentID is set.
+// Replying to a reply attaches to the reply's top-level comment.
+func (s *commentService) CreateComment(ctx context.Context, mangaID uint, req *domain.CreateCommentRequest, userID uint) (*domain.Comment, error) {
+	if _, err := s.mangaRepo.GetByID(ctx, mangaID); err != nil {
+		return nil, err
+	}
+
+	comment := &domain.Comment{
+		MangaID: mangaID,
+		UserID:  userID,
+		Body:    strings.TrimSpace(req.Body),
+	}
+
+	if req.ParentID != nil {
+		parent, err := s.commentRepo.GetByID(ctx, *req.ParentID)
+		if err != nil || parent.MangaID != mangaID {
+			return nil, errors.New("parent comment not found")
+		}
+		parentID := parent.ID
+		if parent.ParentID != nil {
+			parentID = *parent.ParentID
+		}
+		comment.ParentID = &parentID
+	}
+
+	if !comment.IsValid() {
+		return nil, errors.New("invalid comment data")
+	}
+
+	if err := s.commentRepo.Create(ctx, comment); err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// GetCommentsPaginated retrieves paginated comment threads of a manga
+func (s *commentService) GetCommentsPaginated(ctx context.Context, mangaID uint, pagination *domain.PaginationRequest) (*domain.PaginatedResult[*domain.Comment], error) {
+	if _, err := s.mangaRepo.GetByID(ctx, mangaID); err != nil {
+		return nil, err
+	}
+
+	comments, total, err := s.commentRepo.ListThreadsPaginated(ctx, mangaID, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.PaginatedResult[*domain.Comment]{
+		Data:       comments,
+		Pagination: domain.NewPaginationResponse(pagination.Page, pagination.PageSize, total),
+	}, nil
+}
+
+// UpdateComment edits the user's own comment within the edit window
+func (s *commentService) UpdateComment(ctx context.Context, mangaID, commentID uint, req *domain.UpdateCommentRequest, userID uint) (*domain.Comment, error) {
+	comment, err := s.getOwnedComment(ctx, mangaID, commentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !comment.IsEditable(time.Now()) {
+		return nil, errors.New("comment can no longer be edited")
+	}
+
+	comment.Body = strings.TrimSpace(req.Body)
+
+	if !comment.IsValid() {
+		return nil, errors.New("invalid comment data")
+	}
+
+	if err := s.commentRepo.Update(ctx, comment); err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// DeleteComment soft deletes the user's own comment and its replies
+func (s *commentService) DeleteComment(ctx context.Context, mangaID, commentID uint, userID uint) error {
+	if _, err := s.getOwnedComment(ctx, mangaID, commentID, userID); err != nil {
+		return err
+	}
+
+	return s.commentRepo.Delete(ctx, commentID)
+}
+
+// ModerateDeleteComment soft deletes any comment and its replies (admin only)
+func (s *commentService) ModerateDeleteComment(ctx context.Context, commentID uint) error {
+	comment, err := s.commentRepo.GetByID(ctx, commentID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.commentRepo.Delete(ctx, commentID); err != nil {
+		return err
+	}
+	recordAudit(ctx, s.audit, "comment.moderate_delete", commentID, comment, nil)
+
+	// The comment is gone either way, so a failing subscriber doesn't fail
+	// the moderation
+	if err := s.bus.Publish(ctx, domain.CommentRemoved{Comment: comment}); err != nil {
+		utils.Logf(ctx, "Failed to publish the removal of comment %d: %v", commentID, err)
+	}
+	return nil
+}
+
+// getOwnedComment retrieves a manga's comment and checks the user wrote it
+func (s *commentService) getOwnedComment(ctx context.Context, mangaID, commentID uint, userID uint) (*domain.Comment, error) {
+	comment, err := s.commentRepo.GetByID(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if comment.MangaID != mangaID {
+		return nil, errors.New("comment not found")
+	}
+
+	if comment.UserID != userID {
+		return nil, errors.New("access denied: you can only modify your own comment")
+	}
+
+	return comment, nil
+}
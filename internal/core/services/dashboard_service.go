@@ -0,0 +1,56 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// dashboardService implements the DashboardService interface. The figures
+// take a scan of several large tables, so they are kept for cacheTTL and
+// every admin watching the dashboard shares them.
+type dashboardService struct {
+	dashboardRepo ports.DashboardRepository
+	requests      ports.RequestStats
+	cacheTTL      time.Duration
+
+	// mu is held while the figures are computed, so concurrent requests wait
+	// for one computation rather than each running their own
+	mu     sync.Mutex
+	cached *domain.DashboardStats
+}
+
+// NewDashboardService creates a new dashboard service instance. The figures
+// are computed at most once per cacheTTL (0 = on every request).
+func NewDashboardService(dashboardRepo ports.DashboardRepository, requests ports.RequestStats, cacheTTL time.Duration) ports.DashboardService {
+	return &dashboardService{
+		dashboardRepo: dashboardRepo,
+		requests:      requests,
+		cacheTTL:      cacheTTL,
+	}
+}
+
+// GetDashboard returns the dashboard figures, from the cache while they are
+// fresh enough
+func (s *dashboardService) GetDashboard(ctx context.Context) (*domain.DashboardStats, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if s.cached != nil && now.Sub(s.cached.GeneratedAt) < s.cacheTTL {
+		return s.cached, nil
+	}
+
+	stats, err := s.dashboardRepo.Stats(ctx, now, now.AddDate(0, 0, -1), now.AddDate(0, 0, -7))
+	if err != nil {
+		return nil, err
+	}
+	stats.Requests = s.requests.Recent()
+	stats.GeneratedAt = now
+
+	s.cached = stats
+	return stats, nil
+}
@@ -0,0 +1,249 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// digestBatchSize is the number of due digests made per query
+const digestBatchSize = 100
+
+// errDigestTaken rolls back a digest whose items another process took
+var errDigestTaken = errors.New("digest items already taken")
+
+// digestService implements the DigestService interface. Every process runs
+// the scheduler; each pending item goes into one digest, so each digest is
+// made by one of them.
+type digestService struct {
+	digestRepo ports.DigestRepository
+	prefsRepo  ports.NotificationPreferenceRepository
+	userRepo   ports.UserRepository
+	jobs       ports.JobQueue
+	txManager  ports.TransactionManager
+	renderer   ports.EmailRenderer
+	sender     ports.EmailSender
+	baseURL    string
+}
+
+// NewDigestService creates a new digest service instance, and registers the
+// handler of the jobs that email digests. Unsubscribe links in digests point
+// at baseURL, the public URL of the API.
+func NewDigestService(digestRepo ports.DigestRepository, prefsRepo ports.NotificationPreferenceRepository, userRepo ports.UserRepository, jobs ports.JobService, txManager ports.TransactionManager, renderer ports.EmailRenderer, sender ports.EmailSender, baseURL string) ports.DigestService {
+	s := &digestService{
+		digestRepo: digestRepo,
+		prefsRepo:  prefsRepo,
+		userRepo:   userRepo,
+		jobs:       jobs,
+		txManager:  txManager,
+		renderer:   renderer,
+		sender:     sender,
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+	}
+	HandleJob(jobs, s.sendDigest)
+	return s
+}
+
+// GetDigests retrieves the user's digests, newest first
+func (s *digestService) GetDigests(ctx context.Context, userID uint, pagination *domain.PaginationRequest) (*domain.PaginatedResult[*domain.Digest], error) {
+	digests, total, err := s.digestRepo.ListByUser(ctx, userID, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.PaginatedResult[*domain.Digest]{
+		Data:       digests,
+		Pagination: domain.NewPaginationResponse(pagination.Page, pagination.PageSize, total),
+	}, nil
+}
+
+// GetDigest retrieves one of the user's digests
+func (s *digestService) GetDigest(ctx context.Context, id uint, userID uint) (*domain.Digest, error) {
+	digest, err := s.digestRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if digest.UserID != userID {
+		return nil, errors.New("digest not found")
+	}
+	return digest, nil
+}
+
+// MarkRead marks one of the user's digests read, keeping when it was first read
+func (s *digestService) MarkRead(ctx context.Context, id uint, userID uint) (*domain.Digest, error) {
+	digest, err := s.GetDigest(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if digest.ReadAt != nil {
+		return digest, nil
+	}
+
+	now := time.Now()
+	if err := s.digestRepo.MarkRead(ctx, digest.ID, now); err != nil {
+		return nil, err
+	}
+	digest.ReadAt = &now
+	return digest, nil
+}
+
+// QueueDueDigests makes the digest of the last period of every user with
+// items pending since before its end, and queues a job emailing it to the
+// users who get digests by email. Items of users who turned digests off are
+// dropped.
+func (s *digestService) QueueDueDigests(ctx context.Context) (int, error) {
+	if _, err := s.digestRepo.DiscardOffItems(ctx); err != nil {
+		return 0, err
+	}
+
+	made := 0
+	now := time.Now()
+	for _, frequency := range domain.DigestFrequencies {
+		from, to := domain.DigestPeriod(frequency, now)
+		for {
+			userIDs, err := s.digestRepo.ListDueUsers(ctx, frequency, to, digestBatchSize)
+			if err != nil {
+				return made, err
+			}
+			for _, userID := range userIDs {
+				ok, err := s.makeDigest(ctx, userID, frequency, from, to)
+				if err != nil {
+					return made, err
+				}
+				if ok {
+					made++
+				}
+			}
+			if len(userIDs) < digestBatchSize {
+				break
+			}
+		}
+	}
+	return made, nil
+}
+
+// makeDigest puts the user's pending items in a new digest of the period,
+// reporting false when another process took them first
+func (s *digestService) makeDigest(ctx context.Context, userID uint, frequency string, from, to time.Time) (bool, error) {
+	prefs, err := notificationPreferences(ctx, s.prefsRepo, userID)
+	if err != nil {
+		return false, err
+	}
+
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		digest := &domain.Digest{UserID: userID, Frequency: frequency, From: from, To: to}
+		if err := s.digestRepo.Create(ctx, digest); err != nil {
+			return err
+		}
+		attached, err := s.digestRepo.AttachPendingItems(ctx, digest.ID, userID, to)
+		if err != nil {
+			return err
+		}
+		if attached == 0 {
+			return errDigestTaken
+		}
+		if !prefs.Allows(domain.NotificationDigests) {
+			return nil
+		}
+		_, err = s.jobs.Enqueue(ctx, domain.SendDigestArgs{DigestID: digest.ID}, &domain.JobOptions{UserID: &userID})
+		return err
+	})
+	if errors.Is(err, errDigestTaken) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// StartScheduler makes due digests in the background at the given interval
+func (s *digestService) StartScheduler(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			made, err := s.QueueDueDigests(context.Background())
+			if err != nil {
+				log.Printf("making digests failed: %v", err)
+			}
+			if made > 0 {
+				log.Printf("made %d digests", made)
+			}
+		}
+	}()
+}
+
+// Deliver holds the notification for the user's next digest, unless they
+// turned digests off. Only price drops go into digests.
+func (s *digestService) Deliver(ctx context.Context, n *domain.Notification) error {
+	if n.PriceDrop == nil {
+		return nil
+	}
+
+	prefs, err := notificationPreferences(ctx, s.prefsRepo, n.UserID)
+	if err != nil {
+		return err
+	}
+	if prefs.Digests == domain.DigestsOff {
+		return nil
+	}
+	return s.digestRepo.AddItem(ctx, &domain.DigestItem{UserID: n.UserID, Kind: domain.DigestItemPriceDrop, PriceDrop: n.PriceDrop})
+}
+
+// sendDigest renders a digest in the user's locale and emails it, unless the
+// user is gone or has stopped digest emails since it was made. Digests due
+// in the user's quiet hours are queued again for when they end.
+func (s *digestService) sendDigest(ctx context.Context, job *domain.Job, args domain.SendDigestArgs) error {
+	digest, err := s.digestRepo.GetByID(ctx, args.DigestID)
+	if err != nil {
+		if strings.HasSuffix(err.Error(), "not found") {
+			return nil
+		}
+		return err
+	}
+	user, err := s.userRepo.GetByID(ctx, digest.UserID)
+	if err != nil {
+		if strings.HasSuffix(err.Error(), "not found") {
+			return nil
+		}
+		return err
+	}
+	if user.IsSuspended(time.Now()) {
+		return nil
+	}
+
+	prefs, err := notificationPreferences(ctx, s.prefsRepo, user.ID)
+	if err != nil {
+		return err
+	}
+	if !prefs.Allows(domain.NotificationDigests) {
+		return nil
+	}
+	if until := prefs.QuietUntil(time.Now()); !until.IsZero() {
+		_, err := s.jobs.Enqueue(ctx, args, &domain.JobOptions{UserID: &user.ID, RunAt: until})
+		return err
+	}
+
+	unsubscribeURL, err := unsubscribeURL(s.baseURL, user.ID, domain.NotificationDigests)
+	if err != nil {
+		return err
+	}
+	msg, err := s.renderer.Render(domain.EmailDigest, user.Locale, &domain.EmailData{
+		User:           user,
+		Digest:         digest,
+		UnsubscribeURL: unsubscribeURL,
+	})
+	if err != nil {
+		// Rendering again won't fix a template
+		return fmt.Errorf("%w: %v", domain.ErrPermanentJobFailure, err)
+	}
+
+	msg.To = user.Email
+	msg.UnsubscribeURL = unsubscribeURL
+	return s.sender.Send(ctx, msg)
+}
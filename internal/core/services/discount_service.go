@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// discountService implements the DiscountService interface
+type discountService struct {
+	discountRepo ports.DiscountRepository
+	mangaRepo    ports.MangaRepository
+	genreRepo    ports.GenreRepository
+	audit        ports.AuditService
+}
+
+// NewDiscountService creates a new discount service instance
+func NewDiscountService(discountRepo ports.DiscountRepository, mangaRepo ports.MangaRepository, genreRepo ports.GenreRepository, audit ports.AuditService) ports.DiscountService {
+	return &discountService{
+		discountRepo: discountRepo,
+		mangaRepo:    mangaRepo,
+		genreRepo:    genreRepo,
+		audit:        audit,
+	}
+}
+
+// CreateDiscount creates a new discount campaign
+func (s *discountService) CreateDiscount(ctx context.Context, req *domain.DiscountRequest) (*domain.Discount, error) {
+	discount := &domain.Discount{}
+	if err := s.fill(ctx, discount, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.discountRepo.Create(ctx, discount); err != nil {
+		return nil, err
+	}
+
+	discount.FillMangaIDs()
+	recordAudit(ctx, s.audit, "discount.create", discount.ID, nil, discount)
+	return discount, nil
+}
+
+// GetDiscounts retrieves all discounts
+func (s *discountService) GetDiscounts(ctx context.Context) ([]*domain.Discount, error) {
+	discounts, err := s.discountRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, discount := range discounts {
+		discount.FillMangaIDs()
+	}
+
+	return discounts, nil
+}
+
+// UpdateDiscount updates an existing discount and its targets
+func (s *discountService) UpdateDiscount(ctx context.Context, id uint, req *domain.DiscountRequest) (*domain.Discount, error) {
+	discount, err := s.discountRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	discount.FillMangaIDs()
+	before := auditState(discount)
+
+	if err := s.fill(ctx, discount, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.discountRepo.Update(ctx, discount); err != nil {
+		return nil, err
+	}
+
+	discount.FillMangaIDs()
+	recordAudit(ctx, s.audit, "discount.update", discount.ID, before, discount)
+	return discount, nil
+}
+
+// DeleteDiscount deletes a discount
+func (s *discountService) DeleteDiscount(ctx context.Context, id uint) error {
+	discount, err := s.discountRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.discountRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	discount.FillMangaIDs()
+	recordAudit(ctx, s.audit, "discount.delete", id, discount, nil)
+	return nil
+}
+
+// fill copies the request onto the discount, resolving and checking its targets
+func (s *discountService) fill(ctx context.Context, discount *domain.Discount, req *domain.DiscountRequest) error {
+	if len(req.MangaIDs) == 0 && len(req.GenreIDs) == 0 {
+		return errors.New("discount must apply to at least one manga or genre")
+	}
+
+	mangas := make([]domain.Manga, 0, len(req.MangaIDs))
+	for _, id := range req.MangaIDs {
+		manga, err := s.mangaRepo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		mangas = append(mangas, domain.Manga{ID: manga.ID})
+	}
+
+	genres, err := s.genreRepo.GetByIDs(ctx, req.GenreIDs)
+	if err != nil {
+		return err
+	}
+	if len(genres) != len(req.GenreIDs) {
+		return errors.New("genre not found")
+	}
+
+	discount.Name = strings.TrimSpace(req.Name)
+	discount.Type = req.Type
+	discount.Value = req.Value
+	discount.StartsAt = req.StartsAt
+	discount.EndsAt = req.EndsAt
+	discount.Mangas = mangas
+	discount.Genres = make([]domain.Genre, len(genres))
+	for i, genre := range genres {
+		discount.Genres[i] = *genre
+	}
+
+	if !discount.IsValid() {
+		return errors.New("invalid discount data: percentage must be at most 100 and ends_at must be after starts_at")
+	}
+
+	return nil
+}
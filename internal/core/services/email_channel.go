@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// emailChannel emails notifications to users in their locale
+type emailChannel struct {
+	userRepo ports.UserRepository
+	renderer ports.EmailRenderer
+	sender   ports.EmailSender
+}
+
+// NewEmailChannel creates the email notification channel. The sender should
+// queue messages so the requests notifications come from are not held up by
+// the mail server.
+func NewEmailChannel(userRepo ports.UserRepository, renderer ports.EmailRenderer, sender ports.EmailSender) ports.NotificationChannel {
+	return &emailChannel{
+		userRepo: userRepo,
+		renderer: renderer,
+		sender:   sender,
+	}
+}
+
+// Deliver emails the notification with the template of its event; events
+// without one, such as order.delivered, aren't emailed
+func (e *emailChannel) Deliver(ctx context.Context, n *domain.Notification) error {
+	var template string
+	switch n.Event {
+	case domain.EventOrderPaid:
+		template = domain.EmailOrderPaid
+	case domain.EventOrderShipped:
+		template = domain.EmailOrderShipped
+	case domain.EventMangaReviewed:
+		template = domain.EmailMangaReviewed
+	case domain.EventCommentRemoved:
+		template = domain.EmailCommentRemoved
+	default:
+		return nil
+	}
+	return e.send(ctx, template, n.UserID, &domain.EmailData{Order: n.Order, Manga: n.Manga, Comment: n.Comment})
+}
+
+// send renders the email template for the user and sends it
+func (e *emailChannel) send(ctx context.Context, template string, userID uint, data *domain.EmailData) error {
+	user, err := e.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to load user %d for %s email: %w", userID, template, err)
+	}
+
+	data.User = user
+	msg, err := e.renderer.Render(template, user.Locale, data)
+	if err != nil {
+		return fmt.Errorf("failed to render %s email for user %d: %w", template, userID, err)
+	}
+
+	msg.To = user.Email
+	if err := e.sender.Send(ctx, msg); err != nil {
+		return fmt.Errorf("failed to send %s email to user %d: %w", template, userID, err)
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// jobEmailSender implements the EmailSender interface by queuing a send_email
+// job for each message, so callers never wait on the mail server and queued
+// messages survive a restart
+type jobEmailSender struct {
+	jobs ports.JobQueue
+}
+
+// NewJobEmailSender creates a sender that queues messages as jobs, and
+// registers the handler that delivers them through next. Transient failures
+// are retried with the queue's backoff; rejected messages are not.
+func NewJobEmailSender(jobs ports.JobService, next ports.EmailSender) ports.EmailSender {
+	HandleJob(jobs, func(ctx context.Context, job *domain.Job, args domain.SendEmailArgs) error {
+		err := next.Send(ctx, &args.Message)
+		if errors.Is(err, domain.ErrEmailRejected) {
+			return fmt.Errorf("%w: %v", domain.ErrPermanentJobFailure, err)
+		}
+		return err
+	})
+	return &jobEmailSender{jobs: jobs}
+}
+
+// Send queues the message, within the transaction of ctx if there is one, so
+// a rolled back change sends no email
+func (s *jobEmailSender) Send(ctx context.Context, msg *domain.EmailMessage) error {
+	_, err := s.jobs.Enqueue(ctx, domain.SendEmailArgs{Message: *msg}, nil)
+	return err
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/internal/utils"
+)
+
+// eventBus implements the EventBus interface in memory. Handlers run on the
+// publisher's goroutine: in-transaction ones during Publish, after-commit ones
+// when the transaction manager runs them once the publisher commits.
+type eventBus struct {
+	tx ports.TransactionManager
+
+	mu          sync.RWMutex
+	handlers    map[string][]ports.EventHandler
+	afterCommit map[string][]ports.EventHandler
+}
+
+// NewEventBus creates a new event bus instance that defers after-commit
+// handlers with the transaction manager
+func NewEventBus(tx ports.TransactionManager) ports.EventBus {
+	return &eventBus{
+		tx:          tx,
+		handlers:    make(map[string][]ports.EventHandler),
+		afterCommit: make(map[string][]ports.EventHandler),
+	}
+}
+
+// Subscribe registers fn to run in the publisher's transaction for the events
+// of type T
+func Subscribe[T domain.Event](bus ports.EventBus, fn func(ctx context.Context, event T) error) {
+	var zero T
+	bus.Subscribe(zero.EventName(), func(ctx context.Context, event domain.Event) error {
+		return fn(ctx, event.(T))
+	})
+}
+
+// SubscribeAfterCommit registers fn to run once the publisher's transaction
+// has committed for the events of type T
+func SubscribeAfterCommit[T domain.Event](bus ports.EventBus, fn func(ctx context.Context, event T) error) {
+	var zero T
+	bus.SubscribeAfterCommit(zero.EventName(), func(ctx context.Context, event domain.Event) error {
+		return fn(ctx, event.(T))
+	})
+}
+
+// Subscribe adds an in-transaction handler of the named events
+func (b *eventBus) Subscribe(name string, handler ports.EventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[name] = append(b.handlers[name], handler)
+}
+
+// SubscribeAfterCommit adds an after-commit handler of the named events
+func (b *eventBus) SubscribeAfterCommit(name string, handler ports.EventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.afterCommit[name] = append(b.afterCommit[name], handler)
+}
+
+// Publish runs the in-transaction handlers, stopping at the first error, then
+// queues the after-commit handlers on the publisher's transaction
+func (b *eventBus) Publish(ctx context.Context, event domain.Event) error {
+	b.mu.RLock()
+	handlers := b.handlers[event.EventName()]
+	afterCommit := b.afterCommit[event.EventName()]
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	if len(afterCommit) == 0 {
+		return nil
+	}
+	b.tx.AfterCommit(ctx, func(ctx context.Context) {
+		for _, handler := range afterCommit {
+			if err := callEventHandler(ctx, handler, event); err != nil {
+				utils.Logf(ctx, "%s subscriber failed: %v", event.EventName(), err)
+			}
+		}
+	})
+	return nil
+}
+
+// callEventHandler runs an after-commit handler, turning a panic into an
+// error so the other subscribers still run
+func callEventHandler(ctx context.Context, handler ports.EventHandler, event domain.Event) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("subscriber panicked: %v", r)
+		}
+	}()
+	return handler(ctx, event)
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/internal/utils"
+)
+
+// EventTopics picks the broker topic of each domain event: its entry in
+// Overrides by name, then by group ("order.*"), then Prefix followed by its
+// name. An empty override keeps the events it matches from being published.
+type EventTopics struct {
+	Prefix    string
+	Overrides map[string]string
+}
+
+// Topic returns the topic of the named event, and whether it is published
+func (t EventTopics) Topic(event string) (string, bool) {
+	if topic, ok := t.Overrides[event]; ok {
+		return topic, topic != ""
+	}
+	group, _, _ := strings.Cut(event, ".")
+	if topic, ok := t.Overrides[group+".*"]; ok {
+		return topic, topic != ""
+	}
+	return t.Prefix + event, true
+}
+
+// eventPublisher publishes domain events to a message broker as CloudEvents
+type eventPublisher struct {
+	publisher ports.EventPublisher
+	jobs      ports.JobService
+	topics    EventTopics
+	source    string
+}
+
+// SubscribeEventPublisher publishes the user, manga and order events of the
+// bus to the broker, for consumers outside this service. Each event is queued
+// as a background job in the transaction of its change, so it is published at
+// least once after the change commits, and never for a change rolled back.
+// Events are published with source, the public URL of the API.
+func SubscribeEventPublisher(bus ports.EventBus, jobs ports.JobService, publisher ports.EventPublisher, topics EventTopics, source string) {
+	p := &eventPublisher{
+		publisher: publisher,
+		jobs:      jobs,
+		topics:    topics,
+		source:    source,
+	}
+	Subscribe(bus, func(ctx context.Context, event domain.UserRegistered) error {
+		return p.enqueue(ctx, event, resourceSubject("users", event.User.ID), event.User.Sanitize())
+	})
+	Subscribe(bus, func(ctx context.Context, event domain.MangaCreated) error {
+		return p.enqueue(ctx, event, resourceSubject("mangas", event.Manga.ID), event.Manga.Sanitize())
+	})
+	Subscribe(bus, func(ctx context.Context, event domain.MangaUpdated) error {
+		return p.enqueue(ctx, event, resourceSubject("mangas", event.Manga.ID), event.Manga.Sanitize())
+	})
+	Subscribe(bus, func(ctx context.Context, event domain.MangaDeleted) error {
+		return p.enqueue(ctx, event, resourceSubject("mangas", event.Manga.ID), event.Manga.Sanitize())
+	})
+	Subscribe(bus, func(ctx context.Context, event domain.MangaPriceDropped) error {
+		return p.enqueue(ctx, event, resourceSubject("mangas", event.Manga.ID), &domain.WishlistPriceDrop{
+			Manga:    event.Manga.Sanitize(),
+			OldPrice: event.OldPrice,
+			NewPrice: event.Manga.Price,
+		})
+	})
+	Subscribe(bus, func(ctx context.Context, event domain.OrderPlaced) error {
+		return p.enqueue(ctx, event, resourceSubject("orders", event.Order.ID), event.Order)
+	})
+	Subscribe(bus, func(ctx context.Context, event domain.OrderPaid) error {
+		return p.enqueue(ctx, event, resourceSubject("orders", event.Order.ID), event.Order)
+	})
+	Subscribe(bus, func(ctx context.Context, event domain.OrderShipped) error {
+		return p.enqueue(ctx, event, resourceSubject("orders", event.Order.ID), event.Order)
+	})
+	Subscribe(bus, func(ctx context.Context, event domain.OrderDelivered) error {
+		return p.enqueue(ctx, event, resourceSubject("orders", event.Order.ID), event.Order)
+	})
+	HandleJob(jobs, p.publish)
+}
+
+// resourceSubject is the CloudEvents subject of a resource, such as "orders/12"
+func resourceSubject(collection string, id uint) string {
+	return collection + "/" + strconv.FormatUint(uint64(id), 10)
+}
+
+// enqueue encodes the event data right away, so the published event shows
+// the change as it was when the transaction committed, and queues its publish
+func (p *eventPublisher) enqueue(ctx context.Context, event domain.Event, subject string, data interface{}) error {
+	topic, ok := p.topics.Topic(event.EventName())
+	if !ok {
+		return nil
+	}
+
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return errors.New("failed to encode event")
+	}
+	id, err := utils.GenerateRandomToken(16)
+	if err != nil {
+		return errors.New("failed to generate event ID")
+	}
+
+	_, err = p.jobs.Enqueue(ctx, domain.PublishEventArgs{
+		Topic: topic,
+		Event: &domain.CloudEvent{
+			SpecVersion:     domain.CloudEventsSpecVersion,
+			ID:              id,
+			Source:          p.source,
+			Type:            domain.CloudEventTypePrefix + event.EventName(),
+			Subject:         subject,
+			Time:            time.Now().UTC(),
+			DataContentType: "application/json",
+			Data:            encoded,
+		},
+	}, nil)
+	return err
+}
+
+// publish sends a queued event to the broker
+func (p *eventPublisher) publish(ctx context.Context, job *domain.Job, args domain.PublishEventArgs) error {
+	if args.Event == nil || args.Topic == "" {
+		return fmt.Errorf("%w: event missing", domain.ErrPermanentJobFailure)
+	}
+	return p.publisher.Publish(ctx, args.Topic, args.Event)
+}
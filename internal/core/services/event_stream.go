@@ -0,0 +1,107 @@
+package services
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// streamBufferSize is how many events a slow subscriber may fall behind
+// before further events are dropped for it
+const streamBufferSize = 32
+
+// streamSubscriber is one open event stream
+type streamSubscriber struct {
+	events []string
+	ch     chan *domain.StreamEvent
+}
+
+// eventStream implements the EventStream interface with in-memory fan-out
+type eventStream struct {
+	mu          sync.RWMutex
+	subscribers map[uint]map[*streamSubscriber]struct{}
+	nextID      atomic.Uint64
+}
+
+// NewEventStream creates a new event stream instance
+func NewEventStream() ports.EventStream {
+	return &eventStream{
+		subscribers: make(map[uint]map[*streamSubscriber]struct{}),
+	}
+}
+
+// Subscribe registers a subscriber for the user's events of the given types
+func (s *eventStream) Subscribe(userID uint, events []string) (<-chan *domain.StreamEvent, func()) {
+	sub := &streamSubscriber{
+		events: events,
+		ch:     make(chan *domain.StreamEvent, streamBufferSize),
+	}
+
+	s.mu.Lock()
+	if s.subscribers[userID] == nil {
+		s.subscribers[userID] = make(map[*streamSubscriber]struct{})
+	}
+	s.subscribers[userID][sub] = struct{}{}
+	s.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			s.mu.Lock()
+			delete(s.subscribers[userID], sub)
+			if len(s.subscribers[userID]) == 0 {
+				delete(s.subscribers, userID)
+			}
+			s.mu.Unlock()
+		})
+	}
+
+	return sub.ch, unsubscribe
+}
+
+// Dispatch delivers the event to the user's subscribers without blocking;
+// subscribers whose buffer is full miss the event
+func (s *eventStream) Dispatch(userID uint, event string, data interface{}) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	subs := s.subscribers[userID]
+	if len(subs) == 0 {
+		return
+	}
+
+	streamEvent := &domain.StreamEvent{
+		ID:        s.nextID.Add(1),
+		Event:     event,
+		CreatedAt: time.Now(),
+		Data:      data,
+	}
+	for sub := range subs {
+		if len(sub.events) > 0 && !slices.Contains(sub.events, event) {
+			continue
+		}
+		select {
+		case sub.ch <- streamEvent:
+		default:
+		}
+	}
+}
+
+// multiDispatcher sends every event to several dispatchers
+type multiDispatcher []ports.WebhookDispatcher
+
+// NewMultiDispatcher creates a dispatcher that forwards events to each of the given dispatchers
+func NewMultiDispatcher(dispatchers ...ports.WebhookDispatcher) ports.WebhookDispatcher {
+	return multiDispatcher(dispatchers)
+}
+
+// Dispatch forwards the event to every dispatcher
+func (d multiDispatcher) Dispatch(userID uint, event string, data interface{}) {
+	for _, dispatcher := range d {
+		dispatcher.Dispatch(userID, event, data)
+	}
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/internal/utils"
+)
+
+// genreService implements the GenreService interface
+type genreService struct {
+	genreRepo ports.GenreRepository
+	audit     ports.AuditService
+}
+
+// NewGenreService creates a new genre service instance
+func NewGenreService(genreRepo ports.GenreRepository, audit ports.AuditService) ports.GenreService {
+	return &genreService{
+		genreRepo: genreRepo,
+		audit:     audit,
+	}
+}
+
+// CreateGenre creates a new genre, deriving the slug from the name when omitted
+func (s *genreService) CreateGenre(ctx context.Context, req *domain.GenreRequest) (*domain.Genre, error) {
+	genre := &domain.Genre{
+		Name: strings.TrimSpace(req.Name),
+		Slug: genreSlug(req),
+	}
+
+	if !genre.IsValid() {
+		return nil, errors.New("invalid genre data")
+	}
+
+	if _, err := s.genreRepo.GetBySlug(ctx, genre.Slug); err == nil {
+		return nil, errors.New("genre with this slug already exists")
+	}
+
+	if err := s.genreRepo.Create(ctx, genre); err != nil {
+		return nil, err
+	}
+	recordAudit(ctx, s.audit, "genre.create", genre.ID, nil, genre)
+
+	return genre, nil
+}
+
+// GetGenres retrieves all genres with manga counts
+func (s *genreService) GetGenres(ctx context.Context) ([]*domain.GenreCount, error) {
+	return s.genreRepo.ListWithCounts(ctx)
+}
+
+// UpdateGenre updates an existing genre
+func (s *genreService) UpdateGenre(ctx context.Context, id uint, req *domain.GenreRequest) (*domain.Genre, error) {
+	genre, err := s.genreRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	slug := genreSlug(req)
+	if slug != genre.Slug {
+		if _, err := s.genreRepo.GetBySlug(ctx, slug); err == nil {
+			return nil, errors.New("genre with this slug already exists")
+		}
+	}
+
+	before := auditState(genre)
+	genre.Name = strings.TrimSpace(req.Name)
+	genre.Slug = slug
+
+	if !genre.IsValid() {
+		return nil, errors.New("invalid genre data")
+	}
+
+	if err := s.genreRepo.Update(ctx, genre); err != nil {
+		return nil, err
+	}
+	recordAudit(ctx, s.audit, "genre.update", genre.ID, before, genre)
+
+	return genre, nil
+}
+
+// DeleteGenre deletes a genre
+func (s *genreService) DeleteGenre(ctx context.Context, id uint) error {
+	genre, err := s.genreRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.genreRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	recordAudit(ctx, s.audit, "genre.delete", id, genre, nil)
+	return nil
+}
+
+// genreSlug returns the requested slug, or one derived from the name
+func genreSlug(req *domain.GenreRequest) string {
+	if req.Slug != "" {
+		return utils.Slugify(req.Slug)
+	}
+	return utils.Slugify(req.Name)
+}
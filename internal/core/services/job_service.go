@@ -0,0 +1,522 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"runtime"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/internal/utils"
+)
+
+// errJobPanicked marks the errors of jobs that panicked, which are reported
+// with their stack as they happen
+var errJobPanicked = errors.New("job panicked")
+
+// errJobCancelled is the cause of the context of a job an admin cancelled
+var errJobCancelled = errors.New("job cancelled")
+
+// jobPruneInterval is how often finished jobs past the retention period are deleted
+const jobPruneInterval = time.Hour
+
+// jobCancelCheckInterval is how often a worker checks whether the job it runs
+// was cancelled
+const jobCancelCheckInterval = 5 * time.Second
+
+// jobService implements the JobService interface on the jobs table. Every
+// process registers the same handlers; any of them may run a job, and a job
+// runs at least once, so handlers must be safe to run again. Failed jobs are
+// retried by the retry policy of their kind; dead jobs are kept as the
+// dead-letter queue until an admin requeues or discards them. Scheduled
+// tasks registered with it can be run at once as run_scheduled_task jobs.
+type jobService struct {
+	jobRepo   ports.JobRepository
+	alerts    ports.Alerter
+	reporter  ports.ErrorReporter
+	audit     ports.AuditService
+	timeout   time.Duration
+	retention time.Duration
+
+	mu       sync.RWMutex
+	handlers map[string]ports.JobHandler
+	tasks    map[string]ports.ScheduledTask
+}
+
+// NewJobService creates a new job service instance. A job may run for
+// timeout before it is given up and claimed again; succeeded jobs are kept
+// for the retention period. Operators are alerted of jobs that die, which are
+// reported to the error tracker along with panicking jobs. Admin actions on
+// jobs are recorded in the audit log.
+func NewJobService(jobRepo ports.JobRepository, alerts ports.Alerter, reporter ports.ErrorReporter, audit ports.AuditService, timeout, retention time.Duration) ports.JobService {
+	s := &jobService{
+		jobRepo:   jobRepo,
+		alerts:    alerts,
+		reporter:  reporter,
+		audit:     audit,
+		timeout:   timeout,
+		retention: retention,
+		handlers:  make(map[string]ports.JobHandler),
+		tasks:     make(map[string]ports.ScheduledTask),
+	}
+	HandleJob(s, s.runScheduledTask)
+	return s
+}
+
+// HandleJob registers fn as the handler of the jobs with args of type T,
+// decoding their payload for it
+func HandleJob[T domain.JobArgs](jobs ports.JobService, fn func(ctx context.Context, job *domain.Job, args T) error) {
+	var zero T
+	jobs.Handle(zero.JobKind(), func(ctx context.Context, job *domain.Job) error {
+		var args T
+		if err := job.DecodeArgs(&args); err != nil {
+			return err
+		}
+		return fn(ctx, job, args)
+	})
+}
+
+// Enqueue stores a job to run at its run time, right away by default
+func (s *jobService) Enqueue(ctx context.Context, args domain.JobArgs, opts *domain.JobOptions) (*domain.Job, error) {
+	payload, err := json.Marshal(args)
+	if err != nil {
+		return nil, errors.New("failed to encode job")
+	}
+
+	job := &domain.Job{
+		Kind:        args.JobKind(),
+		Payload:     string(payload),
+		Status:      domain.JobStatusPending,
+		MaxAttempts: domain.RetryPolicyFor(args.JobKind()).MaxAttempts,
+		RunAt:       time.Now(),
+	}
+	if opts != nil {
+		job.UserID = opts.UserID
+		if !opts.RunAt.IsZero() {
+			job.RunAt = opts.RunAt
+		}
+		if opts.MaxAttempts > 0 {
+			job.MaxAttempts = opts.MaxAttempts
+		}
+	}
+
+	if err := s.jobRepo.Create(ctx, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// Handle sets the handler of a job kind
+func (s *jobService) Handle(kind string, handler ports.JobHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[kind] = handler
+}
+
+// kinds lists the job kinds with a handler
+func (s *jobService) kinds() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	kinds := make([]string, 0, len(s.handlers))
+	for kind := range s.handlers {
+		kinds = append(kinds, kind)
+	}
+	slices.Sort(kinds)
+	return kinds
+}
+
+// Work runs due jobs until none are left
+func (s *jobService) Work(ctx context.Context) (int, error) {
+	ran := 0
+	for {
+		found, err := s.runNext(ctx)
+		if err != nil || !found {
+			return ran, err
+		}
+		ran++
+	}
+}
+
+// StartWorkers runs jobs on concurrency workers in the background. Idle
+// workers look for due jobs at the given interval.
+func (s *jobService) StartWorkers(concurrency int, pollInterval time.Duration) {
+	for range concurrency {
+		go func() {
+			ctx := context.Background()
+			for {
+				found, err := s.runNext(ctx)
+				if err != nil {
+					log.Printf("job worker: %v", err)
+				}
+				if !found {
+					time.Sleep(pollInterval)
+				}
+			}
+		}()
+	}
+
+	if s.retention <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(jobPruneInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if _, err := s.jobRepo.DeleteFinishedBefore(context.Background(), time.Now().Add(-s.retention)); err != nil {
+				log.Printf("job prune failed: %v", err)
+			}
+		}
+	}()
+}
+
+// runNext claims the next due job and runs it, reporting whether there was one
+func (s *jobService) runNext(ctx context.Context) (bool, error) {
+	kinds := s.kinds()
+	if len(kinds) == 0 {
+		return false, nil
+	}
+
+	jobs, err := s.jobRepo.Claim(ctx, kinds, 1, time.Now().Add(s.timeout))
+	if err != nil || len(jobs) == 0 {
+		return false, err
+	}
+	s.run(ctx, jobs[0])
+	return true, nil
+}
+
+// run runs a claimed job and records the outcome: failed jobs run again after
+// the backoff of their retry policy until they are out of attempts, and every
+// failure is added to the job's error log. A job cancelled while it runs is
+// stopped, and keeps its cancelled status.
+func (s *jobService) run(ctx context.Context, job *domain.Job) {
+	ctx = utils.WithBreadcrumbs(ctx)
+	var err error
+	if job.Attempts > job.MaxAttempts {
+		// The worker making the last attempt stopped before it finished
+		err = errors.New("the last attempt did not finish")
+	} else {
+		runCtx, cancel := context.WithCancelCause(ctx)
+		go s.watchCancellation(runCtx, job.ID, cancel)
+		err = s.call(runCtx, job)
+		cancel(nil)
+		if errors.Is(context.Cause(runCtx), errJobCancelled) {
+			log.Printf("job %d (%s) was cancelled", job.ID, job.Kind)
+			return
+		}
+	}
+
+	now := time.Now()
+	job.LockedUntil = nil
+	switch {
+	case err == nil:
+		job.Status = domain.JobStatusSucceeded
+		job.Progress = 100
+		job.LastError = ""
+		job.FinishedAt = &now
+	case errors.Is(err, domain.ErrPermanentJobFailure) || job.Attempts >= job.MaxAttempts:
+		job.Status = domain.JobStatusDead
+		job.LastError = err.Error()
+		job.FinishedAt = &now
+		log.Printf("job %d (%s) is dead after %d attempts: %v", job.ID, job.Kind, job.Attempts, err)
+		s.alerts.Alert(&domain.Alert{
+			Key:      "job_dead:" + job.Kind,
+			Severity: domain.AlertCritical,
+			Title:    "Background job moved to the dead-letter queue",
+			Message:  err.Error(),
+			Fields: []domain.AlertField{
+				{Name: "Job", Value: strconv.FormatUint(uint64(job.ID), 10)},
+				{Name: "Kind", Value: job.Kind},
+				{Name: "Attempts", Value: strconv.Itoa(job.Attempts)},
+			},
+		})
+		if !errors.Is(err, errJobPanicked) {
+			s.reporter.Capture(ctx, &domain.ErrorReport{
+				Err:   err,
+				Level: domain.ErrorLevelError,
+				Tags:  jobTags(job),
+				Time:  now,
+			})
+		}
+	default:
+		job.Status = domain.JobStatusPending
+		job.LastError = err.Error()
+		job.RunAt = now.Add(domain.RetryPolicyFor(job.Kind).Delay(job.Attempts))
+	}
+
+	if err != nil {
+		jobErr := &domain.JobError{JobID: job.ID, Attempt: job.Attempts, Error: err.Error()}
+		if err := s.jobRepo.CreateError(ctx, jobErr); err != nil {
+			log.Printf("failed to record the error of job %d: %v", job.ID, err)
+		}
+	}
+
+	ok, err := s.jobRepo.UpdateRunning(ctx, job)
+	if err != nil {
+		log.Printf("failed to record the outcome of job %d: %v", job.ID, err)
+	} else if !ok {
+		log.Printf("job %d (%s) was cancelled as it finished", job.ID, job.Kind)
+	}
+}
+
+// watchCancellation cancels the context of a running job once an admin has
+// cancelled the job, until the job returns
+func (s *jobService) watchCancellation(ctx context.Context, id uint, cancel context.CancelCauseFunc) {
+	ticker := time.NewTicker(jobCancelCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			status, err := s.jobRepo.GetStatus(ctx, id)
+			if err == nil && status == domain.JobStatusCancelled {
+				cancel(errJobCancelled)
+				return
+			}
+		}
+	}
+}
+
+// ReportProgress records that a running job has done done of total units of
+// work. Progress stays below 100 until the job has succeeded, and is only
+// written when the percentage changes.
+func (s *jobService) ReportProgress(ctx context.Context, job *domain.Job, done, total int64) error {
+	if total <= 0 {
+		return nil
+	}
+	progress := int(min(done*100/total, 99))
+	if progress <= job.Progress {
+		return nil
+	}
+	if err := s.jobRepo.UpdateProgress(ctx, job.ID, progress); err != nil {
+		return err
+	}
+	job.Progress = progress
+	return nil
+}
+
+// call runs the job's handler within the job timeout, turning a panic into an error
+func (s *jobService) call(ctx context.Context, job *domain.Job) (err error) {
+	s.mu.RLock()
+	handler := s.handlers[job.Kind]
+	s.mu.RUnlock()
+	if handler == nil {
+		return fmt.Errorf("no handler for job kind %q", job.Kind)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	defer func() {
+		if r := recover(); r != nil {
+			pcs := make([]uintptr, 64)
+			n := runtime.Callers(2, pcs)
+			s.reporter.Capture(ctx, &domain.ErrorReport{
+				Err:   fmt.Errorf("%v", r),
+				Level: domain.ErrorLevelFatal,
+				Panic: true,
+				Stack: pcs[:n],
+				Tags:  jobTags(job),
+				Time:  time.Now(),
+			})
+			err = fmt.Errorf("%w: %v", errJobPanicked, r)
+		}
+	}()
+	return handler(ctx, job)
+}
+
+// jobTags label the error reports of a job
+func jobTags(job *domain.Job) map[string]string {
+	return map[string]string{
+		"job_id":   strconv.FormatUint(uint64(job.ID), 10),
+		"job_kind": job.Kind,
+	}
+}
+
+// GetJobs retrieves jobs, newest first, optionally of some statuses and one kind only
+func (s *jobService) GetJobs(ctx context.Context, statuses []string, kind string, pagination *domain.PaginationRequest) (*domain.PaginatedResult[*domain.Job], error) {
+	for _, status := range statuses {
+		if !slices.Contains(domain.JobStatuses, status) {
+			return nil, errors.New("invalid job status")
+		}
+	}
+
+	jobs, total, err := s.jobRepo.ListPaginated(ctx, statuses, kind, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.PaginatedResult[*domain.Job]{
+		Data:       jobs,
+		Pagination: domain.NewPaginationResponse(pagination.Page, pagination.PageSize, total),
+	}, nil
+}
+
+// GetJob retrieves a job by ID with its error log
+func (s *jobService) GetJob(ctx context.Context, id uint) (*domain.Job, error) {
+	return s.jobRepo.GetWithErrorLog(ctx, id)
+}
+
+// GetUserJob retrieves a job run for the user; other jobs are reported as not found
+func (s *jobService) GetUserJob(ctx context.Context, id uint, userID uint) (*domain.Job, error) {
+	job, err := s.jobRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if job.UserID == nil || *job.UserID != userID {
+		return nil, errors.New("job not found")
+	}
+	return job, nil
+}
+
+// RetryJob runs a dead job again right away
+func (s *jobService) RetryJob(ctx context.Context, id uint) (*domain.Job, error) {
+	job, err := s.jobRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status != domain.JobStatusDead {
+		return nil, errors.New("only dead jobs can be retried")
+	}
+	before := auditState(job)
+
+	job.Status = domain.JobStatusPending
+	job.Attempts = 0
+	job.RunAt = time.Now()
+	job.FinishedAt = nil
+	if err := s.jobRepo.Update(ctx, job); err != nil {
+		return nil, err
+	}
+	recordAudit(ctx, s.audit, "job.retry", job.ID, before, job)
+	return job, nil
+}
+
+// DiscardJob deletes a dead job with its error log
+func (s *jobService) DiscardJob(ctx context.Context, id uint) error {
+	job, err := s.jobRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.jobRepo.DeleteDead(ctx, id); err != nil {
+		return err
+	}
+	recordAudit(ctx, s.audit, "job.discard", id, job, nil)
+	return nil
+}
+
+// CancelJob stops a pending job from running, or a running one through the
+// context of its handler. A running job may still finish the step it is on.
+func (s *jobService) CancelJob(ctx context.Context, id uint) (*domain.Job, error) {
+	before, err := s.jobRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.jobRepo.Cancel(ctx, id); err != nil {
+		return nil, err
+	}
+	job, err := s.jobRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	recordAudit(ctx, s.audit, "job.cancel", job.ID, before, job)
+	return job, nil
+}
+
+// RetryDeadJobs runs the dead jobs, of one kind or all, again right away
+func (s *jobService) RetryDeadJobs(ctx context.Context, kind string) (int64, error) {
+	count, err := s.jobRepo.RequeueDead(ctx, kind)
+	if err != nil {
+		return 0, err
+	}
+	if count > 0 {
+		recordAudit(ctx, s.audit, "job.retry_dead", 0, nil, deadJobsAuditState(kind, count))
+	}
+	return count, nil
+}
+
+// DiscardDeadJobs deletes the dead jobs, of one kind or all, with their error logs
+func (s *jobService) DiscardDeadJobs(ctx context.Context, kind string) (int64, error) {
+	count, err := s.jobRepo.DeleteAllDead(ctx, kind)
+	if err != nil {
+		return 0, err
+	}
+	if count > 0 {
+		recordAudit(ctx, s.audit, "job.discard_dead", 0, nil, deadJobsAuditState(kind, count))
+	}
+	return count, nil
+}
+
+// deadJobsAuditState describes an action on every dead job of a kind, or of
+// all kinds when kind is empty
+func deadJobsAuditState(kind string, count int64) map[string]interface{} {
+	state := map[string]interface{}{"count": count}
+	if kind != "" {
+		state["kind"] = kind
+	}
+	return state
+}
+
+// RetryPolicies returns the retry policy of every job kind with a handler
+func (s *jobService) RetryPolicies() map[string]domain.RetryPolicy {
+	policies := make(map[string]domain.RetryPolicy)
+	for _, kind := range s.kinds() {
+		policies[kind] = domain.RetryPolicyFor(kind)
+	}
+	return policies
+}
+
+// HandleScheduledTask registers the work a scheduler does at each interval
+func (s *jobService) HandleScheduledTask(name string, task ports.ScheduledTask) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tasks[name] = task
+}
+
+// ScheduledTasks lists the names of the registered scheduled tasks
+func (s *jobService) ScheduledTasks() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	names := make([]string, 0, len(s.tasks))
+	for name := range s.tasks {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// RunScheduledTask queues a run of a scheduled task, so it runs on a worker
+// with the job's retries and error log, whether or not its scheduler is on
+func (s *jobService) RunScheduledTask(ctx context.Context, name string) (*domain.Job, error) {
+	s.mu.RLock()
+	_, ok := s.tasks[name]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, errors.New("scheduled task not found")
+	}
+
+	job, err := s.Enqueue(ctx, domain.RunScheduledTaskArgs{Task: name}, nil)
+	if err != nil {
+		return nil, err
+	}
+	recordAudit(ctx, s.audit, "job.run_scheduled_task", job.ID, nil, job)
+	return job, nil
+}
+
+// runScheduledTask runs a scheduled task outside its schedule
+func (s *jobService) runScheduledTask(ctx context.Context, job *domain.Job, args domain.RunScheduledTaskArgs) error {
+	s.mu.RLock()
+	task := s.tasks[args.Task]
+	s.mu.RUnlock()
+	if task == nil {
+		return fmt.Errorf("%w: no scheduled task %q", domain.ErrPermanentJobFailure, args.Task)
+	}
+	return task(ctx)
+}
@@ -0,0 +1,265 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/internal/utils"
+)
+
+// Keys of the texts sent on LINE
+const (
+	lineTextLinkPrompt    = "link_prompt"
+	lineTextLinked        = "linked"
+	lineTextOrderPaid     = "order_paid"
+	lineTextOrderShipped  = "order_shipped"
+	lineTextOrderDone     = "order_delivered"
+	lineTextMangaApproved = "manga_approved"
+	lineTextMangaRejected = "manga_rejected"
+	lineTextCommentGone   = "comment_removed"
+	lineTextPriceDrop     = "price_drop"
+)
+
+// lineTexts are the texts sent on LINE by locale; users with another locale
+// get them in English
+var lineTexts = map[string]map[string]string{
+	"en": {
+		lineTextLinkPrompt:    "Link your account to get order and moderation updates here: %s",
+		lineTextLinked:        "Your account is linked. You'll get your updates here.",
+		lineTextOrderPaid:     "Payment for your order #%d is confirmed.",
+		lineTextOrderShipped:  "Your order #%d has shipped.",
+		lineTextOrderDone:     "Your order #%d was delivered.",
+		lineTextMangaApproved: "\"%s\" was approved and is now published.",
+		lineTextMangaRejected: "\"%s\" was not approved: %s",
+		lineTextCommentGone:   "A moderator removed your comment on \"%s\".",
+		lineTextPriceDrop:     "\"%s\" on your wishlist is now %.2f (was %.2f).",
+	},
+	"th": {
+		lineTextLinkPrompt:    "เชื่อมบัญชีของคุณเพื่อรับการแจ้งเตือนคำสั่งซื้อและการตรวจสอบเนื้อหาที่นี่: %s",
+		lineTextLinked:        "เชื่อมบัญชีเรียบร้อยแล้ว คุณจะได้รับการแจ้งเตือนที่นี่",
+		lineTextOrderPaid:     "ยืนยันการชำระเงินสำหรับคำสั่งซื้อ #%d แล้ว",
+		lineTextOrderShipped:  "คำสั่งซื้อ #%d ของคุณถูกจัดส่งแล้ว",
+		lineTextOrderDone:     "คำสั่งซื้อ #%d ของคุณจัดส่งถึงแล้ว",
+		lineTextMangaApproved: "\"%s\" ได้รับการอนุมัติและเผยแพร่แล้ว",
+		lineTextMangaRejected: "\"%s\" ไม่ได้รับการอนุมัติ: %s",
+		lineTextCommentGone:   "ผู้ดูแลได้ลบความคิดเห็นของคุณใน \"%s\"",
+		lineTextPriceDrop:     "\"%s\" ในรายการที่อยากได้ของคุณลดราคาเหลือ %.2f (จาก %.2f)",
+	},
+}
+
+// lineText formats the text with the key in the locale
+func lineText(locale, key string, args ...interface{}) string {
+	texts, ok := lineTexts[locale]
+	if !ok {
+		texts = lineTexts["en"]
+	}
+	return fmt.Sprintf(texts[key], args...)
+}
+
+// lineService implements the LineService interface
+type lineService struct {
+	lineRepo  ports.LineAccountRepository
+	userRepo  ports.UserRepository
+	jobs      ports.JobQueue
+	messenger ports.LineMessenger
+	linkURL   string
+}
+
+// NewLineService creates a new LINE service instance, and registers the
+// handler of the push_line_message jobs notifications are pushed from. LINE
+// is off while the messenger is nil. LINE users who aren't linked yet are
+// sent to linkURL, the page of the frontend that signs them in and calls
+// StartLink with the link token.
+func NewLineService(lineRepo ports.LineAccountRepository, userRepo ports.UserRepository, jobs ports.JobService, messenger ports.LineMessenger, linkURL string) ports.LineService {
+	s := &lineService{
+		lineRepo:  lineRepo,
+		userRepo:  userRepo,
+		jobs:      jobs,
+		messenger: messenger,
+		linkURL:   linkURL,
+	}
+	if messenger != nil {
+		HandleJob(jobs, s.pushMessage)
+	}
+	return s
+}
+
+// Deliver pushes the notification to the user's LINE account, if LINE is on
+// and they linked one
+func (s *lineService) Deliver(ctx context.Context, n *domain.Notification) error {
+	if s.messenger == nil {
+		return nil
+	}
+
+	switch n.Event {
+	case domain.EventOrderPaid:
+		return s.notify(ctx, n.UserID, lineTextOrderPaid, n.Order.ID)
+	case domain.EventOrderShipped:
+		return s.notify(ctx, n.UserID, lineTextOrderShipped, n.Order.ID)
+	case domain.EventOrderDelivered:
+		return s.notify(ctx, n.UserID, lineTextOrderDone, n.Order.ID)
+	case domain.EventMangaReviewed:
+		if n.Manga.Status == domain.MangaStatusRejected {
+			return s.notify(ctx, n.UserID, lineTextMangaRejected, n.Manga.Name, n.Manga.RejectionReason)
+		}
+		return s.notify(ctx, n.UserID, lineTextMangaApproved, n.Manga.Name)
+	case domain.EventCommentRemoved:
+		return s.notify(ctx, n.UserID, lineTextCommentGone, n.Manga.Name)
+	case domain.EventWishlistPriceDropped:
+		return s.notify(ctx, n.UserID, lineTextPriceDrop, n.PriceDrop.Manga.Name, n.PriceDrop.NewPrice, n.PriceDrop.OldPrice)
+	}
+	return nil
+}
+
+// GetAccount retrieves the user's LINE account link
+func (s *lineService) GetAccount(ctx context.Context, userID uint) (*domain.LineAccount, error) {
+	return s.lineRepo.GetByUserID(ctx, userID)
+}
+
+// StartLink stores a nonce for the user and returns the LINE page that
+// confirms the link. LINE sends the nonce back in an accountLink event,
+// which finishes the link; a link started before is replaced.
+func (s *lineService) StartLink(ctx context.Context, userID uint, req *domain.StartLineLinkRequest) (*domain.StartLineLinkResponse, error) {
+	if s.messenger == nil {
+		return nil, domain.ErrLineDisabled
+	}
+
+	account, err := s.lineRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if !strings.HasSuffix(err.Error(), "not found") {
+			return nil, err
+		}
+		account = &domain.LineAccount{UserID: userID}
+	}
+
+	nonce, err := utils.GenerateRandomToken(16)
+	if err != nil {
+		return nil, errors.New("failed to generate nonce")
+	}
+	expiresAt := time.Now().Add(domain.LineLinkTTL)
+	account.Nonce = nonce
+	account.NonceExpiresAt = &expiresAt
+	if err := s.lineRepo.Save(ctx, account); err != nil {
+		return nil, err
+	}
+
+	return &domain.StartLineLinkResponse{
+		RedirectURL: s.messenger.LinkURL(req.LinkToken, nonce),
+		ExpiresAt:   expiresAt,
+	}, nil
+}
+
+// Unlink removes the user's LINE account link
+func (s *lineService) Unlink(ctx context.Context, userID uint) error {
+	return s.lineRepo.Delete(ctx, userID)
+}
+
+// HandleWebhook handles the events of a webhook request. LINE users who add
+// the official account, or message it before linking, are sent a link to
+// link their account; users who block it are unlinked. Failing events are
+// logged rather than failing the request, since LINE doesn't resend them.
+func (s *lineService) HandleWebhook(ctx context.Context, body []byte, signature string) error {
+	if s.messenger == nil {
+		return domain.ErrLineDisabled
+	}
+
+	events, err := s.messenger.ParseWebhook(body, signature)
+	if err != nil {
+		return err
+	}
+	for _, event := range events {
+		if err := s.handleEvent(ctx, event); err != nil {
+			utils.Logf(ctx, "Failed to handle LINE %s event: %v", event.Type, err)
+		}
+	}
+	return nil
+}
+
+// handleEvent handles one event of a webhook request
+func (s *lineService) handleEvent(ctx context.Context, event domain.LineEvent) error {
+	switch event.Type {
+	case domain.LineEventFollow:
+		return s.sendLinkPrompt(ctx, event)
+	case domain.LineEventMessage:
+		if _, err := s.lineRepo.GetByLineUserID(ctx, event.UserID); err == nil || !strings.HasSuffix(err.Error(), "not found") {
+			return err
+		}
+		return s.sendLinkPrompt(ctx, event)
+	case domain.LineEventUnfollow:
+		return s.lineRepo.DeleteByLineUserID(ctx, event.UserID)
+	case domain.LineEventAccountLink:
+		if event.LinkResult != "ok" {
+			return nil
+		}
+		account, err := s.lineRepo.Link(ctx, event.LinkNonce, event.UserID)
+		if err != nil {
+			return err
+		}
+		return s.notify(ctx, account.UserID, lineTextLinked)
+	default:
+		return nil
+	}
+}
+
+// sendLinkPrompt replies to the event of a LINE user who isn't linked with
+// a link to the page that links their account, in Thai and English since we
+// don't know their locale yet
+func (s *lineService) sendLinkPrompt(ctx context.Context, event domain.LineEvent) error {
+	linkToken, err := s.messenger.IssueLinkToken(ctx, event.UserID)
+	if err != nil {
+		return err
+	}
+	link := s.linkURL + "?" + url.Values{"linkToken": {linkToken}}.Encode()
+	text := lineText("th", lineTextLinkPrompt, link) + "\n\n" + lineText("en", lineTextLinkPrompt, link)
+	return s.messenger.Reply(ctx, event.ReplyToken, text)
+}
+
+// notify queues the text with the key, in the user's locale, to be pushed
+// to their LINE account; users who aren't linked are skipped
+func (s *lineService) notify(ctx context.Context, userID uint, key string, args ...interface{}) error {
+	account, err := s.lineRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if strings.HasSuffix(err.Error(), "not found") {
+			return nil
+		}
+		return err
+	}
+	if !account.IsLinked() {
+		return nil
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	_, err = s.jobs.Enqueue(ctx, domain.PushLineMessageArgs{UserID: userID, Text: lineText(user.Locale, key, args...)}, nil)
+	return err
+}
+
+// pushMessage handles push_line_message jobs. The link is looked up again,
+// since the user may have unlinked since the job was queued; messages LINE
+// rejects, as to users who blocked the official account, are not retried.
+func (s *lineService) pushMessage(ctx context.Context, job *domain.Job, args domain.PushLineMessageArgs) error {
+	account, err := s.lineRepo.GetByUserID(ctx, args.UserID)
+	if err != nil {
+		if strings.HasSuffix(err.Error(), "not found") {
+			return nil
+		}
+		return err
+	}
+	if !account.IsLinked() {
+		return nil
+	}
+
+	err = s.messenger.Push(ctx, *account.LineUserID, args.Text)
+	if errors.Is(err, domain.ErrLineRejected) {
+		return fmt.Errorf("%w: %v", domain.ErrPermanentJobFailure, err)
+	}
+	return err
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/internal/utils"
+)
+
+// malwareScanService implements the MalwareScanService interface. Flagged
+// files are kept in the quarantine storage, which is never served, under
+// domain.MalwareQuarantineKey.
+type malwareScanService struct {
+	scanner    ports.MalwareScanner
+	storage    ports.FileStorage
+	quarantine ports.FileStorage
+	userRepo   ports.UserRepository
+	renderer   ports.EmailRenderer
+	sender     ports.EmailSender
+}
+
+// NewMalwareScanService creates a new malware scan service instance for the
+// files in storage. A nil scanner turns scanning off: every file passes.
+func NewMalwareScanService(scanner ports.MalwareScanner, storage, quarantine ports.FileStorage, userRepo ports.UserRepository, renderer ports.EmailRenderer, sender ports.EmailSender) ports.MalwareScanService {
+	return &malwareScanService{
+		scanner:    scanner,
+		storage:    storage,
+		quarantine: quarantine,
+		userRepo:   userRepo,
+		renderer:   renderer,
+		sender:     sender,
+	}
+}
+
+// Scan scans a file before it is stored; a flagged file is stored in
+// quarantine only. A clean file is left at its start.
+func (s *malwareScanService) Scan(ctx context.Context, file io.ReadSeeker, report *domain.MalwareReport) error {
+	if s.scanner == nil {
+		return nil
+	}
+
+	result, err := s.scanner.Scan(ctx, file)
+	if _, seekErr := file.Seek(0, io.SeekStart); seekErr != nil && err == nil {
+		err = errors.New("failed to read file")
+	}
+	if err != nil {
+		return err
+	}
+	if !result.Infected {
+		return nil
+	}
+
+	report.Signature = result.Signature
+	report.QuarantineKey = domain.MalwareQuarantineKey(report.StorageKey)
+	if _, err := s.quarantine.Save(report.QuarantineKey, file); err != nil {
+		utils.Logf(ctx, "Failed to quarantine flagged file %s: %v", report.StorageKey, err)
+		report.QuarantineKey = ""
+	}
+	s.notifyAdmins(ctx, report)
+	return fmt.Errorf("%w: %s", domain.ErrMalwareDetected, result.Signature)
+}
+
+// ScanStored scans a stored file; a flagged file is moved to quarantine, so
+// it is no longer served
+func (s *malwareScanService) ScanStored(ctx context.Context, report *domain.MalwareReport) error {
+	if s.scanner == nil {
+		return nil
+	}
+
+	file, err := s.storage.Open(report.StorageKey)
+	if err != nil {
+		return err
+	}
+	result, err := s.scanner.Scan(ctx, file)
+	file.Close()
+	if err != nil {
+		return err
+	}
+	if !result.Infected {
+		return nil
+	}
+
+	report.Signature = result.Signature
+	if err := s.moveToQuarantine(report); err != nil {
+		// Deleting the file matters more than keeping it for inspection
+		utils.Logf(ctx, "Failed to quarantine flagged file %s: %v", report.StorageKey, err)
+		if err := s.storage.Delete(report.StorageKey); err != nil {
+			utils.Logf(ctx, "Failed to delete flagged file %s: %v", report.StorageKey, err)
+		}
+	}
+	s.notifyAdmins(ctx, report)
+	return fmt.Errorf("%w: %s", domain.ErrMalwareDetected, result.Signature)
+}
+
+// moveToQuarantine copies a stored file to quarantine and deletes it from storage
+func (s *malwareScanService) moveToQuarantine(report *domain.MalwareReport) error {
+	file, err := s.storage.Open(report.StorageKey)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	quarantineKey := domain.MalwareQuarantineKey(report.StorageKey)
+	if _, err := s.quarantine.Save(quarantineKey, file); err != nil {
+		return err
+	}
+	report.QuarantineKey = quarantineKey
+	return s.storage.Delete(report.StorageKey)
+}
+
+// notifyAdmins emails every admin about a flagged file, in their locale
+func (s *malwareScanService) notifyAdmins(ctx context.Context, report *domain.MalwareReport) {
+	utils.Logf(ctx, "Malware %q found in a file uploaded by user %d to %s", report.Signature, report.UploaderID, report.StorageKey)
+
+	admins, err := s.userRepo.ListAdmins(ctx)
+	if err != nil {
+		utils.Logf(ctx, "Failed to load admins to alert of malware: %v", err)
+		return
+	}
+	for _, admin := range admins {
+		msg, err := s.renderer.Render(domain.EmailMalwareDetected, admin.Locale, &domain.EmailData{User: admin, Malware: report})
+		if err != nil {
+			utils.Logf(ctx, "Failed to render malware alert: %v", err)
+			return
+		}
+		msg.To = admin.Email
+		if err := s.sender.Send(ctx, msg); err != nil {
+			utils.Logf(ctx, "Failed to send malware alert to user %d: %v", admin.ID, err)
+		}
+	}
+}
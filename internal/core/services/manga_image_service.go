@@ -0,0 +1,398 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/internal/utils"
+)
+
+// mangaImageService implements the MangaImageService interface
+type mangaImageService struct {
+	imageRepo ports.MangaImageRepository
+	mangaRepo ports.MangaRepository
+	teamRepo  ports.TeamRepository
+	storage   ports.FileStorage
+	scans     ports.MalwareScanService
+	jobs      ports.JobService
+	encoders  []ports.ImageEncoder
+	audit     ports.AuditService
+}
+
+// NewMangaImageService creates a new manga image service instance. Images are
+// processed by process_image jobs, whose handler it registers with jobs; their
+// variants come in JPEG and in the format of each of the encoders. Uploaded
+// files are screened by scans before they are stored.
+func NewMangaImageService(imageRepo ports.MangaImageRepository, mangaRepo ports.MangaRepository, teamRepo ports.TeamRepository, storage ports.FileStorage, scans ports.MalwareScanService, jobs ports.JobService, encoders []ports.ImageEncoder, audit ports.AuditService) ports.MangaImageService {
+	s := &mangaImageService{
+		imageRepo: imageRepo,
+		mangaRepo: mangaRepo,
+		teamRepo:  teamRepo,
+		storage:   storage,
+		scans:     scans,
+		jobs:      jobs,
+		encoders:  encoders,
+		audit:     audit,
+	}
+	HandleJob(jobs, s.processImage)
+	HandleJob(jobs, func(ctx context.Context, job *domain.Job, args domain.MakeThumbnailArgs) error {
+		return s.processImage(ctx, job, domain.ProcessImageArgs{ImageID: args.ImageID})
+	})
+	return s
+}
+
+// getManagedManga loads a manga and checks the user may manage its gallery
+func (s *mangaImageService) getManagedManga(ctx context.Context, mangaID uint, userID uint) (*domain.Manga, error) {
+	manga, err := s.mangaRepo.GetByID(ctx, mangaID)
+	if err != nil {
+		return nil, err
+	}
+	if !canManageManga(ctx, s.teamRepo, manga, userID) {
+		return nil, errors.New("access denied: you can only manage images of your own manga")
+	}
+	return manga, nil
+}
+
+// getMangaImage loads an image and checks it belongs to the manga
+func (s *mangaImageService) getMangaImage(ctx context.Context, mangaID, imageID uint) (*domain.MangaImage, error) {
+	image, err := s.imageRepo.GetByID(ctx, imageID)
+	if err != nil {
+		return nil, err
+	}
+	if image.MangaID != mangaID {
+		return nil, errors.New("manga image not found")
+	}
+	return image, nil
+}
+
+// GetImages retrieves a manga's gallery in order
+func (s *mangaImageService) GetImages(ctx context.Context, mangaID uint) ([]*domain.MangaImage, error) {
+	if _, err := s.mangaRepo.GetByID(ctx, mangaID); err != nil {
+		return nil, err
+	}
+
+	return s.imageRepo.ListByMangaID(ctx, mangaID)
+}
+
+// UploadImage stores an image, adds it to the end of the gallery and queues its
+// processing. Until its variants are made, its thumbnail URL is the image's own.
+func (s *mangaImageService) UploadImage(ctx context.Context, mangaID uint, req *domain.UploadMangaImageRequest, userID uint) (*domain.MangaImage, error) {
+	if _, err := s.getManagedManga(ctx, mangaID, userID); err != nil {
+		return nil, err
+	}
+	if err := s.checkGallerySpace(ctx, mangaID); err != nil {
+		return nil, err
+	}
+
+	ext, err := checkImage(req.File)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := req.File.Seek(0, io.SeekStart); err != nil {
+		return nil, errors.New("failed to read image file")
+	}
+
+	name, err := utils.GenerateRandomToken(16)
+	if err != nil {
+		return nil, errors.New("failed to name image")
+	}
+
+	image := &domain.MangaImage{
+		MangaID:    mangaID,
+		Caption:    req.Caption,
+		StorageKey: fmt.Sprintf("mangas/%d/%s.%s", mangaID, name, ext),
+	}
+	image.ThumbnailKey = image.VariantKey(domain.ImageSizeMedium, domain.ImageFormatJPEG)
+
+	report := &domain.MalwareReport{UploaderID: userID, MangaID: mangaID, StorageKey: image.StorageKey}
+	if err := s.scans.Scan(ctx, req.File, report); err != nil {
+		return nil, err
+	}
+
+	if image.URL, err = s.storage.Save(image.StorageKey, req.File); err != nil {
+		return nil, err
+	}
+	image.ThumbnailURL = image.URL
+
+	if err := s.addImage(ctx, image); err != nil {
+		s.removeFiles(image)
+		return nil, err
+	}
+	return image, nil
+}
+
+// AddStoredImage adds an image a client uploaded straight to storage to the
+// end of the gallery, once it passes the checks UploadImage makes. Its
+// content must match the file extension of its key.
+func (s *mangaImageService) AddStoredImage(ctx context.Context, mangaID uint, req *domain.AddStoredMangaImageRequest, userID uint) (*domain.MangaImage, error) {
+	if _, err := s.getManagedManga(ctx, mangaID, userID); err != nil {
+		return nil, err
+	}
+	if err := s.checkGallerySpace(ctx, mangaID); err != nil {
+		return nil, err
+	}
+
+	file, err := s.storage.Open(req.StorageKey)
+	if err != nil {
+		if strings.HasSuffix(err.Error(), "not found") {
+			return nil, errors.New("the image has not been uploaded")
+		}
+		return nil, err
+	}
+	ext, err := checkImage(file)
+	file.Close()
+	if err != nil {
+		return nil, err
+	}
+	if "."+ext != path.Ext(req.StorageKey) {
+		return nil, errors.New("image content does not match its content type")
+	}
+
+	image := &domain.MangaImage{
+		MangaID:      mangaID,
+		Caption:      req.Caption,
+		URL:          req.URL,
+		ThumbnailURL: req.URL,
+		StorageKey:   req.StorageKey,
+	}
+	image.ThumbnailKey = image.VariantKey(domain.ImageSizeMedium, domain.ImageFormatJPEG)
+	if err := s.addImage(ctx, image); err != nil {
+		return nil, err
+	}
+	return image, nil
+}
+
+// checkGallerySpace fails when the manga's gallery is full
+func (s *mangaImageService) checkGallerySpace(ctx context.Context, mangaID uint) error {
+	count, err := s.imageRepo.CountByMangaID(ctx, mangaID)
+	if err != nil {
+		return err
+	}
+	if count >= domain.MaxMangaImages {
+		return fmt.Errorf("a manga can have at most %d images", domain.MaxMangaImages)
+	}
+	return nil
+}
+
+// addImage records a stored image at the end of the gallery and queues its processing
+func (s *mangaImageService) addImage(ctx context.Context, image *domain.MangaImage) error {
+	if err := s.imageRepo.Create(ctx, image); err != nil {
+		return err
+	}
+	recordAudit(ctx, s.audit, "manga_image.create", image.ID, nil, image)
+
+	if _, err := s.jobs.Enqueue(ctx, domain.ProcessImageArgs{ImageID: image.ID}, nil); err != nil {
+		log.Printf("Failed to queue the processing of manga image %d: %v", image.ID, err)
+	}
+	return nil
+}
+
+// checkImage sniffs an image's content type from its first 512 bytes, as
+// http.DetectContentType does, and checks that it decodes. It returns the
+// file extension of the type.
+func checkImage(r io.Reader) (string, error) {
+	head := make([]byte, 512)
+	n, err := io.ReadFull(r, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return "", errors.New("failed to read image file")
+	}
+	ext, ok := domain.MangaImageTypes[http.DetectContentType(head[:n])]
+	if !ok {
+		return "", errors.New("image must be a JPEG, PNG or GIF")
+	}
+
+	if err := utils.CheckImage(io.MultiReader(bytes.NewReader(head[:n]), r)); err != nil {
+		return "", err
+	}
+	return ext, nil
+}
+
+// processImage strips the metadata of an uploaded image, stores its variants
+// and points the image at them, with the medium JPEG as its thumbnail. A
+// variant that fails to encode in a format other than JPEG is left out.
+func (s *mangaImageService) processImage(ctx context.Context, job *domain.Job, args domain.ProcessImageArgs) error {
+	image, err := s.imageRepo.GetByID(ctx, args.ImageID)
+	if err != nil {
+		if strings.HasSuffix(err.Error(), "not found") {
+			// Deleted before it was processed
+			return nil
+		}
+		return err
+	}
+
+	original, err := s.storage.Open(image.StorageKey)
+	if err != nil {
+		return err
+	}
+	data, err := io.ReadAll(original)
+	original.Close()
+	if err != nil {
+		return errors.New("failed to read image file")
+	}
+
+	decoded, err := utils.DecodeImage(data)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrPermanentJobFailure, err)
+	}
+	stripped, err := utils.StripMetadata(data, decoded)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrPermanentJobFailure, err)
+	}
+	if !bytes.Equal(stripped, data) {
+		if _, err := s.storage.Save(image.StorageKey, bytes.NewReader(stripped)); err != nil {
+			return err
+		}
+	}
+
+	bounds := decoded.Bounds()
+	image.Width, image.Height = bounds.Dx(), bounds.Dy()
+	image.Variants = nil
+	for _, size := range domain.ImageSizes {
+		resized := utils.ResizeImage(decoded, size.MaxSize)
+		variant := domain.MangaImageVariant{
+			Size:   size.Name,
+			Format: domain.ImageFormatJPEG,
+			Width:  resized.Bounds().Dx(),
+			Height: resized.Bounds().Dy(),
+		}
+
+		jpeg, err := utils.EncodeJPEG(resized, 85)
+		if err != nil {
+			return fmt.Errorf("%w: %v", domain.ErrPermanentJobFailure, err)
+		}
+		if err := s.saveVariant(image, variant, jpeg); err != nil {
+			return err
+		}
+
+		for _, encoder := range s.encoders {
+			encoded, err := encoder.Encode(ctx, resized)
+			if err != nil {
+				log.Printf("Failed to encode the %s %s variant of manga image %d: %v", size.Name, encoder.Format(), image.ID, err)
+				continue
+			}
+			variant.Format = encoder.Format()
+			if err := s.saveVariant(image, variant, encoded); err != nil {
+				return err
+			}
+		}
+	}
+
+	if thumbnail := image.PickVariant(domain.ImageSizeMedium, nil); thumbnail != nil {
+		image.ThumbnailURL = thumbnail.URL
+	}
+	return s.imageRepo.SetVariants(ctx, image)
+}
+
+// saveVariant stores an encoded variant of the image and adds it to the image's variants
+func (s *mangaImageService) saveVariant(image *domain.MangaImage, variant domain.MangaImageVariant, data []byte) error {
+	url, err := s.storage.Save(image.VariantKey(variant.Size, variant.Format), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	variant.URL = url
+	image.Variants = append(image.Variants, variant)
+	return nil
+}
+
+// UpdateImage changes an image's caption
+func (s *mangaImageService) UpdateImage(ctx context.Context, mangaID, imageID uint, req *domain.UpdateMangaImageRequest, userID uint) (*domain.MangaImage, error) {
+	if _, err := s.getManagedManga(ctx, mangaID, userID); err != nil {
+		return nil, err
+	}
+
+	image, err := s.getMangaImage(ctx, mangaID, imageID)
+	if err != nil {
+		return nil, err
+	}
+
+	before := auditState(image)
+	image.Caption = req.Caption
+	if err := s.imageRepo.Update(ctx, image); err != nil {
+		return nil, err
+	}
+	recordAudit(ctx, s.audit, "manga_image.update", image.ID, before, image)
+
+	return image, nil
+}
+
+// ReorderImages puts the gallery in the given order, which must list every image once
+func (s *mangaImageService) ReorderImages(ctx context.Context, mangaID uint, req *domain.ReorderMangaImagesRequest, userID uint) ([]*domain.MangaImage, error) {
+	if _, err := s.getManagedManga(ctx, mangaID, userID); err != nil {
+		return nil, err
+	}
+
+	images, err := s.imageRepo.ListByMangaID(ctx, mangaID)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make(map[uint]bool, len(images))
+	order := &domain.ReorderMangaImagesRequest{ImageIDs: make([]uint, len(images))}
+	for i, image := range images {
+		members[image.ID] = true
+		order.ImageIDs[i] = image.ID
+	}
+	if len(req.ImageIDs) != len(members) {
+		return nil, errors.New("image_ids must list every image of the manga exactly once")
+	}
+	seen := make(map[uint]bool, len(req.ImageIDs))
+	for _, imageID := range req.ImageIDs {
+		if !members[imageID] || seen[imageID] {
+			return nil, errors.New("image_ids must list every image of the manga exactly once")
+		}
+		seen[imageID] = true
+	}
+
+	if err := s.imageRepo.Reorder(ctx, mangaID, req.ImageIDs); err != nil {
+		return nil, err
+	}
+	recordAudit(ctx, s.audit, "manga.reorder_images", mangaID, order, req)
+
+	return s.imageRepo.ListByMangaID(ctx, mangaID)
+}
+
+// DeleteImage removes an image from the gallery and from storage
+func (s *mangaImageService) DeleteImage(ctx context.Context, mangaID, imageID uint, userID uint) error {
+	if _, err := s.getManagedManga(ctx, mangaID, userID); err != nil {
+		return err
+	}
+
+	image, err := s.getMangaImage(ctx, mangaID, imageID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.imageRepo.Delete(ctx, image.ID); err != nil {
+		return err
+	}
+	recordAudit(ctx, s.audit, "manga_image.delete", image.ID, image, nil)
+
+	s.removeFiles(image)
+	return nil
+}
+
+// removeFiles deletes an image's files from storage, logging failures since the
+// image itself is already gone or was never saved
+func (s *mangaImageService) removeFiles(image *domain.MangaImage) {
+	keys := []string{image.StorageKey, image.ThumbnailKey}
+	for _, variant := range image.Variants {
+		if key := image.VariantKey(variant.Size, variant.Format); !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	for _, key := range keys {
+		if err := s.storage.Delete(key); err != nil {
+			log.Printf("Failed to delete stored file %s: %v", key, err)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// mangaRelationService implements the MangaRelationService interface
+type mangaRelationService struct {
+	relationRepo ports.MangaRelationRepository
+	mangaRepo    ports.MangaRepository
+	teamRepo     ports.TeamRepository
+	audit        ports.AuditService
+}
+
+// NewMangaRelationService creates a new manga relation service instance
+func NewMangaRelationService(relationRepo ports.MangaRelationRepository, mangaRepo ports.MangaRepository, teamRepo ports.TeamRepository, audit ports.AuditService) ports.MangaRelationService {
+	return &mangaRelationService{
+		relationRepo: relationRepo,
+		mangaRepo:    mangaRepo,
+		teamRepo:     teamRepo,
+		audit:        audit,
+	}
+}
+
+// CreateRelation links a manga the user manages to another manga. The target must be
+// published or also managed by the user, and sequel links may not form a cycle.
+func (s *mangaRelationService) CreateRelation(ctx context.Context, mangaID uint, req *domain.CreateMangaRelationRequest, userID uint) (*domain.MangaRelation, error) {
+	if req.RelatedID == mangaID {
+		return nil, errors.New("a manga cannot be related to itself")
+	}
+
+	manga, err := s.mangaRepo.GetByID(ctx, mangaID)
+	if err != nil {
+		return nil, err
+	}
+	if !canManageManga(ctx, s.teamRepo, manga, userID) {
+		return nil, errors.New("access denied: you can only link your own manga")
+	}
+
+	related, err := s.mangaRepo.GetByID(ctx, req.RelatedID)
+	if err != nil {
+		return nil, err
+	}
+	if related.Status != domain.MangaStatusPublished && !canManageManga(ctx, s.teamRepo, related, userID) {
+		return nil, errors.New("related manga not found")
+	}
+
+	if _, err := s.relationRepo.GetByPair(ctx, mangaID, req.RelatedID); err == nil {
+		return nil, errors.New("mangas are already related")
+	}
+
+	// Orient the link as "next is the sequel of prev" and refuse it when prev
+	// already follows next somewhere down the sequel chain
+	switch req.Type {
+	case domain.MangaRelationSequel:
+		if err := s.checkSequelCycle(ctx, mangaID, req.RelatedID); err != nil {
+			return nil, err
+		}
+	case domain.MangaRelationPrequel:
+		if err := s.checkSequelCycle(ctx, req.RelatedID, mangaID); err != nil {
+			return nil, err
+		}
+	}
+
+	relation := &domain.MangaRelation{
+		MangaID:   mangaID,
+		RelatedID: req.RelatedID,
+		Type:      req.Type,
+		CreatedBy: userID,
+	}
+	if err := s.relationRepo.Create(ctx, relation); err != nil {
+		return nil, err
+	}
+	recordAudit(ctx, s.audit, "manga_relation.create", relation.ID, nil, relation)
+
+	relation.Related = related.Sanitize()
+
+	return relation, nil
+}
+
+// checkSequelCycle returns ErrRelationCycle if prev can be reached from next by
+// following sequel links, which making next the sequel of prev would close into a loop
+func (s *mangaRelationService) checkSequelCycle(ctx context.Context, prev, next uint) error {
+	visited := map[uint]bool{next: true}
+	queue := []uint{next}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		sequels, err := s.relationRepo.ListRelatedIDs(ctx, current, domain.MangaRelationSequel)
+		if err != nil {
+			return err
+		}
+		for _, id := range sequels {
+			if id == prev {
+				return domain.ErrRelationCycle
+			}
+			if !visited[id] {
+				visited[id] = true
+				queue = append(queue, id)
+			}
+		}
+	}
+
+	return nil
+}
+
+// GetRelated retrieves the published mangas related to a published manga
+func (s *mangaRelationService) GetRelated(ctx context.Context, mangaID uint) ([]*domain.MangaRelation, error) {
+	manga, err := s.mangaRepo.GetByID(ctx, mangaID)
+	if err != nil {
+		return nil, err
+	}
+	if manga.Status != domain.MangaStatusPublished {
+		return nil, errors.New("manga not found")
+	}
+
+	relations, err := s.relationRepo.ListByMangaID(ctx, mangaID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, relation := range relations {
+		if relation.Related != nil {
+			relation.Related = relation.Related.Sanitize()
+		}
+	}
+
+	return relations, nil
+}
+
+// DeleteRelation unlinks two mangas; the user must manage the first one
+func (s *mangaRelationService) DeleteRelation(ctx context.Context, mangaID, relatedID uint, userID uint) error {
+	manga, err := s.mangaRepo.GetByID(ctx, mangaID)
+	if err != nil {
+		return err
+	}
+	if !canManageManga(ctx, s.teamRepo, manga, userID) {
+		return errors.New("access denied: you can only unlink your own manga")
+	}
+
+	relation, err := s.relationRepo.GetByPair(ctx, mangaID, relatedID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.relationRepo.Delete(ctx, mangaID, relatedID); err != nil {
+		return err
+	}
+	recordAudit(ctx, s.audit, "manga_relation.delete", relation.ID, relation, nil)
+	return nil
+}
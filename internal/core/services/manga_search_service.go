@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// reindexBatchSize is how many mangas are sent to the search index at once
+const reindexBatchSize = 500
+
+// searchIndexTimeout bounds updating the index for one relayed event
+const searchIndexTimeout = 10 * time.Second
+
+// mangaSearchService implements the MangaSearchService interface
+type mangaSearchService struct {
+	search       ports.SearchService
+	mangaRepo    ports.MangaRepository
+	discountRepo ports.DiscountRepository
+}
+
+// NewMangaSearchService creates a new manga search service instance
+func NewMangaSearchService(search ports.SearchService, mangaRepo ports.MangaRepository, discountRepo ports.DiscountRepository) ports.MangaSearchService {
+	return &mangaSearchService{
+		search:       search,
+		mangaRepo:    mangaRepo,
+		discountRepo: discountRepo,
+	}
+}
+
+// SearchMangas retrieves a page of visible mangas matching the filter with facet counts
+func (s *mangaSearchService) SearchMangas(ctx context.Context, filter *domain.MangaFilter, pagination *domain.PaginationRequest) (*domain.MangaSearchResult, error) {
+	result, err := s.search.Search(ctx, filter, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sanitize all mangas
+	for i, manga := range result.Data {
+		result.Data[i] = manga.Sanitize()
+	}
+	if discounts, err := s.discountRepo.ListActive(ctx, time.Now()); err == nil && len(discounts) > 0 {
+		domain.ApplyDiscounts(result.Data, discounts)
+	}
+
+	return result, nil
+}
+
+// Reindex sends every manga to the search index again, for a new or restored
+// index, and after changes made without events (such as owner suspensions)
+func (s *mangaSearchService) Reindex(ctx context.Context) (int, error) {
+	indexed := 0
+	err := s.mangaRepo.ExportInBatches(ctx, nil, reindexBatchSize, func(mangas []*domain.Manga) error {
+		if err := s.search.Index(ctx, mangas...); err != nil {
+			return err
+		}
+		indexed += len(mangas)
+		return nil
+	})
+	return indexed, err
+}
+
+// Dispatch updates the search index for a relayed manga event. The manga is
+// reloaded rather than taken from the event, so the index catches up with its
+// latest state even when events are relayed again or out of order.
+func (s *mangaSearchService) Dispatch(userID uint, event string, data interface{}) {
+	switch event {
+	case domain.EventMangaCreated, domain.EventMangaUpdated, domain.EventMangaDeleted:
+	default:
+		return
+	}
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return
+	}
+	var ref struct {
+		ID uint `json:"id"`
+	}
+	if err := json.Unmarshal(payload, &ref); err != nil || ref.ID == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), searchIndexTimeout)
+	defer cancel()
+
+	manga, err := s.mangaRepo.GetByID(ctx, ref.ID)
+	switch {
+	case err != nil && strings.HasSuffix(err.Error(), "not found"):
+		err = s.search.Remove(ctx, ref.ID)
+	case err == nil:
+		err = s.search.Index(ctx, manga)
+	}
+	if err != nil {
+		log.Printf("failed to update search index for manga %d: %v", ref.ID, err)
+	}
+}
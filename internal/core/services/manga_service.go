@@ -1,56 +1,406 @@
 package services
 
 import (
+	"cmp"
+	"context"
+	"encoding/json"
 	"errors"
+	"log"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/thitiphongD/my-backend/internal/core/domain"
 	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/internal/utils"
+)
+
+const (
+	// duplicatePrefixLength is how many leading characters of the normalized name
+	// a manga must share to be considered a duplicate candidate
+	duplicatePrefixLength = 3
+	// duplicateThreshold is the minimum name similarity reported as a duplicate
+	duplicateThreshold = 0.8
+	// maxDuplicateCandidates caps the candidates returned to the client
+	maxDuplicateCandidates = 5
 )
 
 // mangaService implements the MangaService interface
 type mangaService struct {
-	mangaRepo ports.MangaRepository
+	mangaRepo        ports.MangaRepository
+	teamRepo         ports.TeamRepository
+	genreRepo        ports.GenreRepository
+	priceHistoryRepo ports.PriceHistoryRepository
+	discountRepo     ports.DiscountRepository
+	versionRepo      ports.MangaVersionRepository
+	bus              ports.EventBus
+	cache            ports.CacheInvalidator
+	tx               ports.TransactionManager
+	audit            ports.AuditService
+	importBatchSize  int
 }
 
 // NewMangaService creates a new manga service instance
-func NewMangaService(mangaRepo ports.MangaRepository) ports.MangaService {
+func NewMangaService(mangaRepo ports.MangaRepository, teamRepo ports.TeamRepository, genreRepo ports.GenreRepository, priceHistoryRepo ports.PriceHistoryRepository, discountRepo ports.DiscountRepository, versionRepo ports.MangaVersionRepository, bus ports.EventBus, cache ports.CacheInvalidator, tx ports.TransactionManager, audit ports.AuditService, importBatchSize int) ports.MangaService {
 	return &mangaService{
-		mangaRepo: mangaRepo,
+		mangaRepo:        mangaRepo,
+		teamRepo:         teamRepo,
+		genreRepo:        genreRepo,
+		priceHistoryRepo: priceHistoryRepo,
+		discountRepo:     discountRepo,
+		versionRepo:      versionRepo,
+		bus:              bus,
+		cache:            cache,
+		tx:               tx,
+		audit:            audit,
+		importBatchSize:  importBatchSize,
+	}
+}
+
+// invalidateCache drops cached listings and the cached responses of the given mangas
+func (s *mangaService) invalidateCache(ids ...uint) {
+	tags := []string{domain.MangaListCacheTag}
+	for _, id := range ids {
+		tags = append(tags, domain.MangaCacheTag(id))
+	}
+	if err := s.cache.Invalidate(tags...); err != nil {
+		log.Printf("Failed to invalidate cached responses of mangas %v: %v", ids, err)
+	}
+}
+
+// resolveGenres loads the genres for the given IDs, failing if any is unknown
+func (s *mangaService) resolveGenres(ctx context.Context, ids []uint) ([]*domain.Genre, error) {
+	genres, err := s.genreRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[uint]bool, len(genres))
+	for _, genre := range genres {
+		found[genre.ID] = true
+	}
+	for _, id := range ids {
+		if !found[id] {
+			return nil, errors.New("genre not found")
+		}
+	}
+
+	return genres, nil
+}
+
+// applyDiscounts sets the effective price of mangas covered by an active discount.
+// Prices are left undiscounted if discounts can't be loaded.
+func (s *mangaService) applyDiscounts(ctx context.Context, mangas ...*domain.Manga) {
+	discounts, err := s.discountRepo.ListActive(ctx, time.Now())
+	if err != nil || len(discounts) == 0 {
+		return
+	}
+	domain.ApplyDiscounts(mangas, discounts)
+}
+
+// initialMangaStatus returns the status a newly created manga starts in
+func initialMangaStatus(req *domain.CreateMangaRequest) string {
+	if req.Submit {
+		return domain.MangaStatusPendingReview
 	}
+	return domain.MangaStatusDraft
 }
 
-// CreateManga creates a new manga
-func (s *mangaService) CreateManga(req *domain.CreateMangaRequest, userID uint) (*domain.Manga, error) {
+// publicationStatusOrDefault returns the requested publication status, or ongoing when none was given
+func publicationStatusOrDefault(status string) string {
+	if status == "" {
+		return domain.PublicationStatusOngoing
+	}
+	return status
+}
+
+// contentRatingOrDefault returns the requested content rating, or all_ages when none was given
+func contentRatingOrDefault(rating string) string {
+	if rating == "" {
+		return domain.ContentRatingAllAges
+	}
+	return rating
+}
+
+// findDuplicates returns existing mangas, owned by the user or published, whose
+// normalized name is similar to the given name, most similar first
+func (s *mangaService) findDuplicates(ctx context.Context, name string, userID uint) ([]domain.DuplicateCandidate, error) {
+	normalized := utils.NormalizeName(name)
+	prefix := []rune(normalized)
+	if len(prefix) > duplicatePrefixLength {
+		prefix = prefix[:duplicatePrefixLength]
+	}
+	if len(prefix) == 0 {
+		return nil, nil
+	}
+
+	mangas, err := s.mangaRepo.FindByNamePrefix(ctx, string(prefix), userID, 200)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []domain.DuplicateCandidate
+	for _, manga := range mangas {
+		existing := utils.NormalizeName(manga.Name)
+		similarity := utils.Similarity(normalized, existing)
+		if similarity < duplicateThreshold && !strings.HasPrefix(existing, normalized) && !strings.HasPrefix(normalized, existing) {
+			continue
+		}
+		candidates = append(candidates, domain.DuplicateCandidate{
+			ID:         manga.ID,
+			Name:       manga.Name,
+			OwnerID:    manga.UserCreated,
+			SameOwner:  manga.UserCreated == userID,
+			Similarity: similarity,
+		})
+	}
+
+	slices.SortStableFunc(candidates, func(a, b domain.DuplicateCandidate) int {
+		return cmp.Compare(b.Similarity, a.Similarity)
+	})
+	if len(candidates) > maxDuplicateCandidates {
+		candidates = candidates[:maxDuplicateCandidates]
+	}
+
+	return candidates, nil
+}
+
+// CreateManga creates a new manga, refusing likely duplicates unless forced
+func (s *mangaService) CreateManga(ctx context.Context, req *domain.CreateMangaRequest, userID uint) (*domain.Manga, error) {
+	// Team-owned mangas can only be created by team members
+	if req.TeamID != nil {
+		if _, err := s.teamRepo.GetMember(ctx, *req.TeamID, userID); err != nil {
+			return nil, errors.New("access denied: you are not a member of this team")
+		}
+	}
+
+	genres, err := s.resolveGenres(ctx, req.GenreIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if !req.Force {
+		candidates, err := s.findDuplicates(ctx, req.Name, userID)
+		if err != nil {
+			return nil, err
+		}
+		if len(candidates) > 0 {
+			return nil, &domain.DuplicateMangaError{Candidates: candidates}
+		}
+	}
+
 	manga := &domain.Manga{
 		Name:        req.Name,
 		Price:       req.Price,
 		IsActive:    req.IsActive,
 		UserCreated: userID,
+		TeamID:      req.TeamID,
+		Status:      initialMangaStatus(req),
+
+		PublicationStatus: publicationStatusOrDefault(req.PublicationStatus),
+		ContentRating:     contentRatingOrDefault(req.ContentRating),
+		StockQuantity:     req.Stock,
+	}
+	for _, genre := range genres {
+		manga.Genres = append(manga.Genres, *genre)
 	}
 
 	if !manga.IsValid() {
 		return nil, errors.New("invalid manga data")
 	}
 
-	if err := s.mangaRepo.Create(manga); err != nil {
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.mangaRepo.Create(ctx, manga); err != nil {
+			return err
+		}
+
+		// Record the initial version as the baseline for history and revert
+		if err := s.versionRepo.Create(ctx, &domain.MangaVersion{
+			MangaID:   manga.ID,
+			ChangedBy: &userID,
+			Snapshot:  domain.NewMangaSnapshot(manga),
+		}); err != nil {
+			return err
+		}
+		if err := s.audit.Record(ctx, "manga.create", manga.ID, nil, manga.Sanitize()); err != nil {
+			return err
+		}
+
+		return s.bus.Publish(ctx, domain.MangaCreated{Manga: manga})
+	})
+	if err != nil {
 		return nil, err
 	}
 
+	s.invalidateCache(manga.ID)
+
 	return manga.Sanitize(), nil
 }
 
-// GetMangaByID retrieves a manga by ID
-func (s *mangaService) GetMangaByID(id uint) (*domain.Manga, error) {
-	manga, err := s.mangaRepo.GetByID(id)
+// ImportMangas creates mangas from pre-validated import rows in batches of
+// importBatchSize and reports the outcome of every row. Rows of a failed batch
+// are retried one by one, so a bad row only fails itself.
+func (s *mangaService) ImportMangas(ctx context.Context, rows []*domain.MangaImportRow, userID uint) *domain.MangaImportReport {
+	report := &domain.MangaImportReport{
+		Total: len(rows),
+		Rows:  make([]domain.MangaImportRowResult, len(rows)),
+	}
+
+	teamAccess := make(map[uint]bool)
+	var mangas []*domain.Manga
+	var mangaRows []int
+
+	for i, row := range rows {
+		report.Rows[i].Row = row.Row
+		if row.Error != "" {
+			report.Rows[i].Error = row.Error
+			continue
+		}
+
+		req := row.Request
+		if req.TeamID != nil {
+			allowed, checked := teamAccess[*req.TeamID]
+			if !checked {
+				_, err := s.teamRepo.GetMember(ctx, *req.TeamID, userID)
+				allowed = err == nil
+				teamAccess[*req.TeamID] = allowed
+			}
+			if !allowed {
+				report.Rows[i].Error = "access denied: you are not a member of this team"
+				continue
+			}
+		}
+
+		genres, err := s.resolveGenres(ctx, req.GenreIDs)
+		if err != nil {
+			report.Rows[i].Error = err.Error()
+			continue
+		}
+
+		manga := &domain.Manga{
+			Name:        req.Name,
+			Price:       req.Price,
+			IsActive:    req.IsActive,
+			UserCreated: userID,
+			TeamID:      req.TeamID,
+			Status:      initialMangaStatus(req),
+
+			PublicationStatus: publicationStatusOrDefault(req.PublicationStatus),
+			ContentRating:     contentRatingOrDefault(req.ContentRating),
+			StockQuantity:     req.Stock,
+		}
+		for _, genre := range genres {
+			manga.Genres = append(manga.Genres, *genre)
+		}
+
+		if !manga.IsValid() {
+			report.Rows[i].Error = "invalid manga data"
+			continue
+		}
+
+		mangas = append(mangas, manga)
+		mangaRows = append(mangaRows, i)
+	}
+
+	// The created mangas and their events are committed together
+	failed := make(map[int]string)
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		for _, failure := range s.mangaRepo.CreateInBatches(ctx, mangas, s.importBatchSize) {
+			failed[failure.Index] = failure.Error
+		}
+		for i, manga := range mangas {
+			if _, ok := failed[i]; ok {
+				continue
+			}
+			if err := s.audit.Record(ctx, "manga.create", manga.ID, nil, manga.Sanitize()); err != nil {
+				return err
+			}
+			if err := s.bus.Publish(ctx, domain.MangaCreated{Manga: manga}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	for i, manga := range mangas {
+		result := &report.Rows[mangaRows[i]]
+		if err != nil {
+			result.Error = err.Error()
+			continue
+		}
+		if msg, ok := failed[i]; ok {
+			result.Error = msg
+			continue
+		}
+		result.Success = true
+		result.MangaID = manga.ID
+	}
+	if err == nil && len(failed) < len(mangas) {
+		s.invalidateCache()
+	}
+
+	for _, result := range report.Rows {
+		if result.Success {
+			report.Imported++
+		} else {
+			report.Failed++
+		}
+	}
+
+	return report
+}
+
+// mangaExportBatchSize is the number of mangas loaded per export chunk
+const mangaExportBatchSize = 500
+
+// ExportMangas streams the user's mangas (or all mangas, for admins) to fn in chunks
+func (s *mangaService) ExportMangas(ctx context.Context, userID uint, isAdmin bool, fn func([]*domain.Manga) error) error {
+	var owner *uint
+	if !isAdmin {
+		owner = &userID
+	}
+
+	return s.mangaRepo.ExportInBatches(ctx, owner, mangaExportBatchSize, func(mangas []*domain.Manga) error {
+		// Sanitize all mangas
+		sanitizedMangas := make([]*domain.Manga, len(mangas))
+		for i, manga := range mangas {
+			sanitizedMangas[i] = manga.Sanitize()
+		}
+		return fn(sanitizedMangas)
+	})
+}
+
+// CountExportMangas counts the mangas ExportMangas exports
+func (s *mangaService) CountExportMangas(ctx context.Context, userID uint, isAdmin bool) (int64, error) {
+	if isAdmin {
+		return s.mangaRepo.CountForExport(ctx, nil)
+	}
+	return s.mangaRepo.CountForExport(ctx, &userID)
+}
+
+// GetMangaByID retrieves a manga by ID; mature mangas are only returned to verified adults
+func (s *mangaService) GetMangaByID(ctx context.Context, id uint, isAdult bool, include domain.Includes) (*domain.Manga, error) {
+	manga, err := s.mangaRepo.GetByIDIncluding(ctx, id, include)
 	if err != nil {
 		return nil, err
 	}
-	return manga.Sanitize(), nil
+
+	// Unpublished listings are not publicly visible
+	if manga.Status != domain.MangaStatusPublished {
+		return nil, errors.New("manga not found")
+	}
+	if manga.ContentRating == domain.ContentRatingMature && !isAdult {
+		return nil, domain.ErrAgeRestricted
+	}
+
+	sanitized := manga.Sanitize()
+	s.applyDiscounts(ctx, sanitized)
+
+	return sanitized, nil
 }
 
-// GetMangas retrieves all mangas
-func (s *mangaService) GetMangas() ([]*domain.Manga, error) {
-	mangas, err := s.mangaRepo.List()
+// GetMangas retrieves a page of visible mangas matching the filter
+func (s *mangaService) GetMangas(ctx context.Context, filter *domain.MangaFilter, pagination *domain.PaginationRequest, sort domain.Sort, fields domain.Fields, include domain.Includes) (*domain.PaginatedResult[*domain.Manga], error) {
+	mangas, total, err := s.mangaRepo.Search(ctx, filter, pagination, sort, fields, include)
 	if err != nil {
 		return nil, err
 	}
@@ -60,13 +410,45 @@ func (s *mangaService) GetMangas() ([]*domain.Manga, error) {
 	for i, manga := range mangas {
 		sanitizedMangas[i] = manga.Sanitize()
 	}
+	if fields.Has("effective_price") {
+		s.applyDiscounts(ctx, sanitizedMangas...)
+	}
 
-	return sanitizedMangas, nil
+	// Create pagination metadata
+	paginationMeta := domain.NewPaginationResponse(pagination.Page, pagination.PageSize, total)
+
+	return &domain.PaginatedResult[*domain.Manga]{
+		Data:       sanitizedMangas,
+		Pagination: paginationMeta,
+	}, nil
 }
 
-// GetMangasByUser retrieves mangas by user ID
-func (s *mangaService) GetMangasByUser(userID uint) ([]*domain.Manga, error) {
-	mangas, err := s.mangaRepo.GetByUserID(userID)
+// GetMangaFacets counts the mangas matching the filter per facet value. Each facet
+// ignores its own filter so clients can see the alternatives they could switch to.
+func (s *mangaService) GetMangaFacets(ctx context.Context, filter *domain.MangaFilter) (*domain.MangaFacets, error) {
+	statusFilter := *filter
+	statusFilter.PublicationStatus = ""
+
+	counts, err := s.mangaRepo.CountByFacet(ctx, &statusFilter, domain.MangaFacetPublicationStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	facets := &domain.MangaFacets{PublicationStatus: make(map[string]int64, len(domain.PublicationStatuses))}
+	for _, status := range domain.PublicationStatuses {
+		facets.PublicationStatus[status] = counts[status]
+	}
+
+	return facets, nil
+}
+
+// GetMangasByTeam retrieves mangas owned by a team, visible to its members
+func (s *mangaService) GetMangasByTeam(ctx context.Context, teamID uint, userID uint, sort domain.Sort) ([]*domain.Manga, error) {
+	if _, err := s.teamRepo.GetMember(ctx, teamID, userID); err != nil {
+		return nil, errors.New("access denied: you are not a member of this team")
+	}
+
+	mangas, err := s.mangaRepo.GetByTeamID(ctx, teamID, sort)
 	if err != nil {
 		return nil, err
 	}
@@ -76,54 +458,139 @@ func (s *mangaService) GetMangasByUser(userID uint) ([]*domain.Manga, error) {
 	for i, manga := range mangas {
 		sanitizedMangas[i] = manga.Sanitize()
 	}
+	s.applyDiscounts(ctx, sanitizedMangas...)
 
 	return sanitizedMangas, nil
 }
 
 // UpdateManga updates an existing manga
-func (s *mangaService) UpdateManga(id uint, req *domain.UpdateMangaRequest, userID uint) (*domain.Manga, error) {
+func (s *mangaService) UpdateManga(ctx context.Context, id uint, req *domain.UpdateMangaRequest, userID uint) (*domain.Manga, error) {
 	// Get existing manga
-	manga, err := s.mangaRepo.GetByID(id)
+	manga, err := s.mangaRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	// Check ownership (user can only update their own manga)
-	if manga.UserCreated != userID {
+	// Check ownership (user can only update their own or their team's manga)
+	if !canManageManga(ctx, s.teamRepo, manga, userID) {
 		return nil, errors.New("access denied: you can only update your own manga")
 	}
+	// The ETag covers the discounted price, as GetMangaByID returns it
+	current := manga.Sanitize()
+	s.applyDiscounts(ctx, current)
+	if req.IfMatch != "" && !domain.ETagMatches(req.IfMatch, current.ETag()) {
+		return nil, domain.ErrPreconditionFailed
+	}
+	version := manga.UpdatedAt
+
+	oldPrice := manga.Price
+	before := domain.NewMangaSnapshot(manga)
+	auditBefore := auditState(manga.Sanitize())
 
 	// Update manga fields
 	manga.Name = req.Name
 	manga.Price = req.Price
 	manga.IsActive = req.IsActive
+	if req.PublicationStatus != "" {
+		manga.PublicationStatus = req.PublicationStatus
+	}
+	if req.ContentRating != "" {
+		manga.ContentRating = req.ContentRating
+	}
+
+	// The manga, its genres and its audit trail change together or not at all
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Fails when someone else saved the manga since it was checked
+		if err := s.mangaRepo.UpdateIfUnchanged(ctx, manga, version); err != nil {
+			return err
+		}
+
+		if err := s.recordPriceChange(ctx, manga, oldPrice, userID); err != nil {
+			return err
+		}
+
+		// Replace genres only when provided
+		if req.GenreIDs != nil {
+			genres, err := s.resolveGenres(ctx, req.GenreIDs)
+			if err != nil {
+				return err
+			}
+			if err := s.mangaRepo.ReplaceGenres(ctx, manga, genres); err != nil {
+				return err
+			}
+			manga.Genres = make([]domain.Genre, len(genres))
+			for i, genre := range genres {
+				manga.Genres[i] = *genre
+			}
+		}
 
-	if err := s.mangaRepo.Update(manga); err != nil {
+		if err := s.recordVersion(ctx, manga, before, userID); err != nil {
+			return err
+		}
+
+		if err := s.notifyPriceDrop(ctx, manga, oldPrice); err != nil {
+			return err
+		}
+		if err := s.audit.Record(ctx, "manga.update", manga.ID, auditBefore, manga.Sanitize()); err != nil {
+			return err
+		}
+		return s.bus.Publish(ctx, domain.MangaUpdated{Manga: manga})
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	return manga.Sanitize(), nil
+	s.invalidateCache(manga.ID)
+
+	updated := manga.Sanitize()
+	s.applyDiscounts(ctx, updated)
+	return updated, nil
 }
 
-// DeleteManga deletes a manga by ID
-func (s *mangaService) DeleteManga(id uint, userID uint) error {
-	// Get existing manga
-	manga, err := s.mangaRepo.GetByID(id)
+// recordPriceChange logs a price change
+func (s *mangaService) recordPriceChange(ctx context.Context, manga *domain.Manga, oldPrice float64, userID uint) error {
+	if manga.Price == oldPrice {
+		return nil
+	}
+
+	return s.priceHistoryRepo.Create(ctx, &domain.MangaPriceHistory{
+		MangaID:   manga.ID,
+		OldPrice:  oldPrice,
+		NewPrice:  manga.Price,
+		ChangedBy: userID,
+	})
+}
+
+// notifyPriceDrop publishes a price drop of a published manga, in the
+// transaction of the price change
+func (s *mangaService) notifyPriceDrop(ctx context.Context, manga *domain.Manga, oldPrice float64) error {
+	if manga.Price >= oldPrice || manga.Status != domain.MangaStatusPublished {
+		return nil
+	}
+	return s.bus.Publish(ctx, domain.MangaPriceDropped{Manga: manga, OldPrice: oldPrice})
+}
+
+// GetPriceHistory retrieves a manga's price changes with a min/max/avg summary
+func (s *mangaService) GetPriceHistory(ctx context.Context, id uint) (*domain.PriceHistoryResponse, error) {
+	manga, err := s.mangaRepo.GetByID(ctx, id)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// Check ownership (user can only delete their own manga)
-	if manga.UserCreated != userID {
-		return errors.New("access denied: you can only delete your own manga")
+	history, err := s.priceHistoryRepo.ListByMangaID(ctx, id)
+	if err != nil {
+		return nil, err
 	}
 
-	return s.mangaRepo.Delete(id)
+	return &domain.PriceHistoryResponse{
+		Summary: domain.NewPriceSummary(history, manga.Price),
+		History: history,
+	}, nil
 }
 
-// GetActiveMangas retrieves all active mangas
-func (s *mangaService) GetActiveMangas() ([]*domain.Manga, error) {
-	mangas, err := s.mangaRepo.GetActiveMangas()
+// GetMyMangas retrieves the user's own mangas in any status, optionally filtered by status
+func (s *mangaService) GetMyMangas(ctx context.Context, userID uint, status string, sort domain.Sort) ([]*domain.Manga, error) {
+	mangas, err := s.mangaRepo.GetOwnedByUser(ctx, userID, status, sort)
 	if err != nil {
 		return nil, err
 	}
@@ -133,29 +600,80 @@ func (s *mangaService) GetActiveMangas() ([]*domain.Manga, error) {
 	for i, manga := range mangas {
 		sanitizedMangas[i] = manga.Sanitize()
 	}
+	s.applyDiscounts(ctx, sanitizedMangas...)
 
 	return sanitizedMangas, nil
 }
 
-// GetMangasByPriceRange retrieves mangas within price range
-func (s *mangaService) GetMangasByPriceRange(min, max float64) ([]*domain.Manga, error) {
-	mangas, err := s.mangaRepo.GetMangasByPriceRange(min, max)
+// SubmitManga sends a draft or rejected manga to the admin review queue
+func (s *mangaService) SubmitManga(ctx context.Context, id uint, userID uint) (*domain.Manga, error) {
+	manga, err := s.mangaRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	// Sanitize all mangas
-	sanitizedMangas := make([]*domain.Manga, len(mangas))
-	for i, manga := range mangas {
-		sanitizedMangas[i] = manga.Sanitize()
+	// Check ownership (user can only submit their own or their team's manga)
+	if !canManageManga(ctx, s.teamRepo, manga, userID) {
+		return nil, errors.New("access denied: you can only submit your own manga")
 	}
 
-	return sanitizedMangas, nil
+	var submitted *domain.Manga
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		submitted, err = s.transition(ctx, manga.ID, domain.MangaStatusPendingReview, "", "manga.submit")
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return submitted, nil
 }
 
-// GetMangasPaginated retrieves paginated mangas
-func (s *mangaService) GetMangasPaginated(pagination *domain.PaginationRequest) (*domain.PaginatedResult[*domain.Manga], error) {
-	mangas, total, err := s.mangaRepo.ListPaginated(pagination)
+// ApproveManga publishes a manga awaiting review
+func (s *mangaService) ApproveManga(ctx context.Context, id uint) (*domain.Manga, error) {
+	var manga *domain.Manga
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		manga, err = s.transition(ctx, id, domain.MangaStatusPublished, "", "manga.approve")
+		if err != nil {
+			return err
+		}
+		if err := s.bus.Publish(ctx, domain.MangaUpdated{Manga: manga}); err != nil {
+			return err
+		}
+		return s.bus.Publish(ctx, domain.MangaReviewed{Manga: manga})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidateCache(manga.ID)
+
+	return manga, nil
+}
+
+// RejectManga rejects a manga awaiting review with a reason for the owner
+func (s *mangaService) RejectManga(ctx context.Context, id uint, reason string) (*domain.Manga, error) {
+	var manga *domain.Manga
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		manga, err = s.transition(ctx, id, domain.MangaStatusRejected, reason, "manga.reject")
+		if err != nil {
+			return err
+		}
+		return s.bus.Publish(ctx, domain.MangaReviewed{Manga: manga})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return manga, nil
+}
+
+// GetReviewQueue retrieves mangas awaiting review, oldest first
+func (s *mangaService) GetReviewQueue(ctx context.Context, pagination *domain.PaginationRequest) (*domain.PaginatedResult[*domain.Manga], error) {
+	mangas, total, err := s.mangaRepo.GetByStatusPaginated(ctx, domain.MangaStatusPendingReview, pagination)
 	if err != nil {
 		return nil, err
 	}
@@ -166,18 +684,80 @@ func (s *mangaService) GetMangasPaginated(pagination *domain.PaginationRequest)
 		sanitizedMangas[i] = manga.Sanitize()
 	}
 
-	// Create pagination metadata
-	paginationMeta := domain.NewPaginationResponse(pagination.Page, pagination.PageSize, total)
-
 	return &domain.PaginatedResult[*domain.Manga]{
 		Data:       sanitizedMangas,
-		Pagination: paginationMeta,
+		Pagination: domain.NewPaginationResponse(pagination.Page, pagination.PageSize, total),
 	}, nil
 }
 
-// GetActiveMangasPaginated retrieves paginated active mangas
-func (s *mangaService) GetActiveMangasPaginated(pagination *domain.PaginationRequest) (*domain.PaginatedResult[*domain.Manga], error) {
-	mangas, total, err := s.mangaRepo.GetActiveMangasPaginated(pagination)
+// transition moves a manga to a new status if allowed from its current status,
+// recording the move in the audit log as action
+func (s *mangaService) transition(ctx context.Context, id uint, to string, reason string, action string) (*domain.Manga, error) {
+	before, err := s.mangaRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.mangaRepo.UpdateStatus(ctx, id, domain.MangaStatusesFrom(to), to, reason); err != nil {
+		return nil, err
+	}
+
+	manga, err := s.mangaRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.audit.Record(ctx, action, id, before.Sanitize(), manga.Sanitize()); err != nil {
+		return nil, err
+	}
+
+	return manga.Sanitize(), nil
+}
+
+// AdjustStock adds delta (positive or negative) to a manga's stock
+func (s *mangaService) AdjustStock(ctx context.Context, id uint, req *domain.StockAdjustRequest, userID uint) (*domain.Manga, error) {
+	manga, err := s.mangaRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check ownership (user can only manage stock of their own or their team's manga)
+	if !canManageManga(ctx, s.teamRepo, manga, userID) {
+		return nil, errors.New("access denied: you can only manage stock of your own manga")
+	}
+
+	if err := s.mangaRepo.AdjustStock(ctx, id, req.Delta); err != nil {
+		return nil, err
+	}
+	s.invalidateCache(id)
+
+	// Reload to return the stock as committed
+	adjusted, err := s.mangaRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	recordAudit(ctx, s.audit, "manga.adjust_stock", id, manga.Sanitize(), stockAdjustmentState(adjusted.Sanitize(), req))
+
+	return adjusted.Sanitize(), nil
+}
+
+// stockAdjustmentState is a manga after a stock adjustment, for the audit log,
+// along with the delta and the reason given for it
+func stockAdjustmentState(manga *domain.Manga, req *domain.StockAdjustRequest) interface{} {
+	return struct {
+		*domain.Manga
+		StockDelta  int    `json:"stock_delta"`
+		StockReason string `json:"stock_reason,omitempty"`
+	}{manga, req.Delta, strings.TrimSpace(req.Reason)}
+}
+
+// GetLowStockMangas retrieves the user's mangas (or all mangas, for admins) at or below the threshold
+func (s *mangaService) GetLowStockMangas(ctx context.Context, userID uint, isAdmin bool, threshold int) ([]*domain.Manga, error) {
+	var owner *uint
+	if !isAdmin {
+		owner = &userID
+	}
+
+	mangas, err := s.mangaRepo.GetLowStock(ctx, owner, threshold)
 	if err != nil {
 		return nil, err
 	}
@@ -187,19 +767,271 @@ func (s *mangaService) GetActiveMangasPaginated(pagination *domain.PaginationReq
 	for i, manga := range mangas {
 		sanitizedMangas[i] = manga.Sanitize()
 	}
+	s.applyDiscounts(ctx, sanitizedMangas...)
 
-	// Create pagination metadata
-	paginationMeta := domain.NewPaginationResponse(pagination.Page, pagination.PageSize, total)
+	return sanitizedMangas, nil
+}
 
-	return &domain.PaginatedResult[*domain.Manga]{
-		Data:       sanitizedMangas,
-		Pagination: paginationMeta,
-	}, nil
+// recordVersion stores a new version when the manga differs from its state before the change.
+// Mangas created before versioning get their prior state recorded as a baseline first.
+func (s *mangaService) recordVersion(ctx context.Context, manga *domain.Manga, before domain.MangaSnapshot, userID uint) error {
+	after := domain.NewMangaSnapshot(manga)
+	changes := after.Diff(before)
+	if len(changes) == 0 {
+		return nil
+	}
+
+	count, err := s.versionRepo.CountByMangaID(ctx, manga.ID)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		if err := s.versionRepo.Create(ctx, &domain.MangaVersion{MangaID: manga.ID, Snapshot: before}); err != nil {
+			return err
+		}
+	}
+
+	return s.versionRepo.Create(ctx, &domain.MangaVersion{
+		MangaID:   manga.ID,
+		ChangedBy: &userID,
+		Snapshot:  after,
+		Changes:   changes,
+	})
+}
+
+// GetMangaHistory retrieves a manga's versions, newest first (owners and team members only)
+func (s *mangaService) GetMangaHistory(ctx context.Context, id uint, userID uint) ([]*domain.MangaVersion, error) {
+	manga, err := s.mangaRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !canManageManga(ctx, s.teamRepo, manga, userID) {
+		return nil, errors.New("access denied: you can only view history of your own manga")
+	}
+
+	return s.versionRepo.ListByMangaID(ctx, id)
 }
 
-// GetMangasByUserPaginated retrieves paginated mangas by user ID
-func (s *mangaService) GetMangasByUserPaginated(userID uint, pagination *domain.PaginationRequest) (*domain.PaginatedResult[*domain.Manga], error) {
-	mangas, total, err := s.mangaRepo.GetMangasByUserIDPaginated(userID, pagination)
+// RevertManga restores a manga to the state of a prior version, recording the revert as a new version
+func (s *mangaService) RevertManga(ctx context.Context, id uint, version int, userID uint) (*domain.Manga, error) {
+	target, err := s.versionRepo.GetByVersion(ctx, id, version)
+	if err != nil {
+		return nil, err
+	}
+
+	genreIDs := target.Snapshot.GenreIDs
+	if genreIDs == nil {
+		genreIDs = []uint{}
+	}
+
+	return s.UpdateManga(ctx, id, &domain.UpdateMangaRequest{
+		Name:     target.Snapshot.Name,
+		Price:    target.Snapshot.Price,
+		IsActive: target.Snapshot.IsActive,
+		GenreIDs: genreIDs,
+
+		PublicationStatus: target.Snapshot.PublicationStatus,
+		ContentRating:     target.Snapshot.ContentRating,
+	}, userID)
+}
+
+// DeleteManga deletes a manga by ID
+func (s *mangaService) DeleteManga(ctx context.Context, id uint, userID uint) error {
+	// Get existing manga
+	manga, err := s.mangaRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Check ownership (user can only delete their own or their team's manga)
+	if !canManageManga(ctx, s.teamRepo, manga, userID) {
+		return errors.New("access denied: you can only delete your own manga")
+	}
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.mangaRepo.Delete(ctx, id); err != nil {
+			return err
+		}
+		if err := s.audit.Record(ctx, "manga.delete", id, manga.Sanitize(), nil); err != nil {
+			return err
+		}
+		return s.bus.Publish(ctx, domain.MangaDeleted{Manga: manga})
+	})
+	if err != nil {
+		return err
+	}
+
+	s.invalidateCache(id)
+
+	return nil
+}
+
+// loadManageable loads each manga and checks the user may manage it, recording
+// failures in the result. It returns the mangas that passed, in request order.
+func (s *mangaService) loadManageable(ctx context.Context, ids []uint, userID uint, result *domain.BatchResult) []*domain.Manga {
+	var mangas []*domain.Manga
+	seen := make(map[uint]bool, len(ids))
+
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		manga, err := s.mangaRepo.GetByID(ctx, id)
+		if err != nil {
+			result.Results = append(result.Results, domain.BatchItemResult{ID: id, Error: err.Error()})
+			continue
+		}
+		if !canManageManga(ctx, s.teamRepo, manga, userID) {
+			result.Results = append(result.Results, domain.BatchItemResult{ID: id, Error: "access denied: you can only modify your own manga"})
+			continue
+		}
+		mangas = append(mangas, manga)
+	}
+
+	return mangas
+}
+
+// mangaIDs returns the IDs of the given mangas
+func mangaIDs(mangas []*domain.Manga) []uint {
+	ids := make([]uint, len(mangas))
+	for i, manga := range mangas {
+		ids[i] = manga.ID
+	}
+	return ids
+}
+
+// finishBatch records the outcome of the applied mangas and tallies the result
+func finishBatch(result *domain.BatchResult, mangas []*domain.Manga, err error) {
+	for _, manga := range mangas {
+		item := domain.BatchItemResult{ID: manga.ID, Success: err == nil}
+		if err != nil {
+			item.Error = err.Error()
+		}
+		result.Results = append(result.Results, item)
+	}
+
+	for _, item := range result.Results {
+		if item.Success {
+			result.Succeeded++
+		} else {
+			result.Failed++
+		}
+	}
+}
+
+// BatchUpdateMangas applies a partial update to every manga the user may manage in a
+// single transaction; mangas failing the ownership check are reported and skipped
+func (s *mangaService) BatchUpdateMangas(ctx context.Context, req *domain.BatchUpdateMangasRequest, userID uint) (*domain.BatchResult, error) {
+	if req.Update.IsEmpty() {
+		return nil, errors.New("update must change at least one field")
+	}
+
+	result := &domain.BatchResult{}
+	mangas := s.loadManageable(ctx, req.IDs, userID, result)
+
+	befores := make([]domain.MangaSnapshot, len(mangas))
+	auditBefores := make([]json.RawMessage, len(mangas))
+	for i, manga := range mangas {
+		befores[i] = domain.NewMangaSnapshot(manga)
+		auditBefores[i] = auditState(manga.Sanitize())
+		if req.Update.Name != nil {
+			manga.Name = *req.Update.Name
+		}
+		if req.Update.Price != nil {
+			manga.Price = *req.Update.Price
+		}
+		if req.Update.IsActive != nil {
+			manga.IsActive = *req.Update.IsActive
+		}
+		if req.Update.PublicationStatus != nil {
+			manga.PublicationStatus = *req.Update.PublicationStatus
+		}
+		if req.Update.ContentRating != nil {
+			manga.ContentRating = *req.Update.ContentRating
+		}
+	}
+
+	var err error
+	if len(mangas) > 0 {
+		// The updates are audited in the same transaction
+		err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+			if err := s.mangaRepo.UpdateMany(ctx, mangas); err != nil {
+				return err
+			}
+			for i, manga := range mangas {
+				if err := s.recordPriceChange(ctx, manga, befores[i].Price, userID); err != nil {
+					return err
+				}
+				if err := s.recordVersion(ctx, manga, befores[i], userID); err != nil {
+					return err
+				}
+				if err := s.notifyPriceDrop(ctx, manga, befores[i].Price); err != nil {
+					return err
+				}
+				if err := s.audit.Record(ctx, "manga.update", manga.ID, auditBefores[i], manga.Sanitize()); err != nil {
+					return err
+				}
+				if err := s.bus.Publish(ctx, domain.MangaUpdated{Manga: manga}); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+	finishBatch(result, mangas, err)
+	if err != nil {
+		return result, nil
+	}
+
+	s.invalidateCache(mangaIDs(mangas)...)
+
+	return result, nil
+}
+
+// BatchDeleteMangas soft deletes every manga the user may manage in a single
+// transaction; mangas failing the ownership check are reported and skipped
+func (s *mangaService) BatchDeleteMangas(ctx context.Context, ids []uint, userID uint) (*domain.BatchResult, error) {
+	result := &domain.BatchResult{}
+	mangas := s.loadManageable(ctx, ids, userID, result)
+
+	var err error
+	if len(mangas) > 0 {
+		err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+			if err := s.mangaRepo.DeleteMany(ctx, mangaIDs(mangas)); err != nil {
+				return err
+			}
+			for _, manga := range mangas {
+				if err := s.audit.Record(ctx, "manga.delete", manga.ID, manga.Sanitize(), nil); err != nil {
+					return err
+				}
+				if err := s.bus.Publish(ctx, domain.MangaDeleted{Manga: manga}); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+	finishBatch(result, mangas, err)
+	if err != nil {
+		return result, nil
+	}
+
+	s.invalidateCache(mangaIDs(mangas)...)
+
+	return result, nil
+}
+
+// GetDeletedMangas retrieves the user's deleted mangas (or all deleted mangas, for admins)
+func (s *mangaService) GetDeletedMangas(ctx context.Context, userID uint, isAdmin bool, pagination *domain.PaginationRequest) (*domain.PaginatedResult[*domain.Manga], error) {
+	var owner *uint
+	if !isAdmin {
+		owner = &userID
+	}
+
+	mangas, total, err := s.mangaRepo.GetDeletedPaginated(ctx, owner, pagination)
 	if err != nil {
 		return nil, err
 	}
@@ -210,18 +1042,75 @@ func (s *mangaService) GetMangasByUserPaginated(userID uint, pagination *domain.
 		sanitizedMangas[i] = manga.Sanitize()
 	}
 
-	// Create pagination metadata
-	paginationMeta := domain.NewPaginationResponse(pagination.Page, pagination.PageSize, total)
-
 	return &domain.PaginatedResult[*domain.Manga]{
 		Data:       sanitizedMangas,
-		Pagination: paginationMeta,
+		Pagination: domain.NewPaginationResponse(pagination.Page, pagination.PageSize, total),
 	}, nil
 }
 
-// GetMangasByPriceRangePaginated retrieves paginated mangas within price range
-func (s *mangaService) GetMangasByPriceRangePaginated(min, max float64, pagination *domain.PaginationRequest) (*domain.PaginatedResult[*domain.Manga], error) {
-	mangas, total, err := s.mangaRepo.GetMangasByPriceRangePaginated(min, max, pagination)
+// RestoreManga undeletes a manga along with the chapters and comments deleted with it
+func (s *mangaService) RestoreManga(ctx context.Context, id uint, userID uint, isAdmin bool) (*domain.Manga, error) {
+	manga, err := s.mangaRepo.GetDeletedByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check ownership (admins may restore any manga)
+	if !isAdmin && !canManageManga(ctx, s.teamRepo, manga, userID) {
+		return nil, errors.New("access denied: you can only restore your own manga")
+	}
+
+	var restored *domain.Manga
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.mangaRepo.Restore(ctx, manga); err != nil {
+			return err
+		}
+
+		var err error
+		restored, err = s.mangaRepo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := s.audit.Record(ctx, "manga.restore", id, nil, restored.Sanitize()); err != nil {
+			return err
+		}
+		return s.bus.Publish(ctx, domain.MangaCreated{Manga: restored})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidateCache(restored.ID)
+
+	return restored.Sanitize(), nil
+}
+
+// PurgeManga permanently deletes a manga and all of its dependent data (admin only)
+func (s *mangaService) PurgeManga(ctx context.Context, id uint) error {
+	manga, err := s.mangaRepo.GetByID(ctx, id)
+	if err != nil {
+		if manga, err = s.mangaRepo.GetDeletedByID(ctx, id); err != nil {
+			return errors.New("manga not found")
+		}
+	}
+
+	if err := s.mangaRepo.Purge(ctx, id); err != nil {
+		return err
+	}
+	recordAudit(ctx, s.audit, "manga.purge", id, manga.Sanitize(), nil)
+
+	s.invalidateCache(id)
+	return nil
+}
+
+// GetRecommendations retrieves mangas similar to the given one
+func (s *mangaService) GetRecommendations(ctx context.Context, id uint, limit int) ([]*domain.Manga, error) {
+	manga, err := s.mangaRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	mangas, err := s.mangaRepo.GetSimilar(ctx, manga, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -231,12 +1120,24 @@ func (s *mangaService) GetMangasByPriceRangePaginated(min, max float64, paginati
 	for i, manga := range mangas {
 		sanitizedMangas[i] = manga.Sanitize()
 	}
+	s.applyDiscounts(ctx, sanitizedMangas...)
 
-	// Create pagination metadata
-	paginationMeta := domain.NewPaginationResponse(pagination.Page, pagination.PageSize, total)
+	return sanitizedMangas, nil
+}
 
-	return &domain.PaginatedResult[*domain.Manga]{
-		Data:       sanitizedMangas,
-		Pagination: paginationMeta,
-	}, nil
+// GetUserRecommendations retrieves mangas recommended from the user's reviews and reading
+func (s *mangaService) GetUserRecommendations(ctx context.Context, userID uint, limit int) ([]*domain.Manga, error) {
+	mangas, err := s.mangaRepo.GetRecommendedForUser(ctx, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sanitize all mangas
+	sanitizedMangas := make([]*domain.Manga, len(mangas))
+	for i, manga := range mangas {
+		sanitizedMangas[i] = manga.Sanitize()
+	}
+	s.applyDiscounts(ctx, sanitizedMangas...)
+
+	return sanitizedMangas, nil
 }
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// mangaTranslationService implements the MangaTranslationService interface
+type mangaTranslationService struct {
+	translationRepo ports.MangaTranslationRepository
+	mangaRepo       ports.MangaRepository
+	teamRepo        ports.TeamRepository
+	audit           ports.AuditService
+}
+
+// NewMangaTranslationService creates a new manga translation service instance
+func NewMangaTranslationService(translationRepo ports.MangaTranslationRepository, mangaRepo ports.MangaRepository, teamRepo ports.TeamRepository, audit ports.AuditService) ports.MangaTranslationService {
+	return &mangaTranslationService{
+		translationRepo: translationRepo,
+		mangaRepo:       mangaRepo,
+		teamRepo:        teamRepo,
+		audit:           audit,
+	}
+}
+
+// checkManagedManga checks the manga exists and the user may manage its translations
+func (s *mangaTranslationService) checkManagedManga(ctx context.Context, mangaID uint, userID uint) error {
+	manga, err := s.mangaRepo.GetByID(ctx, mangaID)
+	if err != nil {
+		return err
+	}
+	if !canManageManga(ctx, s.teamRepo, manga, userID) {
+		return errors.New("access denied: you can only manage translations of your own manga")
+	}
+	return nil
+}
+
+// checkLocale rejects locales the catalogue is not translated into
+func checkLocale(locale string) error {
+	if !domain.IsValidMangaLocale(locale) {
+		return errors.New("locale must be one of " + strings.Join(domain.MangaLocales, ", "))
+	}
+	return nil
+}
+
+// GetTranslations retrieves every translation of a manga
+func (s *mangaTranslationService) GetTranslations(ctx context.Context, mangaID uint) ([]*domain.MangaTranslation, error) {
+	if _, err := s.mangaRepo.GetByID(ctx, mangaID); err != nil {
+		return nil, err
+	}
+
+	return s.translationRepo.ListByMangaID(ctx, mangaID)
+}
+
+// SaveTranslation creates or replaces a manga's translation in one locale
+func (s *mangaTranslationService) SaveTranslation(ctx context.Context, mangaID uint, locale string, req *domain.SaveMangaTranslationRequest, userID uint) (*domain.MangaTranslation, error) {
+	if err := checkLocale(locale); err != nil {
+		return nil, err
+	}
+	if err := s.checkManagedManga(ctx, mangaID, userID); err != nil {
+		return nil, err
+	}
+
+	translation := &domain.MangaTranslation{
+		MangaID:     mangaID,
+		Locale:      locale,
+		Name:        strings.TrimSpace(req.Name),
+		Description: strings.TrimSpace(req.Description),
+	}
+	if translation.Name == "" {
+		return nil, errors.New("name is required")
+	}
+
+	// A translation not saved before has no before state
+	before, _ := s.translationRepo.GetByLocale(ctx, mangaID, locale)
+
+	if err := s.translationRepo.Upsert(ctx, translation); err != nil {
+		return nil, err
+	}
+
+	// Reload so a replaced translation reports its original creation time
+	saved, err := s.translationRepo.GetByLocale(ctx, mangaID, locale)
+	if err != nil {
+		return nil, err
+	}
+	recordAudit(ctx, s.audit, "manga_translation.save", saved.ID, before, saved)
+
+	return saved, nil
+}
+
+// DeleteTranslation removes a manga's translation in one locale
+func (s *mangaTranslationService) DeleteTranslation(ctx context.Context, mangaID uint, locale string, userID uint) error {
+	if err := checkLocale(locale); err != nil {
+		return err
+	}
+	if err := s.checkManagedManga(ctx, mangaID, userID); err != nil {
+		return err
+	}
+
+	translation, err := s.translationRepo.GetByLocale(ctx, mangaID, locale)
+	if err != nil {
+		return err
+	}
+	if err := s.translationRepo.Delete(ctx, mangaID, locale); err != nil {
+		return err
+	}
+	recordAudit(ctx, s.audit, "manga_translation.delete", translation.ID, translation, nil)
+	return nil
+}
@@ -0,0 +1,20 @@
+package services
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// noopErrorReporter implements the ErrorReporter interface by dropping every
+// report, for when no error tracker is configured
+type noopErrorReporter struct{}
+
+// NewNoopErrorReporter creates an error reporter that reports nothing
+func NewNoopErrorReporter() ports.ErrorReporter {
+	return noopErrorReporter{}
+}
+
+// Capture drops the report
+func (noopErrorReporter) Capture(ctx context.Context, report *domain.ErrorReport) {}
@@ -0,0 +1,26 @@
+package services
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// noopTransactionManager implements the TransactionManager interface by running
+// functions directly, for unit testing services against in-memory repositories
+type noopTransactionManager struct{}
+
+// NewNoopTransactionManager creates a transaction manager that opens no transactions
+func NewNoopTransactionManager() ports.TransactionManager {
+	return noopTransactionManager{}
+}
+
+// WithinTransaction runs fn with the given context
+func (noopTransactionManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// AfterCommit runs fn right away, as there is no transaction to wait for
+func (noopTransactionManager) AfterCommit(ctx context.Context, fn func(ctx context.Context)) {
+	fn(ctx)
+}
@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/internal/utils"
+)
+
+// notificationDispatcher implements the NotificationDispatcher interface
+type notificationDispatcher struct {
+	prefsRepo    ports.NotificationPreferenceRepository
+	wishlistRepo ports.WishlistRepository
+	mangaRepo    ports.MangaRepository
+	jobs         ports.JobQueue
+	channels     map[string]ports.NotificationChannel
+}
+
+// NewNotificationDispatcher creates a new notification dispatcher instance
+// that turns order updates, moderation and price drops on bus into
+// notifications, and delivers them on the channels each user routes them
+// to. Channels missing from channels, such as LINE while it is off, are
+// skipped. It registers the handler of the jobs delivering notifications
+// held through quiet hours.
+func NewNotificationDispatcher(prefsRepo ports.NotificationPreferenceRepository, wishlistRepo ports.WishlistRepository, mangaRepo ports.MangaRepository, bus ports.EventBus, jobs ports.JobService, channels map[string]ports.NotificationChannel) ports.NotificationDispatcher {
+	d := &notificationDispatcher{
+		prefsRepo:    prefsRepo,
+		wishlistRepo: wishlistRepo,
+		mangaRepo:    mangaRepo,
+		jobs:         jobs,
+		channels:     channels,
+	}
+	SubscribeAfterCommit(bus, func(ctx context.Context, event domain.OrderPaid) error {
+		return d.dispatchOrder(ctx, domain.EventOrderPaid, event.Order)
+	})
+	SubscribeAfterCommit(bus, func(ctx context.Context, event domain.OrderShipped) error {
+		return d.dispatchOrder(ctx, domain.EventOrderShipped, event.Order)
+	})
+	SubscribeAfterCommit(bus, func(ctx context.Context, event domain.OrderDelivered) error {
+		return d.dispatchOrder(ctx, domain.EventOrderDelivered, event.Order)
+	})
+	SubscribeAfterCommit(bus, func(ctx context.Context, event domain.MangaReviewed) error {
+		return d.Dispatch(ctx, &domain.Notification{
+			UserID: event.Manga.UserCreated,
+			Type:   domain.NotificationModeration,
+			Event:  domain.EventMangaReviewed,
+			Manga:  event.Manga.Sanitize(),
+		})
+	})
+	SubscribeAfterCommit(bus, func(ctx context.Context, event domain.CommentRemoved) error {
+		manga, err := d.mangaRepo.GetByID(ctx, event.Comment.MangaID)
+		if err != nil {
+			return err
+		}
+		return d.Dispatch(ctx, &domain.Notification{
+			UserID:  event.Comment.UserID,
+			Type:    domain.NotificationModeration,
+			Event:   domain.EventCommentRemoved,
+			Manga:   manga.Sanitize(),
+			Comment: event.Comment,
+		})
+	})
+	SubscribeAfterCommit(bus, d.dispatchPriceDrop)
+	HandleJob(jobs, d.deliverNotification)
+	return d
+}
+
+// Dispatch delivers the notification on each channel the user routes its
+// type to. During the user's quiet hours, it is queued for when they end on
+// every channel but in_app, which doesn't interrupt anyone. A channel that
+// fails doesn't stop the others.
+func (d *notificationDispatcher) Dispatch(ctx context.Context, n *domain.Notification) error {
+	prefs, err := notificationPreferences(ctx, d.prefsRepo, n.UserID)
+	if err != nil {
+		return err
+	}
+
+	quietUntil := prefs.QuietUntil(time.Now())
+	for _, name := range prefs.RouteFor(n.Type) {
+		channel, ok := d.channels[name]
+		if !ok {
+			continue
+		}
+		if name != domain.ChannelInApp && !quietUntil.IsZero() {
+			args := domain.DeliverNotificationArgs{Channel: name, Notification: *n}
+			if _, err := d.jobs.Enqueue(ctx, args, &domain.JobOptions{UserID: &n.UserID, RunAt: quietUntil}); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := channel.Deliver(ctx, n); err != nil {
+			utils.Logf(ctx, "%s notification on %s failed: %v", n.Event, name, err)
+		}
+	}
+	return nil
+}
+
+// dispatchOrder notifies the buyer of an update of their order
+func (d *notificationDispatcher) dispatchOrder(ctx context.Context, event string, order *domain.Order) error {
+	return d.Dispatch(ctx, &domain.Notification{
+		UserID: order.UserID,
+		Type:   domain.NotificationOrderUpdates,
+		Event:  event,
+		Order:  order,
+	})
+}
+
+// dispatchPriceDrop notifies each wishlister of the manga that it got cheaper
+func (d *notificationDispatcher) dispatchPriceDrop(ctx context.Context, event domain.MangaPriceDropped) error {
+	userIDs, err := d.wishlistRepo.ListUserIDsByManga(ctx, event.Manga.ID)
+	if err != nil {
+		return err
+	}
+	drop := &domain.WishlistPriceDrop{Manga: event.Manga.Sanitize(), OldPrice: event.OldPrice, NewPrice: event.Manga.Price}
+	for _, userID := range userIDs {
+		err := d.Dispatch(ctx, &domain.Notification{
+			UserID:    userID,
+			Type:      domain.NotificationPriceDrops,
+			Event:     domain.EventWishlistPriceDropped,
+			PriceDrop: drop,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deliverNotification delivers a notification held through quiet hours,
+// unless the user has routed its type off the channel since
+func (d *notificationDispatcher) deliverNotification(ctx context.Context, job *domain.Job, args domain.DeliverNotificationArgs) error {
+	channel, ok := d.channels[args.Channel]
+	if !ok {
+		return nil
+	}
+
+	n := &args.Notification
+	prefs, err := notificationPreferences(ctx, d.prefsRepo, n.UserID)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(prefs.RouteFor(n.Type), args.Channel) {
+		return nil
+	}
+	return channel.Deliver(ctx, n)
+}
@@ -0,0 +1,196 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/internal/utils"
+)
+
+// notificationService implements the NotificationService interface
+type notificationService struct {
+	prefsRepo ports.NotificationPreferenceRepository
+	userRepo  ports.UserRepository
+	jobs      ports.JobQueue
+	renderer  ports.EmailRenderer
+	sender    ports.EmailSender
+	baseURL   string
+}
+
+// NewNotificationService creates a new notification service instance, starts
+// users registered on bus on the onboarding emails, and registers the handler
+// of the jobs that send them. Unsubscribe links in emails point at baseURL,
+// the public URL of the API.
+func NewNotificationService(prefsRepo ports.NotificationPreferenceRepository, userRepo ports.UserRepository, bus ports.EventBus, jobs ports.JobService, renderer ports.EmailRenderer, sender ports.EmailSender, baseURL string) ports.NotificationService {
+	s := &notificationService{
+		prefsRepo: prefsRepo,
+		userRepo:  userRepo,
+		jobs:      jobs,
+		renderer:  renderer,
+		sender:    sender,
+		baseURL:   strings.TrimSuffix(baseURL, "/"),
+	}
+	SubscribeAfterCommit(bus, func(ctx context.Context, event domain.UserRegistered) error {
+		return s.StartOnboarding(ctx, event.User)
+	})
+	HandleJob(jobs, s.sendOnboardingEmail)
+	return s
+}
+
+// GetPreferences retrieves a user's preferences, the defaults when they have none stored
+func (s *notificationService) GetPreferences(ctx context.Context, userID uint) (*domain.NotificationPreferences, error) {
+	return notificationPreferences(ctx, s.prefsRepo, userID)
+}
+
+// notificationPreferences retrieves a user's preferences from prefsRepo, the
+// defaults when they have none stored
+func notificationPreferences(ctx context.Context, prefsRepo ports.NotificationPreferenceRepository, userID uint) (*domain.NotificationPreferences, error) {
+	prefs, err := prefsRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if strings.HasSuffix(err.Error(), "not found") {
+			return domain.DefaultNotificationPreferences(userID), nil
+		}
+		return nil, err
+	}
+	return prefs, nil
+}
+
+// UpdatePreferences changes the preferences given in the request
+func (s *notificationService) UpdatePreferences(ctx context.Context, userID uint, req *domain.UpdateNotificationPreferencesRequest) (*domain.NotificationPreferences, error) {
+	prefs, err := s.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.OnboardingEmails != nil {
+		prefs.OnboardingEmails = *req.OnboardingEmails
+	}
+	if req.SellerReports != nil {
+		prefs.SellerReports = *req.SellerReports
+	}
+	if req.Digests != nil {
+		prefs.Digests = *req.Digests
+	}
+	if req.DigestDelivery != nil {
+		prefs.DigestDelivery = *req.DigestDelivery
+	}
+	for notificationType, channels := range req.Routes {
+		route := []string{}
+		for _, channel := range channels {
+			if !domain.CanRoute(notificationType, channel) {
+				return nil, fmt.Errorf("%w: %s can't be sent by %s", domain.ErrInvalidNotificationRoute, notificationType, channel)
+			}
+			if !slices.Contains(route, channel) {
+				route = append(route, channel)
+			}
+		}
+		if prefs.Routes == nil {
+			prefs.Routes = make(map[string][]string)
+		}
+		prefs.Routes[notificationType] = route
+	}
+	if req.QuietHoursStart != nil {
+		prefs.QuietHoursStart = *req.QuietHoursStart
+	}
+	if req.QuietHoursEnd != nil {
+		prefs.QuietHoursEnd = *req.QuietHoursEnd
+	}
+	if req.TimeZone != nil {
+		prefs.TimeZone = *req.TimeZone
+	}
+
+	if err := s.prefsRepo.Save(ctx, prefs); err != nil {
+		return nil, err
+	}
+	return prefs, nil
+}
+
+// Unsubscribe stops the category of email the token was issued for
+func (s *notificationService) Unsubscribe(ctx context.Context, token string) (string, error) {
+	userID, category, err := utils.ParseUnsubscribeToken(token)
+	if err != nil || !slices.Contains(domain.NotificationCategories, category) {
+		return "", errors.New("invalid unsubscribe token")
+	}
+
+	prefs, err := s.GetPreferences(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	if !prefs.Allows(category) {
+		return category, nil
+	}
+
+	prefs.Unsubscribe(category)
+	if err := s.prefsRepo.Save(ctx, prefs); err != nil {
+		return "", err
+	}
+	return category, nil
+}
+
+// StartOnboarding queues each email of the onboarding sequence for its time.
+// Whether the user still receives onboarding emails is checked when each
+// one is due.
+func (s *notificationService) StartOnboarding(ctx context.Context, user *domain.User) error {
+	now := time.Now()
+	for _, step := range domain.OnboardingSequence {
+		args := domain.SendOnboardingEmailArgs{UserID: user.ID, Email: step.Email}
+		if _, err := s.jobs.Enqueue(ctx, args, &domain.JobOptions{RunAt: now.Add(step.Delay)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sendOnboardingEmail renders an onboarding email in the user's locale and
+// sends it, unless the user is gone or has unsubscribed since registering
+func (s *notificationService) sendOnboardingEmail(ctx context.Context, job *domain.Job, args domain.SendOnboardingEmailArgs) error {
+	user, err := s.userRepo.GetByID(ctx, args.UserID)
+	if err != nil {
+		if strings.HasSuffix(err.Error(), "not found") {
+			return nil
+		}
+		return err
+	}
+	if user.IsSuspended(time.Now()) {
+		return nil
+	}
+
+	prefs, err := s.GetPreferences(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	if !prefs.Allows(domain.NotificationOnboarding) {
+		return nil
+	}
+
+	unsubscribeURL, err := unsubscribeURL(s.baseURL, user.ID, domain.NotificationOnboarding)
+	if err != nil {
+		return err
+	}
+	msg, err := s.renderer.Render(args.Email, user.Locale, &domain.EmailData{User: user, UnsubscribeURL: unsubscribeURL})
+	if err != nil {
+		// Rendering again won't fix a template
+		return fmt.Errorf("%w: %v", domain.ErrPermanentJobFailure, err)
+	}
+
+	msg.To = user.Email
+	msg.UnsubscribeURL = unsubscribeURL
+	return s.sender.Send(ctx, msg)
+}
+
+// unsubscribeURL returns the link on the API at baseURL that unsubscribes the
+// user from a category of email
+func unsubscribeURL(baseURL string, userID uint, category string) (string, error) {
+	token, err := utils.GenerateUnsubscribeToken(userID, category)
+	if err != nil {
+		return "", err
+	}
+	return baseURL + "/api/v1/notifications/unsubscribe?token=" + url.QueryEscape(token), nil
+}
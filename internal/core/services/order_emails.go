@@ -0,0 +1,23 @@
+package services
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// SubscribeOrderEmails emails buyers a receipt in their locale once an order
+// is placed. Receipts are always sent; later order updates go through the
+// notification dispatcher. The sender should queue messages so order requests
+// are not held up by the mail server.
+func SubscribeOrderEmails(bus ports.EventBus, userRepo ports.UserRepository, renderer ports.EmailRenderer, sender ports.EmailSender) {
+	e := &emailChannel{
+		userRepo: userRepo,
+		renderer: renderer,
+		sender:   sender,
+	}
+	SubscribeAfterCommit(bus, func(ctx context.Context, event domain.OrderPlaced) error {
+		return e.send(ctx, domain.EmailOrderPlaced, event.Order.UserID, &domain.EmailData{Order: event.Order})
+	})
+}
@@ -0,0 +1,353 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/internal/utils"
+)
+
+// orderService implements the OrderService interface
+type orderService struct {
+	orderRepo    ports.OrderRepository
+	cartRepo     ports.CartRepository
+	mangaRepo    ports.MangaRepository
+	discountRepo ports.DiscountRepository
+	taxRepo      ports.TaxRateRepository
+	bus          ports.EventBus
+	tx           ports.TransactionManager
+	audit        ports.AuditService
+
+	// reservationTTL is how long checkout holds stock for an unpaid order
+	reservationTTL time.Duration
+}
+
+// NewOrderService creates a new order service instance. Order events are
+// published on bus in the transaction of the change.
+func NewOrderService(orderRepo ports.OrderRepository, cartRepo ports.CartRepository, mangaRepo ports.MangaRepository, discountRepo ports.DiscountRepository, taxRepo ports.TaxRateRepository, bus ports.EventBus, tx ports.TransactionManager, audit ports.AuditService, reservationTTL time.Duration) ports.OrderService {
+	return &orderService{
+		orderRepo:      orderRepo,
+		cartRepo:       cartRepo,
+		mangaRepo:      mangaRepo,
+		discountRepo:   discountRepo,
+		taxRepo:        taxRepo,
+		bus:            bus,
+		tx:             tx,
+		audit:          audit,
+		reservationTTL: reservationTTL,
+	}
+}
+
+// Checkout orders the user's cart: it prices the mangas at their current
+// effective price, adds tax for the buyer's location and places the order,
+// reserving the items' stock until the order is paid or the reservation
+// lapses. The cart is emptied in the same transaction.
+func (s *orderService) Checkout(ctx context.Context, req *domain.CheckoutRequest, userID uint) (*domain.Order, error) {
+	items, err := s.cartRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, errors.New("cart is empty")
+	}
+
+	mangas := make([]*domain.Manga, 0, len(items))
+	quantities := make(map[uint]int, len(items))
+	for _, item := range items {
+		manga, err := s.mangaRepo.GetByID(ctx, item.MangaID)
+		if err != nil {
+			return nil, err
+		}
+		if manga.Status != domain.MangaStatusPublished || !manga.IsActive {
+			return nil, fmt.Errorf("manga %d is not available for purchase", item.MangaID)
+		}
+		mangas = append(mangas, manga)
+		quantities[manga.ID] = item.Quantity
+	}
+
+	if discounts, err := s.discountRepo.ListActive(ctx, time.Now()); err == nil {
+		domain.ApplyDiscounts(mangas, discounts)
+	}
+
+	reservedUntil := time.Now().Add(s.reservationTTL)
+	order := &domain.Order{
+		UserID:        userID,
+		Status:        domain.OrderStatusPending,
+		ReservedUntil: &reservedUntil,
+	}
+	for _, manga := range mangas {
+		order.AddItem(manga, quantities[manga.ID])
+	}
+
+	// Tax at the rate for the buyer's location; places without a rate are untaxed
+	if req.Country != "" {
+		country, region := domain.NormalizeTaxLocation(req.Country, req.Region)
+		if rate, err := s.taxRepo.FindForLocation(ctx, country, region); err == nil {
+			order.ApplyTax(rate)
+		}
+	}
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.orderRepo.Create(ctx, order); err != nil {
+			return err
+		}
+		if err := s.cartRepo.Clear(ctx, userID); err != nil {
+			return err
+		}
+		if err := s.audit.Record(ctx, "order.checkout", order.ID, nil, order); err != nil {
+			return err
+		}
+		return s.bus.Publish(ctx, domain.OrderPlaced{Order: order})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// viewOrder returns the order as the user may see it: in full for its buyer
+// and admins, limited to their own items for sellers
+func viewOrder(order *domain.Order, userID uint, isAdmin bool) (*domain.Order, error) {
+	if order.UserID == userID || isAdmin {
+		return order, nil
+	}
+	view := order.ForSeller(userID)
+	if len(view.Items) == 0 {
+		return nil, errors.New("order not found")
+	}
+	return view, nil
+}
+
+// GetOrder retrieves an order visible to the user, looking in the archive
+// when it is no longer live
+func (s *orderService) GetOrder(ctx context.Context, id uint, userID uint, isAdmin bool) (*domain.Order, error) {
+	order, err := s.orderRepo.GetByID(ctx, id)
+	if errors.Is(err, domain.ErrOrderNotFound) {
+		order, err = s.orderRepo.GetArchivedByID(ctx, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return viewOrder(order, userID, isAdmin)
+}
+
+// GetInvoice builds the invoice of a paid order visible to the user
+func (s *orderService) GetInvoice(ctx context.Context, id uint, userID uint, isAdmin bool) (*domain.Invoice, error) {
+	order, err := s.GetOrder(ctx, id, userID, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(domain.InvoiceableOrderStatuses, order.Status) {
+		return nil, domain.ErrOrderNotPaid
+	}
+
+	var sellerID *uint
+	if order.UserID != userID && !isAdmin {
+		sellerID = &userID
+	}
+	return domain.NewInvoice(order, sellerID), nil
+}
+
+// GetMyOrders retrieves the user's own live or archived orders, newest first
+func (s *orderService) GetMyOrders(ctx context.Context, userID uint, pagination *domain.PaginationRequest, archived bool) (*domain.PaginatedResult[*domain.Order], error) {
+	list := s.orderRepo.ListByUserIDPaginated
+	if archived {
+		list = s.orderRepo.ListArchivedByUserIDPaginated
+	}
+	orders, total, err := list(ctx, userID, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.PaginatedResult[*domain.Order]{
+		Data:       orders,
+		Pagination: domain.NewPaginationResponse(pagination.Page, pagination.PageSize, total),
+	}, nil
+}
+
+// salesExportBatchSize is the number of sold items loaded per export chunk
+const salesExportBatchSize = 1000
+
+// ExportSales streams the items the user sold in orders placed from from
+// until to to fn in chunks
+func (s *orderService) ExportSales(ctx context.Context, userID uint, from, to time.Time, fn func(rows []*domain.SalesReportRow, total int64) error) error {
+	if !from.Before(to) {
+		return errors.New("report period must end after it starts")
+	}
+	if to.Sub(from) > domain.MaxSalesReportPeriod {
+		return errors.New("report period must not exceed a year")
+	}
+	return s.orderRepo.ExportSellerSales(ctx, userID, from, to, salesExportBatchSize, fn)
+}
+
+// GetSellerOrders retrieves orders containing the user's mangas, showing only the user's items
+func (s *orderService) GetSellerOrders(ctx context.Context, userID uint, pagination *domain.PaginationRequest) (*domain.PaginatedResult[*domain.Order], error) {
+	orders, total, err := s.orderRepo.ListBySellerPaginated(ctx, userID, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, order := range orders {
+		orders[i] = order.ForSeller(userID)
+	}
+
+	return &domain.PaginatedResult[*domain.Order]{
+		Data:       orders,
+		Pagination: domain.NewPaginationResponse(pagination.Page, pagination.PageSize, total),
+	}, nil
+}
+
+// checkOrderTransition verifies the user may move the order to the given status.
+// Buyers cancel their pending orders and confirm delivery of shipped ones,
+// sellers fulfill or cancel orders made up entirely of their mangas, and admins may do anything.
+// Only admins mark orders paid, once they have confirmed the payment: there is
+// no payment provider to confirm it, and paid orders count as revenue.
+func checkOrderTransition(order *domain.Order, to string, userID uint, isAdmin bool) error {
+	if isAdmin {
+		return nil
+	}
+
+	isBuyer := order.UserID == userID
+	isSeller := order.SoldBy(userID)
+
+	switch to {
+	case domain.OrderStatusPaid:
+		return errors.New("access denied: only admins can mark an order paid")
+	case domain.OrderStatusFulfilled:
+		if isSeller || (isBuyer && order.Status == domain.OrderStatusShipped) {
+			return nil
+		}
+		return errors.New("access denied: only the seller can fulfill an order before it ships")
+	case domain.OrderStatusCancelled:
+		if isSeller || (isBuyer && order.Status == domain.OrderStatusPending) {
+			return nil
+		}
+		return errors.New("access denied: only pending orders can be cancelled by the buyer")
+	}
+	return nil
+}
+
+// UpdateOrderStatus moves an order through pending → paid → (shipped →) fulfilled, or cancels it
+func (s *orderService) UpdateOrderStatus(ctx context.Context, id uint, req *domain.UpdateOrderStatusRequest, userID uint, isAdmin bool) (*domain.Order, error) {
+	order, err := s.orderRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := viewOrder(order, userID, isAdmin); err != nil {
+		return nil, err
+	}
+	if err := checkOrderTransition(order, req.Status, userID, isAdmin); err != nil {
+		return nil, err
+	}
+
+	before := auditState(order)
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.orderRepo.UpdateStatus(ctx, id, domain.OrderStatusesFrom(req.Status), req.Status); err != nil {
+			return err
+		}
+
+		var err error
+		order, err = s.orderRepo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := s.audit.Record(ctx, "order.update_status", id, before, order); err != nil {
+			return err
+		}
+
+		switch req.Status {
+		case domain.OrderStatusPaid:
+			return s.bus.Publish(ctx, domain.OrderPaid{Order: order})
+		case domain.OrderStatusFulfilled:
+			return s.bus.Publish(ctx, domain.OrderDelivered{Order: order})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return viewOrder(order, userID, isAdmin)
+}
+
+// ShipOrder attaches tracking details to a paid order and marks it shipped.
+// Calling it again on a shipped order corrects the tracking details.
+func (s *orderService) ShipOrder(ctx context.Context, id uint, req *domain.ShipOrderRequest, userID uint, isAdmin bool) (*domain.Order, error) {
+	order, err := s.orderRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := viewOrder(order, userID, isAdmin); err != nil {
+		return nil, err
+	}
+	if !isAdmin && !order.SoldBy(userID) {
+		return nil, errors.New("access denied: only the seller can ship an order")
+	}
+
+	shipment := &domain.Shipment{
+		Carrier:        req.Carrier,
+		TrackingNumber: req.TrackingNumber,
+		TrackingURL:    req.TrackingURL,
+	}
+	before := auditState(order)
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.orderRepo.Ship(ctx, id, shipment); err != nil {
+			return err
+		}
+
+		var err error
+		order, err = s.orderRepo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := s.audit.Record(ctx, "order.ship", id, before, order); err != nil {
+			return err
+		}
+		return s.bus.Publish(ctx, domain.OrderShipped{Order: order})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return viewOrder(order, userID, isAdmin)
+}
+
+// StartReservationSweeper cancels unpaid orders whose reservation lapsed, returning
+// their stock, in the background at the given interval
+func (s *orderService) StartReservationSweeper(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			s.releaseExpiredReservations(context.Background())
+		}
+	}()
+}
+
+// releaseExpiredReservations cancels every pending order with a lapsed reservation
+func (s *orderService) releaseExpiredReservations(ctx context.Context) {
+	ids, err := s.orderRepo.ListExpiredReservations(ctx, time.Now())
+	if err != nil {
+		utils.Logf(ctx, "reservation sweep failed: %v", err)
+		return
+	}
+
+	for _, id := range ids {
+		// An order paid since it was listed is no longer pending and is left alone
+		err := s.orderRepo.UpdateStatus(ctx, id, []string{domain.OrderStatusPending}, domain.OrderStatusCancelled)
+		if err != nil {
+			if !errors.Is(err, domain.ErrInvalidOrderTransition) {
+				utils.Logf(ctx, "failed to release reservation of order %d: %v", id, err)
+			}
+			continue
+		}
+		recordAudit(ctx, s.audit, "order.expire_reservation", id, nil, nil)
+	}
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// outboxPruneInterval is how often the relay deletes events past the retention period
+const outboxPruneInterval = time.Hour
+
+// outboxService implements the OutboxService interface. Events are recorded in
+// the transaction of their change, from the domain events it subscribes to or
+// by services calling Record; the relay hands committed events to the
+// dispatcher (webhooks and event streams) at least once, in the order they were
+// recorded, and keeps published events for the retention period.
+type outboxService struct {
+	outboxRepo ports.OutboxRepository
+	dispatcher ports.WebhookDispatcher
+	batchSize  int
+	retention  time.Duration
+}
+
+// NewOutboxService creates a new outbox service instance, and subscribes it to
+// the manga and order events of the bus
+func NewOutboxService(outboxRepo ports.OutboxRepository, dispatcher ports.WebhookDispatcher, bus ports.EventBus, batchSize int, retention time.Duration) ports.OutboxService {
+	s := &outboxService{
+		outboxRepo: outboxRepo,
+		dispatcher: dispatcher,
+		batchSize:  batchSize,
+		retention:  retention,
+	}
+	Subscribe(bus, func(ctx context.Context, event domain.MangaCreated) error {
+		return s.recordMangaEvent(ctx, event.Manga, event.EventName())
+	})
+	Subscribe(bus, func(ctx context.Context, event domain.MangaUpdated) error {
+		return s.recordMangaEvent(ctx, event.Manga, event.EventName())
+	})
+	Subscribe(bus, func(ctx context.Context, event domain.MangaDeleted) error {
+		return s.recordMangaEvent(ctx, event.Manga, event.EventName())
+	})
+	Subscribe(bus, func(ctx context.Context, event domain.OrderPlaced) error {
+		return s.recordOrderEvent(ctx, event.Order, event.EventName())
+	})
+	Subscribe(bus, func(ctx context.Context, event domain.OrderPaid) error {
+		if err := s.recordSellerEvents(ctx, event.Order, domain.EventMangaPurchased); err != nil {
+			return err
+		}
+		return s.recordOrderEvent(ctx, event.Order, event.EventName())
+	})
+	Subscribe(bus, func(ctx context.Context, event domain.OrderShipped) error {
+		return s.recordOrderEvent(ctx, event.Order, event.EventName())
+	})
+	Subscribe(bus, func(ctx context.Context, event domain.OrderDelivered) error {
+		return s.recordOrderEvent(ctx, event.Order, event.EventName())
+	})
+	return s
+}
+
+// Record encodes the event data right away, so the published event shows the
+// change as it was when the transaction committed
+func (s *outboxService) Record(ctx context.Context, userID uint, event string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return errors.New("failed to encode event")
+	}
+
+	return s.outboxRepo.Create(ctx, &domain.OutboxEvent{
+		UserID:  userID,
+		Event:   event,
+		Payload: string(payload),
+	})
+}
+
+// recordMangaEvent records a manga event for the manga's creator
+func (s *outboxService) recordMangaEvent(ctx context.Context, manga *domain.Manga, event string) error {
+	return s.Record(ctx, manga.UserCreated, event, manga.Sanitize())
+}
+
+// recordOrderEvent records an order event for the buyer and for each seller,
+// who only see their own items
+func (s *outboxService) recordOrderEvent(ctx context.Context, order *domain.Order, event string) error {
+	if err := s.Record(ctx, order.UserID, event, order); err != nil {
+		return err
+	}
+	return s.recordSellerEvents(ctx, order, event)
+}
+
+// recordSellerEvents records the event for each seller of an order with the items they sold
+func (s *outboxService) recordSellerEvents(ctx context.Context, order *domain.Order, event string) error {
+	notified := make(map[uint]bool)
+	for _, item := range order.Items {
+		if notified[item.SellerID] {
+			continue
+		}
+		notified[item.SellerID] = true
+		if err := s.Record(ctx, item.SellerID, event, order.ForSeller(item.SellerID)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Relay publishes the oldest batch of pending events with their recorded data
+func (s *outboxService) Relay(ctx context.Context) (int, error) {
+	return s.outboxRepo.PublishPending(ctx, s.batchSize, func(event *domain.OutboxEvent) {
+		s.dispatcher.Dispatch(event.UserID, event.Event, json.RawMessage(event.Payload))
+	})
+}
+
+// StartRelay publishes pending events in the background at the given interval,
+// working off a backlog batch by batch, and prunes old published events
+func (s *outboxService) StartRelay(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		ctx := context.Background()
+		var lastPrune time.Time
+		for range ticker.C {
+			for {
+				published, err := s.Relay(ctx)
+				if err != nil {
+					log.Printf("outbox relay failed: %v", err)
+				}
+				if err != nil || published == 0 || published < s.batchSize {
+					break
+				}
+			}
+
+			if s.retention > 0 && time.Since(lastPrune) >= outboxPruneInterval {
+				lastPrune = time.Now()
+				if _, err := s.outboxRepo.DeletePublishedBefore(ctx, lastPrune.Add(-s.retention)); err != nil {
+					log.Printf("outbox prune failed: %v", err)
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,20 @@
+package services
+
+import (
+	"context"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// canManageManga checks if the user owns the manga directly or through team membership
+func canManageManga(ctx context.Context, teamRepo ports.TeamRepository, manga *domain.Manga, userID uint) bool {
+	if manga.UserCreated == userID {
+		return true
+	}
+	if manga.TeamID == nil {
+		return false
+	}
+	_, err := teamRepo.GetMember(ctx, *manga.TeamID, userID)
+	return err == nil
+}
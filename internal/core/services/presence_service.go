@@ -0,0 +1,69 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+const (
+	// presenceWriteInterval is the minimum time between last-seen writes per user
+	presenceWriteInterval = time.Minute
+	// presenceOnlineWindow is how recently a user must be seen to count as online
+	presenceOnlineWindow = 5 * time.Minute
+)
+
+// presenceService implements the PresenceService interface
+type presenceService struct {
+	userRepo ports.UserRepository
+
+	mu        sync.Mutex
+	lastWrite map[uint]time.Time
+	lastPrune time.Time
+}
+
+// NewPresenceService creates a new presence service instance
+func NewPresenceService(userRepo ports.UserRepository) ports.PresenceService {
+	return &presenceService{
+		userRepo:  userRepo,
+		lastWrite: make(map[uint]time.Time),
+	}
+}
+
+// Touch records user activity, throttled to one write per interval
+func (s *presenceService) Touch(ctx context.Context, userID uint) error {
+	now := time.Now()
+
+	s.mu.Lock()
+	s.prune(now)
+	if last, ok := s.lastWrite[userID]; ok && now.Sub(last) < presenceWriteInterval {
+		s.mu.Unlock()
+		return nil
+	}
+	s.lastWrite[userID] = now
+	s.mu.Unlock()
+
+	return s.userRepo.UpdateLastSeen(ctx, userID, now)
+}
+
+// prune forgets the writes older than the write interval, which no longer
+// throttle anything, at most once per interval so the map doesn't grow with
+// every user ever seen. The caller holds mu.
+func (s *presenceService) prune(now time.Time) {
+	if now.Sub(s.lastPrune) < presenceWriteInterval {
+		return
+	}
+	for userID, last := range s.lastWrite {
+		if now.Sub(last) >= presenceWriteInterval {
+			delete(s.lastWrite, userID)
+		}
+	}
+	s.lastPrune = now
+}
+
+// CountOnline counts users seen within the online window
+func (s *presenceService) CountOnline(ctx context.Context) (int64, error) {
+	return s.userRepo.CountSeenSince(ctx, time.Now().Add(-presenceOnlineWindow))
+}
@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// purgeService implements the PurgeService interface. Mangas and users soft
+// deleted longer ago than the retention period are deleted for good, at most
+// batchSize of each per run so a backlog is worked off over several runs.
+type purgeService struct {
+	mangaRepo  ports.MangaRepository
+	userRepo   ports.UserRepository
+	uploadRepo ports.UploadRepository
+	storage    ports.FileStorage
+	retention  time.Duration
+	batchSize  int
+	audit      ports.AuditService
+
+	// runMu keeps scheduled and on-demand runs from overlapping
+	runMu sync.Mutex
+	mu    sync.Mutex
+	stats domain.PurgeStats
+}
+
+// NewPurgeService creates a new purge service instance. The files of a purged
+// manga's uploads are deleted from storage. Runs that purged anything are
+// recorded in the audit log.
+func NewPurgeService(mangaRepo ports.MangaRepository, userRepo ports.UserRepository, uploadRepo ports.UploadRepository, storage ports.FileStorage, retention time.Duration, batchSize int, audit ports.AuditService) ports.PurgeService {
+	return &purgeService{
+		mangaRepo:  mangaRepo,
+		userRepo:   userRepo,
+		uploadRepo: uploadRepo,
+		storage:    storage,
+		retention:  retention,
+		batchSize:  batchSize,
+		audit:      audit,
+	}
+}
+
+// Run purges the records due for deletion. Mangas go first, so users whose
+// mangas were deleted with them can be purged in the same run. A dry run only
+// reports what would be purged.
+func (s *purgeService) Run(ctx context.Context, dryRun bool) (*domain.PurgeReport, error) {
+	s.runMu.Lock()
+	defer s.runMu.Unlock()
+
+	now := time.Now()
+	report := &domain.PurgeReport{DryRun: dryRun, Cutoff: now.Add(-s.retention)}
+	err := s.purge(ctx, report)
+
+	s.mu.Lock()
+	s.stats.Runs++
+	if err != nil {
+		s.stats.FailedRuns++
+	}
+	if !dryRun {
+		s.stats.MangasPurged += int64(len(report.MangaIDs))
+		s.stats.UsersPurged += int64(len(report.UserIDs))
+	}
+	s.stats.LastRunAt = &now
+	s.stats.LastReport = report
+	s.mu.Unlock()
+
+	// Records purged before a failure are gone all the same
+	if !dryRun && len(report.MangaIDs)+len(report.UserIDs) > 0 {
+		recordAudit(ctx, s.audit, "purge.run", 0, nil, report)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// purge fills the report with the IDs purged, stopping at the first failure
+func (s *purgeService) purge(ctx context.Context, report *domain.PurgeReport) error {
+	mangaIDs, err := s.mangaRepo.GetPurgeableIDs(ctx, report.Cutoff, s.batchSize)
+	if err != nil {
+		return err
+	}
+	report.MangaIDs = make([]uint, 0, len(mangaIDs))
+	for _, id := range mangaIDs {
+		if !report.DryRun {
+			if err := s.purgeManga(ctx, id); err != nil {
+				return err
+			}
+		}
+		report.MangaIDs = append(report.MangaIDs, id)
+	}
+
+	// In a dry run the mangas are still there, so users owning only due mangas
+	// are not reported
+	userIDs, err := s.userRepo.GetPurgeableIDs(ctx, report.Cutoff, s.batchSize)
+	if err != nil {
+		return err
+	}
+	report.UserIDs = make([]uint, 0, len(userIDs))
+	for _, id := range userIDs {
+		if !report.DryRun {
+			if err := s.userRepo.Purge(ctx, id); err != nil {
+				return err
+			}
+		}
+		report.UserIDs = append(report.UserIDs, id)
+	}
+
+	report.Complete = len(mangaIDs) < s.batchSize && len(userIDs) < s.batchSize
+	return nil
+}
+
+// purgeManga purges a manga, then deletes the files of its uploads, logging
+// failures since the uploads themselves are already gone. Blocked uploads'
+// files were moved to quarantine, and stay there.
+func (s *purgeService) purgeManga(ctx context.Context, id uint) error {
+	uploads, err := s.uploadRepo.ListByMangaID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.mangaRepo.Purge(ctx, id); err != nil {
+		return err
+	}
+	for _, upload := range uploads {
+		if upload.Status == domain.UploadStatusBlocked {
+			continue
+		}
+		if err := s.storage.Delete(upload.StorageKey); err != nil {
+			log.Printf("Failed to delete stored file %s: %v", upload.StorageKey, err)
+		}
+	}
+	return nil
+}
+
+// Stats returns the purge counters since startup
+func (s *purgeService) Stats() domain.PurgeStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stats
+}
+
+// StartScheduler runs the purge in the background at the given interval
+func (s *purgeService) StartScheduler(interval time.Duration, dryRun bool) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			report, err := s.Run(context.Background(), dryRun)
+			if err != nil {
+				log.Printf("purge failed: %v", err)
+				continue
+			}
+			if len(report.MangaIDs) == 0 && len(report.UserIDs) == 0 {
+				continue
+			}
+			if dryRun {
+				log.Printf("purge dry run: would purge mangas %v and users %v", report.MangaIDs, report.UserIDs)
+			} else {
+				log.Printf("purged %d mangas and %d users", len(report.MangaIDs), len(report.UserIDs))
+			}
+		}
+	}()
+}
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// quotaService implements the QuotaService interface
+type quotaService struct {
+	quotaRepo ports.QuotaRepository
+	userRepo  ports.UserRepository
+	limits    map[string]domain.QuotaLimits
+	audit     ports.AuditService
+}
+
+// NewQuotaService creates a new quota service instance with limits keyed by user role
+func NewQuotaService(quotaRepo ports.QuotaRepository, userRepo ports.UserRepository, limits map[string]domain.QuotaLimits, audit ports.AuditService) ports.QuotaService {
+	return &quotaService{
+		quotaRepo: quotaRepo,
+		userRepo:  userRepo,
+		limits:    limits,
+		audit:     audit,
+	}
+}
+
+// Consume counts one request against the user's quotas and returns the resulting status
+func (s *quotaService) Consume(ctx context.Context, userID uint) (*domain.QuotaStatus, error) {
+	status, err := s.newStatus(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if status.DailyUsed, err = s.quotaRepo.Increment(ctx, userID, domain.QuotaPeriodDaily, domain.QuotaPeriodStart(domain.QuotaPeriodDaily, now)); err != nil {
+		return nil, err
+	}
+	if status.MonthlyUsed, err = s.quotaRepo.Increment(ctx, userID, domain.QuotaPeriodMonthly, domain.QuotaPeriodStart(domain.QuotaPeriodMonthly, now)); err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// GetStatus returns the user's current quota consumption without counting a request
+func (s *quotaService) GetStatus(ctx context.Context, userID uint) (*domain.QuotaStatus, error) {
+	status, err := s.newStatus(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if status.DailyUsed, err = s.quotaRepo.GetUsage(ctx, userID, domain.QuotaPeriodDaily, domain.QuotaPeriodStart(domain.QuotaPeriodDaily, now)); err != nil {
+		return nil, err
+	}
+	if status.MonthlyUsed, err = s.quotaRepo.GetUsage(ctx, userID, domain.QuotaPeriodMonthly, domain.QuotaPeriodStart(domain.QuotaPeriodMonthly, now)); err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// Reset clears the user's quota consumption
+func (s *quotaService) Reset(ctx context.Context, userID uint) error {
+	before, err := s.GetStatus(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.quotaRepo.Reset(ctx, userID); err != nil {
+		return err
+	}
+	recordAudit(ctx, s.audit, "quota.reset", userID, before, nil)
+	return nil
+}
+
+// newStatus builds an empty status with the limits for the user's role
+func (s *quotaService) newStatus(ctx context.Context, userID uint) (*domain.QuotaStatus, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &domain.QuotaStatus{
+		UserID:       userID,
+		Limits:       s.limits[user.Role],
+		DailyReset:   domain.QuotaPeriodStart(domain.QuotaPeriodDaily, now).AddDate(0, 0, 1),
+		MonthlyReset: domain.QuotaPeriodStart(domain.QuotaPeriodMonthly, now).AddDate(0, 1, 0),
+	}, nil
+}
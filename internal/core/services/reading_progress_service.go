@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// readingProgressService implements the ReadingProgressService interface
+type readingProgressService struct {
+	progressRepo ports.ReadingProgressRepository
+	mangaRepo    ports.MangaRepository
+	chapterRepo  ports.ChapterRepository
+}
+
+// NewReadingProgressService creates a new reading progress service instance
+func NewReadingProgressService(progressRepo ports.ReadingProgressRepository, mangaRepo ports.MangaRepository, chapterRepo ports.ChapterRepository) ports.ReadingProgressService {
+	return &readingProgressService{
+		progressRepo: progressRepo,
+		mangaRepo:    mangaRepo,
+		chapterRepo:  chapterRepo,
+	}
+}
+
+// UpdateProgress records the user's reading position in a manga
+func (s *readingProgressService) UpdateProgress(ctx context.Context, mangaID uint, req *domain.UpdateProgressRequest, userID uint) (*domain.ReadingProgress, error) {
+	if _, err := s.mangaRepo.GetByID(ctx, mangaID); err != nil {
+		return nil, err
+	}
+
+	// The chapter must belong to this manga and the page must be within it
+	if req.ChapterID != nil {
+		chapter, err := s.chapterRepo.GetByID(ctx, *req.ChapterID)
+		if err != nil || chapter.MangaID != mangaID {
+			return nil, errors.New("chapter not found")
+		}
+		if chapter.PageCount > 0 && req.Page > chapter.PageCount {
+			return nil, errors.New("page exceeds chapter page count")
+		}
+	}
+
+	progress := &domain.ReadingProgress{
+		UserID:    userID,
+		MangaID:   mangaID,
+		ChapterID: req.ChapterID,
+		Page:      req.Page,
+		Status:    req.Status,
+	}
+
+	if !progress.IsValid() {
+		return nil, errors.New("invalid reading progress data")
+	}
+
+	if err := s.progressRepo.Upsert(ctx, progress); err != nil {
+		return nil, err
+	}
+
+	return s.progressRepo.GetByUserAndManga(ctx, userID, mangaID)
+}
+
+// GetProgress retrieves the user's reading position in a manga
+func (s *readingProgressService) GetProgress(ctx context.Context, mangaID, userID uint) (*domain.ReadingProgress, error) {
+	return s.progressRepo.GetByUserAndManga(ctx, userID, mangaID)
+}
+
+// GetContinueReading retrieves the mangas the user is currently reading, most recent first
+func (s *readingProgressService) GetContinueReading(ctx context.Context, userID uint, pagination *domain.PaginationRequest) (*domain.PaginatedResult[*domain.ReadingProgress], error) {
+	entries, total, err := s.progressRepo.ListByUserIDPaginated(ctx, userID, domain.ReadingStatusReading, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sanitize embedded mangas
+	for _, entry := range entries {
+		if entry.Manga != nil {
+			entry.Manga = entry.Manga.Sanitize()
+		}
+	}
+
+	return &domain.PaginatedResult[*domain.ReadingProgress]{
+		Data:       entries,
+		Pagination: domain.NewPaginationResponse(pagination.Page, pagination.PageSize, total),
+	}, nil
+}
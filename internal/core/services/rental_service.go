@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// rentalService implements the RentalService interface
+type rentalService struct {
+	rentalRepo ports.RentalRepository
+	mangaRepo  ports.MangaRepository
+	audit      ports.AuditService
+}
+
+// NewRentalService creates a new rental service instance
+func NewRentalService(rentalRepo ports.RentalRepository, mangaRepo ports.MangaRepository, audit ports.AuditService) ports.RentalService {
+	return &rentalService{
+		rentalRepo: rentalRepo,
+		mangaRepo:  mangaRepo,
+		audit:      audit,
+	}
+}
+
+// RentManga gives the user access to a published manga for the requested number of days
+func (s *rentalService) RentManga(ctx context.Context, mangaID uint, req *domain.RentMangaRequest, userID uint) (*domain.Rental, error) {
+	manga, err := s.mangaRepo.GetByID(ctx, mangaID)
+	if err != nil {
+		return nil, err
+	}
+	if manga.Status != domain.MangaStatusPublished || !manga.IsActive {
+		return nil, errors.New("manga is not available for rent")
+	}
+
+	now := time.Now()
+	if _, err := s.rentalRepo.GetActive(ctx, userID, mangaID, now); err == nil {
+		return nil, errors.New("you are already renting this manga")
+	}
+
+	days := req.Days
+	if days == 0 {
+		days = domain.DefaultRentalDays
+	}
+
+	rental := &domain.Rental{
+		UserID:    userID,
+		MangaID:   mangaID,
+		Status:    domain.RentalStatusActive,
+		ExpiresAt: now.AddDate(0, 0, days),
+	}
+
+	if err := s.rentalRepo.Create(ctx, rental); err != nil {
+		return nil, err
+	}
+	recordAudit(ctx, s.audit, "rental.create", rental.ID, nil, rental)
+
+	rental.Manga = manga.Sanitize()
+	return rental, nil
+}
+
+// GetActiveRentals retrieves the user's running rentals
+func (s *rentalService) GetActiveRentals(ctx context.Context, userID uint, pagination *domain.PaginationRequest) (*domain.PaginatedResult[*domain.Rental], error) {
+	rentals, total, err := s.rentalRepo.ListActiveByUserIDPaginated(ctx, userID, time.Now(), pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rental := range rentals {
+		if rental.Manga != nil {
+			rental.Manga = rental.Manga.Sanitize()
+		}
+	}
+
+	return &domain.PaginatedResult[*domain.Rental]{
+		Data:       rentals,
+		Pagination: domain.NewPaginationResponse(pagination.Page, pagination.PageSize, total),
+	}, nil
+}
+
+// StartSweeper marks overdue rentals as expired in the background at the given interval
+func (s *rentalService) StartSweeper(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			expired, err := s.rentalRepo.ExpireDue(context.Background(), time.Now())
+			if err != nil {
+				log.Printf("rental sweep failed: %v", err)
+				continue
+			}
+			if expired > 0 {
+				log.Printf("expired %d rentals", expired)
+			}
+		}
+	}()
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// requestStatsBucket is the span of time counted together
+const requestStatsBucket = time.Minute
+
+// requestBucket counts the responses of one minute
+type requestBucket struct {
+	minute       int64
+	requests     int64
+	clientErrors int64
+	serverErrors int64
+}
+
+// requestStats implements the RequestStats interface, counting responses in
+// memory by the minute, for the last domain.RequestStatsWindow
+type requestStats struct {
+	mu      sync.Mutex
+	buckets []requestBucket
+}
+
+// NewRequestStats creates a new request stats instance
+func NewRequestStats() ports.RequestStats {
+	return &requestStats{
+		buckets: make([]requestBucket, domain.RequestStatsWindow/requestStatsBucket),
+	}
+}
+
+// Record counts a response with the status
+func (s *requestStats) Record(status int) {
+	minute := time.Now().Unix() / int64(requestStatsBucket/time.Second)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	bucket := &s.buckets[minute%int64(len(s.buckets))]
+	if bucket.minute != minute {
+		*bucket = requestBucket{minute: minute}
+	}
+	bucket.requests++
+	switch {
+	case status >= 500:
+		bucket.serverErrors++
+	case status >= 400:
+		bucket.clientErrors++
+	}
+}
+
+// Recent returns the counts of the last domain.RequestStatsWindow
+func (s *requestStats) Recent() domain.RequestCounts {
+	minute := time.Now().Unix() / int64(requestStatsBucket/time.Second)
+	counts := domain.RequestCounts{WindowSeconds: int64(domain.RequestStatsWindow / time.Second)}
+
+	s.mu.Lock()
+	for _, bucket := range s.buckets {
+		// Buckets left over from more than a window ago are stale
+		if minute-bucket.minute >= int64(len(s.buckets)) {
+			continue
+		}
+		counts.Requests += bucket.requests
+		counts.ClientErrors += bucket.clientErrors
+		counts.ServerErrors += bucket.serverErrors
+	}
+	s.mu.Unlock()
+
+	if counts.Requests > 0 {
+		counts.ServerErrorRate = float64(counts.ServerErrors) / float64(counts.Requests)
+	}
+	return counts
+}
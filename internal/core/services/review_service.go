@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// reviewService implements the ReviewService interface
+type reviewService struct {
+	reviewRepo ports.ReviewRepository
+	mangaRepo  ports.MangaRepository
+}
+
+// NewReviewService creates a new review service instance
+func NewReviewService(reviewRepo ports.ReviewRepository, mangaRepo ports.MangaRepository) ports.ReviewService {
+	return &reviewService{
+		reviewRepo: reviewRepo,
+		mangaRepo:  mangaRepo,
+	}
+}
+
+// CreateReview adds the user's review of a manga (one per user per manga)
+func (s *reviewService) CreateReview(ctx context.Context, mangaID uint, req *domain.ReviewRequest, userID uint) (*domain.Review, error) {
+	manga, err := s.mangaRepo.GetByID(ctx, mangaID)
+	if err != nil {
+		return nil, err
+	}
+
+	if manga.UserCreated == userID {
+		return nil, errors.New("you cannot review your own manga")
+	}
+
+	if _, err := s.reviewRepo.GetByMangaAndUser(ctx, mangaID, userID); err == nil {
+		return nil, errors.New("you have already reviewed this manga")
+	}
+
+	review := &domain.Review{
+		MangaID: mangaID,
+		UserID:  userID,
+		Rating:  req.Rating,
+		Text:    strings.TrimSpace(req.Text),
+	}
+
+	if !review.IsValid() {
+		return nil, errors.New("invalid review data")
+	}
+
+	if err := s.reviewRepo.Create(ctx, review); err != nil {
+		return nil, err
+	}
+
+	return review, nil
+}
+
+// GetReviewsPaginated retrieves paginated reviews of a manga
+func (s *reviewService) GetReviewsPaginated(ctx context.Context, mangaID uint, pagination *domain.PaginationRequest) (*domain.PaginatedResult[*domain.Review], error) {
+	if _, err := s.mangaRepo.GetByID(ctx, mangaID); err != nil {
+		return nil, err
+	}
+
+	reviews, total, err := s.reviewRepo.ListByMangaIDPaginated(ctx, mangaID, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.PaginatedResult[*domain.Review]{
+		Data:       reviews,
+		Pagination: domain.NewPaginationResponse(pagination.Page, pagination.PageSize, total),
+	}, nil
+}
+
+// UpdateReview updates the user's own review
+func (s *reviewService) UpdateReview(ctx context.Context, mangaID, reviewID uint, req *domain.ReviewRequest, userID uint) (*domain.Review, error) {
+	review, err := s.getOwnedReview(ctx, mangaID, reviewID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	review.Rating = req.Rating
+	review.Text = strings.TrimSpace(req.Text)
+
+	if !review.IsValid() {
+		return nil, errors.New("invalid review data")
+	}
+
+	if err := s.reviewRepo.Update(ctx, review); err != nil {
+		return nil, err
+	}
+
+	return review, nil
+}
+
+// DeleteReview deletes the user's own review
+func (s *reviewService) DeleteReview(ctx context.Context, mangaID, reviewID uint, userID uint) error {
+	review, err := s.getOwnedReview(ctx, mangaID, reviewID, userID)
+	if err != nil {
+		return err
+	}
+
+	return s.reviewRepo.Delete(ctx, review)
+}
+
+// getOwnedReview retrieves a manga's review and checks the user wrote it
+func (s *reviewService) getOwnedReview(ctx context.Context, mangaID, reviewID uint, userID uint) (*domain.Review, error) {
+	review, err := s.reviewRepo.GetByID(ctx, reviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	if review.MangaID != mangaID {
+		return nil, errors.New("review not found")
+	}
+
+	if review.UserID != userID {
+		return nil, errors.New("access denied: you can only modify your own review")
+	}
+
+	return review, nil
+}
@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// sellerReportBatchSize is the number of due reports queued per query
+const sellerReportBatchSize = 100
+
+// sellerReportService implements the SellerReportService interface. Every
+// process runs the scheduler; marking a report queued only succeeds once per
+// period, so each report is queued by one of them.
+type sellerReportService struct {
+	prefsRepo ports.NotificationPreferenceRepository
+	userRepo  ports.UserRepository
+	orderRepo ports.OrderRepository
+	viewRepo  ports.ViewRepository
+	jobs      ports.JobQueue
+	txManager ports.TransactionManager
+	renderer  ports.EmailRenderer
+	sender    ports.EmailSender
+	baseURL   string
+}
+
+// NewSellerReportService creates a new seller report service instance, and
+// registers the handler of the jobs that send the reports. Unsubscribe links
+// in reports point at baseURL, the public URL of the API.
+func NewSellerReportService(prefsRepo ports.NotificationPreferenceRepository, userRepo ports.UserRepository, orderRepo ports.OrderRepository, viewRepo ports.ViewRepository, jobs ports.JobService, txManager ports.TransactionManager, renderer ports.EmailRenderer, sender ports.EmailSender, baseURL string) ports.SellerReportService {
+	s := &sellerReportService{
+		prefsRepo: prefsRepo,
+		userRepo:  userRepo,
+		orderRepo: orderRepo,
+		viewRepo:  viewRepo,
+		jobs:      jobs,
+		txManager: txManager,
+		renderer:  renderer,
+		sender:    sender,
+		baseURL:   strings.TrimSuffix(baseURL, "/"),
+	}
+	HandleJob(jobs, s.sendReport)
+	return s
+}
+
+// QueueDueReports queues a job sending the report of the last period to every
+// seller who has not been sent it yet
+func (s *sellerReportService) QueueDueReports(ctx context.Context) (int, error) {
+	queued := 0
+	now := time.Now()
+	for _, frequency := range domain.SellerReportFrequencies {
+		from, to := domain.SellerReportPeriod(frequency, now)
+		for {
+			userIDs, err := s.prefsRepo.ListDueSellerReports(ctx, frequency, to, sellerReportBatchSize)
+			if err != nil {
+				return queued, err
+			}
+			for _, userID := range userIDs {
+				args := domain.SendSellerReportArgs{UserID: userID, Frequency: frequency, From: from, To: to}
+				err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+					marked, err := s.prefsRepo.MarkSellerReportQueued(ctx, userID, to)
+					if err != nil || !marked {
+						// Another process queued it
+						return err
+					}
+					if _, err := s.jobs.Enqueue(ctx, args, &domain.JobOptions{UserID: &userID}); err != nil {
+						return err
+					}
+					queued++
+					return nil
+				})
+				if err != nil {
+					return queued, err
+				}
+			}
+			if len(userIDs) < sellerReportBatchSize {
+				break
+			}
+		}
+	}
+	return queued, nil
+}
+
+// StartScheduler queues due reports in the background at the given interval
+func (s *sellerReportService) StartScheduler(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			queued, err := s.QueueDueReports(context.Background())
+			if err != nil {
+				log.Printf("queueing seller reports failed: %v", err)
+			}
+			if queued > 0 {
+				log.Printf("queued %d seller reports", queued)
+			}
+		}
+	}()
+}
+
+// buildReport gathers a seller's sales and views of the period
+func (s *sellerReportService) buildReport(ctx context.Context, args domain.SendSellerReportArgs) (*domain.SellerReport, error) {
+	summary, err := s.orderRepo.SummarizeSellerSales(ctx, args.UserID, args.From, args.To)
+	if err != nil {
+		return nil, err
+	}
+	views, err := s.viewRepo.CountSellerViews(ctx, args.UserID, args.From, args.To)
+	if err != nil {
+		return nil, err
+	}
+	top, err := s.orderRepo.GetTopSellerMangas(ctx, args.UserID, args.From, args.To, domain.SellerReportTopMangas)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.SellerReport{
+		Frequency:    args.Frequency,
+		From:         args.From,
+		To:           args.To,
+		SalesSummary: *summary,
+		Views:        views,
+		TopMangas:    top,
+	}, nil
+}
+
+// sendReport renders a seller's report in their locale and sends it, unless
+// the user is gone, has stopped seller reports since it was queued, or had
+// neither sales nor views in the period
+func (s *sellerReportService) sendReport(ctx context.Context, job *domain.Job, args domain.SendSellerReportArgs) error {
+	user, err := s.userRepo.GetByID(ctx, args.UserID)
+	if err != nil {
+		if strings.HasSuffix(err.Error(), "not found") {
+			return nil
+		}
+		return err
+	}
+	if user.IsSuspended(time.Now()) {
+		return nil
+	}
+
+	prefs, err := s.prefsRepo.GetByUserID(ctx, user.ID)
+	if err != nil {
+		if strings.HasSuffix(err.Error(), "not found") {
+			return nil
+		}
+		return err
+	}
+	if prefs.SellerReports != args.Frequency {
+		return nil
+	}
+
+	report, err := s.buildReport(ctx, args)
+	if err != nil {
+		return err
+	}
+	if report.IsEmpty() {
+		return nil
+	}
+
+	unsubscribeURL, err := unsubscribeURL(s.baseURL, user.ID, domain.NotificationSellerReports)
+	if err != nil {
+		return err
+	}
+	msg, err := s.renderer.Render(domain.EmailSellerReport, user.Locale, &domain.EmailData{
+		User:           user,
+		Report:         report,
+		UnsubscribeURL: unsubscribeURL,
+	})
+	if err != nil {
+		// Rendering again won't fix a template
+		return fmt.Errorf("%w: %v", domain.ErrPermanentJobFailure, err)
+	}
+
+	msg.To = user.Email
+	msg.UnsubscribeURL = unsubscribeURL
+	return s.sender.Send(ctx, msg)
+}
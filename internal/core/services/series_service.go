@@ -0,0 +1,236 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// seriesService implements the SeriesService interface
+type seriesService struct {
+	seriesRepo ports.SeriesRepository
+	mangaRepo  ports.MangaRepository
+	teamRepo   ports.TeamRepository
+	audit      ports.AuditService
+}
+
+// NewSeriesService creates a new series service instance
+func NewSeriesService(seriesRepo ports.SeriesRepository, mangaRepo ports.MangaRepository, teamRepo ports.TeamRepository, audit ports.AuditService) ports.SeriesService {
+	return &seriesService{
+		seriesRepo: seriesRepo,
+		mangaRepo:  mangaRepo,
+		teamRepo:   teamRepo,
+		audit:      audit,
+	}
+}
+
+// canManageSeries reports whether the user created the series or is a member of its team
+func (s *seriesService) canManageSeries(ctx context.Context, series *domain.Series, userID uint) bool {
+	if series.UserCreated == userID {
+		return true
+	}
+	if series.TeamID == nil {
+		return false
+	}
+	_, err := s.teamRepo.GetMember(ctx, *series.TeamID, userID)
+	return err == nil
+}
+
+// getManagedSeries loads a series and checks the user may manage it
+func (s *seriesService) getManagedSeries(ctx context.Context, id uint, userID uint) (*domain.Series, error) {
+	series, err := s.seriesRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !s.canManageSeries(ctx, series, userID) {
+		return nil, errors.New("access denied: you can only manage your own series")
+	}
+	return series, nil
+}
+
+// CreateSeries creates a new series owned by the user, optionally on behalf of a team
+func (s *seriesService) CreateSeries(ctx context.Context, req *domain.SeriesRequest, userID uint) (*domain.Series, error) {
+	if req.TeamID != nil {
+		if _, err := s.teamRepo.GetMember(ctx, *req.TeamID, userID); err != nil {
+			return nil, errors.New("access denied: you are not a member of this team")
+		}
+	}
+
+	series := &domain.Series{
+		Name:        strings.TrimSpace(req.Name),
+		Description: strings.TrimSpace(req.Description),
+		UserCreated: userID,
+		TeamID:      req.TeamID,
+	}
+
+	if !series.IsValid() {
+		return nil, errors.New("invalid series data")
+	}
+
+	if err := s.seriesRepo.Create(ctx, series); err != nil {
+		return nil, err
+	}
+	recordAudit(ctx, s.audit, "series.create", series.ID, nil, series)
+
+	return series, nil
+}
+
+// GetSeries retrieves a series with its published volumes, volume count and total price
+func (s *seriesService) GetSeries(ctx context.Context, id uint) (*domain.SeriesDetail, error) {
+	series, err := s.seriesRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.detail(ctx, series)
+}
+
+// detail assembles the public view of a series
+func (s *seriesService) detail(ctx context.Context, series *domain.Series) (*domain.SeriesDetail, error) {
+	volumes, err := s.seriesRepo.GetVolumes(ctx, series.ID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.SeriesDetail{
+		Series:      series,
+		Volumes:     make([]*domain.Manga, len(volumes)),
+		VolumeCount: len(volumes),
+	}
+	for i, volume := range volumes {
+		result.Volumes[i] = volume.Sanitize()
+		result.TotalPrice += volume.Price
+	}
+
+	return result, nil
+}
+
+// UpdateSeries updates a series' name and description
+func (s *seriesService) UpdateSeries(ctx context.Context, id uint, req *domain.SeriesRequest, userID uint) (*domain.Series, error) {
+	series, err := s.getManagedSeries(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	before := auditState(series)
+	series.Name = strings.TrimSpace(req.Name)
+	series.Description = strings.TrimSpace(req.Description)
+
+	if !series.IsValid() {
+		return nil, errors.New("invalid series data")
+	}
+
+	if err := s.seriesRepo.Update(ctx, series); err != nil {
+		return nil, err
+	}
+	recordAudit(ctx, s.audit, "series.update", series.ID, before, series)
+
+	return series, nil
+}
+
+// DeleteSeries deletes a series; its volumes become ungrouped
+func (s *seriesService) DeleteSeries(ctx context.Context, id uint, userID uint) error {
+	series, err := s.getManagedSeries(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.seriesRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	recordAudit(ctx, s.audit, "series.delete", id, series, nil)
+	return nil
+}
+
+// AddVolume adds a manga the user manages to the series, by default as its last volume
+func (s *seriesService) AddVolume(ctx context.Context, id uint, req *domain.AddSeriesVolumeRequest, userID uint) (*domain.SeriesDetail, error) {
+	series, err := s.getManagedSeries(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	manga, err := s.mangaRepo.GetByID(ctx, req.MangaID)
+	if err != nil {
+		return nil, err
+	}
+	if !canManageManga(ctx, s.teamRepo, manga, userID) {
+		return nil, errors.New("access denied: you can only add your own manga")
+	}
+	if manga.SeriesID != nil {
+		return nil, errors.New("manga already belongs to a series")
+	}
+
+	volumeNumber := 0
+	if req.VolumeNumber != nil {
+		volumeNumber = *req.VolumeNumber
+	} else if volumeNumber, err = s.seriesRepo.NextVolumeNumber(ctx, id); err != nil {
+		return nil, err
+	}
+
+	if err := s.seriesRepo.AddVolume(ctx, id, manga.ID, volumeNumber); err != nil {
+		return nil, err
+	}
+	recordAudit(ctx, s.audit, "series.add_volume", id, nil, &domain.AddSeriesVolumeRequest{MangaID: manga.ID, VolumeNumber: &volumeNumber})
+
+	return s.detail(ctx, series)
+}
+
+// RemoveVolume takes a manga out of the series
+func (s *seriesService) RemoveVolume(ctx context.Context, id, mangaID uint, userID uint) error {
+	if _, err := s.getManagedSeries(ctx, id, userID); err != nil {
+		return err
+	}
+
+	manga, err := s.mangaRepo.GetByID(ctx, mangaID)
+	if err != nil {
+		return err
+	}
+	if manga.SeriesID == nil || *manga.SeriesID != id {
+		return errors.New("manga is not in this series")
+	}
+
+	if err := s.seriesRepo.RemoveVolume(ctx, id, mangaID); err != nil {
+		return err
+	}
+	recordAudit(ctx, s.audit, "series.remove_volume", id, &domain.AddSeriesVolumeRequest{MangaID: mangaID, VolumeNumber: manga.VolumeNumber}, nil)
+	return nil
+}
+
+// ReorderVolumes renumbers the series' volumes in the given order; the list must
+// contain every volume of the series exactly once
+func (s *seriesService) ReorderVolumes(ctx context.Context, id uint, req *domain.ReorderSeriesVolumesRequest, userID uint) (*domain.SeriesDetail, error) {
+	series, err := s.getManagedSeries(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	volumes, err := s.seriesRepo.GetVolumes(ctx, id, false)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make(map[uint]bool, len(volumes))
+	for _, volume := range volumes {
+		members[volume.ID] = true
+	}
+	if len(req.MangaIDs) != len(members) {
+		return nil, errors.New("manga_ids must list every volume of the series exactly once")
+	}
+	seen := make(map[uint]bool, len(req.MangaIDs))
+	for _, mangaID := range req.MangaIDs {
+		if !members[mangaID] || seen[mangaID] {
+			return nil, errors.New("manga_ids must list every volume of the series exactly once")
+		}
+		seen[mangaID] = true
+	}
+
+	if err := s.seriesRepo.ReorderVolumes(ctx, id, req.MangaIDs); err != nil {
+		return nil, err
+	}
+	recordAudit(ctx, s.audit, "series.reorder_volumes", id, &domain.ReorderSeriesVolumesRequest{MangaIDs: mangaIDs(volumes)}, req)
+
+	return s.detail(ctx, series)
+}
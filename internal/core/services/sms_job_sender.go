@@ -0,0 +1,36 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// jobSMSSender implements the SMSSender interface by queuing a send_sms job
+// for each message, so callers never wait on the SMS provider
+type jobSMSSender struct {
+	jobs ports.JobQueue
+}
+
+// NewJobSMSSender creates a sender that queues messages as jobs, and
+// registers the handler that delivers them through next. Transient failures
+// are retried with the backoff of send_sms jobs; rejected messages are not.
+func NewJobSMSSender(jobs ports.JobService, next ports.SMSSender) ports.SMSSender {
+	HandleJob(jobs, func(ctx context.Context, job *domain.Job, args domain.SendSMSArgs) error {
+		err := next.Send(ctx, &args.Message)
+		if errors.Is(err, domain.ErrSMSRejected) {
+			return fmt.Errorf("%w: %v", domain.ErrPermanentJobFailure, err)
+		}
+		return err
+	})
+	return &jobSMSSender{jobs: jobs}
+}
+
+// Send queues the message
+func (s *jobSMSSender) Send(ctx context.Context, msg *domain.SMSMessage) error {
+	_, err := s.jobs.Enqueue(ctx, domain.SendSMSArgs{Message: *msg}, nil)
+	return err
+}
@@ -0,0 +1,291 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/internal/utils"
+)
+
+// smsCodeTTL is how long a texted code is valid for
+const smsCodeTTL = 10 * time.Minute
+
+// smsCodeMaxAttempts is how many wrong guesses use up a texted code
+const smsCodeMaxAttempts = 5
+
+// SMSLimits keep texts from being used to flood a number or run up the bill
+type SMSLimits struct {
+	// CodesPerHour caps the codes texted to each user per hour
+	CodesPerHour int64
+	// PerNumberDaily caps the texts of any kind sent to each number per day
+	PerNumberDaily int64
+}
+
+// smsService implements the SMSService interface
+type smsService struct {
+	phoneRepo ports.UserPhoneRepository
+	sender    ports.SMSSender
+	limiter   ports.RateLimitStore
+	audit     ports.AuditService
+	limits    SMSLimits
+}
+
+// NewSMSService creates a new SMS service instance. The sender should queue
+// messages so requests are not held up by the SMS provider; limits are
+// counted in limiter, shared between instances when it is.
+func NewSMSService(phoneRepo ports.UserPhoneRepository, sender ports.SMSSender, limiter ports.RateLimitStore, audit ports.AuditService, limits SMSLimits) ports.SMSService {
+	return &smsService{
+		phoneRepo: phoneRepo,
+		sender:    sender,
+		limiter:   limiter,
+		audit:     audit,
+		limits:    limits,
+	}
+}
+
+// Deliver texts the buyer once their order is paid or shipped, if they opted
+// into order alerts; other notifications have no text
+func (s *smsService) Deliver(ctx context.Context, n *domain.Notification) error {
+	switch n.Event {
+	case domain.EventOrderPaid:
+		return s.sendOrderAlert(ctx, n.Order, "Payment for your order #%d is confirmed.")
+	case domain.EventOrderShipped:
+		return s.sendOrderAlert(ctx, n.Order, "Your order #%d has shipped.")
+	}
+	return nil
+}
+
+// GetPhone retrieves the user's phone number
+func (s *smsService) GetPhone(ctx context.Context, userID uint) (*domain.UserPhone, error) {
+	return s.phoneRepo.GetByUserID(ctx, userID)
+}
+
+// SetPhone stores the number unverified and texts it a verification code.
+// Consent given with the number only counts once the number is verified;
+// leaving it out keeps any consent given before.
+func (s *smsService) SetPhone(ctx context.Context, userID uint, req *domain.SetPhoneRequest) (*domain.UserPhone, error) {
+	phone, err := s.phoneRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if !strings.HasSuffix(err.Error(), "not found") {
+			return nil, err
+		}
+		phone = &domain.UserPhone{UserID: userID}
+	}
+	before := smsConsentState(phone)
+
+	if phone.Number != req.Number {
+		phone.Number = req.Number
+		phone.VerifiedAt = nil
+	}
+	consented := req.SMSConsent && phone.SMSConsentAt == nil
+	if consented {
+		setSMSConsent(ctx, phone, true)
+	}
+	if err := s.sendCode(ctx, phone, domain.SMSCodeVerify, "Your verification code is %s. It expires in 10 minutes."); err != nil {
+		return nil, err
+	}
+	if consented {
+		recordAudit(ctx, s.audit, "user_phone.consent", userID, before, smsConsentState(phone))
+	}
+	return phone, nil
+}
+
+// VerifyPhone marks the user's number verified once they enter the code texted to it
+func (s *smsService) VerifyPhone(ctx context.Context, userID uint, req *domain.VerifyPhoneRequest) (*domain.UserPhone, error) {
+	phone, err := s.phoneRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if phone.IsVerified() {
+		return phone, nil
+	}
+
+	if err := s.checkCode(ctx, phone, domain.SMSCodeVerify, req.Code); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	phone.VerifiedAt = &now
+	if err := s.phoneRepo.Save(ctx, phone); err != nil {
+		return nil, err
+	}
+	return phone, nil
+}
+
+// UpdateConsent records that the user opted into or out of order alerts by
+// text, with the time and the IP they did it from
+func (s *smsService) UpdateConsent(ctx context.Context, userID uint, req *domain.UpdateSMSConsentRequest) (*domain.UserPhone, error) {
+	phone, err := s.phoneRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if (phone.SMSConsentAt != nil) == *req.SMSConsent {
+		return phone, nil
+	}
+
+	before := smsConsentState(phone)
+	setSMSConsent(ctx, phone, *req.SMSConsent)
+	if err := s.phoneRepo.Save(ctx, phone); err != nil {
+		return nil, err
+	}
+
+	action := "user_phone.consent"
+	if !*req.SMSConsent {
+		action = "user_phone.revoke_consent"
+	}
+	recordAudit(ctx, s.audit, action, userID, before, smsConsentState(phone))
+	return phone, nil
+}
+
+// RemovePhone deletes the user's number, withdrawing their consent with it
+func (s *smsService) RemovePhone(ctx context.Context, userID uint) error {
+	phone, err := s.phoneRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.phoneRepo.Delete(ctx, userID); err != nil {
+		return err
+	}
+	if phone.SMSConsentAt != nil {
+		recordAudit(ctx, s.audit, "user_phone.revoke_consent", userID, smsConsentState(phone), nil)
+	}
+	return nil
+}
+
+// sendCode stores a new code for the purpose, replacing any sent before, and
+// texts it to the number; text is the message, with a %s for the code
+func (s *smsService) sendCode(ctx context.Context, phone *domain.UserPhone, purpose, text string) error {
+	if err := s.allow(ctx, "sms:codes:user:"+strconv.FormatUint(uint64(phone.UserID), 10), s.limits.CodesPerHour, time.Hour); err != nil {
+		return err
+	}
+	if err := s.allowNumber(ctx, phone.Number); err != nil {
+		return err
+	}
+
+	code, err := generateSMSCode()
+	if err != nil {
+		return errors.New("failed to generate code")
+	}
+	hash, err := utils.HashPassword(code)
+	if err != nil {
+		return errors.New("failed to hash code")
+	}
+	expiresAt := time.Now().Add(smsCodeTTL)
+	phone.CodeHash = hash
+	phone.CodePurpose = purpose
+	phone.CodeExpiresAt = &expiresAt
+	phone.CodeAttempts = 0
+	if err := s.phoneRepo.Save(ctx, phone); err != nil {
+		return err
+	}
+
+	return s.sender.Send(ctx, &domain.SMSMessage{To: phone.Number, Body: fmt.Sprintf(text, code)})
+}
+
+// checkCode checks a code entered for the purpose against the one texted,
+// clearing it once it is used or has had too many wrong guesses. Only a
+// wrong guess is saved here; the caller saves the phone on success.
+func (s *smsService) checkCode(ctx context.Context, phone *domain.UserPhone, purpose, code string) error {
+	if phone.CodeHash == "" || phone.CodePurpose != purpose || phone.CodeExpiresAt == nil || time.Now().After(*phone.CodeExpiresAt) {
+		return errors.New("code is expired, request a new one")
+	}
+
+	if !utils.CheckPasswordHash(code, phone.CodeHash) {
+		phone.CodeAttempts++
+		if phone.CodeAttempts >= smsCodeMaxAttempts {
+			phone.ClearCode()
+		}
+		if err := s.phoneRepo.Save(ctx, phone); err != nil {
+			return err
+		}
+		return errors.New("invalid code")
+	}
+
+	phone.ClearCode()
+	return nil
+}
+
+// sendOrderAlert texts the buyer of the order, if they have a verified
+// number and opted into order alerts; text has a %d for the order's ID
+func (s *smsService) sendOrderAlert(ctx context.Context, order *domain.Order, text string) error {
+	phone, err := s.phoneRepo.GetByUserID(ctx, order.UserID)
+	if err != nil {
+		if strings.HasSuffix(err.Error(), "not found") {
+			return nil
+		}
+		return err
+	}
+	if !phone.AllowsAlerts() {
+		return nil
+	}
+
+	if err := s.allowNumber(ctx, phone.Number); err != nil {
+		return fmt.Errorf("order %d alert not sent: %w", order.ID, err)
+	}
+	return s.sender.Send(ctx, &domain.SMSMessage{To: phone.Number, Body: fmt.Sprintf(text, order.ID)})
+}
+
+// allowNumber counts a text to the number against its daily limit. The key
+// holds a hash of the number, so shared stores don't hold phone numbers.
+func (s *smsService) allowNumber(ctx context.Context, number string) error {
+	sum := sha256.Sum256([]byte(number))
+	return s.allow(ctx, "sms:number:"+hex.EncodeToString(sum[:16]), s.limits.PerNumberDaily, 24*time.Hour)
+}
+
+// allow counts a text against key, failing once more than limit were sent
+// within the window; a limit of 0 is unlimited. A failing store lets the
+// text through, since codes must still go out.
+func (s *smsService) allow(ctx context.Context, key string, limit int64, window time.Duration) error {
+	if limit <= 0 {
+		return nil
+	}
+	count, _, err := s.limiter.Increment(key, window)
+	if err != nil {
+		utils.Logf(ctx, "Failed to apply SMS rate limit: %v", err)
+		return nil
+	}
+	if count > limit {
+		return domain.ErrSMSRateLimited
+	}
+	return nil
+}
+
+// setSMSConsent records the user opting into or out of order alerts by text
+func setSMSConsent(ctx context.Context, phone *domain.UserPhone, consent bool) {
+	if !consent {
+		phone.SMSConsentAt = nil
+		phone.SMSConsentIP = ""
+		return
+	}
+	now := time.Now()
+	phone.SMSConsentAt = &now
+	phone.SMSConsentIP = utils.ClientIPFromContext(ctx)
+}
+
+// smsConsentState is the consent of a user to texts, for the audit log; it
+// leaves out the number
+func smsConsentState(phone *domain.UserPhone) interface{} {
+	return map[string]interface{}{
+		"verified":       phone.IsVerified(),
+		"sms_consent_at": phone.SMSConsentAt,
+		"sms_consent_ip": phone.SMSConsentIP,
+	}
+}
+
+// generateSMSCode returns a random code of domain.SMSCodeLength digits
+func generateSMSCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1_000_000))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", domain.SMSCodeLength, n.Int64()), nil
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// taxRateService implements the TaxRateService interface
+type taxRateService struct {
+	taxRepo ports.TaxRateRepository
+	audit   ports.AuditService
+}
+
+// NewTaxRateService creates a new tax rate service instance
+func NewTaxRateService(taxRepo ports.TaxRateRepository, audit ports.AuditService) ports.TaxRateService {
+	return &taxRateService{
+		taxRepo: taxRepo,
+		audit:   audit,
+	}
+}
+
+// CreateTaxRate creates a tax rate for a country or region
+func (s *taxRateService) CreateTaxRate(ctx context.Context, req *domain.TaxRateRequest) (*domain.TaxRate, error) {
+	rate := &domain.TaxRate{}
+	if err := s.fill(ctx, rate, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.taxRepo.Create(ctx, rate); err != nil {
+		return nil, err
+	}
+	recordAudit(ctx, s.audit, "tax_rate.create", rate.ID, nil, rate)
+
+	return rate, nil
+}
+
+// GetTaxRates retrieves all tax rates
+func (s *taxRateService) GetTaxRates(ctx context.Context) ([]*domain.TaxRate, error) {
+	return s.taxRepo.List(ctx)
+}
+
+// UpdateTaxRate updates an existing tax rate
+func (s *taxRateService) UpdateTaxRate(ctx context.Context, id uint, req *domain.TaxRateRequest) (*domain.TaxRate, error) {
+	rate, err := s.taxRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	before := auditState(rate)
+	if err := s.fill(ctx, rate, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.taxRepo.Update(ctx, rate); err != nil {
+		return nil, err
+	}
+	recordAudit(ctx, s.audit, "tax_rate.update", rate.ID, before, rate)
+
+	return rate, nil
+}
+
+// DeleteTaxRate deletes a tax rate
+func (s *taxRateService) DeleteTaxRate(ctx context.Context, id uint) error {
+	rate, err := s.taxRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.taxRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	recordAudit(ctx, s.audit, "tax_rate.delete", id, rate, nil)
+	return nil
+}
+
+// fill copies the request onto the rate, checking no other rate covers the same location
+func (s *taxRateService) fill(ctx context.Context, rate *domain.TaxRate, req *domain.TaxRateRequest) error {
+	country, region := domain.NormalizeTaxLocation(req.Country, req.Region)
+
+	if existing, err := s.taxRepo.GetByLocation(ctx, country, region); err == nil && existing.ID != rate.ID {
+		return errors.New("a tax rate already exists for this location")
+	}
+
+	rate.Country = country
+	rate.Region = region
+	rate.Name = req.Name
+	rate.Rate = req.Rate
+	rate.Inclusive = req.Inclusive
+	return nil
+}
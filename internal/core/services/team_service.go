@@ -0,0 +1,194 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/internal/utils"
+)
+
+// invitationTTL is how long a team invitation stays valid
+const invitationTTL = 7 * 24 * time.Hour
+
+// teamService implements the TeamService interface
+type teamService struct {
+	teamRepo ports.TeamRepository
+	userRepo ports.UserRepository
+	audit    ports.AuditService
+}
+
+// NewTeamService creates a new team service instance
+func NewTeamService(teamRepo ports.TeamRepository, userRepo ports.UserRepository, audit ports.AuditService) ports.TeamService {
+	return &teamService{
+		teamRepo: teamRepo,
+		userRepo: userRepo,
+		audit:    audit,
+	}
+}
+
+// CreateTeam creates a new team owned by the user
+func (s *teamService) CreateTeam(ctx context.Context, req *domain.CreateTeamRequest, userID uint) (*domain.Team, error) {
+	team := &domain.Team{
+		Name:    strings.TrimSpace(req.Name),
+		OwnerID: userID,
+	}
+
+	if !team.IsValid() {
+		return nil, errors.New("invalid team data")
+	}
+
+	if err := s.teamRepo.Create(ctx, team); err != nil {
+		return nil, err
+	}
+	recordAudit(ctx, s.audit, "team.create", team.ID, nil, team)
+
+	return team, nil
+}
+
+// GetTeam retrieves a team, visible to its members only
+func (s *teamService) GetTeam(ctx context.Context, id uint, userID uint) (*domain.Team, error) {
+	if _, err := s.teamRepo.GetMember(ctx, id, userID); err != nil {
+		return nil, errors.New("access denied: you are not a member of this team")
+	}
+
+	return s.teamRepo.GetByID(ctx, id)
+}
+
+// GetTeamsByUser retrieves all teams the user belongs to
+func (s *teamService) GetTeamsByUser(ctx context.Context, userID uint) ([]*domain.Team, error) {
+	return s.teamRepo.ListByUserID(ctx, userID)
+}
+
+// DeleteTeam deletes a team (owner only)
+func (s *teamService) DeleteTeam(ctx context.Context, id uint, userID uint) error {
+	if err := s.requireOwner(ctx, id, userID); err != nil {
+		return err
+	}
+
+	team, err := s.teamRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.teamRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	recordAudit(ctx, s.audit, "team.delete", id, team, nil)
+	return nil
+}
+
+// InviteMember creates an invitation for the given email (owner only)
+func (s *teamService) InviteMember(ctx context.Context, teamID uint, req *domain.InviteTeamMemberRequest, userID uint) (*domain.TeamInvitation, error) {
+	if err := s.requireOwner(ctx, teamID, userID); err != nil {
+		return nil, err
+	}
+
+	if invitee, err := s.userRepo.GetByEmail(ctx, req.Email); err == nil {
+		if _, err := s.teamRepo.GetMember(ctx, teamID, invitee.ID); err == nil {
+			return nil, errors.New("user is already a member of this team")
+		}
+	}
+
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, errors.New("failed to generate invitation token")
+	}
+
+	invitation := &domain.TeamInvitation{
+		TeamID:    teamID,
+		Email:     strings.ToLower(req.Email),
+		Token:     token,
+		InvitedBy: userID,
+		Status:    domain.InvitationStatusPending,
+		ExpiresAt: time.Now().Add(invitationTTL),
+	}
+
+	if err := s.teamRepo.CreateInvitation(ctx, invitation); err != nil {
+		return nil, err
+	}
+	recordAudit(ctx, s.audit, "team.invite", teamID, nil, invitationAuditState(invitation))
+
+	return invitation, nil
+}
+
+// AcceptInvitation adds the user to the invited team
+func (s *teamService) AcceptInvitation(ctx context.Context, token string, userID uint) (*domain.Team, error) {
+	invitation, err := s.teamRepo.GetInvitationByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if !invitation.IsUsable(time.Now()) {
+		return nil, errors.New("invitation is no longer valid")
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.EqualFold(user.Email, invitation.Email) {
+		return nil, errors.New("invitation was sent to a different email")
+	}
+
+	if _, err := s.teamRepo.GetMember(ctx, invitation.TeamID, userID); err == nil {
+		return nil, errors.New("you are already a member of this team")
+	}
+
+	member := &domain.TeamMember{
+		TeamID: invitation.TeamID,
+		UserID: userID,
+		Role:   domain.TeamRoleMember,
+	}
+
+	if err := s.teamRepo.AcceptInvitation(ctx, invitation, member); err != nil {
+		return nil, err
+	}
+	recordAudit(ctx, s.audit, "team.add_member", invitation.TeamID, nil, member)
+
+	return s.teamRepo.GetByID(ctx, invitation.TeamID)
+}
+
+// RemoveMember removes a member from a team (owner, or the member leaving)
+func (s *teamService) RemoveMember(ctx context.Context, teamID, memberID, userID uint) error {
+	if memberID != userID {
+		if err := s.requireOwner(ctx, teamID, userID); err != nil {
+			return err
+		}
+	}
+
+	member, err := s.teamRepo.GetMember(ctx, teamID, memberID)
+	if err != nil {
+		return err
+	}
+
+	if member.IsOwner() {
+		return errors.New("the team owner cannot be removed")
+	}
+
+	if err := s.teamRepo.RemoveMember(ctx, teamID, memberID); err != nil {
+		return err
+	}
+	recordAudit(ctx, s.audit, "team.remove_member", teamID, member, nil)
+	return nil
+}
+
+// invitationAuditState is the state of an invitation kept in the audit log,
+// without its token
+func invitationAuditState(invitation *domain.TeamInvitation) *domain.TeamInvitation {
+	state := *invitation
+	state.Token = ""
+	return &state
+}
+
+// requireOwner checks that the user owns the team
+func (s *teamService) requireOwner(ctx context.Context, teamID, userID uint) error {
+	member, err := s.teamRepo.GetMember(ctx, teamID, userID)
+	if err != nil || !member.IsOwner() {
+		return errors.New("access denied: only the team owner can do this")
+	}
+	return nil
+}
@@ -0,0 +1,205 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/internal/utils"
+)
+
+const (
+	// uploadConfirmWindow is how long after its URL expires an upload may
+	// still be confirmed, for uploads that started just before
+	uploadConfirmWindow = time.Hour
+	// uploadExpireDelay separates the end of the confirm window from the
+	// deletion of unconfirmed files, so a confirmation in progress finishes first
+	uploadExpireDelay = 10 * time.Minute
+)
+
+// uploadService implements the UploadService interface. Clients upload files
+// straight to storage on presigned URLs, so large files never pass through
+// the API, and then confirm the upload for the server to validate the file.
+type uploadService struct {
+	uploadRepo ports.UploadRepository
+	mangaRepo  ports.MangaRepository
+	teamRepo   ports.TeamRepository
+	images     ports.MangaImageService
+	scans      ports.MalwareScanService
+	storage    ports.PresignedStorage
+	jobs       ports.JobService
+	txManager  ports.TransactionManager
+	expiry     time.Duration
+}
+
+// NewUploadService creates a new upload service instance, and registers the
+// handler of the jobs that delete unconfirmed uploads. Presigned URLs are
+// valid for expiry. storage is nil when the file storage can't presign URLs,
+// which turns presigned uploads off. Uploaded files are screened by scans.
+func NewUploadService(uploadRepo ports.UploadRepository, mangaRepo ports.MangaRepository, teamRepo ports.TeamRepository, images ports.MangaImageService, scans ports.MalwareScanService, storage ports.PresignedStorage, jobs ports.JobService, txManager ports.TransactionManager, expiry time.Duration) ports.UploadService {
+	s := &uploadService{
+		uploadRepo: uploadRepo,
+		mangaRepo:  mangaRepo,
+		teamRepo:   teamRepo,
+		images:     images,
+		scans:      scans,
+		storage:    storage,
+		jobs:       jobs,
+		txManager:  txManager,
+		expiry:     expiry,
+	}
+	HandleJob(jobs, s.expireUpload)
+	return s
+}
+
+// Presign records a pending upload of a gallery image and presigns the URL it
+// is uploaded to. The URL only accepts the declared content type and size.
+func (s *uploadService) Presign(ctx context.Context, req *domain.PresignUploadRequest, userID uint) (*domain.PresignedUpload, error) {
+	if s.storage == nil {
+		return nil, errors.New("presigned uploads need S3 storage; upload the image to the API instead")
+	}
+
+	ext, ok := domain.MangaImageTypes[req.ContentType]
+	if !ok {
+		return nil, errors.New("image must be a JPEG, PNG or GIF")
+	}
+	if req.Size > domain.MaxPresignedImageSize {
+		return nil, fmt.Errorf("image must be at most %d MB", domain.MaxPresignedImageSize>>20)
+	}
+
+	manga, err := s.mangaRepo.GetByID(ctx, req.MangaID)
+	if err != nil {
+		return nil, err
+	}
+	if !canManageManga(ctx, s.teamRepo, manga, userID) {
+		return nil, errors.New("access denied: you can only upload images of your own manga")
+	}
+
+	name, err := utils.GenerateRandomToken(16)
+	if err != nil {
+		return nil, errors.New("failed to name image")
+	}
+
+	upload := &domain.Upload{
+		UserID:      userID,
+		Purpose:     req.Purpose,
+		MangaID:     manga.ID,
+		StorageKey:  fmt.Sprintf("mangas/%d/%s.%s", manga.ID, name, ext),
+		ContentType: req.ContentType,
+		Size:        req.Size,
+		Status:      domain.UploadStatusPending,
+		ExpiresAt:   time.Now().Add(s.expiry),
+	}
+	url, err := s.storage.PresignPut(upload.StorageKey, upload.ContentType, upload.Size, s.expiry)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.uploadRepo.Create(ctx, upload); err != nil {
+			return err
+		}
+		runAt := upload.ExpiresAt.Add(uploadConfirmWindow + uploadExpireDelay)
+		_, err := s.jobs.Enqueue(ctx, domain.ExpireUploadArgs{UploadID: upload.ID}, &domain.JobOptions{RunAt: runAt})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.PresignedUpload{
+		Upload:  upload,
+		Method:  http.MethodPut,
+		URL:     url,
+		Headers: map[string]string{"Content-Type": upload.ContentType},
+	}, nil
+}
+
+// Confirm checks the uploaded file and adds it to the manga's gallery. A file
+// that fails the checks stays pending, so the client may upload it again
+// while the URL is valid, unless the malware scanner flagged it: the file is
+// then quarantined and the upload blocked for good.
+func (s *uploadService) Confirm(ctx context.Context, id uint, req *domain.ConfirmUploadRequest, userID uint) (*domain.MangaImage, error) {
+	upload, err := s.uploadRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if upload.UserID != userID {
+		return nil, errors.New("upload not found")
+	}
+	switch upload.Status {
+	case domain.UploadStatusConfirmed:
+		return nil, errors.New("upload has already been confirmed")
+	case domain.UploadStatusBlocked:
+		return nil, fmt.Errorf("upload has been blocked: %w", domain.ErrMalwareDetected)
+	}
+	if time.Now().After(upload.ExpiresAt.Add(uploadConfirmWindow)) {
+		return nil, errors.New("upload has expired")
+	}
+
+	report := &domain.MalwareReport{
+		UploaderID: userID,
+		MangaID:    upload.MangaID,
+		UploadID:   &upload.ID,
+		StorageKey: upload.StorageKey,
+	}
+	err = s.scans.ScanStored(ctx, report)
+	if errors.Is(err, domain.ErrMalwareDetected) {
+		if err := s.uploadRepo.Block(ctx, upload.ID, report.Signature); err != nil {
+			return nil, err
+		}
+		return nil, err
+	}
+	if err != nil {
+		if strings.HasSuffix(err.Error(), "not found") {
+			return nil, errors.New("the image has not been uploaded")
+		}
+		return nil, err
+	}
+
+	var image *domain.MangaImage
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		image, err = s.images.AddStoredImage(ctx, upload.MangaID, &domain.AddStoredMangaImageRequest{
+			StorageKey: upload.StorageKey,
+			URL:        s.storage.URL(upload.StorageKey),
+			Caption:    strings.TrimSpace(req.Caption),
+		}, userID)
+		if err != nil {
+			return err
+		}
+		return s.uploadRepo.Confirm(ctx, upload.ID, image.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return image, nil
+}
+
+// expireUpload deletes the file of an upload that was never confirmed, and
+// the upload itself
+func (s *uploadService) expireUpload(ctx context.Context, job *domain.Job, args domain.ExpireUploadArgs) error {
+	upload, err := s.uploadRepo.GetByID(ctx, args.UploadID)
+	if err != nil {
+		if strings.HasSuffix(err.Error(), "not found") {
+			return nil
+		}
+		return err
+	}
+
+	if upload.Status == domain.UploadStatusBlocked {
+		// Kept as the record of the quarantined file
+		return nil
+	}
+	if upload.Status != domain.UploadStatusConfirmed && s.storage != nil {
+		if err := s.storage.Delete(upload.StorageKey); err != nil {
+			return err
+		}
+	}
+	return s.uploadRepo.Delete(ctx, upload.ID)
+}
@@ -1,28 +1,47 @@
 package services
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
 
 	"github.com/thitiphongD/my-backend/internal/core/domain"
 	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/internal/utils"
 )
 
+// emailChangeTTL is how long the link confirming a new email address is valid for
+const emailChangeTTL = 24 * time.Hour
+
 // userService implements the UserService interface
 type userService struct {
 	userRepo ports.UserRepository
+	audit    ports.AuditService
+	renderer ports.EmailRenderer
+	sender   ports.EmailSender
+	baseURL  string
 }
 
-// NewUserService creates a new user service instance
-func NewUserService(userRepo ports.UserRepository) ports.UserService {
+// NewUserService creates a new user service instance. Changes to users are
+// recorded in the audit log; a new email address only replaces the old one
+// once confirmed from the link emailed to it, built on baseURL.
+func NewUserService(userRepo ports.UserRepository, audit ports.AuditService, renderer ports.EmailRenderer, sender ports.EmailSender, baseURL string) ports.UserService {
 	return &userService{
 		userRepo: userRepo,
+		audit:    audit,
+		renderer: renderer,
+		sender:   sender,
+		baseURL:  baseURL,
 	}
 }
 
 // CreateUser creates a new user
-func (s *userService) CreateUser(req *domain.CreateUserRequest) (*domain.User, error) {
+func (s *userService) CreateUser(ctx context.Context, req *domain.CreateUserRequest) (*domain.User, error) {
 	// Check if user already exists
-	_, err := s.userRepo.GetByEmail(req.Email)
+	_, err := s.userRepo.GetByEmail(ctx, req.Email)
 	if err == nil {
 		return nil, errors.New("user with this email already exists")
 	}
@@ -31,18 +50,20 @@ func (s *userService) CreateUser(req *domain.CreateUserRequest) (*domain.User, e
 	user := &domain.User{
 		Name:  req.Name,
 		Email: req.Email,
+		Role:  domain.RoleUser,
 	}
 
-	if err := s.userRepo.Create(user); err != nil {
+	if err := s.userRepo.Create(ctx, user); err != nil {
 		return nil, err
 	}
+	recordAudit(ctx, s.audit, "user.create", user.ID, nil, user.Sanitize())
 
 	return user.Sanitize(), nil
 }
 
 // GetUserByID retrieves a user by ID
-func (s *userService) GetUserByID(id uint) (*domain.User, error) {
-	user, err := s.userRepo.GetByID(id)
+func (s *userService) GetUserByID(ctx context.Context, id uint) (*domain.User, error) {
+	user, err := s.userRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -50,8 +71,8 @@ func (s *userService) GetUserByID(id uint) (*domain.User, error) {
 }
 
 // GetUsers retrieves all users
-func (s *userService) GetUsers() ([]*domain.User, error) {
-	users, err := s.userRepo.List()
+func (s *userService) GetUsers(ctx context.Context) ([]*domain.User, error) {
+	users, err := s.userRepo.List(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -65,32 +86,306 @@ func (s *userService) GetUsers() ([]*domain.User, error) {
 	return sanitizedUsers, nil
 }
 
-// UpdateUser updates an existing user
-func (s *userService) UpdateUser(id uint, req *domain.CreateUserRequest) (*domain.User, error) {
+// SearchUsers retrieves users whose name starts with the query, for typeahead
+func (s *userService) SearchUsers(ctx context.Context, query string, limit int) ([]*domain.User, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, errors.New("search query is required")
+	}
+
+	if limit < 1 || limit > 20 {
+		limit = 10 // Default suggestion count
+	}
+
+	return s.userRepo.SearchByNamePrefix(ctx, query, limit)
+}
+
+// UpdateUser updates an existing user; only the user themselves or an admin
+// may. A changed email address is only stored as pending, see PatchUser.
+func (s *userService) UpdateUser(ctx context.Context, id uint, req *domain.CreateUserRequest, callerID uint, isAdmin bool) (*domain.User, error) {
+	if err := checkUserAccess(id, callerID, isAdmin); err != nil {
+		return nil, err
+	}
+
 	// Get existing user
-	user, err := s.userRepo.GetByID(id)
+	user, err := s.userRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	if req.IfMatch != "" && !domain.ETagMatches(req.IfMatch, user.ETag()) {
+		return nil, domain.ErrPreconditionFailed
+	}
+	version := user.UpdatedAt
+	before := auditState(user.Sanitize())
 
 	// Update user fields
 	user.Name = req.Name
-	user.Email = req.Email
+	emailChanged, err := s.requestEmailChange(ctx, user, req.Email)
+	if err != nil {
+		return nil, err
+	}
 
-	if err := s.userRepo.Update(user); err != nil {
+	// Fails when someone else saved the user since it was checked
+	if err := s.userRepo.UpdateIfUnchanged(ctx, user, version); err != nil {
 		return nil, err
 	}
+	recordAudit(ctx, s.audit, "user.update", user.ID, before, user.Sanitize())
 
+	if emailChanged {
+		if err := s.sendEmailChange(ctx, user); err != nil {
+			return nil, err
+		}
+	}
 	return user.Sanitize(), nil
 }
 
-// DeleteUser deletes a user by ID
-func (s *userService) DeleteUser(id uint) error {
+// PatchUser applies a partial update to an existing user; only the user
+// themselves or an admin may. A new email address is stored as pending and
+// emailed a confirmation link, and only replaces the current one once
+// ConfirmEmailChange is called with the link's token.
+func (s *userService) PatchUser(ctx context.Context, id uint, req *domain.UpdateUserRequest, callerID uint, isAdmin bool) (*domain.User, error) {
+	if err := checkUserAccess(id, callerID, isAdmin); err != nil {
+		return nil, err
+	}
+
+	// Get existing user
+	user, err := s.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	before := auditState(user.Sanitize())
+
+	emailChanged := false
+	if req.Email != nil {
+		if emailChanged, err = s.requestEmailChange(ctx, user, *req.Email); err != nil {
+			return nil, err
+		}
+	}
+	if req.Name != nil {
+		user.Name = *req.Name
+	}
+	if req.AvatarURL != nil {
+		user.AvatarURL = *req.AvatarURL
+	}
+	if req.Locale != nil {
+		user.Locale = *req.Locale
+	}
+	if req.BirthDate != nil {
+		// The birth date gates mature content, so users cannot change it once
+		// confirmed; admins correct wrong ones
+		if user.BirthDate != nil && !isAdmin {
+			return nil, errors.New("birth date cannot be changed once set; ask an admin to correct it")
+		}
+		birthDate, err := time.Parse("2006-01-02", *req.BirthDate)
+		if err != nil || birthDate.After(time.Now()) {
+			return nil, errors.New("birth_date must be a past date")
+		}
+		user.BirthDate = &birthDate
+	}
+
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	recordAudit(ctx, s.audit, "user.update", user.ID, before, user.Sanitize())
+
+	if emailChanged {
+		if err := s.sendEmailChange(ctx, user); err != nil {
+			return nil, err
+		}
+	}
+	return user.Sanitize(), nil
+}
+
+// ConfirmEmailChange replaces the user's email address with the pending one
+// the token was emailed to
+func (s *userService) ConfirmEmailChange(ctx context.Context, token string) (*domain.User, error) {
+	userID, email, err := utils.ParseEmailChangeToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	// A later change, or a cancelled one, voids the links sent before
+	if user.PendingEmail == "" || user.PendingEmail != email {
+		return nil, errors.New("invalid email change token")
+	}
+	if _, err := s.userRepo.GetByEmail(ctx, email); err == nil {
+		return nil, errors.New("user with this email already exists")
+	}
+	before := auditState(user.Sanitize())
+
+	user.Email = email
+	user.PendingEmail = ""
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	recordAudit(ctx, s.audit, "user.change_email", user.ID, before, user.Sanitize())
+
+	return user.Sanitize(), nil
+}
+
+// DeleteUser deletes a user by ID; only the user themselves or an admin may
+func (s *userService) DeleteUser(ctx context.Context, id uint, callerID uint, isAdmin bool) error {
+	if err := checkUserAccess(id, callerID, isAdmin); err != nil {
+		return err
+	}
+
 	// Check if user exists
-	_, err := s.userRepo.GetByID(id)
+	user, err := s.userRepo.GetByID(ctx, id)
 	if err != nil {
 		return err
 	}
 
-	return s.userRepo.Delete(id)
+	if err := s.userRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	recordAudit(ctx, s.audit, "user.delete", id, user.Sanitize(), nil)
+	return nil
+}
+
+// checkUserAccess refuses changes to another user's account, unless the
+// caller is an admin
+func checkUserAccess(id, callerID uint, isAdmin bool) error {
+	if id != callerID && !isAdmin {
+		return errors.New("access denied: you can only change your own account")
+	}
+	return nil
+}
+
+// requestEmailChange stores email as the user's pending address, and reports
+// whether a confirmation link has to be sent to it. Asking for the current
+// address cancels a pending change.
+func (s *userService) requestEmailChange(ctx context.Context, user *domain.User, email string) (bool, error) {
+	if email == user.Email {
+		user.PendingEmail = ""
+		return false, nil
+	}
+	if _, err := s.userRepo.GetByEmail(ctx, email); err == nil {
+		return false, errors.New("user with this email already exists")
+	}
+	user.PendingEmail = email
+	return true, nil
+}
+
+// sendEmailChange emails the link confirming the user's pending address to
+// that address
+func (s *userService) sendEmailChange(ctx context.Context, user *domain.User) error {
+	token, err := utils.GenerateEmailChangeToken(user.ID, user.PendingEmail, time.Now().Add(emailChangeTTL))
+	if err != nil {
+		return err
+	}
+	msg, err := s.renderer.Render(domain.EmailChange, user.Locale, &domain.EmailData{
+		User:      user,
+		ActionURL: s.baseURL + "/api/v1/users/email/confirm?token=" + url.QueryEscape(token),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to render email change confirmation: %w", err)
+	}
+
+	msg.To = user.PendingEmail
+	if err := s.sender.Send(ctx, msg); err != nil {
+		return errors.New("failed to send email change confirmation")
+	}
+	return nil
+}
+
+// ExportPersonalData passes fn every table's rows of the user's data, for a
+// personal data archive
+func (s *userService) ExportPersonalData(ctx context.Context, id uint, fn func(table string, rows interface{}, tables int) error) error {
+	return s.userRepo.ExportPersonalData(ctx, id, fn)
+}
+
+// SuspendUser suspends a user account until the optional expiry
+func (s *userService) SuspendUser(ctx context.Context, id uint, req *domain.SuspendUserRequest, adminID uint) (*domain.User, error) {
+	if id == adminID {
+		return nil, errors.New("you cannot suspend your own account")
+	}
+
+	user, err := s.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(now) {
+		return nil, errors.New("suspension expiry must be in the future")
+	}
+	before := auditState(user.Sanitize())
+
+	user.SuspendedAt = &now
+	user.SuspendedUntil = req.ExpiresAt
+	user.SuspensionReason = req.Reason
+
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	recordAudit(ctx, s.audit, "user.suspend", user.ID, before, user.Sanitize())
+
+	return user.Sanitize(), nil
+}
+
+// UnsuspendUser lifts a user's suspension
+func (s *userService) UnsuspendUser(ctx context.Context, id uint) (*domain.User, error) {
+	user, err := s.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	before := auditState(user.Sanitize())
+
+	user.SuspendedAt = nil
+	user.SuspendedUntil = nil
+	user.SuspensionReason = ""
+
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	recordAudit(ctx, s.audit, "user.unsuspend", user.ID, before, user.Sanitize())
+
+	return user.Sanitize(), nil
+}
+
+// MergeUsers merges a duplicate (source) account into the target account.
+// With DryRun set, it only reports what would be reassigned.
+func (s *userService) MergeUsers(ctx context.Context, req *domain.MergeUsersRequest) (*domain.MergeUsersResult, error) {
+	if req.SourceID == req.TargetID {
+		return nil, errors.New("source and target users must be different")
+	}
+
+	source, err := s.userRepo.GetByID(ctx, req.SourceID)
+	if err != nil {
+		return nil, errors.New("source user not found")
+	}
+
+	target, err := s.userRepo.GetByID(ctx, req.TargetID)
+	if err != nil {
+		return nil, errors.New("target user not found")
+	}
+
+	if source.IsAdmin() {
+		return nil, errors.New("admin accounts cannot be merged")
+	}
+
+	var reassigned map[string]int64
+	if req.DryRun {
+		reassigned, err = s.userRepo.CountOwnedResources(ctx, source.ID)
+	} else {
+		reassigned, err = s.userRepo.MergeInto(ctx, source.ID, target.ID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.MergeUsersResult{
+		Source:     source.Sanitize(),
+		Target:     target.Sanitize(),
+		Reassigned: reassigned,
+		DryRun:     req.DryRun,
+	}
+	if !req.DryRun {
+		recordAudit(ctx, s.audit, "user.merge", source.ID, source.Sanitize(), result)
+	}
+	return result, nil
 }
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+)
+
+// viewService implements the ViewService interface. Views are buffered in
+// memory and written in batches so reads don't cost a write each.
+type viewService struct {
+	viewRepo     ports.ViewRepository
+	discountRepo ports.DiscountRepository
+
+	mu     sync.Mutex
+	buffer map[uint]int64
+}
+
+// NewViewService creates a new view service instance
+func NewViewService(viewRepo ports.ViewRepository, discountRepo ports.DiscountRepository) ports.ViewService {
+	return &viewService{
+		viewRepo:     viewRepo,
+		discountRepo: discountRepo,
+		buffer:       make(map[uint]int64),
+	}
+}
+
+// RecordView buffers a view of a manga until the next flush
+func (s *viewService) RecordView(mangaID uint) {
+	s.mu.Lock()
+	s.buffer[mangaID]++
+	s.mu.Unlock()
+}
+
+// Flush writes the buffered views to today's counters
+func (s *viewService) Flush() error {
+	s.mu.Lock()
+	if len(s.buffer) == 0 {
+		s.mu.Unlock()
+		return nil
+	}
+	counts := s.buffer
+	s.buffer = make(map[uint]int64)
+	s.mu.Unlock()
+
+	if err := s.viewRepo.IncrementViews(context.Background(), counts, startOfDay(time.Now())); err != nil {
+		// Put the counts back so they are retried on the next flush
+		s.mu.Lock()
+		for mangaID, count := range counts {
+			s.buffer[mangaID] += count
+		}
+		s.mu.Unlock()
+		return err
+	}
+
+	return nil
+}
+
+// StartFlusher flushes buffered views in the background at the given interval
+func (s *viewService) StartFlusher(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if err := s.Flush(); err != nil {
+				log.Printf("view flush failed: %v", err)
+			}
+		}
+	}()
+}
+
+// GetTrending ranks mangas by their weighted views within the window
+func (s *viewService) GetTrending(ctx context.Context, window time.Duration, limit int) ([]*domain.Manga, error) {
+	since := startOfDay(time.Now().Add(-window)).AddDate(0, 0, 1)
+
+	mangas, err := s.viewRepo.GetTrending(ctx, since, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sanitize all mangas
+	sanitizedMangas := make([]*domain.Manga, len(mangas))
+	for i, manga := range mangas {
+		sanitizedMangas[i] = manga.Sanitize()
+	}
+
+	if discounts, err := s.discountRepo.ListActive(ctx, time.Now()); err == nil {
+		domain.ApplyDiscounts(sanitizedMangas, discounts)
+	}
+
+	return sanitizedMangas, nil
+}
+
+// startOfDay truncates a time to midnight UTC
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
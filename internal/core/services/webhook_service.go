@@ -0,0 +1,407 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/internal/utils"
+)
+
+// webhookService implements the WebhookService interface
+type webhookService struct {
+	webhookRepo ports.WebhookRepository
+	sender      ports.WebhookSender
+	jobs        ports.JobService
+	alerts      ports.Alerter
+	audit       ports.AuditService
+}
+
+// NewWebhookService creates a new webhook service instance. Deliveries are
+// sent by deliver_webhook jobs, whose handler it registers with jobs.
+// Operators are alerted when a payment event can't be delivered.
+func NewWebhookService(webhookRepo ports.WebhookRepository, sender ports.WebhookSender, jobs ports.JobService, alerts ports.Alerter, audit ports.AuditService) ports.WebhookService {
+	s := &webhookService{
+		webhookRepo: webhookRepo,
+		sender:      sender,
+		jobs:        jobs,
+		alerts:      alerts,
+		audit:       audit,
+	}
+	HandleJob(jobs, s.deliver)
+	return s
+}
+
+// CreateWebhook registers a new webhook; the secret is only returned here
+func (s *webhookService) CreateWebhook(ctx context.Context, req *domain.CreateWebhookRequest, userID uint) (*domain.Webhook, error) {
+	endpoint, err := url.Parse(req.URL)
+	if err != nil || (endpoint.Scheme != "https" && endpoint.Scheme != "http") || endpoint.Hostname() == "" {
+		return nil, errors.New("webhook URL must use http or https")
+	}
+	// Deliveries are checked again when connecting, since a name may later
+	// resolve elsewhere
+	if err := utils.CheckPublicHost(ctx, endpoint.Hostname()); err != nil {
+		return nil, errors.New("webhook URL must point to a public address: " + err.Error())
+	}
+
+	for _, event := range req.Events {
+		if !domain.IsValidWebhookEvent(event) {
+			return nil, errors.New("unsupported webhook event: " + event)
+		}
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		generated, err := utils.GenerateRandomToken(32)
+		if err != nil {
+			return nil, errors.New("failed to generate webhook secret")
+		}
+		secret = generated
+	}
+
+	webhook := &domain.Webhook{
+		UserID:   userID,
+		URL:      req.URL,
+		Secret:   secret,
+		Events:   strings.Join(req.Events, ","),
+		IsActive: true,
+	}
+
+	if err := s.webhookRepo.Create(ctx, webhook); err != nil {
+		return nil, err
+	}
+	recordAudit(ctx, s.audit, "webhook.create", webhook.ID, nil, webhook.Sanitize())
+
+	return webhook, nil
+}
+
+// GetWebhooks retrieves the user's webhooks without secrets
+func (s *webhookService) GetWebhooks(ctx context.Context, userID uint) ([]*domain.Webhook, error) {
+	webhooks, err := s.webhookRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sanitized := make([]*domain.Webhook, len(webhooks))
+	for i, webhook := range webhooks {
+		sanitized[i] = webhook.Sanitize()
+	}
+
+	return sanitized, nil
+}
+
+// DeleteWebhook deletes one of the user's webhooks
+func (s *webhookService) DeleteWebhook(ctx context.Context, id uint, userID uint) error {
+	webhook, err := s.getOwnedWebhook(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.webhookRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	recordAudit(ctx, s.audit, "webhook.delete", id, webhook.Sanitize(), nil)
+	return nil
+}
+
+// GetDeliveriesPaginated retrieves the delivery log of one of the user's webhooks
+func (s *webhookService) GetDeliveriesPaginated(ctx context.Context, webhookID uint, userID uint, pagination *domain.PaginationRequest) (*domain.PaginatedResult[*domain.WebhookDelivery], error) {
+	if _, err := s.getOwnedWebhook(ctx, webhookID, userID); err != nil {
+		return nil, err
+	}
+
+	deliveries, total, err := s.webhookRepo.ListDeliveriesPaginated(ctx, webhookID, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.PaginatedResult[*domain.WebhookDelivery]{
+		Data:       deliveries,
+		Pagination: domain.NewPaginationResponse(pagination.Page, pagination.PageSize, total),
+	}, nil
+}
+
+// Dispatch sends the event to every active webhook of the user subscribed to it.
+// The deliveries are recorded before Dispatch returns, so the outbox relay only
+// marks an event published once its deliveries are on record; they are sent by
+// background jobs so callers are never blocked by remote endpoints.
+func (s *webhookService) Dispatch(userID uint, event string, data interface{}) {
+	ctx := context.Background()
+	webhooks, err := s.webhookRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		utils.Logf(ctx, "Failed to load webhooks for user %d: %v", userID, err)
+		return
+	}
+
+	for _, webhook := range webhooks {
+		if !webhook.IsActive || !webhook.Subscribes(event) {
+			continue
+		}
+		if delivery := s.recordDelivery(ctx, webhook, event, data); delivery != nil {
+			s.enqueueDelivery(ctx, webhook, delivery)
+		}
+	}
+}
+
+// recordDelivery records a pending delivery of the event with its payload. It
+// returns nil if the delivery could not be recorded or was dead-lettered.
+func (s *webhookService) recordDelivery(ctx context.Context, webhook *domain.Webhook, event string, data interface{}) *domain.WebhookDelivery {
+	delivery := &domain.WebhookDelivery{
+		WebhookID: webhook.ID,
+		Event:     event,
+		Status:    domain.WebhookDeliveryPending,
+	}
+	if err := s.webhookRepo.CreateDelivery(ctx, delivery); err != nil {
+		utils.Logf(ctx, "Failed to record webhook delivery for webhook %d: %v", webhook.ID, err)
+		return nil
+	}
+
+	payload, err := json.Marshal(&domain.WebhookPayload{
+		DeliveryID: delivery.ID,
+		Event:      event,
+		CreatedAt:  delivery.CreatedAt,
+		Data:       data,
+	})
+	if err != nil {
+		delivery.Status = domain.WebhookDeliveryDeadLettered
+		delivery.LastError = "failed to encode payload"
+		_ = s.webhookRepo.UpdateDelivery(ctx, delivery)
+		return nil
+	}
+	delivery.Payload = string(payload)
+	if err := s.webhookRepo.UpdateDelivery(ctx, delivery); err != nil {
+		utils.Logf(ctx, "Failed to update webhook delivery %d: %v", delivery.ID, err)
+	}
+
+	return delivery
+}
+
+// enqueueDelivery queues the job that sends a recorded delivery, dead-lettering
+// the delivery if the job can't be queued. The delivery is tried as often as
+// the retry policy of deliver_webhook jobs allows.
+func (s *webhookService) enqueueDelivery(ctx context.Context, webhook *domain.Webhook, delivery *domain.WebhookDelivery) error {
+	userID := webhook.UserID
+	_, err := s.jobs.Enqueue(ctx, domain.DeliverWebhookArgs{DeliveryID: delivery.ID}, &domain.JobOptions{UserID: &userID})
+	if err != nil {
+		utils.Logf(ctx, "Failed to queue webhook delivery %d: %v", delivery.ID, err)
+		delivery.Status = domain.WebhookDeliveryDeadLettered
+		delivery.LastError = "failed to queue delivery"
+		if err := s.webhookRepo.UpdateDelivery(ctx, delivery); err != nil {
+			utils.Logf(ctx, "Failed to update webhook delivery %d: %v", delivery.ID, err)
+		}
+	}
+	return err
+}
+
+// deliver makes one attempt at a pending delivery and records it. A failed
+// attempt returns its error, so the job queue tries again with backoff; the
+// delivery is dead-lettered with the job's last attempt.
+func (s *webhookService) deliver(ctx context.Context, job *domain.Job, args domain.DeliverWebhookArgs) error {
+	delivery, err := s.webhookRepo.GetDeliveryByID(ctx, args.DeliveryID)
+	if err != nil {
+		if strings.HasSuffix(err.Error(), "not found") {
+			// Deleted along with its webhook
+			return nil
+		}
+		return err
+	}
+	if delivery.Status != domain.WebhookDeliveryPending {
+		// Already finished by an earlier run of the job
+		return nil
+	}
+
+	webhook, err := s.webhookRepo.GetByID(ctx, delivery.WebhookID)
+	if err != nil && !strings.HasSuffix(err.Error(), "not found") {
+		return err
+	}
+	if webhook == nil || !webhook.IsActive {
+		delivery.Status = domain.WebhookDeliveryDeadLettered
+		delivery.LastError = "webhook is inactive"
+		return s.webhookRepo.UpdateDelivery(ctx, delivery)
+	}
+
+	headers := map[string]string{
+		"X-Webhook-Event":    delivery.Event,
+		"X-Webhook-Delivery": strconv.FormatUint(uint64(delivery.ID), 10),
+	}
+
+	delivery.Attempts++
+	started := time.Now()
+	statusCode, sendErr := s.sender.Send(webhook.URL, webhook.Secret, headers, []byte(delivery.Payload))
+
+	attempt := &domain.WebhookDeliveryAttempt{
+		DeliveryID: delivery.ID,
+		Attempt:    delivery.Attempts,
+		StatusCode: statusCode,
+		DurationMs: time.Since(started).Milliseconds(),
+	}
+	if sendErr != nil {
+		attempt.Error = sendErr.Error()
+	}
+	if err := s.webhookRepo.CreateDeliveryAttempt(ctx, attempt); err != nil {
+		utils.Logf(ctx, "Failed to record attempt %d of webhook delivery %d: %v", attempt.Attempt, delivery.ID, err)
+	}
+
+	delivery.StatusCode = statusCode
+	if sendErr == nil {
+		now := time.Now()
+		delivery.Status = domain.WebhookDeliverySucceeded
+		delivery.Success = true
+		delivery.LastError = ""
+		delivery.DeliveredAt = &now
+	} else {
+		delivery.LastError = sendErr.Error()
+		if job.Attempts >= job.MaxAttempts {
+			delivery.Status = domain.WebhookDeliveryDeadLettered
+			utils.Logf(ctx, "Webhook delivery %d dead-lettered after %d attempts: %s", delivery.ID, delivery.Attempts, delivery.LastError)
+			if slices.Contains(domain.PaymentWebhookEvents, delivery.Event) {
+				s.alertPaymentDeadLettered(webhook, delivery)
+			}
+		}
+	}
+
+	if err := s.webhookRepo.UpdateDelivery(ctx, delivery); err != nil {
+		utils.Logf(ctx, "Failed to update webhook delivery %d: %v", delivery.ID, err)
+	}
+	return sendErr
+}
+
+// alertPaymentDeadLettered alerts operators that a seller wasn't told of a
+// payment; the alert is keyed by webhook, so a broken endpoint alerts once
+func (s *webhookService) alertPaymentDeadLettered(webhook *domain.Webhook, delivery *domain.WebhookDelivery) {
+	s.alerts.Alert(&domain.Alert{
+		Key:      "payment_webhook:" + strconv.FormatUint(uint64(webhook.ID), 10),
+		Severity: domain.AlertCritical,
+		Title:    "Payment webhook failed",
+		Message:  delivery.LastError,
+		Fields: []domain.AlertField{
+			{Name: "Event", Value: delivery.Event},
+			{Name: "Webhook", Value: strconv.FormatUint(uint64(webhook.ID), 10)},
+			{Name: "User", Value: strconv.FormatUint(uint64(webhook.UserID), 10)},
+			{Name: "Delivery", Value: strconv.FormatUint(uint64(delivery.ID), 10)},
+			{Name: "Attempts", Value: strconv.Itoa(delivery.Attempts)},
+		},
+	})
+}
+
+// redeliver queues a finished delivery to be sent again
+func (s *webhookService) redeliver(ctx context.Context, delivery *domain.WebhookDelivery) (*domain.WebhookDelivery, error) {
+	if delivery.Status == domain.WebhookDeliveryPending {
+		return nil, errors.New("webhook delivery is still in progress")
+	}
+	if delivery.Payload == "" {
+		return nil, errors.New("webhook delivery has no payload to resend")
+	}
+
+	webhook, err := s.webhookRepo.GetByID(ctx, delivery.WebhookID)
+	if err != nil {
+		return nil, err
+	}
+	if !webhook.IsActive {
+		return nil, errors.New("webhook is inactive")
+	}
+
+	before := auditState(deliveryAuditState(delivery))
+	delivery.Status = domain.WebhookDeliveryPending
+	delivery.Success = false
+	delivery.DeliveredAt = nil
+	if err := s.webhookRepo.UpdateDelivery(ctx, delivery); err != nil {
+		return nil, err
+	}
+
+	if err := s.enqueueDelivery(ctx, webhook, delivery); err != nil {
+		return nil, errors.New("failed to queue webhook delivery")
+	}
+	recordAudit(ctx, s.audit, "webhook_delivery.redeliver", delivery.ID, before, deliveryAuditState(delivery))
+
+	return delivery, nil
+}
+
+// deliveryAuditState is the state of a delivery kept in the audit log,
+// without the payload and the attempt log
+func deliveryAuditState(delivery *domain.WebhookDelivery) *domain.WebhookDelivery {
+	state := *delivery
+	state.Payload = ""
+	state.AttemptLog = nil
+	return &state
+}
+
+// GetDelivery retrieves one delivery of the user's webhook with its attempt log
+func (s *webhookService) GetDelivery(ctx context.Context, webhookID, deliveryID uint, userID uint) (*domain.WebhookDelivery, error) {
+	if _, err := s.getOwnedWebhook(ctx, webhookID, userID); err != nil {
+		return nil, err
+	}
+
+	delivery, err := s.webhookRepo.GetDeliveryByID(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if delivery.WebhookID != webhookID {
+		return nil, errors.New("webhook delivery not found")
+	}
+
+	return delivery, nil
+}
+
+// RedeliverDelivery sends one delivery of the user's webhook again
+func (s *webhookService) RedeliverDelivery(ctx context.Context, webhookID, deliveryID uint, userID uint) (*domain.WebhookDelivery, error) {
+	delivery, err := s.GetDelivery(ctx, webhookID, deliveryID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.redeliver(ctx, delivery)
+}
+
+// GetAllDeliveries retrieves deliveries of every webhook, optionally only those with the given status
+func (s *webhookService) GetAllDeliveries(ctx context.Context, status string, pagination *domain.PaginationRequest) (*domain.PaginatedResult[*domain.WebhookDelivery], error) {
+	if status != "" && !domain.IsValidWebhookDeliveryStatus(status) {
+		return nil, errors.New("status must be one of " + strings.Join(domain.WebhookDeliveryStatuses, ", "))
+	}
+
+	deliveries, total, err := s.webhookRepo.ListDeliveriesByStatusPaginated(ctx, status, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.PaginatedResult[*domain.WebhookDelivery]{
+		Data:       deliveries,
+		Pagination: domain.NewPaginationResponse(pagination.Page, pagination.PageSize, total),
+	}, nil
+}
+
+// GetAnyDelivery retrieves any delivery with its attempt log
+func (s *webhookService) GetAnyDelivery(ctx context.Context, id uint) (*domain.WebhookDelivery, error) {
+	return s.webhookRepo.GetDeliveryByID(ctx, id)
+}
+
+// RedeliverAnyDelivery sends any delivery again
+func (s *webhookService) RedeliverAnyDelivery(ctx context.Context, id uint) (*domain.WebhookDelivery, error) {
+	delivery, err := s.webhookRepo.GetDeliveryByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.redeliver(ctx, delivery)
+}
+
+// getOwnedWebhook retrieves a webhook and checks it belongs to the user
+func (s *webhookService) getOwnedWebhook(ctx context.Context, id uint, userID uint) (*domain.Webhook, error) {
+	webhook, err := s.webhookRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if webhook.UserID != userID {
+		return nil, errors.New("webhook not found")
+	}
+
+	return webhook, nil
+}
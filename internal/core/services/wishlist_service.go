@@ -0,0 +1,321 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/thitiphongD/my-backend/internal/core/domain"
+	"github.com/thitiphongD/my-backend/internal/core/ports"
+	"github.com/thitiphongD/my-backend/internal/utils"
+)
+
+// wishlistService implements the WishlistService interface
+type wishlistService struct {
+	wishlistRepo ports.WishlistRepository
+	mangaRepo    ports.MangaRepository
+	events       ports.EventOutbox
+}
+
+// NewWishlistService creates a new wishlist service instance, and subscribes
+// it to price drops on the bus to notify wishlisters
+func NewWishlistService(wishlistRepo ports.WishlistRepository, mangaRepo ports.MangaRepository, bus ports.EventBus, events ports.EventOutbox) ports.WishlistService {
+	s := &wishlistService{
+		wishlistRepo: wishlistRepo,
+		mangaRepo:    mangaRepo,
+		events:       events,
+	}
+	Subscribe(bus, s.notifyPriceDrop)
+	return s
+}
+
+// notifyPriceDrop records a price drop event for each wishlister of the
+// manga, in the transaction of the price change
+func (s *wishlistService) notifyPriceDrop(ctx context.Context, event domain.MangaPriceDropped) error {
+	userIDs, err := s.wishlistRepo.ListUserIDsByManga(ctx, event.Manga.ID)
+	if err != nil {
+		return err
+	}
+	drop := &domain.WishlistPriceDrop{Manga: event.Manga.Sanitize(), OldPrice: event.OldPrice, NewPrice: event.Manga.Price}
+	for _, wishlisterID := range userIDs {
+		if err := s.events.Record(ctx, wishlisterID, domain.EventWishlistPriceDropped, drop); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newShareToken generates the secret part of a wishlist's share link
+func newShareToken() (string, error) {
+	return utils.GenerateRandomToken(16)
+}
+
+// forOwner prepares a wishlist for its owner, dropping items whose manga was deleted
+func forOwner(wishlist *domain.Wishlist) *domain.Wishlist {
+	items := make([]domain.WishlistItem, 0, len(wishlist.Items))
+	for _, item := range wishlist.Items {
+		if item.Manga == nil {
+			continue
+		}
+		item.Manga = item.Manga.Sanitize()
+		items = append(items, item)
+	}
+	wishlist.Items = items
+	return wishlist
+}
+
+// forViewer prepares a read-only copy of a wishlist showing only published mangas
+func forViewer(wishlist *domain.Wishlist) *domain.Wishlist {
+	items := make([]domain.WishlistItem, 0, len(wishlist.Items))
+	for _, item := range wishlist.Items {
+		if item.Manga == nil || item.Manga.Status != domain.MangaStatusPublished {
+			continue
+		}
+		item.Manga = item.Manga.Sanitize()
+		items = append(items, item)
+	}
+	return wishlist.ReadOnly(items)
+}
+
+// getOwnedWishlist loads a wishlist and checks the user owns it
+func (s *wishlistService) getOwnedWishlist(ctx context.Context, id uint, userID uint) (*domain.Wishlist, error) {
+	wishlist, err := s.wishlistRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if wishlist.UserID != userID {
+		return nil, errors.New("wishlist not found")
+	}
+	return forOwner(wishlist), nil
+}
+
+// reload fetches a wishlist again after a change and prepares it for its owner
+func (s *wishlistService) reload(ctx context.Context, id uint) (*domain.Wishlist, error) {
+	wishlist, err := s.wishlistRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return forOwner(wishlist), nil
+}
+
+// CreateWishlist creates a new named wishlist for the user
+func (s *wishlistService) CreateWishlist(ctx context.Context, req *domain.WishlistRequest, userID uint) (*domain.Wishlist, error) {
+	count, err := s.wishlistRepo.CountByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= domain.MaxWishlistsPerUser {
+		return nil, errors.New("you have reached the maximum number of wishlists")
+	}
+
+	token, err := newShareToken()
+	if err != nil {
+		return nil, errors.New("failed to generate share token")
+	}
+
+	wishlist := &domain.Wishlist{
+		UserID:     userID,
+		Name:       strings.TrimSpace(req.Name),
+		Visibility: req.Visibility,
+		ShareToken: token,
+	}
+	if wishlist.Visibility == "" {
+		wishlist.Visibility = domain.WishlistPrivate
+	}
+	if wishlist.Name == "" {
+		return nil, errors.New("name is required")
+	}
+
+	if err := s.wishlistRepo.Create(ctx, wishlist); err != nil {
+		return nil, err
+	}
+
+	return wishlist, nil
+}
+
+// GetMyWishlists retrieves all of the user's wishlists
+func (s *wishlistService) GetMyWishlists(ctx context.Context, userID uint) ([]*domain.Wishlist, error) {
+	wishlists, err := s.wishlistRepo.ListByUserID(ctx, userID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, wishlist := range wishlists {
+		wishlists[i] = forOwner(wishlist)
+	}
+
+	return wishlists, nil
+}
+
+// GetPublicWishlists retrieves a user's public wishlists as read-only copies
+func (s *wishlistService) GetPublicWishlists(ctx context.Context, userID uint) ([]*domain.Wishlist, error) {
+	wishlists, err := s.wishlistRepo.ListByUserID(ctx, userID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, wishlist := range wishlists {
+		wishlists[i] = forViewer(wishlist)
+	}
+
+	return wishlists, nil
+}
+
+// GetWishlist retrieves a wishlist for its owner, or a read-only copy of a public one
+func (s *wishlistService) GetWishlist(ctx context.Context, id uint, viewerID uint) (*domain.Wishlist, error) {
+	wishlist, err := s.wishlistRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if wishlist.UserID == viewerID {
+		return forOwner(wishlist), nil
+	}
+	if wishlist.Visibility != domain.WishlistPublic {
+		return nil, errors.New("wishlist not found")
+	}
+
+	return forViewer(wishlist), nil
+}
+
+// GetSharedWishlist retrieves a read-only copy of a wishlist through its share link
+func (s *wishlistService) GetSharedWishlist(ctx context.Context, token string) (*domain.Wishlist, error) {
+	wishlist, err := s.wishlistRepo.GetByShareToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if !wishlist.IsShared() {
+		return nil, errors.New("wishlist not found")
+	}
+
+	return forViewer(wishlist), nil
+}
+
+// UpdateWishlist renames a wishlist and changes its visibility
+func (s *wishlistService) UpdateWishlist(ctx context.Context, id uint, req *domain.WishlistRequest, userID uint) (*domain.Wishlist, error) {
+	wishlist, err := s.getOwnedWishlist(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	wishlist.Name = strings.TrimSpace(req.Name)
+	if wishlist.Name == "" {
+		return nil, errors.New("name is required")
+	}
+	if req.Visibility != "" {
+		wishlist.Visibility = req.Visibility
+	}
+
+	if err := s.wishlistRepo.Update(ctx, wishlist); err != nil {
+		return nil, err
+	}
+
+	return wishlist, nil
+}
+
+// RotateShareToken replaces a wishlist's share token, revoking the old link
+func (s *wishlistService) RotateShareToken(ctx context.Context, id uint, userID uint) (*domain.Wishlist, error) {
+	wishlist, err := s.getOwnedWishlist(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := newShareToken()
+	if err != nil {
+		return nil, errors.New("failed to generate share token")
+	}
+	wishlist.ShareToken = token
+
+	if err := s.wishlistRepo.Update(ctx, wishlist); err != nil {
+		return nil, err
+	}
+
+	return wishlist, nil
+}
+
+// DeleteWishlist deletes one of the user's wishlists
+func (s *wishlistService) DeleteWishlist(ctx context.Context, id uint, userID uint) error {
+	if _, err := s.getOwnedWishlist(ctx, id, userID); err != nil {
+		return err
+	}
+
+	return s.wishlistRepo.Delete(ctx, id)
+}
+
+// AddItem adds a published manga to the end of one of the user's wishlists
+func (s *wishlistService) AddItem(ctx context.Context, id uint, req *domain.AddWishlistItemRequest, userID uint) (*domain.Wishlist, error) {
+	wishlist, err := s.getOwnedWishlist(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(wishlist.Items) >= domain.MaxWishlistItems {
+		return nil, errors.New("wishlist is full")
+	}
+	for _, item := range wishlist.Items {
+		if item.MangaID == req.MangaID {
+			return nil, errors.New("manga is already on this wishlist")
+		}
+	}
+
+	manga, err := s.mangaRepo.GetByID(ctx, req.MangaID)
+	if err != nil {
+		return nil, err
+	}
+	if manga.Status != domain.MangaStatusPublished {
+		return nil, errors.New("manga not found")
+	}
+
+	item := &domain.WishlistItem{
+		WishlistID:     wishlist.ID,
+		MangaID:        manga.ID,
+		PriceWhenAdded: manga.Price,
+	}
+	if err := s.wishlistRepo.AddItem(ctx, item); err != nil {
+		return nil, err
+	}
+
+	return s.reload(ctx, id)
+}
+
+// RemoveItem removes a manga from one of the user's wishlists
+func (s *wishlistService) RemoveItem(ctx context.Context, id, mangaID uint, userID uint) (*domain.Wishlist, error) {
+	if _, err := s.getOwnedWishlist(ctx, id, userID); err != nil {
+		return nil, err
+	}
+
+	if err := s.wishlistRepo.RemoveItem(ctx, id, mangaID); err != nil {
+		return nil, err
+	}
+
+	return s.reload(ctx, id)
+}
+
+// ReorderItems reorders a wishlist; the list must contain every manga on it exactly once
+func (s *wishlistService) ReorderItems(ctx context.Context, id uint, req *domain.ReorderWishlistRequest, userID uint) (*domain.Wishlist, error) {
+	wishlist, err := s.getOwnedWishlist(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make(map[uint]bool, len(wishlist.Items))
+	for _, item := range wishlist.Items {
+		members[item.MangaID] = true
+	}
+	if len(req.MangaIDs) != len(members) {
+		return nil, errors.New("manga_ids must list every manga on the wishlist exactly once")
+	}
+	seen := make(map[uint]bool, len(req.MangaIDs))
+	for _, mangaID := range req.MangaIDs {
+		if !members[mangaID] || seen[mangaID] {
+			return nil, errors.New("manga_ids must list every manga on the wishlist exactly once")
+		}
+		seen[mangaID] = true
+	}
+
+	if err := s.wishlistRepo.ReorderItems(ctx, id, req.MangaIDs); err != nil {
+		return nil, err
+	}
+
+	return s.reload(ctx, id)
+}
@@ -0,0 +1,38 @@
+package utils
+
+import "context"
+
+// Actor is the signed-in user a request is made by
+type Actor struct {
+	UserID uint
+	Role   string
+}
+
+// actorKey is the context key of the actor
+type actorKey struct{}
+
+// clientIPKey is the context key of the client IP
+type clientIPKey struct{}
+
+// WithActor returns a copy of ctx carrying the user the request is made by
+func WithActor(ctx context.Context, userID uint, role string) context.Context {
+	return context.WithValue(ctx, actorKey{}, Actor{UserID: userID, Role: role})
+}
+
+// ActorFromContext returns the actor carried by ctx; there is none for
+// anonymous requests and background work
+func ActorFromContext(ctx context.Context) (Actor, bool) {
+	actor, ok := ctx.Value(actorKey{}).(Actor)
+	return actor, ok
+}
+
+// WithClientIP returns a copy of ctx carrying the IP the request came from
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPKey{}, ip)
+}
+
+// ClientIPFromContext returns the client IP carried by ctx, or "" when there is none
+func ClientIPFromContext(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPKey{}).(string)
+	return ip
+}
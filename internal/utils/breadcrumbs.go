@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// maxBreadcrumbs is how many breadcrumbs a trail keeps; older ones are dropped
+const maxBreadcrumbs = 50
+
+// Breadcrumb is a step a request or job took before an error, sent with the
+// error to the error tracker
+type Breadcrumb struct {
+	Time     time.Time
+	Category string
+	Message  string
+}
+
+// breadcrumbTrail collects the breadcrumbs of one request or job
+type breadcrumbTrail struct {
+	mu     sync.Mutex
+	crumbs []Breadcrumb
+}
+
+// breadcrumbsKey is the context key of the breadcrumb trail
+type breadcrumbsKey struct{}
+
+// WithBreadcrumbs returns a copy of ctx carrying a new, empty breadcrumb trail
+func WithBreadcrumbs(ctx context.Context) context.Context {
+	return context.WithValue(ctx, breadcrumbsKey{}, &breadcrumbTrail{})
+}
+
+// AddBreadcrumb adds a breadcrumb to the trail carried by ctx, if any
+func AddBreadcrumb(ctx context.Context, category, message string) {
+	trail, ok := ctx.Value(breadcrumbsKey{}).(*breadcrumbTrail)
+	if !ok {
+		return
+	}
+	trail.mu.Lock()
+	defer trail.mu.Unlock()
+	if len(trail.crumbs) == maxBreadcrumbs {
+		trail.crumbs = append(trail.crumbs[:0], trail.crumbs[1:]...)
+	}
+	trail.crumbs = append(trail.crumbs, Breadcrumb{Time: time.Now(), Category: category, Message: message})
+}
+
+// BreadcrumbsFromContext returns the breadcrumbs carried by ctx, oldest first
+func BreadcrumbsFromContext(ctx context.Context) []Breadcrumb {
+	trail, ok := ctx.Value(breadcrumbsKey{}).(*breadcrumbTrail)
+	if !ok {
+		return nil
+	}
+	trail.mu.Lock()
+	defer trail.mu.Unlock()
+	return append([]Breadcrumb(nil), trail.crumbs...)
+}
@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"encoding/base64"
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GenerateEmailChangeToken returns a token that confirms the user owns email,
+// the address they asked to change theirs to, until expires. It is sent to
+// that address, so only its owner can complete the change.
+func GenerateEmailChangeToken(userID uint, email string, expires time.Time) (string, error) {
+	secret := os.Getenv("JWT_SECRET")
+	if secret == "" {
+		return "", errors.New("JWT_SECRET is not set in environment variables")
+	}
+
+	payload := strconv.FormatUint(uint64(userID), 10) + ":" + strconv.FormatInt(expires.Unix(), 10) + ":" + email
+	return base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." + signEmailChange(secret, payload), nil
+}
+
+// ParseEmailChangeToken checks an email change token and that it has not
+// expired, and returns the user and address it was issued for
+func ParseEmailChangeToken(token string) (uint, string, error) {
+	secret := os.Getenv("JWT_SECRET")
+	if secret == "" {
+		return 0, "", errors.New("JWT_SECRET is not set in environment variables")
+	}
+
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return 0, "", errors.New("invalid email change token")
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return 0, "", errors.New("invalid email change token")
+	}
+	payload := string(raw)
+	if !hmac.Equal([]byte(signature), []byte(signEmailChange(secret, payload))) {
+		return 0, "", errors.New("invalid email change token")
+	}
+
+	parts := strings.SplitN(payload, ":", 3)
+	if len(parts) != 3 {
+		return 0, "", errors.New("invalid email change token")
+	}
+	userID, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return 0, "", errors.New("invalid email change token")
+	}
+	expires, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, "", errors.New("invalid email change token")
+	}
+	if time.Now().Unix() > expires {
+		return 0, "", errors.New("email change link has expired")
+	}
+	return uint(userID), parts[2], nil
+}
+
+// signEmailChange signs an email change token's payload, keeping the
+// signature apart from other uses of the secret
+func signEmailChange(secret, payload string) string {
+	return SignPayload(secret, []byte("email-change:"+payload))
+}
@@ -0,0 +1,157 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+)
+
+// jpegQuality is the quality a JPEG is re-encoded at when it has to be turned
+const jpegQuality = 92
+
+var (
+	jpegSignature = []byte{0xFF, 0xD8}
+	pngSignature  = []byte("\x89PNG\r\n\x1a\n")
+	exifHeader    = []byte("Exif\x00\x00")
+)
+
+// jpegMetadataMarkers are the JPEG segments StripMetadata removes: EXIF and
+// XMP (APP1), IPTC (APP13) and comments. JFIF, ICC profiles and the Adobe
+// segment stay, since they affect how the image is displayed.
+var jpegMetadataMarkers = map[byte]bool{0xE1: true, 0xED: true, 0xFE: true}
+
+// pngMetadataChunks are the PNG chunks StripMetadata removes
+var pngMetadataChunks = map[string]bool{"tEXt": true, "zTXt": true, "iTXt": true, "eXIf": true, "tIME": true}
+
+// StripMetadata removes the EXIF data, such as the camera and GPS position,
+// and other metadata from a JPEG or PNG image without re-encoding it. A JPEG
+// whose EXIF orientation turns it is re-encoded from decoded, the image
+// DecodeImage returned for it, since it would display differently without.
+// Other images are returned as they are.
+func StripMetadata(data []byte, decoded image.Image) ([]byte, error) {
+	switch {
+	case bytes.HasPrefix(data, jpegSignature):
+		if orientation := ImageOrientation(data); orientation >= 2 && orientation <= 8 {
+			return EncodeJPEG(decoded, jpegQuality)
+		}
+		return stripJPEGMetadata(data)
+	case bytes.HasPrefix(data, pngSignature):
+		return stripPNGMetadata(data)
+	default:
+		return data, nil
+	}
+}
+
+// ImageOrientation reads the EXIF orientation of a JPEG image, from 1 to 8.
+// Images without one are displayed as stored, which is orientation 1.
+func ImageOrientation(data []byte) int {
+	orientation := 1
+	_ = walkJPEGSegments(data, func(marker byte, segment []byte) bool {
+		if marker == 0xE1 && bytes.HasPrefix(segment, exifHeader) {
+			if o, ok := exifOrientation(segment[len(exifHeader):]); ok {
+				orientation = o
+			}
+			return false
+		}
+		return true
+	})
+	return orientation
+}
+
+// exifOrientation finds the orientation tag in the first IFD of TIFF data
+func exifOrientation(tiff []byte) (int, bool) {
+	if len(tiff) < 8 {
+		return 0, false
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0, false
+	}
+
+	offset := int(order.Uint32(tiff[4:8]))
+	if offset < 8 || offset+2 > len(tiff) {
+		return 0, false
+	}
+	entries := int(order.Uint16(tiff[offset:]))
+	for i := 0; i < entries; i++ {
+		entry := offset + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 0, false
+		}
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			return int(order.Uint16(tiff[entry+8:])), true
+		}
+	}
+	return 0, false
+}
+
+// walkJPEGSegments calls fn with the marker and contents of each segment
+// before the image data, until fn returns false
+func walkJPEGSegments(data []byte, fn func(marker byte, segment []byte) bool) error {
+	if !bytes.HasPrefix(data, jpegSignature) {
+		return errors.New("not a JPEG image")
+	}
+	for pos := len(jpegSignature); ; {
+		if pos+4 > len(data) || data[pos] != 0xFF {
+			return errors.New("corrupt JPEG image")
+		}
+		marker := data[pos+1]
+		if marker == 0xDA { // start of scan: the image data follows
+			return nil
+		}
+		length := int(binary.BigEndian.Uint16(data[pos+2:]))
+		if length < 2 || pos+2+length > len(data) {
+			return errors.New("corrupt JPEG image")
+		}
+		if !fn(marker, data[pos+4:pos+2+length]) {
+			return nil
+		}
+		pos += 2 + length
+	}
+}
+
+// stripJPEGMetadata copies a JPEG without its metadata segments
+func stripJPEGMetadata(data []byte) ([]byte, error) {
+	out := make([]byte, 0, len(data))
+	out = append(out, jpegSignature...)
+	pos := len(jpegSignature)
+	err := walkJPEGSegments(data, func(marker byte, segment []byte) bool {
+		end := pos + 4 + len(segment)
+		if !jpegMetadataMarkers[marker] {
+			out = append(out, data[pos:end]...)
+		}
+		pos = end
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append(out, data[pos:]...), nil
+}
+
+// stripPNGMetadata copies a PNG without its metadata chunks
+func stripPNGMetadata(data []byte) ([]byte, error) {
+	out := make([]byte, 0, len(data))
+	out = append(out, pngSignature...)
+	for pos := len(pngSignature); pos < len(data); {
+		if pos+12 > len(data) {
+			return nil, errors.New("corrupt PNG image")
+		}
+		// Length, type, data and CRC
+		end := pos + 12 + int(binary.BigEndian.Uint32(data[pos:]))
+		if end < pos+12 || end > len(data) {
+			return nil, errors.New("corrupt PNG image")
+		}
+		if !pngMetadataChunks[string(data[pos+4:pos+8])] {
+			out = append(out, data[pos:end]...)
+		}
+		pos = end
+	}
+	return out, nil
+}
@@ -0,0 +1,42 @@
+package utils
+
+import "strings"
+
+// NormalizeISBN strips hyphens and spaces from an ISBN-10 or ISBN-13 and
+// verifies its check digit. It returns the bare ISBN and whether it is valid.
+func NormalizeISBN(raw string) (string, bool) {
+	isbn := strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(raw)))
+
+	switch len(isbn) {
+	case 10:
+		sum := 0
+		for i, r := range isbn {
+			var digit int
+			switch {
+			case r >= '0' && r <= '9':
+				digit = int(r - '0')
+			case r == 'X' && i == 9:
+				digit = 10
+			default:
+				return "", false
+			}
+			sum += digit * (10 - i)
+		}
+		return isbn, sum%11 == 0
+	case 13:
+		sum := 0
+		for i, r := range isbn {
+			if r < '0' || r > '9' {
+				return "", false
+			}
+			weight := 1
+			if i%2 == 1 {
+				weight = 3
+			}
+			sum += int(r-'0') * weight
+		}
+		return isbn, sum%10 == 0
+	default:
+		return "", false
+	}
+}
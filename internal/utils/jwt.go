@@ -3,11 +3,26 @@ package utils
 import (
 	"errors"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// AuthCookieName is the cookie browser sessions carry their JWT in, instead
+// of the Authorization header
+const AuthCookieName = "access_token"
+
+// JWTLifetime is how long a JWT, and the cookie carrying it, is valid
+const JWTLifetime = 24 * time.Hour
+
+// Auth modes, which decide how clients send their JWT
+const (
+	AuthModeHeader = "header" // in the Authorization header only
+	AuthModeCookie = "cookie" // in the auth cookie only; login doesn't return it
+	AuthModeBoth   = "both"   // in either; login returns it and sets the cookie
+)
+
 type JWTClaims struct {
 	UserID uint   `json:"user_id"`
 	Email  string `json:"email"`
@@ -21,11 +36,18 @@ func GenerateJWT(userID uint, email string) (string, error) {
 		return "", errors.New("JWT_SECRET is not set in environment variables")
 	}
 
+	// The ID tells sessions apart, so CSRF tokens can be bound to one
+	id, err := GenerateRandomToken(16)
+	if err != nil {
+		return "", err
+	}
+
 	claims := &JWTClaims{
 		UserID: userID,
 		Email:  email,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
+			ID:        id,
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(JWTLifetime)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
 		},
@@ -40,6 +62,16 @@ func GenerateJWT(userID uint, email string) (string, error) {
 	return tokenString, nil
 }
 
+// Session identifies the session the token was issued for: its user and ID.
+// Tokens issued before they carried an ID are told apart by issue time.
+func (c *JWTClaims) Session() string {
+	id := c.ID
+	if id == "" && c.IssuedAt != nil {
+		id = strconv.FormatInt(c.IssuedAt.Unix(), 10)
+	}
+	return strconv.FormatUint(uint64(c.UserID), 10) + ":" + id
+}
+
 // ValidateJWT validates the JWT token and returns the claims
 func ValidateJWT(tokenString string) (*JWTClaims, error) {
 	jwtSecret := os.Getenv("JWT_SECRET")
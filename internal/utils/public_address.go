@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"net"
+)
+
+// reservedNetworks are special-purpose ranges that net.IP doesn't classify but
+// that never belong to a public host, such as carrier-grade NAT
+var reservedNetworks = mustParseCIDRs(
+	"0.0.0.0/8",
+	"100.64.0.0/10",
+	"192.0.0.0/24",
+	"198.18.0.0/15",
+	"240.0.0.0/4",
+)
+
+// IsPublicIP reports whether ip is a public unicast address: not loopback,
+// link-local, private, unspecified, multicast or otherwise reserved. Requests
+// sent on a user's behalf must only go to public addresses, so they can't
+// reach internal services.
+func IsPublicIP(ip net.IP) bool {
+	if !ip.IsGlobalUnicast() || ip.IsPrivate() {
+		return false
+	}
+	for _, network := range reservedNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckPublicHost resolves host, a name or an IP address, and fails unless
+// every address it resolves to is public
+func CheckPublicHost(ctx context.Context, host string) error {
+	if ip := net.ParseIP(host); ip != nil {
+		if !IsPublicIP(ip) {
+			return errors.New("host is not a public address")
+		}
+		return nil
+	}
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil || len(addrs) == 0 {
+		return errors.New("host could not be resolved")
+	}
+	for _, addr := range addrs {
+		if !IsPublicIP(addr.IP) {
+			return errors.New("host is not a public address")
+		}
+	}
+	return nil
+}
+
+// mustParseCIDRs parses constant CIDR ranges, panicking on a typo
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"log"
+)
+
+// RequestIDHeader is the header that carries a request's correlation ID
+const RequestIDHeader = "X-Request-ID"
+
+// requestIDKey is the context key of the request ID
+type requestIDKey struct{}
+
+// WithRequestID returns a copy of ctx carrying the request ID
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request ID carried by ctx, or "" when there is none
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// Logf logs like log.Printf, prefixed with the request ID carried by ctx so
+// lines from every layer of one request can be correlated. The line is also
+// left as a breadcrumb, in case the request fails later.
+func Logf(ctx context.Context, format string, args ...interface{}) {
+	message := fmt.Sprintf(format, args...)
+	AddBreadcrumb(ctx, "log", message)
+	if requestID := RequestIDFromContext(ctx); requestID != "" {
+		message = "[" + requestID + "] " + message
+	}
+	log.Output(2, message)
+}
@@ -0,0 +1,26 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Slugify converts text into a lowercase, hyphen-separated URL slug
+func Slugify(text string) string {
+	var b strings.Builder
+	lastHyphen := true
+
+	for _, r := range strings.ToLower(strings.TrimSpace(text)) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			lastHyphen = false
+			continue
+		}
+		if !lastHyphen {
+			b.WriteRune('-')
+			lastHyphen = true
+		}
+	}
+
+	return strings.TrimSuffix(b.String(), "-")
+}
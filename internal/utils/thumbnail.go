@@ -0,0 +1,123 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	_ "image/gif" // register GIF decoding
+	"image/jpeg"
+	_ "image/png" // register PNG decoding
+	"io"
+)
+
+// CheckImage reads the header of a JPEG, PNG or GIF image to check it can be decoded
+func CheckImage(r io.Reader) error {
+	if _, _, err := image.DecodeConfig(r); err != nil {
+		return errors.New("unsupported or corrupt image")
+	}
+	return nil
+}
+
+// DecodeImage decodes a JPEG, PNG or GIF image, turning a JPEG the way its
+// EXIF orientation says it is displayed
+func DecodeImage(data []byte) (image.Image, error) {
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.New("unsupported or corrupt image")
+	}
+	if bounds := src.Bounds(); bounds.Dx() == 0 || bounds.Dy() == 0 {
+		return nil, errors.New("unsupported or corrupt image")
+	}
+	return orient(src, ImageOrientation(data)), nil
+}
+
+// ResizeImage scales an image down to fit within maxSize×maxSize. Smaller
+// images keep their size.
+func ResizeImage(src image.Image, maxSize int) image.Image {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	thumbWidth, thumbHeight := width, height
+	if width > maxSize || height > maxSize {
+		if width >= height {
+			thumbWidth, thumbHeight = maxSize, max(1, height*maxSize/width)
+		} else {
+			thumbWidth, thumbHeight = max(1, width*maxSize/height), maxSize
+		}
+	}
+
+	// Box filter: each thumbnail pixel averages the source pixels it covers
+	thumb := image.NewRGBA(image.Rect(0, 0, thumbWidth, thumbHeight))
+	for y := 0; y < thumbHeight; y++ {
+		y0 := bounds.Min.Y + y*height/thumbHeight
+		y1 := max(y0+1, bounds.Min.Y+(y+1)*height/thumbHeight)
+		for x := 0; x < thumbWidth; x++ {
+			x0 := bounds.Min.X + x*width/thumbWidth
+			x1 := max(x0+1, bounds.Min.X+(x+1)*width/thumbWidth)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := src.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa)
+					n++
+				}
+			}
+			thumb.SetRGBA64(x, y, color.RGBA64{
+				R: uint16(r / n), G: uint16(g / n), B: uint16(b / n), A: uint16(a / n),
+			})
+		}
+	}
+	return thumb
+}
+
+// EncodeJPEG encodes an image as a JPEG of the given quality
+func EncodeJPEG(img image.Image, quality int) ([]byte, error) {
+	var out bytes.Buffer
+	if err := jpeg.Encode(&out, img, &jpeg.Options{Quality: quality}); err != nil {
+		return nil, errors.New("failed to encode image")
+	}
+	return out.Bytes(), nil
+}
+
+// orient turns an image the way an EXIF orientation of 2 to 8 says it is displayed
+func orient(src image.Image, orientation int) image.Image {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	// Orientations 5 to 8 swap the sides
+	swap := orientation >= 5
+	dstWidth, dstHeight := width, height
+	if swap {
+		dstWidth, dstHeight = height, width
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // mirrored
+				dx, dy = width-1-x, y
+			case 3: // rotated 180°
+				dx, dy = width-1-x, height-1-y
+			case 4: // mirrored and rotated 180°
+				dx, dy = x, height-1-y
+			case 5: // mirrored and rotated 90° counterclockwise
+				dx, dy = y, x
+			case 6: // rotated 90° clockwise
+				dx, dy = height-1-y, x
+			case 7: // mirrored and rotated 90° clockwise
+				dx, dy = height-1-y, width-1-x
+			case 8: // rotated 90° counterclockwise
+				dx, dy = y, width-1-x
+			}
+			dst.Set(dx, dy, src.At(bounds.Min.X+x, bounds.Min.Y+y))
+		}
+	}
+	return dst
+}
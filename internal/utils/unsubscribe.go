@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"encoding/base64"
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// GenerateUnsubscribeToken returns a token that unsubscribes the user from a
+// category of email without signing in. It never expires, so the links in
+// old emails keep working.
+func GenerateUnsubscribeToken(userID uint, category string) (string, error) {
+	secret := os.Getenv("JWT_SECRET")
+	if secret == "" {
+		return "", errors.New("JWT_SECRET is not set in environment variables")
+	}
+
+	payload := strconv.FormatUint(uint64(userID), 10) + ":" + category
+	return base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." + signUnsubscribe(secret, payload), nil
+}
+
+// ParseUnsubscribeToken checks an unsubscribe token and returns the user and
+// category it was issued for
+func ParseUnsubscribeToken(token string) (uint, string, error) {
+	secret := os.Getenv("JWT_SECRET")
+	if secret == "" {
+		return 0, "", errors.New("JWT_SECRET is not set in environment variables")
+	}
+
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return 0, "", errors.New("invalid unsubscribe token")
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return 0, "", errors.New("invalid unsubscribe token")
+	}
+	payload := string(raw)
+	if !hmac.Equal([]byte(signature), []byte(signUnsubscribe(secret, payload))) {
+		return 0, "", errors.New("invalid unsubscribe token")
+	}
+
+	id, category, _ := strings.Cut(payload, ":")
+	userID, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return 0, "", errors.New("invalid unsubscribe token")
+	}
+	return uint(userID), category, nil
+}
+
+// signUnsubscribe signs an unsubscribe token's payload, keeping the signature
+// apart from other uses of the secret
+func signUnsubscribe(secret, payload string) string {
+	return SignPayload(secret, []byte("unsubscribe:"+payload))
+}
@@ -0,0 +1,711 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: mybackend/v1/manga.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Manga struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Price float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
+	// Set only when a discount applies
+	EffectivePrice    *float64               `protobuf:"fixed64,4,opt,name=effective_price,json=effectivePrice,proto3,oneof" json:"effective_price,omitempty"`
+	IsActive          bool                   `protobuf:"varint,5,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	UserCreated       uint32                 `protobuf:"varint,6,opt,name=user_created,json=userCreated,proto3" json:"user_created,omitempty"`
+	TeamId            *uint32                `protobuf:"varint,7,opt,name=team_id,json=teamId,proto3,oneof" json:"team_id,omitempty"`
+	Genres            []string               `protobuf:"bytes,8,rep,name=genres,proto3" json:"genres,omitempty"`
+	Status            string                 `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"`
+	PublicationStatus string                 `protobuf:"bytes,10,opt,name=publication_status,json=publicationStatus,proto3" json:"publication_status,omitempty"`
+	ContentRating     string                 `protobuf:"bytes,11,opt,name=content_rating,json=contentRating,proto3" json:"content_rating,omitempty"`
+	StockQuantity     int32                  `protobuf:"varint,12,opt,name=stock_quantity,json=stockQuantity,proto3" json:"stock_quantity,omitempty"`
+	AverageRating     float64                `protobuf:"fixed64,13,opt,name=average_rating,json=averageRating,proto3" json:"average_rating,omitempty"`
+	ReviewCount       int32                  `protobuf:"varint,14,opt,name=review_count,json=reviewCount,proto3" json:"review_count,omitempty"`
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt         *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Manga) Reset() {
+	*x = Manga{}
+	mi := &file_mybackend_v1_manga_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Manga) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Manga) ProtoMessage() {}
+
+func (x *Manga) ProtoReflect() protoreflect.Message {
+	mi := &file_mybackend_v1_manga_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Manga.ProtoReflect.Descriptor instead.
+func (*Manga) Descriptor() ([]byte, []int) {
+	return file_mybackend_v1_manga_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Manga) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Manga) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Manga) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Manga) GetEffectivePrice() float64 {
+	if x != nil && x.EffectivePrice != nil {
+		return *x.EffectivePrice
+	}
+	return 0
+}
+
+func (x *Manga) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *Manga) GetUserCreated() uint32 {
+	if x != nil {
+		return x.UserCreated
+	}
+	return 0
+}
+
+func (x *Manga) GetTeamId() uint32 {
+	if x != nil && x.TeamId != nil {
+		return *x.TeamId
+	}
+	return 0
+}
+
+func (x *Manga) GetGenres() []string {
+	if x != nil {
+		return x.Genres
+	}
+	return nil
+}
+
+func (x *Manga) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Manga) GetPublicationStatus() string {
+	if x != nil {
+		return x.PublicationStatus
+	}
+	return ""
+}
+
+func (x *Manga) GetContentRating() string {
+	if x != nil {
+		return x.ContentRating
+	}
+	return ""
+}
+
+func (x *Manga) GetStockQuantity() int32 {
+	if x != nil {
+		return x.StockQuantity
+	}
+	return 0
+}
+
+func (x *Manga) GetAverageRating() float64 {
+	if x != nil {
+		return x.AverageRating
+	}
+	return 0
+}
+
+func (x *Manga) GetReviewCount() int32 {
+	if x != nil {
+		return x.ReviewCount
+	}
+	return 0
+}
+
+func (x *Manga) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Manga) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type GetMangaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMangaRequest) Reset() {
+	*x = GetMangaRequest{}
+	mi := &file_mybackend_v1_manga_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMangaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMangaRequest) ProtoMessage() {}
+
+func (x *GetMangaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mybackend_v1_manga_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMangaRequest.ProtoReflect.Descriptor instead.
+func (*GetMangaRequest) Descriptor() ([]byte, []int) {
+	return file_mybackend_v1_manga_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetMangaRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// ListMangasRequest takes the same filters as GET /api/v1/mangas
+type ListMangasRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Page              int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize          int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Genre             string                 `protobuf:"bytes,3,opt,name=genre,proto3" json:"genre,omitempty"`
+	Query             string                 `protobuf:"bytes,4,opt,name=query,proto3" json:"query,omitempty"`
+	PublicationStatus string                 `protobuf:"bytes,5,opt,name=publication_status,json=publicationStatus,proto3" json:"publication_status,omitempty"`
+	ContentRating     string                 `protobuf:"bytes,6,opt,name=content_rating,json=contentRating,proto3" json:"content_rating,omitempty"`
+	UserId            *uint32                `protobuf:"varint,7,opt,name=user_id,json=userId,proto3,oneof" json:"user_id,omitempty"`
+	// Same format as the sort query parameter, e.g. "price:asc"
+	Sort          string `protobuf:"bytes,8,opt,name=sort,proto3" json:"sort,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMangasRequest) Reset() {
+	*x = ListMangasRequest{}
+	mi := &file_mybackend_v1_manga_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMangasRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMangasRequest) ProtoMessage() {}
+
+func (x *ListMangasRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mybackend_v1_manga_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMangasRequest.ProtoReflect.Descriptor instead.
+func (*ListMangasRequest) Descriptor() ([]byte, []int) {
+	return file_mybackend_v1_manga_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListMangasRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListMangasRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListMangasRequest) GetGenre() string {
+	if x != nil {
+		return x.Genre
+	}
+	return ""
+}
+
+func (x *ListMangasRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *ListMangasRequest) GetPublicationStatus() string {
+	if x != nil {
+		return x.PublicationStatus
+	}
+	return ""
+}
+
+func (x *ListMangasRequest) GetContentRating() string {
+	if x != nil {
+		return x.ContentRating
+	}
+	return ""
+}
+
+func (x *ListMangasRequest) GetUserId() uint32 {
+	if x != nil && x.UserId != nil {
+		return *x.UserId
+	}
+	return 0
+}
+
+func (x *ListMangasRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+type Pagination struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CurrentPage   int32                  `protobuf:"varint,1,opt,name=current_page,json=currentPage,proto3" json:"current_page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	TotalItems    int64                  `protobuf:"varint,3,opt,name=total_items,json=totalItems,proto3" json:"total_items,omitempty"`
+	TotalPages    int32                  `protobuf:"varint,4,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Pagination) Reset() {
+	*x = Pagination{}
+	mi := &file_mybackend_v1_manga_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pagination) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pagination) ProtoMessage() {}
+
+func (x *Pagination) ProtoReflect() protoreflect.Message {
+	mi := &file_mybackend_v1_manga_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pagination.ProtoReflect.Descriptor instead.
+func (*Pagination) Descriptor() ([]byte, []int) {
+	return file_mybackend_v1_manga_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Pagination) GetCurrentPage() int32 {
+	if x != nil {
+		return x.CurrentPage
+	}
+	return 0
+}
+
+func (x *Pagination) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *Pagination) GetTotalItems() int64 {
+	if x != nil {
+		return x.TotalItems
+	}
+	return 0
+}
+
+func (x *Pagination) GetTotalPages() int32 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+type ListMangasResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Mangas        []*Manga               `protobuf:"bytes,1,rep,name=mangas,proto3" json:"mangas,omitempty"`
+	Pagination    *Pagination            `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMangasResponse) Reset() {
+	*x = ListMangasResponse{}
+	mi := &file_mybackend_v1_manga_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMangasResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMangasResponse) ProtoMessage() {}
+
+func (x *ListMangasResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mybackend_v1_manga_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMangasResponse.ProtoReflect.Descriptor instead.
+func (*ListMangasResponse) Descriptor() ([]byte, []int) {
+	return file_mybackend_v1_manga_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListMangasResponse) GetMangas() []*Manga {
+	if x != nil {
+		return x.Mangas
+	}
+	return nil
+}
+
+func (x *ListMangasResponse) GetPagination() *Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type CreateMangaRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Name              string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Price             float64                `protobuf:"fixed64,2,opt,name=price,proto3" json:"price,omitempty"`
+	IsActive          bool                   `protobuf:"varint,3,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	StockQuantity     int32                  `protobuf:"varint,4,opt,name=stock_quantity,json=stockQuantity,proto3" json:"stock_quantity,omitempty"`
+	TeamId            *uint32                `protobuf:"varint,5,opt,name=team_id,json=teamId,proto3,oneof" json:"team_id,omitempty"`
+	GenreIds          []uint32               `protobuf:"varint,6,rep,packed,name=genre_ids,json=genreIds,proto3" json:"genre_ids,omitempty"`
+	Submit            bool                   `protobuf:"varint,7,opt,name=submit,proto3" json:"submit,omitempty"`
+	Force             bool                   `protobuf:"varint,8,opt,name=force,proto3" json:"force,omitempty"`
+	PublicationStatus string                 `protobuf:"bytes,9,opt,name=publication_status,json=publicationStatus,proto3" json:"publication_status,omitempty"`
+	ContentRating     string                 `protobuf:"bytes,10,opt,name=content_rating,json=contentRating,proto3" json:"content_rating,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CreateMangaRequest) Reset() {
+	*x = CreateMangaRequest{}
+	mi := &file_mybackend_v1_manga_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateMangaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMangaRequest) ProtoMessage() {}
+
+func (x *CreateMangaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mybackend_v1_manga_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateMangaRequest.ProtoReflect.Descriptor instead.
+func (*CreateMangaRequest) Descriptor() ([]byte, []int) {
+	return file_mybackend_v1_manga_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateMangaRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateMangaRequest) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *CreateMangaRequest) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *CreateMangaRequest) GetStockQuantity() int32 {
+	if x != nil {
+		return x.StockQuantity
+	}
+	return 0
+}
+
+func (x *CreateMangaRequest) GetTeamId() uint32 {
+	if x != nil && x.TeamId != nil {
+		return *x.TeamId
+	}
+	return 0
+}
+
+func (x *CreateMangaRequest) GetGenreIds() []uint32 {
+	if x != nil {
+		return x.GenreIds
+	}
+	return nil
+}
+
+func (x *CreateMangaRequest) GetSubmit() bool {
+	if x != nil {
+		return x.Submit
+	}
+	return false
+}
+
+func (x *CreateMangaRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+func (x *CreateMangaRequest) GetPublicationStatus() string {
+	if x != nil {
+		return x.PublicationStatus
+	}
+	return ""
+}
+
+func (x *CreateMangaRequest) GetContentRating() string {
+	if x != nil {
+		return x.ContentRating
+	}
+	return ""
+}
+
+var File_mybackend_v1_manga_proto protoreflect.FileDescriptor
+
+const file_mybackend_v1_manga_proto_rawDesc = "" +
+	"\n" +
+	"\x18mybackend/v1/manga.proto\x12\fmybackend.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xda\x04\n" +
+	"\x05Manga\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05price\x18\x03 \x01(\x01R\x05price\x12,\n" +
+	"\x0feffective_price\x18\x04 \x01(\x01H\x00R\x0eeffectivePrice\x88\x01\x01\x12\x1b\n" +
+	"\tis_active\x18\x05 \x01(\bR\bisActive\x12!\n" +
+	"\fuser_created\x18\x06 \x01(\rR\vuserCreated\x12\x1c\n" +
+	"\ateam_id\x18\a \x01(\rH\x01R\x06teamId\x88\x01\x01\x12\x16\n" +
+	"\x06genres\x18\b \x03(\tR\x06genres\x12\x16\n" +
+	"\x06status\x18\t \x01(\tR\x06status\x12-\n" +
+	"\x12publication_status\x18\n" +
+	" \x01(\tR\x11publicationStatus\x12%\n" +
+	"\x0econtent_rating\x18\v \x01(\tR\rcontentRating\x12%\n" +
+	"\x0estock_quantity\x18\f \x01(\x05R\rstockQuantity\x12%\n" +
+	"\x0eaverage_rating\x18\r \x01(\x01R\raverageRating\x12!\n" +
+	"\freview_count\x18\x0e \x01(\x05R\vreviewCount\x129\n" +
+	"\n" +
+	"created_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\x12\n" +
+	"\x10_effective_priceB\n" +
+	"\n" +
+	"\b_team_id\"!\n" +
+	"\x0fGetMangaRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"\x84\x02\n" +
+	"\x11ListMangasRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x14\n" +
+	"\x05genre\x18\x03 \x01(\tR\x05genre\x12\x14\n" +
+	"\x05query\x18\x04 \x01(\tR\x05query\x12-\n" +
+	"\x12publication_status\x18\x05 \x01(\tR\x11publicationStatus\x12%\n" +
+	"\x0econtent_rating\x18\x06 \x01(\tR\rcontentRating\x12\x1c\n" +
+	"\auser_id\x18\a \x01(\rH\x00R\x06userId\x88\x01\x01\x12\x12\n" +
+	"\x04sort\x18\b \x01(\tR\x04sortB\n" +
+	"\n" +
+	"\b_user_id\"\x8e\x01\n" +
+	"\n" +
+	"Pagination\x12!\n" +
+	"\fcurrent_page\x18\x01 \x01(\x05R\vcurrentPage\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1f\n" +
+	"\vtotal_items\x18\x03 \x01(\x03R\n" +
+	"totalItems\x12\x1f\n" +
+	"\vtotal_pages\x18\x04 \x01(\x05R\n" +
+	"totalPages\"{\n" +
+	"\x12ListMangasResponse\x12+\n" +
+	"\x06mangas\x18\x01 \x03(\v2\x13.mybackend.v1.MangaR\x06mangas\x128\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2\x18.mybackend.v1.PaginationR\n" +
+	"pagination\"\xcd\x02\n" +
+	"\x12CreateMangaRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05price\x18\x02 \x01(\x01R\x05price\x12\x1b\n" +
+	"\tis_active\x18\x03 \x01(\bR\bisActive\x12%\n" +
+	"\x0estock_quantity\x18\x04 \x01(\x05R\rstockQuantity\x12\x1c\n" +
+	"\ateam_id\x18\x05 \x01(\rH\x00R\x06teamId\x88\x01\x01\x12\x1b\n" +
+	"\tgenre_ids\x18\x06 \x03(\rR\bgenreIds\x12\x16\n" +
+	"\x06submit\x18\a \x01(\bR\x06submit\x12\x14\n" +
+	"\x05force\x18\b \x01(\bR\x05force\x12-\n" +
+	"\x12publication_status\x18\t \x01(\tR\x11publicationStatus\x12%\n" +
+	"\x0econtent_rating\x18\n" +
+	" \x01(\tR\rcontentRatingB\n" +
+	"\n" +
+	"\b_team_id2\xe5\x01\n" +
+	"\fMangaService\x12>\n" +
+	"\bGetManga\x12\x1d.mybackend.v1.GetMangaRequest\x1a\x13.mybackend.v1.Manga\x12O\n" +
+	"\n" +
+	"ListMangas\x12\x1f.mybackend.v1.ListMangasRequest\x1a .mybackend.v1.ListMangasResponse\x12D\n" +
+	"\vCreateManga\x12 .mybackend.v1.CreateMangaRequest\x1a\x13.mybackend.v1.MangaB-Z+github.com/thitiphongD/my-backend/pkg/pb;pbb\x06proto3"
+
+var (
+	file_mybackend_v1_manga_proto_rawDescOnce sync.Once
+	file_mybackend_v1_manga_proto_rawDescData []byte
+)
+
+func file_mybackend_v1_manga_proto_rawDescGZIP() []byte {
+	file_mybackend_v1_manga_proto_rawDescOnce.Do(func() {
+		file_mybackend_v1_manga_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_mybackend_v1_manga_proto_rawDesc), len(file_mybackend_v1_manga_proto_rawDesc)))
+	})
+	return file_mybackend_v1_manga_proto_rawDescData
+}
+
+var file_mybackend_v1_manga_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_mybackend_v1_manga_proto_goTypes = []any{
+	(*Manga)(nil),                 // 0: mybackend.v1.Manga
+	(*GetMangaRequest)(nil),       // 1: mybackend.v1.GetMangaRequest
+	(*ListMangasRequest)(nil),     // 2: mybackend.v1.ListMangasRequest
+	(*Pagination)(nil),            // 3: mybackend.v1.Pagination
+	(*ListMangasResponse)(nil),    // 4: mybackend.v1.ListMangasResponse
+	(*CreateMangaRequest)(nil),    // 5: mybackend.v1.CreateMangaRequest
+	(*timestamppb.Timestamp)(nil), // 6: google.protobuf.Timestamp
+}
+var file_mybackend_v1_manga_proto_depIdxs = []int32{
+	6, // 0: mybackend.v1.Manga.created_at:type_name -> google.protobuf.Timestamp
+	6, // 1: mybackend.v1.Manga.updated_at:type_name -> google.protobuf.Timestamp
+	0, // 2: mybackend.v1.ListMangasResponse.mangas:type_name -> mybackend.v1.Manga
+	3, // 3: mybackend.v1.ListMangasResponse.pagination:type_name -> mybackend.v1.Pagination
+	1, // 4: mybackend.v1.MangaService.GetManga:input_type -> mybackend.v1.GetMangaRequest
+	2, // 5: mybackend.v1.MangaService.ListMangas:input_type -> mybackend.v1.ListMangasRequest
+	5, // 6: mybackend.v1.MangaService.CreateManga:input_type -> mybackend.v1.CreateMangaRequest
+	0, // 7: mybackend.v1.MangaService.GetManga:output_type -> mybackend.v1.Manga
+	4, // 8: mybackend.v1.MangaService.ListMangas:output_type -> mybackend.v1.ListMangasResponse
+	0, // 9: mybackend.v1.MangaService.CreateManga:output_type -> mybackend.v1.Manga
+	7, // [7:10] is the sub-list for method output_type
+	4, // [4:7] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_mybackend_v1_manga_proto_init() }
+func file_mybackend_v1_manga_proto_init() {
+	if File_mybackend_v1_manga_proto != nil {
+		return
+	}
+	file_mybackend_v1_manga_proto_msgTypes[0].OneofWrappers = []any{}
+	file_mybackend_v1_manga_proto_msgTypes[2].OneofWrappers = []any{}
+	file_mybackend_v1_manga_proto_msgTypes[5].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_mybackend_v1_manga_proto_rawDesc), len(file_mybackend_v1_manga_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_mybackend_v1_manga_proto_goTypes,
+		DependencyIndexes: file_mybackend_v1_manga_proto_depIdxs,
+		MessageInfos:      file_mybackend_v1_manga_proto_msgTypes,
+	}.Build()
+	File_mybackend_v1_manga_proto = out.File
+	file_mybackend_v1_manga_proto_goTypes = nil
+	file_mybackend_v1_manga_proto_depIdxs = nil
+}
@@ -0,0 +1,203 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: mybackend/v1/manga.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	MangaService_GetManga_FullMethodName    = "/mybackend.v1.MangaService/GetManga"
+	MangaService_ListMangas_FullMethodName  = "/mybackend.v1.MangaService/ListMangas"
+	MangaService_CreateManga_FullMethodName = "/mybackend.v1.MangaService/CreateManga"
+)
+
+// MangaServiceClient is the client API for MangaService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// MangaService exposes the manga catalogue to internal services. Every call
+// requires a bearer token in the "authorization" metadata.
+type MangaServiceClient interface {
+	GetManga(ctx context.Context, in *GetMangaRequest, opts ...grpc.CallOption) (*Manga, error)
+	ListMangas(ctx context.Context, in *ListMangasRequest, opts ...grpc.CallOption) (*ListMangasResponse, error)
+	CreateManga(ctx context.Context, in *CreateMangaRequest, opts ...grpc.CallOption) (*Manga, error)
+}
+
+type mangaServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMangaServiceClient(cc grpc.ClientConnInterface) MangaServiceClient {
+	return &mangaServiceClient{cc}
+}
+
+func (c *mangaServiceClient) GetManga(ctx context.Context, in *GetMangaRequest, opts ...grpc.CallOption) (*Manga, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Manga)
+	err := c.cc.Invoke(ctx, MangaService_GetManga_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mangaServiceClient) ListMangas(ctx context.Context, in *ListMangasRequest, opts ...grpc.CallOption) (*ListMangasResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMangasResponse)
+	err := c.cc.Invoke(ctx, MangaService_ListMangas_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mangaServiceClient) CreateManga(ctx context.Context, in *CreateMangaRequest, opts ...grpc.CallOption) (*Manga, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Manga)
+	err := c.cc.Invoke(ctx, MangaService_CreateManga_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MangaServiceServer is the server API for MangaService service.
+// All implementations must embed UnimplementedMangaServiceServer
+// for forward compatibility.
+//
+// MangaService exposes the manga catalogue to internal services. Every call
+// requires a bearer token in the "authorization" metadata.
+type MangaServiceServer interface {
+	GetManga(context.Context, *GetMangaRequest) (*Manga, error)
+	ListMangas(context.Context, *ListMangasRequest) (*ListMangasResponse, error)
+	CreateManga(context.Context, *CreateMangaRequest) (*Manga, error)
+	mustEmbedUnimplementedMangaServiceServer()
+}
+
+// UnimplementedMangaServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedMangaServiceServer struct{}
+
+func (UnimplementedMangaServiceServer) GetManga(context.Context, *GetMangaRequest) (*Manga, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetManga not implemented")
+}
+func (UnimplementedMangaServiceServer) ListMangas(context.Context, *ListMangasRequest) (*ListMangasResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMangas not implemented")
+}
+func (UnimplementedMangaServiceServer) CreateManga(context.Context, *CreateMangaRequest) (*Manga, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateManga not implemented")
+}
+func (UnimplementedMangaServiceServer) mustEmbedUnimplementedMangaServiceServer() {}
+func (UnimplementedMangaServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeMangaServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MangaServiceServer will
+// result in compilation errors.
+type UnsafeMangaServiceServer interface {
+	mustEmbedUnimplementedMangaServiceServer()
+}
+
+func RegisterMangaServiceServer(s grpc.ServiceRegistrar, srv MangaServiceServer) {
+	// If the following call pancis, it indicates UnimplementedMangaServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&MangaService_ServiceDesc, srv)
+}
+
+func _MangaService_GetManga_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMangaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MangaServiceServer).GetManga(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MangaService_GetManga_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MangaServiceServer).GetManga(ctx, req.(*GetMangaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MangaService_ListMangas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMangasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MangaServiceServer).ListMangas(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MangaService_ListMangas_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MangaServiceServer).ListMangas(ctx, req.(*ListMangasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MangaService_CreateManga_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateMangaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MangaServiceServer).CreateManga(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MangaService_CreateManga_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MangaServiceServer).CreateManga(ctx, req.(*CreateMangaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// MangaService_ServiceDesc is the grpc.ServiceDesc for MangaService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MangaService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "mybackend.v1.MangaService",
+	HandlerType: (*MangaServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetManga",
+			Handler:    _MangaService_GetManga_Handler,
+		},
+		{
+			MethodName: "ListMangas",
+			Handler:    _MangaService_ListMangas_Handler,
+		},
+		{
+			MethodName: "CreateManga",
+			Handler:    _MangaService_CreateManga_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "mybackend/v1/manga.proto",
+}
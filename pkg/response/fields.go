@@ -0,0 +1,28 @@
+package response
+
+import "encoding/json"
+
+// SelectFields serializes each item and keeps only the given JSON fields,
+// so clients receive exactly the fields they asked for
+func SelectFields[T any](items []T, fields []string) ([]map[string]json.RawMessage, error) {
+	selected := make([]map[string]json.RawMessage, len(items))
+	for i, item := range items {
+		raw, err := json.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+
+		var all map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &all); err != nil {
+			return nil, err
+		}
+
+		selected[i] = make(map[string]json.RawMessage, len(fields))
+		for _, field := range fields {
+			if value, ok := all[field]; ok {
+				selected[i][field] = value
+			}
+		}
+	}
+	return selected, nil
+}
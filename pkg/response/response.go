@@ -8,8 +8,15 @@ type APIResponse struct {
 	Message string      `json:"message,omitempty"`
 	Data    interface{} `json:"data,omitempty"`
 	Error   interface{} `json:"error,omitempty"`
+
+	// RequestID identifies the failed request in the server logs
+	RequestID string `json:"request_id,omitempty"`
 }
 
+// ServerErrorKey is the c.Locals key of the error of a 5xx response, which
+// error reporting middleware sends on
+const ServerErrorKey = "serverError"
+
 // Success returns a successful response
 func Success(c *fiber.Ctx, data interface{}, message ...string) error {
 	response := APIResponse{
@@ -30,10 +37,14 @@ func Error(c *fiber.Ctx, statusCode int, error interface{}, message ...string) e
 		Success: false,
 		Error:   error,
 	}
+	response.RequestID, _ = c.Locals("requestID").(string)
 
 	if len(message) > 0 {
 		response.Message = message[0]
 	}
+	if statusCode >= fiber.StatusInternalServerError {
+		c.Locals(ServerErrorKey, error)
+	}
 
 	return c.Status(statusCode).JSON(response)
 }
@@ -51,3 +62,17 @@ func Created(c *fiber.Ctx, data interface{}, message ...string) error {
 
 	return c.Status(fiber.StatusCreated).JSON(response)
 }
+
+// Accepted returns an accepted response (202) for work that continues in the background
+func Accepted(c *fiber.Ctx, data interface{}, message ...string) error {
+	response := APIResponse{
+		Success: true,
+		Data:    data,
+	}
+
+	if len(message) > 0 {
+		response.Message = message[0]
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(response)
+}
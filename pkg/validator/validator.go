@@ -1,6 +1,7 @@
 package validator
 
 import (
+	"errors"
 	"reflect"
 	"strings"
 
@@ -28,6 +29,14 @@ func ValidateStruct(s interface{}) error {
 	return validate.Struct(s)
 }
 
+// Validate validates a struct and returns a readable validation error
+func Validate(s interface{}) error {
+	if err := ValidateStruct(s); err != nil {
+		return errors.New(formatValidationError(err))
+	}
+	return nil
+}
+
 // ParseAndValidate parses the request body and validates it
 func ParseAndValidate(c *fiber.Ctx, s interface{}) error {
 	if err := c.BodyParser(s); err != nil {